@@ -3,10 +3,12 @@ package main
 import (
 	"database/sql"
 	"embed"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/database"
@@ -16,7 +18,46 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
-const driverName = "sqlite3"
+// dialect describes how to talk to one kind of database and where its
+// migrations live.
+type dialect struct {
+	name      string // Used in messages
+	driver    string // database/sql driver name, also the goose dialect
+	fs        embed.FS
+	dir       string // Embedded migrations directory
+	createDir string // Source directory new migrations are written to
+	dsn       func(dbURL string) string
+}
+
+var sqliteDialect = dialect{
+	name:      "SQLite",
+	driver:    "sqlite3",
+	fs:        migrations.SQLiteMigrations,
+	dir:       migrations.SQLiteDir,
+	createDir: "internal/migrations/sqlite",
+	dsn:       database.NormalizeSQLiteDSN,
+}
+
+// errNoPostgresMigrations is returned for postgres:// URLs: the scheme is
+// recognized, but only SQLite migrations are embedded.
+var errNoPostgresMigrations = errors.New("PostgreSQL is not supported yet: only SQLite migrations are embedded")
+
+// detectDialect picks the dialect for dbURL from its scheme. SQLite takes a
+// file path, so any other URL-form value is rejected rather than letting
+// SQLite create a file literally named after it.
+func detectDialect(dbURL string) (dialect, error) {
+	scheme, _, ok := strings.Cut(strings.ToLower(dbURL), "://")
+	if !ok {
+		return sqliteDialect, nil
+	}
+
+	switch scheme {
+	case "postgres", "postgresql":
+		return dialect{}, errNoPostgresMigrations
+	default:
+		return dialect{}, fmt.Errorf("unsupported DATABASE_URL scheme %q: use a SQLite file path", scheme)
+	}
+}
 
 func main() {
 	var (
@@ -45,31 +86,29 @@ func main() {
 		}
 	}
 
+	d, err := detectDialect(*dbURL)
+	if err != nil {
+		log.Fatalf("Invalid database URL: %v", err)
+	}
+
 	var migrationsFS embed.FS
 	useEmbeddedFS := false
 	if *dir == "" {
 		useEmbeddedFS = true
-		*dir = migrations.SQLiteDir
-		migrationsFS = migrations.SQLiteMigrations
+		*dir = d.dir
+		migrationsFS = d.fs
 	}
 
-	if strings.Contains(*dbURL, "://") {
-		scheme, _, _ := strings.Cut(strings.ToLower(*dbURL), "://")
-		log.Fatalf("Unsupported DATABASE_URL scheme %q: migrate tool currently supports SQLite only", scheme)
-	}
-
-	dsn := database.NormalizeSQLiteDSN(*dbURL)
-
-	db, err := sql.Open(driverName, dsn)
+	db, err := sql.Open(d.driver, d.dsn(*dbURL))
 	if err != nil {
-		log.Fatalf("Failed to open database: %v", err)
+		log.Fatalf("Failed to open %s database: %v", d.name, err)
 	}
 	defer db.Close()
 
 	if useEmbeddedFS {
 		goose.SetBaseFS(migrationsFS)
 	}
-	if err := goose.SetDialect(driverName); err != nil {
+	if err := goose.SetDialect(d.driver); err != nil {
 		log.Fatalf("Failed to set dialect: %v", err)
 	}
 
@@ -86,6 +125,19 @@ func main() {
 		}
 		fmt.Println("Migration rollback completed successfully")
 
+	case "redo":
+		if err := goose.Redo(db, *dir); err != nil {
+			log.Fatalf("Migration redo failed: %v", err)
+		}
+		fmt.Println("Migration redo completed successfully")
+
+	case "up-to", "down-to":
+		version, err := migrateTo(db, *dir, command, args[1:])
+		if err != nil {
+			log.Fatalf("Migration %s failed: %v", command, err)
+		}
+		fmt.Printf("Migrated to version %d\n", version)
+
 	case "status":
 		if err := goose.Status(db, *dir); err != nil {
 			log.Fatalf("Migration status failed: %v", err)
@@ -108,7 +160,7 @@ func main() {
 			migrationType = args[2]
 		}
 
-		createDir := "internal/migrations/sqlite"
+		createDir := d.createDir
 
 		if useEmbeddedFS {
 			originalFS := migrationsFS
@@ -133,8 +185,30 @@ func main() {
 	}
 }
 
+// migrateTo runs up-to or down-to with the target version in args, returning
+// the version it migrated to.
+func migrateTo(db *sql.DB, dir, command string, args []string) (int64, error) {
+	if len(args) < 1 {
+		return 0, fmt.Errorf("%s command requires a version", command)
+	}
+	version, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || version < 0 {
+		return 0, fmt.Errorf("invalid version %q: must be a non-negative integer", args[0])
+	}
+
+	if command == "up-to" {
+		err = goose.UpTo(db, dir, version)
+	} else {
+		err = goose.DownTo(db, dir, version)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
 func usage() {
-	fmt.Print(`migrate - Database migration tool (SQLite only)
+	fmt.Print(`migrate - Database migration tool
 
 Usage:
     migrate [flags] <command> [args]
@@ -142,6 +216,9 @@ Usage:
 Commands:
     up          Apply all pending migrations
     down        Rollback the most recent migration
+    redo        Rollback the most recent migration, then apply it again
+    up-to VERSION    Apply pending migrations up to and including VERSION
+    down-to VERSION  Rollback migrations until VERSION is the current version
     status      Show migration status
     version     Show current migration version
     create NAME [TYPE]  Create a new migration file (TYPE: sql or go, default: sql)
@@ -150,8 +227,10 @@ Commands:
 Flags:
     -url string
         Database connection string. Uses DATABASE_URL env var if not provided.
+        A file path selects SQLite. postgres:// URLs are recognized but
+        rejected until PostgreSQL migrations are added.
     -dir string
-        Directory with migration files. Defaults to embedded SQLite migrations if not provided.
+        Directory with migration files. Defaults to the embedded migrations for the database.
 
 Examples:
     # Apply migrations (uses DATABASE_URL)
@@ -160,6 +239,10 @@ Examples:
     # Apply migrations with explicit URL
     migrate -url ./database.db up
 
+    # Apply migrations up to 00003, then roll back to 00002
+    migrate up-to 3
+    migrate down-to 2
+
     # Create new migration
     migrate create add_users_table
 
@@ -2,42 +2,73 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 
+	"github.com/matt-riley/mjrwtf/internal/cli"
 	"github.com/matt-riley/mjrwtf/internal/tui"
 )
 
 func main() {
-	args := os.Args[1:]
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run dispatches a subcommand and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
 	if len(args) == 0 {
-		usage(1)
-		return
+		usage(stderr)
+		return 1
 	}
 
+	var err error
 	switch args[0] {
 	case "tui":
-		if err := tui.Run(args[1:]); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-			os.Exit(1)
-		}
+		err = tui.Run(args[1:])
+	case "list":
+		err = cli.List(args[1:], stdout, stderr)
+	case "create":
+		err = cli.Create(args[1:], stdout, stderr)
+	case "delete":
+		err = cli.Delete(args[1:], stdin, stdout, stderr)
+	case "import":
+		err = cli.Import(args[1:], stdout, stderr)
 	case "-h", "--help", "help":
-		usage(0)
+		usage(stderr)
+		return 0
 	default:
-		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", args[0])
-		usage(1)
+		fmt.Fprintf(stderr, "unknown command: %s\n\n", args[0])
+		usage(stderr)
+		return 1
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
 	}
+	return 0
 }
 
-func usage(exitCode int) {
-	fmt.Fprint(os.Stderr, `Usage:
-  mjr tui [--base-url URL] [--token TOKEN]
+func usage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  mjr tui [--base-url URL] [--token TOKEN] [--theme THEME] [--theme-mode MODE] [--page-size N] [--timeout DURATION]
+  mjr list [--json] [--limit N] [--offset N] [--base-url URL] [--token TOKEN] [--timeout DURATION]
+  mjr create <url> [--custom-code CODE] [--base-url URL] [--token TOKEN] [--timeout DURATION]
+  mjr delete <short-code> [--yes] [--base-url URL] [--token TOKEN] [--timeout DURATION]
+  mjr import <file.csv> [--results FILE] [--base-url URL] [--token TOKEN] [--timeout DURATION]
 
 Commands:
   tui    Launch the interactive terminal UI
+  list   Print one page of your URLs (as JSON with --json)
+  create Shorten a URL and print the short URL
+  delete Delete a short URL (asks first unless --yes)
+  import Create a URL for each row of a CSV (original_url[,short_code])
 
 Environment variables:
   MJR_BASE_URL  Default base URL for the mjr.wtf API (overridden by --base-url)
   MJR_TOKEN     Default auth token (overridden by --token)
+  MJR_THEME     Color theme: catppuccin, high-contrast (overridden by --theme)
+  MJR_THEME_MODE Force light or dark colors: auto, dark, light (overridden by --theme-mode)
+  MJR_PAGE_SIZE URLs per page, 5-100 (default 20; overridden by --page-size)
+  MJR_TIMEOUT   Per-request timeout, e.g. 10s (default 5s; overridden by --timeout)
 `)
-	os.Exit(exitCode)
 }
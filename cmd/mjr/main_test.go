@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRun_CreateConflictExitsNonZero(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":"short code already exists","code":"duplicate_short_code"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MJR_BASE_URL", srv.URL)
+	t.Setenv("MJR_TOKEN", "t")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"create", "https://example.com", "--custom-code", "taken"}, nil, &stdout, &stderr)
+
+	if code == 0 {
+		t.Fatal("expected non-zero exit code")
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("expected no stdout, got %q", stdout.String())
+	}
+	if !strings.Contains(stderr.String(), "short code already exists") {
+		t.Fatalf("stderr=%q", stderr.String())
+	}
+}
+
+func TestRun_CreatePrintsShortURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"short_code":"abc123","short_url":"https://mjr.wtf/abc123","original_url":"https://example.com"}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MJR_BASE_URL", srv.URL)
+	t.Setenv("MJR_TOKEN", "t")
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"create", "https://example.com"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr=%q", code, stderr.String())
+	}
+	if stdout.String() != "https://mjr.wtf/abc123\n" {
+		t.Fatalf("stdout=%q", stdout.String())
+	}
+}
+
+func TestRun_UsageAndUnknownCommand(t *testing.T) {
+	tests := []struct {
+		args     []string
+		wantCode int
+		wantErr  string
+	}{
+		{args: nil, wantCode: 1, wantErr: "Usage:"},
+		{args: []string{"help"}, wantCode: 0, wantErr: "mjr create <url>"},
+		{args: []string{"help"}, wantCode: 0, wantErr: "mjr import <file.csv>"},
+		{args: []string{"bogus"}, wantCode: 1, wantErr: "unknown command: bogus"},
+	}
+
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(tt.args, nil, &stdout, &stderr); code != tt.wantCode {
+			t.Errorf("run(%v) = %d, want %d", tt.args, code, tt.wantCode)
+		}
+		if !strings.Contains(stderr.String(), tt.wantErr) {
+			t.Errorf("run(%v) stderr=%q, want it to contain %q", tt.args, stderr.String(), tt.wantErr)
+		}
+	}
+}
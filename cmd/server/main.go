@@ -19,6 +19,15 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// Build information, set at build time with
+// -ldflags "-X main.version=... -X main.commit=... -X main.buildTime=...".
+// Unset values are reported as dev/unknown by GET /version.
+var (
+	version   string
+	commit    string
+	buildTime string
+)
+
 func main() {
 	// Load configuration
 	cfg, err := config.LoadConfig()
@@ -37,7 +46,7 @@ func main() {
 		Msg("configuration loaded")
 
 	// Open database connection
-	db, err := openDatabase(cfg.DatabaseURL)
+	db, err := openDatabase(cfg)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to open database")
 	}
@@ -47,7 +56,9 @@ func main() {
 
 	// Initialize Tailscale server if enabled
 	var tsServer *tailscale.Server
-	var serverOpts []server.ServerOption
+	serverOpts := []server.ServerOption{
+		server.WithBuildInfo(server.BuildInfo{Version: version, Commit: commit, BuildTime: buildTime}),
+	}
 
 	if cfg.TailscaleEnabled {
 		var err error
@@ -110,24 +121,26 @@ func main() {
 	}
 }
 
-// openDatabase opens a SQLite database connection.
-func openDatabase(dbURL string) (*sql.DB, error) {
+// openDatabase opens a SQLite database connection with cfg's pool settings.
+func openDatabase(cfg *config.Config) (*sql.DB, error) {
 	// Fail fast if DATABASE_URL looks like a network URL,
 	// instead of letting sqlite create a local file literally named after it.
-	if strings.Contains(dbURL, "://") {
-		scheme, _, _ := strings.Cut(strings.ToLower(dbURL), "://")
+	if strings.Contains(cfg.DatabaseURL, "://") {
+		scheme, _, _ := strings.Cut(strings.ToLower(cfg.DatabaseURL), "://")
 		return nil, fmt.Errorf("unsupported DATABASE_URL scheme %q: server currently supports SQLite file paths only", scheme)
 	}
 
-	dsn := database.NormalizeSQLiteDSN(dbURL)
+	dsn := database.NormalizeSQLiteDSN(cfg.DatabaseURL)
 
 	db, err := sql.Open("sqlite3", dsn)
 	if err != nil {
 		return nil, err
 	}
 
-	// SQLite works best with a single write connection.
-	db.SetMaxOpenConns(database.SQLiteMaxOpenConns)
+	// SQLite works best with a single write connection (the DB_MAX_OPEN_CONNS default).
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
 
 	if err := db.Ping(); err != nil {
 		db.Close()
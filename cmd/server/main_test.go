@@ -1,17 +1,29 @@
 package main
 
 import (
+	"database/sql"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/config"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/database"
 )
 
+// sqliteConfig returns a config for dbURL with the default pool settings
+func sqliteConfig(dbURL string) *config.Config {
+	return &config.Config{
+		DatabaseURL:    dbURL,
+		DBMaxOpenConns: database.SQLiteMaxOpenConns,
+		DBMaxIdleConns: database.SQLiteMaxOpenConns,
+	}
+}
+
 func TestOpenDatabase_SQLite_WithoutQueryParams(t *testing.T) {
 	tmpFile := t.TempDir() + "/test.db"
 	defer os.Remove(tmpFile)
 
-	db, err := openDatabase(tmpFile)
+	db, err := openDatabase(sqliteConfig(tmpFile))
 	if err != nil {
 		t.Fatalf("openDatabase failed: %v", err)
 	}
@@ -50,7 +62,7 @@ func TestOpenDatabase_SQLite_WithExistingQueryParams(t *testing.T) {
 	tmpFile := t.TempDir() + "/test.db?cache=shared"
 	defer os.Remove(tmpFile)
 
-	db, err := openDatabase(tmpFile)
+	db, err := openDatabase(sqliteConfig(tmpFile))
 	if err != nil {
 		t.Fatalf("openDatabase failed: %v", err)
 	}
@@ -72,7 +84,7 @@ func TestOpenDatabase_SQLite_WithJournalModeInQueryParams(t *testing.T) {
 	tmpFile := t.TempDir() + "/test.db?_journal_mode=DELETE"
 	defer os.Remove(tmpFile)
 
-	db, err := openDatabase(tmpFile)
+	db, err := openDatabase(sqliteConfig(tmpFile))
 	if err != nil {
 		t.Fatalf("openDatabase failed: %v", err)
 	}
@@ -95,7 +107,7 @@ func TestOpenDatabase_SQLite_FilenameWithJournalMode(t *testing.T) {
 	tmpFile := t.TempDir() + "/my_journal_mode.db"
 	defer os.Remove(tmpFile)
 
-	db, err := openDatabase(tmpFile)
+	db, err := openDatabase(sqliteConfig(tmpFile))
 	if err != nil {
 		t.Fatalf("openDatabase failed: %v", err)
 	}
@@ -115,8 +127,42 @@ func TestOpenDatabase_SQLite_FilenameWithJournalMode(t *testing.T) {
 
 func TestOpenDatabase_InvalidDatabase(t *testing.T) {
 	// Test with an invalid SQLite database path that will fail to open
-	_, err := openDatabase("/invalid/path/that/does/not/exist/db.sqlite")
+	_, err := openDatabase(sqliteConfig("/invalid/path/that/does/not/exist/db.sqlite"))
 	if err == nil {
 		t.Error("expected error when opening invalid database path, got nil")
 	}
 }
+
+func TestOpenDatabase_AppliesPoolSettings(t *testing.T) {
+	cfg := sqliteConfig(t.TempDir() + "/test.db")
+	cfg.DBMaxOpenConns = 4
+	cfg.DBMaxIdleConns = 2
+	cfg.DBConnMaxLifetime = time.Minute
+
+	db, err := openDatabase(cfg)
+	if err != nil {
+		t.Fatalf("openDatabase failed: %v", err)
+	}
+	defer db.Close()
+
+	if got := db.Stats().MaxOpenConnections; got != 4 {
+		t.Errorf("expected MaxOpenConnections to be 4, got %d", got)
+	}
+
+	// Hold three connections, then release them: only two stay idle
+	conns := make([]*sql.Conn, 0, 3)
+	for i := 0; i < 3; i++ {
+		conn, err := db.Conn(t.Context())
+		if err != nil {
+			t.Fatalf("failed to get connection: %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		_ = conn.Close()
+	}
+
+	if got := db.Stats().Idle; got != 2 {
+		t.Errorf("expected 2 idle connections, got %d", got)
+	}
+}
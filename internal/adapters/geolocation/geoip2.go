@@ -24,6 +24,23 @@ func NewGeoIP2Service(databasePath string) (geolocation.LookupService, error) {
 	return &geoIP2Service{db: db}, nil
 }
 
+// CountryForIP returns the ISO 3166-1 alpha-2 country code for the given IP address.
+// Returns geolocation.ErrInvalidIPAddress for malformed input and the reader error
+// if the database lookup fails. Addresses without a country record yield "".
+func (s *geoIP2Service) CountryForIP(ipAddress string) (string, error) {
+	ip, err := netip.ParseAddr(ipAddress)
+	if err != nil {
+		return "", geolocation.ErrInvalidIPAddress
+	}
+
+	record, err := s.db.Country(ip)
+	if err != nil {
+		return "", err
+	}
+
+	return record.Country.ISOCode, nil
+}
+
 // LookupCountry returns the ISO 3166-1 alpha-2 country code for the given IP address.
 // Returns an empty string if the lookup fails or the IP is invalid.
 // This is a best-effort operation - failures result in empty string.
@@ -32,19 +49,13 @@ func NewGeoIP2Service(databasePath string) (geolocation.LookupService, error) {
 // geoip2-golang library does not support context-aware operations. The lookup
 // is performed against a local in-memory database and is expected to be fast.
 func (s *geoIP2Service) LookupCountry(_ context.Context, ipAddress string) string {
-	ip, err := netip.ParseAddr(ipAddress)
-	if err != nil {
-		// Invalid IP address format, return empty string
-		return ""
-	}
-
-	record, err := s.db.Country(ip)
+	country, err := s.CountryForIP(ipAddress)
 	if err != nil {
-		// Lookup failed, return empty string (best effort)
+		// Invalid IP address or failed lookup, return empty string (best effort)
 		return ""
 	}
 
-	return record.Country.ISOCode
+	return country
 }
 
 // Close releases the GeoIP2 database resources.
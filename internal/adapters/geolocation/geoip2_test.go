@@ -2,8 +2,11 @@ package geolocation
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/geolocation"
 )
 
 func TestGeoIP2Service_LookupCountry_InvalidIP(t *testing.T) {
@@ -56,6 +59,29 @@ func TestGeoIP2Service_LookupCountry_InvalidIP(t *testing.T) {
 	}
 }
 
+func TestGeoIP2Service_CountryForIP_InvalidIP(t *testing.T) {
+	dbPath := os.Getenv("GEOIP_TEST_DATABASE")
+	if dbPath == "" {
+		t.Skip("GEOIP_TEST_DATABASE environment variable not set, skipping GeoIP2 tests")
+	}
+
+	service, err := NewGeoIP2Service(dbPath)
+	if err != nil {
+		t.Skipf("Could not open GeoIP database at %s: %v", dbPath, err)
+	}
+	defer service.Close()
+
+	for _, ip := range []string{"", "not-an-ip", "192.168"} {
+		got, err := service.CountryForIP(ip)
+		if !errors.Is(err, geolocation.ErrInvalidIPAddress) {
+			t.Errorf("CountryForIP(%q) error = %v, want %v", ip, err, geolocation.ErrInvalidIPAddress)
+		}
+		if got != "" {
+			t.Errorf("CountryForIP(%q) = %q, want empty", ip, got)
+		}
+	}
+}
+
 func TestGeoIP2Service_OpenInvalidDatabase(t *testing.T) {
 	// Test that opening a non-existent database returns an error
 	_, err := NewGeoIP2Service("/path/to/nonexistent/database.mmdb")
@@ -16,6 +16,11 @@ func NewNoopService() geolocation.LookupService {
 	return &noopService{}
 }
 
+// CountryForIP always returns an empty string and no error since this is a no-op implementation.
+func (s *noopService) CountryForIP(_ string) (string, error) {
+	return "", nil
+}
+
 // LookupCountry always returns an empty string since this is a no-op implementation.
 func (s *noopService) LookupCountry(_ context.Context, _ string) string {
 	return ""
@@ -72,3 +72,17 @@ func TestNewNoopService_ReturnsLookupService(t *testing.T) {
 		t.Error("NewNoopService() returned nil, want non-nil LookupService")
 	}
 }
+
+func TestNoopService_CountryForIP(t *testing.T) {
+	service := NewNoopService()
+
+	for _, ip := range []string{"8.8.8.8", "2001:4860:4860::8888", "", "not-an-ip"} {
+		got, err := service.CountryForIP(ip)
+		if err != nil {
+			t.Errorf("CountryForIP(%q) error = %v, want nil", ip, err)
+		}
+		if got != "" {
+			t.Errorf("CountryForIP(%q) = %q, want empty", ip, got)
+		}
+	}
+}
@@ -1,2 +1,2 @@
-// Package notification provides adapters for external notifications (e.g., Discord webhooks).
+// Package notification provides adapters for external notifications (Discord and Slack webhooks).
 package notification
@@ -0,0 +1,144 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// ErrorContext contains contextual information about an error
+type ErrorContext struct {
+	ErrorMessage string
+	StackTrace   string
+	RequestID    string
+	Method       string
+	Path         string
+	UserID       string
+	Timestamp    time.Time
+}
+
+// ErrorMessage builds the message for a critical error such as a recovered panic.
+// Messages for the same error are rate limited together.
+func ErrorMessage(errCtx ErrorContext) Message {
+	fields := []Field{
+		{Name: "Error Message", Value: truncate(errCtx.ErrorMessage, 1024)},
+	}
+
+	if errCtx.RequestID != "" {
+		fields = append(fields, Field{Name: "Request ID", Value: errCtx.RequestID, Inline: true})
+	}
+
+	if errCtx.Method != "" && errCtx.Path != "" {
+		fields = append(fields, Field{Name: "Request", Value: fmt.Sprintf("%s %s", errCtx.Method, errCtx.Path), Inline: true})
+	}
+
+	if errCtx.UserID != "" {
+		fields = append(fields, Field{Name: "User ID", Value: errCtx.UserID, Inline: true})
+	}
+
+	if errCtx.StackTrace != "" {
+		// Truncate stack trace to fit the 1024 char field limit
+		// Account for code block markers: ```\n (4 chars) + \n``` (4 chars) = 8 chars total overhead
+		// Truncate to 1014 to leave room for markers and stay under 1024 limit
+		stackTrace := truncate(errCtx.StackTrace, 1014)
+		fields = append(fields, Field{Name: "Stack Trace", Value: fmt.Sprintf("```\n%s\n```", stackTrace)})
+	}
+
+	return Message{
+		Title:        "🚨 Critical Error Detected",
+		Description:  "A critical error has occurred in the application",
+		Severity:     SeverityError,
+		Fields:       fields,
+		Footer:       "mjr.wtf Error Notification",
+		Timestamp:    errCtx.Timestamp,
+		RateLimitKey: generateErrorType(errCtx.ErrorMessage),
+		Event:        EventPanic,
+	}
+}
+
+// NotFoundSpikeMessage builds the alert for count requests for unknown short
+// codes within window. Only the count and window are included, never paths or codes.
+func NotFoundSpikeMessage(count int, window time.Duration) Message {
+	return Message{
+		Title:       "⚠️ 404 Spike Detected",
+		Description: "Requests for unknown short codes crossed the alert threshold (possible scraping)",
+		Severity:    SeverityWarning,
+		Fields: []Field{
+			{Name: "Requests", Value: strconv.Itoa(count), Inline: true},
+			{Name: "Window", Value: window.String(), Inline: true},
+		},
+		Footer:    "mjr.wtf Alert",
+		Timestamp: time.Now(),
+		Event:     EventNotFoundSpike,
+	}
+}
+
+// URLCreatedMessage builds the post for a newly created short URL. The
+// destination is reduced to its domain unless fullDestination is set.
+func URLCreatedMessage(u *url.URL, shortURL string, fullDestination bool) Message {
+	destination := destinationDomain(u.OriginalURL)
+	if fullDestination {
+		destination = u.OriginalURL
+	}
+
+	return Message{
+		Title:    "🔗 New Short URL",
+		URL:      shortURL,
+		Severity: SeverityInfo,
+		Fields: []Field{
+			{Name: "Short Code", Value: u.ShortCode, Inline: true},
+			{Name: "Destination", Value: truncate(destination, 1024), Inline: true},
+			{Name: "Created By", Value: u.CreatedBy, Inline: true},
+		},
+		Footer:    "mjr.wtf",
+		Timestamp: u.CreatedAt,
+		Event:     EventCreated,
+	}
+}
+
+// URLCreatedAnnouncer posts newly created URLs through a Notifier; it satisfies
+// application.URLCreatedNotifier
+type URLCreatedAnnouncer struct {
+	notifier        Notifier
+	fullDestination bool
+}
+
+// NewURLCreatedAnnouncer creates an announcer sending URLCreatedMessage through notifier
+func NewURLCreatedAnnouncer(notifier Notifier, fullDestination bool) *URLCreatedAnnouncer {
+	return &URLCreatedAnnouncer{notifier: notifier, fullDestination: fullDestination}
+}
+
+// NotifyURLCreated sends the created-URL message for u
+func (a *URLCreatedAnnouncer) NotifyURLCreated(ctx context.Context, u *url.URL, shortURL string) {
+	a.notifier.Send(ctx, URLCreatedMessage(u, shortURL, a.fullDestination))
+}
+
+// destinationDomain returns the host of a destination URL, or a placeholder if it can't be parsed
+func destinationDomain(originalURL string) string {
+	parsed, err := neturl.Parse(originalURL)
+	if err != nil || parsed.Hostname() == "" {
+		return "(unknown)"
+	}
+	return parsed.Hostname()
+}
+
+// generateErrorType generates a simple error type identifier from error message
+func generateErrorType(errorMsg string) string {
+	// Take first 50 characters as error type for rate limiting purposes
+	if len(errorMsg) > 50 {
+		return errorMsg[:50]
+	}
+	return errorMsg
+}
+
+// truncate truncates a string to maxLen characters
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
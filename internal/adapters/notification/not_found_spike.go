@@ -0,0 +1,60 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// NotFoundSpikeDetector counts requests for unknown short codes in fixed windows
+// and alerts once per window when the count reaches a threshold, which usually
+// means someone is enumerating short codes.
+type NotFoundSpikeDetector struct {
+	notifier  Notifier
+	threshold int
+	window    time.Duration
+	now       func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+	alerted     bool
+}
+
+// NewNotFoundSpikeDetector creates a detector that alerts notifier when threshold
+// 404s arrive within window. A threshold or window <= 0 makes Record a no-op.
+func NewNotFoundSpikeDetector(notifier Notifier, threshold int, window time.Duration) *NotFoundSpikeDetector {
+	return &NotFoundSpikeDetector{
+		notifier:  notifier,
+		threshold: threshold,
+		window:    window,
+		now:       time.Now,
+	}
+}
+
+// Record counts one 404 and sends the alert if it crosses the threshold for the
+// current window. Later 404s in the same window don't alert again.
+func (d *NotFoundSpikeDetector) Record(ctx context.Context) {
+	if d == nil || d.notifier == nil || d.threshold <= 0 || d.window <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	now := d.now()
+	if d.windowStart.IsZero() || now.Sub(d.windowStart) >= d.window {
+		d.windowStart = now
+		d.count = 0
+		d.alerted = false
+	}
+	d.count++
+	fire := !d.alerted && d.count >= d.threshold
+	if fire {
+		d.alerted = true
+	}
+	count := d.count
+	d.mu.Unlock()
+
+	if fire {
+		d.notifier.Send(ctx, NotFoundSpikeMessage(count, d.window))
+	}
+}
@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeNotifier records every message it is sent
+type fakeNotifier struct {
+	mu     sync.Mutex
+	alerts []Message
+}
+
+func (f *fakeNotifier) Send(ctx context.Context, msg Message) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.alerts = append(f.alerts, msg)
+}
+
+// fieldValues indexes a message's fields by name
+func fieldValues(msg Message) map[string]string {
+	values := make(map[string]string, len(msg.Fields))
+	for _, f := range msg.Fields {
+		values[f.Name] = f.Value
+	}
+	return values
+}
+
+func TestNotFoundSpikeDetector_AlertsOncePerWindow(t *testing.T) {
+	notifier := &fakeNotifier{}
+	detector := NewNotFoundSpikeDetector(notifier, 3, time.Minute)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	detector.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// Below the threshold: no alert
+	detector.Record(ctx)
+	detector.Record(ctx)
+	if len(notifier.alerts) != 0 {
+		t.Fatalf("expected no alert below threshold, got %d", len(notifier.alerts))
+	}
+
+	// Crossing the threshold alerts once; further 404s in the window stay quiet
+	for i := 0; i < 10; i++ {
+		now = now.Add(time.Second)
+		detector.Record(ctx)
+	}
+	if len(notifier.alerts) != 1 {
+		t.Fatalf("expected 1 alert in the first window, got %d", len(notifier.alerts))
+	}
+	if got := fieldValues(notifier.alerts[0]); got["Requests"] != "3" || got["Window"] != "1m0s" {
+		t.Errorf("alert fields = %v, want 3 requests in 1m0s", got)
+	}
+
+	// A new window starts counting from zero again
+	now = now.Add(time.Minute)
+	detector.Record(ctx)
+	detector.Record(ctx)
+	if len(notifier.alerts) != 1 {
+		t.Fatalf("expected no alert before the threshold in the second window, got %d", len(notifier.alerts))
+	}
+	detector.Record(ctx)
+	if len(notifier.alerts) != 2 {
+		t.Fatalf("expected a second alert in the second window, got %d", len(notifier.alerts))
+	}
+}
+
+func TestNotFoundSpikeDetector_Disabled(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold int
+		window    time.Duration
+	}{
+		{name: "zero threshold", threshold: 0, window: time.Minute},
+		{name: "zero window", threshold: 1, window: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := &fakeNotifier{}
+			detector := NewNotFoundSpikeDetector(notifier, tt.threshold, tt.window)
+			for i := 0; i < 5; i++ {
+				detector.Record(context.Background())
+			}
+			if len(notifier.alerts) != 0 {
+				t.Errorf("expected no alerts, got %d", len(notifier.alerts))
+			}
+		})
+	}
+
+	// A nil detector is safe to call
+	var detector *NotFoundSpikeDetector
+	detector.Record(context.Background())
+}
+
+func TestNotFoundSpikeMessage(t *testing.T) {
+	msg := NotFoundSpikeMessage(42, 5*time.Minute)
+
+	if msg.Severity != SeverityWarning {
+		t.Errorf("expected warning severity, got %v", msg.Severity)
+	}
+	if len(msg.Fields) != 2 {
+		t.Fatalf("expected only the count and window fields, got %+v", msg.Fields)
+	}
+	if got := fieldValues(msg); got["Requests"] != "42" || got["Window"] != "5m0s" {
+		t.Errorf("fields = %v, want 42 requests in 5m0s", got)
+	}
+}
@@ -0,0 +1,102 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Notifier delivers a Message to an external channel such as a chat webhook.
+// Implementations must not block callers for long; webhook notifiers send
+// asynchronously by default.
+type Notifier interface {
+	Send(ctx context.Context, msg Message)
+}
+
+// Supported notifier kinds, selected by NOTIFIER_KIND
+const (
+	KindDiscord = "discord"
+	KindSlack   = "slack"
+	KindNone    = "none"
+)
+
+// Notification events, selectable with NOTIFY_EVENTS
+const (
+	EventPanic         = "panic"
+	EventCreated       = "created"
+	EventNotFoundSpike = "404-spike"
+)
+
+// ErrUnknownKind is returned by New for an unsupported notifier kind
+var ErrUnknownKind = errors.New("unknown notifier kind")
+
+// Severity controls how a message is highlighted (e.g. the embed or attachment colour)
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+// Field is a labelled value shown with a message
+type Field struct {
+	Name   string
+	Value  string
+	Inline bool
+}
+
+// Message is a chat-agnostic notification rendered by each Notifier
+type Message struct {
+	Title       string
+	Description string
+	// URL, when set, links the title
+	URL       string
+	Severity  Severity
+	Fields    []Field
+	Footer    string
+	Timestamp time.Time
+	// RateLimitKey, when set, limits messages sharing the key to one per rate limit interval
+	RateLimitKey string
+	// Event names what triggered the message (e.g. EventPanic), for WithEvents filtering
+	Event string
+}
+
+// New returns the notifier for kind posting to webhookURL. An empty kind means
+// KindDiscord. KindNone returns a no-op notifier, as does an empty webhookURL.
+func New(kind, webhookURL string, opts ...Option) (Notifier, error) {
+	switch kind {
+	case "", KindDiscord:
+		return NewDiscordNotifier(webhookURL, opts...), nil
+	case KindSlack:
+		return NewSlackNotifier(webhookURL, opts...), nil
+	case KindNone:
+		return Nop(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKind, kind)
+	}
+}
+
+type nopNotifier struct{}
+
+func (nopNotifier) Send(context.Context, Message) {}
+
+func (nopNotifier) IsEnabled() bool { return false }
+
+// Nop returns a Notifier that discards every message
+func Nop() Notifier {
+	return nopNotifier{}
+}
+
+// Enabled reports whether n will actually deliver messages: false for nil, the
+// no-op notifier, and webhook notifiers without a URL.
+func Enabled(n Notifier) bool {
+	if n == nil {
+		return false
+	}
+	if e, ok := n.(interface{ IsEnabled() bool }); ok {
+		return e.IsEnabled()
+	}
+	return true
+}
@@ -0,0 +1,143 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        string
+		webhookURL  string
+		wantType    string
+		wantEnabled bool
+		wantErr     error
+	}{
+		{name: "default is discord", kind: "", webhookURL: "https://discord.com/api/webhooks/test", wantType: "discord", wantEnabled: true},
+		{name: "discord", kind: KindDiscord, webhookURL: "https://discord.com/api/webhooks/test", wantType: "discord", wantEnabled: true},
+		{name: "slack", kind: KindSlack, webhookURL: "https://hooks.slack.com/services/test", wantType: "slack", wantEnabled: true},
+		{name: "slack without url", kind: KindSlack, webhookURL: "", wantType: "slack", wantEnabled: false},
+		{name: "none", kind: KindNone, webhookURL: "https://discord.com/api/webhooks/test", wantType: "none", wantEnabled: false},
+		{name: "unknown", kind: "teams", wantErr: ErrUnknownKind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := New(tt.kind, tt.webhookURL)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("New() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			var gotType string
+			switch n.(type) {
+			case *DiscordNotifier:
+				gotType = "discord"
+			case *SlackNotifier:
+				gotType = "slack"
+			case nopNotifier:
+				gotType = "none"
+			}
+			if gotType != tt.wantType {
+				t.Errorf("New() returned %T, want %s", n, tt.wantType)
+			}
+			if got := Enabled(n); got != tt.wantEnabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.wantEnabled)
+			}
+		})
+	}
+}
+
+func TestNop(t *testing.T) {
+	n := Nop()
+	// Sending is a no-op and must not panic
+	n.Send(context.Background(), Message{Title: "ignored"})
+
+	if Enabled(n) {
+		t.Error("expected the no-op notifier to report disabled")
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	var nilDiscord *DiscordNotifier
+
+	if Enabled(nil) {
+		t.Error("expected nil notifier to be disabled")
+	}
+	if Enabled(nilDiscord) {
+		t.Error("expected typed nil notifier to be disabled")
+	}
+	if !Enabled(&fakeNotifier{}) {
+		t.Error("expected a notifier without IsEnabled to count as enabled")
+	}
+}
+
+func TestWithEvents(t *testing.T) {
+	created := URLCreatedMessage(&url.URL{ShortCode: "abc123", OriginalURL: "https://example.com"}, "https://mjr.wtf/abc123", false)
+
+	tests := []struct {
+		name     string
+		events   []string
+		msg      Message
+		wantSent bool
+	}{
+		{name: "no filter sends everything", msg: created, wantSent: true},
+		{name: "enabled event", events: []string{EventPanic, EventCreated}, msg: created, wantSent: true},
+		{name: "disabled event", events: []string{EventPanic}, msg: created, wantSent: false},
+		{name: "disabled panic", events: []string{EventNotFoundSpike}, msg: ErrorMessage(ErrorContext{ErrorMessage: "boom"}), wantSent: false},
+		{name: "spike", events: []string{EventNotFoundSpike}, msg: NotFoundSpikeMessage(10, time.Minute), wantSent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					requests++
+					return &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))}, nil
+				}),
+			}
+
+			for _, n := range []Notifier{
+				NewDiscordNotifier("https://discord.com/api/webhooks/test", WithHTTPClient(client), WithAsyncSend(false), WithEvents(tt.events...)),
+				NewSlackNotifier("https://hooks.slack.com/services/test", WithHTTPClient(client), WithAsyncSend(false), WithEvents(tt.events...)),
+			} {
+				requests = 0
+				n.Send(context.Background(), tt.msg)
+				if got := requests == 1; got != tt.wantSent {
+					t.Errorf("%T sent %d requests, want sent = %v", n, requests, tt.wantSent)
+				}
+			}
+		})
+	}
+}
+
+func TestWithEvents_WebhookNotifier(t *testing.T) {
+	requests := 0
+	client := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		}),
+	}
+
+	notifier := NewWebhookNotifier("https://hooks.example.com/mjrwtf", "", WithHTTPClient(client), WithAsyncSend(false), WithEvents(EventPanic))
+	notifier.NotifyURLCreated(context.Background(), &url.URL{ShortCode: "abc123"}, "https://mjr.wtf/abc123")
+
+	if requests != 0 {
+		t.Errorf("expected no request for a disabled created event, got %d", requests)
+	}
+}
@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"context"
+)
+
+// SlackNotifier sends notifications to a Slack incoming webhook
+type SlackNotifier struct {
+	webhookSender
+}
+
+// NewSlackNotifier creates a new Slack notifier
+func NewSlackNotifier(webhookURL string, opts ...Option) *SlackNotifier {
+	return &SlackNotifier{webhookSender: newWebhookSender(webhookURL, opts)}
+}
+
+// Send posts msg to Slack as a message attachment
+func (n *SlackNotifier) Send(ctx context.Context, msg Message) {
+	n.deliver(ctx, msg, formatSlackMessage(msg))
+}
+
+// IsEnabled returns true if the notifier is configured with a webhook URL
+func (n *SlackNotifier) IsEnabled() bool {
+	return n != nil && n.webhookURL != ""
+}
+
+// slackColors maps severities to attachment colours
+var slackColors = map[Severity]string{
+	SeverityInfo:    "#5865F2",
+	SeverityWarning: "#FFA500",
+	SeverityError:   "#FF0000",
+}
+
+// formatSlackMessage renders msg as a Slack webhook payload. The top-level text
+// is the fallback shown in notifications; the attachment carries the details.
+func formatSlackMessage(msg Message) map[string]interface{} {
+	attachment := map[string]interface{}{
+		"color": slackColors[msg.Severity],
+		"title": msg.Title,
+	}
+
+	if msg.URL != "" {
+		attachment["title_link"] = msg.URL
+	}
+	if msg.Description != "" {
+		attachment["text"] = msg.Description
+	}
+	if msg.Footer != "" {
+		attachment["footer"] = msg.Footer
+	}
+	if !msg.Timestamp.IsZero() {
+		attachment["ts"] = msg.Timestamp.Unix()
+	}
+
+	if len(msg.Fields) > 0 {
+		fields := make([]map[string]interface{}, 0, len(msg.Fields))
+		for _, f := range msg.Fields {
+			fields = append(fields, map[string]interface{}{
+				"title": f.Name,
+				"value": f.Value,
+				"short": f.Inline,
+			})
+		}
+		attachment["fields"] = fields
+	}
+
+	return map[string]interface{}{
+		"text":        msg.Title,
+		"attachments": []map[string]interface{}{attachment},
+	}
+}
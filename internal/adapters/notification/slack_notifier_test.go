@@ -0,0 +1,150 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSlackNotifier_Send(t *testing.T) {
+	var capturedRequest *http.Request
+	var capturedBody []byte
+
+	mockClient := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			capturedRequest = req
+			capturedBody, _ = io.ReadAll(req.Body)
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString("ok")),
+			}, nil
+		}),
+	}
+
+	notifier := NewSlackNotifier(
+		"https://hooks.slack.com/services/T000/B000/XXXX",
+		WithHTTPClient(mockClient),
+		WithAsyncSend(false),
+	)
+
+	notifier.Send(context.Background(), ErrorMessage(ErrorContext{
+		ErrorMessage: "test error",
+		RequestID:    "req-123",
+		Method:       "GET",
+		Path:         "/test",
+		Timestamp:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	}))
+
+	if capturedRequest == nil {
+		t.Fatal("expected HTTP request to be made")
+	}
+	if capturedRequest.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %s", capturedRequest.Header.Get("Content-Type"))
+	}
+
+	var payload struct {
+		Text        string `json:"text"`
+		Attachments []struct {
+			Color  string `json:"color"`
+			Title  string `json:"title"`
+			Text   string `json:"text"`
+			Footer string `json:"footer"`
+			TS     int64  `json:"ts"`
+			Fields []struct {
+				Title string `json:"title"`
+				Value string `json:"value"`
+				Short bool   `json:"short"`
+			} `json:"fields"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(capturedBody, &payload); err != nil {
+		t.Fatalf("failed to unmarshal payload: %v", err)
+	}
+
+	if payload.Text != "🚨 Critical Error Detected" {
+		t.Errorf("unexpected fallback text: %q", payload.Text)
+	}
+	if len(payload.Attachments) != 1 {
+		t.Fatalf("expected one attachment, got %d", len(payload.Attachments))
+	}
+
+	attachment := payload.Attachments[0]
+	if attachment.Color != "#FF0000" {
+		t.Errorf("expected error colour, got %q", attachment.Color)
+	}
+	if attachment.Text != "A critical error has occurred in the application" {
+		t.Errorf("unexpected attachment text: %q", attachment.Text)
+	}
+	if attachment.Footer != "mjr.wtf Error Notification" {
+		t.Errorf("unexpected footer: %q", attachment.Footer)
+	}
+	if attachment.TS != 1704110400 {
+		t.Errorf("expected ts 1704110400, got %d", attachment.TS)
+	}
+
+	fields := map[string]string{}
+	for _, f := range attachment.Fields {
+		fields[f.Title] = f.Value
+	}
+	want := map[string]string{
+		"Error Message": "test error",
+		"Request ID":    "req-123",
+		"Request":       "GET /test",
+	}
+	for name, value := range want {
+		if fields[name] != value {
+			t.Errorf("field %q = %q, want %q", name, fields[name], value)
+		}
+	}
+}
+
+func TestFormatSlackMessage_OptionalParts(t *testing.T) {
+	payload := formatSlackMessage(Message{
+		Title:    "🔗 New Short URL",
+		URL:      "https://mjr.wtf/abc123",
+		Severity: SeverityInfo,
+	})
+
+	attachments := payload["attachments"].([]map[string]interface{})
+	attachment := attachments[0]
+
+	if attachment["title_link"] != "https://mjr.wtf/abc123" {
+		t.Errorf("expected title_link to be the message URL, got %v", attachment["title_link"])
+	}
+	for _, key := range []string{"text", "footer", "ts", "fields"} {
+		if _, ok := attachment[key]; ok {
+			t.Errorf("expected %q to be omitted when empty", key)
+		}
+	}
+}
+
+func TestSlackNotifier_RateLimiting(t *testing.T) {
+	requestCount := 0
+	mockClient := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requestCount++
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString("ok")),
+			}, nil
+		}),
+	}
+
+	notifier := NewSlackNotifier(
+		"https://hooks.slack.com/services/T000/B000/XXXX",
+		WithHTTPClient(mockClient),
+		WithAsyncSend(false),
+	)
+
+	msg := ErrorMessage(ErrorContext{ErrorMessage: "same error", Timestamp: time.Now()})
+	notifier.Send(context.Background(), msg)
+	notifier.Send(context.Background(), msg)
+
+	if requestCount != 1 {
+		t.Errorf("expected 1 request due to rate limiting, got %d", requestCount)
+	}
+}
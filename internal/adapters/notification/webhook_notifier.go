@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// Event types sent by WebhookNotifier
+const (
+	EventTypeURLCreated = "url.created"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the request body, as "sha256=<hex>",
+// when the webhook has a secret
+const SignatureHeader = "X-Mjrwtf-Signature"
+
+// Event is the JSON body WebhookNotifier POSTs
+type Event struct {
+	Type        string    `json:"type"`
+	ShortCode   string    `json:"short_code"`
+	ShortURL    string    `json:"short_url,omitempty"`
+	OriginalURL string    `json:"original_url,omitempty"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// WebhookNotifier POSTs JSON events to a generic HTTP endpoint, e.g. your own service
+type WebhookNotifier struct {
+	webhookSender
+	secret []byte
+}
+
+// NewWebhookNotifier creates a notifier posting events to webhookURL. A non-empty
+// secret signs each body with HMAC-SHA256 in SignatureHeader.
+func NewWebhookNotifier(webhookURL, secret string, opts ...Option) *WebhookNotifier {
+	n := &WebhookNotifier{webhookSender: newWebhookSender(webhookURL, opts)}
+	if secret != "" {
+		n.secret = []byte(secret)
+	}
+	return n
+}
+
+// Publish sends event to the webhook
+func (n *WebhookNotifier) Publish(ctx context.Context, event Event) {
+	if !n.IsEnabled() {
+		return
+	}
+
+	n.dispatch(ctx, func(ctx context.Context) {
+		if n.post(ctx, event, n.sign) {
+			n.logger.Debug().Str("type", event.Type).Str("short_code", event.ShortCode).Msg("webhook event sent")
+		}
+	})
+}
+
+// NotifyURLCreated publishes a url.created event for u; it satisfies application.URLCreatedNotifier
+func (n *WebhookNotifier) NotifyURLCreated(ctx context.Context, u *url.URL, shortURL string) {
+	if !n.allows(EventCreated) {
+		return
+	}
+
+	n.Publish(ctx, Event{
+		Type:        EventTypeURLCreated,
+		ShortCode:   u.ShortCode,
+		ShortURL:    shortURL,
+		OriginalURL: u.OriginalURL,
+		CreatedBy:   u.CreatedBy,
+		Timestamp:   u.CreatedAt.UTC(),
+	})
+}
+
+// IsEnabled returns true if the notifier is configured with a webhook URL
+func (n *WebhookNotifier) IsEnabled() bool {
+	return n != nil && n.webhookURL != ""
+}
+
+func (n *WebhookNotifier) sign(body []byte, header http.Header) {
+	if n.secret == nil {
+		return
+	}
+	header.Set(SignatureHeader, Signature(n.secret, body))
+}
+
+// Signature returns the SignatureHeader value for body signed with secret.
+// Receivers should recompute it and compare with hmac.Equal.
+func Signature(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
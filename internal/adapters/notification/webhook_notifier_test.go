@@ -0,0 +1,132 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func TestWebhookNotifier_NotifyURLCreated(t *testing.T) {
+	tests := []struct {
+		name          string
+		secret        string
+		wantSignature bool
+	}{
+		{name: "signed with secret", secret: "s3cret", wantSignature: true},
+		{name: "unsigned without secret", secret: "", wantSignature: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedRequest *http.Request
+			var capturedBody []byte
+			mockClient := &http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					capturedRequest = req
+					capturedBody, _ = io.ReadAll(req.Body)
+					return &http.Response{
+						StatusCode: 202,
+						Body:       io.NopCloser(bytes.NewBufferString("")),
+					}, nil
+				}),
+			}
+
+			notifier := NewWebhookNotifier("https://hooks.example.com/mjrwtf", tt.secret,
+				WithHTTPClient(mockClient),
+				WithAsyncSend(false),
+			)
+
+			createdAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			notifier.NotifyURLCreated(context.Background(), &url.URL{
+				ShortCode:   "abc123",
+				OriginalURL: "https://example.com/page",
+				CreatedBy:   "user-1",
+				CreatedAt:   createdAt,
+			}, "https://mjr.wtf/abc123")
+
+			if capturedRequest == nil {
+				t.Fatal("expected HTTP request to be made")
+			}
+			if capturedRequest.Method != http.MethodPost {
+				t.Errorf("expected POST, got %s", capturedRequest.Method)
+			}
+			if capturedRequest.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("expected Content-Type application/json, got %s", capturedRequest.Header.Get("Content-Type"))
+			}
+
+			var payload map[string]interface{}
+			if err := json.Unmarshal(capturedBody, &payload); err != nil {
+				t.Fatalf("failed to unmarshal payload: %v", err)
+			}
+			want := map[string]interface{}{
+				"type":         "url.created",
+				"short_code":   "abc123",
+				"short_url":    "https://mjr.wtf/abc123",
+				"original_url": "https://example.com/page",
+				"created_by":   "user-1",
+				"timestamp":    "2024-01-01T12:00:00Z",
+			}
+			for key, value := range want {
+				if payload[key] != value {
+					t.Errorf("payload[%q] = %v, want %v", key, payload[key], value)
+				}
+			}
+			if len(payload) != len(want) {
+				t.Errorf("unexpected payload keys: %v", payload)
+			}
+
+			got := capturedRequest.Header.Get(SignatureHeader)
+			if !tt.wantSignature {
+				if got != "" {
+					t.Errorf("expected no %s header, got %q", SignatureHeader, got)
+				}
+				return
+			}
+
+			mac := hmac.New(sha256.New, []byte(tt.secret))
+			mac.Write(capturedBody)
+			expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+			if got != expected {
+				t.Errorf("%s = %q, want %q", SignatureHeader, got, expected)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifier_Disabled(t *testing.T) {
+	called := false
+	mockClient := &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			called = true
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(""))}, nil
+		}),
+	}
+
+	notifier := NewWebhookNotifier("", "secret", WithHTTPClient(mockClient), WithAsyncSend(false))
+	notifier.Publish(context.Background(), Event{Type: EventTypeURLCreated, ShortCode: "abc123"})
+
+	if called {
+		t.Error("expected no request without a webhook URL")
+	}
+	if notifier.IsEnabled() {
+		t.Error("expected notifier without a webhook URL to be disabled")
+	}
+}
+
+func TestSignature(t *testing.T) {
+	// Known HMAC-SHA256 test vector (RFC 4231 test case 2)
+	got := Signature([]byte("Jefe"), []byte("what do ya want for nothing?"))
+	want := "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Errorf("Signature() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,213 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// webhookSender holds the HTTP plumbing shared by the webhook notifiers:
+// rate limiting, async dispatch and posting a JSON payload
+type webhookSender struct {
+	webhookURL  string
+	client      *http.Client
+	logger      zerolog.Logger
+	rateLimiter *rateLimiter
+	sendAsync   bool
+	events      map[string]bool
+}
+
+// Option is a functional option for configuring a webhook notifier
+type Option func(*webhookSender)
+
+// WithLogger sets a custom logger for the notifier
+func WithLogger(logger zerolog.Logger) Option {
+	return func(s *webhookSender) {
+		s.logger = logger
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client for the notifier
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *webhookSender) {
+		s.client = client
+	}
+}
+
+// WithRateLimit sets the interval between messages sharing a RateLimitKey (default: 1 minute)
+func WithRateLimit(interval time.Duration) Option {
+	return func(s *webhookSender) {
+		s.rateLimiter = newRateLimiter(interval)
+	}
+}
+
+// WithAsyncSend enables or disables async sending (default: true)
+func WithAsyncSend(async bool) Option {
+	return func(s *webhookSender) {
+		s.sendAsync = async
+	}
+}
+
+// WithEvents limits the notifier to messages for the given events (e.g. EventPanic).
+// Without it, or with no events, every message is sent.
+func WithEvents(events ...string) Option {
+	return func(s *webhookSender) {
+		if len(events) == 0 {
+			s.events = nil
+			return
+		}
+		s.events = make(map[string]bool, len(events))
+		for _, event := range events {
+			s.events[event] = true
+		}
+	}
+}
+
+func newWebhookSender(webhookURL string, opts []Option) webhookSender {
+	s := webhookSender{
+		webhookURL:  webhookURL,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		logger:      zerolog.Nop(),
+		rateLimiter: newRateLimiter(1 * time.Minute),
+		sendAsync:   true,
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return s
+}
+
+// deliver posts payload for msg unless the sender is disabled, msg.Event is
+// filtered out by WithEvents, or msg is rate limited
+func (s *webhookSender) deliver(ctx context.Context, msg Message, payload interface{}) {
+	// Skip if webhook URL is not configured
+	if s.webhookURL == "" {
+		return
+	}
+
+	if !s.allows(msg.Event) {
+		s.logger.Debug().
+			Str("event", msg.Event).
+			Msg("notification event disabled")
+		return
+	}
+
+	if msg.RateLimitKey != "" && !s.rateLimiter.allow(msg.RateLimitKey) {
+		s.logger.Debug().
+			Str("error_type", msg.RateLimitKey).
+			Msg("notification rate limited")
+		return
+	}
+
+	s.dispatch(ctx, func(ctx context.Context) {
+		if s.post(ctx, payload, nil) {
+			s.logger.Debug().Str("title", msg.Title).Msg("notification sent")
+		}
+	})
+}
+
+// allows reports whether messages for event should be sent
+func (s *webhookSender) allows(event string) bool {
+	return s.events == nil || s.events[event]
+}
+
+// dispatch runs send synchronously or, in async mode, on a goroutine
+func (s *webhookSender) dispatch(ctx context.Context, send func(context.Context)) {
+	if s.sendAsync {
+		// For async mode, create a detached context with timeout
+		// since the goroutine may outlive the original context
+		ctxWithTimeout, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		go func() {
+			defer cancel()
+			send(ctxWithTimeout)
+		}()
+	} else {
+		// For sync mode, use the original context
+		send(ctx)
+	}
+}
+
+// post sends payload as JSON to the webhook, logging any failure. If sign is
+// set, it is called with the encoded body to add headers such as a signature.
+// It reports whether the webhook accepted the payload.
+func (s *webhookSender) post(ctx context.Context, payload interface{}, sign func(body []byte, header http.Header)) bool {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to marshal webhook payload")
+		return false
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.webhookURL, bytes.NewBuffer(payloadBytes))
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to create webhook request")
+		return false
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if sign != nil {
+		sign(payloadBytes, req.Header)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("failed to send webhook notification")
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		s.logger.Error().
+			Int("status_code", resp.StatusCode).
+			Msg("webhook returned error status")
+		return false
+	}
+
+	return true
+}
+
+// rateLimiter implements rate limiting per error type
+type rateLimiter struct {
+	lastSent map[string]time.Time
+	mu       sync.RWMutex
+	interval time.Duration
+}
+
+func newRateLimiter(interval time.Duration) *rateLimiter {
+	return &rateLimiter{
+		lastSent: make(map[string]time.Time),
+		interval: interval,
+	}
+}
+
+func (rl *rateLimiter) allow(errorType string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// Clean up old entries to prevent unbounded map growth
+	rl.cleanup()
+
+	lastSent, exists := rl.lastSent[errorType]
+	if !exists || time.Since(lastSent) >= rl.interval {
+		rl.lastSent[errorType] = time.Now()
+		return true
+	}
+	return false
+}
+
+// cleanup removes entries older than 2x the rate limit interval to prevent memory leaks
+// This is called while the lock is already held by allow()
+func (rl *rateLimiter) cleanup() {
+	threshold := time.Now().Add(-2 * rl.interval)
+	for errorType, lastSent := range rl.lastSent {
+		if lastSent.Before(threshold) {
+			delete(rl.lastSent, errorType)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+)
+
+// AuditRepository is an interface implemented by this repo's SQLite repository adapters.
+type AuditRepository interface {
+	audit.Repository
+}
+
+// auditRepositoryBase provides common functionality for audit repositories.
+type auditRepositoryBase struct {
+	db *sql.DB
+}
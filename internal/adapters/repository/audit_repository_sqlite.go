@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/matt-riley/mjrwtf/internal/adapters/repository/sqlc/sqlite"
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+)
+
+// SQLiteAuditRepository implements the audit repository for SQLite.
+type SQLiteAuditRepository struct {
+	auditRepositoryBase
+	queries *sqliterepo.Queries
+}
+
+// NewSQLiteAuditRepository creates a new SQLite audit repository.
+func NewSQLiteAuditRepository(db *sql.DB) *SQLiteAuditRepository {
+	return &SQLiteAuditRepository{
+		auditRepositoryBase: auditRepositoryBase{db: db},
+		queries:             sqliterepo.New(db),
+	}
+}
+
+// Record stores a new audit entry.
+func (r *SQLiteAuditRepository) Record(ctx context.Context, entry *audit.Entry) error {
+	err := r.queries.CreateAuditEntry(ctx, sqliterepo.CreateAuditEntryParams{
+		Action:    string(entry.Action),
+		ShortCode: entry.ShortCode,
+		UserID:    entry.UserID,
+		IpAddress: entry.IPAddress,
+		CreatedAt: entry.CreatedAt,
+	})
+	return MapSQLError(err, nil, nil)
+}
+
+// ListByShortCode retrieves up to limit entries for shortCode, newest first.
+func (r *SQLiteAuditRepository) ListByShortCode(ctx context.Context, shortCode string, limit int) ([]*audit.Entry, error) {
+	rows, err := r.queries.ListAuditEntriesByShortCode(ctx, sqliterepo.ListAuditEntriesByShortCodeParams{
+		ShortCode: shortCode,
+		Limit:     int64(limit),
+	})
+	if err != nil {
+		return nil, MapSQLError(err, nil, nil)
+	}
+
+	entries := make([]*audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, &audit.Entry{
+			ID:        row.ID,
+			Action:    audit.Action(row.Action),
+			ShortCode: row.ShortCode,
+			UserID:    row.UserID,
+			IPAddress: row.IpAddress,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return entries, nil
+}
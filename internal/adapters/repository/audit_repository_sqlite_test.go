@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+)
+
+func TestSQLiteAuditRepository_RecordAndList(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteAuditRepository(db)
+	ctx := context.Background()
+
+	entries, err := repo.ListByShortCode(ctx, "abc123", 10)
+	if err != nil {
+		t.Fatalf("ListByShortCode() error = %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("ListByShortCode() before Record = %d entries, want 0", len(entries))
+	}
+
+	for _, e := range []struct {
+		action    audit.Action
+		shortCode string
+	}{
+		{audit.ActionCreate, "abc123"},
+		{audit.ActionCreate, "other1"},
+		{audit.ActionDelete, "abc123"},
+	} {
+		entry, err := audit.NewEntry(e.action, e.shortCode, "user1", "192.0.2.1")
+		if err != nil {
+			t.Fatalf("NewEntry() error = %v", err)
+		}
+		if err := repo.Record(ctx, entry); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	entries, err = repo.ListByShortCode(ctx, "abc123", 10)
+	if err != nil {
+		t.Fatalf("ListByShortCode() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("ListByShortCode() = %d entries, want 2", len(entries))
+	}
+	// Newest first
+	if entries[0].Action != audit.ActionDelete || entries[1].Action != audit.ActionCreate {
+		t.Errorf("ListByShortCode() actions = %q, %q, want delete, create", entries[0].Action, entries[1].Action)
+	}
+	got := entries[1]
+	if got.ID == 0 || got.ShortCode != "abc123" || got.UserID != "user1" || got.IPAddress != "192.0.2.1" || got.CreatedAt.IsZero() {
+		t.Errorf("ListByShortCode() entry = %+v", got)
+	}
+
+	entries, err = repo.ListByShortCode(ctx, "abc123", 1)
+	if err != nil {
+		t.Fatalf("ListByShortCode() with limit error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Action != audit.ActionDelete {
+		t.Errorf("ListByShortCode() with limit 1 = %+v, want the delete only", entries)
+	}
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// CachingURLRepository wraps a URL repository and keeps recently found URLs in
+// an in-memory LRU cache, so redirects for popular short codes skip the
+// database. Entries expire after the TTL, and every write through the wrapper
+// evicts the short codes it touches.
+//
+// The cache is per process: writes made by another instance are only picked up
+// once the entry expires, unless a CacheInvalidator relays them.
+type CachingURLRepository struct {
+	wrapped     url.Repository
+	size        int
+	ttl         time.Duration
+	now         func() time.Time
+	invalidator CacheInvalidator
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // Most recently used at the front
+	// generation is bumped on every eviction, so a lookup that raced with a
+	// write doesn't cache the row it read before the write
+	generation uint64
+}
+
+type cacheEntry struct {
+	shortCode string
+	url       *url.URL
+	expiresAt time.Time
+}
+
+// CacheInvalidator relays the short codes evicted by a write to the caches of
+// other instances, e.g. over Postgres LISTEN/NOTIFY. Receiving instances apply
+// them with Invalidate and InvalidatePrefix.
+type CacheInvalidator interface {
+	Invalidated(shortCode string)
+	PrefixInvalidated(prefix string)
+}
+
+// noopCacheInvalidator is the single-instance default, with no one to tell
+type noopCacheInvalidator struct{}
+
+func (noopCacheInvalidator) Invalidated(string)       {}
+func (noopCacheInvalidator) PrefixInvalidated(string) {}
+
+// CachingOption is a functional option for configuring a CachingURLRepository
+type CachingOption func(*CachingURLRepository)
+
+// WithCacheInvalidator relays local evictions through invalidator
+func WithCacheInvalidator(invalidator CacheInvalidator) CachingOption {
+	return func(r *CachingURLRepository) {
+		if invalidator != nil {
+			r.invalidator = invalidator
+		}
+	}
+}
+
+// NewCachingURLRepository creates a URL repository wrapper caching up to size
+// FindByShortCode results for ttl each
+func NewCachingURLRepository(repo url.Repository, size int, ttl time.Duration, opts ...CachingOption) *CachingURLRepository {
+	r := &CachingURLRepository{
+		wrapped:     repo,
+		size:        size,
+		ttl:         ttl,
+		now:         time.Now,
+		invalidator: noopCacheInvalidator{},
+		entries:     make(map[string]*list.Element, size),
+		order:       list.New(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Create creates a new shortened URL. Only found URLs are cached, so a new
+// short code has nothing to evict.
+func (r *CachingURLRepository) Create(ctx context.Context, u *url.URL) error {
+	return r.wrapped.Create(ctx, u)
+}
+
+// FindByShortCode returns the cached URL for shortCode, or looks it up and
+// caches it. Errors, including ErrURLNotFound, are never cached.
+func (r *CachingURLRepository) FindByShortCode(ctx context.Context, shortCode string) (*url.URL, error) {
+	r.mu.Lock()
+	if u, ok := r.get(shortCode); ok {
+		r.mu.Unlock()
+		return u, nil
+	}
+	generation := r.generation
+	r.mu.Unlock()
+
+	u, err := r.wrapped.FindByShortCode(ctx, shortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if generation == r.generation {
+		r.add(shortCode, u)
+	}
+	r.mu.Unlock()
+
+	return u, nil
+}
+
+// FindByOriginalURL retrieves a user's live URL for a destination; it is not cached
+func (r *CachingURLRepository) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	return r.wrapped.FindByOriginalURL(ctx, createdBy, originalURL)
+}
+
+// Delete removes a URL by its short code and evicts it
+func (r *CachingURLRepository) Delete(ctx context.Context, shortCode string) error {
+	defer r.evict(shortCode)
+	return r.wrapped.Delete(ctx, shortCode)
+}
+
+// DeleteByShortCodePrefix removes a user's URLs matching a short code prefix and evicts every cached code with that prefix
+func (r *CachingURLRepository) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	defer r.evictPrefix(prefix)
+	return r.wrapped.DeleteByShortCodePrefix(ctx, createdBy, prefix)
+}
+
+// SoftDelete marks a URL as deleted and evicts it
+func (r *CachingURLRepository) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	defer r.evict(shortCode)
+	return r.wrapped.SoftDelete(ctx, shortCode, deletedAt)
+}
+
+// SoftDeleteByShortCodePrefix marks a user's URLs matching a short code prefix as deleted and evicts every cached code with that prefix
+func (r *CachingURLRepository) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	defer r.evictPrefix(prefix)
+	return r.wrapped.SoftDeleteByShortCodePrefix(ctx, createdBy, prefix, deletedAt)
+}
+
+// Restore clears the soft-delete marker on a URL and evicts it
+func (r *CachingURLRepository) Restore(ctx context.Context, shortCode string) error {
+	defer r.evict(shortCode)
+	return r.wrapped.Restore(ctx, shortCode)
+}
+
+// List retrieves URLs with optional filtering and pagination; it is not cached
+func (r *CachingURLRepository) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
+	return r.wrapped.List(ctx, createdBy, limit, offset)
+}
+
+// Search retrieves URLs matching a query with optional filtering and pagination; it is not cached
+func (r *CachingURLRepository) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	return r.wrapped.Search(ctx, createdBy, query, limit, offset)
+}
+
+// ListByCreatedByAndTimeRange retrieves URLs created by a specific user within a time range; it is not cached
+func (r *CachingURLRepository) ListByCreatedByAndTimeRange(ctx context.Context, createdBy string, startTime, endTime time.Time) ([]*url.URL, error) {
+	return r.wrapped.ListByCreatedByAndTimeRange(ctx, createdBy, startTime, endTime)
+}
+
+// Count returns the total count of URLs for a specific user; it is not cached
+func (r *CachingURLRepository) Count(ctx context.Context, createdBy string) (int, error) {
+	return r.wrapped.Count(ctx, createdBy)
+}
+
+// CountAll returns the count of all stored URLs, soft-deleted ones included; it is not cached
+func (r *CachingURLRepository) CountAll(ctx context.Context) (int, error) {
+	return r.wrapped.CountAll(ctx)
+}
+
+// CountMatching returns the total count of URLs matching a query for a specific user; it is not cached
+func (r *CachingURLRepository) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	return r.wrapped.CountMatching(ctx, createdBy, query)
+}
+
+// Invalidate evicts shortCode after another instance wrote it. Unlike a local
+// write, it isn't relayed back to the invalidator.
+func (r *CachingURLRepository) Invalidate(shortCode string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	if elem, ok := r.entries[shortCode]; ok {
+		r.remove(elem)
+	}
+}
+
+// InvalidatePrefix evicts every cached code with prefix after another instance
+// wrote them. Unlike a local write, it isn't relayed back to the invalidator.
+func (r *CachingURLRepository) InvalidatePrefix(prefix string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.generation++
+	for shortCode, elem := range r.entries {
+		if strings.HasPrefix(shortCode, prefix) {
+			r.remove(elem)
+		}
+	}
+}
+
+// get returns a copy of the live entry for shortCode, dropping it if expired.
+// It must be called with mu held.
+func (r *CachingURLRepository) get(shortCode string) (*url.URL, bool) {
+	elem, ok := r.entries[shortCode]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !r.now().Before(entry.expiresAt) {
+		r.remove(elem)
+		return nil, false
+	}
+
+	r.order.MoveToFront(elem)
+	return cloneURL(entry.url), true
+}
+
+// add caches a copy of u, evicting the least recently used entry when full.
+// It must be called with mu held.
+func (r *CachingURLRepository) add(shortCode string, u *url.URL) {
+	entry := &cacheEntry{shortCode: shortCode, url: cloneURL(u), expiresAt: r.now().Add(r.ttl)}
+
+	if elem, ok := r.entries[shortCode]; ok {
+		elem.Value = entry
+		r.order.MoveToFront(elem)
+		return
+	}
+
+	r.entries[shortCode] = r.order.PushFront(entry)
+	for r.order.Len() > r.size {
+		r.remove(r.order.Back())
+	}
+}
+
+// remove drops elem from the cache. It must be called with mu held.
+func (r *CachingURLRepository) remove(elem *list.Element) {
+	r.order.Remove(elem)
+	delete(r.entries, elem.Value.(*cacheEntry).shortCode)
+}
+
+// evict drops shortCode after a local write and relays it to other instances
+func (r *CachingURLRepository) evict(shortCode string) {
+	r.Invalidate(shortCode)
+	r.invalidator.Invalidated(shortCode)
+}
+
+// evictPrefix drops every code with prefix after a local write and relays it to other instances
+func (r *CachingURLRepository) evictPrefix(prefix string) {
+	r.InvalidatePrefix(prefix)
+	r.invalidator.PrefixInvalidated(prefix)
+}
+
+// cloneURL copies u so callers can't modify a cached entry
+func cloneURL(u *url.URL) *url.URL {
+	c := *u
+	if u.DeletedAt != nil {
+		deletedAt := *u.DeletedAt
+		c.DeletedAt = &deletedAt
+	}
+	return &c
+}
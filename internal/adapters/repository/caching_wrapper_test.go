@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// countingURLRepository counts FindByShortCode calls that reach the wrapped
+// repository; onFind runs after each read, before the result is returned
+type countingURLRepository struct {
+	url.Repository
+	finds  int
+	onFind func()
+}
+
+func (c *countingURLRepository) FindByShortCode(ctx context.Context, shortCode string) (*url.URL, error) {
+	c.finds++
+	u, err := c.Repository.FindByShortCode(ctx, shortCode)
+	if c.onFind != nil {
+		c.onFind()
+	}
+	return u, err
+}
+
+func setupCachingRepo(t *testing.T, size int, ttl time.Duration, codes ...string) (*CachingURLRepository, *countingURLRepository) {
+	t.Helper()
+
+	db, cleanup := setupSQLiteTestDB(t)
+	t.Cleanup(cleanup)
+
+	sqliteRepo := NewSQLiteURLRepository(db)
+	for _, code := range codes {
+		u, err := url.NewURL(code, "https://example.com/"+code, "user1")
+		if err != nil {
+			t.Fatalf("NewURL() error = %v", err)
+		}
+		if err := sqliteRepo.Create(context.Background(), u); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	counting := &countingURLRepository{Repository: sqliteRepo}
+	return NewCachingURLRepository(counting, size, ttl), counting
+}
+
+func TestCachingURLRepository_HitAndMiss(t *testing.T) {
+	repo, counting := setupCachingRepo(t, 10, time.Minute, "abc123")
+	ctx := context.Background()
+
+	first, err := repo.FindByShortCode(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("FindByShortCode() error = %v", err)
+	}
+	// Callers get copies, so changing one must not touch the cached entry
+	first.OriginalURL = "https://changed.example"
+
+	second, err := repo.FindByShortCode(ctx, "abc123")
+	if err != nil {
+		t.Fatalf("FindByShortCode() error = %v", err)
+	}
+	if counting.finds != 1 {
+		t.Errorf("expected 1 lookup to reach the database, got %d", counting.finds)
+	}
+	if second.OriginalURL != "https://example.com/abc123" {
+		t.Errorf("cached OriginalURL = %q, want the stored destination", second.OriginalURL)
+	}
+
+	// Unknown codes fall through every time and are never cached
+	for i := 0; i < 2; i++ {
+		if _, err := repo.FindByShortCode(ctx, "missing"); !errors.Is(err, url.ErrURLNotFound) {
+			t.Fatalf("FindByShortCode(missing) error = %v, want ErrURLNotFound", err)
+		}
+	}
+	if counting.finds != 3 {
+		t.Errorf("expected misses to reach the database each time, got %d lookups", counting.finds)
+	}
+}
+
+func TestCachingURLRepository_TTL(t *testing.T) {
+	repo, counting := setupCachingRepo(t, 10, time.Minute, "abc123")
+	ctx := context.Background()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time { return now }
+
+	for _, advance := range []time.Duration{0, 59 * time.Second, time.Second} {
+		now = now.Add(advance)
+		if _, err := repo.FindByShortCode(ctx, "abc123"); err != nil {
+			t.Fatalf("FindByShortCode() error = %v", err)
+		}
+	}
+
+	// Cached at 0s, hit at 59s, expired at 60s
+	if counting.finds != 2 {
+		t.Errorf("expected 2 lookups to reach the database, got %d", counting.finds)
+	}
+}
+
+func TestCachingURLRepository_LRUEviction(t *testing.T) {
+	repo, counting := setupCachingRepo(t, 2, time.Minute, "aaa", "bbb", "ccc")
+	ctx := context.Background()
+
+	// aaa is used again before ccc arrives, so bbb is the least recently used
+	for _, code := range []string{"aaa", "bbb", "aaa", "ccc"} {
+		if _, err := repo.FindByShortCode(ctx, code); err != nil {
+			t.Fatalf("FindByShortCode(%s) error = %v", code, err)
+		}
+	}
+	if counting.finds != 3 {
+		t.Fatalf("expected 3 lookups before eviction checks, got %d", counting.finds)
+	}
+
+	// Checked in order: looking up bbb re-caches it and evicts another entry
+	for _, check := range []struct {
+		code       string
+		wantCached bool
+	}{{"aaa", true}, {"ccc", true}, {"bbb", false}} {
+		before := counting.finds
+		if _, err := repo.FindByShortCode(ctx, check.code); err != nil {
+			t.Fatalf("FindByShortCode(%s) error = %v", check.code, err)
+		}
+		if cached := counting.finds == before; cached != check.wantCached {
+			t.Errorf("%s cached = %v, want %v", check.code, cached, check.wantCached)
+		}
+	}
+}
+
+func TestCachingURLRepository_Invalidation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		write       func(*CachingURLRepository) error
+		wantErr     error
+		wantDeleted bool
+	}{
+		{
+			name:    "delete",
+			write:   func(r *CachingURLRepository) error { return r.Delete(ctx, "launch-1") },
+			wantErr: url.ErrURLNotFound,
+		},
+		{
+			name: "delete by prefix",
+			write: func(r *CachingURLRepository) error {
+				_, err := r.DeleteByShortCodePrefix(ctx, "user1", "launch-")
+				return err
+			},
+			wantErr: url.ErrURLNotFound,
+		},
+		{
+			name:        "soft delete",
+			write:       func(r *CachingURLRepository) error { return r.SoftDelete(ctx, "launch-1", time.Now()) },
+			wantDeleted: true,
+		},
+		{
+			name: "soft delete by prefix",
+			write: func(r *CachingURLRepository) error {
+				_, err := r.SoftDeleteByShortCodePrefix(ctx, "user1", "launch-", time.Now())
+				return err
+			},
+			wantDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, _ := setupCachingRepo(t, 10, time.Hour, "launch-1")
+
+			if _, err := repo.FindByShortCode(ctx, "launch-1"); err != nil {
+				t.Fatalf("FindByShortCode() error = %v", err)
+			}
+			if err := tt.write(repo); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+
+			got, err := repo.FindByShortCode(ctx, "launch-1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindByShortCode() after write error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.IsDeleted() != tt.wantDeleted {
+				t.Errorf("IsDeleted() = %v, want %v", got.IsDeleted(), tt.wantDeleted)
+			}
+		})
+	}
+
+	t.Run("restore", func(t *testing.T) {
+		repo, _ := setupCachingRepo(t, 10, time.Hour, "launch-1")
+
+		if err := repo.SoftDelete(ctx, "launch-1", time.Now()); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+		if got, err := repo.FindByShortCode(ctx, "launch-1"); err != nil || !got.IsDeleted() {
+			t.Fatalf("expected a cached soft-deleted URL, got %v, %v", got, err)
+		}
+		if err := repo.Restore(ctx, "launch-1"); err != nil {
+			t.Fatalf("Restore() error = %v", err)
+		}
+		if got, err := repo.FindByShortCode(ctx, "launch-1"); err != nil || got.IsDeleted() {
+			t.Errorf("expected the restored URL after Restore, got %v, %v", got, err)
+		}
+	})
+}
+
+func TestCachingURLRepository_WriteDuringLookupIsNotCached(t *testing.T) {
+	repo, counting := setupCachingRepo(t, 10, time.Hour, "abc123")
+	ctx := context.Background()
+
+	// A delete that lands while the lookup is in flight must win over the row it read
+	counting.onFind = func() {
+		counting.onFind = nil
+		if err := repo.Delete(ctx, "abc123"); err != nil {
+			t.Errorf("Delete() error = %v", err)
+		}
+	}
+
+	if _, err := repo.FindByShortCode(ctx, "abc123"); err != nil {
+		t.Fatalf("FindByShortCode() error = %v", err)
+	}
+	if _, err := repo.FindByShortCode(ctx, "abc123"); !errors.Is(err, url.ErrURLNotFound) {
+		t.Errorf("FindByShortCode() after racing delete error = %v, want ErrURLNotFound", err)
+	}
+}
+
+// relayInvalidator stands in for a LISTEN/NOTIFY channel, handing every
+// eviction straight to the caches of the other instances
+type relayInvalidator struct {
+	peers []*CachingURLRepository
+}
+
+func (r *relayInvalidator) Invalidated(shortCode string) {
+	for _, peer := range r.peers {
+		peer.Invalidate(shortCode)
+	}
+}
+
+func (r *relayInvalidator) PrefixInvalidated(prefix string) {
+	for _, peer := range r.peers {
+		peer.InvalidatePrefix(prefix)
+	}
+}
+
+func TestCachingURLRepository_InvalidatorRelaysWrites(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		write func(*CachingURLRepository) error
+	}{
+		{
+			name:  "delete",
+			write: func(r *CachingURLRepository) error { return r.Delete(ctx, "launch-1") },
+		},
+		{
+			name: "soft delete by prefix",
+			write: func(r *CachingURLRepository) error {
+				_, err := r.SoftDeleteByShortCodePrefix(ctx, "user1", "launch-", time.Now())
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Two instances sharing one database, each with its own cache
+			peer, counting := setupCachingRepo(t, 10, time.Hour, "launch-1")
+			writer := NewCachingURLRepository(counting.Repository, 10, time.Hour,
+				WithCacheInvalidator(&relayInvalidator{peers: []*CachingURLRepository{peer}}))
+
+			if _, err := peer.FindByShortCode(ctx, "launch-1"); err != nil {
+				t.Fatalf("FindByShortCode() error = %v", err)
+			}
+			if err := tt.write(writer); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+
+			got, err := peer.FindByShortCode(ctx, "launch-1")
+			if counting.finds != 2 {
+				t.Errorf("finds = %d, want 2: the peer served its stale entry", counting.finds)
+			}
+			if err == nil && !got.IsDeleted() {
+				t.Errorf("peer FindByShortCode() = live URL, want it gone after the write")
+			}
+		})
+	}
+}
+
+func BenchmarkURLRepository_FindByShortCode(b *testing.B) {
+	db, cleanup := setupSQLiteTestDB(b)
+	defer cleanup()
+
+	sqliteRepo := NewSQLiteURLRepository(db)
+	codes := make([]string, 100)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("bench%03d", i)
+		u, err := url.NewURL(codes[i], "https://example.com/"+codes[i], "bench")
+		if err != nil {
+			b.Fatalf("NewURL() error = %v", err)
+		}
+		if err := sqliteRepo.Create(context.Background(), u); err != nil {
+			b.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	repos := map[string]url.Repository{
+		"uncached": sqliteRepo,
+		"cached":   NewCachingURLRepository(sqliteRepo, len(codes), time.Hour),
+	}
+	for _, name := range []string{"uncached", "cached"} {
+		repo := repos[name]
+		b.Run(name, func(b *testing.B) {
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.FindByShortCode(ctx, codes[i%len(codes)]); err != nil {
+					b.Fatalf("FindByShortCode() error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -23,6 +23,42 @@ func mapClickSQLError(err error) error {
 	return MapSQLError(err, nil, nil)
 }
 
+// topNLimit converts a requested topN into a SQL LIMIT, using click.DefaultTopN
+// when topN is not positive and capping it at click.MaxTopN
+func topNLimit(topN int) int64 {
+	switch {
+	case topN <= 0:
+		return click.DefaultTopN
+	case topN > click.MaxTopN:
+		return click.MaxTopN
+	}
+	return int64(topN)
+}
+
+// userAgentBuckets accumulates per-user-agent click counts into browser and OS buckets.
+// SQLite can't parse user agents, so rows are grouped by raw user_agent and bucketed here.
+type userAgentBuckets struct {
+	byBrowser map[string]int64
+	byOS      map[string]int64
+}
+
+func newUserAgentBuckets() *userAgentBuckets {
+	return &userAgentBuckets{
+		byBrowser: make(map[string]int64),
+		byOS:      make(map[string]int64),
+	}
+}
+
+// add counts clicks for a user agent; nil (no header recorded) lands in the Other buckets
+func (b *userAgentBuckets) add(userAgent *string, count int64) {
+	var ua string
+	if userAgent != nil {
+		ua = *userAgent
+	}
+	b.byBrowser[click.ParseBrowser(ua)] += count
+	b.byOS[click.ParseOS(ua)] += count
+}
+
 // stringToStringPtr converts string to *string, returning nil for empty string
 func stringToStringPtr(s string) *string {
 	if s == "" {
@@ -31,6 +67,14 @@ func stringToStringPtr(s string) *string {
 	return &s
 }
 
+// stringPtrToString converts *string to string, returning "" for nil
+func stringPtrToString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 // stringToNullString converts string to sql.NullString
 func stringToNullString(s string) sql.NullString {
 	if s == "" {
@@ -32,6 +32,7 @@ func (r *SQLiteClickRepository) Record(ctx context.Context, c *click.Click) erro
 		ReferrerDomain: stringToStringPtr(c.ReferrerDomain),
 		Country:        stringToStringPtr(c.Country),
 		UserAgent:      stringToStringPtr(c.UserAgent),
+		VisitorHash:    stringToStringPtr(c.VisitorHash),
 	})
 
 	if err != nil {
@@ -43,7 +44,7 @@ func (r *SQLiteClickRepository) Record(ctx context.Context, c *click.Click) erro
 }
 
 // GetStatsByURL retrieves aggregate statistics for a specific URL
-func (r *SQLiteClickRepository) GetStatsByURL(ctx context.Context, urlID int64) (*click.Stats, error) {
+func (r *SQLiteClickRepository) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
 	// Get total count
 	totalCount, err := r.queries.GetTotalClickCount(ctx, urlID)
 	if err != nil {
@@ -64,7 +65,10 @@ func (r *SQLiteClickRepository) GetStatsByURL(ctx context.Context, urlID int64)
 	}
 
 	// Get clicks by referrer
-	referrerRows, err := r.queries.GetClicksByReferrer(ctx, urlID)
+	referrerRows, err := r.queries.GetClicksByReferrer(ctx, sqliterepo.GetClicksByReferrerParams{
+		UrlID: urlID,
+		Limit: topNLimit(topN),
+	})
 	if err != nil {
 		return nil, mapClickSQLError(err)
 	}
@@ -76,6 +80,17 @@ func (r *SQLiteClickRepository) GetStatsByURL(ctx context.Context, urlID int64)
 		}
 	}
 
+	// Get clicks by user agent, bucketed into browser and OS
+	userAgentRows, err := r.queries.GetClicksByUserAgent(ctx, urlID)
+	if err != nil {
+		return nil, mapClickSQLError(err)
+	}
+
+	userAgents := newUserAgentBuckets()
+	for _, row := range userAgentRows {
+		userAgents.add(row.UserAgent, row.Count)
+	}
+
 	// Get clicks by date
 	dateRows, err := r.queries.GetClicksByDate(ctx, urlID)
 	if err != nil {
@@ -94,12 +109,14 @@ func (r *SQLiteClickRepository) GetStatsByURL(ctx context.Context, urlID int64)
 		TotalCount: totalCount,
 		ByCountry:  byCountry,
 		ByReferrer: byReferrer,
+		ByBrowser:  userAgents.byBrowser,
+		ByOS:       userAgents.byOS,
 		ByDate:     byDate,
 	}, nil
 }
 
 // GetStatsByURLAndTimeRange retrieves statistics for a URL within a time range
-func (r *SQLiteClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+func (r *SQLiteClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
 	// Get total count in time range
 	totalCount, err := r.queries.GetTotalClickCountInTimeRange(ctx, sqliterepo.GetTotalClickCountInTimeRangeParams{
 		UrlID:       urlID,
@@ -132,6 +149,7 @@ func (r *SQLiteClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, u
 		UrlID:       urlID,
 		ClickedAt:   startTime,
 		ClickedAt_2: endTime,
+		Limit:       topNLimit(topN),
 	})
 	if err != nil {
 		return nil, mapClickSQLError(err)
@@ -144,6 +162,21 @@ func (r *SQLiteClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, u
 		}
 	}
 
+	// Get clicks by user agent in time range, bucketed into browser and OS
+	userAgentRows, err := r.queries.GetClicksByUserAgentInTimeRange(ctx, sqliterepo.GetClicksByUserAgentInTimeRangeParams{
+		UrlID:       urlID,
+		ClickedAt:   startTime,
+		ClickedAt_2: endTime,
+	})
+	if err != nil {
+		return nil, mapClickSQLError(err)
+	}
+
+	userAgents := newUserAgentBuckets()
+	for _, row := range userAgentRows {
+		userAgents.add(row.UserAgent, row.Count)
+	}
+
 	return &click.TimeRangeStats{
 		URLID:      urlID,
 		StartTime:  startTime,
@@ -151,6 +184,8 @@ func (r *SQLiteClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, u
 		TotalCount: totalCount,
 		ByCountry:  byCountry,
 		ByReferrer: byReferrer,
+		ByBrowser:  userAgents.byBrowser,
+		ByOS:       userAgents.byOS,
 	}, nil
 }
 
@@ -164,6 +199,69 @@ func (r *SQLiteClickRepository) GetTotalClickCount(ctx context.Context, urlID in
 	return count, nil
 }
 
+// GetTotalClicksByUser returns the total number of clicks across all live URLs
+// created by a user. Clicks on soft-deleted URLs are not counted.
+func (r *SQLiteClickRepository) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	count, err := r.queries.GetTotalClicksByCreatedBy(ctx, createdBy)
+	if err != nil {
+		return 0, mapClickSQLError(err)
+	}
+
+	return count, nil
+}
+
+// GetTopURLsByUser returns up to limit of a user's live URLs, most clicked first,
+// including URLs without clicks. Ties go to the most recently created URL.
+// limit is clamped like a topN: DefaultTopN when not positive, at most MaxTopN.
+func (r *SQLiteClickRepository) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	rows, err := r.queries.GetTopURLsByCreatedBy(ctx, sqliterepo.GetTopURLsByCreatedByParams{
+		CreatedBy: createdBy,
+		Limit:     topNLimit(limit),
+	})
+	if err != nil {
+		return nil, mapClickSQLError(err)
+	}
+
+	result := make([]click.URLClicks, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, click.URLClicks{
+			URLID:       row.ID,
+			ShortCode:   row.ShortCode,
+			OriginalURL: row.OriginalUrl,
+			CreatedAt:   row.CreatedAt,
+			CreatedBy:   row.CreatedBy,
+			Count:       row.ClickCount,
+		})
+	}
+
+	return result, nil
+}
+
+// GetUniqueVisitorCount returns the number of distinct visitor hashes recorded for a URL.
+// Clicks recorded without a hash are not counted.
+func (r *SQLiteClickRepository) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	count, err := r.queries.GetUniqueVisitorCount(ctx, urlID)
+	if err != nil {
+		return 0, mapClickSQLError(err)
+	}
+
+	return count, nil
+}
+
+// GetUniqueVisitorCountInTimeRange returns the number of distinct visitor hashes for a URL within a time range
+func (r *SQLiteClickRepository) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	count, err := r.queries.GetUniqueVisitorCountInTimeRange(ctx, sqliterepo.GetUniqueVisitorCountInTimeRangeParams{
+		UrlID:       urlID,
+		ClickedAt:   startTime,
+		ClickedAt_2: endTime,
+	})
+	if err != nil {
+		return 0, mapClickSQLError(err)
+	}
+
+	return count, nil
+}
+
 // GetClicksByCountry returns click counts grouped by country for a URL
 func (r *SQLiteClickRepository) GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error) {
 	rows, err := r.queries.GetClicksByCountry(ctx, urlID)
@@ -180,3 +278,152 @@ func (r *SQLiteClickRepository) GetClicksByCountry(ctx context.Context, urlID in
 
 	return result, nil
 }
+
+// GetClicksByReferrerDomain returns the top referrer domains and their click counts for a URL
+func (r *SQLiteClickRepository) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	rows, err := r.queries.GetClicksByReferrerDomain(ctx, sqliterepo.GetClicksByReferrerDomainParams{
+		UrlID: urlID,
+		Limit: topNLimit(topN),
+	})
+	if err != nil {
+		return nil, mapClickSQLError(err)
+	}
+
+	result := make(map[string]int64)
+	for _, row := range rows {
+		if row.ReferrerDomain != nil {
+			result[*row.ReferrerDomain] = row.Count
+		}
+	}
+
+	return result, nil
+}
+
+// GetClicksByReferrerDomainInTimeRange returns the top referrer domains for a URL within a time range
+func (r *SQLiteClickRepository) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	rows, err := r.queries.GetClicksByReferrerDomainInTimeRange(ctx, sqliterepo.GetClicksByReferrerDomainInTimeRangeParams{
+		UrlID:       urlID,
+		ClickedAt:   startTime,
+		ClickedAt_2: endTime,
+		Limit:       topNLimit(topN),
+	})
+	if err != nil {
+		return nil, mapClickSQLError(err)
+	}
+
+	result := make(map[string]int64)
+	for _, row := range rows {
+		if row.ReferrerDomain != nil {
+			result[*row.ReferrerDomain] = row.Count
+		}
+	}
+
+	return result, nil
+}
+
+// ListByURL returns a page of a URL's clicks, newest first. The full referrer and
+// visitor hash are not read, so they cannot leak into an export.
+func (r *SQLiteClickRepository) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	// Handle unlimited case
+	if limit == 0 {
+		limit = -1 // SQLite uses -1 for no limit
+	}
+
+	rows, err := r.queries.ListClicksByURL(ctx, sqliterepo.ListClicksByURLParams{
+		UrlID:  urlID,
+		Limit:  int64(limit),
+		Offset: int64(offset),
+	})
+	if err != nil {
+		return nil, mapClickSQLError(err)
+	}
+
+	clicks := make([]*click.Click, len(rows))
+	for i, row := range rows {
+		clicks[i] = &click.Click{
+			ID:             row.ID,
+			URLID:          urlID,
+			ClickedAt:      row.ClickedAt,
+			ReferrerDomain: stringPtrToString(row.ReferrerDomain),
+			Country:        stringPtrToString(row.Country),
+			UserAgent:      stringPtrToString(row.UserAgent),
+		}
+	}
+
+	return clicks, nil
+}
+
+// DeleteOlderThan deletes clicks recorded before t and returns how many were removed
+func (r *SQLiteClickRepository) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	deleted, err := r.queries.DeleteClicksOlderThan(ctx, t)
+	if err != nil {
+		return 0, mapClickSQLError(err)
+	}
+	return deleted, nil
+}
+
+// GetDailyClicksInRange returns one entry per UTC day from startDate to endDate inclusive,
+// oldest first. Days without clicks are filled in with a zero count.
+func (r *SQLiteClickRepository) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	start := click.TruncateToDay(startDate)
+	end := click.TruncateToDay(endDate).AddDate(0, 0, 1)
+
+	rows, err := r.queries.GetDailyClicksInRange(ctx, sqliterepo.GetDailyClicksInRangeParams{
+		UrlID:       urlID,
+		ClickedAt:   start,
+		ClickedAt_2: end,
+	})
+	if err != nil {
+		return nil, mapClickSQLError(err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Date] = row.Count
+	}
+
+	result := []click.DailyCount{}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		result = append(result, click.DailyCount{Date: date, Count: counts[date]})
+	}
+	return result, nil
+}
+
+// GetClicksOverTimeInTimeRange returns click counts for a URL within a time range,
+// keyed by UTC day (YYYY-MM-DD) or, for click.GranularityHour, UTC hour (YYYY-MM-DD HH:00)
+func (r *SQLiteClickRepository) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	result := make(map[string]int64)
+
+	if granularity == click.GranularityHour {
+		rows, err := r.queries.GetClicksByHourInTimeRange(ctx, sqliterepo.GetClicksByHourInTimeRangeParams{
+			UrlID:       urlID,
+			ClickedAt:   startTime,
+			ClickedAt_2: endTime,
+		})
+		if err != nil {
+			return nil, mapClickSQLError(err)
+		}
+		for _, row := range rows {
+			if row.Hour != "" {
+				result[row.Hour] = row.Count
+			}
+		}
+		return result, nil
+	}
+
+	rows, err := r.queries.GetClicksByDateInTimeRange(ctx, sqliterepo.GetClicksByDateInTimeRangeParams{
+		UrlID:       urlID,
+		ClickedAt:   startTime,
+		ClickedAt_2: endTime,
+	})
+	if err != nil {
+		return nil, mapClickSQLError(err)
+	}
+	for _, row := range rows {
+		if row.Date != "" {
+			result[row.Date] = row.Count
+		}
+	}
+	return result, nil
+}
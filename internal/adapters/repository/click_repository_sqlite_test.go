@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -168,7 +169,7 @@ func TestSQLiteClickRepository_GetStatsByURL(t *testing.T) {
 	}
 
 	t.Run("get aggregate stats", func(t *testing.T) {
-		stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID)
+		stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID, 0)
 		if err != nil {
 			t.Fatalf("GetStatsByURL() error = %v", err)
 		}
@@ -237,7 +238,7 @@ func TestSQLiteClickRepository_GetStatsByURLAndTimeRange(t *testing.T) {
 		startTime := now.Add(-90 * time.Minute)
 		endTime := now
 
-		stats, err := clickRepo.GetStatsByURLAndTimeRange(context.Background(), u.ID, startTime, endTime)
+		stats, err := clickRepo.GetStatsByURLAndTimeRange(context.Background(), u.ID, startTime, endTime, 0)
 		if err != nil {
 			t.Fatalf("GetStatsByURLAndTimeRange() error = %v", err)
 		}
@@ -270,7 +271,7 @@ func TestSQLiteClickRepository_GetStatsByURLAndTimeRange(t *testing.T) {
 		startTime := now.Add(1 * time.Hour)
 		endTime := now.Add(2 * time.Hour)
 
-		stats, err := clickRepo.GetStatsByURLAndTimeRange(context.Background(), u.ID, startTime, endTime)
+		stats, err := clickRepo.GetStatsByURLAndTimeRange(context.Background(), u.ID, startTime, endTime, 0)
 		if err != nil {
 			t.Fatalf("GetStatsByURLAndTimeRange() error = %v", err)
 		}
@@ -281,6 +282,621 @@ func TestSQLiteClickRepository_GetStatsByURLAndTimeRange(t *testing.T) {
 	})
 }
 
+func TestSQLiteClickRepository_UserAgentBreakdown(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+
+	u, _ := url.NewURL("test", "https://example.com", "testuser")
+	urlRepo.Create(context.Background(), u)
+
+	const (
+		chromeWindows = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+		chromeMac     = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4_1) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+		safariIPhone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1"
+		googlebot     = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+	)
+
+	now := time.Now()
+	userAgents := []struct {
+		ua  string
+		ago time.Duration
+	}{
+		{chromeWindows, 3 * time.Hour},
+		{chromeWindows, 30 * time.Minute},
+		{chromeMac, 30 * time.Minute},
+		{safariIPhone, 30 * time.Minute},
+		{googlebot, 3 * time.Hour},
+		{"", 30 * time.Minute},
+	}
+	for _, tt := range userAgents {
+		c, _ := click.NewClick(u.ID, "", "", tt.ua)
+		c.ClickedAt = now.Add(-tt.ago)
+		if err := clickRepo.Record(context.Background(), c); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	t.Run("all time", func(t *testing.T) {
+		stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID, 0)
+		if err != nil {
+			t.Fatalf("GetStatsByURL() error = %v", err)
+		}
+
+		wantBrowser := map[string]int64{click.BrowserChrome: 3, click.BrowserSafari: 1, click.BrowserBot: 1, click.BrowserOther: 1}
+		for k, want := range wantBrowser {
+			if stats.ByBrowser[k] != want {
+				t.Errorf("GetStatsByURL() ByBrowser[%s] = %d, want %d", k, stats.ByBrowser[k], want)
+			}
+		}
+		if len(stats.ByBrowser) != len(wantBrowser) {
+			t.Errorf("GetStatsByURL() ByBrowser = %v, want %v", stats.ByBrowser, wantBrowser)
+		}
+
+		wantOS := map[string]int64{click.OSWindows: 2, click.OSMacOS: 1, click.OSiOS: 1, click.OSOther: 2}
+		for k, want := range wantOS {
+			if stats.ByOS[k] != want {
+				t.Errorf("GetStatsByURL() ByOS[%s] = %d, want %d", k, stats.ByOS[k], want)
+			}
+		}
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		stats, err := clickRepo.GetStatsByURLAndTimeRange(context.Background(), u.ID, now.Add(-time.Hour), now, 0)
+		if err != nil {
+			t.Fatalf("GetStatsByURLAndTimeRange() error = %v", err)
+		}
+
+		if stats.ByBrowser[click.BrowserChrome] != 2 {
+			t.Errorf("GetStatsByURLAndTimeRange() ByBrowser[Chrome] = %d, want 2", stats.ByBrowser[click.BrowserChrome])
+		}
+		if stats.ByBrowser[click.BrowserBot] != 0 {
+			t.Errorf("GetStatsByURLAndTimeRange() ByBrowser[Bot] = %d, want 0", stats.ByBrowser[click.BrowserBot])
+		}
+		if stats.ByOS[click.OSWindows] != 1 {
+			t.Errorf("GetStatsByURLAndTimeRange() ByOS[Windows] = %d, want 1", stats.ByOS[click.OSWindows])
+		}
+	})
+}
+
+func TestSQLiteClickRepository_GetClicksOverTimeInTimeRange(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+
+	u, _ := url.NewURL("test", "https://example.com", "testuser")
+	urlRepo.Create(context.Background(), u)
+
+	day := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	clickTimes := []time.Time{
+		day.Add(9*time.Hour + 5*time.Minute),
+		day.Add(9*time.Hour + 55*time.Minute),
+		day.Add(13 * time.Hour),
+		day.Add(23*time.Hour + 59*time.Minute),
+		day.Add(24*time.Hour + 30*time.Minute), // next day
+	}
+	for _, at := range clickTimes {
+		c, _ := click.NewClick(u.ID, "", "", "")
+		c.ClickedAt = at
+		if err := clickRepo.Record(context.Background(), c); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	start := day
+	end := day.Add(48 * time.Hour)
+
+	t.Run("hourly buckets", func(t *testing.T) {
+		got, err := clickRepo.GetClicksOverTimeInTimeRange(context.Background(), u.ID, start, end, click.GranularityHour)
+		if err != nil {
+			t.Fatalf("GetClicksOverTimeInTimeRange() error = %v", err)
+		}
+
+		want := map[string]int64{
+			"2025-11-20 09:00": 2,
+			"2025-11-20 13:00": 1,
+			"2025-11-20 23:00": 1,
+			"2025-11-21 00:00": 1,
+		}
+		if len(got) != len(want) {
+			t.Fatalf("GetClicksOverTimeInTimeRange() = %v, want %v", got, want)
+		}
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("GetClicksOverTimeInTimeRange()[%s] = %d, want %d", k, got[k], v)
+			}
+		}
+	})
+
+	t.Run("daily buckets", func(t *testing.T) {
+		got, err := clickRepo.GetClicksOverTimeInTimeRange(context.Background(), u.ID, start, end, click.GranularityDay)
+		if err != nil {
+			t.Fatalf("GetClicksOverTimeInTimeRange() error = %v", err)
+		}
+
+		if len(got) != 2 || got["2025-11-20"] != 4 || got["2025-11-21"] != 1 {
+			t.Errorf("GetClicksOverTimeInTimeRange() = %v, want 2025-11-20=4 2025-11-21=1", got)
+		}
+	})
+
+	t.Run("respects time range", func(t *testing.T) {
+		got, err := clickRepo.GetClicksOverTimeInTimeRange(context.Background(), u.ID, day.Add(10*time.Hour), day.Add(14*time.Hour), click.GranularityHour)
+		if err != nil {
+			t.Fatalf("GetClicksOverTimeInTimeRange() error = %v", err)
+		}
+
+		if len(got) != 1 || got["2025-11-20 13:00"] != 1 {
+			t.Errorf("GetClicksOverTimeInTimeRange() = %v, want only 2025-11-20 13:00=1", got)
+		}
+	})
+}
+
+func TestSQLiteClickRepository_DeleteOlderThan(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+	ctx := context.Background()
+
+	u1, _ := url.NewURL("old001", "https://example.com/1", "testuser")
+	u2, _ := url.NewURL("old002", "https://example.com/2", "otheruser")
+	urlRepo.Create(ctx, u1)
+	urlRepo.Create(ctx, u2)
+
+	cutoff := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, rec := range []struct {
+		urlID int64
+		at    time.Time
+	}{
+		{u1.ID, cutoff.AddDate(0, -2, 0)},
+		{u1.ID, cutoff.Add(-time.Second)},
+		{u2.ID, cutoff.AddDate(-1, 0, 0)},
+		{u1.ID, cutoff}, // exactly at the cutoff is kept
+		{u2.ID, cutoff.Add(time.Hour)},
+	} {
+		c, _ := click.NewClick(rec.urlID, "", "", "")
+		c.ClickedAt = rec.at
+		if err := clickRepo.Record(ctx, c); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	deleted, err := clickRepo.DeleteOlderThan(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("DeleteOlderThan() error = %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("DeleteOlderThan() deleted %d, want 3", deleted)
+	}
+
+	for _, want := range []struct {
+		urlID int64
+		count int64
+	}{{u1.ID, 1}, {u2.ID, 1}} {
+		got, err := clickRepo.GetTotalClickCount(ctx, want.urlID)
+		if err != nil {
+			t.Fatalf("GetTotalClickCount() error = %v", err)
+		}
+		if got != want.count {
+			t.Errorf("GetTotalClickCount(%d) = %d after purge, want %d", want.urlID, got, want.count)
+		}
+	}
+
+	// A second pass has nothing left to remove
+	deleted, err = clickRepo.DeleteOlderThan(ctx, cutoff)
+	if err != nil {
+		t.Fatalf("DeleteOlderThan() error = %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("second DeleteOlderThan() deleted %d, want 0", deleted)
+	}
+}
+
+func TestSQLiteClickRepository_ListByURL(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+	ctx := context.Background()
+
+	u1, _ := url.NewURL("list01", "https://example.com/1", "testuser")
+	u2, _ := url.NewURL("list02", "https://example.com/2", "testuser")
+	urlRepo.Create(ctx, u1)
+	urlRepo.Create(ctx, u2)
+
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		c, _ := click.NewClick(u1.ID, "https://news.example.org/a?b=c", "US", "Mozilla/5.0 Firefox/120.0")
+		c.ClickedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := clickRepo.Record(ctx, c); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+	other, _ := click.NewClick(u2.ID, "", "", "")
+	clickRepo.Record(ctx, other)
+
+	tests := []struct {
+		name      string
+		limit     int
+		offset    int
+		wantCount int
+		wantFirst time.Time
+	}{
+		{"first page", 2, 0, 2, base.Add(4 * time.Minute)},
+		{"middle page", 2, 2, 2, base.Add(2 * time.Minute)},
+		{"partial last page", 2, 4, 1, base},
+		{"offset at end", 2, 5, 0, time.Time{}},
+		{"offset past end", 2, 10, 0, time.Time{}},
+		{"no limit", 0, 0, 5, base.Add(4 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clicks, err := clickRepo.ListByURL(ctx, u1.ID, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("ListByURL() error = %v", err)
+			}
+			if len(clicks) != tt.wantCount {
+				t.Fatalf("ListByURL() returned %d clicks, want %d", len(clicks), tt.wantCount)
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+			if !clicks[0].ClickedAt.Equal(tt.wantFirst) {
+				t.Errorf("first click at %v, want %v", clicks[0].ClickedAt, tt.wantFirst)
+			}
+			for i, c := range clicks {
+				if c.URLID != u1.ID {
+					t.Errorf("click %d has URLID %d, want %d", i, c.URLID, u1.ID)
+				}
+				if i > 0 && c.ClickedAt.After(clicks[i-1].ClickedAt) {
+					t.Errorf("clicks not newest first at index %d", i)
+				}
+			}
+		})
+	}
+
+	// Only the parts safe to export are read back
+	clicks, err := clickRepo.ListByURL(ctx, u1.ID, 1, 0)
+	if err != nil {
+		t.Fatalf("ListByURL() error = %v", err)
+	}
+	c := clicks[0]
+	if c.ReferrerDomain != "news.example.org" {
+		t.Errorf("ReferrerDomain = %q, want %q", c.ReferrerDomain, "news.example.org")
+	}
+	if c.Country != "US" || c.UserAgent != "Mozilla/5.0 Firefox/120.0" {
+		t.Errorf("Country/UserAgent = %q/%q", c.Country, c.UserAgent)
+	}
+	if c.Referrer != "" || c.VisitorHash != "" {
+		t.Errorf("Referrer/VisitorHash should not be read, got %q/%q", c.Referrer, c.VisitorHash)
+	}
+}
+
+func TestSQLiteClickRepository_GetDailyClicksInRange(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+	ctx := context.Background()
+
+	u, _ := url.NewURL("daily", "https://example.com", "testuser")
+	urlRepo.Create(ctx, u)
+
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clickTimes := []time.Time{
+		day.Add(-time.Minute),                  // day before the range
+		day,                                    // first instant of the range
+		day.Add(23*time.Hour + 59*time.Minute), // still the first day
+		day.Add(48*time.Hour + 12*time.Hour),   // third day; the second has none
+		day.Add(72 * time.Hour),                // first instant after the range
+	}
+	for _, at := range clickTimes {
+		c, _ := click.NewClick(u.ID, "", "", "")
+		c.ClickedAt = at
+		if err := clickRepo.Record(ctx, c); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	t.Run("zero-fills missing days", func(t *testing.T) {
+		got, err := clickRepo.GetDailyClicksInRange(ctx, u.ID, day, day.AddDate(0, 0, 2))
+		if err != nil {
+			t.Fatalf("GetDailyClicksInRange() error = %v", err)
+		}
+
+		want := []click.DailyCount{
+			{Date: "2025-01-01", Count: 2},
+			{Date: "2025-01-02", Count: 0},
+			{Date: "2025-01-03", Count: 1},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("GetDailyClicksInRange() = %v, want %v", got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("GetDailyClicksInRange()[%d] = %v, want %v", i, got[i], want[i])
+			}
+		}
+	})
+
+	t.Run("uses whole UTC days", func(t *testing.T) {
+		got, err := clickRepo.GetDailyClicksInRange(ctx, u.ID, day.Add(18*time.Hour), day.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("GetDailyClicksInRange() error = %v", err)
+		}
+		if len(got) != 1 || got[0] != (click.DailyCount{Date: "2025-01-01", Count: 2}) {
+			t.Errorf("GetDailyClicksInRange() = %v, want only 2025-01-01=2", got)
+		}
+	})
+
+	t.Run("no clicks", func(t *testing.T) {
+		got, err := clickRepo.GetDailyClicksInRange(ctx, u.ID+1, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			t.Fatalf("GetDailyClicksInRange() error = %v", err)
+		}
+		if len(got) != 2 || got[0].Count != 0 || got[1].Count != 0 {
+			t.Errorf("GetDailyClicksInRange() = %v, want two zero days", got)
+		}
+	})
+}
+
+func TestSQLiteClickRepository_GetTotalClicksByUser(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+	ctx := context.Background()
+
+	first, _ := url.NewURL("first", "https://example.com/1", "alice")
+	second, _ := url.NewURL("second", "https://example.com/2", "alice")
+	quiet, _ := url.NewURL("quiet", "https://example.com/3", "alice")
+	others, _ := url.NewURL("others", "https://example.com/4", "bob")
+	for _, u := range []*url.URL{first, second, quiet, others} {
+		if err := urlRepo.Create(ctx, u); err != nil {
+			t.Fatalf("failed to create URL: %v", err)
+		}
+	}
+
+	record := func(urlID int64, n int) {
+		for i := 0; i < n; i++ {
+			c, _ := click.NewClick(urlID, "", "", "")
+			if err := clickRepo.Record(ctx, c); err != nil {
+				t.Fatalf("Record() error = %v", err)
+			}
+		}
+	}
+	record(first.ID, 3)
+	record(second.ID, 2)
+	record(others.ID, 4)
+
+	t.Run("sums clicks across the user's URLs", func(t *testing.T) {
+		got, err := clickRepo.GetTotalClicksByUser(ctx, "alice")
+		if err != nil {
+			t.Fatalf("GetTotalClicksByUser() error = %v", err)
+		}
+
+		var want int64
+		for _, u := range []*url.URL{first, second, quiet} {
+			n, _ := clickRepo.GetTotalClickCount(ctx, u.ID)
+			want += n
+		}
+		if got != 5 || got != want {
+			t.Errorf("GetTotalClicksByUser() = %d, want 5 (sum of per-URL counts %d)", got, want)
+		}
+	})
+
+	t.Run("excludes other users", func(t *testing.T) {
+		got, err := clickRepo.GetTotalClicksByUser(ctx, "bob")
+		if err != nil {
+			t.Fatalf("GetTotalClicksByUser() error = %v", err)
+		}
+		if got != 4 {
+			t.Errorf("GetTotalClicksByUser(bob) = %d, want 4", got)
+		}
+	})
+
+	t.Run("user without URLs", func(t *testing.T) {
+		got, err := clickRepo.GetTotalClicksByUser(ctx, "nobody")
+		if err != nil {
+			t.Fatalf("GetTotalClicksByUser() error = %v", err)
+		}
+		if got != 0 {
+			t.Errorf("GetTotalClicksByUser(nobody) = %d, want 0", got)
+		}
+	})
+
+	t.Run("excludes soft-deleted URLs", func(t *testing.T) {
+		if err := urlRepo.SoftDelete(ctx, second.ShortCode, time.Now()); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+		got, err := clickRepo.GetTotalClicksByUser(ctx, "alice")
+		if err != nil {
+			t.Fatalf("GetTotalClicksByUser() error = %v", err)
+		}
+		if got != 3 {
+			t.Errorf("GetTotalClicksByUser() after soft delete = %d, want 3", got)
+		}
+	})
+}
+
+func TestSQLiteClickRepository_GetTopURLsByUser(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	urls := []struct {
+		code      string
+		createdBy string
+		age       time.Duration
+		clicks    int
+	}{
+		{"popular", "alice", 50 * time.Minute, 5},
+		{"tieold", "alice", 40 * time.Minute, 2},
+		{"tienew", "alice", 10 * time.Minute, 2},
+		{"single", "alice", 30 * time.Minute, 1},
+		{"unseen", "alice", 20 * time.Minute, 0},
+		{"bobs", "bob", 5 * time.Minute, 9},
+	}
+	for _, tt := range urls {
+		u := &url.URL{ShortCode: tt.code, OriginalURL: "https://example.com/" + tt.code, CreatedBy: tt.createdBy, CreatedAt: base.Add(-tt.age)}
+		if err := urlRepo.Create(ctx, u); err != nil {
+			t.Fatalf("failed to create URL: %v", err)
+		}
+		for i := 0; i < tt.clicks; i++ {
+			c, _ := click.NewClick(u.ID, "", "", "")
+			if err := clickRepo.Record(ctx, c); err != nil {
+				t.Fatalf("Record() error = %v", err)
+			}
+		}
+	}
+
+	codes := func(got []click.URLClicks) []string {
+		out := make([]string, len(got))
+		for i, u := range got {
+			out[i] = fmt.Sprintf("%s:%d", u.ShortCode, u.Count)
+		}
+		return out
+	}
+
+	t.Run("most clicked first, ties by newest", func(t *testing.T) {
+		got, err := clickRepo.GetTopURLsByUser(ctx, "alice", 10)
+		if err != nil {
+			t.Fatalf("GetTopURLsByUser() error = %v", err)
+		}
+		want := []string{"popular:5", "tienew:2", "tieold:2", "single:1", "unseen:0"}
+		if fmt.Sprint(codes(got)) != fmt.Sprint(want) {
+			t.Errorf("GetTopURLsByUser() = %v, want %v", codes(got), want)
+		}
+		if got[0].OriginalURL != "https://example.com/popular" || got[0].CreatedBy != "alice" || got[0].URLID == 0 {
+			t.Errorf("GetTopURLsByUser()[0] = %+v, want the popular URL's details", got[0])
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		got, err := clickRepo.GetTopURLsByUser(ctx, "alice", 2)
+		if err != nil {
+			t.Fatalf("GetTopURLsByUser() error = %v", err)
+		}
+		want := []string{"popular:5", "tienew:2"}
+		if fmt.Sprint(codes(got)) != fmt.Sprint(want) {
+			t.Errorf("GetTopURLsByUser(limit 2) = %v, want %v", codes(got), want)
+		}
+	})
+
+	t.Run("non-positive limit uses the default", func(t *testing.T) {
+		got, err := clickRepo.GetTopURLsByUser(ctx, "alice", 0)
+		if err != nil {
+			t.Fatalf("GetTopURLsByUser() error = %v", err)
+		}
+		if len(got) != 5 {
+			t.Errorf("GetTopURLsByUser(limit 0) returned %d URLs, want all 5", len(got))
+		}
+	})
+
+	t.Run("excludes other users and soft-deleted URLs", func(t *testing.T) {
+		if err := urlRepo.SoftDelete(ctx, "popular", time.Now()); err != nil {
+			t.Fatalf("SoftDelete() error = %v", err)
+		}
+		got, err := clickRepo.GetTopURLsByUser(ctx, "alice", 10)
+		if err != nil {
+			t.Fatalf("GetTopURLsByUser() error = %v", err)
+		}
+		want := []string{"tienew:2", "tieold:2", "single:1", "unseen:0"}
+		if fmt.Sprint(codes(got)) != fmt.Sprint(want) {
+			t.Errorf("GetTopURLsByUser() = %v, want %v", codes(got), want)
+		}
+	})
+}
+
+func TestSQLiteClickRepository_GetUniqueVisitorCount(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+
+	u, _ := url.NewURL("test", "https://example.com", "testuser")
+	urlRepo.Create(context.Background(), u)
+	other, _ := url.NewURL("other", "https://example.org", "testuser")
+	urlRepo.Create(context.Background(), other)
+
+	now := time.Now()
+	clicks := []struct {
+		urlID int64
+		ip    string
+		ago   time.Duration
+	}{
+		{u.ID, "203.0.113.7", 3 * time.Hour},
+		{u.ID, "203.0.113.7", 30 * time.Minute}, // refresh from the same visitor
+		{u.ID, "203.0.113.7", 10 * time.Minute},
+		{u.ID, "198.51.100.2", 20 * time.Minute},
+		{u.ID, "2001:db8::1", 3 * time.Hour},
+		{u.ID, "", 5 * time.Minute},               // no client IP: no hash
+		{other.ID, "192.0.2.44", 5 * time.Minute}, // different URL
+	}
+	for _, tt := range clicks {
+		c, _ := click.NewClick(tt.urlID, "", "", "")
+		c.ClickedAt = now.Add(-tt.ago)
+		c.VisitorHash = click.HashVisitor("test-salt", tt.ip)
+		if err := clickRepo.Record(context.Background(), c); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	t.Run("same IP counts once", func(t *testing.T) {
+		got, err := clickRepo.GetUniqueVisitorCount(context.Background(), u.ID)
+		if err != nil {
+			t.Fatalf("GetUniqueVisitorCount() error = %v", err)
+		}
+		if got != 3 {
+			t.Errorf("GetUniqueVisitorCount() = %d, want 3", got)
+		}
+
+		total, _ := clickRepo.GetTotalClickCount(context.Background(), u.ID)
+		if total != 6 {
+			t.Errorf("GetTotalClickCount() = %d, want 6", total)
+		}
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		got, err := clickRepo.GetUniqueVisitorCountInTimeRange(context.Background(), u.ID, now.Add(-time.Hour), now)
+		if err != nil {
+			t.Fatalf("GetUniqueVisitorCountInTimeRange() error = %v", err)
+		}
+		if got != 2 {
+			t.Errorf("GetUniqueVisitorCountInTimeRange() = %d, want 2", got)
+		}
+	})
+
+	t.Run("no hashed clicks", func(t *testing.T) {
+		empty, _ := url.NewURL("empty", "https://example.net", "testuser")
+		urlRepo.Create(context.Background(), empty)
+		c, _ := click.NewClick(empty.ID, "", "", "")
+		clickRepo.Record(context.Background(), c)
+
+		got, err := clickRepo.GetUniqueVisitorCount(context.Background(), empty.ID)
+		if err != nil {
+			t.Fatalf("GetUniqueVisitorCount() error = %v", err)
+		}
+		if got != 0 {
+			t.Errorf("GetUniqueVisitorCount() = %d, want 0", got)
+		}
+	})
+}
+
 func TestSQLiteClickRepository_ReferrerDomainExtraction(t *testing.T) {
 	db, cleanup := setupSQLiteTestDB(t)
 	defer cleanup()
@@ -339,7 +955,7 @@ func TestSQLiteClickRepository_ReferrerDomainExtraction(t *testing.T) {
 			clickRepo.Record(context.Background(), c)
 		}
 
-		stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID)
+		stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID, 0)
 		if err != nil {
 			t.Fatalf("GetStatsByURL() error = %v", err)
 		}
@@ -358,6 +974,73 @@ func TestSQLiteClickRepository_ReferrerDomainExtraction(t *testing.T) {
 	})
 }
 
+func TestSQLiteClickRepository_GetClicksByReferrerDomain(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+
+	// Create a URL
+	u, _ := url.NewURL("test", "https://example.com", "testuser")
+	urlRepo.Create(context.Background(), u)
+
+	now := time.Now()
+
+	// Three distinct twitter.com paths, one other domain and one direct click
+	referrers := []string{
+		"https://twitter.com/alice/status/1",
+		"https://twitter.com/bob/status/2",
+		"https://twitter.com/search?q=mjr",
+		"https://news.ycombinator.com/item?id=1",
+		"",
+	}
+	for i, ref := range referrers {
+		c, _ := click.NewClick(u.ID, ref, "US", "")
+		c.ClickedAt = now.Add(-time.Duration(i) * time.Hour)
+		clickRepo.Record(context.Background(), c)
+	}
+
+	t.Run("full referrers stay distinct", func(t *testing.T) {
+		stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID, 0)
+		if err != nil {
+			t.Fatalf("GetStatsByURL() error = %v", err)
+		}
+		if len(stats.ByReferrer) != 4 {
+			t.Errorf("ByReferrer has %d entries, want 4", len(stats.ByReferrer))
+		}
+	})
+
+	t.Run("grouped by domain", func(t *testing.T) {
+		results, err := clickRepo.GetClicksByReferrerDomain(context.Background(), u.ID, 0)
+		if err != nil {
+			t.Fatalf("GetClicksByReferrerDomain() error = %v", err)
+		}
+
+		if len(results) != 2 {
+			t.Errorf("GetClicksByReferrerDomain() returned %d domains, want 2: %v", len(results), results)
+		}
+		if results["twitter.com"] != 3 {
+			t.Errorf("GetClicksByReferrerDomain() twitter.com count = %d, want 3", results["twitter.com"])
+		}
+		if results["news.ycombinator.com"] != 1 {
+			t.Errorf("GetClicksByReferrerDomain() news.ycombinator.com count = %d, want 1", results["news.ycombinator.com"])
+		}
+	})
+
+	t.Run("grouped by domain in time range", func(t *testing.T) {
+		// Covers the two most recent twitter.com clicks only
+		results, err := clickRepo.GetClicksByReferrerDomainInTimeRange(context.Background(), u.ID, now.Add(-90*time.Minute), now.Add(time.Minute), 0)
+		if err != nil {
+			t.Fatalf("GetClicksByReferrerDomainInTimeRange() error = %v", err)
+		}
+
+		if len(results) != 1 || results["twitter.com"] != 2 {
+			t.Errorf("GetClicksByReferrerDomainInTimeRange() = %v, want map[twitter.com:2]", results)
+		}
+	})
+}
+
 func TestSQLiteClickRepository_Top10ReferrersLimit(t *testing.T) {
 	db, cleanup := setupSQLiteTestDB(t)
 	defer cleanup()
@@ -400,7 +1083,7 @@ func TestSQLiteClickRepository_Top10ReferrersLimit(t *testing.T) {
 			}
 		}
 
-		stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID)
+		stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID, 0)
 		if err != nil {
 			t.Fatalf("GetStatsByURL() error = %v", err)
 		}
@@ -446,3 +1129,82 @@ func TestSQLiteClickRepository_Top10ReferrersLimit(t *testing.T) {
 		}
 	})
 }
+
+func TestSQLiteClickRepository_TopNReferrers(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	urlRepo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+
+	// Create a URL
+	u, _ := url.NewURL("test", "https://example.com", "testuser")
+	urlRepo.Create(context.Background(), u)
+
+	// 25 distinct referrers: site00-site09 get 3 clicks each, site10-site24 get 1.
+	// Equal counts are ranked by referrer name.
+	now := time.Now()
+	for i := 0; i < 25; i++ {
+		clicks := 1
+		if i < 10 {
+			clicks = 3
+		}
+		for j := 0; j < clicks; j++ {
+			c, _ := click.NewClick(u.ID, fmt.Sprintf("https://site%02d.example/page", i), "US", "")
+			c.ClickedAt = now.Add(-time.Minute)
+			clickRepo.Record(context.Background(), c)
+		}
+	}
+
+	want := func(n int) []string {
+		out := make([]string, n)
+		for i := range out {
+			out[i] = fmt.Sprintf("https://site%02d.example/page", i)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		topN int
+		want []string
+	}{
+		{"top 5", 5, want(5)},
+		{"top 20", 20, want(20)},
+		{"default", 0, want(click.DefaultTopN)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := clickRepo.GetStatsByURL(context.Background(), u.ID, tt.topN)
+			if err != nil {
+				t.Fatalf("GetStatsByURL() error = %v", err)
+			}
+			ranged, err := clickRepo.GetStatsByURLAndTimeRange(context.Background(), u.ID, now.Add(-time.Hour), now, tt.topN)
+			if err != nil {
+				t.Fatalf("GetStatsByURLAndTimeRange() error = %v", err)
+			}
+
+			for name, byReferrer := range map[string]map[string]int64{"all-time": stats.ByReferrer, "time range": ranged.ByReferrer} {
+				if len(byReferrer) != len(tt.want) {
+					t.Errorf("%s: returned %d referrers, want %d", name, len(byReferrer), len(tt.want))
+				}
+				for _, ref := range tt.want {
+					if _, ok := byReferrer[ref]; !ok {
+						t.Errorf("%s: expected referrer %s in top %d", name, ref, len(tt.want))
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("referrer domains", func(t *testing.T) {
+		results, err := clickRepo.GetClicksByReferrerDomain(context.Background(), u.ID, 5)
+		if err != nil {
+			t.Fatalf("GetClicksByReferrerDomain() error = %v", err)
+		}
+		if len(results) != 5 || results["site00.example"] != 3 || results["site04.example"] != 3 {
+			t.Errorf("GetClicksByReferrerDomain() = %v, want site00-site04.example", results)
+		}
+	})
+}
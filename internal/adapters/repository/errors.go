@@ -8,14 +8,15 @@ import (
 	"github.com/mattn/go-sqlite3"
 )
 
-// IsSQLiteUniqueConstraintError checks if the error is a SQLite unique constraint violation.
+// IsSQLiteUniqueConstraintError checks if the error is a SQLite unique or primary key constraint violation.
 func IsSQLiteUniqueConstraintError(err error) bool {
 	if err == nil {
 		return false
 	}
 	var sqliteErr sqlite3.Error
 	if errors.As(err, &sqliteErr) {
-		return sqliteErr.Code == sqlite3.ErrConstraint && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+		return sqliteErr.Code == sqlite3.ErrConstraint &&
+			(sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
 	}
 	return false
 }
@@ -29,6 +29,11 @@ func TestIsSQLiteUniqueConstraintError(t *testing.T) {
 			err:  sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique},
 			want: true,
 		},
+		{
+			name: "SQLite primary key constraint error returns true",
+			err:  sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintPrimaryKey},
+			want: true,
+		},
 		{
 			name: "SQLite constraint error without unique returns false",
 			err:  sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintNotNull},
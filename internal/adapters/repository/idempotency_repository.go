@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/idempotency"
+)
+
+// IdempotencyRepository is an interface implemented by this repo's SQLite repository adapters.
+type IdempotencyRepository interface {
+	idempotency.Repository
+}
+
+// idempotencyRepositoryBase provides common functionality for idempotency repositories.
+type idempotencyRepositoryBase struct {
+	db *sql.DB
+}
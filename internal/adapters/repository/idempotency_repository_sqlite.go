@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/adapters/repository/sqlc/sqlite"
+	"github.com/matt-riley/mjrwtf/internal/domain/idempotency"
+)
+
+// SQLiteIdempotencyRepository implements the idempotency repository for SQLite.
+type SQLiteIdempotencyRepository struct {
+	idempotencyRepositoryBase
+	queries *sqliterepo.Queries
+}
+
+// NewSQLiteIdempotencyRepository creates a new SQLite idempotency repository.
+func NewSQLiteIdempotencyRepository(db *sql.DB) *SQLiteIdempotencyRepository {
+	return &SQLiteIdempotencyRepository{
+		idempotencyRepositoryBase: idempotencyRepositoryBase{db: db},
+		queries:                   sqliterepo.New(db),
+	}
+}
+
+// Find retrieves a creator's record for key if it was created at or after notBefore.
+func (r *SQLiteIdempotencyRepository) Find(ctx context.Context, createdBy, key string, notBefore time.Time) (*idempotency.Record, error) {
+	row, err := r.queries.FindIdempotencyKey(ctx, sqliterepo.FindIdempotencyKeyParams{
+		CreatedBy:      createdBy,
+		IdempotencyKey: key,
+		CreatedAt:      notBefore,
+	})
+	if err != nil {
+		return nil, MapSQLError(err, idempotency.ErrKeyNotFound, nil)
+	}
+
+	return &idempotency.Record{
+		CreatedBy:   row.CreatedBy,
+		Key:         row.IdempotencyKey,
+		OriginalURL: row.OriginalUrl,
+		ShortCode:   row.ShortCode,
+		CreatedAt:   row.CreatedAt,
+	}, nil
+}
+
+// Save prunes the creator's expired records, then stores rec.
+func (r *SQLiteIdempotencyRepository) Save(ctx context.Context, rec *idempotency.Record, notBefore time.Time) error {
+	err := r.queries.DeleteExpiredIdempotencyKeys(ctx, sqliterepo.DeleteExpiredIdempotencyKeysParams{
+		CreatedBy: rec.CreatedBy,
+		CreatedAt: notBefore,
+	})
+	if err != nil {
+		return MapSQLError(err, nil, nil)
+	}
+
+	err = r.queries.CreateIdempotencyKey(ctx, sqliterepo.CreateIdempotencyKeyParams{
+		CreatedBy:      rec.CreatedBy,
+		IdempotencyKey: rec.Key,
+		OriginalUrl:    rec.OriginalURL,
+		ShortCode:      rec.ShortCode,
+		CreatedAt:      rec.CreatedAt,
+	})
+	return MapSQLError(err, nil, idempotency.ErrDuplicateKey)
+}
+
+// Complete sets the short code of the creator's record for key.
+func (r *SQLiteIdempotencyRepository) Complete(ctx context.Context, createdBy, key, shortCode string) error {
+	err := r.queries.CompleteIdempotencyKey(ctx, sqliterepo.CompleteIdempotencyKeyParams{
+		ShortCode:      shortCode,
+		CreatedBy:      createdBy,
+		IdempotencyKey: key,
+	})
+	return MapSQLError(err, nil, nil)
+}
+
+// Release deletes the creator's record for key.
+func (r *SQLiteIdempotencyRepository) Release(ctx context.Context, createdBy, key string) error {
+	err := r.queries.DeleteIdempotencyKey(ctx, sqliterepo.DeleteIdempotencyKeyParams{
+		CreatedBy:      createdBy,
+		IdempotencyKey: key,
+	})
+	return MapSQLError(err, nil, nil)
+}
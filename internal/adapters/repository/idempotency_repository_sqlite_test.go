@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/idempotency"
+)
+
+func TestSQLiteIdempotencyRepository_SaveAndFind(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteIdempotencyRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	notBefore := now.Add(-time.Hour)
+
+	if _, err := repo.Find(ctx, "alice", "key-1", notBefore); err != idempotency.ErrKeyNotFound {
+		t.Fatalf("Find() before Save error = %v, want %v", err, idempotency.ErrKeyNotFound)
+	}
+
+	rec := &idempotency.Record{
+		CreatedBy:   "alice",
+		Key:         "key-1",
+		OriginalURL: "https://example.com",
+		ShortCode:   "abc123",
+		CreatedAt:   now,
+	}
+	if err := repo.Save(ctx, rec, notBefore); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	found, err := repo.Find(ctx, "alice", "key-1", notBefore)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.ShortCode != "abc123" || found.OriginalURL != "https://example.com" || !found.CreatedAt.Equal(now) {
+		t.Errorf("Find() = %+v, want %+v", found, rec)
+	}
+
+	// Keys are scoped per creator
+	if _, err := repo.Find(ctx, "bob", "key-1", notBefore); err != idempotency.ErrKeyNotFound {
+		t.Errorf("Find() for another creator error = %v, want %v", err, idempotency.ErrKeyNotFound)
+	}
+	bobs := *rec
+	bobs.CreatedBy = "bob"
+	bobs.ShortCode = "xyz789"
+	if err := repo.Save(ctx, &bobs, notBefore); err != nil {
+		t.Errorf("Save() for another creator error = %v", err)
+	}
+
+	// A live key cannot be saved twice
+	if err := repo.Save(ctx, rec, notBefore); err != idempotency.ErrDuplicateKey {
+		t.Errorf("Save() twice error = %v, want %v", err, idempotency.ErrDuplicateKey)
+	}
+}
+
+func TestSQLiteIdempotencyRepository_Expiry(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteIdempotencyRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	old := &idempotency.Record{
+		CreatedBy:   "alice",
+		Key:         "key-1",
+		OriginalURL: "https://example.com",
+		ShortCode:   "old123",
+		CreatedAt:   now.Add(-2 * time.Hour),
+	}
+	if err := repo.Save(ctx, old, now.Add(-3*time.Hour)); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Past the TTL the record is no longer found...
+	notBefore := now.Add(-time.Hour)
+	if _, err := repo.Find(ctx, "alice", "key-1", notBefore); err != idempotency.ErrKeyNotFound {
+		t.Fatalf("Find() after TTL error = %v, want %v", err, idempotency.ErrKeyNotFound)
+	}
+
+	// ...and the key can be used again, replacing the expired record
+	fresh := *old
+	fresh.ShortCode = "new123"
+	fresh.CreatedAt = now
+	if err := repo.Save(ctx, &fresh, notBefore); err != nil {
+		t.Fatalf("Save() over expired record error = %v", err)
+	}
+	found, err := repo.Find(ctx, "alice", "key-1", notBefore)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if found.ShortCode != "new123" {
+		t.Errorf("Find() short code = %q, want new123", found.ShortCode)
+	}
+}
+
+func TestSQLiteIdempotencyRepository_CompleteAndRelease(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteIdempotencyRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	notBefore := now.Add(-time.Hour)
+
+	// A claim is saved without a short code...
+	claim := &idempotency.Record{
+		CreatedBy:   "alice",
+		Key:         "key-1",
+		OriginalURL: "https://example.com",
+		CreatedAt:   now,
+	}
+	if err := repo.Save(ctx, claim, notBefore); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.Find(ctx, "alice", "key-1", notBefore)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if !found.Pending() {
+		t.Errorf("Find() = %+v, want a pending claim", found)
+	}
+
+	// ...and completed with the short code once the URL exists
+	if err := repo.Complete(ctx, "alice", "key-1", "abc123"); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	found, err = repo.Find(ctx, "alice", "key-1", notBefore)
+	if err != nil {
+		t.Fatalf("Find() after Complete() error = %v", err)
+	}
+	if found.Pending() || found.ShortCode != "abc123" {
+		t.Errorf("Find() after Complete() short code = %q, want abc123", found.ShortCode)
+	}
+
+	// Releasing frees the key for another request
+	if err := repo.Release(ctx, "alice", "key-1"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := repo.Find(ctx, "alice", "key-1", notBefore); err != idempotency.ErrKeyNotFound {
+		t.Errorf("Find() after Release() error = %v, want %v", err, idempotency.ErrKeyNotFound)
+	}
+	if err := repo.Save(ctx, claim, notBefore); err != nil {
+		t.Errorf("Save() after Release() error = %v", err)
+	}
+}
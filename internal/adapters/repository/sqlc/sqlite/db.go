@@ -24,18 +24,54 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.completeIdempotencyKeyStmt, err = db.PrepareContext(ctx, completeIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteIdempotencyKey: %w", err)
+	}
+	if q.countAllURLsStmt, err = db.PrepareContext(ctx, countAllURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query CountAllURLs: %w", err)
+	}
 	if q.countURLsStmt, err = db.PrepareContext(ctx, countURLs); err != nil {
 		return nil, fmt.Errorf("error preparing query CountURLs: %w", err)
 	}
 	if q.countURLsByCreatedByStmt, err = db.PrepareContext(ctx, countURLsByCreatedBy); err != nil {
 		return nil, fmt.Errorf("error preparing query CountURLsByCreatedBy: %w", err)
 	}
+	if q.countURLsByCreatedByMatchingStmt, err = db.PrepareContext(ctx, countURLsByCreatedByMatching); err != nil {
+		return nil, fmt.Errorf("error preparing query CountURLsByCreatedByMatching: %w", err)
+	}
+	if q.countURLsMatchingStmt, err = db.PrepareContext(ctx, countURLsMatching); err != nil {
+		return nil, fmt.Errorf("error preparing query CountURLsMatching: %w", err)
+	}
+	if q.createAuditEntryStmt, err = db.PrepareContext(ctx, createAuditEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAuditEntry: %w", err)
+	}
+	if q.createIdempotencyKeyStmt, err = db.PrepareContext(ctx, createIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateIdempotencyKey: %w", err)
+	}
 	if q.createURLStmt, err = db.PrepareContext(ctx, createURL); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateURL: %w", err)
 	}
+	if q.deleteClicksOlderThanStmt, err = db.PrepareContext(ctx, deleteClicksOlderThan); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteClicksOlderThan: %w", err)
+	}
+	if q.deleteExpiredIdempotencyKeysStmt, err = db.PrepareContext(ctx, deleteExpiredIdempotencyKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredIdempotencyKeys: %w", err)
+	}
+	if q.deleteIdempotencyKeyStmt, err = db.PrepareContext(ctx, deleteIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIdempotencyKey: %w", err)
+	}
 	if q.deleteURLByShortCodeStmt, err = db.PrepareContext(ctx, deleteURLByShortCode); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteURLByShortCode: %w", err)
 	}
+	if q.deleteURLsByCreatedByAndShortCodePrefixStmt, err = db.PrepareContext(ctx, deleteURLsByCreatedByAndShortCodePrefix); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteURLsByCreatedByAndShortCodePrefix: %w", err)
+	}
+	if q.findIdempotencyKeyStmt, err = db.PrepareContext(ctx, findIdempotencyKey); err != nil {
+		return nil, fmt.Errorf("error preparing query FindIdempotencyKey: %w", err)
+	}
+	if q.findURLByCreatedByAndOriginalURLStmt, err = db.PrepareContext(ctx, findURLByCreatedByAndOriginalURL); err != nil {
+		return nil, fmt.Errorf("error preparing query FindURLByCreatedByAndOriginalURL: %w", err)
+	}
 	if q.findURLByShortCodeStmt, err = db.PrepareContext(ctx, findURLByShortCode); err != nil {
 		return nil, fmt.Errorf("error preparing query FindURLByShortCode: %w", err)
 	}
@@ -48,24 +84,63 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getClicksByDateStmt, err = db.PrepareContext(ctx, getClicksByDate); err != nil {
 		return nil, fmt.Errorf("error preparing query GetClicksByDate: %w", err)
 	}
+	if q.getClicksByDateInTimeRangeStmt, err = db.PrepareContext(ctx, getClicksByDateInTimeRange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClicksByDateInTimeRange: %w", err)
+	}
+	if q.getClicksByHourInTimeRangeStmt, err = db.PrepareContext(ctx, getClicksByHourInTimeRange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClicksByHourInTimeRange: %w", err)
+	}
 	if q.getClicksByReferrerStmt, err = db.PrepareContext(ctx, getClicksByReferrer); err != nil {
 		return nil, fmt.Errorf("error preparing query GetClicksByReferrer: %w", err)
 	}
+	if q.getClicksByReferrerDomainStmt, err = db.PrepareContext(ctx, getClicksByReferrerDomain); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClicksByReferrerDomain: %w", err)
+	}
+	if q.getClicksByReferrerDomainInTimeRangeStmt, err = db.PrepareContext(ctx, getClicksByReferrerDomainInTimeRange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClicksByReferrerDomainInTimeRange: %w", err)
+	}
 	if q.getClicksByReferrerInTimeRangeStmt, err = db.PrepareContext(ctx, getClicksByReferrerInTimeRange); err != nil {
 		return nil, fmt.Errorf("error preparing query GetClicksByReferrerInTimeRange: %w", err)
 	}
+	if q.getClicksByUserAgentStmt, err = db.PrepareContext(ctx, getClicksByUserAgent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClicksByUserAgent: %w", err)
+	}
+	if q.getClicksByUserAgentInTimeRangeStmt, err = db.PrepareContext(ctx, getClicksByUserAgentInTimeRange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetClicksByUserAgentInTimeRange: %w", err)
+	}
+	if q.getDailyClicksInRangeStmt, err = db.PrepareContext(ctx, getDailyClicksInRange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDailyClicksInRange: %w", err)
+	}
+	if q.getTopURLsByCreatedByStmt, err = db.PrepareContext(ctx, getTopURLsByCreatedBy); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTopURLsByCreatedBy: %w", err)
+	}
 	if q.getTotalClickCountStmt, err = db.PrepareContext(ctx, getTotalClickCount); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTotalClickCount: %w", err)
 	}
 	if q.getTotalClickCountInTimeRangeStmt, err = db.PrepareContext(ctx, getTotalClickCountInTimeRange); err != nil {
 		return nil, fmt.Errorf("error preparing query GetTotalClickCountInTimeRange: %w", err)
 	}
+	if q.getTotalClicksByCreatedByStmt, err = db.PrepareContext(ctx, getTotalClicksByCreatedBy); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTotalClicksByCreatedBy: %w", err)
+	}
 	if q.getURLStatusByURLIDStmt, err = db.PrepareContext(ctx, getURLStatusByURLID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetURLStatusByURLID: %w", err)
 	}
+	if q.getUniqueVisitorCountStmt, err = db.PrepareContext(ctx, getUniqueVisitorCount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUniqueVisitorCount: %w", err)
+	}
+	if q.getUniqueVisitorCountInTimeRangeStmt, err = db.PrepareContext(ctx, getUniqueVisitorCountInTimeRange); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUniqueVisitorCountInTimeRange: %w", err)
+	}
 	if q.listAllURLsStmt, err = db.PrepareContext(ctx, listAllURLs); err != nil {
 		return nil, fmt.Errorf("error preparing query ListAllURLs: %w", err)
 	}
+	if q.listAuditEntriesByShortCodeStmt, err = db.PrepareContext(ctx, listAuditEntriesByShortCode); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAuditEntriesByShortCode: %w", err)
+	}
+	if q.listClicksByURLStmt, err = db.PrepareContext(ctx, listClicksByURL); err != nil {
+		return nil, fmt.Errorf("error preparing query ListClicksByURL: %w", err)
+	}
 	if q.listURLsStmt, err = db.PrepareContext(ctx, listURLs); err != nil {
 		return nil, fmt.Errorf("error preparing query ListURLs: %w", err)
 	}
@@ -78,6 +153,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.recordClickStmt, err = db.PrepareContext(ctx, recordClick); err != nil {
 		return nil, fmt.Errorf("error preparing query RecordClick: %w", err)
 	}
+	if q.restoreURLByShortCodeStmt, err = db.PrepareContext(ctx, restoreURLByShortCode); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreURLByShortCode: %w", err)
+	}
+	if q.searchAllURLsStmt, err = db.PrepareContext(ctx, searchAllURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchAllURLs: %w", err)
+	}
+	if q.searchURLsStmt, err = db.PrepareContext(ctx, searchURLs); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchURLs: %w", err)
+	}
+	if q.softDeleteURLByShortCodeStmt, err = db.PrepareContext(ctx, softDeleteURLByShortCode); err != nil {
+		return nil, fmt.Errorf("error preparing query SoftDeleteURLByShortCode: %w", err)
+	}
+	if q.softDeleteURLsByCreatedByAndShortCodePrefixStmt, err = db.PrepareContext(ctx, softDeleteURLsByCreatedByAndShortCodePrefix); err != nil {
+		return nil, fmt.Errorf("error preparing query SoftDeleteURLsByCreatedByAndShortCodePrefix: %w", err)
+	}
 	if q.upsertURLStatusStmt, err = db.PrepareContext(ctx, upsertURLStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertURLStatus: %w", err)
 	}
@@ -86,6 +176,16 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 
 func (q *Queries) Close() error {
 	var err error
+	if q.completeIdempotencyKeyStmt != nil {
+		if cerr := q.completeIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.countAllURLsStmt != nil {
+		if cerr := q.countAllURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countAllURLsStmt: %w", cerr)
+		}
+	}
 	if q.countURLsStmt != nil {
 		if cerr := q.countURLsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countURLsStmt: %w", cerr)
@@ -96,16 +196,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing countURLsByCreatedByStmt: %w", cerr)
 		}
 	}
+	if q.countURLsByCreatedByMatchingStmt != nil {
+		if cerr := q.countURLsByCreatedByMatchingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countURLsByCreatedByMatchingStmt: %w", cerr)
+		}
+	}
+	if q.countURLsMatchingStmt != nil {
+		if cerr := q.countURLsMatchingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countURLsMatchingStmt: %w", cerr)
+		}
+	}
+	if q.createAuditEntryStmt != nil {
+		if cerr := q.createAuditEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAuditEntryStmt: %w", cerr)
+		}
+	}
+	if q.createIdempotencyKeyStmt != nil {
+		if cerr := q.createIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createIdempotencyKeyStmt: %w", cerr)
+		}
+	}
 	if q.createURLStmt != nil {
 		if cerr := q.createURLStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createURLStmt: %w", cerr)
 		}
 	}
+	if q.deleteClicksOlderThanStmt != nil {
+		if cerr := q.deleteClicksOlderThanStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteClicksOlderThanStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredIdempotencyKeysStmt != nil {
+		if cerr := q.deleteExpiredIdempotencyKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredIdempotencyKeysStmt: %w", cerr)
+		}
+	}
+	if q.deleteIdempotencyKeyStmt != nil {
+		if cerr := q.deleteIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIdempotencyKeyStmt: %w", cerr)
+		}
+	}
 	if q.deleteURLByShortCodeStmt != nil {
 		if cerr := q.deleteURLByShortCodeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteURLByShortCodeStmt: %w", cerr)
 		}
 	}
+	if q.deleteURLsByCreatedByAndShortCodePrefixStmt != nil {
+		if cerr := q.deleteURLsByCreatedByAndShortCodePrefixStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteURLsByCreatedByAndShortCodePrefixStmt: %w", cerr)
+		}
+	}
+	if q.findIdempotencyKeyStmt != nil {
+		if cerr := q.findIdempotencyKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findIdempotencyKeyStmt: %w", cerr)
+		}
+	}
+	if q.findURLByCreatedByAndOriginalURLStmt != nil {
+		if cerr := q.findURLByCreatedByAndOriginalURLStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findURLByCreatedByAndOriginalURLStmt: %w", cerr)
+		}
+	}
 	if q.findURLByShortCodeStmt != nil {
 		if cerr := q.findURLByShortCodeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findURLByShortCodeStmt: %w", cerr)
@@ -126,16 +276,56 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getClicksByDateStmt: %w", cerr)
 		}
 	}
+	if q.getClicksByDateInTimeRangeStmt != nil {
+		if cerr := q.getClicksByDateInTimeRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClicksByDateInTimeRangeStmt: %w", cerr)
+		}
+	}
+	if q.getClicksByHourInTimeRangeStmt != nil {
+		if cerr := q.getClicksByHourInTimeRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClicksByHourInTimeRangeStmt: %w", cerr)
+		}
+	}
 	if q.getClicksByReferrerStmt != nil {
 		if cerr := q.getClicksByReferrerStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getClicksByReferrerStmt: %w", cerr)
 		}
 	}
+	if q.getClicksByReferrerDomainStmt != nil {
+		if cerr := q.getClicksByReferrerDomainStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClicksByReferrerDomainStmt: %w", cerr)
+		}
+	}
+	if q.getClicksByReferrerDomainInTimeRangeStmt != nil {
+		if cerr := q.getClicksByReferrerDomainInTimeRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClicksByReferrerDomainInTimeRangeStmt: %w", cerr)
+		}
+	}
 	if q.getClicksByReferrerInTimeRangeStmt != nil {
 		if cerr := q.getClicksByReferrerInTimeRangeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getClicksByReferrerInTimeRangeStmt: %w", cerr)
 		}
 	}
+	if q.getClicksByUserAgentStmt != nil {
+		if cerr := q.getClicksByUserAgentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClicksByUserAgentStmt: %w", cerr)
+		}
+	}
+	if q.getClicksByUserAgentInTimeRangeStmt != nil {
+		if cerr := q.getClicksByUserAgentInTimeRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getClicksByUserAgentInTimeRangeStmt: %w", cerr)
+		}
+	}
+	if q.getDailyClicksInRangeStmt != nil {
+		if cerr := q.getDailyClicksInRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDailyClicksInRangeStmt: %w", cerr)
+		}
+	}
+	if q.getTopURLsByCreatedByStmt != nil {
+		if cerr := q.getTopURLsByCreatedByStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTopURLsByCreatedByStmt: %w", cerr)
+		}
+	}
 	if q.getTotalClickCountStmt != nil {
 		if cerr := q.getTotalClickCountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getTotalClickCountStmt: %w", cerr)
@@ -146,16 +336,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getTotalClickCountInTimeRangeStmt: %w", cerr)
 		}
 	}
+	if q.getTotalClicksByCreatedByStmt != nil {
+		if cerr := q.getTotalClicksByCreatedByStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTotalClicksByCreatedByStmt: %w", cerr)
+		}
+	}
 	if q.getURLStatusByURLIDStmt != nil {
 		if cerr := q.getURLStatusByURLIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getURLStatusByURLIDStmt: %w", cerr)
 		}
 	}
+	if q.getUniqueVisitorCountStmt != nil {
+		if cerr := q.getUniqueVisitorCountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUniqueVisitorCountStmt: %w", cerr)
+		}
+	}
+	if q.getUniqueVisitorCountInTimeRangeStmt != nil {
+		if cerr := q.getUniqueVisitorCountInTimeRangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUniqueVisitorCountInTimeRangeStmt: %w", cerr)
+		}
+	}
 	if q.listAllURLsStmt != nil {
 		if cerr := q.listAllURLsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listAllURLsStmt: %w", cerr)
 		}
 	}
+	if q.listAuditEntriesByShortCodeStmt != nil {
+		if cerr := q.listAuditEntriesByShortCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAuditEntriesByShortCodeStmt: %w", cerr)
+		}
+	}
+	if q.listClicksByURLStmt != nil {
+		if cerr := q.listClicksByURLStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listClicksByURLStmt: %w", cerr)
+		}
+	}
 	if q.listURLsStmt != nil {
 		if cerr := q.listURLsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listURLsStmt: %w", cerr)
@@ -176,6 +391,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing recordClickStmt: %w", cerr)
 		}
 	}
+	if q.restoreURLByShortCodeStmt != nil {
+		if cerr := q.restoreURLByShortCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreURLByShortCodeStmt: %w", cerr)
+		}
+	}
+	if q.searchAllURLsStmt != nil {
+		if cerr := q.searchAllURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchAllURLsStmt: %w", cerr)
+		}
+	}
+	if q.searchURLsStmt != nil {
+		if cerr := q.searchURLsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchURLsStmt: %w", cerr)
+		}
+	}
+	if q.softDeleteURLByShortCodeStmt != nil {
+		if cerr := q.softDeleteURLByShortCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing softDeleteURLByShortCodeStmt: %w", cerr)
+		}
+	}
+	if q.softDeleteURLsByCreatedByAndShortCodePrefixStmt != nil {
+		if cerr := q.softDeleteURLsByCreatedByAndShortCodePrefixStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing softDeleteURLsByCreatedByAndShortCodePrefixStmt: %w", cerr)
+		}
+	}
 	if q.upsertURLStatusStmt != nil {
 		if cerr := q.upsertURLStatusStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertURLStatusStmt: %w", cerr)
@@ -218,51 +458,111 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                  DBTX
-	tx                                  *sql.Tx
-	countURLsStmt                       *sql.Stmt
-	countURLsByCreatedByStmt            *sql.Stmt
-	createURLStmt                       *sql.Stmt
-	deleteURLByShortCodeStmt            *sql.Stmt
-	findURLByShortCodeStmt              *sql.Stmt
-	getClicksByCountryStmt              *sql.Stmt
-	getClicksByCountryInTimeRangeStmt   *sql.Stmt
-	getClicksByDateStmt                 *sql.Stmt
-	getClicksByReferrerStmt             *sql.Stmt
-	getClicksByReferrerInTimeRangeStmt  *sql.Stmt
-	getTotalClickCountStmt              *sql.Stmt
-	getTotalClickCountInTimeRangeStmt   *sql.Stmt
-	getURLStatusByURLIDStmt             *sql.Stmt
-	listAllURLsStmt                     *sql.Stmt
-	listURLsStmt                        *sql.Stmt
-	listURLsByCreatedByAndTimeRangeStmt *sql.Stmt
-	listURLsDueForStatusCheckStmt       *sql.Stmt
-	recordClickStmt                     *sql.Stmt
-	upsertURLStatusStmt                 *sql.Stmt
+	db                                              DBTX
+	tx                                              *sql.Tx
+	completeIdempotencyKeyStmt                      *sql.Stmt
+	countAllURLsStmt                                *sql.Stmt
+	countURLsStmt                                   *sql.Stmt
+	countURLsByCreatedByStmt                        *sql.Stmt
+	countURLsByCreatedByMatchingStmt                *sql.Stmt
+	countURLsMatchingStmt                           *sql.Stmt
+	createAuditEntryStmt                            *sql.Stmt
+	createIdempotencyKeyStmt                        *sql.Stmt
+	createURLStmt                                   *sql.Stmt
+	deleteClicksOlderThanStmt                       *sql.Stmt
+	deleteExpiredIdempotencyKeysStmt                *sql.Stmt
+	deleteIdempotencyKeyStmt                        *sql.Stmt
+	deleteURLByShortCodeStmt                        *sql.Stmt
+	deleteURLsByCreatedByAndShortCodePrefixStmt     *sql.Stmt
+	findIdempotencyKeyStmt                          *sql.Stmt
+	findURLByCreatedByAndOriginalURLStmt            *sql.Stmt
+	findURLByShortCodeStmt                          *sql.Stmt
+	getClicksByCountryStmt                          *sql.Stmt
+	getClicksByCountryInTimeRangeStmt               *sql.Stmt
+	getClicksByDateStmt                             *sql.Stmt
+	getClicksByDateInTimeRangeStmt                  *sql.Stmt
+	getClicksByHourInTimeRangeStmt                  *sql.Stmt
+	getClicksByReferrerStmt                         *sql.Stmt
+	getClicksByReferrerDomainStmt                   *sql.Stmt
+	getClicksByReferrerDomainInTimeRangeStmt        *sql.Stmt
+	getClicksByReferrerInTimeRangeStmt              *sql.Stmt
+	getClicksByUserAgentStmt                        *sql.Stmt
+	getClicksByUserAgentInTimeRangeStmt             *sql.Stmt
+	getDailyClicksInRangeStmt                       *sql.Stmt
+	getTopURLsByCreatedByStmt                       *sql.Stmt
+	getTotalClickCountStmt                          *sql.Stmt
+	getTotalClickCountInTimeRangeStmt               *sql.Stmt
+	getTotalClicksByCreatedByStmt                   *sql.Stmt
+	getURLStatusByURLIDStmt                         *sql.Stmt
+	getUniqueVisitorCountStmt                       *sql.Stmt
+	getUniqueVisitorCountInTimeRangeStmt            *sql.Stmt
+	listAllURLsStmt                                 *sql.Stmt
+	listAuditEntriesByShortCodeStmt                 *sql.Stmt
+	listClicksByURLStmt                             *sql.Stmt
+	listURLsStmt                                    *sql.Stmt
+	listURLsByCreatedByAndTimeRangeStmt             *sql.Stmt
+	listURLsDueForStatusCheckStmt                   *sql.Stmt
+	recordClickStmt                                 *sql.Stmt
+	restoreURLByShortCodeStmt                       *sql.Stmt
+	searchAllURLsStmt                               *sql.Stmt
+	searchURLsStmt                                  *sql.Stmt
+	softDeleteURLByShortCodeStmt                    *sql.Stmt
+	softDeleteURLsByCreatedByAndShortCodePrefixStmt *sql.Stmt
+	upsertURLStatusStmt                             *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                  tx,
-		tx:                                  tx,
-		countURLsStmt:                       q.countURLsStmt,
-		countURLsByCreatedByStmt:            q.countURLsByCreatedByStmt,
-		createURLStmt:                       q.createURLStmt,
-		deleteURLByShortCodeStmt:            q.deleteURLByShortCodeStmt,
-		findURLByShortCodeStmt:              q.findURLByShortCodeStmt,
-		getClicksByCountryStmt:              q.getClicksByCountryStmt,
-		getClicksByCountryInTimeRangeStmt:   q.getClicksByCountryInTimeRangeStmt,
-		getClicksByDateStmt:                 q.getClicksByDateStmt,
-		getClicksByReferrerStmt:             q.getClicksByReferrerStmt,
-		getClicksByReferrerInTimeRangeStmt:  q.getClicksByReferrerInTimeRangeStmt,
-		getTotalClickCountStmt:              q.getTotalClickCountStmt,
-		getTotalClickCountInTimeRangeStmt:   q.getTotalClickCountInTimeRangeStmt,
-		getURLStatusByURLIDStmt:             q.getURLStatusByURLIDStmt,
-		listAllURLsStmt:                     q.listAllURLsStmt,
-		listURLsStmt:                        q.listURLsStmt,
-		listURLsByCreatedByAndTimeRangeStmt: q.listURLsByCreatedByAndTimeRangeStmt,
-		listURLsDueForStatusCheckStmt:       q.listURLsDueForStatusCheckStmt,
-		recordClickStmt:                     q.recordClickStmt,
-		upsertURLStatusStmt:                 q.upsertURLStatusStmt,
+		db:                               tx,
+		tx:                               tx,
+		completeIdempotencyKeyStmt:       q.completeIdempotencyKeyStmt,
+		countAllURLsStmt:                 q.countAllURLsStmt,
+		countURLsStmt:                    q.countURLsStmt,
+		countURLsByCreatedByStmt:         q.countURLsByCreatedByStmt,
+		countURLsByCreatedByMatchingStmt: q.countURLsByCreatedByMatchingStmt,
+		countURLsMatchingStmt:            q.countURLsMatchingStmt,
+		createAuditEntryStmt:             q.createAuditEntryStmt,
+		createIdempotencyKeyStmt:         q.createIdempotencyKeyStmt,
+		createURLStmt:                    q.createURLStmt,
+		deleteClicksOlderThanStmt:        q.deleteClicksOlderThanStmt,
+		deleteExpiredIdempotencyKeysStmt: q.deleteExpiredIdempotencyKeysStmt,
+		deleteIdempotencyKeyStmt:         q.deleteIdempotencyKeyStmt,
+		deleteURLByShortCodeStmt:         q.deleteURLByShortCodeStmt,
+		deleteURLsByCreatedByAndShortCodePrefixStmt:     q.deleteURLsByCreatedByAndShortCodePrefixStmt,
+		findIdempotencyKeyStmt:                          q.findIdempotencyKeyStmt,
+		findURLByCreatedByAndOriginalURLStmt:            q.findURLByCreatedByAndOriginalURLStmt,
+		findURLByShortCodeStmt:                          q.findURLByShortCodeStmt,
+		getClicksByCountryStmt:                          q.getClicksByCountryStmt,
+		getClicksByCountryInTimeRangeStmt:               q.getClicksByCountryInTimeRangeStmt,
+		getClicksByDateStmt:                             q.getClicksByDateStmt,
+		getClicksByDateInTimeRangeStmt:                  q.getClicksByDateInTimeRangeStmt,
+		getClicksByHourInTimeRangeStmt:                  q.getClicksByHourInTimeRangeStmt,
+		getClicksByReferrerStmt:                         q.getClicksByReferrerStmt,
+		getClicksByReferrerDomainStmt:                   q.getClicksByReferrerDomainStmt,
+		getClicksByReferrerDomainInTimeRangeStmt:        q.getClicksByReferrerDomainInTimeRangeStmt,
+		getClicksByReferrerInTimeRangeStmt:              q.getClicksByReferrerInTimeRangeStmt,
+		getClicksByUserAgentStmt:                        q.getClicksByUserAgentStmt,
+		getClicksByUserAgentInTimeRangeStmt:             q.getClicksByUserAgentInTimeRangeStmt,
+		getDailyClicksInRangeStmt:                       q.getDailyClicksInRangeStmt,
+		getTopURLsByCreatedByStmt:                       q.getTopURLsByCreatedByStmt,
+		getTotalClickCountStmt:                          q.getTotalClickCountStmt,
+		getTotalClickCountInTimeRangeStmt:               q.getTotalClickCountInTimeRangeStmt,
+		getTotalClicksByCreatedByStmt:                   q.getTotalClicksByCreatedByStmt,
+		getURLStatusByURLIDStmt:                         q.getURLStatusByURLIDStmt,
+		getUniqueVisitorCountStmt:                       q.getUniqueVisitorCountStmt,
+		getUniqueVisitorCountInTimeRangeStmt:            q.getUniqueVisitorCountInTimeRangeStmt,
+		listAllURLsStmt:                                 q.listAllURLsStmt,
+		listAuditEntriesByShortCodeStmt:                 q.listAuditEntriesByShortCodeStmt,
+		listClicksByURLStmt:                             q.listClicksByURLStmt,
+		listURLsStmt:                                    q.listURLsStmt,
+		listURLsByCreatedByAndTimeRangeStmt:             q.listURLsByCreatedByAndTimeRangeStmt,
+		listURLsDueForStatusCheckStmt:                   q.listURLsDueForStatusCheckStmt,
+		recordClickStmt:                                 q.recordClickStmt,
+		restoreURLByShortCodeStmt:                       q.restoreURLByShortCodeStmt,
+		searchAllURLsStmt:                               q.searchAllURLsStmt,
+		searchURLsStmt:                                  q.searchURLsStmt,
+		softDeleteURLByShortCodeStmt:                    q.softDeleteURLByShortCodeStmt,
+		softDeleteURLsByCreatedByAndShortCodePrefixStmt: q.softDeleteURLsByCreatedByAndShortCodePrefixStmt,
+		upsertURLStatusStmt:                             q.upsertURLStatusStmt,
 	}
 }
@@ -8,6 +8,15 @@ import (
 	"time"
 )
 
+type Audit struct {
+	ID        int64     `json:"id"`
+	Action    string    `json:"action"`
+	ShortCode string    `json:"short_code"`
+	UserID    string    `json:"user_id"`
+	IpAddress string    `json:"ip_address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Click struct {
 	ID             int64     `json:"id"`
 	UrlID          int64     `json:"url_id"`
@@ -16,14 +25,24 @@ type Click struct {
 	Country        *string   `json:"country"`
 	UserAgent      *string   `json:"user_agent"`
 	ReferrerDomain *string   `json:"referrer_domain"`
+	VisitorHash    *string   `json:"visitor_hash"`
+}
+
+type IdempotencyKey struct {
+	CreatedBy      string    `json:"created_by"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	OriginalUrl    string    `json:"original_url"`
+	ShortCode      string    `json:"short_code"`
+	CreatedAt      time.Time `json:"created_at"`
 }
 
 type Url struct {
-	ID          int64     `json:"id"`
-	ShortCode   string    `json:"short_code"`
-	OriginalUrl string    `json:"original_url"`
-	CreatedAt   time.Time `json:"created_at"`
-	CreatedBy   string    `json:"created_by"`
+	ID          int64      `json:"id"`
+	ShortCode   string     `json:"short_code"`
+	OriginalUrl string     `json:"original_url"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CreatedBy   string     `json:"created_by"`
+	DeletedAt   *time.Time `json:"deleted_at"`
 }
 
 type UrlStatus struct {
@@ -6,29 +6,61 @@ package sqliterepo
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
+	CountAllURLs(ctx context.Context) (int64, error)
 	CountURLs(ctx context.Context) (int64, error)
 	CountURLsByCreatedBy(ctx context.Context, createdBy string) (int64, error)
+	CountURLsByCreatedByMatching(ctx context.Context, arg CountURLsByCreatedByMatchingParams) (int64, error)
+	CountURLsMatching(ctx context.Context, arg CountURLsMatchingParams) (int64, error)
+	// ============================================================================
+	// Audit Queries
+	// ============================================================================
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error
+	// ============================================================================
+	// Idempotency Key Queries
+	// ============================================================================
+	CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) error
 	// ============================================================================
 	// URL Queries
 	// ============================================================================
 	CreateURL(ctx context.Context, arg CreateURLParams) (Url, error)
+	DeleteClicksOlderThan(ctx context.Context, clickedAt time.Time) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context, arg DeleteExpiredIdempotencyKeysParams) error
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
 	DeleteURLByShortCode(ctx context.Context, shortCode string) error
+	DeleteURLsByCreatedByAndShortCodePrefix(ctx context.Context, arg DeleteURLsByCreatedByAndShortCodePrefixParams) ([]string, error)
+	FindIdempotencyKey(ctx context.Context, arg FindIdempotencyKeyParams) (IdempotencyKey, error)
+	FindURLByCreatedByAndOriginalURL(ctx context.Context, arg FindURLByCreatedByAndOriginalURLParams) (Url, error)
 	FindURLByShortCode(ctx context.Context, shortCode string) (Url, error)
 	GetClicksByCountry(ctx context.Context, urlID int64) ([]GetClicksByCountryRow, error)
 	GetClicksByCountryInTimeRange(ctx context.Context, arg GetClicksByCountryInTimeRangeParams) ([]GetClicksByCountryInTimeRangeRow, error)
 	GetClicksByDate(ctx context.Context, urlID int64) ([]GetClicksByDateRow, error)
-	GetClicksByReferrer(ctx context.Context, urlID int64) ([]GetClicksByReferrerRow, error)
+	GetClicksByDateInTimeRange(ctx context.Context, arg GetClicksByDateInTimeRangeParams) ([]GetClicksByDateInTimeRangeRow, error)
+	GetClicksByHourInTimeRange(ctx context.Context, arg GetClicksByHourInTimeRangeParams) ([]GetClicksByHourInTimeRangeRow, error)
+	GetClicksByReferrer(ctx context.Context, arg GetClicksByReferrerParams) ([]GetClicksByReferrerRow, error)
+	GetClicksByReferrerDomain(ctx context.Context, arg GetClicksByReferrerDomainParams) ([]GetClicksByReferrerDomainRow, error)
+	GetClicksByReferrerDomainInTimeRange(ctx context.Context, arg GetClicksByReferrerDomainInTimeRangeParams) ([]GetClicksByReferrerDomainInTimeRangeRow, error)
 	GetClicksByReferrerInTimeRange(ctx context.Context, arg GetClicksByReferrerInTimeRangeParams) ([]GetClicksByReferrerInTimeRangeRow, error)
+	GetClicksByUserAgent(ctx context.Context, urlID int64) ([]GetClicksByUserAgentRow, error)
+	GetClicksByUserAgentInTimeRange(ctx context.Context, arg GetClicksByUserAgentInTimeRangeParams) ([]GetClicksByUserAgentInTimeRangeRow, error)
+	GetDailyClicksInRange(ctx context.Context, arg GetDailyClicksInRangeParams) ([]GetDailyClicksInRangeRow, error)
+	GetTopURLsByCreatedBy(ctx context.Context, arg GetTopURLsByCreatedByParams) ([]GetTopURLsByCreatedByRow, error)
 	GetTotalClickCount(ctx context.Context, urlID int64) (int64, error)
 	GetTotalClickCountInTimeRange(ctx context.Context, arg GetTotalClickCountInTimeRangeParams) (int64, error)
+	GetTotalClicksByCreatedBy(ctx context.Context, createdBy string) (int64, error)
 	// ============================================================================
 	// URL Status Queries
 	// ============================================================================
 	GetURLStatusByURLID(ctx context.Context, urlID int64) (UrlStatus, error)
+	GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error)
+	GetUniqueVisitorCountInTimeRange(ctx context.Context, arg GetUniqueVisitorCountInTimeRangeParams) (int64, error)
 	ListAllURLs(ctx context.Context, arg ListAllURLsParams) ([]Url, error)
+	ListAuditEntriesByShortCode(ctx context.Context, arg ListAuditEntriesByShortCodeParams) ([]Audit, error)
+	ListClicksByURL(ctx context.Context, arg ListClicksByURLParams) ([]ListClicksByURLRow, error)
 	ListURLs(ctx context.Context, arg ListURLsParams) ([]Url, error)
 	ListURLsByCreatedByAndTimeRange(ctx context.Context, arg ListURLsByCreatedByAndTimeRangeParams) ([]Url, error)
 	ListURLsDueForStatusCheck(ctx context.Context, arg ListURLsDueForStatusCheckParams) ([]ListURLsDueForStatusCheckRow, error)
@@ -36,6 +68,11 @@ type Querier interface {
 	// Click Queries
 	// ============================================================================
 	RecordClick(ctx context.Context, arg RecordClickParams) (RecordClickRow, error)
+	RestoreURLByShortCode(ctx context.Context, shortCode string) (int64, error)
+	SearchAllURLs(ctx context.Context, arg SearchAllURLsParams) ([]Url, error)
+	SearchURLs(ctx context.Context, arg SearchURLsParams) ([]Url, error)
+	SoftDeleteURLByShortCode(ctx context.Context, arg SoftDeleteURLByShortCodeParams) (int64, error)
+	SoftDeleteURLsByCreatedByAndShortCodePrefix(ctx context.Context, arg SoftDeleteURLsByCreatedByAndShortCodePrefixParams) ([]string, error)
 	UpsertURLStatus(ctx context.Context, arg UpsertURLStatusParams) error
 }
 
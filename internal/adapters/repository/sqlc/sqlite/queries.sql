@@ -5,10 +5,17 @@
 -- name: CreateURL :one
 INSERT INTO urls (short_code, original_url, created_at, created_by)
 VALUES (?, ?, ?, ?)
-RETURNING id, short_code, original_url, created_at, created_by;
+RETURNING id, short_code, original_url, created_at, created_by, deleted_at;
+
+-- name: FindURLByCreatedByAndOriginalURL :one
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
+FROM urls
+WHERE created_by = ? AND original_url = ? AND deleted_at IS NULL
+ORDER BY id ASC
+LIMIT 1;
 
 -- name: FindURLByShortCode :one
-SELECT id, short_code, original_url, created_at, created_by
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
 FROM urls
 WHERE short_code = ?;
 
@@ -16,35 +23,91 @@ WHERE short_code = ?;
 DELETE FROM urls
 WHERE short_code = ?;
 
+-- name: DeleteURLsByCreatedByAndShortCodePrefix :many
+DELETE FROM urls
+WHERE created_by = ? AND short_code GLOB ?
+RETURNING short_code;
+
+-- name: SoftDeleteURLByShortCode :execrows
+UPDATE urls
+SET deleted_at = ?
+WHERE short_code = ? AND deleted_at IS NULL;
+
+-- name: SoftDeleteURLsByCreatedByAndShortCodePrefix :many
+UPDATE urls
+SET deleted_at = ?
+WHERE created_by = ? AND short_code GLOB ? AND deleted_at IS NULL
+RETURNING short_code;
+
+-- name: RestoreURLByShortCode :execrows
+UPDATE urls
+SET deleted_at = NULL
+WHERE short_code = ?;
+
 -- name: ListURLs :many
-SELECT id, short_code, original_url, created_at, created_by
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
 FROM urls
-WHERE created_by = ?
+WHERE created_by = ? AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?;
 
 -- name: ListAllURLs :many
-SELECT id, short_code, original_url, created_at, created_by
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
 FROM urls
+WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?;
 
 -- name: ListURLsByCreatedByAndTimeRange :many
-SELECT id, short_code, original_url, created_at, created_by
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
 FROM urls
 WHERE created_by = ?
+  AND deleted_at IS NULL
   AND created_at >= ?
   AND created_at <= ?
 ORDER BY created_at DESC;
 
--- name: CountURLs :one
+-- name: CountAllURLs :one
 SELECT COUNT(*) as count
 FROM urls;
 
+-- name: CountURLs :one
+SELECT COUNT(*) as count
+FROM urls
+WHERE deleted_at IS NULL;
+
 -- name: CountURLsByCreatedBy :one
 SELECT COUNT(*) as count
 FROM urls
-WHERE created_by = ?;
+WHERE created_by = ? AND deleted_at IS NULL;
+
+-- name: SearchURLs :many
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
+FROM urls
+WHERE created_by = ? AND deleted_at IS NULL
+  AND (original_url LIKE ? ESCAPE '\' OR short_code LIKE ? ESCAPE '\')
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?;
+
+-- name: SearchAllURLs :many
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
+FROM urls
+WHERE deleted_at IS NULL
+  AND (original_url LIKE ? ESCAPE '\' OR short_code LIKE ? ESCAPE '\')
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?;
+
+-- name: CountURLsByCreatedByMatching :one
+SELECT COUNT(*) as count
+FROM urls
+WHERE created_by = ? AND deleted_at IS NULL
+  AND (original_url LIKE ? ESCAPE '\' OR short_code LIKE ? ESCAPE '\');
+
+-- name: CountURLsMatching :one
+SELECT COUNT(*) as count
+FROM urls
+WHERE deleted_at IS NULL
+  AND (original_url LIKE ? ESCAPE '\' OR short_code LIKE ? ESCAPE '\');
 
 -- ============================================================================
 -- URL Status Queries
@@ -83,9 +146,12 @@ SELECT
     us.archive_checked_at
 FROM urls u
 LEFT JOIN url_status us ON us.url_id = u.id
-WHERE us.last_checked_at IS NULL
-   OR (us.gone_at IS NULL AND us.last_checked_at <= ?)
-   OR (us.gone_at IS NOT NULL AND us.last_checked_at <= ?)
+WHERE u.deleted_at IS NULL
+  AND (
+    us.last_checked_at IS NULL
+    OR (us.gone_at IS NULL AND us.last_checked_at <= ?)
+    OR (us.gone_at IS NOT NULL AND us.last_checked_at <= ?)
+  )
 ORDER BY COALESCE(us.last_checked_at, u.created_at) ASC
 LIMIT ?;
 
@@ -94,9 +160,9 @@ LIMIT ?;
 -- ============================================================================
 
 -- name: RecordClick :one
-INSERT INTO clicks (url_id, clicked_at, referrer, referrer_domain, country, user_agent)
-VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, url_id, clicked_at, referrer, referrer_domain, country, user_agent;
+INSERT INTO clicks (url_id, clicked_at, referrer, referrer_domain, country, user_agent, visitor_hash)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, url_id, clicked_at, referrer, referrer_domain, country, user_agent, visitor_hash;
 
 -- name: GetTotalClickCount :one
 SELECT COUNT(*) as count
@@ -119,8 +185,18 @@ WHERE url_id = ?
   AND referrer IS NOT NULL
   AND referrer != ''
 GROUP BY referrer
-ORDER BY count DESC
-LIMIT 10;
+ORDER BY count DESC, referrer ASC
+LIMIT ?;
+
+-- name: GetClicksByReferrerDomain :many
+SELECT referrer_domain, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND referrer_domain IS NOT NULL
+  AND referrer_domain != ''
+GROUP BY referrer_domain
+ORDER BY count DESC, referrer_domain ASC
+LIMIT ?;
 
 -- name: GetClicksByDate :many
 SELECT CAST(DATE(clicked_at) AS TEXT) as date, COUNT(*) as count
@@ -129,6 +205,36 @@ WHERE url_id = ?
 GROUP BY date
 ORDER BY date DESC;
 
+-- name: GetClicksByDateInTimeRange :many
+SELECT CAST(DATE(clicked_at) AS TEXT) as date, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+GROUP BY date
+ORDER BY date DESC;
+
+-- name: GetClicksByHourInTimeRange :many
+SELECT CAST(strftime('%Y-%m-%d %H:00', clicked_at) AS TEXT) as hour, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+GROUP BY hour
+ORDER BY hour DESC;
+
+-- name: GetUniqueVisitorCount :one
+SELECT COUNT(DISTINCT visitor_hash) as count
+FROM clicks
+WHERE url_id = ?;
+
+-- name: GetUniqueVisitorCountInTimeRange :one
+SELECT COUNT(DISTINCT visitor_hash) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?;
+
 -- name: GetTotalClickCountInTimeRange :one
 SELECT COUNT(*) as count
 FROM clicks
@@ -156,5 +262,109 @@ WHERE url_id = ?
   AND referrer IS NOT NULL
   AND referrer != ''
 GROUP BY referrer
-ORDER BY count DESC
-LIMIT 10;
+ORDER BY count DESC, referrer ASC
+LIMIT ?;
+
+-- name: GetClicksByReferrerDomainInTimeRange :many
+SELECT referrer_domain, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+  AND referrer_domain IS NOT NULL
+  AND referrer_domain != ''
+GROUP BY referrer_domain
+ORDER BY count DESC, referrer_domain ASC
+LIMIT ?;
+
+-- name: GetClicksByUserAgent :many
+SELECT user_agent, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+GROUP BY user_agent;
+
+-- name: GetClicksByUserAgentInTimeRange :many
+SELECT user_agent, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+GROUP BY user_agent;
+
+-- name: GetTotalClicksByCreatedBy :one
+SELECT COUNT(*) as count
+FROM clicks
+JOIN urls ON urls.id = clicks.url_id
+WHERE urls.created_by = ?
+  AND urls.deleted_at IS NULL;
+
+-- name: GetTopURLsByCreatedBy :many
+SELECT urls.id, urls.short_code, urls.original_url, urls.created_at, urls.created_by, COUNT(clicks.id) as click_count
+FROM urls
+LEFT JOIN clicks ON clicks.url_id = urls.id
+WHERE urls.created_by = ?
+  AND urls.deleted_at IS NULL
+GROUP BY urls.id
+ORDER BY click_count DESC, urls.created_at DESC, urls.id DESC
+LIMIT ?;
+
+-- name: GetDailyClicksInRange :many
+SELECT CAST(DATE(clicked_at) AS TEXT) as date, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at < ?
+GROUP BY date
+ORDER BY date ASC;
+
+-- name: DeleteClicksOlderThan :execrows
+DELETE FROM clicks
+WHERE clicked_at < ?;
+
+-- name: ListClicksByURL :many
+SELECT id, clicked_at, referrer_domain, country, user_agent
+FROM clicks
+WHERE url_id = ?
+ORDER BY clicked_at DESC, id DESC
+LIMIT ? OFFSET ?;
+
+-- ============================================================================
+-- Idempotency Key Queries
+-- ============================================================================
+
+-- name: CreateIdempotencyKey :exec
+INSERT INTO idempotency_keys (created_by, idempotency_key, original_url, short_code, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: FindIdempotencyKey :one
+SELECT created_by, idempotency_key, original_url, short_code, created_at
+FROM idempotency_keys
+WHERE created_by = ? AND idempotency_key = ? AND created_at >= ?;
+
+-- name: DeleteExpiredIdempotencyKeys :exec
+DELETE FROM idempotency_keys
+WHERE created_by = ? AND created_at < ?;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET short_code = ?
+WHERE created_by = ? AND idempotency_key = ?;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE created_by = ? AND idempotency_key = ?;
+
+-- ============================================================================
+-- Audit Queries
+-- ============================================================================
+
+-- name: CreateAuditEntry :exec
+INSERT INTO audit (action, short_code, user_id, ip_address, created_at)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListAuditEntriesByShortCode :many
+SELECT id, action, short_code, user_id, ip_address, created_at
+FROM audit
+WHERE short_code = ?
+ORDER BY id DESC
+LIMIT ?;
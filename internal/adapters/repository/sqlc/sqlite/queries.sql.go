@@ -10,9 +10,39 @@ import (
 	"time"
 )
 
+const completeIdempotencyKey = `-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET short_code = ?
+WHERE created_by = ? AND idempotency_key = ?
+`
+
+type CompleteIdempotencyKeyParams struct {
+	ShortCode      string `json:"short_code"`
+	CreatedBy      string `json:"created_by"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+func (q *Queries) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error {
+	_, err := q.exec(ctx, q.completeIdempotencyKeyStmt, completeIdempotencyKey, arg.ShortCode, arg.CreatedBy, arg.IdempotencyKey)
+	return err
+}
+
+const countAllURLs = `-- name: CountAllURLs :one
+SELECT COUNT(*) as count
+FROM urls
+`
+
+func (q *Queries) CountAllURLs(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countAllURLsStmt, countAllURLs)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countURLs = `-- name: CountURLs :one
 SELECT COUNT(*) as count
 FROM urls
+WHERE deleted_at IS NULL
 `
 
 func (q *Queries) CountURLs(ctx context.Context) (int64, error) {
@@ -25,7 +55,7 @@ func (q *Queries) CountURLs(ctx context.Context) (int64, error) {
 const countURLsByCreatedBy = `-- name: CountURLsByCreatedBy :one
 SELECT COUNT(*) as count
 FROM urls
-WHERE created_by = ?
+WHERE created_by = ? AND deleted_at IS NULL
 `
 
 func (q *Queries) CountURLsByCreatedBy(ctx context.Context, createdBy string) (int64, error) {
@@ -35,11 +65,106 @@ func (q *Queries) CountURLsByCreatedBy(ctx context.Context, createdBy string) (i
 	return count, err
 }
 
+const countURLsByCreatedByMatching = `-- name: CountURLsByCreatedByMatching :one
+SELECT COUNT(*) as count
+FROM urls
+WHERE created_by = ? AND deleted_at IS NULL
+  AND (original_url LIKE ? ESCAPE '\' OR short_code LIKE ? ESCAPE '\')
+`
+
+type CountURLsByCreatedByMatchingParams struct {
+	CreatedBy   string `json:"created_by"`
+	OriginalUrl string `json:"original_url"`
+	ShortCode   string `json:"short_code"`
+}
+
+func (q *Queries) CountURLsByCreatedByMatching(ctx context.Context, arg CountURLsByCreatedByMatchingParams) (int64, error) {
+	row := q.queryRow(ctx, q.countURLsByCreatedByMatchingStmt, countURLsByCreatedByMatching, arg.CreatedBy, arg.OriginalUrl, arg.ShortCode)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countURLsMatching = `-- name: CountURLsMatching :one
+SELECT COUNT(*) as count
+FROM urls
+WHERE deleted_at IS NULL
+  AND (original_url LIKE ? ESCAPE '\' OR short_code LIKE ? ESCAPE '\')
+`
+
+type CountURLsMatchingParams struct {
+	OriginalUrl string `json:"original_url"`
+	ShortCode   string `json:"short_code"`
+}
+
+func (q *Queries) CountURLsMatching(ctx context.Context, arg CountURLsMatchingParams) (int64, error) {
+	row := q.queryRow(ctx, q.countURLsMatchingStmt, countURLsMatching, arg.OriginalUrl, arg.ShortCode)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuditEntry = `-- name: CreateAuditEntry :exec
+
+INSERT INTO audit (action, short_code, user_id, ip_address, created_at)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateAuditEntryParams struct {
+	Action    string    `json:"action"`
+	ShortCode string    `json:"short_code"`
+	UserID    string    `json:"user_id"`
+	IpAddress string    `json:"ip_address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ============================================================================
+// Audit Queries
+// ============================================================================
+func (q *Queries) CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) error {
+	_, err := q.exec(ctx, q.createAuditEntryStmt, createAuditEntry,
+		arg.Action,
+		arg.ShortCode,
+		arg.UserID,
+		arg.IpAddress,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createIdempotencyKey = `-- name: CreateIdempotencyKey :exec
+
+INSERT INTO idempotency_keys (created_by, idempotency_key, original_url, short_code, created_at)
+VALUES (?, ?, ?, ?, ?)
+`
+
+type CreateIdempotencyKeyParams struct {
+	CreatedBy      string    `json:"created_by"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	OriginalUrl    string    `json:"original_url"`
+	ShortCode      string    `json:"short_code"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ============================================================================
+// Idempotency Key Queries
+// ============================================================================
+func (q *Queries) CreateIdempotencyKey(ctx context.Context, arg CreateIdempotencyKeyParams) error {
+	_, err := q.exec(ctx, q.createIdempotencyKeyStmt, createIdempotencyKey,
+		arg.CreatedBy,
+		arg.IdempotencyKey,
+		arg.OriginalUrl,
+		arg.ShortCode,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const createURL = `-- name: CreateURL :one
 
 INSERT INTO urls (short_code, original_url, created_at, created_by)
 VALUES (?, ?, ?, ?)
-RETURNING id, short_code, original_url, created_at, created_by
+RETURNING id, short_code, original_url, created_at, created_by, deleted_at
 `
 
 type CreateURLParams struct {
@@ -66,10 +191,54 @@ func (q *Queries) CreateURL(ctx context.Context, arg CreateURLParams) (Url, erro
 		&i.OriginalUrl,
 		&i.CreatedAt,
 		&i.CreatedBy,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const deleteClicksOlderThan = `-- name: DeleteClicksOlderThan :execrows
+DELETE FROM clicks
+WHERE clicked_at < ?
+`
+
+func (q *Queries) DeleteClicksOlderThan(ctx context.Context, clickedAt time.Time) (int64, error) {
+	result, err := q.exec(ctx, q.deleteClicksOlderThanStmt, deleteClicksOlderThan, clickedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteExpiredIdempotencyKeys = `-- name: DeleteExpiredIdempotencyKeys :exec
+DELETE FROM idempotency_keys
+WHERE created_by = ? AND created_at < ?
+`
+
+type DeleteExpiredIdempotencyKeysParams struct {
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (q *Queries) DeleteExpiredIdempotencyKeys(ctx context.Context, arg DeleteExpiredIdempotencyKeysParams) error {
+	_, err := q.exec(ctx, q.deleteExpiredIdempotencyKeysStmt, deleteExpiredIdempotencyKeys, arg.CreatedBy, arg.CreatedAt)
+	return err
+}
+
+const deleteIdempotencyKey = `-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE created_by = ? AND idempotency_key = ?
+`
+
+type DeleteIdempotencyKeyParams struct {
+	CreatedBy      string `json:"created_by"`
+	IdempotencyKey string `json:"idempotency_key"`
+}
+
+func (q *Queries) DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error {
+	_, err := q.exec(ctx, q.deleteIdempotencyKeyStmt, deleteIdempotencyKey, arg.CreatedBy, arg.IdempotencyKey)
+	return err
+}
+
 const deleteURLByShortCode = `-- name: DeleteURLByShortCode :exec
 DELETE FROM urls
 WHERE short_code = ?
@@ -80,8 +249,94 @@ func (q *Queries) DeleteURLByShortCode(ctx context.Context, shortCode string) er
 	return err
 }
 
+const deleteURLsByCreatedByAndShortCodePrefix = `-- name: DeleteURLsByCreatedByAndShortCodePrefix :many
+DELETE FROM urls
+WHERE created_by = ? AND short_code GLOB ?
+RETURNING short_code
+`
+
+type DeleteURLsByCreatedByAndShortCodePrefixParams struct {
+	CreatedBy string `json:"created_by"`
+	ShortCode string `json:"short_code"`
+}
+
+func (q *Queries) DeleteURLsByCreatedByAndShortCodePrefix(ctx context.Context, arg DeleteURLsByCreatedByAndShortCodePrefixParams) ([]string, error) {
+	rows, err := q.query(ctx, q.deleteURLsByCreatedByAndShortCodePrefixStmt, deleteURLsByCreatedByAndShortCodePrefix, arg.CreatedBy, arg.ShortCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var short_code string
+		if err := rows.Scan(&short_code); err != nil {
+			return nil, err
+		}
+		items = append(items, short_code)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findIdempotencyKey = `-- name: FindIdempotencyKey :one
+SELECT created_by, idempotency_key, original_url, short_code, created_at
+FROM idempotency_keys
+WHERE created_by = ? AND idempotency_key = ? AND created_at >= ?
+`
+
+type FindIdempotencyKeyParams struct {
+	CreatedBy      string    `json:"created_by"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (q *Queries) FindIdempotencyKey(ctx context.Context, arg FindIdempotencyKeyParams) (IdempotencyKey, error) {
+	row := q.queryRow(ctx, q.findIdempotencyKeyStmt, findIdempotencyKey, arg.CreatedBy, arg.IdempotencyKey, arg.CreatedAt)
+	var i IdempotencyKey
+	err := row.Scan(
+		&i.CreatedBy,
+		&i.IdempotencyKey,
+		&i.OriginalUrl,
+		&i.ShortCode,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const findURLByCreatedByAndOriginalURL = `-- name: FindURLByCreatedByAndOriginalURL :one
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
+FROM urls
+WHERE created_by = ? AND original_url = ? AND deleted_at IS NULL
+ORDER BY id ASC
+LIMIT 1
+`
+
+type FindURLByCreatedByAndOriginalURLParams struct {
+	CreatedBy   string `json:"created_by"`
+	OriginalUrl string `json:"original_url"`
+}
+
+func (q *Queries) FindURLByCreatedByAndOriginalURL(ctx context.Context, arg FindURLByCreatedByAndOriginalURLParams) (Url, error) {
+	row := q.queryRow(ctx, q.findURLByCreatedByAndOriginalURLStmt, findURLByCreatedByAndOriginalURL, arg.CreatedBy, arg.OriginalUrl)
+	var i Url
+	err := row.Scan(
+		&i.ID,
+		&i.ShortCode,
+		&i.OriginalUrl,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const findURLByShortCode = `-- name: FindURLByShortCode :one
-SELECT id, short_code, original_url, created_at, created_by
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
 FROM urls
 WHERE short_code = ?
 `
@@ -95,6 +350,7 @@ func (q *Queries) FindURLByShortCode(ctx context.Context, shortCode string) (Url
 		&i.OriginalUrl,
 		&i.CreatedAt,
 		&i.CreatedBy,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -219,6 +475,94 @@ func (q *Queries) GetClicksByDate(ctx context.Context, urlID int64) ([]GetClicks
 	return items, nil
 }
 
+const getClicksByDateInTimeRange = `-- name: GetClicksByDateInTimeRange :many
+SELECT CAST(DATE(clicked_at) AS TEXT) as date, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+GROUP BY date
+ORDER BY date DESC
+`
+
+type GetClicksByDateInTimeRangeParams struct {
+	UrlID       int64     `json:"url_id"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ClickedAt_2 time.Time `json:"clicked_at_2"`
+}
+
+type GetClicksByDateInTimeRangeRow struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) GetClicksByDateInTimeRange(ctx context.Context, arg GetClicksByDateInTimeRangeParams) ([]GetClicksByDateInTimeRangeRow, error) {
+	rows, err := q.query(ctx, q.getClicksByDateInTimeRangeStmt, getClicksByDateInTimeRange, arg.UrlID, arg.ClickedAt, arg.ClickedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClicksByDateInTimeRangeRow{}
+	for rows.Next() {
+		var i GetClicksByDateInTimeRangeRow
+		if err := rows.Scan(&i.Date, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClicksByHourInTimeRange = `-- name: GetClicksByHourInTimeRange :many
+SELECT CAST(strftime('%Y-%m-%d %H:00', clicked_at) AS TEXT) as hour, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+GROUP BY hour
+ORDER BY hour DESC
+`
+
+type GetClicksByHourInTimeRangeParams struct {
+	UrlID       int64     `json:"url_id"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ClickedAt_2 time.Time `json:"clicked_at_2"`
+}
+
+type GetClicksByHourInTimeRangeRow struct {
+	Hour  string `json:"hour"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) GetClicksByHourInTimeRange(ctx context.Context, arg GetClicksByHourInTimeRangeParams) ([]GetClicksByHourInTimeRangeRow, error) {
+	rows, err := q.query(ctx, q.getClicksByHourInTimeRangeStmt, getClicksByHourInTimeRange, arg.UrlID, arg.ClickedAt, arg.ClickedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClicksByHourInTimeRangeRow{}
+	for rows.Next() {
+		var i GetClicksByHourInTimeRangeRow
+		if err := rows.Scan(&i.Hour, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getClicksByReferrer = `-- name: GetClicksByReferrer :many
 SELECT referrer, COUNT(*) as count
 FROM clicks
@@ -226,25 +570,302 @@ WHERE url_id = ?
   AND referrer IS NOT NULL
   AND referrer != ''
 GROUP BY referrer
-ORDER BY count DESC
-LIMIT 10
+ORDER BY count DESC, referrer ASC
+LIMIT ?
 `
 
+type GetClicksByReferrerParams struct {
+	UrlID int64 `json:"url_id"`
+	Limit int64 `json:"limit"`
+}
+
 type GetClicksByReferrerRow struct {
 	Referrer *string `json:"referrer"`
 	Count    int64   `json:"count"`
 }
 
-func (q *Queries) GetClicksByReferrer(ctx context.Context, urlID int64) ([]GetClicksByReferrerRow, error) {
-	rows, err := q.query(ctx, q.getClicksByReferrerStmt, getClicksByReferrer, urlID)
+func (q *Queries) GetClicksByReferrer(ctx context.Context, arg GetClicksByReferrerParams) ([]GetClicksByReferrerRow, error) {
+	rows, err := q.query(ctx, q.getClicksByReferrerStmt, getClicksByReferrer, arg.UrlID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClicksByReferrerRow{}
+	for rows.Next() {
+		var i GetClicksByReferrerRow
+		if err := rows.Scan(&i.Referrer, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClicksByReferrerDomain = `-- name: GetClicksByReferrerDomain :many
+SELECT referrer_domain, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND referrer_domain IS NOT NULL
+  AND referrer_domain != ''
+GROUP BY referrer_domain
+ORDER BY count DESC, referrer_domain ASC
+LIMIT ?
+`
+
+type GetClicksByReferrerDomainParams struct {
+	UrlID int64 `json:"url_id"`
+	Limit int64 `json:"limit"`
+}
+
+type GetClicksByReferrerDomainRow struct {
+	ReferrerDomain *string `json:"referrer_domain"`
+	Count          int64   `json:"count"`
+}
+
+func (q *Queries) GetClicksByReferrerDomain(ctx context.Context, arg GetClicksByReferrerDomainParams) ([]GetClicksByReferrerDomainRow, error) {
+	rows, err := q.query(ctx, q.getClicksByReferrerDomainStmt, getClicksByReferrerDomain, arg.UrlID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClicksByReferrerDomainRow{}
+	for rows.Next() {
+		var i GetClicksByReferrerDomainRow
+		if err := rows.Scan(&i.ReferrerDomain, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClicksByReferrerDomainInTimeRange = `-- name: GetClicksByReferrerDomainInTimeRange :many
+SELECT referrer_domain, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+  AND referrer_domain IS NOT NULL
+  AND referrer_domain != ''
+GROUP BY referrer_domain
+ORDER BY count DESC, referrer_domain ASC
+LIMIT ?
+`
+
+type GetClicksByReferrerDomainInTimeRangeParams struct {
+	UrlID       int64     `json:"url_id"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ClickedAt_2 time.Time `json:"clicked_at_2"`
+	Limit       int64     `json:"limit"`
+}
+
+type GetClicksByReferrerDomainInTimeRangeRow struct {
+	ReferrerDomain *string `json:"referrer_domain"`
+	Count          int64   `json:"count"`
+}
+
+func (q *Queries) GetClicksByReferrerDomainInTimeRange(ctx context.Context, arg GetClicksByReferrerDomainInTimeRangeParams) ([]GetClicksByReferrerDomainInTimeRangeRow, error) {
+	rows, err := q.query(ctx, q.getClicksByReferrerDomainInTimeRangeStmt, getClicksByReferrerDomainInTimeRange,
+		arg.UrlID,
+		arg.ClickedAt,
+		arg.ClickedAt_2,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClicksByReferrerDomainInTimeRangeRow{}
+	for rows.Next() {
+		var i GetClicksByReferrerDomainInTimeRangeRow
+		if err := rows.Scan(&i.ReferrerDomain, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClicksByReferrerInTimeRange = `-- name: GetClicksByReferrerInTimeRange :many
+SELECT referrer, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+  AND referrer IS NOT NULL
+  AND referrer != ''
+GROUP BY referrer
+ORDER BY count DESC, referrer ASC
+LIMIT ?
+`
+
+type GetClicksByReferrerInTimeRangeParams struct {
+	UrlID       int64     `json:"url_id"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ClickedAt_2 time.Time `json:"clicked_at_2"`
+	Limit       int64     `json:"limit"`
+}
+
+type GetClicksByReferrerInTimeRangeRow struct {
+	Referrer *string `json:"referrer"`
+	Count    int64   `json:"count"`
+}
+
+func (q *Queries) GetClicksByReferrerInTimeRange(ctx context.Context, arg GetClicksByReferrerInTimeRangeParams) ([]GetClicksByReferrerInTimeRangeRow, error) {
+	rows, err := q.query(ctx, q.getClicksByReferrerInTimeRangeStmt, getClicksByReferrerInTimeRange,
+		arg.UrlID,
+		arg.ClickedAt,
+		arg.ClickedAt_2,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClicksByReferrerInTimeRangeRow{}
+	for rows.Next() {
+		var i GetClicksByReferrerInTimeRangeRow
+		if err := rows.Scan(&i.Referrer, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClicksByUserAgent = `-- name: GetClicksByUserAgent :many
+SELECT user_agent, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+GROUP BY user_agent
+`
+
+type GetClicksByUserAgentRow struct {
+	UserAgent *string `json:"user_agent"`
+	Count     int64   `json:"count"`
+}
+
+func (q *Queries) GetClicksByUserAgent(ctx context.Context, urlID int64) ([]GetClicksByUserAgentRow, error) {
+	rows, err := q.query(ctx, q.getClicksByUserAgentStmt, getClicksByUserAgent, urlID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClicksByUserAgentRow{}
+	for rows.Next() {
+		var i GetClicksByUserAgentRow
+		if err := rows.Scan(&i.UserAgent, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClicksByUserAgentInTimeRange = `-- name: GetClicksByUserAgentInTimeRange :many
+SELECT user_agent, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+GROUP BY user_agent
+`
+
+type GetClicksByUserAgentInTimeRangeParams struct {
+	UrlID       int64     `json:"url_id"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ClickedAt_2 time.Time `json:"clicked_at_2"`
+}
+
+type GetClicksByUserAgentInTimeRangeRow struct {
+	UserAgent *string `json:"user_agent"`
+	Count     int64   `json:"count"`
+}
+
+func (q *Queries) GetClicksByUserAgentInTimeRange(ctx context.Context, arg GetClicksByUserAgentInTimeRangeParams) ([]GetClicksByUserAgentInTimeRangeRow, error) {
+	rows, err := q.query(ctx, q.getClicksByUserAgentInTimeRangeStmt, getClicksByUserAgentInTimeRange, arg.UrlID, arg.ClickedAt, arg.ClickedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetClicksByUserAgentInTimeRangeRow{}
+	for rows.Next() {
+		var i GetClicksByUserAgentInTimeRangeRow
+		if err := rows.Scan(&i.UserAgent, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDailyClicksInRange = `-- name: GetDailyClicksInRange :many
+SELECT CAST(DATE(clicked_at) AS TEXT) as date, COUNT(*) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at < ?
+GROUP BY date
+ORDER BY date ASC
+`
+
+type GetDailyClicksInRangeParams struct {
+	UrlID       int64     `json:"url_id"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ClickedAt_2 time.Time `json:"clicked_at_2"`
+}
+
+type GetDailyClicksInRangeRow struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+func (q *Queries) GetDailyClicksInRange(ctx context.Context, arg GetDailyClicksInRangeParams) ([]GetDailyClicksInRangeRow, error) {
+	rows, err := q.query(ctx, q.getDailyClicksInRangeStmt, getDailyClicksInRange, arg.UrlID, arg.ClickedAt, arg.ClickedAt_2)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetClicksByReferrerRow{}
+	items := []GetDailyClicksInRangeRow{}
 	for rows.Next() {
-		var i GetClicksByReferrerRow
-		if err := rows.Scan(&i.Referrer, &i.Count); err != nil {
+		var i GetDailyClicksInRangeRow
+		if err := rows.Scan(&i.Date, &i.Count); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -258,40 +879,48 @@ func (q *Queries) GetClicksByReferrer(ctx context.Context, urlID int64) ([]GetCl
 	return items, nil
 }
 
-const getClicksByReferrerInTimeRange = `-- name: GetClicksByReferrerInTimeRange :many
-SELECT referrer, COUNT(*) as count
-FROM clicks
-WHERE url_id = ?
-  AND clicked_at >= ?
-  AND clicked_at <= ?
-  AND referrer IS NOT NULL
-  AND referrer != ''
-GROUP BY referrer
-ORDER BY count DESC
-LIMIT 10
+const getTopURLsByCreatedBy = `-- name: GetTopURLsByCreatedBy :many
+SELECT urls.id, urls.short_code, urls.original_url, urls.created_at, urls.created_by, COUNT(clicks.id) as click_count
+FROM urls
+LEFT JOIN clicks ON clicks.url_id = urls.id
+WHERE urls.created_by = ?
+  AND urls.deleted_at IS NULL
+GROUP BY urls.id
+ORDER BY click_count DESC, urls.created_at DESC, urls.id DESC
+LIMIT ?
 `
 
-type GetClicksByReferrerInTimeRangeParams struct {
-	UrlID       int64     `json:"url_id"`
-	ClickedAt   time.Time `json:"clicked_at"`
-	ClickedAt_2 time.Time `json:"clicked_at_2"`
+type GetTopURLsByCreatedByParams struct {
+	CreatedBy string `json:"created_by"`
+	Limit     int64  `json:"limit"`
 }
 
-type GetClicksByReferrerInTimeRangeRow struct {
-	Referrer *string `json:"referrer"`
-	Count    int64   `json:"count"`
+type GetTopURLsByCreatedByRow struct {
+	ID          int64     `json:"id"`
+	ShortCode   string    `json:"short_code"`
+	OriginalUrl string    `json:"original_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by"`
+	ClickCount  int64     `json:"click_count"`
 }
 
-func (q *Queries) GetClicksByReferrerInTimeRange(ctx context.Context, arg GetClicksByReferrerInTimeRangeParams) ([]GetClicksByReferrerInTimeRangeRow, error) {
-	rows, err := q.query(ctx, q.getClicksByReferrerInTimeRangeStmt, getClicksByReferrerInTimeRange, arg.UrlID, arg.ClickedAt, arg.ClickedAt_2)
+func (q *Queries) GetTopURLsByCreatedBy(ctx context.Context, arg GetTopURLsByCreatedByParams) ([]GetTopURLsByCreatedByRow, error) {
+	rows, err := q.query(ctx, q.getTopURLsByCreatedByStmt, getTopURLsByCreatedBy, arg.CreatedBy, arg.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetClicksByReferrerInTimeRangeRow{}
+	items := []GetTopURLsByCreatedByRow{}
 	for rows.Next() {
-		var i GetClicksByReferrerInTimeRangeRow
-		if err := rows.Scan(&i.Referrer, &i.Count); err != nil {
+		var i GetTopURLsByCreatedByRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShortCode,
+			&i.OriginalUrl,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.ClickCount,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -339,6 +968,21 @@ func (q *Queries) GetTotalClickCountInTimeRange(ctx context.Context, arg GetTota
 	return count, err
 }
 
+const getTotalClicksByCreatedBy = `-- name: GetTotalClicksByCreatedBy :one
+SELECT COUNT(*) as count
+FROM clicks
+JOIN urls ON urls.id = clicks.url_id
+WHERE urls.created_by = ?
+  AND urls.deleted_at IS NULL
+`
+
+func (q *Queries) GetTotalClicksByCreatedBy(ctx context.Context, createdBy string) (int64, error) {
+	row := q.queryRow(ctx, q.getTotalClicksByCreatedByStmt, getTotalClicksByCreatedBy, createdBy)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const getURLStatusByURLID = `-- name: GetURLStatusByURLID :one
 
 SELECT url_id, last_checked_at, last_status_code, gone_at, archive_url, archive_checked_at
@@ -363,9 +1007,44 @@ func (q *Queries) GetURLStatusByURLID(ctx context.Context, urlID int64) (UrlStat
 	return i, err
 }
 
+const getUniqueVisitorCount = `-- name: GetUniqueVisitorCount :one
+SELECT COUNT(DISTINCT visitor_hash) as count
+FROM clicks
+WHERE url_id = ?
+`
+
+func (q *Queries) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	row := q.queryRow(ctx, q.getUniqueVisitorCountStmt, getUniqueVisitorCount, urlID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getUniqueVisitorCountInTimeRange = `-- name: GetUniqueVisitorCountInTimeRange :one
+SELECT COUNT(DISTINCT visitor_hash) as count
+FROM clicks
+WHERE url_id = ?
+  AND clicked_at >= ?
+  AND clicked_at <= ?
+`
+
+type GetUniqueVisitorCountInTimeRangeParams struct {
+	UrlID       int64     `json:"url_id"`
+	ClickedAt   time.Time `json:"clicked_at"`
+	ClickedAt_2 time.Time `json:"clicked_at_2"`
+}
+
+func (q *Queries) GetUniqueVisitorCountInTimeRange(ctx context.Context, arg GetUniqueVisitorCountInTimeRangeParams) (int64, error) {
+	row := q.queryRow(ctx, q.getUniqueVisitorCountInTimeRangeStmt, getUniqueVisitorCountInTimeRange, arg.UrlID, arg.ClickedAt, arg.ClickedAt_2)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const listAllURLs = `-- name: ListAllURLs :many
-SELECT id, short_code, original_url, created_at, created_by
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
 FROM urls
+WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?
 `
@@ -390,6 +1069,101 @@ func (q *Queries) ListAllURLs(ctx context.Context, arg ListAllURLsParams) ([]Url
 			&i.OriginalUrl,
 			&i.CreatedAt,
 			&i.CreatedBy,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEntriesByShortCode = `-- name: ListAuditEntriesByShortCode :many
+SELECT id, action, short_code, user_id, ip_address, created_at
+FROM audit
+WHERE short_code = ?
+ORDER BY id DESC
+LIMIT ?
+`
+
+type ListAuditEntriesByShortCodeParams struct {
+	ShortCode string `json:"short_code"`
+	Limit     int64  `json:"limit"`
+}
+
+func (q *Queries) ListAuditEntriesByShortCode(ctx context.Context, arg ListAuditEntriesByShortCodeParams) ([]Audit, error) {
+	rows, err := q.query(ctx, q.listAuditEntriesByShortCodeStmt, listAuditEntriesByShortCode, arg.ShortCode, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Audit{}
+	for rows.Next() {
+		var i Audit
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.ShortCode,
+			&i.UserID,
+			&i.IpAddress,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listClicksByURL = `-- name: ListClicksByURL :many
+SELECT id, clicked_at, referrer_domain, country, user_agent
+FROM clicks
+WHERE url_id = ?
+ORDER BY clicked_at DESC, id DESC
+LIMIT ? OFFSET ?
+`
+
+type ListClicksByURLParams struct {
+	UrlID  int64 `json:"url_id"`
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
+
+type ListClicksByURLRow struct {
+	ID             int64     `json:"id"`
+	ClickedAt      time.Time `json:"clicked_at"`
+	ReferrerDomain *string   `json:"referrer_domain"`
+	Country        *string   `json:"country"`
+	UserAgent      *string   `json:"user_agent"`
+}
+
+func (q *Queries) ListClicksByURL(ctx context.Context, arg ListClicksByURLParams) ([]ListClicksByURLRow, error) {
+	rows, err := q.query(ctx, q.listClicksByURLStmt, listClicksByURL, arg.UrlID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListClicksByURLRow{}
+	for rows.Next() {
+		var i ListClicksByURLRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ClickedAt,
+			&i.ReferrerDomain,
+			&i.Country,
+			&i.UserAgent,
 		); err != nil {
 			return nil, err
 		}
@@ -405,9 +1179,9 @@ func (q *Queries) ListAllURLs(ctx context.Context, arg ListAllURLsParams) ([]Url
 }
 
 const listURLs = `-- name: ListURLs :many
-SELECT id, short_code, original_url, created_at, created_by
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
 FROM urls
-WHERE created_by = ?
+WHERE created_by = ? AND deleted_at IS NULL
 ORDER BY created_at DESC
 LIMIT ? OFFSET ?
 `
@@ -433,6 +1207,7 @@ func (q *Queries) ListURLs(ctx context.Context, arg ListURLsParams) ([]Url, erro
 			&i.OriginalUrl,
 			&i.CreatedAt,
 			&i.CreatedBy,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -448,9 +1223,10 @@ func (q *Queries) ListURLs(ctx context.Context, arg ListURLsParams) ([]Url, erro
 }
 
 const listURLsByCreatedByAndTimeRange = `-- name: ListURLsByCreatedByAndTimeRange :many
-SELECT id, short_code, original_url, created_at, created_by
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
 FROM urls
 WHERE created_by = ?
+  AND deleted_at IS NULL
   AND created_at >= ?
   AND created_at <= ?
 ORDER BY created_at DESC
@@ -477,6 +1253,7 @@ func (q *Queries) ListURLsByCreatedByAndTimeRange(ctx context.Context, arg ListU
 			&i.OriginalUrl,
 			&i.CreatedAt,
 			&i.CreatedBy,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -503,9 +1280,12 @@ SELECT
     us.archive_checked_at
 FROM urls u
 LEFT JOIN url_status us ON us.url_id = u.id
-WHERE us.last_checked_at IS NULL
-   OR (us.gone_at IS NULL AND us.last_checked_at <= ?)
-   OR (us.gone_at IS NOT NULL AND us.last_checked_at <= ?)
+WHERE u.deleted_at IS NULL
+  AND (
+    us.last_checked_at IS NULL
+    OR (us.gone_at IS NULL AND us.last_checked_at <= ?)
+    OR (us.gone_at IS NOT NULL AND us.last_checked_at <= ?)
+  )
 ORDER BY COALESCE(us.last_checked_at, u.created_at) ASC
 LIMIT ?
 `
@@ -561,9 +1341,9 @@ func (q *Queries) ListURLsDueForStatusCheck(ctx context.Context, arg ListURLsDue
 
 const recordClick = `-- name: RecordClick :one
 
-INSERT INTO clicks (url_id, clicked_at, referrer, referrer_domain, country, user_agent)
-VALUES (?, ?, ?, ?, ?, ?)
-RETURNING id, url_id, clicked_at, referrer, referrer_domain, country, user_agent
+INSERT INTO clicks (url_id, clicked_at, referrer, referrer_domain, country, user_agent, visitor_hash)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, url_id, clicked_at, referrer, referrer_domain, country, user_agent, visitor_hash
 `
 
 type RecordClickParams struct {
@@ -573,6 +1353,7 @@ type RecordClickParams struct {
 	ReferrerDomain *string   `json:"referrer_domain"`
 	Country        *string   `json:"country"`
 	UserAgent      *string   `json:"user_agent"`
+	VisitorHash    *string   `json:"visitor_hash"`
 }
 
 type RecordClickRow struct {
@@ -583,6 +1364,7 @@ type RecordClickRow struct {
 	ReferrerDomain *string   `json:"referrer_domain"`
 	Country        *string   `json:"country"`
 	UserAgent      *string   `json:"user_agent"`
+	VisitorHash    *string   `json:"visitor_hash"`
 }
 
 // ============================================================================
@@ -596,6 +1378,7 @@ func (q *Queries) RecordClick(ctx context.Context, arg RecordClickParams) (Recor
 		arg.ReferrerDomain,
 		arg.Country,
 		arg.UserAgent,
+		arg.VisitorHash,
 	)
 	var i RecordClickRow
 	err := row.Scan(
@@ -606,10 +1389,184 @@ func (q *Queries) RecordClick(ctx context.Context, arg RecordClickParams) (Recor
 		&i.ReferrerDomain,
 		&i.Country,
 		&i.UserAgent,
+		&i.VisitorHash,
 	)
 	return i, err
 }
 
+const restoreURLByShortCode = `-- name: RestoreURLByShortCode :execrows
+UPDATE urls
+SET deleted_at = NULL
+WHERE short_code = ?
+`
+
+func (q *Queries) RestoreURLByShortCode(ctx context.Context, shortCode string) (int64, error) {
+	result, err := q.exec(ctx, q.restoreURLByShortCodeStmt, restoreURLByShortCode, shortCode)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const searchAllURLs = `-- name: SearchAllURLs :many
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
+FROM urls
+WHERE deleted_at IS NULL
+  AND (original_url LIKE ? ESCAPE '\' OR short_code LIKE ? ESCAPE '\')
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type SearchAllURLsParams struct {
+	OriginalUrl string `json:"original_url"`
+	ShortCode   string `json:"short_code"`
+	Limit       int64  `json:"limit"`
+	Offset      int64  `json:"offset"`
+}
+
+func (q *Queries) SearchAllURLs(ctx context.Context, arg SearchAllURLsParams) ([]Url, error) {
+	rows, err := q.query(ctx, q.searchAllURLsStmt, searchAllURLs,
+		arg.OriginalUrl,
+		arg.ShortCode,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShortCode,
+			&i.OriginalUrl,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchURLs = `-- name: SearchURLs :many
+SELECT id, short_code, original_url, created_at, created_by, deleted_at
+FROM urls
+WHERE created_by = ? AND deleted_at IS NULL
+  AND (original_url LIKE ? ESCAPE '\' OR short_code LIKE ? ESCAPE '\')
+ORDER BY created_at DESC
+LIMIT ? OFFSET ?
+`
+
+type SearchURLsParams struct {
+	CreatedBy   string `json:"created_by"`
+	OriginalUrl string `json:"original_url"`
+	ShortCode   string `json:"short_code"`
+	Limit       int64  `json:"limit"`
+	Offset      int64  `json:"offset"`
+}
+
+func (q *Queries) SearchURLs(ctx context.Context, arg SearchURLsParams) ([]Url, error) {
+	rows, err := q.query(ctx, q.searchURLsStmt, searchURLs,
+		arg.CreatedBy,
+		arg.OriginalUrl,
+		arg.ShortCode,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Url{}
+	for rows.Next() {
+		var i Url
+		if err := rows.Scan(
+			&i.ID,
+			&i.ShortCode,
+			&i.OriginalUrl,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteURLByShortCode = `-- name: SoftDeleteURLByShortCode :execrows
+UPDATE urls
+SET deleted_at = ?
+WHERE short_code = ? AND deleted_at IS NULL
+`
+
+type SoftDeleteURLByShortCodeParams struct {
+	DeletedAt *time.Time `json:"deleted_at"`
+	ShortCode string     `json:"short_code"`
+}
+
+func (q *Queries) SoftDeleteURLByShortCode(ctx context.Context, arg SoftDeleteURLByShortCodeParams) (int64, error) {
+	result, err := q.exec(ctx, q.softDeleteURLByShortCodeStmt, softDeleteURLByShortCode, arg.DeletedAt, arg.ShortCode)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const softDeleteURLsByCreatedByAndShortCodePrefix = `-- name: SoftDeleteURLsByCreatedByAndShortCodePrefix :many
+UPDATE urls
+SET deleted_at = ?
+WHERE created_by = ? AND short_code GLOB ? AND deleted_at IS NULL
+RETURNING short_code
+`
+
+type SoftDeleteURLsByCreatedByAndShortCodePrefixParams struct {
+	DeletedAt *time.Time `json:"deleted_at"`
+	CreatedBy string     `json:"created_by"`
+	ShortCode string     `json:"short_code"`
+}
+
+func (q *Queries) SoftDeleteURLsByCreatedByAndShortCodePrefix(ctx context.Context, arg SoftDeleteURLsByCreatedByAndShortCodePrefixParams) ([]string, error) {
+	rows, err := q.query(ctx, q.softDeleteURLsByCreatedByAndShortCodePrefixStmt, softDeleteURLsByCreatedByAndShortCodePrefix, arg.DeletedAt, arg.CreatedBy, arg.ShortCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var short_code string
+		if err := rows.Scan(&short_code); err != nil {
+			return nil, err
+		}
+		items = append(items, short_code)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const upsertURLStatus = `-- name: UpsertURLStatus :exec
 INSERT INTO url_status (
     url_id,
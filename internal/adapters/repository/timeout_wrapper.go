@@ -36,6 +36,13 @@ func (r *URLRepositoryWithTimeout) FindByShortCode(ctx context.Context, shortCod
 	return r.wrapped.FindByShortCode(ctx, shortCode)
 }
 
+// FindByOriginalURL retrieves a user's live URL for a destination with a timeout
+func (r *URLRepositoryWithTimeout) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.FindByOriginalURL(ctx, createdBy, originalURL)
+}
+
 // Delete removes a URL by its short code with a timeout
 func (r *URLRepositoryWithTimeout) Delete(ctx context.Context, shortCode string) error {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
@@ -43,6 +50,34 @@ func (r *URLRepositoryWithTimeout) Delete(ctx context.Context, shortCode string)
 	return r.wrapped.Delete(ctx, shortCode)
 }
 
+// DeleteByShortCodePrefix removes a user's URLs matching a short code prefix with a timeout
+func (r *URLRepositoryWithTimeout) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.DeleteByShortCodePrefix(ctx, createdBy, prefix)
+}
+
+// SoftDelete marks a URL as deleted with a timeout
+func (r *URLRepositoryWithTimeout) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.SoftDelete(ctx, shortCode, deletedAt)
+}
+
+// SoftDeleteByShortCodePrefix marks a user's URLs matching a short code prefix as deleted with a timeout
+func (r *URLRepositoryWithTimeout) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.SoftDeleteByShortCodePrefix(ctx, createdBy, prefix, deletedAt)
+}
+
+// Restore clears the soft-delete marker on a URL with a timeout
+func (r *URLRepositoryWithTimeout) Restore(ctx context.Context, shortCode string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.Restore(ctx, shortCode)
+}
+
 // List retrieves URLs with optional filtering and pagination with a timeout
 func (r *URLRepositoryWithTimeout) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
@@ -50,6 +85,13 @@ func (r *URLRepositoryWithTimeout) List(ctx context.Context, createdBy string, l
 	return r.wrapped.List(ctx, createdBy, limit, offset)
 }
 
+// Search retrieves URLs matching a query with optional filtering and pagination with a timeout
+func (r *URLRepositoryWithTimeout) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.Search(ctx, createdBy, query, limit, offset)
+}
+
 // ListByCreatedByAndTimeRange retrieves URLs created by a specific user within a time range with a timeout
 func (r *URLRepositoryWithTimeout) ListByCreatedByAndTimeRange(ctx context.Context, createdBy string, startTime, endTime time.Time) ([]*url.URL, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
@@ -64,6 +106,20 @@ func (r *URLRepositoryWithTimeout) Count(ctx context.Context, createdBy string)
 	return r.wrapped.Count(ctx, createdBy)
 }
 
+// CountAll returns the count of all stored URLs, soft-deleted ones included, with a timeout
+func (r *URLRepositoryWithTimeout) CountAll(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.CountAll(ctx)
+}
+
+// CountMatching returns the total count of URLs matching a query for a specific user with a timeout
+func (r *URLRepositoryWithTimeout) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.CountMatching(ctx, createdBy, query)
+}
+
 // ClickRepositoryWithTimeout wraps a Click repository and applies timeouts to all operations
 type ClickRepositoryWithTimeout struct {
 	wrapped click.Repository
@@ -86,17 +142,17 @@ func (r *ClickRepositoryWithTimeout) Record(ctx context.Context, c *click.Click)
 }
 
 // GetStatsByURL retrieves aggregate statistics for a specific URL with a timeout
-func (r *ClickRepositoryWithTimeout) GetStatsByURL(ctx context.Context, urlID int64) (*click.Stats, error) {
+func (r *ClickRepositoryWithTimeout) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
-	return r.wrapped.GetStatsByURL(ctx, urlID)
+	return r.wrapped.GetStatsByURL(ctx, urlID, topN)
 }
 
 // GetStatsByURLAndTimeRange retrieves statistics for a URL within a time range with a timeout
-func (r *ClickRepositoryWithTimeout) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+func (r *ClickRepositoryWithTimeout) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
-	return r.wrapped.GetStatsByURLAndTimeRange(ctx, urlID, startTime, endTime)
+	return r.wrapped.GetStatsByURLAndTimeRange(ctx, urlID, startTime, endTime, topN)
 }
 
 // GetTotalClickCount returns the total number of clicks for a URL with a timeout
@@ -106,9 +162,79 @@ func (r *ClickRepositoryWithTimeout) GetTotalClickCount(ctx context.Context, url
 	return r.wrapped.GetTotalClickCount(ctx, urlID)
 }
 
+// GetTotalClicksByUser returns the total number of clicks across a user's URLs with a timeout
+func (r *ClickRepositoryWithTimeout) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.GetTotalClicksByUser(ctx, createdBy)
+}
+
+// GetTopURLsByUser returns a user's most clicked URLs with a timeout
+func (r *ClickRepositoryWithTimeout) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.GetTopURLsByUser(ctx, createdBy, limit)
+}
+
+// GetUniqueVisitorCount returns the number of distinct visitors for a URL with a timeout
+func (r *ClickRepositoryWithTimeout) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.GetUniqueVisitorCount(ctx, urlID)
+}
+
+// GetUniqueVisitorCountInTimeRange returns the number of distinct visitors within a time range with a timeout
+func (r *ClickRepositoryWithTimeout) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.GetUniqueVisitorCountInTimeRange(ctx, urlID, startTime, endTime)
+}
+
 // GetClicksByCountry returns click counts grouped by country for a URL with a timeout
 func (r *ClickRepositoryWithTimeout) GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 	return r.wrapped.GetClicksByCountry(ctx, urlID)
 }
+
+// GetClicksByReferrerDomain returns click counts grouped by referrer domain for a URL with a timeout
+func (r *ClickRepositoryWithTimeout) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.GetClicksByReferrerDomain(ctx, urlID, topN)
+}
+
+// GetClicksByReferrerDomainInTimeRange returns click counts grouped by referrer domain within a time range with a timeout
+func (r *ClickRepositoryWithTimeout) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.GetClicksByReferrerDomainInTimeRange(ctx, urlID, startTime, endTime, topN)
+}
+
+// ListByURL returns a page of a URL's clicks with a timeout
+func (r *ClickRepositoryWithTimeout) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.ListByURL(ctx, urlID, limit, offset)
+}
+
+// DeleteOlderThan deletes clicks recorded before t with a timeout
+func (r *ClickRepositoryWithTimeout) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.DeleteOlderThan(ctx, t)
+}
+
+// GetDailyClicksInRange returns zero-filled daily click counts within a date range with a timeout
+func (r *ClickRepositoryWithTimeout) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.GetDailyClicksInRange(ctx, urlID, startDate, endDate)
+}
+
+// GetClicksOverTimeInTimeRange returns click counts bucketed by granularity within a time range with a timeout
+func (r *ClickRepositoryWithTimeout) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	return r.wrapped.GetClicksOverTimeInTimeRange(ctx, urlID, startTime, endTime, granularity)
+}
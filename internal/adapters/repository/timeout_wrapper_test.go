@@ -15,7 +15,11 @@ import (
 type mockURLRepository struct {
 	createDelay                      time.Duration
 	findByShortCodeDelay             time.Duration
+	findByOriginalURLDelay           time.Duration
 	deleteDelay                      time.Duration
+	deleteByShortCodePrefixDelay     time.Duration
+	softDeleteDelay                  time.Duration
+	restoreDelay                     time.Duration
 	listDelay                        time.Duration
 	listByCreatedByAndTimeRangeDelay time.Duration
 	countDelay                       time.Duration
@@ -63,6 +67,18 @@ func (m *mockURLRepository) FindByShortCode(ctx context.Context, shortCode strin
 	return &url.URL{ShortCode: shortCode}, nil
 }
 
+func (m *mockURLRepository) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	if m.findByOriginalURLDelay > 0 {
+		select {
+		case <-time.After(m.findByOriginalURLDelay):
+		case <-ctx.Done():
+			m.lastCtxCancelled = true
+			return nil, ctx.Err()
+		}
+	}
+	return &url.URL{CreatedBy: createdBy, OriginalURL: originalURL}, nil
+}
+
 func (m *mockURLRepository) Delete(ctx context.Context, shortCode string) error {
 	if m.deleteDelay > 0 {
 		select {
@@ -75,6 +91,46 @@ func (m *mockURLRepository) Delete(ctx context.Context, shortCode string) error
 	return m.deleteErr
 }
 
+func (m *mockURLRepository) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	if m.deleteByShortCodePrefixDelay > 0 {
+		select {
+		case <-time.After(m.deleteByShortCodePrefixDelay):
+		case <-ctx.Done():
+			m.lastCtxCancelled = true
+			return nil, ctx.Err()
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockURLRepository) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	if m.softDeleteDelay > 0 {
+		select {
+		case <-time.After(m.softDeleteDelay):
+		case <-ctx.Done():
+			m.lastCtxCancelled = true
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (m *mockURLRepository) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockURLRepository) Restore(ctx context.Context, shortCode string) error {
+	if m.restoreDelay > 0 {
+		select {
+		case <-time.After(m.restoreDelay):
+		case <-ctx.Done():
+			m.lastCtxCancelled = true
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 func (m *mockURLRepository) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
 	if m.listDelay > 0 {
 		select {
@@ -117,6 +173,18 @@ func (m *mockURLRepository) Count(ctx context.Context, createdBy string) (int, e
 	return 0, m.countErr
 }
 
+func (m *mockURLRepository) CountAll(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *mockURLRepository) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	return nil, nil
+}
+
+func (m *mockURLRepository) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	return 0, nil
+}
+
 // mockClickRepository is a mock that can simulate slow operations
 type mockClickRepository struct {
 	recordDelay                    time.Duration
@@ -130,6 +198,10 @@ type mockClickRepository struct {
 	getTotalClickCountErr          error
 	getClicksByCountryErr          error
 	lastCtxCancelled               bool
+
+	getClicksByReferrerDomainDelay time.Duration
+	getClicksOverTimeDelay         time.Duration
+	getUniqueVisitorCountDelay     time.Duration
 }
 
 func (m *mockClickRepository) Record(ctx context.Context, c *click.Click) error {
@@ -144,7 +216,7 @@ func (m *mockClickRepository) Record(ctx context.Context, c *click.Click) error
 	return m.recordErr
 }
 
-func (m *mockClickRepository) GetStatsByURL(ctx context.Context, urlID int64) (*click.Stats, error) {
+func (m *mockClickRepository) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
 	if m.getStatsByURLDelay > 0 {
 		select {
 		case <-time.After(m.getStatsByURLDelay):
@@ -159,7 +231,7 @@ func (m *mockClickRepository) GetStatsByURL(ctx context.Context, urlID int64) (*
 	return &click.Stats{}, nil
 }
 
-func (m *mockClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+func (m *mockClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
 	if m.getStatsByURLAndTimeRangeDelay > 0 {
 		select {
 		case <-time.After(m.getStatsByURLAndTimeRangeDelay):
@@ -186,6 +258,42 @@ func (m *mockClickRepository) GetTotalClickCount(ctx context.Context, urlID int6
 	return 0, m.getTotalClickCountErr
 }
 
+func (m *mockClickRepository) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockClickRepository) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockClickRepository) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	return m.GetUniqueVisitorCountInTimeRange(ctx, urlID, time.Time{}, time.Time{})
+}
+
+func (m *mockClickRepository) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	if m.getUniqueVisitorCountDelay > 0 {
+		select {
+		case <-time.After(m.getUniqueVisitorCountDelay):
+		case <-ctx.Done():
+			m.lastCtxCancelled = true
+			return 0, ctx.Err()
+		}
+	}
+	return 0, nil
+}
+
 func (m *mockClickRepository) GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error) {
 	if m.getClicksByCountryDelay > 0 {
 		select {
@@ -201,6 +309,34 @@ func (m *mockClickRepository) GetClicksByCountry(ctx context.Context, urlID int6
 	return make(map[string]int64), nil
 }
 
+func (m *mockClickRepository) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	return m.GetClicksByReferrerDomainInTimeRange(ctx, urlID, time.Time{}, time.Time{}, 0)
+}
+
+func (m *mockClickRepository) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	if m.getClicksByReferrerDomainDelay > 0 {
+		select {
+		case <-time.After(m.getClicksByReferrerDomainDelay):
+		case <-ctx.Done():
+			m.lastCtxCancelled = true
+			return nil, ctx.Err()
+		}
+	}
+	return make(map[string]int64), nil
+}
+
+func (m *mockClickRepository) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	if m.getClicksOverTimeDelay > 0 {
+		select {
+		case <-time.After(m.getClicksOverTimeDelay):
+		case <-ctx.Done():
+			m.lastCtxCancelled = true
+			return nil, ctx.Err()
+		}
+	}
+	return make(map[string]int64), nil
+}
+
 func TestURLRepositoryWithTimeout_Create_Timeout(t *testing.T) {
 	mock := &mockURLRepository{
 		createDelay: 200 * time.Millisecond, // Longer than timeout
@@ -267,6 +403,22 @@ func TestURLRepositoryWithTimeout_FindByShortCode_Timeout(t *testing.T) {
 	}
 }
 
+func TestURLRepositoryWithTimeout_FindByOriginalURL_Timeout(t *testing.T) {
+	mock := &mockURLRepository{
+		findByOriginalURLDelay: 200 * time.Millisecond,
+	}
+	repo := NewURLRepositoryWithTimeout(mock, 50*time.Millisecond)
+
+	_, err := repo.FindByOriginalURL(context.Background(), "user", "https://example.com")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FindByOriginalURL() with timeout expected DeadlineExceeded, got %v", err)
+	}
+	if !mock.lastCtxCancelled {
+		t.Error("FindByOriginalURL() should have received cancelled context")
+	}
+}
+
 func TestURLRepositoryWithTimeout_Delete_Timeout(t *testing.T) {
 	mock := &mockURLRepository{
 		deleteDelay: 200 * time.Millisecond,
@@ -283,6 +435,54 @@ func TestURLRepositoryWithTimeout_Delete_Timeout(t *testing.T) {
 	}
 }
 
+func TestURLRepositoryWithTimeout_DeleteByShortCodePrefix_Timeout(t *testing.T) {
+	mock := &mockURLRepository{
+		deleteByShortCodePrefixDelay: 200 * time.Millisecond,
+	}
+	repo := NewURLRepositoryWithTimeout(mock, 50*time.Millisecond)
+
+	_, err := repo.DeleteByShortCodePrefix(context.Background(), "user", "launch-")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("DeleteByShortCodePrefix() with timeout expected DeadlineExceeded, got %v", err)
+	}
+	if !mock.lastCtxCancelled {
+		t.Error("DeleteByShortCodePrefix() should have received cancelled context")
+	}
+}
+
+func TestURLRepositoryWithTimeout_SoftDelete_Timeout(t *testing.T) {
+	mock := &mockURLRepository{
+		softDeleteDelay: 200 * time.Millisecond,
+	}
+	repo := NewURLRepositoryWithTimeout(mock, 50*time.Millisecond)
+
+	err := repo.SoftDelete(context.Background(), "test", time.Now())
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SoftDelete() with timeout expected DeadlineExceeded, got %v", err)
+	}
+	if !mock.lastCtxCancelled {
+		t.Error("SoftDelete() should have received cancelled context")
+	}
+}
+
+func TestURLRepositoryWithTimeout_Restore_Timeout(t *testing.T) {
+	mock := &mockURLRepository{
+		restoreDelay: 200 * time.Millisecond,
+	}
+	repo := NewURLRepositoryWithTimeout(mock, 50*time.Millisecond)
+
+	err := repo.Restore(context.Background(), "test")
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Restore() with timeout expected DeadlineExceeded, got %v", err)
+	}
+	if !mock.lastCtxCancelled {
+		t.Error("Restore() should have received cancelled context")
+	}
+}
+
 func TestURLRepositoryWithTimeout_List_Timeout(t *testing.T) {
 	mock := &mockURLRepository{
 		listDelay: 200 * time.Millisecond,
@@ -368,7 +568,7 @@ func TestClickRepositoryWithTimeout_GetStatsByURL_Timeout(t *testing.T) {
 	}
 	repo := NewClickRepositoryWithTimeout(mock, 50*time.Millisecond)
 
-	_, err := repo.GetStatsByURL(context.Background(), 1)
+	_, err := repo.GetStatsByURL(context.Background(), 1, 0)
 
 	if !errors.Is(err, context.DeadlineExceeded) {
 		t.Errorf("GetStatsByURL() with timeout expected DeadlineExceeded, got %v", err)
@@ -384,7 +584,7 @@ func TestClickRepositoryWithTimeout_GetStatsByURLAndTimeRange_Timeout(t *testing
 	}
 	repo := NewClickRepositoryWithTimeout(mock, 50*time.Millisecond)
 
-	_, err := repo.GetStatsByURLAndTimeRange(context.Background(), 1, time.Now(), time.Now())
+	_, err := repo.GetStatsByURLAndTimeRange(context.Background(), 1, time.Now(), time.Now(), 0)
 
 	if !errors.Is(err, context.DeadlineExceeded) {
 		t.Errorf("GetStatsByURLAndTimeRange() with timeout expected DeadlineExceeded, got %v", err)
@@ -426,6 +626,54 @@ func TestClickRepositoryWithTimeout_GetClicksByCountry_Timeout(t *testing.T) {
 	}
 }
 
+func TestClickRepositoryWithTimeout_GetClicksByReferrerDomain_Timeout(t *testing.T) {
+	mock := &mockClickRepository{
+		getClicksByReferrerDomainDelay: 200 * time.Millisecond,
+	}
+	repo := NewClickRepositoryWithTimeout(mock, 50*time.Millisecond)
+
+	_, err := repo.GetClicksByReferrerDomain(context.Background(), 1, 0)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetClicksByReferrerDomain() with timeout expected DeadlineExceeded, got %v", err)
+	}
+	if !mock.lastCtxCancelled {
+		t.Error("GetClicksByReferrerDomain() should have received cancelled context")
+	}
+}
+
+func TestClickRepositoryWithTimeout_GetClicksOverTimeInTimeRange_Timeout(t *testing.T) {
+	mock := &mockClickRepository{
+		getClicksOverTimeDelay: 200 * time.Millisecond,
+	}
+	repo := NewClickRepositoryWithTimeout(mock, 50*time.Millisecond)
+
+	_, err := repo.GetClicksOverTimeInTimeRange(context.Background(), 1, time.Now().Add(-time.Hour), time.Now(), click.GranularityHour)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetClicksOverTimeInTimeRange() with timeout expected DeadlineExceeded, got %v", err)
+	}
+	if !mock.lastCtxCancelled {
+		t.Error("GetClicksOverTimeInTimeRange() should have received cancelled context")
+	}
+}
+
+func TestClickRepositoryWithTimeout_GetUniqueVisitorCount_Timeout(t *testing.T) {
+	mock := &mockClickRepository{
+		getUniqueVisitorCountDelay: 200 * time.Millisecond,
+	}
+	repo := NewClickRepositoryWithTimeout(mock, 50*time.Millisecond)
+
+	_, err := repo.GetUniqueVisitorCount(context.Background(), 1)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetUniqueVisitorCount() with timeout expected DeadlineExceeded, got %v", err)
+	}
+	if !mock.lastCtxCancelled {
+		t.Error("GetUniqueVisitorCount() should have received cancelled context")
+	}
+}
+
 // Integration test with real SQLite database to verify timeout behavior
 func TestURLRepositoryWithTimeout_Integration_SlowQuery(t *testing.T) {
 	if testing.Short() {
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dbSystem tags repository spans with the database they query
+var dbSystem = attribute.String("db.system.name", "sqlite")
+
+// startSpan starts a client span for a repository operation
+func startSpan(ctx context.Context, tracer trace.Tracer, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(attrs, dbSystem)...),
+	)
+}
+
+// endSpan records err on span, unless it is ErrURLNotFound, which is an
+// expected outcome (e.g. a redirect for an unknown code), and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, url.ErrURLNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// URLRepositoryWithTracing wraps a URL repository and records a span for every
+// operation. Spans carry the short code but never the destination URL.
+type URLRepositoryWithTracing struct {
+	wrapped url.Repository
+	tracer  trace.Tracer
+}
+
+// NewURLRepositoryWithTracing creates a URL repository wrapper that traces all operations with tracer
+func NewURLRepositoryWithTracing(repo url.Repository, tracer trace.Tracer) *URLRepositoryWithTracing {
+	return &URLRepositoryWithTracing{
+		wrapped: repo,
+		tracer:  tracer,
+	}
+}
+
+// Create creates a new shortened URL in a span
+func (r *URLRepositoryWithTracing) Create(ctx context.Context, u *url.URL) error {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.Create", tracing.ShortCode(u.ShortCode))
+	err := r.wrapped.Create(ctx, u)
+	endSpan(span, err)
+	return err
+}
+
+// FindByShortCode retrieves a URL by its short code in a span
+func (r *URLRepositoryWithTracing) FindByShortCode(ctx context.Context, shortCode string) (*url.URL, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.FindByShortCode", tracing.ShortCode(shortCode))
+	u, err := r.wrapped.FindByShortCode(ctx, shortCode)
+	endSpan(span, err)
+	return u, err
+}
+
+// FindByOriginalURL retrieves a user's live URL for a destination in a span
+func (r *URLRepositoryWithTracing) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.FindByOriginalURL")
+	u, err := r.wrapped.FindByOriginalURL(ctx, createdBy, originalURL)
+	endSpan(span, err)
+	return u, err
+}
+
+// Delete removes a URL by its short code in a span
+func (r *URLRepositoryWithTracing) Delete(ctx context.Context, shortCode string) error {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.Delete", tracing.ShortCode(shortCode))
+	err := r.wrapped.Delete(ctx, shortCode)
+	endSpan(span, err)
+	return err
+}
+
+// DeleteByShortCodePrefix removes a user's URLs matching a short code prefix in a span
+func (r *URLRepositoryWithTracing) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.DeleteByShortCodePrefix", tracing.ShortCodePrefixKey.String(prefix))
+	codes, err := r.wrapped.DeleteByShortCodePrefix(ctx, createdBy, prefix)
+	endSpan(span, err)
+	return codes, err
+}
+
+// SoftDelete marks a URL as deleted in a span
+func (r *URLRepositoryWithTracing) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.SoftDelete", tracing.ShortCode(shortCode))
+	err := r.wrapped.SoftDelete(ctx, shortCode, deletedAt)
+	endSpan(span, err)
+	return err
+}
+
+// SoftDeleteByShortCodePrefix marks a user's URLs matching a short code prefix as deleted in a span
+func (r *URLRepositoryWithTracing) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.SoftDeleteByShortCodePrefix", tracing.ShortCodePrefixKey.String(prefix))
+	codes, err := r.wrapped.SoftDeleteByShortCodePrefix(ctx, createdBy, prefix, deletedAt)
+	endSpan(span, err)
+	return codes, err
+}
+
+// Restore clears the soft-delete marker on a URL in a span
+func (r *URLRepositoryWithTracing) Restore(ctx context.Context, shortCode string) error {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.Restore", tracing.ShortCode(shortCode))
+	err := r.wrapped.Restore(ctx, shortCode)
+	endSpan(span, err)
+	return err
+}
+
+// List retrieves URLs with optional filtering and pagination in a span
+func (r *URLRepositoryWithTracing) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.List")
+	urls, err := r.wrapped.List(ctx, createdBy, limit, offset)
+	endSpan(span, err)
+	return urls, err
+}
+
+// Search retrieves URLs matching a query with optional filtering and pagination in a span
+func (r *URLRepositoryWithTracing) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.Search")
+	urls, err := r.wrapped.Search(ctx, createdBy, query, limit, offset)
+	endSpan(span, err)
+	return urls, err
+}
+
+// ListByCreatedByAndTimeRange retrieves URLs created by a specific user within a time range in a span
+func (r *URLRepositoryWithTracing) ListByCreatedByAndTimeRange(ctx context.Context, createdBy string, startTime, endTime time.Time) ([]*url.URL, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.ListByCreatedByAndTimeRange")
+	urls, err := r.wrapped.ListByCreatedByAndTimeRange(ctx, createdBy, startTime, endTime)
+	endSpan(span, err)
+	return urls, err
+}
+
+// Count returns the total count of URLs for a specific user in a span
+func (r *URLRepositoryWithTracing) Count(ctx context.Context, createdBy string) (int, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.Count")
+	n, err := r.wrapped.Count(ctx, createdBy)
+	endSpan(span, err)
+	return n, err
+}
+
+// CountAll returns the count of all stored URLs, soft-deleted ones included, in a span
+func (r *URLRepositoryWithTracing) CountAll(ctx context.Context) (int, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.CountAll")
+	n, err := r.wrapped.CountAll(ctx)
+	endSpan(span, err)
+	return n, err
+}
+
+// CountMatching returns the total count of URLs matching a query for a specific user in a span
+func (r *URLRepositoryWithTracing) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	ctx, span := startSpan(ctx, r.tracer, "URLRepository.CountMatching")
+	n, err := r.wrapped.CountMatching(ctx, createdBy, query)
+	endSpan(span, err)
+	return n, err
+}
+
+// ClickRepositoryWithTracing wraps a Click repository and records a span for every operation
+type ClickRepositoryWithTracing struct {
+	wrapped click.Repository
+	tracer  trace.Tracer
+}
+
+// NewClickRepositoryWithTracing creates a Click repository wrapper that traces all operations with tracer
+func NewClickRepositoryWithTracing(repo click.Repository, tracer trace.Tracer) *ClickRepositoryWithTracing {
+	return &ClickRepositoryWithTracing{
+		wrapped: repo,
+		tracer:  tracer,
+	}
+}
+
+// Record records a new click event in a span
+func (r *ClickRepositoryWithTracing) Record(ctx context.Context, c *click.Click) error {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.Record", tracing.URLIDKey.Int64(c.URLID))
+	err := r.wrapped.Record(ctx, c)
+	endSpan(span, err)
+	return err
+}
+
+// GetStatsByURL retrieves aggregate statistics for a specific URL in a span
+func (r *ClickRepositoryWithTracing) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetStatsByURL", tracing.URLIDKey.Int64(urlID))
+	stats, err := r.wrapped.GetStatsByURL(ctx, urlID, topN)
+	endSpan(span, err)
+	return stats, err
+}
+
+// GetStatsByURLAndTimeRange retrieves statistics for a URL within a time range in a span
+func (r *ClickRepositoryWithTracing) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetStatsByURLAndTimeRange", tracing.URLIDKey.Int64(urlID))
+	stats, err := r.wrapped.GetStatsByURLAndTimeRange(ctx, urlID, startTime, endTime, topN)
+	endSpan(span, err)
+	return stats, err
+}
+
+// GetTotalClickCount returns the total number of clicks for a URL in a span
+func (r *ClickRepositoryWithTracing) GetTotalClickCount(ctx context.Context, urlID int64) (int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetTotalClickCount", tracing.URLIDKey.Int64(urlID))
+	n, err := r.wrapped.GetTotalClickCount(ctx, urlID)
+	endSpan(span, err)
+	return n, err
+}
+
+// GetTotalClicksByUser returns the total number of clicks across a user's URLs in a span
+func (r *ClickRepositoryWithTracing) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetTotalClicksByUser")
+	n, err := r.wrapped.GetTotalClicksByUser(ctx, createdBy)
+	endSpan(span, err)
+	return n, err
+}
+
+// GetTopURLsByUser returns a user's most clicked URLs in a span
+func (r *ClickRepositoryWithTracing) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetTopURLsByUser")
+	urls, err := r.wrapped.GetTopURLsByUser(ctx, createdBy, limit)
+	endSpan(span, err)
+	return urls, err
+}
+
+// GetUniqueVisitorCount returns the number of distinct visitors for a URL in a span
+func (r *ClickRepositoryWithTracing) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetUniqueVisitorCount", tracing.URLIDKey.Int64(urlID))
+	n, err := r.wrapped.GetUniqueVisitorCount(ctx, urlID)
+	endSpan(span, err)
+	return n, err
+}
+
+// GetUniqueVisitorCountInTimeRange returns the number of distinct visitors within a time range in a span
+func (r *ClickRepositoryWithTracing) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetUniqueVisitorCountInTimeRange", tracing.URLIDKey.Int64(urlID))
+	n, err := r.wrapped.GetUniqueVisitorCountInTimeRange(ctx, urlID, startTime, endTime)
+	endSpan(span, err)
+	return n, err
+}
+
+// GetClicksByCountry returns click counts grouped by country for a URL in a span
+func (r *ClickRepositoryWithTracing) GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetClicksByCountry", tracing.URLIDKey.Int64(urlID))
+	counts, err := r.wrapped.GetClicksByCountry(ctx, urlID)
+	endSpan(span, err)
+	return counts, err
+}
+
+// GetClicksByReferrerDomain returns click counts grouped by referrer domain for a URL in a span
+func (r *ClickRepositoryWithTracing) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetClicksByReferrerDomain", tracing.URLIDKey.Int64(urlID))
+	counts, err := r.wrapped.GetClicksByReferrerDomain(ctx, urlID, topN)
+	endSpan(span, err)
+	return counts, err
+}
+
+// GetClicksByReferrerDomainInTimeRange returns click counts grouped by referrer domain within a time range in a span
+func (r *ClickRepositoryWithTracing) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetClicksByReferrerDomainInTimeRange", tracing.URLIDKey.Int64(urlID))
+	counts, err := r.wrapped.GetClicksByReferrerDomainInTimeRange(ctx, urlID, startTime, endTime, topN)
+	endSpan(span, err)
+	return counts, err
+}
+
+// ListByURL returns a page of a URL's clicks in a span
+func (r *ClickRepositoryWithTracing) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.ListByURL", tracing.URLIDKey.Int64(urlID))
+	clicks, err := r.wrapped.ListByURL(ctx, urlID, limit, offset)
+	endSpan(span, err)
+	return clicks, err
+}
+
+// DeleteOlderThan deletes clicks recorded before t in a span
+func (r *ClickRepositoryWithTracing) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.DeleteOlderThan")
+	deleted, err := r.wrapped.DeleteOlderThan(ctx, t)
+	endSpan(span, err)
+	return deleted, err
+}
+
+// GetDailyClicksInRange returns zero-filled daily click counts within a date range in a span
+func (r *ClickRepositoryWithTracing) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetDailyClicksInRange", tracing.URLIDKey.Int64(urlID))
+	counts, err := r.wrapped.GetDailyClicksInRange(ctx, urlID, startDate, endDate)
+	endSpan(span, err)
+	return counts, err
+}
+
+// GetClicksOverTimeInTimeRange returns click counts bucketed by granularity within a time range in a span
+func (r *ClickRepositoryWithTracing) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ClickRepository.GetClicksOverTimeInTimeRange", tracing.URLIDKey.Int64(urlID))
+	counts, err := r.wrapped.GetClicksOverTimeInTimeRange(ctx, urlID, startTime, endTime, granularity)
+	endSpan(span, err)
+	return counts, err
+}
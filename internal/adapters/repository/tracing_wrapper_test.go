@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestTracer() (*tracetest.InMemoryExporter, *sdktrace.TracerProvider) {
+	exporter := tracetest.NewInMemoryExporter()
+	return exporter, sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+}
+
+func TestURLRepositoryWithTracing_Create(t *testing.T) {
+	exporter, provider := newTestTracer()
+	repo := NewURLRepositoryWithTracing(&mockURLRepository{}, provider.Tracer(tracing.TracerName))
+
+	u := &url.URL{ShortCode: "abc123", OriginalURL: "https://example.com/private?token=secret"}
+	if err := repo.Create(context.Background(), u); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "URLRepository.Create" {
+		t.Errorf("span name = %q, want URLRepository.Create", span.Name)
+	}
+	if span.Status.Code != codes.Unset {
+		t.Errorf("span status = %v, want unset", span.Status.Code)
+	}
+
+	var gotCode string
+	for _, attr := range span.Attributes {
+		if attr.Key == tracing.ShortCodeKey {
+			gotCode = attr.Value.AsString()
+		}
+		if strings.Contains(attr.Value.Emit(), "example.com") {
+			t.Errorf("span attribute %s leaks the destination: %q", attr.Key, attr.Value.Emit())
+		}
+	}
+	if gotCode != "abc123" {
+		t.Errorf("short code attribute = %q, want abc123", gotCode)
+	}
+}
+
+func TestURLRepositoryWithTracing_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus codes.Code
+	}{
+		{name: "not found is not an error", err: url.ErrURLNotFound, wantStatus: codes.Unset},
+		{name: "failure", err: errors.New("database is locked"), wantStatus: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter, provider := newTestTracer()
+			repo := NewURLRepositoryWithTracing(&mockURLRepository{findByShortCodeErr: tt.err}, provider.Tracer(tracing.TracerName))
+
+			if _, err := repo.FindByShortCode(context.Background(), "abc123"); !errors.Is(err, tt.err) {
+				t.Fatalf("FindByShortCode() error = %v, want %v", err, tt.err)
+			}
+
+			spans := exporter.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("expected 1 span, got %d", len(spans))
+			}
+			if spans[0].Status.Code != tt.wantStatus {
+				t.Errorf("span status = %v, want %v", spans[0].Status.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClickRepositoryWithTracing_Record(t *testing.T) {
+	exporter, provider := newTestTracer()
+	repo := NewClickRepositoryWithTracing(&mockClickRepository{}, provider.Tracer(tracing.TracerName))
+
+	if err := repo.Record(context.Background(), &click.Click{URLID: 42}); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "ClickRepository.Record" {
+		t.Fatalf("expected one ClickRepository.Record span, got %v", spans)
+	}
+	var gotID int64
+	for _, attr := range spans[0].Attributes {
+		if attr.Key == tracing.URLIDKey {
+			gotID = attr.Value.AsInt64()
+		}
+	}
+	if gotID != 42 {
+		t.Errorf("url id attribute = %d, want 42", gotID)
+	}
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/matt-riley/mjrwtf/internal/adapters/repository/sqlc/sqlite"
@@ -53,6 +55,27 @@ func (r *SQLiteURLRepository) FindByShortCode(ctx context.Context, shortCode str
 		OriginalURL: result.OriginalUrl,
 		CreatedAt:   result.CreatedAt,
 		CreatedBy:   result.CreatedBy,
+		DeletedAt:   result.DeletedAt,
+	}, nil
+}
+
+// FindByOriginalURL retrieves the oldest live URL a user created for a destination
+func (r *SQLiteURLRepository) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	result, err := r.queries.FindURLByCreatedByAndOriginalURL(ctx, sqliterepo.FindURLByCreatedByAndOriginalURLParams{
+		CreatedBy:   createdBy,
+		OriginalUrl: originalURL,
+	})
+	if err != nil {
+		return nil, mapURLSQLError(err)
+	}
+
+	return &url.URL{
+		ID:          result.ID,
+		ShortCode:   result.ShortCode,
+		OriginalURL: result.OriginalUrl,
+		CreatedAt:   result.CreatedAt,
+		CreatedBy:   result.CreatedBy,
+		DeletedAt:   result.DeletedAt,
 	}, nil
 }
 
@@ -66,6 +89,79 @@ func (r *SQLiteURLRepository) Delete(ctx context.Context, shortCode string) erro
 	return nil
 }
 
+// DeleteByShortCodePrefix removes every URL created by createdBy whose short code starts with prefix,
+// returning the removed codes sorted. GLOB is used rather than LIKE because it is case-sensitive and
+// does not treat "_" as a wildcard; the prefix is validated first so it cannot contain GLOB metacharacters.
+func (r *SQLiteURLRepository) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	if err := url.ValidateShortCodePrefix(prefix); err != nil {
+		return nil, err
+	}
+
+	deleted, err := r.queries.DeleteURLsByCreatedByAndShortCodePrefix(ctx, sqliterepo.DeleteURLsByCreatedByAndShortCodePrefixParams{
+		CreatedBy: createdBy,
+		ShortCode: prefix + "*",
+	})
+	if err != nil {
+		return nil, mapURLSQLError(err)
+	}
+
+	// RETURNING order is unspecified
+	sort.Strings(deleted)
+	return deleted, nil
+}
+
+// SoftDelete marks a live URL as deleted without removing it or its clicks
+func (r *SQLiteURLRepository) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	affected, err := r.queries.SoftDeleteURLByShortCode(ctx, sqliterepo.SoftDeleteURLByShortCodeParams{
+		DeletedAt: &deletedAt,
+		ShortCode: shortCode,
+	})
+	if err != nil {
+		return mapURLSQLError(err)
+	}
+
+	if affected == 0 {
+		return url.ErrURLNotFound
+	}
+
+	return nil
+}
+
+// SoftDeleteByShortCodePrefix marks every live URL created by createdBy whose short code starts with prefix as deleted,
+// returning the marked codes sorted. Matching follows DeleteByShortCodePrefix.
+func (r *SQLiteURLRepository) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	if err := url.ValidateShortCodePrefix(prefix); err != nil {
+		return nil, err
+	}
+
+	deleted, err := r.queries.SoftDeleteURLsByCreatedByAndShortCodePrefix(ctx, sqliterepo.SoftDeleteURLsByCreatedByAndShortCodePrefixParams{
+		DeletedAt: &deletedAt,
+		CreatedBy: createdBy,
+		ShortCode: prefix + "*",
+	})
+	if err != nil {
+		return nil, mapURLSQLError(err)
+	}
+
+	sort.Strings(deleted)
+	return deleted, nil
+}
+
+// Restore clears the soft-delete marker on a URL
+func (r *SQLiteURLRepository) Restore(ctx context.Context, shortCode string) error {
+	affected, err := r.queries.RestoreURLByShortCode(ctx, shortCode)
+	if err != nil {
+		return mapURLSQLError(err)
+	}
+
+	// SQLite counts matched rows, so a live URL still reports one row
+	if affected == 0 {
+		return url.ErrURLNotFound
+	}
+
+	return nil
+}
+
 // List retrieves URLs with optional filtering and pagination
 func (r *SQLiteURLRepository) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
 	// Handle unlimited case
@@ -109,6 +205,54 @@ func (r *SQLiteURLRepository) List(ctx context.Context, createdBy string, limit,
 	return urls, nil
 }
 
+// Search retrieves URLs whose original URL or short code contains query, with optional filtering and pagination
+func (r *SQLiteURLRepository) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	// Handle unlimited case
+	if limit == 0 {
+		limit = -1 // SQLite uses -1 for no limit
+	}
+
+	var (
+		results []sqliterepo.Url
+		err     error
+	)
+
+	pattern := likeContains(query)
+	if createdBy == "" {
+		results, err = r.queries.SearchAllURLs(ctx, sqliterepo.SearchAllURLsParams{
+			OriginalUrl: pattern,
+			ShortCode:   pattern,
+			Limit:       int64(limit),
+			Offset:      int64(offset),
+		})
+	} else {
+		results, err = r.queries.SearchURLs(ctx, sqliterepo.SearchURLsParams{
+			CreatedBy:   createdBy,
+			OriginalUrl: pattern,
+			ShortCode:   pattern,
+			Limit:       int64(limit),
+			Offset:      int64(offset),
+		})
+	}
+
+	if err != nil {
+		return nil, mapURLSQLError(err)
+	}
+
+	urls := make([]*url.URL, len(results))
+	for i, result := range results {
+		urls[i] = &url.URL{
+			ID:          result.ID,
+			ShortCode:   result.ShortCode,
+			OriginalURL: result.OriginalUrl,
+			CreatedAt:   result.CreatedAt,
+			CreatedBy:   result.CreatedBy,
+		}
+	}
+
+	return urls, nil
+}
+
 // ListByCreatedByAndTimeRange retrieves URLs created by a specific user within a time range
 func (r *SQLiteURLRepository) ListByCreatedByAndTimeRange(ctx context.Context, createdBy string, startTime, endTime time.Time) ([]*url.URL, error) {
 	results, err := r.queries.ListURLsByCreatedByAndTimeRange(ctx, sqliterepo.ListURLsByCreatedByAndTimeRangeParams{
@@ -154,3 +298,50 @@ func (r *SQLiteURLRepository) Count(ctx context.Context, createdBy string) (int,
 
 	return int(count), nil
 }
+
+// CountAll returns the count of all stored URLs, soft-deleted ones included
+func (r *SQLiteURLRepository) CountAll(ctx context.Context) (int, error) {
+	count, err := r.queries.CountAllURLs(ctx)
+	if err != nil {
+		return 0, mapURLSQLError(err)
+	}
+
+	return int(count), nil
+}
+
+// CountMatching returns the total count of URLs Search matches for a specific user
+func (r *SQLiteURLRepository) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	var (
+		count int64
+		err   error
+	)
+
+	pattern := likeContains(query)
+	if createdBy == "" {
+		count, err = r.queries.CountURLsMatching(ctx, sqliterepo.CountURLsMatchingParams{
+			OriginalUrl: pattern,
+			ShortCode:   pattern,
+		})
+	} else {
+		count, err = r.queries.CountURLsByCreatedByMatching(ctx, sqliterepo.CountURLsByCreatedByMatchingParams{
+			CreatedBy:   createdBy,
+			OriginalUrl: pattern,
+			ShortCode:   pattern,
+		})
+	}
+
+	if err != nil {
+		return 0, mapURLSQLError(err)
+	}
+
+	return int(count), nil
+}
+
+// likeEscaper escapes LIKE wildcards and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likeContains returns a LIKE pattern (used with ESCAPE '\') matching values
+// that contain query literally
+func likeContains(query string) string {
+	return "%" + likeEscaper.Replace(query) + "%"
+}
@@ -6,17 +6,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 	"github.com/matt-riley/mjrwtf/internal/migrations"
 	"github.com/pressly/goose/v3"
 )
 
-func setupSQLiteTestDB(t *testing.T) (*sql.DB, func()) {
+func setupSQLiteTestDB(t testing.TB) (*sql.DB, func()) {
 	t.Helper()
 
 	// Create temporary directory for test database
@@ -131,6 +133,54 @@ func TestSQLiteURLRepository_FindByShortCode(t *testing.T) {
 	})
 }
 
+func TestSQLiteURLRepository_FindByOriginalURL(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteURLRepository(db)
+	ctx := context.Background()
+
+	for _, tc := range []struct{ code, original, owner string }{
+		{"first1", "https://example.com/a", "alice"},
+		{"second", "https://example.com/a", "alice"},
+		{"bobs01", "https://example.com/a", "bob"},
+		{"other1", "https://example.com/b", "alice"},
+	} {
+		u, _ := url.NewURL(tc.code, tc.original, tc.owner)
+		if err := repo.Create(ctx, u); err != nil {
+			t.Fatalf("Create(%s) error = %v", tc.code, err)
+		}
+	}
+
+	// The oldest match for the creator wins
+	found, err := repo.FindByOriginalURL(ctx, "alice", "https://example.com/a")
+	if err != nil {
+		t.Fatalf("FindByOriginalURL() error = %v", err)
+	}
+	if found.ShortCode != "first1" {
+		t.Errorf("FindByOriginalURL() short code = %q, want first1", found.ShortCode)
+	}
+
+	// Soft-deleted URLs are skipped
+	if err := repo.SoftDelete(ctx, "first1", time.Now()); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+	found, err = repo.FindByOriginalURL(ctx, "alice", "https://example.com/a")
+	if err != nil {
+		t.Fatalf("FindByOriginalURL() after soft delete error = %v", err)
+	}
+	if found.ShortCode != "second" {
+		t.Errorf("FindByOriginalURL() after soft delete short code = %q, want second", found.ShortCode)
+	}
+
+	if _, err := repo.FindByOriginalURL(ctx, "carol", "https://example.com/a"); err != url.ErrURLNotFound {
+		t.Errorf("FindByOriginalURL() for another creator error = %v, want %v", err, url.ErrURLNotFound)
+	}
+	if _, err := repo.FindByOriginalURL(ctx, "alice", "https://example.com/c"); err != url.ErrURLNotFound {
+		t.Errorf("FindByOriginalURL() for unknown destination error = %v, want %v", err, url.ErrURLNotFound)
+	}
+}
+
 func TestSQLiteURLRepository_Delete(t *testing.T) {
 	db, cleanup := setupSQLiteTestDB(t)
 	defer cleanup()
@@ -165,6 +215,237 @@ func TestSQLiteURLRepository_Delete(t *testing.T) {
 	})
 }
 
+func TestSQLiteURLRepository_DeleteByShortCodePrefix(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteURLRepository(db)
+	ctx := context.Background()
+
+	urls := []struct {
+		shortCode string
+		createdBy string
+	}{
+		{"launch-one", "user1"},
+		{"launch-two", "user1"},
+		{"launch-three", "user2"},
+		{"LAUNCH-four", "user1"},
+		{"launchXfive", "user1"},
+		{"prelaunch-six", "user1"},
+	}
+	for _, u := range urls {
+		created, _ := url.NewURL(u.shortCode, "https://example.com", u.createdBy)
+		if err := repo.Create(ctx, created); err != nil {
+			t.Fatalf("Create(%s) error = %v", u.shortCode, err)
+		}
+	}
+
+	deleted, err := repo.DeleteByShortCodePrefix(ctx, "user1", "launch-")
+	if err != nil {
+		t.Fatalf("DeleteByShortCodePrefix() error = %v", err)
+	}
+	if want := []string{"launch-one", "launch-two"}; !slices.Equal(deleted, want) {
+		t.Errorf("DeleteByShortCodePrefix() deleted = %v, want %v", deleted, want)
+	}
+
+	for _, code := range []string{"launch-one", "launch-two"} {
+		if _, err := repo.FindByShortCode(ctx, code); err != url.ErrURLNotFound {
+			t.Errorf("FindByShortCode(%s) error = %v, want %v", code, err, url.ErrURLNotFound)
+		}
+	}
+
+	// Other users' URLs, case variants, and codes that merely contain the prefix are kept
+	for _, code := range []string{"launch-three", "LAUNCH-four", "launchXfive", "prelaunch-six"} {
+		if _, err := repo.FindByShortCode(ctx, code); err != nil {
+			t.Errorf("FindByShortCode(%s) error = %v, want nil", code, err)
+		}
+	}
+
+	t.Run("underscore is not a wildcard", func(t *testing.T) {
+		deleted, err := repo.DeleteByShortCodePrefix(ctx, "user1", "launch_")
+		if err != nil {
+			t.Fatalf("DeleteByShortCodePrefix() error = %v", err)
+		}
+		if len(deleted) != 0 {
+			t.Errorf("DeleteByShortCodePrefix() deleted = %v, want none", deleted)
+		}
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		deleted, err := repo.DeleteByShortCodePrefix(ctx, "user1", "missing")
+		if err != nil {
+			t.Fatalf("DeleteByShortCodePrefix() error = %v", err)
+		}
+		if len(deleted) != 0 {
+			t.Errorf("DeleteByShortCodePrefix() deleted = %v, want none", deleted)
+		}
+	})
+
+	t.Run("empty prefix", func(t *testing.T) {
+		_, err := repo.DeleteByShortCodePrefix(ctx, "user1", "")
+		if err != url.ErrEmptyShortCodePrefix {
+			t.Errorf("DeleteByShortCodePrefix() error = %v, want %v", err, url.ErrEmptyShortCodePrefix)
+		}
+
+		count, err := repo.Count(ctx, "user1")
+		if err != nil {
+			t.Fatalf("Count() error = %v", err)
+		}
+		if count != 3 {
+			t.Errorf("Count() = %d, want 3", count)
+		}
+	})
+}
+
+func TestSQLiteURLRepository_SoftDeleteRestore(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteURLRepository(db)
+	clickRepo := NewSQLiteClickRepository(db)
+	ctx := context.Background()
+
+	u, _ := url.NewURL("softdel", "https://example.com", "testuser")
+	if err := repo.Create(ctx, u); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	c, _ := click.NewClick(u.ID, "", "US", "")
+	if err := clickRepo.Record(ctx, c); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+	if err := repo.SoftDelete(ctx, "softdel", deletedAt); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+
+	// FindByShortCode still returns the row, flagged as deleted
+	found, err := repo.FindByShortCode(ctx, "softdel")
+	if err != nil {
+		t.Fatalf("FindByShortCode() error = %v", err)
+	}
+	if !found.IsDeleted() || !found.DeletedAt.Equal(deletedAt) {
+		t.Errorf("FindByShortCode() DeletedAt = %v, want %v", found.DeletedAt, deletedAt)
+	}
+
+	// Listings and counts exclude it
+	list, err := repo.List(ctx, "testuser", 10, 0)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("List() returned %d URLs, want 0", len(list))
+	}
+	if count, _ := repo.Count(ctx, "testuser"); count != 0 {
+		t.Errorf("Count() = %d, want 0", count)
+	}
+	if count, _ := repo.Count(ctx, ""); count != 0 {
+		t.Errorf("Count(all) = %d, want 0", count)
+	}
+	// but the code is still taken
+	if count, _ := repo.CountAll(ctx); count != 1 {
+		t.Errorf("CountAll() = %d, want 1", count)
+	}
+
+	// Soft-deleting again reports not found, and the code stays reserved
+	if err := repo.SoftDelete(ctx, "softdel", deletedAt); err != url.ErrURLNotFound {
+		t.Errorf("SoftDelete() twice error = %v, want %v", err, url.ErrURLNotFound)
+	}
+	dup, _ := url.NewURL("softdel", "https://other.example", "testuser")
+	if err := repo.Create(ctx, dup); err != url.ErrDuplicateShortCode {
+		t.Errorf("Create() over soft-deleted code error = %v, want %v", err, url.ErrDuplicateShortCode)
+	}
+
+	if err := repo.Restore(ctx, "softdel"); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	found, err = repo.FindByShortCode(ctx, "softdel")
+	if err != nil {
+		t.Fatalf("FindByShortCode() after Restore() error = %v", err)
+	}
+	if found.IsDeleted() {
+		t.Error("FindByShortCode() after Restore() still deleted")
+	}
+	if count, _ := repo.Count(ctx, "testuser"); count != 1 {
+		t.Errorf("Count() after Restore() = %d, want 1", count)
+	}
+
+	// Clicks survive the round trip
+	total, err := clickRepo.GetTotalClickCount(ctx, u.ID)
+	if err != nil {
+		t.Fatalf("GetTotalClickCount() error = %v", err)
+	}
+	if total != 1 {
+		t.Errorf("GetTotalClickCount() = %d, want 1", total)
+	}
+
+	t.Run("restore live URL is a no-op", func(t *testing.T) {
+		if err := repo.Restore(ctx, "softdel"); err != nil {
+			t.Errorf("Restore() live URL error = %v, want nil", err)
+		}
+	})
+
+	t.Run("restore non-existent URL", func(t *testing.T) {
+		if err := repo.Restore(ctx, "missing"); err != url.ErrURLNotFound {
+			t.Errorf("Restore() error = %v, want %v", err, url.ErrURLNotFound)
+		}
+	})
+
+	t.Run("soft delete non-existent URL", func(t *testing.T) {
+		if err := repo.SoftDelete(ctx, "missing", time.Now()); err != url.ErrURLNotFound {
+			t.Errorf("SoftDelete() error = %v, want %v", err, url.ErrURLNotFound)
+		}
+	})
+}
+
+func TestSQLiteURLRepository_SoftDeleteByShortCodePrefix(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteURLRepository(db)
+	ctx := context.Background()
+
+	for _, u := range []struct{ shortCode, createdBy string }{
+		{"launch-one", "user1"},
+		{"launch-two", "user1"},
+		{"launch-three", "user2"},
+		{"other", "user1"},
+	} {
+		created, _ := url.NewURL(u.shortCode, "https://example.com", u.createdBy)
+		if err := repo.Create(ctx, created); err != nil {
+			t.Fatalf("Create(%s) error = %v", u.shortCode, err)
+		}
+	}
+
+	deleted, err := repo.SoftDeleteByShortCodePrefix(ctx, "user1", "launch-", time.Now())
+	if err != nil {
+		t.Fatalf("SoftDeleteByShortCodePrefix() error = %v", err)
+	}
+	if want := []string{"launch-one", "launch-two"}; !slices.Equal(deleted, want) {
+		t.Errorf("SoftDeleteByShortCodePrefix() deleted = %v, want %v", deleted, want)
+	}
+
+	// Already soft-deleted URLs are not returned again
+	deleted, err = repo.SoftDeleteByShortCodePrefix(ctx, "user1", "launch-", time.Now())
+	if err != nil {
+		t.Fatalf("SoftDeleteByShortCodePrefix() error = %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("SoftDeleteByShortCodePrefix() second call deleted = %v, want none", deleted)
+	}
+
+	if count, _ := repo.Count(ctx, "user1"); count != 1 {
+		t.Errorf("Count(user1) = %d, want 1", count)
+	}
+	if count, _ := repo.Count(ctx, "user2"); count != 1 {
+		t.Errorf("Count(user2) = %d, want 1", count)
+	}
+
+	if _, err := repo.SoftDeleteByShortCodePrefix(ctx, "user1", "", time.Now()); err != url.ErrEmptyShortCodePrefix {
+		t.Errorf("SoftDeleteByShortCodePrefix() empty prefix error = %v, want %v", err, url.ErrEmptyShortCodePrefix)
+	}
+}
+
 func TestSQLiteURLRepository_List(t *testing.T) {
 	db, cleanup := setupSQLiteTestDB(t)
 	defer cleanup()
@@ -251,6 +532,104 @@ func TestSQLiteURLRepository_List(t *testing.T) {
 	})
 }
 
+func TestSQLiteURLRepository_Search(t *testing.T) {
+	db, cleanup := setupSQLiteTestDB(t)
+	defer cleanup()
+
+	repo := NewSQLiteURLRepository(db)
+
+	// Create test data
+	urls := []struct {
+		shortCode   string
+		originalURL string
+		createdBy   string
+	}{
+		{"docs1", "https://example.com/Docs/Guide", "user1"},
+		{"Launch-1", "https://blog.example.com/posts/1", "user1"},
+		{"docs2", "https://example.com/docs/api", "user1"},
+		{"plain_1", "https://example.com/100%25", "user1"},
+		{"docs3", "https://example.com/docs/other", "user2"},
+		{"docs4", "https://example.com/docs/deleted", "user1"},
+	}
+
+	for _, u := range urls {
+		url, _ := url.NewURL(u.shortCode, u.originalURL, u.createdBy)
+		if err := repo.Create(context.Background(), url); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		time.Sleep(10 * time.Millisecond) // Ensure different timestamps
+	}
+	if err := repo.SoftDelete(context.Background(), "docs4", time.Now()); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+
+	shortCodes := func(urls []*url.URL) []string {
+		codes := make([]string, len(urls))
+		for i, u := range urls {
+			codes[i] = u.ShortCode
+		}
+		return codes
+	}
+
+	tests := []struct {
+		name      string
+		createdBy string
+		query     string
+		want      []string
+	}{
+		{name: "matches original URL ignoring case", createdBy: "user1", query: "DOCS", want: []string{"docs2", "docs1"}},
+		{name: "matches short code ignoring case", createdBy: "user1", query: "launch", want: []string{"Launch-1"}},
+		{name: "matches either column", createdBy: "user1", query: "blog", want: []string{"Launch-1"}},
+		{name: "scoped to creator", createdBy: "user2", query: "docs", want: []string{"docs3"}},
+		{name: "every creator", createdBy: "", query: "docs", want: []string{"docs3", "docs2", "docs1"}},
+		{name: "underscore is literal", createdBy: "user1", query: "_", want: []string{"plain_1"}},
+		{name: "percent is literal", createdBy: "user1", query: "%", want: []string{"plain_1"}},
+		{name: "no matches", createdBy: "user1", query: "nothing", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := repo.Search(context.Background(), tt.createdBy, tt.query, 0, 0)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if got := shortCodes(results); !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+
+			count, err := repo.CountMatching(context.Background(), tt.createdBy, tt.query)
+			if err != nil {
+				t.Fatalf("CountMatching() error = %v", err)
+			}
+			if count != len(tt.want) {
+				t.Errorf("CountMatching(%q) = %d, want %d", tt.query, count, len(tt.want))
+			}
+		})
+	}
+
+	t.Run("pagination within the filtered set", func(t *testing.T) {
+		page, err := repo.Search(context.Background(), "user1", "example.com/docs", 1, 1)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if got := shortCodes(page); !slices.Equal(got, []string{"docs1"}) {
+			t.Errorf("Search() second page = %v, want [docs1]", got)
+		}
+
+		// The total counts matches on every page, not the unfiltered URLs
+		count, err := repo.CountMatching(context.Background(), "user1", "example.com/docs")
+		if err != nil {
+			t.Fatalf("CountMatching() error = %v", err)
+		}
+		if count != 2 {
+			t.Errorf("CountMatching() = %d, want 2", count)
+		}
+		if total, _ := repo.Count(context.Background(), "user1"); total != 4 {
+			t.Errorf("Count() = %d, want 4", total)
+		}
+	})
+}
+
 func TestSQLiteURLRepository_ListByCreatedByAndTimeRange(t *testing.T) {
 	db, cleanup := setupSQLiteTestDB(t)
 	defer cleanup()
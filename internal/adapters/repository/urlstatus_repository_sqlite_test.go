@@ -106,4 +106,15 @@ func TestSQLiteURLStatusRepository_ListDueForStatusCheck(t *testing.T) {
 	if due[0].LastCheckedAt != nil {
 		t.Fatalf("ListDueForStatusCheck() LastCheckedAt = %v, want nil", due[0].LastCheckedAt)
 	}
+	// Soft-deleted URLs are not checked
+	if err := urlRepo.SoftDelete(context.Background(), "due1", time.Now()); err != nil {
+		t.Fatalf("SoftDelete() error = %v", err)
+	}
+	due, err = statusRepo.ListDueForStatusCheck(context.Background(), aliveCutoff, goneCutoff, 10)
+	if err != nil {
+		t.Fatalf("ListDueForStatusCheck() error = %v", err)
+	}
+	if len(due) != 0 {
+		t.Fatalf("ListDueForStatusCheck() after SoftDelete() len = %d, want %d", len(due), 0)
+	}
 }
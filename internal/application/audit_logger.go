@@ -0,0 +1,43 @@
+package application
+
+import (
+	"context"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+	"github.com/rs/zerolog"
+)
+
+// AuditLogger records create and delete actions to the audit trail.
+// Recording is best-effort: failures are logged and never fail the action
+// being audited. A nil *AuditLogger records nothing.
+type AuditLogger struct {
+	repo   audit.Repository
+	logger zerolog.Logger
+}
+
+// NewAuditLogger creates an AuditLogger that stores entries in repo
+func NewAuditLogger(repo audit.Repository, logger zerolog.Logger) *AuditLogger {
+	return &AuditLogger{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Log records that userID performed action on shortCode from ipAddress
+func (l *AuditLogger) Log(ctx context.Context, action audit.Action, shortCode, userID, ipAddress string) {
+	if l == nil {
+		return
+	}
+
+	entry, err := audit.NewEntry(action, shortCode, userID, ipAddress)
+	if err == nil {
+		err = l.repo.Record(ctx, entry)
+	}
+	if err != nil {
+		l.logger.Error().Err(err).
+			Str("action", string(action)).
+			Str("short_code", shortCode).
+			Str("user_id", userID).
+			Msg("audit: failed to record entry")
+	}
+}
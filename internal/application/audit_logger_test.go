@@ -0,0 +1,73 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+	"github.com/rs/zerolog"
+)
+
+// mockAuditRepository is an in-memory audit.Repository for testing
+type mockAuditRepository struct {
+	entries []*audit.Entry
+	err     error
+}
+
+func (m *mockAuditRepository) Record(ctx context.Context, entry *audit.Entry) error {
+	if m.err != nil {
+		return m.err
+	}
+	entry.ID = int64(len(m.entries) + 1)
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *mockAuditRepository) ListByShortCode(ctx context.Context, shortCode string, limit int) ([]*audit.Entry, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var out []*audit.Entry
+	for i := len(m.entries) - 1; i >= 0 && len(out) < limit; i-- {
+		if m.entries[i].ShortCode == shortCode {
+			out = append(out, m.entries[i])
+		}
+	}
+	return out, nil
+}
+
+func TestAuditLogger_Log(t *testing.T) {
+	repo := &mockAuditRepository{}
+	l := NewAuditLogger(repo, zerolog.Nop())
+
+	l.Log(context.Background(), audit.ActionCreate, "abc123", "user1", "192.0.2.1")
+
+	if len(repo.entries) != 1 {
+		t.Fatalf("recorded %d entries, want 1", len(repo.entries))
+	}
+	got := repo.entries[0]
+	if got.Action != audit.ActionCreate || got.ShortCode != "abc123" || got.UserID != "user1" || got.IPAddress != "192.0.2.1" {
+		t.Errorf("recorded entry = %+v", got)
+	}
+}
+
+func TestAuditLogger_Log_FailureIsLogged(t *testing.T) {
+	var buf bytes.Buffer
+	repo := &mockAuditRepository{err: errors.New("disk full")}
+	l := NewAuditLogger(repo, zerolog.New(&buf))
+
+	l.Log(context.Background(), audit.ActionDelete, "abc123", "user1", "")
+
+	if !strings.Contains(buf.String(), "disk full") || !strings.Contains(buf.String(), "abc123") {
+		t.Errorf("expected the failure to be logged, got %q", buf.String())
+	}
+}
+
+func TestAuditLogger_Log_Nil(t *testing.T) {
+	var l *AuditLogger
+	// Must not panic
+	l.Log(context.Background(), audit.ActionCreate, "abc123", "user1", "")
+}
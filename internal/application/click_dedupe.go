@@ -0,0 +1,66 @@
+package application
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// clickDeduper remembers recently recorded clicks so an identical one arriving
+// within window (typically a bot fetching the redirect twice) can be skipped.
+// The window runs from the last click that was recorded, not from the last one
+// seen, so a client repeating a request counts once per window rather than once.
+type clickDeduper struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	lastSweep time.Time
+}
+
+func newClickDeduper(window time.Duration) *clickDeduper {
+	return &clickDeduper{
+		window: window,
+		now:    time.Now,
+		seen:   make(map[string]time.Time),
+	}
+}
+
+// clickDedupeKey identifies a click by short code, visitor and user agent.
+// The visitor is the visitor hash when unique-visitor tracking is on, else the client IP.
+func clickDedupeKey(shortCode, visitor, userAgent string) string {
+	return strings.Join([]string{shortCode, visitor, userAgent}, "\x00")
+}
+
+// duplicate reports whether a click with key was recorded within the window.
+// If not, the click is remembered as recorded now.
+func (d *clickDeduper) duplicate(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	// Forget expired clicks once per window, so the map only holds recent ones
+	if now.Sub(d.lastSweep) >= d.window {
+		for k, at := range d.seen {
+			if now.Sub(at) >= d.window {
+				delete(d.seen, k)
+			}
+		}
+		d.lastSweep = now
+	}
+
+	if at, ok := d.seen[key]; ok && now.Sub(at) < d.window {
+		return true
+	}
+	d.seen[key] = now
+	return false
+}
+
+// forget drops key, for a click that duplicate remembered but that was never
+// recorded, so it doesn't suppress the next identical click.
+func (d *clickDeduper) forget(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.seen, key)
+}
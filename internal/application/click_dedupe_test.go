@@ -0,0 +1,47 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClickDeduper_WindowRunsFromRecordedClick(t *testing.T) {
+	d := newClickDeduper(time.Second)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	key := clickDedupeKey("abc", "203.0.113.7", "Bot/1.0")
+	if d.duplicate(key) {
+		t.Fatal("first click should not be a duplicate")
+	}
+
+	// Repeats inside the window are skipped without extending it
+	for _, offset := range []time.Duration{100 * time.Millisecond, 500 * time.Millisecond, 900 * time.Millisecond} {
+		now = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC).Add(offset)
+		if !d.duplicate(key) {
+			t.Fatalf("click %v after the first should be a duplicate", offset)
+		}
+	}
+
+	now = time.Date(2026, 1, 1, 12, 0, 1, 0, time.UTC)
+	if d.duplicate(key) {
+		t.Fatal("click a full window after the recorded one should not be a duplicate")
+	}
+}
+
+func TestClickDeduper_ForgetsExpiredClicks(t *testing.T) {
+	d := newClickDeduper(time.Second)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	for _, ua := range []string{"a", "b", "c"} {
+		d.duplicate(clickDedupeKey("abc", "203.0.113.7", ua))
+	}
+
+	now = now.Add(2 * time.Second)
+	d.duplicate(clickDedupeKey("abc", "203.0.113.7", "d"))
+
+	if len(d.seen) != 1 {
+		t.Errorf("expected expired clicks to be forgotten, %d remembered", len(d.seen))
+	}
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/rs/zerolog"
+)
+
+// DefaultClickPurgeInterval is how often the ClickPurger deletes expired clicks when no interval is configured
+const DefaultClickPurgeInterval = time.Hour
+
+// ClickPurgerConfig configures a ClickPurger
+type ClickPurgerConfig struct {
+	// RetentionDays keeps clicks for this many days; 0 keeps them forever and disables the purger
+	RetentionDays int
+	// Interval between purge passes (default: DefaultClickPurgeInterval)
+	Interval time.Duration
+}
+
+// ClickPurgerOption is a functional option for configuring a ClickPurger
+type ClickPurgerOption func(*ClickPurger)
+
+// WithClickPurgerNow overrides the clock used to compute the retention cutoff
+func WithClickPurgerNow(now func() time.Time) ClickPurgerOption {
+	return func(p *ClickPurger) {
+		if now != nil {
+			p.now = now
+		}
+	}
+}
+
+// ClickPurger periodically deletes clicks older than the retention window
+type ClickPurger struct {
+	repo   click.Repository
+	cfg    ClickPurgerConfig
+	logger zerolog.Logger
+	now    func() time.Time
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewClickPurger creates a ClickPurger for repo. Call Start to begin purging.
+func NewClickPurger(repo click.Repository, cfg ClickPurgerConfig, logger zerolog.Logger, opts ...ClickPurgerOption) *ClickPurger {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultClickPurgeInterval
+	}
+
+	p := &ClickPurger{
+		repo:   repo,
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+		stopCh: make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(p)
+		}
+	}
+
+	return p
+}
+
+// Enabled reports whether clicks expire, i.e. RetentionDays is above 0
+func (p *ClickPurger) Enabled() bool {
+	return p.cfg.RetentionDays > 0
+}
+
+// Start purges once, then again every Interval until ctx is done or Shutdown is called.
+// It is a no-op when retention is disabled; subsequent calls are no-ops too.
+func (p *ClickPurger) Start(ctx context.Context) {
+	if !p.Enabled() {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	p.startOnce.Do(func() {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+
+			ticker := time.NewTicker(p.cfg.Interval)
+			defer ticker.Stop()
+
+			// Purge on startup, so a restart never leaves a backlog until the first tick
+			p.RunOnce(ctx)
+
+			for {
+				select {
+				case <-p.stopCh:
+					return
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					p.RunOnce(ctx)
+				}
+			}
+		}()
+	})
+}
+
+// Shutdown stops the purge goroutine and waits for an in-flight pass to finish
+func (p *ClickPurger) Shutdown() {
+	p.stopOnce.Do(func() { close(p.stopCh) })
+	p.wg.Wait()
+}
+
+// RunOnce deletes clicks older than the retention window and returns how many were removed.
+// With retention disabled it deletes nothing.
+func (p *ClickPurger) RunOnce(ctx context.Context) int64 {
+	if !p.Enabled() {
+		return 0
+	}
+
+	cutoff := p.now().UTC().AddDate(0, 0, -p.cfg.RetentionDays)
+	deleted, err := p.repo.DeleteOlderThan(ctx, cutoff)
+	if err != nil {
+		p.logger.Error().Err(err).Time("cutoff", cutoff).Msg("click purge failed")
+		return 0
+	}
+
+	event := p.logger.Debug()
+	if deleted > 0 {
+		event = p.logger.Info()
+	}
+	event.Int64("deleted", deleted).Time("cutoff", cutoff).Msg("click purge completed")
+
+	return deleted
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestClickPurger_RunOnce_DeletesBeforeCutoff(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	var gotCutoff time.Time
+	repo := &mockListClickRepository{
+		deleteOlderThanFunc: func(ctx context.Context, cutoff time.Time) (int64, error) {
+			gotCutoff = cutoff
+			return 42, nil
+		},
+	}
+
+	purger := NewClickPurger(repo, ClickPurgerConfig{RetentionDays: 30}, zerolog.Nop(), WithClickPurgerNow(func() time.Time { return now }))
+	if deleted := purger.RunOnce(context.Background()); deleted != 42 {
+		t.Errorf("RunOnce() = %d, want 42", deleted)
+	}
+	if want := now.AddDate(0, 0, -30); !gotCutoff.Equal(want) {
+		t.Errorf("DeleteOlderThan cutoff = %v, want %v", gotCutoff, want)
+	}
+}
+
+func TestClickPurger_RunOnce_Error(t *testing.T) {
+	repo := &mockListClickRepository{
+		deleteOlderThanFunc: func(ctx context.Context, cutoff time.Time) (int64, error) {
+			return 0, errors.New("database is locked")
+		},
+	}
+
+	purger := NewClickPurger(repo, ClickPurgerConfig{RetentionDays: 7}, zerolog.Nop())
+	if deleted := purger.RunOnce(context.Background()); deleted != 0 {
+		t.Errorf("RunOnce() = %d after an error, want 0", deleted)
+	}
+}
+
+func TestClickPurger_RetentionZeroIsNoOp(t *testing.T) {
+	called := false
+	repo := &mockListClickRepository{
+		deleteOlderThanFunc: func(ctx context.Context, cutoff time.Time) (int64, error) {
+			called = true
+			return 0, nil
+		},
+	}
+
+	purger := NewClickPurger(repo, ClickPurgerConfig{RetentionDays: 0, Interval: time.Millisecond}, zerolog.Nop())
+	if purger.Enabled() {
+		t.Error("Enabled() = true with retention 0")
+	}
+
+	purger.Start(context.Background())
+	time.Sleep(10 * time.Millisecond)
+	purger.Shutdown()
+
+	if deleted := purger.RunOnce(context.Background()); deleted != 0 {
+		t.Errorf("RunOnce() = %d, want 0", deleted)
+	}
+	if called {
+		t.Error("DeleteOlderThan should never be called with retention 0")
+	}
+}
+
+func TestClickPurger_StartPurgesUntilShutdown(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	purged := make(chan struct{}, 16)
+	repo := &mockListClickRepository{
+		deleteOlderThanFunc: func(ctx context.Context, cutoff time.Time) (int64, error) {
+			mu.Lock()
+			calls++
+			mu.Unlock()
+			select {
+			case purged <- struct{}{}:
+			default:
+			}
+			return 1, nil
+		},
+	}
+
+	purger := NewClickPurger(repo, ClickPurgerConfig{RetentionDays: 1, Interval: 5 * time.Millisecond}, zerolog.Nop())
+	purger.Start(context.Background())
+
+	// One pass on startup, then at least one on the ticker
+	for i := 0; i < 2; i++ {
+		select {
+		case <-purged:
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for purge pass %d", i+1)
+		}
+	}
+
+	purger.Shutdown()
+	mu.Lock()
+	after := calls
+	mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != after {
+		t.Errorf("purge ran %d more times after Shutdown", calls-after)
+	}
+}
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// CountURLsRequest represents the input for counting URLs
+type CountURLsRequest struct {
+	CreatedBy string
+}
+
+// CountURLsResponse represents the output after counting URLs
+type CountURLsResponse struct {
+	Total int `json:"total"` // Total is the number of live URLs the user created
+}
+
+// CountURLsUseCase counts a user's shortened URLs without listing them
+type CountURLsUseCase struct {
+	urlRepo url.Repository
+}
+
+// NewCountURLsUseCase creates a new CountURLsUseCase
+func NewCountURLsUseCase(urlRepo url.Repository) *CountURLsUseCase {
+	return &CountURLsUseCase{
+		urlRepo: urlRepo,
+	}
+}
+
+// Execute counts the URLs created by a specific user; soft-deleted URLs are not counted
+func (uc *CountURLsUseCase) Execute(ctx context.Context, req CountURLsRequest) (*CountURLsResponse, error) {
+	// An empty creator would count every user's URLs
+	if req.CreatedBy == "" {
+		return nil, url.ErrInvalidCreatedBy
+	}
+
+	total, err := uc.urlRepo.Count(ctx, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CountURLsResponse{Total: total}, nil
+}
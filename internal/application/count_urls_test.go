@@ -0,0 +1,62 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func TestCountURLsUseCase_Execute(t *testing.T) {
+	counts := map[string]int{"user1": 3, "user2": 1}
+	repo := &mockListURLRepository{
+		countFunc: func(ctx context.Context, createdBy string) (int, error) {
+			return counts[createdBy], nil
+		},
+	}
+	uc := NewCountURLsUseCase(repo)
+
+	for createdBy, want := range counts {
+		resp, err := uc.Execute(context.Background(), CountURLsRequest{CreatedBy: createdBy})
+		if err != nil {
+			t.Fatalf("Execute(%q) error = %v", createdBy, err)
+		}
+		if resp.Total != want {
+			t.Errorf("Execute(%q) Total = %d, want %d", createdBy, resp.Total, want)
+		}
+	}
+}
+
+func TestCountURLsUseCase_Execute_EmptyCreatedBy(t *testing.T) {
+	called := false
+	repo := &mockListURLRepository{
+		countFunc: func(ctx context.Context, createdBy string) (int, error) {
+			called = true
+			return 0, nil
+		},
+	}
+	uc := NewCountURLsUseCase(repo)
+
+	_, err := uc.Execute(context.Background(), CountURLsRequest{})
+	if !errors.Is(err, url.ErrInvalidCreatedBy) {
+		t.Errorf("Execute() error = %v, want %v", err, url.ErrInvalidCreatedBy)
+	}
+	if called {
+		t.Error("expected Count not to be called without a creator")
+	}
+}
+
+func TestCountURLsUseCase_Execute_RepositoryError(t *testing.T) {
+	repoErr := errors.New("database error")
+	repo := &mockListURLRepository{
+		countFunc: func(ctx context.Context, createdBy string) (int, error) {
+			return 0, repoErr
+		},
+	}
+	uc := NewCountURLsUseCase(repo)
+
+	if _, err := uc.Execute(context.Background(), CountURLsRequest{CreatedBy: "user1"}); !errors.Is(err, repoErr) {
+		t.Errorf("Execute() error = %v, want %v", err, repoErr)
+	}
+}
@@ -2,9 +2,13 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+	"github.com/matt-riley/mjrwtf/internal/domain/idempotency"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 )
 
@@ -12,6 +16,12 @@ import (
 type CreateURLRequest struct {
 	OriginalURL string
 	CreatedBy   string
+	// ShortCode is an optional caller-chosen short code; a random one is generated when empty.
+	ShortCode string
+	// IdempotencyKey is an optional client key; retries with the same key return the first result.
+	IdempotencyKey string
+	// ClientIP is the requester's IP, recorded in the audit trail when one is configured.
+	ClientIP string
 }
 
 // CreateURLResponse represents the output after creating a shortened URL
@@ -19,36 +29,189 @@ type CreateURLResponse struct {
 	ShortCode   string
 	ShortURL    string
 	OriginalURL string
+	// Reused is true when an existing URL for the same destination was returned instead of a new one
+	Reused bool
+}
+
+// CreateOption configures CreateURLUseCase
+type CreateOption func(*CreateURLUseCase)
+
+// WithDedupeDestinations makes creates without a custom short code return the
+// caller's existing live URL for the same destination instead of minting a new code
+func WithDedupeDestinations(urlRepo url.Repository) CreateOption {
+	return func(uc *CreateURLUseCase) {
+		uc.dedupeRepo = urlRepo
+	}
+}
+
+// WithIdempotencyKeys makes requests carrying an IdempotencyKey return the
+// first result for that creator and key for ttl, instead of creating another URL
+func WithIdempotencyKeys(repo idempotency.Repository, ttl time.Duration) CreateOption {
+	return func(uc *CreateURLUseCase) {
+		uc.idempotencyRepo = repo
+		uc.idempotencyTTL = ttl
+	}
+}
+
+// WithAuditLogger records each newly created URL in the audit trail
+func WithAuditLogger(auditLogger *AuditLogger) CreateOption {
+	return func(uc *CreateURLUseCase) {
+		uc.auditLogger = auditLogger
+	}
+}
+
+// URLCreatedNotifier is told about each newly created URL, e.g. to post it to a chat channel.
+// Implementations must not block the request; the Discord notifier sends asynchronously.
+type URLCreatedNotifier interface {
+	NotifyURLCreated(ctx context.Context, u *url.URL, shortURL string)
+}
+
+// WithCreatedNotifier announces each newly created URL through notifier.
+// It can be given more than once, e.g. for a chat post and a webhook event.
+func WithCreatedNotifier(notifier URLCreatedNotifier) CreateOption {
+	return func(uc *CreateURLUseCase) {
+		uc.createdNotifiers = append(uc.createdNotifiers, notifier)
+	}
 }
 
 // CreateURLUseCase handles the creation of shortened URLs
 type CreateURLUseCase struct {
 	generator *url.Generator
 	baseURL   string
+	// dedupeRepo is set when destination deduplication is enabled
+	dedupeRepo url.Repository
+
+	idempotencyRepo idempotency.Repository
+	idempotencyTTL  time.Duration
+
+	auditLogger      *AuditLogger
+	createdNotifiers []URLCreatedNotifier
 }
 
 // NewCreateURLUseCase creates a new CreateURLUseCase
-func NewCreateURLUseCase(generator *url.Generator, baseURL string) *CreateURLUseCase {
-	return &CreateURLUseCase{
+func NewCreateURLUseCase(generator *url.Generator, baseURL string, opts ...CreateOption) *CreateURLUseCase {
+	uc := &CreateURLUseCase{
 		generator: generator,
 		baseURL:   strings.TrimSuffix(baseURL, "/"),
 	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
 }
 
 // Execute creates a shortened URL
 func (uc *CreateURLUseCase) Execute(ctx context.Context, req CreateURLRequest) (*CreateURLResponse, error) {
-	// Generate and store shortened URL
-	shortenedURL, err := uc.generator.ShortenURL(ctx, req.OriginalURL, req.CreatedBy)
+	// Deduplication and idempotency compare against the stored, normalized destination
+	originalURL, err := uc.generator.NormalizeURL(req.OriginalURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create shortened URL: %w", err)
 	}
+	req.OriginalURL = originalURL
 
-	// Build response
-	shortURL := fmt.Sprintf("%s/%s", uc.baseURL, shortenedURL.ShortCode)
+	if req.IdempotencyKey == "" || uc.idempotencyRepo == nil {
+		return uc.create(ctx, req)
+	}
 
+	if err := idempotency.ValidateKey(req.IdempotencyKey); err != nil {
+		return nil, err
+	}
+
+	notBefore := time.Now().Add(-uc.idempotencyTTL)
+	if resp, err := uc.replay(ctx, req, notBefore); !errors.Is(err, idempotency.ErrKeyNotFound) {
+		return resp, err
+	}
+
+	// Claim the key before creating, so a concurrent request with the same key
+	// can't create a second URL
+	err = uc.idempotencyRepo.Save(ctx, &idempotency.Record{
+		CreatedBy:   req.CreatedBy,
+		Key:         req.IdempotencyKey,
+		OriginalURL: req.OriginalURL,
+		CreatedAt:   time.Now(),
+	}, notBefore)
+	if errors.Is(err, idempotency.ErrDuplicateKey) {
+		// A concurrent request with the same key claimed it first
+		return uc.replay(ctx, req, notBefore)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+
+	resp, err := uc.create(ctx, req)
+	if err != nil {
+		// Nothing was created, so a retry with the key may try again
+		_ = uc.idempotencyRepo.Release(ctx, req.CreatedBy, req.IdempotencyKey)
+		return nil, err
+	}
+
+	// A failure to record the short code is not reported: the URL exists, and
+	// failing now would only prompt the client to retry. Retries see the claim
+	// as in progress until it expires.
+	_ = uc.idempotencyRepo.Complete(ctx, req.CreatedBy, req.IdempotencyKey, resp.ShortCode)
+	return resp, nil
+}
+
+// replay returns the stored result for req's idempotency key
+func (uc *CreateURLUseCase) replay(ctx context.Context, req CreateURLRequest, notBefore time.Time) (*CreateURLResponse, error) {
+	rec, err := uc.idempotencyRepo.Find(ctx, req.CreatedBy, req.IdempotencyKey, notBefore)
+	if errors.Is(err, idempotency.ErrKeyNotFound) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	if rec.Pending() {
+		if rec.OriginalURL != req.OriginalURL {
+			return nil, idempotency.ErrKeyMismatch
+		}
+		return nil, idempotency.ErrKeyInProgress
+	}
+	if !rec.Matches(req.OriginalURL, req.ShortCode) {
+		return nil, idempotency.ErrKeyMismatch
+	}
+	return uc.response(&url.URL{ShortCode: rec.ShortCode, OriginalURL: rec.OriginalURL}, false), nil
+}
+
+func (uc *CreateURLUseCase) create(ctx context.Context, req CreateURLRequest) (*CreateURLResponse, error) {
+	// A caller-chosen short code always gets its own URL
+	if uc.dedupeRepo != nil && req.ShortCode == "" {
+		existing, err := uc.dedupeRepo.FindByOriginalURL(ctx, req.CreatedBy, req.OriginalURL)
+		if err == nil {
+			return uc.response(existing, true), nil
+		}
+		if !errors.Is(err, url.ErrURLNotFound) {
+			return nil, fmt.Errorf("failed to look up existing URL: %w", err)
+		}
+	}
+
+	// Generate (or use the requested) short code and store the shortened URL
+	var shortenedURL *url.URL
+	var err error
+	if req.ShortCode != "" {
+		shortenedURL, err = uc.generator.ShortenURLWithCode(ctx, req.OriginalURL, req.ShortCode, req.CreatedBy)
+	} else {
+		shortenedURL, err = uc.generator.ShortenURL(ctx, req.OriginalURL, req.CreatedBy)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shortened URL: %w", err)
+	}
+
+	// Only new URLs are audited and announced; reused and replayed results created nothing
+	uc.auditLogger.Log(ctx, audit.ActionCreate, shortenedURL.ShortCode, req.CreatedBy, req.ClientIP)
+
+	resp := uc.response(shortenedURL, false)
+	for _, notifier := range uc.createdNotifiers {
+		notifier.NotifyURLCreated(ctx, shortenedURL, resp.ShortURL)
+	}
+	return resp, nil
+}
+
+func (uc *CreateURLUseCase) response(u *url.URL, reused bool) *CreateURLResponse {
 	return &CreateURLResponse{
-		ShortCode:   shortenedURL.ShortCode,
-		ShortURL:    shortURL,
-		OriginalURL: shortenedURL.OriginalURL,
-	}, nil
+		ShortCode:   u.ShortCode,
+		ShortURL:    fmt.Sprintf("%s/%s", uc.baseURL, u.ShortCode),
+		OriginalURL: u.OriginalURL,
+		Reused:      reused,
+	}
 }
@@ -3,11 +3,16 @@ package application
 import (
 	"context"
 	"errors"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+	"github.com/matt-riley/mjrwtf/internal/domain/idempotency"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
+	"github.com/rs/zerolog"
 )
 
 // mockRepository is a mock implementation of url.Repository for testing
@@ -36,11 +41,68 @@ func (m *mockRepository) FindByShortCode(ctx context.Context, shortCode string)
 	return nil, url.ErrURLNotFound
 }
 
+func (m *mockRepository) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	for _, u := range m.urls {
+		if u.CreatedBy == createdBy && u.OriginalURL == originalURL && !u.IsDeleted() {
+			return u, nil
+		}
+	}
+	return nil, url.ErrURLNotFound
+}
+
 func (m *mockRepository) Delete(ctx context.Context, shortCode string) error {
 	delete(m.urls, shortCode)
 	return nil
 }
 
+func (m *mockRepository) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	if err := url.ValidateShortCodePrefix(prefix); err != nil {
+		return nil, err
+	}
+	var deleted []string
+	for code, u := range m.urls {
+		if u.CreatedBy == createdBy && strings.HasPrefix(code, prefix) {
+			delete(m.urls, code)
+			deleted = append(deleted, code)
+		}
+	}
+	sort.Strings(deleted)
+	return deleted, nil
+}
+
+func (m *mockRepository) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	u, exists := m.urls[shortCode]
+	if !exists || u.IsDeleted() {
+		return url.ErrURLNotFound
+	}
+	u.DeletedAt = &deletedAt
+	return nil
+}
+
+func (m *mockRepository) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	if err := url.ValidateShortCodePrefix(prefix); err != nil {
+		return nil, err
+	}
+	var deleted []string
+	for code, u := range m.urls {
+		if u.CreatedBy == createdBy && strings.HasPrefix(code, prefix) && !u.IsDeleted() {
+			u.DeletedAt = &deletedAt
+			deleted = append(deleted, code)
+		}
+	}
+	sort.Strings(deleted)
+	return deleted, nil
+}
+
+func (m *mockRepository) Restore(ctx context.Context, shortCode string) error {
+	u, exists := m.urls[shortCode]
+	if !exists {
+		return url.ErrURLNotFound
+	}
+	u.DeletedAt = nil
+	return nil
+}
+
 func (m *mockRepository) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
 	return nil, nil
 }
@@ -53,6 +115,18 @@ func (m *mockRepository) Count(ctx context.Context, createdBy string) (int, erro
 	return 0, nil
 }
 
+func (m *mockRepository) CountAll(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *mockRepository) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	return nil, nil
+}
+
+func (m *mockRepository) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	return 0, nil
+}
+
 func TestNewCreateURLUseCase(t *testing.T) {
 	repo := newMockRepository()
 	gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
@@ -282,6 +356,484 @@ func TestCreateURLUseCase_Execute_CollisionHandling(t *testing.T) {
 	})
 }
 
+func TestCreateURLUseCase_Execute_CustomShortCode(t *testing.T) {
+	repo := newMockRepository()
+	gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	uc := NewCreateURLUseCase(gen, "https://mjr.wtf")
+
+	res, err := uc.Execute(context.Background(), CreateURLRequest{
+		OriginalURL: "https://example.com",
+		CreatedBy:   "user1",
+		ShortCode:   "my-link",
+	})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if res.ShortCode != "my-link" || res.ShortURL != "https://mjr.wtf/my-link" {
+		t.Errorf("Execute() = %+v, want short code my-link", res)
+	}
+
+	_, err = uc.Execute(context.Background(), CreateURLRequest{
+		OriginalURL: "https://example.org",
+		CreatedBy:   "user2",
+		ShortCode:   "my-link",
+	})
+	if !errors.Is(err, url.ErrDuplicateShortCode) {
+		t.Errorf("Execute() with taken code error = %v, want %v", err, url.ErrDuplicateShortCode)
+	}
+}
+
+func TestCreateURLUseCase_Execute_DedupeDestinations(t *testing.T) {
+	tests := []struct {
+		name       string
+		dedupe     bool
+		wantReused bool
+	}{
+		{name: "dedupe on returns existing URL", dedupe: true, wantReused: true},
+		{name: "dedupe off mints a new code", dedupe: false, wantReused: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			var opts []CreateOption
+			if tt.dedupe {
+				opts = append(opts, WithDedupeDestinations(repo))
+			}
+			uc := NewCreateURLUseCase(gen, "https://mjr.wtf", opts...)
+
+			req := CreateURLRequest{OriginalURL: "https://example.com/page", CreatedBy: "user1"}
+			first, err := uc.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("first Execute() error = %v", err)
+			}
+			if first.Reused {
+				t.Error("first Execute() Reused = true, want false")
+			}
+
+			second, err := uc.Execute(context.Background(), req)
+			if err != nil {
+				t.Fatalf("second Execute() error = %v", err)
+			}
+			if second.Reused != tt.wantReused {
+				t.Errorf("second Execute() Reused = %v, want %v", second.Reused, tt.wantReused)
+			}
+			if sameCode := second.ShortCode == first.ShortCode; sameCode != tt.wantReused {
+				t.Errorf("second Execute() short code = %q, first = %q", second.ShortCode, first.ShortCode)
+			}
+			if second.ShortURL != "https://mjr.wtf/"+second.ShortCode {
+				t.Errorf("second Execute() ShortURL = %q", second.ShortURL)
+			}
+		})
+	}
+
+	t.Run("scoped per creator and skipped for custom codes", func(t *testing.T) {
+		repo := newMockRepository()
+		gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
+		if err != nil {
+			t.Fatalf("NewGenerator() error = %v", err)
+		}
+		uc := NewCreateURLUseCase(gen, "https://mjr.wtf", WithDedupeDestinations(repo))
+
+		first, err := uc.Execute(context.Background(), CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		other, err := uc.Execute(context.Background(), CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user2"})
+		if err != nil {
+			t.Fatalf("Execute() for another creator error = %v", err)
+		}
+		if other.Reused || other.ShortCode == first.ShortCode {
+			t.Errorf("Execute() for another creator reused %q", first.ShortCode)
+		}
+
+		custom, err := uc.Execute(context.Background(), CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", ShortCode: "my-link"})
+		if err != nil {
+			t.Fatalf("Execute() with custom code error = %v", err)
+		}
+		if custom.Reused || custom.ShortCode != "my-link" {
+			t.Errorf("Execute() with custom code = %+v, want new my-link", custom)
+		}
+	})
+
+	t.Run("matches normalized destinations", func(t *testing.T) {
+		repo := newMockRepository()
+		gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
+		if err != nil {
+			t.Fatalf("NewGenerator() error = %v", err)
+		}
+		uc := NewCreateURLUseCase(gen, "https://mjr.wtf", WithDedupeDestinations(repo))
+
+		first, err := uc.Execute(context.Background(), CreateURLRequest{OriginalURL: "https://example.com/Page", CreatedBy: "user1"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+
+		second, err := uc.Execute(context.Background(), CreateURLRequest{OriginalURL: "  HTTPS://Example.COM/Page\n", CreatedBy: "user1"})
+		if err != nil {
+			t.Fatalf("Execute() with unnormalized destination error = %v", err)
+		}
+		if !second.Reused || second.ShortCode != first.ShortCode {
+			t.Errorf("Execute() with unnormalized destination = %+v, want reuse of %q", second, first.ShortCode)
+		}
+	})
+}
+
+// mockIdempotencyRepository is a map-backed idempotency.Repository for testing
+type mockIdempotencyRepository struct {
+	records map[string]*idempotency.Record
+}
+
+func newMockIdempotencyRepository() *mockIdempotencyRepository {
+	return &mockIdempotencyRepository{records: make(map[string]*idempotency.Record)}
+}
+
+func (m *mockIdempotencyRepository) Find(ctx context.Context, createdBy, key string, notBefore time.Time) (*idempotency.Record, error) {
+	rec, ok := m.records[createdBy+"\x00"+key]
+	if !ok || rec.CreatedAt.Before(notBefore) {
+		return nil, idempotency.ErrKeyNotFound
+	}
+	return rec, nil
+}
+
+func (m *mockIdempotencyRepository) Save(ctx context.Context, rec *idempotency.Record, notBefore time.Time) error {
+	if _, err := m.Find(ctx, rec.CreatedBy, rec.Key, notBefore); err == nil {
+		return idempotency.ErrDuplicateKey
+	}
+	m.records[rec.CreatedBy+"\x00"+rec.Key] = rec
+	return nil
+}
+
+func (m *mockIdempotencyRepository) Complete(ctx context.Context, createdBy, key, shortCode string) error {
+	if rec, ok := m.records[createdBy+"\x00"+key]; ok {
+		rec.ShortCode = shortCode
+	}
+	return nil
+}
+
+func (m *mockIdempotencyRepository) Release(ctx context.Context, createdBy, key string) error {
+	delete(m.records, createdBy+"\x00"+key)
+	return nil
+}
+
+// gatedCreateRepository holds the first Create until release is closed
+type gatedCreateRepository struct {
+	*mockRepository
+	gated   atomic.Bool
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (m *gatedCreateRepository) Create(ctx context.Context, u *url.URL) error {
+	if m.gated.CompareAndSwap(false, true) {
+		close(m.entered)
+		<-m.release
+	}
+	return m.mockRepository.Create(ctx, u)
+}
+
+func TestCreateURLUseCase_Execute_IdempotencyKeyConcurrent(t *testing.T) {
+	repo := &gatedCreateRepository{
+		mockRepository: newMockRepository(),
+		entered:        make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+	gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	uc := NewCreateURLUseCase(gen, "https://mjr.wtf", WithIdempotencyKeys(newMockIdempotencyRepository(), time.Hour))
+	req := CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", IdempotencyKey: "key-1"}
+
+	// The first request stalls while storing its URL...
+	done := make(chan error, 1)
+	go func() {
+		_, err := uc.Execute(context.Background(), req)
+		done <- err
+	}()
+	<-repo.entered
+
+	// ...so a second request with the same key must not create one too
+	if _, err := uc.Execute(context.Background(), req); !errors.Is(err, idempotency.ErrKeyInProgress) {
+		t.Errorf("concurrent Execute() error = %v, want %v", err, idempotency.ErrKeyInProgress)
+	}
+
+	close(repo.release)
+	if err := <-done; err != nil {
+		t.Fatalf("first Execute() error = %v", err)
+	}
+	if len(repo.urls) != 1 {
+		t.Errorf("stored %d URLs, want 1", len(repo.urls))
+	}
+
+	// Once the first request has finished, the key replays its URL
+	resp, err := uc.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Execute() after the first finished error = %v", err)
+	}
+	if _, ok := repo.urls[resp.ShortCode]; !ok || len(repo.urls) != 1 {
+		t.Errorf("Execute() = %q, want the single stored URL", resp.ShortCode)
+	}
+}
+
+func TestCreateURLUseCase_Execute_IdempotencyKey(t *testing.T) {
+	newUseCase := func(t *testing.T, idemRepo idempotency.Repository, ttl time.Duration) (*CreateURLUseCase, *mockRepository) {
+		t.Helper()
+		repo := newMockRepository()
+		gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
+		if err != nil {
+			t.Fatalf("NewGenerator() error = %v", err)
+		}
+		return NewCreateURLUseCase(gen, "https://mjr.wtf", WithIdempotencyKeys(idemRepo, ttl)), repo
+	}
+	ctx := context.Background()
+
+	t.Run("same key returns the first result", func(t *testing.T) {
+		uc, repo := newUseCase(t, newMockIdempotencyRepository(), time.Hour)
+		req := CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", IdempotencyKey: "key-1"}
+
+		first, err := uc.Execute(ctx, req)
+		if err != nil {
+			t.Fatalf("first Execute() error = %v", err)
+		}
+		second, err := uc.Execute(ctx, req)
+		if err != nil {
+			t.Fatalf("second Execute() error = %v", err)
+		}
+		if second.ShortCode != first.ShortCode || second.ShortURL != first.ShortURL {
+			t.Errorf("second Execute() = %+v, want %+v", second, first)
+		}
+		if len(repo.urls) != 1 {
+			t.Errorf("stored %d URLs, want 1", len(repo.urls))
+		}
+	})
+
+	t.Run("different keys and creators create distinct URLs", func(t *testing.T) {
+		uc, repo := newUseCase(t, newMockIdempotencyRepository(), time.Hour)
+
+		a, err := uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", IdempotencyKey: "key-1"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		b, err := uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", IdempotencyKey: "key-2"})
+		if err != nil {
+			t.Fatalf("Execute() with another key error = %v", err)
+		}
+		c, err := uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user2", IdempotencyKey: "key-1"})
+		if err != nil {
+			t.Fatalf("Execute() for another creator error = %v", err)
+		}
+		if a.ShortCode == b.ShortCode || a.ShortCode == c.ShortCode || b.ShortCode == c.ShortCode {
+			t.Errorf("short codes not distinct: %q, %q, %q", a.ShortCode, b.ShortCode, c.ShortCode)
+		}
+		if len(repo.urls) != 3 {
+			t.Errorf("stored %d URLs, want 3", len(repo.urls))
+		}
+	})
+
+	t.Run("reused key with a different request is rejected", func(t *testing.T) {
+		uc, _ := newUseCase(t, newMockIdempotencyRepository(), time.Hour)
+
+		if _, err := uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", IdempotencyKey: "key-1"}); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		_, err := uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.org", CreatedBy: "user1", IdempotencyKey: "key-1"})
+		if !errors.Is(err, idempotency.ErrKeyMismatch) {
+			t.Errorf("Execute() error = %v, want %v", err, idempotency.ErrKeyMismatch)
+		}
+	})
+
+	t.Run("expired key creates a new URL", func(t *testing.T) {
+		idemRepo := newMockIdempotencyRepository()
+		idemRepo.records["user1\x00key-1"] = &idempotency.Record{
+			CreatedBy:   "user1",
+			Key:         "key-1",
+			OriginalURL: "https://example.com",
+			ShortCode:   "old123",
+			CreatedAt:   time.Now().Add(-2 * time.Hour),
+		}
+		uc, _ := newUseCase(t, idemRepo, time.Hour)
+
+		resp, err := uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", IdempotencyKey: "key-1"})
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if resp.ShortCode == "old123" {
+			t.Error("Execute() replayed an expired key")
+		}
+	})
+
+	t.Run("key claimed by an unfinished request is in progress", func(t *testing.T) {
+		idemRepo := newMockIdempotencyRepository()
+		idemRepo.records["user1\x00key-1"] = &idempotency.Record{
+			CreatedBy:   "user1",
+			Key:         "key-1",
+			OriginalURL: "https://example.com",
+			CreatedAt:   time.Now(),
+		}
+		uc, repo := newUseCase(t, idemRepo, time.Hour)
+
+		_, err := uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", IdempotencyKey: "key-1"})
+		if !errors.Is(err, idempotency.ErrKeyInProgress) {
+			t.Errorf("Execute() error = %v, want %v", err, idempotency.ErrKeyInProgress)
+		}
+		_, err = uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.org", CreatedBy: "user1", IdempotencyKey: "key-1"})
+		if !errors.Is(err, idempotency.ErrKeyMismatch) {
+			t.Errorf("Execute() with another URL error = %v, want %v", err, idempotency.ErrKeyMismatch)
+		}
+		if len(repo.urls) != 0 {
+			t.Errorf("stored %d URLs, want 0", len(repo.urls))
+		}
+	})
+
+	t.Run("failed create releases the key", func(t *testing.T) {
+		idemRepo := newMockIdempotencyRepository()
+		uc, repo := newUseCase(t, idemRepo, time.Hour)
+		repo.urls["taken"] = &url.URL{ShortCode: "taken", OriginalURL: "https://example.org", CreatedBy: "user2"}
+
+		req := CreateURLRequest{OriginalURL: "https://example.com", ShortCode: "taken", CreatedBy: "user1", IdempotencyKey: "key-1"}
+		if _, err := uc.Execute(ctx, req); !errors.Is(err, url.ErrDuplicateShortCode) {
+			t.Fatalf("Execute() error = %v, want %v", err, url.ErrDuplicateShortCode)
+		}
+		if len(idemRepo.records) != 0 {
+			t.Errorf("kept %d idempotency records after a failed create, want 0", len(idemRepo.records))
+		}
+
+		req.ShortCode = "free"
+		if _, err := uc.Execute(ctx, req); err != nil {
+			t.Errorf("Execute() retry with the key error = %v", err)
+		}
+	})
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		uc, repo := newUseCase(t, newMockIdempotencyRepository(), time.Hour)
+
+		_, err := uc.Execute(ctx, CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", IdempotencyKey: strings.Repeat("k", 256)})
+		if !errors.Is(err, idempotency.ErrInvalidKey) {
+			t.Errorf("Execute() error = %v, want %v", err, idempotency.ErrInvalidKey)
+		}
+		if len(repo.urls) != 0 {
+			t.Errorf("stored %d URLs, want 0", len(repo.urls))
+		}
+	})
+
+	t.Run("no key always creates", func(t *testing.T) {
+		uc, repo := newUseCase(t, newMockIdempotencyRepository(), time.Hour)
+		req := CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1"}
+
+		for i := 0; i < 2; i++ {
+			if _, err := uc.Execute(ctx, req); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+		}
+		if len(repo.urls) != 2 {
+			t.Errorf("stored %d URLs, want 2", len(repo.urls))
+		}
+	})
+}
+
+func TestCreateURLUseCase_Execute_Audit(t *testing.T) {
+	repo := newMockRepository()
+	gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	auditRepo := &mockAuditRepository{}
+	uc := NewCreateURLUseCase(gen, "https://mjr.wtf",
+		WithAuditLogger(NewAuditLogger(auditRepo, zerolog.Nop())),
+		WithDedupeDestinations(repo),
+	)
+	req := CreateURLRequest{OriginalURL: "https://example.com", CreatedBy: "user1", ClientIP: "192.0.2.1"}
+
+	resp, err := uc.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(auditRepo.entries) != 1 {
+		t.Fatalf("recorded %d entries, want 1", len(auditRepo.entries))
+	}
+	got := auditRepo.entries[0]
+	if got.Action != audit.ActionCreate || got.ShortCode != resp.ShortCode || got.UserID != "user1" || got.IPAddress != "192.0.2.1" {
+		t.Errorf("recorded entry = %+v", got)
+	}
+
+	// A reused URL was not created, so it is not audited
+	if resp, err := uc.Execute(context.Background(), req); err != nil || !resp.Reused {
+		t.Fatalf("second Execute() = %+v, %v, want reused", resp, err)
+	}
+	if len(auditRepo.entries) != 1 {
+		t.Errorf("reused URL recorded an entry, have %d", len(auditRepo.entries))
+	}
+
+	// A failing audit trail does not fail the create
+	uc = NewCreateURLUseCase(gen, "https://mjr.wtf", WithAuditLogger(NewAuditLogger(&mockAuditRepository{err: errors.New("db down")}, zerolog.Nop())))
+	if _, err := uc.Execute(context.Background(), CreateURLRequest{OriginalURL: "https://example.org", CreatedBy: "user1"}); err != nil {
+		t.Errorf("Execute() with failing audit error = %v", err)
+	}
+}
+
+type createdNotification struct {
+	url      *url.URL
+	shortURL string
+}
+
+type fakeCreatedNotifier struct {
+	notified []createdNotification
+}
+
+func (f *fakeCreatedNotifier) NotifyURLCreated(ctx context.Context, u *url.URL, shortURL string) {
+	f.notified = append(f.notified, createdNotification{url: u, shortURL: shortURL})
+}
+
+func TestCreateURLUseCase_Execute_CreatedNotifier(t *testing.T) {
+	repo := newMockRepository()
+	gen, err := url.NewGenerator(repo, url.DefaultGeneratorConfig())
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	notifier, second := &fakeCreatedNotifier{}, &fakeCreatedNotifier{}
+	uc := NewCreateURLUseCase(gen, "https://mjr.wtf",
+		WithCreatedNotifier(notifier),
+		WithCreatedNotifier(second),
+		WithDedupeDestinations(repo),
+	)
+	req := CreateURLRequest{OriginalURL: "https://example.com/page", CreatedBy: "user1"}
+
+	resp, err := uc.Execute(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(notifier.notified) != 1 {
+		t.Fatalf("sent %d notifications, want 1", len(notifier.notified))
+	}
+	got := notifier.notified[0]
+	if got.url.ShortCode != resp.ShortCode || got.url.OriginalURL != req.OriginalURL || got.url.CreatedBy != "user1" {
+		t.Errorf("notified URL = %+v", got.url)
+	}
+	if got.shortURL != resp.ShortURL {
+		t.Errorf("notified short URL = %q, want %q", got.shortURL, resp.ShortURL)
+	}
+	if len(second.notified) != 1 {
+		t.Errorf("second notifier sent %d notifications, want 1", len(second.notified))
+	}
+
+	// A reused URL was not created, so it is not announced
+	if resp, err := uc.Execute(context.Background(), req); err != nil || !resp.Reused {
+		t.Fatalf("second Execute() = %+v, %v, want reused", resp, err)
+	}
+	if len(notifier.notified) != 1 {
+		t.Errorf("reused URL sent a notification, have %d", len(notifier.notified))
+	}
+}
+
 func TestCreateURLUseCase_Execute_MaxRetriesExceeded(t *testing.T) {
 	repo := newMockRepository()
 
@@ -394,10 +946,30 @@ func (m *mockAlwaysCollisionRepo) FindByShortCode(ctx context.Context, shortCode
 	}, nil
 }
 
+func (m *mockAlwaysCollisionRepo) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	return m.wrapped.FindByOriginalURL(ctx, createdBy, originalURL)
+}
+
 func (m *mockAlwaysCollisionRepo) Delete(ctx context.Context, shortCode string) error {
 	return m.wrapped.Delete(ctx, shortCode)
 }
 
+func (m *mockAlwaysCollisionRepo) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	return m.wrapped.DeleteByShortCodePrefix(ctx, createdBy, prefix)
+}
+
+func (m *mockAlwaysCollisionRepo) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	return m.wrapped.SoftDelete(ctx, shortCode, deletedAt)
+}
+
+func (m *mockAlwaysCollisionRepo) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	return m.wrapped.SoftDeleteByShortCodePrefix(ctx, createdBy, prefix, deletedAt)
+}
+
+func (m *mockAlwaysCollisionRepo) Restore(ctx context.Context, shortCode string) error {
+	return m.wrapped.Restore(ctx, shortCode)
+}
+
 func (m *mockAlwaysCollisionRepo) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
 	return m.wrapped.List(ctx, createdBy, limit, offset)
 }
@@ -409,3 +981,15 @@ func (m *mockAlwaysCollisionRepo) ListByCreatedByAndTimeRange(ctx context.Contex
 func (m *mockAlwaysCollisionRepo) Count(ctx context.Context, createdBy string) (int, error) {
 	return m.wrapped.Count(ctx, createdBy)
 }
+
+func (m *mockAlwaysCollisionRepo) CountAll(ctx context.Context) (int, error) {
+	return m.wrapped.CountAll(ctx)
+}
+
+func (m *mockAlwaysCollisionRepo) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	return m.wrapped.Search(ctx, createdBy, query, limit, offset)
+}
+
+func (m *mockAlwaysCollisionRepo) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	return m.wrapped.CountMatching(ctx, createdBy, query)
+}
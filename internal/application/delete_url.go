@@ -3,7 +3,9 @@ package application
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 )
 
@@ -11,6 +13,7 @@ import (
 type DeleteURLRequest struct {
 	ShortCode   string
 	RequestedBy string
+	ClientIP    string // Recorded in the audit trail when one is configured
 }
 
 // DeleteURLResponse represents the output after deleting a shortened URL
@@ -18,15 +21,51 @@ type DeleteURLResponse struct {
 	Success bool
 }
 
+// DeleteOption configures how DeleteURLUseCase and DeleteURLsByPrefixUseCase remove URLs
+type DeleteOption func(*deleteOptions)
+
+type deleteOptions struct {
+	softDelete  bool
+	auditLogger *AuditLogger
+}
+
+// WithSoftDelete marks URLs as deleted instead of removing them, so their
+// analytics survive and they can be restored with RestoreURLUseCase
+func WithSoftDelete() DeleteOption {
+	return func(o *deleteOptions) {
+		o.softDelete = true
+	}
+}
+
+// WithDeleteAuditLogger records each successful delete in the audit trail
+func WithDeleteAuditLogger(auditLogger *AuditLogger) DeleteOption {
+	return func(o *deleteOptions) {
+		o.auditLogger = auditLogger
+	}
+}
+
+func applyDeleteOptions(opts []DeleteOption) deleteOptions {
+	var o deleteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // DeleteURLUseCase handles the deletion of shortened URLs with authorization
 type DeleteURLUseCase struct {
-	urlRepo url.Repository
+	urlRepo     url.Repository
+	softDelete  bool
+	auditLogger *AuditLogger
 }
 
 // NewDeleteURLUseCase creates a new DeleteURLUseCase
-func NewDeleteURLUseCase(urlRepo url.Repository) *DeleteURLUseCase {
+func NewDeleteURLUseCase(urlRepo url.Repository, opts ...DeleteOption) *DeleteURLUseCase {
+	o := applyDeleteOptions(opts)
 	return &DeleteURLUseCase{
-		urlRepo: urlRepo,
+		urlRepo:     urlRepo,
+		softDelete:  o.softDelete,
+		auditLogger: o.auditLogger,
 	}
 }
 
@@ -53,10 +92,25 @@ func (uc *DeleteURLUseCase) Execute(ctx context.Context, req DeleteURLRequest) (
 		return nil, url.ErrUnauthorizedDeletion
 	}
 
-	// Delete the URL from the repository
+	if uc.softDelete {
+		// A URL that is already soft-deleted is gone as far as the caller is concerned
+		if foundURL.IsDeleted() {
+			return nil, url.ErrURLNotFound
+		}
+		if err := uc.urlRepo.SoftDelete(ctx, req.ShortCode, time.Now()); err != nil {
+			return nil, fmt.Errorf("failed to delete URL: %w", err)
+		}
+		uc.auditLogger.Log(ctx, audit.ActionDelete, req.ShortCode, req.RequestedBy, req.ClientIP)
+		return &DeleteURLResponse{
+			Success: true,
+		}, nil
+	}
+
+	// Delete the URL from the repository; this also purges URLs soft-deleted earlier
 	if err := uc.urlRepo.Delete(ctx, req.ShortCode); err != nil {
 		return nil, fmt.Errorf("failed to delete URL: %w", err)
 	}
+	uc.auditLogger.Log(ctx, audit.ActionDelete, req.ShortCode, req.RequestedBy, req.ClientIP)
 
 	return &DeleteURLResponse{
 		Success: true,
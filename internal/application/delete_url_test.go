@@ -6,7 +6,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
+	"github.com/rs/zerolog"
 )
 
 func TestNewDeleteURLUseCase(t *testing.T) {
@@ -338,3 +340,95 @@ func TestDeleteURLUseCase_Execute_IdempotentCheck(t *testing.T) {
 		}
 	})
 }
+
+func TestDeleteURLUseCase_Execute_SoftDelete(t *testing.T) {
+	repo := newMockRepository()
+	repo.urls["soft123"] = &url.URL{
+		ID:          1,
+		ShortCode:   "soft123",
+		OriginalURL: "https://example.com",
+		CreatedAt:   time.Now(),
+		CreatedBy:   "user1",
+	}
+
+	uc := NewDeleteURLUseCase(repo, WithSoftDelete())
+	req := DeleteURLRequest{ShortCode: "soft123", RequestedBy: "user1"}
+
+	if _, err := uc.Execute(context.Background(), req); err != nil {
+		t.Fatalf("Execute() unexpected error = %v", err)
+	}
+
+	// The row is kept and marked as deleted
+	u, ok := repo.urls["soft123"]
+	if !ok {
+		t.Fatal("Execute() removed the URL, want it soft-deleted")
+	}
+	if !u.IsDeleted() {
+		t.Error("Execute() did not mark the URL as deleted")
+	}
+
+	// Deleting again reports not found
+	if _, err := uc.Execute(context.Background(), req); !errors.Is(err, url.ErrURLNotFound) {
+		t.Errorf("Execute() second delete error = %v, want %v", err, url.ErrURLNotFound)
+	}
+
+	// Hard delete purges a soft-deleted URL
+	if _, err := NewDeleteURLUseCase(repo).Execute(context.Background(), req); err != nil {
+		t.Fatalf("Execute() hard delete error = %v", err)
+	}
+	if _, ok := repo.urls["soft123"]; ok {
+		t.Error("Execute() hard delete left the soft-deleted URL in place")
+	}
+}
+
+func TestDeleteURLUseCase_Execute_Audit(t *testing.T) {
+	for _, soft := range []bool{false, true} {
+		repo := newMockRepository()
+		repo.urls["audit123"] = &url.URL{
+			ID:          1,
+			ShortCode:   "audit123",
+			OriginalURL: "https://example.com",
+			CreatedAt:   time.Now(),
+			CreatedBy:   "user1",
+		}
+		auditRepo := &mockAuditRepository{}
+		opts := []DeleteOption{WithDeleteAuditLogger(NewAuditLogger(auditRepo, zerolog.Nop()))}
+		if soft {
+			opts = append(opts, WithSoftDelete())
+		}
+		uc := NewDeleteURLUseCase(repo, opts...)
+
+		// A rejected delete is not audited
+		if _, err := uc.Execute(context.Background(), DeleteURLRequest{ShortCode: "audit123", RequestedBy: "user2"}); !errors.Is(err, url.ErrUnauthorizedDeletion) {
+			t.Fatalf("soft=%v: Execute() by another user error = %v", soft, err)
+		}
+		if len(auditRepo.entries) != 0 {
+			t.Fatalf("soft=%v: rejected delete recorded %d entries", soft, len(auditRepo.entries))
+		}
+
+		if _, err := uc.Execute(context.Background(), DeleteURLRequest{ShortCode: "audit123", RequestedBy: "user1", ClientIP: "192.0.2.1"}); err != nil {
+			t.Fatalf("soft=%v: Execute() error = %v", soft, err)
+		}
+		if len(auditRepo.entries) != 1 {
+			t.Fatalf("soft=%v: recorded %d entries, want 1", soft, len(auditRepo.entries))
+		}
+		got := auditRepo.entries[0]
+		if got.Action != audit.ActionDelete || got.ShortCode != "audit123" || got.UserID != "user1" || got.IPAddress != "192.0.2.1" {
+			t.Errorf("soft=%v: recorded entry = %+v", soft, got)
+		}
+	}
+
+	t.Run("audit failure does not block the delete", func(t *testing.T) {
+		repo := newMockRepository()
+		repo.urls["audit123"] = &url.URL{ShortCode: "audit123", OriginalURL: "https://example.com", CreatedBy: "user1"}
+		auditLogger := NewAuditLogger(&mockAuditRepository{err: errors.New("db down")}, zerolog.Nop())
+		uc := NewDeleteURLUseCase(repo, WithDeleteAuditLogger(auditLogger))
+
+		if _, err := uc.Execute(context.Background(), DeleteURLRequest{ShortCode: "audit123", RequestedBy: "user1"}); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if _, ok := repo.urls["audit123"]; ok {
+			t.Error("Execute() did not delete the URL")
+		}
+	})
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// DeleteURLsByPrefixRequest represents the input for deleting a user's URLs by short code prefix
+type DeleteURLsByPrefixRequest struct {
+	Prefix      string
+	RequestedBy string
+	ClientIP    string // Recorded in the audit trail when one is configured
+}
+
+// DeleteURLsByPrefixResponse represents the output after deleting URLs by short code prefix
+type DeleteURLsByPrefixResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// DeleteURLsByPrefixUseCase handles bulk deletion of a user's URLs whose short code shares a prefix
+type DeleteURLsByPrefixUseCase struct {
+	urlRepo     url.Repository
+	softDelete  bool
+	auditLogger *AuditLogger
+}
+
+// NewDeleteURLsByPrefixUseCase creates a new DeleteURLsByPrefixUseCase
+func NewDeleteURLsByPrefixUseCase(urlRepo url.Repository, opts ...DeleteOption) *DeleteURLsByPrefixUseCase {
+	o := applyDeleteOptions(opts)
+	return &DeleteURLsByPrefixUseCase{
+		urlRepo:     urlRepo,
+		softDelete:  o.softDelete,
+		auditLogger: o.auditLogger,
+	}
+}
+
+// Execute deletes every URL owned by the requester whose short code starts with the prefix.
+// Ownership is enforced by scoping the delete to the requester, so other users' URLs are never touched.
+func (uc *DeleteURLsByPrefixUseCase) Execute(ctx context.Context, req DeleteURLsByPrefixRequest) (*DeleteURLsByPrefixResponse, error) {
+	// Validate prefix - an empty prefix would match every URL the user owns
+	if err := url.ValidateShortCodePrefix(req.Prefix); err != nil {
+		return nil, err
+	}
+
+	// Validate requested by
+	if req.RequestedBy == "" {
+		return nil, url.ErrInvalidCreatedBy
+	}
+
+	var (
+		codes []string
+		err   error
+	)
+	if uc.softDelete {
+		codes, err = uc.urlRepo.SoftDeleteByShortCodePrefix(ctx, req.RequestedBy, req.Prefix, time.Now())
+	} else {
+		codes, err = uc.urlRepo.DeleteByShortCodePrefix(ctx, req.RequestedBy, req.Prefix)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete URLs: %w", err)
+	}
+
+	// Record each code so its own audit trail shows the delete
+	for _, code := range codes {
+		uc.auditLogger.Log(ctx, audit.ActionDelete, code, req.RequestedBy, req.ClientIP)
+	}
+
+	return &DeleteURLsByPrefixResponse{
+		Deleted: int64(len(codes)),
+	}, nil
+}
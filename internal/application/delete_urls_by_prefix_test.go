@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+	"github.com/rs/zerolog"
+)
+
+func TestDeleteURLsByPrefixUseCase_Execute(t *testing.T) {
+	seed := func(repo *mockRepository) {
+		for _, u := range []struct{ shortCode, createdBy string }{
+			{"launch-a", "user1"},
+			{"launch-b", "user1"},
+			{"launch-c", "user2"},
+			{"other1", "user1"},
+		} {
+			repo.urls[u.shortCode] = &url.URL{
+				ShortCode:   u.shortCode,
+				OriginalURL: "https://example.com",
+				CreatedAt:   time.Now(),
+				CreatedBy:   u.createdBy,
+			}
+		}
+	}
+
+	tests := []struct {
+		name        string
+		request     DeleteURLsByPrefixRequest
+		wantErr     error
+		wantDeleted int64
+		wantKept    []string
+	}{
+		{
+			name:        "deletes only the requester's matching URLs",
+			request:     DeleteURLsByPrefixRequest{Prefix: "launch-", RequestedBy: "user1"},
+			wantDeleted: 2,
+			wantKept:    []string{"launch-c", "other1"},
+		},
+		{
+			name:        "no matches",
+			request:     DeleteURLsByPrefixRequest{Prefix: "missing", RequestedBy: "user1"},
+			wantDeleted: 0,
+			wantKept:    []string{"launch-a", "launch-b", "launch-c", "other1"},
+		},
+		{
+			name:     "empty prefix",
+			request:  DeleteURLsByPrefixRequest{Prefix: "", RequestedBy: "user1"},
+			wantErr:  url.ErrEmptyShortCodePrefix,
+			wantKept: []string{"launch-a", "launch-b", "launch-c", "other1"},
+		},
+		{
+			name:    "invalid prefix",
+			request: DeleteURLsByPrefixRequest{Prefix: "launch*", RequestedBy: "user1"},
+			wantErr: url.ErrInvalidShortCodePrefix,
+		},
+		{
+			name:    "empty requested by",
+			request: DeleteURLsByPrefixRequest{Prefix: "launch-", RequestedBy: ""},
+			wantErr: url.ErrInvalidCreatedBy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			seed(repo)
+
+			uc := NewDeleteURLsByPrefixUseCase(repo)
+			res, err := uc.Execute(context.Background(), tt.request)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Execute() unexpected error = %v", err)
+				}
+				if res.Deleted != tt.wantDeleted {
+					t.Errorf("Execute() Deleted = %d, want %d", res.Deleted, tt.wantDeleted)
+				}
+			}
+
+			for _, code := range tt.wantKept {
+				if _, ok := repo.urls[code]; !ok {
+					t.Errorf("URL %q was deleted, want kept", code)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteURLsByPrefixUseCase_Execute_SoftDelete(t *testing.T) {
+	repo := newMockRepository()
+	for _, code := range []string{"launch-a", "launch-b"} {
+		repo.urls[code] = &url.URL{ShortCode: code, CreatedBy: "user1"}
+	}
+
+	uc := NewDeleteURLsByPrefixUseCase(repo, WithSoftDelete())
+	res, err := uc.Execute(context.Background(), DeleteURLsByPrefixRequest{Prefix: "launch-", RequestedBy: "user1"})
+	if err != nil {
+		t.Fatalf("Execute() unexpected error = %v", err)
+	}
+	if res.Deleted != 2 {
+		t.Errorf("Execute() Deleted = %d, want 2", res.Deleted)
+	}
+
+	for _, code := range []string{"launch-a", "launch-b"} {
+		u, ok := repo.urls[code]
+		if !ok || !u.IsDeleted() {
+			t.Errorf("URL %q should be kept and marked deleted", code)
+		}
+	}
+}
+
+func TestDeleteURLsByPrefixUseCase_Execute_Audit(t *testing.T) {
+	repo := newMockRepository()
+	repo.urls["launch-a"] = &url.URL{ShortCode: "launch-a", OriginalURL: "https://example.com", CreatedBy: "user1"}
+	repo.urls["launch-b"] = &url.URL{ShortCode: "launch-b", OriginalURL: "https://example.com", CreatedBy: "user1"}
+	auditRepo := &mockAuditRepository{}
+	uc := NewDeleteURLsByPrefixUseCase(repo, WithDeleteAuditLogger(NewAuditLogger(auditRepo, zerolog.Nop())))
+
+	if _, err := uc.Execute(context.Background(), DeleteURLsByPrefixRequest{Prefix: "launch-", RequestedBy: "user1", ClientIP: "192.0.2.1"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(auditRepo.entries) != 2 {
+		t.Fatalf("recorded %d entries, want 2", len(auditRepo.entries))
+	}
+	for i, code := range []string{"launch-a", "launch-b"} {
+		got := auditRepo.entries[i]
+		if got.Action != audit.ActionDelete || got.ShortCode != code || got.UserID != "user1" || got.IPAddress != "192.0.2.1" {
+			t.Errorf("recorded entry %d = %+v", i, got)
+		}
+	}
+
+	// Each removed code shows the delete in its own trail
+	list, err := NewListAuditEntriesUseCase(auditRepo).Execute(context.Background(), ListAuditEntriesRequest{ShortCode: "launch-b"})
+	if err != nil {
+		t.Fatalf("ListAuditEntries Execute() error = %v", err)
+	}
+	if len(list.Entries) != 1 || list.Entries[0].Action != string(audit.ActionDelete) {
+		t.Errorf("audit entries for launch-b = %+v, want one delete", list.Entries)
+	}
+
+	// Nothing left to delete, so nothing to audit
+	if _, err := uc.Execute(context.Background(), DeleteURLsByPrefixRequest{Prefix: "launch-", RequestedBy: "user1"}); err != nil {
+		t.Fatalf("second Execute() error = %v", err)
+	}
+	if len(auditRepo.entries) != 2 {
+		t.Errorf("empty delete recorded an entry, have %d", len(auditRepo.entries))
+	}
+}
@@ -8,45 +8,97 @@ import (
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 )
 
+// Referrer groupings for GetAnalyticsRequest.GroupReferrersBy
+const (
+	ReferrerGroupURL    = "url"    // Full referrer URL (default)
+	ReferrerGroupDomain = "domain" // Referrer domain, e.g. twitter.com
+)
+
 // GetAnalyticsRequest represents the input for getting analytics for a URL
 type GetAnalyticsRequest struct {
 	ShortCode   string
 	RequestedBy string     // User requesting analytics (for ownership verification)
 	StartTime   *time.Time // Optional: filter clicks from this time
 	EndTime     *time.Time // Optional: filter clicks until this time
+
+	GroupReferrersBy string // Optional: ReferrerGroupDomain aggregates ByReferrer by domain
+	TopN             int    // Optional: referrers to keep; 0 uses the use case default, capped at click.MaxTopN
+
+	Granularity click.Granularity // Optional: time-range bucket size; GranularityDay fills ByDate, GranularityHour fills ByHour
 }
 
 // GetAnalyticsResponse represents the analytics data for a URL
 type GetAnalyticsResponse struct {
-	ShortCode   string           `json:"short_code"`
-	OriginalURL string           `json:"original_url"`
-	TotalClicks int64            `json:"total_clicks"`
-	ByCountry   map[string]int64 `json:"by_country"`
-	ByReferrer  map[string]int64 `json:"by_referrer"`
-	ByDate      map[string]int64 `json:"by_date,omitempty"` // Only for all-time stats
-	StartTime   *time.Time       `json:"start_time,omitempty"`
-	EndTime     *time.Time       `json:"end_time,omitempty"`
+	ShortCode      string           `json:"short_code"`
+	OriginalURL    string           `json:"original_url"`
+	TotalClicks    int64            `json:"total_clicks"`
+	UniqueVisitors *int64           `json:"unique_visitors,omitempty"` // Distinct hashed client IPs; nil unless unique visitors are enabled
+	ByCountry      map[string]int64 `json:"by_country"`
+	ByReferrer     map[string]int64 `json:"by_referrer"`
+	ByBrowser      map[string]int64 `json:"by_browser"`
+	ByOS           map[string]int64 `json:"by_os"`
+	ByDate         map[string]int64 `json:"by_date,omitempty"` // All-time stats, or time-range stats with day granularity
+	ByHour         map[string]int64 `json:"by_hour,omitempty"` // Per hour ("YYYY-MM-DD HH:00" UTC); only for hourly time-range queries
+	StartTime      *time.Time       `json:"start_time,omitempty"`
+	EndTime        *time.Time       `json:"end_time,omitempty"`
 }
 
 // GetAnalyticsUseCase handles retrieving analytics for shortened URLs
 type GetAnalyticsUseCase struct {
 	urlRepo   url.Repository
 	clickRepo click.Repository
+	topN      int
+
+	uniqueVisitors bool
+}
+
+// GetAnalyticsOption configures a GetAnalyticsUseCase
+type GetAnalyticsOption func(*GetAnalyticsUseCase)
+
+// WithAnalyticsTopN sets how many referrers are returned when a request does not specify TopN
+func WithAnalyticsTopN(n int) GetAnalyticsOption {
+	return func(uc *GetAnalyticsUseCase) {
+		if n > 0 {
+			uc.topN = n
+		}
+	}
+}
+
+// WithUniqueVisitors includes UniqueVisitors in responses. Only enable it when
+// clicks are recorded with visitor hashes (RedirectURLOptions.VisitorHashSalt).
+func WithUniqueVisitors() GetAnalyticsOption {
+	return func(uc *GetAnalyticsUseCase) {
+		uc.uniqueVisitors = true
+	}
 }
 
 // NewGetAnalyticsUseCase creates a new GetAnalyticsUseCase
-func NewGetAnalyticsUseCase(urlRepo url.Repository, clickRepo click.Repository) *GetAnalyticsUseCase {
-	return &GetAnalyticsUseCase{
+func NewGetAnalyticsUseCase(urlRepo url.Repository, clickRepo click.Repository, opts ...GetAnalyticsOption) *GetAnalyticsUseCase {
+	uc := &GetAnalyticsUseCase{
 		urlRepo:   urlRepo,
 		clickRepo: clickRepo,
+		topN:      click.DefaultTopN,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// resolveTopN returns the number of referrers to keep for a request
+func (uc *GetAnalyticsUseCase) resolveTopN(requested int) int {
+	n := uc.topN
+	if requested > 0 {
+		n = requested
 	}
+	return min(n, click.MaxTopN)
 }
 
 // Execute retrieves analytics data for a specific URL
 func (uc *GetAnalyticsUseCase) Execute(ctx context.Context, req GetAnalyticsRequest) (*GetAnalyticsResponse, error) {
 	// Validate short code
-	if req.ShortCode == "" {
-		return nil, url.ErrEmptyShortCode
+	if err := url.ValidateShortCode(req.ShortCode); err != nil {
+		return nil, err
 	}
 
 	// Validate requested by
@@ -54,48 +106,107 @@ func (uc *GetAnalyticsUseCase) Execute(ctx context.Context, req GetAnalyticsRequ
 		return nil, url.ErrInvalidCreatedBy
 	}
 
+	// Validate time range: none, or both ends with start before end
+	if err := click.ValidateTimeRange(req.StartTime, req.EndTime); err != nil {
+		return nil, err
+	}
+
 	// Find URL by short code
 	foundURL, err := uc.urlRepo.FindByShortCode(ctx, req.ShortCode)
 	if err != nil {
 		return nil, err
 	}
+	// Soft-deleted URLs keep their clicks, but analytics are only served again after a restore
+	if foundURL.IsDeleted() {
+		return nil, url.ErrURLNotFound
+	}
 
 	// Verify ownership - only the creator can view analytics
 	if foundURL.CreatedBy != req.RequestedBy {
 		return nil, url.ErrUnauthorizedDeletion
 	}
 
+	topN := uc.resolveTopN(req.TopN)
+
 	// Check if time range is specified
 	if req.StartTime != nil && req.EndTime != nil {
 		// Get time range statistics
-		stats, err := uc.clickRepo.GetStatsByURLAndTimeRange(ctx, foundURL.ID, *req.StartTime, *req.EndTime)
+		stats, err := uc.clickRepo.GetStatsByURLAndTimeRange(ctx, foundURL.ID, *req.StartTime, *req.EndTime, topN)
 		if err != nil {
 			return nil, err
 		}
 
-		return &GetAnalyticsResponse{
+		byReferrer := stats.ByReferrer
+		if req.GroupReferrersBy == ReferrerGroupDomain {
+			byReferrer, err = uc.clickRepo.GetClicksByReferrerDomainInTimeRange(ctx, foundURL.ID, *req.StartTime, *req.EndTime, topN)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp := &GetAnalyticsResponse{
 			ShortCode:   foundURL.ShortCode,
 			OriginalURL: foundURL.OriginalURL,
 			TotalClicks: stats.TotalCount,
 			ByCountry:   stats.ByCountry,
-			ByReferrer:  stats.ByReferrer,
+			ByReferrer:  byReferrer,
+			ByBrowser:   stats.ByBrowser,
+			ByOS:        stats.ByOS,
 			StartTime:   req.StartTime,
 			EndTime:     req.EndTime,
-		}, nil
+		}
+
+		switch req.Granularity {
+		case click.GranularityDay:
+			resp.ByDate, err = uc.clickRepo.GetClicksOverTimeInTimeRange(ctx, foundURL.ID, *req.StartTime, *req.EndTime, click.GranularityDay)
+		case click.GranularityHour:
+			resp.ByHour, err = uc.clickRepo.GetClicksOverTimeInTimeRange(ctx, foundURL.ID, *req.StartTime, *req.EndTime, click.GranularityHour)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if uc.uniqueVisitors {
+			unique, err := uc.clickRepo.GetUniqueVisitorCountInTimeRange(ctx, foundURL.ID, *req.StartTime, *req.EndTime)
+			if err != nil {
+				return nil, err
+			}
+			resp.UniqueVisitors = &unique
+		}
+		return resp, nil
 	}
 
 	// Get all-time statistics
-	stats, err := uc.clickRepo.GetStatsByURL(ctx, foundURL.ID)
+	stats, err := uc.clickRepo.GetStatsByURL(ctx, foundURL.ID, topN)
 	if err != nil {
 		return nil, err
 	}
 
-	return &GetAnalyticsResponse{
+	byReferrer := stats.ByReferrer
+	if req.GroupReferrersBy == ReferrerGroupDomain {
+		byReferrer, err = uc.clickRepo.GetClicksByReferrerDomain(ctx, foundURL.ID, topN)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &GetAnalyticsResponse{
 		ShortCode:   foundURL.ShortCode,
 		OriginalURL: foundURL.OriginalURL,
 		TotalClicks: stats.TotalCount,
 		ByCountry:   stats.ByCountry,
-		ByReferrer:  stats.ByReferrer,
+		ByReferrer:  byReferrer,
+		ByBrowser:   stats.ByBrowser,
+		ByOS:        stats.ByOS,
 		ByDate:      stats.ByDate,
-	}, nil
+	}
+
+	if uc.uniqueVisitors {
+		unique, err := uc.clickRepo.GetUniqueVisitorCount(ctx, foundURL.ID)
+		if err != nil {
+			return nil, err
+		}
+		resp.UniqueVisitors = &unique
+	}
+	return resp, nil
 }
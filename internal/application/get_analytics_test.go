@@ -26,10 +26,30 @@ func (m *mockURLRepoForAnalytics) FindByShortCode(ctx context.Context, shortCode
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockURLRepoForAnalytics) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	return nil, url.ErrURLNotFound
+}
+
 func (m *mockURLRepoForAnalytics) Delete(ctx context.Context, shortCode string) error {
 	return nil
 }
 
+func (m *mockURLRepoForAnalytics) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockURLRepoForAnalytics) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	return nil
+}
+
+func (m *mockURLRepoForAnalytics) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockURLRepoForAnalytics) Restore(ctx context.Context, shortCode string) error {
+	return nil
+}
+
 func (m *mockURLRepoForAnalytics) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
 	return nil, nil
 }
@@ -42,26 +62,48 @@ func (m *mockURLRepoForAnalytics) Count(ctx context.Context, createdBy string) (
 	return 0, nil
 }
 
+func (m *mockURLRepoForAnalytics) CountAll(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *mockURLRepoForAnalytics) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	return nil, nil
+}
+
+func (m *mockURLRepoForAnalytics) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	return 0, nil
+}
+
 // Mock Click Repository
 type mockClickRepoForAnalytics struct {
 	getStatsByURLFunc             func(ctx context.Context, urlID int64) (*click.Stats, error)
 	getStatsByURLAndTimeRangeFunc func(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error)
 	getTotalClickCountFunc        func(ctx context.Context, urlID int64) (int64, error)
 	getClicksByCountryFunc        func(ctx context.Context, urlID int64) (map[string]int64, error)
+
+	getClicksByReferrerDomainFunc            func(ctx context.Context, urlID int64) (map[string]int64, error)
+	getClicksByReferrerDomainInTimeRangeFunc func(ctx context.Context, urlID int64, startTime, endTime time.Time) (map[string]int64, error)
+	getClicksOverTimeInTimeRangeFunc         func(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error)
+	getUniqueVisitorCountFunc                func(ctx context.Context, urlID int64, startTime, endTime *time.Time) (int64, error)
+
+	lastTopN        int
+	lastGranularity click.Granularity
 }
 
 func (m *mockClickRepoForAnalytics) Record(ctx context.Context, c *click.Click) error {
 	return nil
 }
 
-func (m *mockClickRepoForAnalytics) GetStatsByURL(ctx context.Context, urlID int64) (*click.Stats, error) {
+func (m *mockClickRepoForAnalytics) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
+	m.lastTopN = topN
 	if m.getStatsByURLFunc != nil {
 		return m.getStatsByURLFunc(ctx, urlID)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockClickRepoForAnalytics) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+func (m *mockClickRepoForAnalytics) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
+	m.lastTopN = topN
 	if m.getStatsByURLAndTimeRangeFunc != nil {
 		return m.getStatsByURLAndTimeRangeFunc(ctx, urlID, startTime, endTime)
 	}
@@ -75,6 +117,40 @@ func (m *mockClickRepoForAnalytics) GetTotalClickCount(ctx context.Context, urlI
 	return 0, errors.New("not implemented")
 }
 
+func (m *mockClickRepoForAnalytics) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClickRepoForAnalytics) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClickRepoForAnalytics) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (m *mockClickRepoForAnalytics) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClickRepoForAnalytics) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (m *mockClickRepoForAnalytics) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	if m.getUniqueVisitorCountFunc != nil {
+		return m.getUniqueVisitorCountFunc(ctx, urlID, nil, nil)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *mockClickRepoForAnalytics) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	if m.getUniqueVisitorCountFunc != nil {
+		return m.getUniqueVisitorCountFunc(ctx, urlID, &startTime, &endTime)
+	}
+	return 0, errors.New("not implemented")
+}
+
 func (m *mockClickRepoForAnalytics) GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error) {
 	if m.getClicksByCountryFunc != nil {
 		return m.getClicksByCountryFunc(ctx, urlID)
@@ -82,6 +158,28 @@ func (m *mockClickRepoForAnalytics) GetClicksByCountry(ctx context.Context, urlI
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockClickRepoForAnalytics) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	if m.getClicksByReferrerDomainFunc != nil {
+		return m.getClicksByReferrerDomainFunc(ctx, urlID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClickRepoForAnalytics) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	if m.getClicksByReferrerDomainInTimeRangeFunc != nil {
+		return m.getClicksByReferrerDomainInTimeRangeFunc(ctx, urlID, startTime, endTime)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClickRepoForAnalytics) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	m.lastGranularity = granularity
+	if m.getClicksOverTimeInTimeRangeFunc != nil {
+		return m.getClicksOverTimeInTimeRangeFunc(ctx, urlID, startTime, endTime, granularity)
+	}
+	return map[string]int64{}, nil
+}
+
 func TestGetAnalyticsUseCase_Execute_AllTimeStats(t *testing.T) {
 	ctx := context.Background()
 
@@ -116,6 +214,10 @@ func TestGetAnalyticsUseCase_Execute_AllTimeStats(t *testing.T) {
 			if shortCode == "abc123" {
 				return testURL, nil
 			}
+			if shortCode == "deleted" {
+				deletedAt := time.Now()
+				return &url.URL{ID: 1, ShortCode: "deleted", CreatedBy: "user1", DeletedAt: &deletedAt}, nil
+			}
 			return nil, url.ErrURLNotFound
 		},
 	}
@@ -181,6 +283,17 @@ func TestGetAnalyticsUseCase_Execute_AllTimeStats(t *testing.T) {
 		}
 	})
 
+	t.Run("soft-deleted URL", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, GetAnalyticsRequest{
+			ShortCode:   "deleted",
+			RequestedBy: "user1",
+		})
+
+		if !errors.Is(err, url.ErrURLNotFound) {
+			t.Errorf("expected ErrURLNotFound, got %v", err)
+		}
+	})
+
 	t.Run("empty short code", func(t *testing.T) {
 		_, err := useCase.Execute(ctx, GetAnalyticsRequest{
 			ShortCode:   "",
@@ -192,6 +305,17 @@ func TestGetAnalyticsUseCase_Execute_AllTimeStats(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid short code", func(t *testing.T) {
+		_, err := useCase.Execute(ctx, GetAnalyticsRequest{
+			ShortCode:   "bad code!",
+			RequestedBy: "user1",
+		})
+
+		if !errors.Is(err, url.ErrInvalidShortCode) {
+			t.Errorf("expected ErrInvalidShortCode, got %v", err)
+		}
+	})
+
 	t.Run("unauthorized access", func(t *testing.T) {
 		_, err := useCase.Execute(ctx, GetAnalyticsRequest{
 			ShortCode:   "abc123",
@@ -304,4 +428,285 @@ func TestGetAnalyticsUseCase_Execute_TimeRangeStats(t *testing.T) {
 			t.Errorf("expected end_time %v, got %v", endTime, resp.EndTime)
 		}
 	})
+
+	invalidRanges := []struct {
+		name       string
+		start, end *time.Time
+	}{
+		{"only start time", &startTime, nil},
+		{"only end time", nil, &endTime},
+		{"start equals end", &startTime, &startTime},
+		{"start after end", &endTime, &startTime},
+	}
+	for _, tr := range invalidRanges {
+		t.Run(tr.name, func(t *testing.T) {
+			_, err := useCase.Execute(ctx, GetAnalyticsRequest{
+				ShortCode:   "abc123",
+				RequestedBy: "user1",
+				StartTime:   tr.start,
+				EndTime:     tr.end,
+			})
+
+			if !errors.Is(err, click.ErrInvalidTimeRange) {
+				t.Errorf("expected ErrInvalidTimeRange, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetAnalyticsUseCase_Execute_GroupReferrersByDomain(t *testing.T) {
+	ctx := context.Background()
+
+	testURL := &url.URL{
+		ID:          1,
+		ShortCode:   "abc123",
+		OriginalURL: "https://example.com",
+		CreatedAt:   time.Now(),
+		CreatedBy:   "user1",
+	}
+
+	urlRepo := &mockURLRepoForAnalytics{
+		findByShortCodeFunc: func(ctx context.Context, shortCode string) (*url.URL, error) {
+			return testURL, nil
+		},
+	}
+
+	clickRepo := &mockClickRepoForAnalytics{
+		getStatsByURLFunc: func(ctx context.Context, urlID int64) (*click.Stats, error) {
+			return &click.Stats{
+				URLID:      urlID,
+				TotalCount: 3,
+				ByReferrer: map[string]int64{
+					"https://twitter.com/a": 1,
+					"https://twitter.com/b": 2,
+				},
+			}, nil
+		},
+		getStatsByURLAndTimeRangeFunc: func(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+			return &click.TimeRangeStats{
+				URLID:      urlID,
+				TotalCount: 1,
+				ByReferrer: map[string]int64{"https://twitter.com/a": 1},
+			}, nil
+		},
+		getClicksByReferrerDomainFunc: func(ctx context.Context, urlID int64) (map[string]int64, error) {
+			return map[string]int64{"twitter.com": 3}, nil
+		},
+		getClicksByReferrerDomainInTimeRangeFunc: func(ctx context.Context, urlID int64, startTime, endTime time.Time) (map[string]int64, error) {
+			return map[string]int64{"twitter.com": 1}, nil
+		},
+	}
+
+	useCase := NewGetAnalyticsUseCase(urlRepo, clickRepo)
+
+	t.Run("all-time stats grouped by domain", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, GetAnalyticsRequest{
+			ShortCode:        "abc123",
+			RequestedBy:      "user1",
+			GroupReferrersBy: ReferrerGroupDomain,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.ByReferrer) != 1 || resp.ByReferrer["twitter.com"] != 3 {
+			t.Errorf("expected by_referrer map[twitter.com:3], got %v", resp.ByReferrer)
+		}
+	})
+
+	t.Run("time range stats grouped by domain", func(t *testing.T) {
+		start := time.Now().Add(-time.Hour)
+		end := time.Now()
+		resp, err := useCase.Execute(ctx, GetAnalyticsRequest{
+			ShortCode:        "abc123",
+			RequestedBy:      "user1",
+			StartTime:        &start,
+			EndTime:          &end,
+			GroupReferrersBy: ReferrerGroupDomain,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.ByReferrer) != 1 || resp.ByReferrer["twitter.com"] != 1 {
+			t.Errorf("expected by_referrer map[twitter.com:1], got %v", resp.ByReferrer)
+		}
+	})
+
+	t.Run("default keeps full referrers", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, GetAnalyticsRequest{
+			ShortCode:   "abc123",
+			RequestedBy: "user1",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.ByReferrer) != 2 {
+			t.Errorf("expected 2 full referrers, got %v", resp.ByReferrer)
+		}
+	})
+}
+
+func TestGetAnalyticsUseCase_Execute_TopN(t *testing.T) {
+	ctx := context.Background()
+
+	urlRepo := &mockURLRepoForAnalytics{
+		findByShortCodeFunc: func(ctx context.Context, shortCode string) (*url.URL, error) {
+			return &url.URL{ID: 1, ShortCode: shortCode, CreatedBy: "user1"}, nil
+		},
+	}
+	clickRepo := &mockClickRepoForAnalytics{
+		getStatsByURLFunc: func(ctx context.Context, urlID int64) (*click.Stats, error) {
+			return &click.Stats{URLID: urlID}, nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		opts      []GetAnalyticsOption
+		requested int
+		want      int
+	}{
+		{"package default", nil, 0, click.DefaultTopN},
+		{"configured default", []GetAnalyticsOption{WithAnalyticsTopN(25)}, 0, 25},
+		{"request overrides default", []GetAnalyticsOption{WithAnalyticsTopN(25)}, 5, 5},
+		{"request clamped to max", nil, click.MaxTopN + 50, click.MaxTopN},
+		{"non-positive option ignored", []GetAnalyticsOption{WithAnalyticsTopN(0)}, 0, click.DefaultTopN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewGetAnalyticsUseCase(urlRepo, clickRepo, tt.opts...)
+			if _, err := useCase.Execute(ctx, GetAnalyticsRequest{ShortCode: "abc123", RequestedBy: "user1", TopN: tt.requested}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if clickRepo.lastTopN != tt.want {
+				t.Errorf("expected topN %d, got %d", tt.want, clickRepo.lastTopN)
+			}
+		})
+	}
+}
+
+func TestGetAnalyticsUseCase_Execute_Granularity(t *testing.T) {
+	ctx := context.Background()
+	startTime := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2025, 11, 20, 23, 59, 59, 0, time.UTC)
+
+	urlRepo := &mockURLRepoForAnalytics{
+		findByShortCodeFunc: func(ctx context.Context, shortCode string) (*url.URL, error) {
+			return &url.URL{ID: 1, ShortCode: shortCode, CreatedBy: "user1"}, nil
+		},
+	}
+	clickRepo := &mockClickRepoForAnalytics{
+		getStatsByURLAndTimeRangeFunc: func(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+			return &click.TimeRangeStats{URLID: urlID, TotalCount: 3}, nil
+		},
+		getClicksOverTimeInTimeRangeFunc: func(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+			if granularity == click.GranularityHour {
+				return map[string]int64{"2025-11-20 09:00": 2, "2025-11-20 17:00": 1}, nil
+			}
+			return map[string]int64{"2025-11-20": 3}, nil
+		},
+	}
+	useCase := NewGetAnalyticsUseCase(urlRepo, clickRepo)
+
+	t.Run("no granularity omits buckets", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, GetAnalyticsRequest{ShortCode: "abc123", RequestedBy: "user1", StartTime: &startTime, EndTime: &endTime})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if resp.ByDate != nil || resp.ByHour != nil {
+			t.Errorf("expected no time buckets, got by_date=%v by_hour=%v", resp.ByDate, resp.ByHour)
+		}
+	})
+
+	t.Run("daily buckets", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, GetAnalyticsRequest{ShortCode: "abc123", RequestedBy: "user1", StartTime: &startTime, EndTime: &endTime, Granularity: click.GranularityDay})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if clickRepo.lastGranularity != click.GranularityDay {
+			t.Errorf("expected granularity %q, got %q", click.GranularityDay, clickRepo.lastGranularity)
+		}
+		if resp.ByDate["2025-11-20"] != 3 {
+			t.Errorf("expected ByDate 2025-11-20=3, got %v", resp.ByDate)
+		}
+		if resp.ByHour != nil {
+			t.Errorf("expected no ByHour, got %v", resp.ByHour)
+		}
+	})
+
+	t.Run("hourly buckets", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, GetAnalyticsRequest{ShortCode: "abc123", RequestedBy: "user1", StartTime: &startTime, EndTime: &endTime, Granularity: click.GranularityHour})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.ByHour) != 2 || resp.ByHour["2025-11-20 09:00"] != 2 {
+			t.Errorf("expected two hourly buckets, got %v", resp.ByHour)
+		}
+		if resp.ByDate != nil {
+			t.Errorf("expected no ByDate, got %v", resp.ByDate)
+		}
+	})
+}
+
+func TestGetAnalyticsUseCase_Execute_UniqueVisitors(t *testing.T) {
+	ctx := context.Background()
+	startTime := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2025, 11, 22, 23, 59, 59, 0, time.UTC)
+
+	urlRepo := &mockURLRepoForAnalytics{
+		findByShortCodeFunc: func(ctx context.Context, shortCode string) (*url.URL, error) {
+			return &url.URL{ID: 1, ShortCode: shortCode, CreatedBy: "user1"}, nil
+		},
+	}
+	var gotRange bool
+	clickRepo := &mockClickRepoForAnalytics{
+		getStatsByURLFunc: func(ctx context.Context, urlID int64) (*click.Stats, error) {
+			return &click.Stats{URLID: urlID, TotalCount: 10}, nil
+		},
+		getStatsByURLAndTimeRangeFunc: func(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+			return &click.TimeRangeStats{URLID: urlID, TotalCount: 6}, nil
+		},
+		getUniqueVisitorCountFunc: func(ctx context.Context, urlID int64, startTime, endTime *time.Time) (int64, error) {
+			gotRange = startTime != nil && endTime != nil
+			if gotRange {
+				return 2, nil
+			}
+			return 4, nil
+		},
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		resp, err := NewGetAnalyticsUseCase(urlRepo, clickRepo).Execute(ctx, GetAnalyticsRequest{ShortCode: "abc123", RequestedBy: "user1"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if resp.UniqueVisitors != nil {
+			t.Errorf("expected no unique visitors, got %d", *resp.UniqueVisitors)
+		}
+	})
+
+	useCase := NewGetAnalyticsUseCase(urlRepo, clickRepo, WithUniqueVisitors())
+
+	t.Run("all time", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, GetAnalyticsRequest{ShortCode: "abc123", RequestedBy: "user1"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if resp.UniqueVisitors == nil || *resp.UniqueVisitors != 4 || gotRange {
+			t.Errorf("expected all-time unique visitors 4, got %v", resp.UniqueVisitors)
+		}
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		resp, err := useCase.Execute(ctx, GetAnalyticsRequest{ShortCode: "abc123", RequestedBy: "user1", StartTime: &startTime, EndTime: &endTime})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if resp.UniqueVisitors == nil || *resp.UniqueVisitors != 2 || !gotRange {
+			t.Errorf("expected time-range unique visitors 2, got %v", resp.UniqueVisitors)
+		}
+	})
 }
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// DefaultDailyClicksDays is how many days a daily click series covers when no start date is given
+const DefaultDailyClicksDays = 30
+
+// GetDailyClicksRequest represents the input for a URL's clicks per day
+type GetDailyClicksRequest struct {
+	ShortCode   string
+	RequestedBy string     // User requesting the series (for ownership verification)
+	StartDate   *time.Time // Optional: first UTC day; defaults to DefaultDailyClicksDays days ending on EndDate
+	EndDate     *time.Time // Optional: last UTC day, inclusive; defaults to today
+}
+
+// DailyClicks is the click count for one UTC day
+type DailyClicks struct {
+	Date  string `json:"date"` // YYYY-MM-DD
+	Count int64  `json:"count"`
+}
+
+// GetDailyClicksUseCase returns a URL's clicks per day, for charting
+type GetDailyClicksUseCase struct {
+	urlRepo   url.Repository
+	clickRepo click.Repository
+	now       func() time.Time
+}
+
+// NewGetDailyClicksUseCase creates a new GetDailyClicksUseCase
+func NewGetDailyClicksUseCase(urlRepo url.Repository, clickRepo click.Repository) *GetDailyClicksUseCase {
+	return &GetDailyClicksUseCase{
+		urlRepo:   urlRepo,
+		clickRepo: clickRepo,
+		now:       time.Now,
+	}
+}
+
+// Execute returns one entry per day in the requested range, oldest first, including days without clicks
+func (uc *GetDailyClicksUseCase) Execute(ctx context.Context, req GetDailyClicksRequest) ([]DailyClicks, error) {
+	// Validate short code
+	if err := url.ValidateShortCode(req.ShortCode); err != nil {
+		return nil, err
+	}
+
+	// Validate requested by
+	if req.RequestedBy == "" {
+		return nil, url.ErrInvalidCreatedBy
+	}
+
+	end := uc.now()
+	if req.EndDate != nil {
+		end = *req.EndDate
+	}
+	end = click.TruncateToDay(end)
+	start := end.AddDate(0, 0, -(DefaultDailyClicksDays - 1))
+	if req.StartDate != nil {
+		start = click.TruncateToDay(*req.StartDate)
+	}
+	if err := click.ValidateDateRange(start, end); err != nil {
+		return nil, err
+	}
+
+	// Find URL by short code
+	foundURL, err := uc.urlRepo.FindByShortCode(ctx, req.ShortCode)
+	if err != nil {
+		return nil, err
+	}
+	// Soft-deleted URLs keep their clicks, but analytics are only served again after a restore
+	if foundURL.IsDeleted() {
+		return nil, url.ErrURLNotFound
+	}
+
+	// Verify ownership - only the creator can view analytics
+	if foundURL.CreatedBy != req.RequestedBy {
+		return nil, url.ErrUnauthorizedDeletion
+	}
+
+	counts, err := uc.clickRepo.GetDailyClicksInRange(ctx, foundURL.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]DailyClicks, 0, len(counts))
+	for _, c := range counts {
+		series = append(series, DailyClicks{Date: c.Date, Count: c.Count})
+	}
+	return series, nil
+}
@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func newDailyClicksTestUseCase(owner string, deleted bool, getDaily func(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error)) *GetDailyClicksUseCase {
+	urlRepo := &mockURLRepoForAnalytics{
+		findByShortCodeFunc: func(ctx context.Context, shortCode string) (*url.URL, error) {
+			if shortCode != "abc123" {
+				return nil, url.ErrURLNotFound
+			}
+			u := &url.URL{ID: 7, ShortCode: shortCode, OriginalURL: "https://example.com", CreatedBy: owner}
+			if deleted {
+				now := time.Now()
+				u.DeletedAt = &now
+			}
+			return u, nil
+		},
+	}
+	uc := NewGetDailyClicksUseCase(urlRepo, &mockListClickRepository{getDailyClicksFunc: getDaily})
+	uc.now = func() time.Time { return time.Date(2025, 1, 31, 15, 0, 0, 0, time.UTC) }
+	return uc
+}
+
+func TestGetDailyClicksUseCase_Execute(t *testing.T) {
+	var gotStart, gotEnd time.Time
+	uc := newDailyClicksTestUseCase("user1", false, func(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+		if urlID != 7 {
+			t.Errorf("urlID = %d, want 7", urlID)
+		}
+		gotStart, gotEnd = startDate, endDate
+		return []click.DailyCount{{Date: "2025-01-01", Count: 3}, {Date: "2025-01-02", Count: 0}}, nil
+	})
+
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	got, err := uc.Execute(context.Background(), GetDailyClicksRequest{ShortCode: "abc123", RequestedBy: "user1", StartDate: &start, EndDate: &end})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if !gotStart.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) || !gotEnd.Equal(end) {
+		t.Errorf("repository range = %v to %v, want whole days 2025-01-01 to 2025-01-02", gotStart, gotEnd)
+	}
+	want := []DailyClicks{{Date: "2025-01-01", Count: 3}, {Date: "2025-01-02", Count: 0}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Execute() = %v, want %v", got, want)
+	}
+}
+
+func TestGetDailyClicksUseCase_Execute_DefaultRange(t *testing.T) {
+	var gotStart, gotEnd time.Time
+	uc := newDailyClicksTestUseCase("user1", false, func(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+		gotStart, gotEnd = startDate, endDate
+		return []click.DailyCount{}, nil
+	})
+
+	if _, err := uc.Execute(context.Background(), GetDailyClicksRequest{ShortCode: "abc123", RequestedBy: "user1"}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	wantEnd := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	wantStart := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !gotStart.Equal(wantStart) || !gotEnd.Equal(wantEnd) {
+		t.Errorf("default range = %v to %v, want %v to %v", gotStart, gotEnd, wantStart, wantEnd)
+	}
+}
+
+func TestGetDailyClicksUseCase_Execute_Errors(t *testing.T) {
+	day := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	before := day.AddDate(0, 0, -1)
+	tooEarly := day.AddDate(0, 0, -click.MaxDailyRangeDays)
+
+	tests := []struct {
+		name    string
+		owner   string
+		deleted bool
+		req     GetDailyClicksRequest
+		wantErr error
+	}{
+		{name: "end before start", owner: "user1", req: GetDailyClicksRequest{ShortCode: "abc123", RequestedBy: "user1", StartDate: &day, EndDate: &before}, wantErr: click.ErrInvalidDateRange},
+		{name: "range too long", owner: "user1", req: GetDailyClicksRequest{ShortCode: "abc123", RequestedBy: "user1", StartDate: &tooEarly, EndDate: &day}, wantErr: click.ErrInvalidDateRange},
+		{name: "not the owner", owner: "user2", req: GetDailyClicksRequest{ShortCode: "abc123", RequestedBy: "user1"}, wantErr: url.ErrUnauthorizedDeletion},
+		{name: "unknown short code", owner: "user1", req: GetDailyClicksRequest{ShortCode: "nope12", RequestedBy: "user1"}, wantErr: url.ErrURLNotFound},
+		{name: "soft-deleted", owner: "user1", deleted: true, req: GetDailyClicksRequest{ShortCode: "abc123", RequestedBy: "user1"}, wantErr: url.ErrURLNotFound},
+		{name: "no requester", owner: "user1", req: GetDailyClicksRequest{ShortCode: "abc123"}, wantErr: url.ErrInvalidCreatedBy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newDailyClicksTestUseCase(tt.owner, tt.deleted, func(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+				t.Error("repository should not be queried")
+				return nil, nil
+			})
+
+			if _, err := uc.Execute(context.Background(), tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// GetStatsSummaryRequest represents the input for a user's overview stats
+type GetStatsSummaryRequest struct {
+	CreatedBy string
+}
+
+// GetStatsSummaryResponse represents a user's totals across all their URLs
+type GetStatsSummaryResponse struct {
+	TotalURLs   int   `json:"total_urls"`   // TotalURLs is the number of live URLs the user created
+	TotalClicks int64 `json:"total_clicks"` // TotalClicks is the number of clicks on those URLs
+}
+
+// GetStatsSummaryUseCase reports a user's URL and click totals for an overview screen
+type GetStatsSummaryUseCase struct {
+	urlRepo   url.Repository
+	clickRepo click.Repository
+}
+
+// NewGetStatsSummaryUseCase creates a new GetStatsSummaryUseCase
+func NewGetStatsSummaryUseCase(urlRepo url.Repository, clickRepo click.Repository) *GetStatsSummaryUseCase {
+	return &GetStatsSummaryUseCase{
+		urlRepo:   urlRepo,
+		clickRepo: clickRepo,
+	}
+}
+
+// Execute counts the user's URLs and the clicks on them; soft-deleted URLs and their clicks are not counted
+func (uc *GetStatsSummaryUseCase) Execute(ctx context.Context, req GetStatsSummaryRequest) (*GetStatsSummaryResponse, error) {
+	// An empty creator would count every user's URLs
+	if req.CreatedBy == "" {
+		return nil, url.ErrInvalidCreatedBy
+	}
+
+	totalURLs, err := uc.urlRepo.Count(ctx, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	totalClicks, err := uc.clickRepo.GetTotalClicksByUser(ctx, req.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetStatsSummaryResponse{
+		TotalURLs:   totalURLs,
+		TotalClicks: totalClicks,
+	}, nil
+}
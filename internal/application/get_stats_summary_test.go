@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func TestGetStatsSummaryUseCase_Execute(t *testing.T) {
+	urlCounts := map[string]int{"user1": 3, "user2": 1}
+	clickCounts := map[string]int64{"user1": 42, "user2": 0}
+	urlRepo := &mockListURLRepository{
+		countFunc: func(ctx context.Context, createdBy string) (int, error) {
+			return urlCounts[createdBy], nil
+		},
+	}
+	clickRepo := &mockListClickRepository{
+		getTotalClicksByUserFunc: func(ctx context.Context, createdBy string) (int64, error) {
+			return clickCounts[createdBy], nil
+		},
+	}
+	uc := NewGetStatsSummaryUseCase(urlRepo, clickRepo)
+
+	for createdBy, wantURLs := range urlCounts {
+		resp, err := uc.Execute(context.Background(), GetStatsSummaryRequest{CreatedBy: createdBy})
+		if err != nil {
+			t.Fatalf("Execute(%q) error = %v", createdBy, err)
+		}
+		if resp.TotalURLs != wantURLs || resp.TotalClicks != clickCounts[createdBy] {
+			t.Errorf("Execute(%q) = %+v, want %d URLs and %d clicks", createdBy, resp, wantURLs, clickCounts[createdBy])
+		}
+	}
+}
+
+func TestGetStatsSummaryUseCase_Execute_EmptyCreatedBy(t *testing.T) {
+	called := false
+	clickRepo := &mockListClickRepository{
+		getTotalClicksByUserFunc: func(ctx context.Context, createdBy string) (int64, error) {
+			called = true
+			return 0, nil
+		},
+	}
+	uc := NewGetStatsSummaryUseCase(&mockListURLRepository{}, clickRepo)
+
+	_, err := uc.Execute(context.Background(), GetStatsSummaryRequest{})
+	if !errors.Is(err, url.ErrInvalidCreatedBy) {
+		t.Errorf("Execute() error = %v, want %v", err, url.ErrInvalidCreatedBy)
+	}
+	if called {
+		t.Error("expected GetTotalClicksByUser not to be called without a creator")
+	}
+}
+
+func TestGetStatsSummaryUseCase_Execute_RepositoryError(t *testing.T) {
+	repoErr := errors.New("database error")
+	clickRepo := &mockListClickRepository{
+		getTotalClicksByUserFunc: func(ctx context.Context, createdBy string) (int64, error) {
+			return 0, repoErr
+		},
+	}
+	uc := NewGetStatsSummaryUseCase(&mockListURLRepository{}, clickRepo)
+
+	if _, err := uc.Execute(context.Background(), GetStatsSummaryRequest{CreatedBy: "user1"}); !errors.Is(err, repoErr) {
+		t.Errorf("Execute() error = %v, want %v", err, repoErr)
+	}
+}
@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// GetTopURLsRequest represents the input for a user's click leaderboard
+type GetTopURLsRequest struct {
+	CreatedBy string
+	Limit     int
+}
+
+// GetTopURLsResponse represents a user's URLs, most clicked first
+type GetTopURLsResponse struct {
+	URLs  []URLResponse `json:"urls"`
+	Limit int           `json:"limit"`
+}
+
+// GetTopURLsUseCase ranks a user's URLs by click count
+type GetTopURLsUseCase struct {
+	clickRepo click.Repository
+}
+
+// NewGetTopURLsUseCase creates a new GetTopURLsUseCase
+func NewGetTopURLsUseCase(clickRepo click.Repository) *GetTopURLsUseCase {
+	return &GetTopURLsUseCase{
+		clickRepo: clickRepo,
+	}
+}
+
+// Execute returns up to Limit of the user's live URLs, most clicked first; ties go to the newest URL.
+// Limit defaults to click.DefaultTopN and is capped at click.MaxTopN.
+func (uc *GetTopURLsUseCase) Execute(ctx context.Context, req GetTopURLsRequest) (*GetTopURLsResponse, error) {
+	// An empty creator would rank every user's URLs
+	if req.CreatedBy == "" {
+		return nil, url.ErrInvalidCreatedBy
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = click.DefaultTopN
+	}
+	if limit > click.MaxTopN {
+		limit = click.MaxTopN
+	}
+
+	top, err := uc.clickRepo.GetTopURLsByUser(ctx, req.CreatedBy, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]URLResponse, 0, len(top))
+	for _, u := range top {
+		urls = append(urls, URLResponse{
+			ID:          u.URLID,
+			ShortCode:   u.ShortCode,
+			OriginalURL: u.OriginalURL,
+			CreatedAt:   u.CreatedAt,
+			CreatedBy:   u.CreatedBy,
+			ClickCount:  u.Count,
+		})
+	}
+
+	return &GetTopURLsResponse{
+		URLs:  urls,
+		Limit: limit,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func TestGetTopURLsUseCase_Execute(t *testing.T) {
+	var gotLimit int
+	clickRepo := &mockListClickRepository{
+		getTopURLsByUserFunc: func(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+			gotLimit = limit
+			return []click.URLClicks{
+				{URLID: 2, ShortCode: "hot", OriginalURL: "https://example.com/hot", CreatedBy: createdBy, Count: 9},
+				{URLID: 1, ShortCode: "cold", OriginalURL: "https://example.com/cold", CreatedBy: createdBy, Count: 0},
+			}, nil
+		},
+	}
+	uc := NewGetTopURLsUseCase(clickRepo)
+
+	resp, err := uc.Execute(context.Background(), GetTopURLsRequest{CreatedBy: "user1", Limit: 5})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if gotLimit != 5 || resp.Limit != 5 {
+		t.Errorf("Execute() limit = %d (repository %d), want 5", resp.Limit, gotLimit)
+	}
+	if len(resp.URLs) != 2 || resp.URLs[0].ShortCode != "hot" || resp.URLs[0].ClickCount != 9 || resp.URLs[1].ShortCode != "cold" {
+		t.Errorf("Execute() URLs = %+v, want hot (9) then cold (0) in repository order", resp.URLs)
+	}
+}
+
+func TestGetTopURLsUseCase_Execute_Limit(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{name: "default", limit: 0, want: click.DefaultTopN},
+		{name: "negative uses default", limit: -3, want: click.DefaultTopN},
+		{name: "within range", limit: 25, want: 25},
+		{name: "capped", limit: 500, want: click.MaxTopN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLimit int
+			uc := NewGetTopURLsUseCase(&mockListClickRepository{
+				getTopURLsByUserFunc: func(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+					gotLimit = limit
+					return nil, nil
+				},
+			})
+
+			resp, err := uc.Execute(context.Background(), GetTopURLsRequest{CreatedBy: "user1", Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if gotLimit != tt.want || resp.Limit != tt.want {
+				t.Errorf("Execute(limit %d) used %d (response %d), want %d", tt.limit, gotLimit, resp.Limit, tt.want)
+			}
+			if resp.URLs == nil {
+				t.Error("Execute() URLs should be an empty list, not nil")
+			}
+		})
+	}
+}
+
+func TestGetTopURLsUseCase_Execute_Errors(t *testing.T) {
+	uc := NewGetTopURLsUseCase(&mockListClickRepository{})
+	if _, err := uc.Execute(context.Background(), GetTopURLsRequest{}); !errors.Is(err, url.ErrInvalidCreatedBy) {
+		t.Errorf("Execute() without creator error = %v, want %v", err, url.ErrInvalidCreatedBy)
+	}
+
+	repoErr := errors.New("database error")
+	failing := NewGetTopURLsUseCase(&mockListClickRepository{
+		getTopURLsByUserFunc: func(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+			return nil, repoErr
+		},
+	})
+	if _, err := failing.Execute(context.Background(), GetTopURLsRequest{CreatedBy: "user1"}); !errors.Is(err, repoErr) {
+		t.Errorf("Execute() error = %v, want %v", err, repoErr)
+	}
+}
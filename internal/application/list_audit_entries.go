@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// ListAuditEntriesRequest represents the input for listing a short code's audit trail
+type ListAuditEntriesRequest struct {
+	ShortCode string
+	Limit     int
+}
+
+// AuditEntryResponse represents a single audit entry in the response
+type AuditEntryResponse struct {
+	ID        int64     `json:"id"`
+	Action    string    `json:"action"`
+	ShortCode string    `json:"short_code"`
+	UserID    string    `json:"user_id"`
+	IPAddress string    `json:"ip_address,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListAuditEntriesResponse represents the output after listing audit entries
+type ListAuditEntriesResponse struct {
+	Entries []AuditEntryResponse `json:"entries"`
+	Limit   int                  `json:"limit"`
+}
+
+// ListAuditEntriesUseCase handles listing the audit trail for a short code
+type ListAuditEntriesUseCase struct {
+	auditRepo audit.Repository
+}
+
+// NewListAuditEntriesUseCase creates a new ListAuditEntriesUseCase
+func NewListAuditEntriesUseCase(auditRepo audit.Repository) *ListAuditEntriesUseCase {
+	return &ListAuditEntriesUseCase{
+		auditRepo: auditRepo,
+	}
+}
+
+// Execute lists audit entries for a short code, newest first.
+// Entries outlive their URLs, so deleted short codes can still be queried.
+func (uc *ListAuditEntriesUseCase) Execute(ctx context.Context, req ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	// Validate short code
+	if err := url.ValidateShortCode(req.ShortCode); err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+
+	entries, err := uc.auditRepo.ListByShortCode(ctx, req.ShortCode, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	resp := &ListAuditEntriesResponse{
+		Entries: make([]AuditEntryResponse, 0, len(entries)),
+		Limit:   limit,
+	}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, AuditEntryResponse{
+			ID:        e.ID,
+			Action:    string(e.Action),
+			ShortCode: e.ShortCode,
+			UserID:    e.UserID,
+			IPAddress: e.IPAddress,
+			CreatedAt: e.CreatedAt,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/audit"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func TestListAuditEntriesUseCase_Execute(t *testing.T) {
+	repo := &mockAuditRepository{}
+	for _, e := range []struct {
+		action    audit.Action
+		shortCode string
+	}{
+		{audit.ActionCreate, "abc123"},
+		{audit.ActionCreate, "other1"},
+		{audit.ActionDelete, "abc123"},
+	} {
+		entry, _ := audit.NewEntry(e.action, e.shortCode, "user1", "192.0.2.1")
+		repo.Record(context.Background(), entry)
+	}
+	uc := NewListAuditEntriesUseCase(repo)
+
+	resp, err := uc.Execute(context.Background(), ListAuditEntriesRequest{ShortCode: "abc123"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Limit != 20 {
+		t.Errorf("Execute() Limit = %d, want default 20", resp.Limit)
+	}
+	if len(resp.Entries) != 2 {
+		t.Fatalf("Execute() returned %d entries, want 2", len(resp.Entries))
+	}
+	if resp.Entries[0].Action != "delete" || resp.Entries[1].Action != "create" {
+		t.Errorf("Execute() actions = %q, %q, want newest first", resp.Entries[0].Action, resp.Entries[1].Action)
+	}
+	if resp.Entries[1].UserID != "user1" || resp.Entries[1].IPAddress != "192.0.2.1" {
+		t.Errorf("Execute() entry = %+v", resp.Entries[1])
+	}
+
+	resp, err = uc.Execute(context.Background(), ListAuditEntriesRequest{ShortCode: "abc123", Limit: 1000})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if resp.Limit != 100 {
+		t.Errorf("Execute() Limit = %d, want max 100", resp.Limit)
+	}
+}
+
+func TestListAuditEntriesUseCase_Execute_Errors(t *testing.T) {
+	uc := NewListAuditEntriesUseCase(&mockAuditRepository{})
+	if _, err := uc.Execute(context.Background(), ListAuditEntriesRequest{ShortCode: ""}); !errors.Is(err, url.ErrEmptyShortCode) {
+		t.Errorf("Execute() with empty short code error = %v, want %v", err, url.ErrEmptyShortCode)
+	}
+	if _, err := uc.Execute(context.Background(), ListAuditEntriesRequest{ShortCode: "bad code!"}); !errors.Is(err, url.ErrInvalidShortCode) {
+		t.Errorf("Execute() with invalid short code error = %v, want %v", err, url.ErrInvalidShortCode)
+	}
+
+	repoErr := errors.New("db down")
+	uc = NewListAuditEntriesUseCase(&mockAuditRepository{err: repoErr})
+	if _, err := uc.Execute(context.Background(), ListAuditEntriesRequest{ShortCode: "abc123"}); !errors.Is(err, repoErr) {
+		t.Errorf("Execute() repository error = %v, want %v", err, repoErr)
+	}
+}
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// ListClicksRequest represents the input for a page of a URL's individual clicks
+type ListClicksRequest struct {
+	ShortCode   string
+	RequestedBy string // User requesting the clicks (for ownership verification)
+	Limit       int
+	Offset      int
+}
+
+// ClickResponse is one click as exported to the URL's owner. The visitor's IP
+// (and its hash) and the full referrer are deliberately left out: the referrer
+// domain and bucketed user agent are as much as an export reveals.
+type ClickResponse struct {
+	ID             int64     `json:"id"`
+	ClickedAt      time.Time `json:"clicked_at"`
+	ReferrerDomain string    `json:"referrer_domain,omitempty"`
+	Country        string    `json:"country,omitempty"`
+	Browser        string    `json:"browser"`
+	OS             string    `json:"os"`
+}
+
+// ListClicksResponse represents a page of clicks, newest first
+type ListClicksResponse struct {
+	Clicks []ClickResponse `json:"clicks"`
+	Total  int64           `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// ListClicksUseCase pages through a URL's individual clicks
+type ListClicksUseCase struct {
+	urlRepo   url.Repository
+	clickRepo click.Repository
+}
+
+// NewListClicksUseCase creates a new ListClicksUseCase
+func NewListClicksUseCase(urlRepo url.Repository, clickRepo click.Repository) *ListClicksUseCase {
+	return &ListClicksUseCase{
+		urlRepo:   urlRepo,
+		clickRepo: clickRepo,
+	}
+}
+
+// Execute returns a page of the URL's clicks, newest first
+func (uc *ListClicksUseCase) Execute(ctx context.Context, req ListClicksRequest) (*ListClicksResponse, error) {
+	// Validate short code
+	if err := url.ValidateShortCode(req.ShortCode); err != nil {
+		return nil, err
+	}
+
+	// Validate requested by
+	if req.RequestedBy == "" {
+		return nil, url.ErrInvalidCreatedBy
+	}
+
+	// Set default values for pagination
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20 // Default limit
+	}
+	if limit > 100 {
+		limit = 100 // Max limit
+	}
+
+	offset := req.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Find URL by short code
+	foundURL, err := uc.urlRepo.FindByShortCode(ctx, req.ShortCode)
+	if err != nil {
+		return nil, err
+	}
+	// Soft-deleted URLs keep their clicks, but analytics are only served again after a restore
+	if foundURL.IsDeleted() {
+		return nil, url.ErrURLNotFound
+	}
+
+	// Verify ownership - only the creator can view clicks
+	if foundURL.CreatedBy != req.RequestedBy {
+		return nil, url.ErrUnauthorizedDeletion
+	}
+
+	clicks, err := uc.clickRepo.ListByURL(ctx, foundURL.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.clickRepo.GetTotalClickCount(ctx, foundURL.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ClickResponse, len(clicks))
+	for i, c := range clicks {
+		responses[i] = ClickResponse{
+			ID:             c.ID,
+			ClickedAt:      c.ClickedAt,
+			ReferrerDomain: c.ReferrerDomain,
+			Country:        c.Country,
+			Browser:        click.ParseBrowser(c.UserAgent),
+			OS:             click.ParseOS(c.UserAgent),
+		}
+	}
+
+	return &ListClicksResponse{
+		Clicks: responses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func newListClicksTestUseCase(owner string, deleted bool, clickRepo *mockListClickRepository) *ListClicksUseCase {
+	urlRepo := &mockURLRepoForAnalytics{
+		findByShortCodeFunc: func(ctx context.Context, shortCode string) (*url.URL, error) {
+			if shortCode != "abc123" {
+				return nil, url.ErrURLNotFound
+			}
+			u := &url.URL{ID: 7, ShortCode: shortCode, OriginalURL: "https://example.com", CreatedBy: owner}
+			if deleted {
+				now := time.Now()
+				u.DeletedAt = &now
+			}
+			return u, nil
+		},
+	}
+	return NewListClicksUseCase(urlRepo, clickRepo)
+}
+
+func TestListClicksUseCase_Execute(t *testing.T) {
+	clickedAt := time.Date(2025, 1, 31, 15, 0, 0, 0, time.UTC)
+	var gotLimit, gotOffset int
+	clickRepo := &mockListClickRepository{
+		listByURLFunc: func(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+			if urlID != 7 {
+				t.Errorf("urlID = %d, want 7", urlID)
+			}
+			gotLimit, gotOffset = limit, offset
+			return []*click.Click{{
+				ID:             42,
+				URLID:          7,
+				ClickedAt:      clickedAt,
+				Referrer:       "https://news.example.org/private?token=secret",
+				ReferrerDomain: "news.example.org",
+				Country:        "GB",
+				UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Gecko/20100101 Firefox/120.0",
+				VisitorHash:    "deadbeef",
+			}}, nil
+		},
+		getTotalClickCountFunc: func(ctx context.Context, urlID int64) (int64, error) {
+			return 11, nil
+		},
+	}
+	uc := newListClicksTestUseCase("user1", false, clickRepo)
+
+	resp, err := uc.Execute(context.Background(), ListClicksRequest{ShortCode: "abc123", RequestedBy: "user1", Limit: 10, Offset: 10})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if gotLimit != 10 || gotOffset != 10 {
+		t.Errorf("repository page = limit %d offset %d, want 10 and 10", gotLimit, gotOffset)
+	}
+	if resp.Total != 11 || resp.Limit != 10 || resp.Offset != 10 {
+		t.Errorf("Execute() total/limit/offset = %d/%d/%d, want 11/10/10", resp.Total, resp.Limit, resp.Offset)
+	}
+	want := ClickResponse{
+		ID:             42,
+		ClickedAt:      clickedAt,
+		ReferrerDomain: "news.example.org",
+		Country:        "GB",
+		Browser:        click.BrowserFirefox,
+		OS:             click.OSWindows,
+	}
+	if len(resp.Clicks) != 1 || resp.Clicks[0] != want {
+		t.Errorf("Execute() clicks = %+v, want [%+v]", resp.Clicks, want)
+	}
+}
+
+func TestListClicksUseCase_Execute_Pagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		limit      int
+		offset     int
+		wantLimit  int
+		wantOffset int
+	}{
+		{"defaults", 0, 0, 20, 0},
+		{"limit capped", 500, 0, 100, 0},
+		{"negative offset", 5, -3, 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLimit, gotOffset int
+			uc := newListClicksTestUseCase("user1", false, &mockListClickRepository{
+				listByURLFunc: func(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+					gotLimit, gotOffset = limit, offset
+					return nil, nil
+				},
+			})
+
+			resp, err := uc.Execute(context.Background(), ListClicksRequest{ShortCode: "abc123", RequestedBy: "user1", Limit: tt.limit, Offset: tt.offset})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if gotLimit != tt.wantLimit || gotOffset != tt.wantOffset {
+				t.Errorf("repository page = limit %d offset %d, want %d and %d", gotLimit, gotOffset, tt.wantLimit, tt.wantOffset)
+			}
+			if resp.Clicks == nil {
+				t.Error("Execute() clicks should be an empty slice, not nil")
+			}
+		})
+	}
+}
+
+func TestListClicksUseCase_Execute_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		owner   string
+		deleted bool
+		req     ListClicksRequest
+		wantErr error
+	}{
+		{name: "not the owner", owner: "user2", req: ListClicksRequest{ShortCode: "abc123", RequestedBy: "user1"}, wantErr: url.ErrUnauthorizedDeletion},
+		{name: "unknown short code", owner: "user1", req: ListClicksRequest{ShortCode: "nope12", RequestedBy: "user1"}, wantErr: url.ErrURLNotFound},
+		{name: "soft-deleted", owner: "user1", deleted: true, req: ListClicksRequest{ShortCode: "abc123", RequestedBy: "user1"}, wantErr: url.ErrURLNotFound},
+		{name: "no requester", owner: "user1", req: ListClicksRequest{ShortCode: "abc123"}, wantErr: url.ErrInvalidCreatedBy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := newListClicksTestUseCase(tt.owner, tt.deleted, &mockListClickRepository{
+				listByURLFunc: func(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+					t.Error("repository should not be queried")
+					return nil, nil
+				},
+			})
+
+			if _, err := uc.Execute(context.Background(), tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
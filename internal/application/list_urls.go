@@ -13,6 +13,8 @@ type ListURLsRequest struct {
 	CreatedBy string
 	Limit     int
 	Offset    int
+	// Query, when set, keeps only URLs whose original URL or short code contains it (ignoring case)
+	Query string
 }
 
 // URLResponse represents a single URL in the response
@@ -69,7 +71,13 @@ func (uc *ListURLsUseCase) Execute(ctx context.Context, req ListURLsRequest) (*L
 	}
 
 	// Retrieve URLs from repository
-	urls, err := uc.urlRepo.List(ctx, req.CreatedBy, limit, offset)
+	var urls []*url.URL
+	var err error
+	if req.Query != "" {
+		urls, err = uc.urlRepo.Search(ctx, req.CreatedBy, req.Query, limit, offset)
+	} else {
+		urls, err = uc.urlRepo.List(ctx, req.CreatedBy, limit, offset)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -94,8 +102,13 @@ func (uc *ListURLsUseCase) Execute(ctx context.Context, req ListURLsRequest) (*L
 		}
 	}
 
-	// Get total count of URLs for this user
-	totalCount, err := uc.urlRepo.Count(ctx, req.CreatedBy)
+	// Get total count of URLs for this user, so pages of a search add up to its total
+	var totalCount int
+	if req.Query != "" {
+		totalCount, err = uc.urlRepo.CountMatching(ctx, req.CreatedBy, req.Query)
+	} else {
+		totalCount, err = uc.urlRepo.Count(ctx, req.CreatedBy)
+	}
 	if err != nil {
 		return nil, err
 	}
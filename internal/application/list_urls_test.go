@@ -13,8 +13,10 @@ import (
 
 // mockURLRepository is a test double for URL repository
 type mockListURLRepository struct {
-	listFunc  func(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error)
-	countFunc func(ctx context.Context, createdBy string) (int, error)
+	listFunc          func(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error)
+	countFunc         func(ctx context.Context, createdBy string) (int, error)
+	searchFunc        func(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error)
+	countMatchingFunc func(ctx context.Context, createdBy, query string) (int, error)
 }
 
 func (m *mockListURLRepository) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
@@ -31,6 +33,24 @@ func (m *mockListURLRepository) Count(ctx context.Context, createdBy string) (in
 	return 0, nil
 }
 
+func (m *mockListURLRepository) CountAll(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *mockListURLRepository) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, createdBy, query, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *mockListURLRepository) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	if m.countMatchingFunc != nil {
+		return m.countMatchingFunc(ctx, createdBy, query)
+	}
+	return 0, nil
+}
+
 func (m *mockListURLRepository) Create(ctx context.Context, u *url.URL) error {
 	return nil
 }
@@ -39,17 +59,42 @@ func (m *mockListURLRepository) FindByShortCode(ctx context.Context, shortCode s
 	return nil, nil
 }
 
+func (m *mockListURLRepository) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	return nil, nil
+}
+
 func (m *mockListURLRepository) Delete(ctx context.Context, shortCode string) error {
 	return nil
 }
 
+func (m *mockListURLRepository) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockListURLRepository) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	return nil
+}
+
+func (m *mockListURLRepository) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockListURLRepository) Restore(ctx context.Context, shortCode string) error {
+	return nil
+}
+
 func (m *mockListURLRepository) ListByCreatedByAndTimeRange(ctx context.Context, createdBy string, startTime, endTime time.Time) ([]*url.URL, error) {
 	return nil, nil
 }
 
 // mockClickRepository is a test double for Click repository
 type mockListClickRepository struct {
-	getTotalClickCountFunc func(ctx context.Context, urlID int64) (int64, error)
+	getTotalClickCountFunc   func(ctx context.Context, urlID int64) (int64, error)
+	getTotalClicksByUserFunc func(ctx context.Context, createdBy string) (int64, error)
+	getTopURLsByUserFunc     func(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error)
+	getDailyClicksFunc       func(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error)
+	deleteOlderThanFunc      func(ctx context.Context, t time.Time) (int64, error)
+	listByURLFunc            func(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error)
 }
 
 func (m *mockListClickRepository) GetTotalClickCount(ctx context.Context, urlID int64) (int64, error) {
@@ -59,15 +104,50 @@ func (m *mockListClickRepository) GetTotalClickCount(ctx context.Context, urlID
 	return 0, nil
 }
 
+func (m *mockListClickRepository) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	if m.getTopURLsByUserFunc != nil {
+		return m.getTopURLsByUserFunc(ctx, createdBy, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockListClickRepository) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	if m.listByURLFunc != nil {
+		return m.listByURLFunc(ctx, urlID, limit, offset)
+	}
+	return nil, nil
+}
+
+func (m *mockListClickRepository) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	if m.deleteOlderThanFunc != nil {
+		return m.deleteOlderThanFunc(ctx, t)
+	}
+	return 0, nil
+}
+
+func (m *mockListClickRepository) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	if m.getDailyClicksFunc != nil {
+		return m.getDailyClicksFunc(ctx, urlID, startDate, endDate)
+	}
+	return nil, nil
+}
+
+func (m *mockListClickRepository) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	if m.getTotalClicksByUserFunc != nil {
+		return m.getTotalClicksByUserFunc(ctx, createdBy)
+	}
+	return 0, nil
+}
+
 func (m *mockListClickRepository) Record(ctx context.Context, c *click.Click) error {
 	return nil
 }
 
-func (m *mockListClickRepository) GetStatsByURL(ctx context.Context, urlID int64) (*click.Stats, error) {
+func (m *mockListClickRepository) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
 	return nil, nil
 }
 
-func (m *mockListClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+func (m *mockListClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
 	return nil, nil
 }
 
@@ -75,6 +155,26 @@ func (m *mockListClickRepository) GetClicksByCountry(ctx context.Context, urlID
 	return nil, nil
 }
 
+func (m *mockListClickRepository) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	return nil, nil
+}
+
+func (m *mockListClickRepository) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	return nil, nil
+}
+
+func (m *mockListClickRepository) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	return nil, nil
+}
+
+func (m *mockListClickRepository) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockListClickRepository) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	return 0, nil
+}
+
 func TestListURLsUseCase_Execute_Success(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -467,3 +567,52 @@ func TestListURLsUseCase_Execute_PaginationTotal(t *testing.T) {
 		}
 	})
 }
+
+func TestListURLsUseCase_Execute_Query(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	mockRepo := &mockListURLRepository{
+		listFunc: func(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
+			t.Error("expected List not to be called when searching")
+			return nil, nil
+		},
+		countFunc: func(ctx context.Context, createdBy string) (int, error) {
+			t.Error("expected Count not to be called when searching")
+			return 0, nil
+		},
+		searchFunc: func(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+			if createdBy != "user1" || query != "docs" || limit != 5 || offset != 5 {
+				t.Errorf("unexpected search arguments: %q %q %d %d", createdBy, query, limit, offset)
+			}
+			return []*url.URL{
+				{ID: 6, ShortCode: "docs6", OriginalURL: "https://example.com/docs/6", CreatedAt: now, CreatedBy: "user1"},
+			}, nil
+		},
+		countMatchingFunc: func(ctx context.Context, createdBy, query string) (int, error) {
+			if createdBy != "user1" || query != "docs" {
+				t.Errorf("unexpected count arguments: %q %q", createdBy, query)
+			}
+			return 6, nil
+		},
+	}
+
+	useCase := NewListURLsUseCase(mockRepo, &mockListClickRepository{})
+
+	resp, err := useCase.Execute(ctx, ListURLsRequest{
+		CreatedBy: "user1",
+		Limit:     5,
+		Offset:    5,
+		Query:     "docs",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(resp.URLs) != 1 || resp.URLs[0].ShortCode != "docs6" {
+		t.Errorf("expected the matching page, got %+v", resp.URLs)
+	}
+	if resp.Total != 6 {
+		t.Errorf("expected total to be the matching count 6, got %d", resp.Total)
+	}
+}
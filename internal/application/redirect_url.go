@@ -2,10 +2,14 @@ package application
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/geolocation"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 	"github.com/matt-riley/mjrwtf/internal/domain/urlstatus"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/metrics"
@@ -17,14 +21,43 @@ const (
 	DefaultMaxWorkers = 100
 	// bufferSizeMultiplier determines the channel buffer size relative to worker count
 	bufferSizeMultiplier = 2
+	// DefaultClickEnqueueTimeout is the default bounded wait used by ClickBackpressureBlockWithTimeout
+	DefaultClickEnqueueTimeout = 50 * time.Millisecond
+	// DefaultClickRetryDelay is the default wait before the first click record retry
+	DefaultClickRetryDelay = 100 * time.Millisecond
 )
 
+// ClickBackpressurePolicy controls how click recording behaves when the queue is full.
+type ClickBackpressurePolicy string
+
+const (
+	// ClickBackpressureDrop drops the click immediately when the queue is full (default).
+	ClickBackpressureDrop ClickBackpressurePolicy = "drop"
+	// ClickBackpressureBlockWithTimeout waits briefly for queue space before dropping the click.
+	// This trades a little redirect latency for more accurate analytics.
+	ClickBackpressureBlockWithTimeout ClickBackpressurePolicy = "block-with-timeout"
+)
+
+// IsValid reports whether p is a known backpressure policy.
+func (p ClickBackpressurePolicy) IsValid() bool {
+	switch p {
+	case ClickBackpressureDrop, ClickBackpressureBlockWithTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
 // RedirectRequest contains the data needed to redirect and track a short URL
 type RedirectRequest struct {
 	ShortCode string
 	Referrer  string
 	UserAgent string
-	Country   string
+	// Country is an ISO 3166-1 alpha-2 code. When empty, it is resolved from
+	// ClientIP using the configured geolocation.Resolver.
+	Country string
+	// ClientIP is the resolved client address (honouring trusted proxy headers).
+	ClientIP string
 }
 
 // RedirectResponse contains the result of a redirect lookup
@@ -43,6 +76,7 @@ type clickRecordTask struct {
 	referrer  string
 	country   string
 	userAgent string
+	clientIP  string
 }
 
 // RedirectURLUseCase handles redirecting short URLs and tracking analytics
@@ -53,11 +87,24 @@ type RedirectURLUseCase struct {
 	clickTaskChan chan clickRecordTask
 	done          chan struct{}
 
+	// abort is closed when a bounded shutdown runs out of time; workers then
+	// skip the tasks still queued, counting them in abandoned
+	abort     chan struct{}
+	abandoned atomic.Int64
+
 	workersWg    sync.WaitGroup
 	callbackMu   sync.RWMutex
 	submitMu     sync.RWMutex
 	maxWorkers   int
 	queueSize    int
+	policy       ClickBackpressurePolicy
+	enqueueWait  time.Duration
+	attempts     int
+	retryDelay   time.Duration
+	geoResolver  geolocation.Resolver
+	visitorSalt  string
+	deduper      *clickDeduper
+	filterBots   bool
 	logger       zerolog.Logger
 	metrics      *metrics.Metrics
 	shutdownOnce sync.Once
@@ -72,6 +119,37 @@ type RedirectURLOptions struct {
 	Logger     *zerolog.Logger
 	Metrics    *metrics.Metrics
 	StatusRepo urlstatus.Repository
+
+	// BackpressurePolicy selects what happens when the click queue is full.
+	// Empty or unknown values fall back to ClickBackpressureDrop.
+	BackpressurePolicy ClickBackpressurePolicy
+	// EnqueueTimeout bounds the wait under ClickBackpressureBlockWithTimeout
+	// (default: DefaultClickEnqueueTimeout).
+	EnqueueTimeout time.Duration
+
+	// RecordAttempts is the total number of tries for each click record,
+	// including the first. Values below 2 disable retries.
+	RecordAttempts int
+	// RecordRetryDelay is the wait before the first retry; it doubles for
+	// each retry after that (default: DefaultClickRetryDelay).
+	RecordRetryDelay time.Duration
+
+	// GeoResolver resolves the click country from the client IP when the
+	// request does not carry one. Nil disables the lookup.
+	GeoResolver geolocation.Resolver
+
+	// VisitorHashSalt enables unique-visitor tracking: each click stores
+	// click.HashVisitor(salt, ClientIP). Empty disables hashing.
+	VisitorHashSalt string
+
+	// ClickDedupeWindow skips recording a click when one with the same short
+	// code, client and user agent was recorded less than this long ago.
+	// Zero disables deduplication.
+	ClickDedupeWindow time.Duration
+
+	// FilterBotClicks skips recording clicks whose user agent click.IsBot
+	// classifies as a crawler, so they stay out of analytics.
+	FilterBotClicks bool
 }
 
 // NewRedirectURLUseCase creates a new RedirectURLUseCase with bounded concurrency for click recording.
@@ -96,6 +174,26 @@ func NewRedirectURLUseCaseWithOptions(urlRepo url.Repository, clickRepo click.Re
 		queueSize = maxWorkers * bufferSizeMultiplier
 	}
 
+	policy := opts.BackpressurePolicy
+	if !policy.IsValid() {
+		policy = ClickBackpressureDrop
+	}
+
+	enqueueWait := opts.EnqueueTimeout
+	if enqueueWait <= 0 {
+		enqueueWait = DefaultClickEnqueueTimeout
+	}
+
+	attempts := opts.RecordAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	retryDelay := opts.RecordRetryDelay
+	if retryDelay <= 0 {
+		retryDelay = DefaultClickRetryDelay
+	}
+
 	logger := zerolog.Nop()
 	if opts.Logger != nil {
 		logger = *opts.Logger
@@ -107,12 +205,24 @@ func NewRedirectURLUseCaseWithOptions(urlRepo url.Repository, clickRepo click.Re
 		clickRepo:     clickRepo,
 		clickTaskChan: make(chan clickRecordTask, queueSize),
 		done:          make(chan struct{}),
+		abort:         make(chan struct{}),
 		maxWorkers:    maxWorkers,
 		queueSize:     queueSize,
+		policy:        policy,
+		enqueueWait:   enqueueWait,
+		attempts:      attempts,
+		retryDelay:    retryDelay,
+		geoResolver:   opts.GeoResolver,
+		visitorSalt:   opts.VisitorHashSalt,
+		filterBots:    opts.FilterBotClicks,
 		logger:        logger,
 		metrics:       opts.Metrics,
 	}
 
+	if opts.ClickDedupeWindow > 0 {
+		uc.deduper = newClickDeduper(opts.ClickDedupeWindow)
+	}
+
 	uc.updateQueueDepth()
 
 	uc.workersWg.Add(maxWorkers)
@@ -138,19 +248,43 @@ func (uc *RedirectURLUseCase) WithClickCallback(callback func()) *RedirectURLUse
 // - All tasks already in clickTaskChan are processed before workers exit
 // - The method blocks until all workers have finished processing
 func (uc *RedirectURLUseCase) Shutdown() {
+	uc.ShutdownContext(context.Background())
+}
+
+// ShutdownContext is Shutdown bounded by ctx. If ctx ends before the queue has
+// drained, the tasks still queued are dropped and pending retries are cut short;
+// record attempts already in progress are left to finish (each is bounded by the
+// click repository's own timeout) rather than cancelled mid-write.
+// It returns the number of click tasks dropped this way (0 when fully drained).
+func (uc *RedirectURLUseCase) ShutdownContext(ctx context.Context) int {
+	var dropped int
 	uc.shutdownOnce.Do(func() {
 		uc.submitMu.Lock()
 		close(uc.done)
 		close(uc.clickTaskChan)
 		uc.submitMu.Unlock()
 
-		uc.workersWg.Wait()
+		drained := make(chan struct{})
+		go func() {
+			uc.workersWg.Wait()
+			close(drained)
+		}()
+
+		select {
+		case <-drained:
+		case <-ctx.Done():
+			close(uc.abort)
+			<-drained
+		}
 		uc.updateQueueDepth()
+		dropped = int(uc.abandoned.Load())
 	})
+	return dropped
 }
 
 // clickRecordWorker processes click recording tasks from the channel.
-// It drains all tasks from clickTaskChan before exiting, ensuring no queued tasks are lost during shutdown.
+// It drains all tasks from clickTaskChan before exiting, ensuring no queued tasks are lost during shutdown;
+// only a ShutdownContext that runs out of time makes it skip the rest.
 func (uc *RedirectURLUseCase) clickRecordWorker() {
 	defer uc.workersWg.Done()
 
@@ -159,11 +293,23 @@ func (uc *RedirectURLUseCase) clickRecordWorker() {
 		cb := uc.onClickRecorded
 		uc.callbackMu.RUnlock()
 
+		uc.updateQueueDepth()
+
+		select {
+		case <-uc.abort:
+			uc.abandonTask()
+			continue
+		default:
+		}
+
 		bgCtx := context.Background()
 
-		uc.updateQueueDepth()
+		country := task.country
+		if country == "" {
+			country = uc.resolveCountry(task.clientIP)
+		}
 
-		newClick, err := click.NewClick(task.urlID, task.referrer, task.country, task.userAgent)
+		newClick, err := click.NewClick(task.urlID, task.referrer, country, task.userAgent)
 		if err != nil {
 			uc.recordFailure(err, "failed to create click entity")
 			if cb != nil {
@@ -172,8 +318,14 @@ func (uc *RedirectURLUseCase) clickRecordWorker() {
 			continue
 		}
 
-		if err := uc.clickRepo.Record(bgCtx, newClick); err != nil {
-			uc.recordFailure(err, "failed to record click")
+		newClick.VisitorHash = click.HashVisitor(uc.visitorSalt, task.clientIP)
+
+		if err := uc.recordWithRetry(bgCtx, newClick); err != nil {
+			if err == errClickRecordAborted {
+				uc.abandonTask()
+			} else {
+				uc.recordFailure(err, "failed to record click")
+			}
 		}
 
 		if cb != nil {
@@ -182,12 +334,60 @@ func (uc *RedirectURLUseCase) clickRecordWorker() {
 	}
 }
 
+// errClickRecordAborted reports that a timed-out shutdown cut a click's retries short.
+var errClickRecordAborted = errors.New("click record retries aborted by shutdown")
+
+// recordWithRetry stores c, retrying failed writes up to uc.attempts in total with
+// a doubling delay between tries. Waits end early when a bounded shutdown runs out
+// of time, so retries can't hold up draining past the caller's deadline.
+func (uc *RedirectURLUseCase) recordWithRetry(ctx context.Context, c *click.Click) error {
+	delay := uc.retryDelay
+	for attempt := 1; ; attempt++ {
+		err := uc.clickRepo.Record(ctx, c)
+		if err == nil || attempt >= uc.attempts {
+			return err
+		}
+
+		uc.logger.Debug().Err(err).Int("attempt", attempt).Dur("retry_in", delay).Msg("click record failed; retrying")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-uc.abort:
+			timer.Stop()
+			return errClickRecordAborted
+		}
+		delay *= 2
+	}
+}
+
+// resolveCountry looks up the country for a client IP. Lookups run on the click
+// workers so a slow provider never delays the redirect; failures are logged and
+// yield an empty country.
+func (uc *RedirectURLUseCase) resolveCountry(clientIP string) string {
+	if uc.geoResolver == nil || clientIP == "" {
+		return ""
+	}
+
+	country, err := uc.geoResolver.CountryForIP(clientIP)
+	if err != nil {
+		uc.logger.Debug().Err(err).Str("client_ip", clientIP).Msg("geoip lookup failed")
+		return ""
+	}
+
+	return country
+}
+
 // Execute performs the redirect lookup and records analytics asynchronously
 func (uc *RedirectURLUseCase) Execute(ctx context.Context, req RedirectRequest) (*RedirectResponse, error) {
 	foundURL, err := uc.urlRepo.FindByShortCode(ctx, req.ShortCode)
 	if err != nil {
 		return nil, err
 	}
+	// Soft-deleted codes 404 like missing ones and record no clicks
+	if foundURL.IsDeleted() {
+		return nil, url.ErrURLNotFound
+	}
 
 	var resp RedirectResponse
 	resp.OriginalURL = foundURL.OriginalURL
@@ -207,33 +407,86 @@ func (uc *RedirectURLUseCase) Execute(ctx context.Context, req RedirectRequest)
 		}
 	}
 
-	uc.enqueueClick(clickRecordTask{
+	if uc.filterBots && click.IsBot(req.UserAgent) {
+		uc.logger.Debug().Str("short_code", req.ShortCode).Msg("skipping bot click")
+		return &resp, nil
+	}
+	dedupeKey, duplicate := uc.dedupeClick(req)
+	if duplicate {
+		uc.logger.Debug().Str("short_code", req.ShortCode).Msg("skipping duplicate click")
+		return &resp, nil
+	}
+
+	queued := uc.enqueueClick(ctx, clickRecordTask{
 		urlID:     foundURL.ID,
 		shortCode: req.ShortCode,
 		referrer:  req.Referrer,
 		country:   req.Country,
 		userAgent: req.UserAgent,
+		clientIP:  req.ClientIP,
 	})
+	// A dropped click was never recorded, so it must not suppress the next one
+	if !queued && dedupeKey != "" {
+		uc.deduper.forget(dedupeKey)
+	}
 
 	return &resp, nil
 }
 
-func (uc *RedirectURLUseCase) enqueueClick(task clickRecordTask) {
+// dedupeClick reports whether req repeats a click recorded within the dedupe
+// window, returning the key it was checked under. Requests with neither a client
+// IP nor a user agent can't be told apart, so they are never treated as
+// duplicates and get an empty key.
+func (uc *RedirectURLUseCase) dedupeClick(req RedirectRequest) (string, bool) {
+	if uc.deduper == nil || (req.ClientIP == "" && req.UserAgent == "") {
+		return "", false
+	}
+
+	visitor := req.ClientIP
+	if hash := click.HashVisitor(uc.visitorSalt, req.ClientIP); hash != "" {
+		visitor = hash
+	}
+	key := clickDedupeKey(req.ShortCode, visitor, req.UserAgent)
+	return key, uc.deduper.duplicate(key)
+}
+
+// enqueueClick submits a click task according to the configured backpressure policy
+// and reports whether it was queued. Under ClickBackpressureBlockWithTimeout the wait
+// is bounded by both the request context and the enqueue timeout; a timed-out task
+// is counted as dropped.
+func (uc *RedirectURLUseCase) enqueueClick(ctx context.Context, task clickRecordTask) bool {
 	uc.submitMu.RLock()
 	defer uc.submitMu.RUnlock()
 
 	select {
 	case <-uc.done:
 		uc.dropTask("shutdown in progress")
-		return
+		return false
 	default:
 	}
 
 	select {
 	case uc.clickTaskChan <- task:
 		uc.updateQueueDepth()
+		return true
 	default:
+	}
+
+	if uc.policy != ClickBackpressureBlockWithTimeout {
 		uc.dropTask("queue full")
+		return false
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, uc.enqueueWait)
+	defer cancel()
+
+	select {
+	case uc.clickTaskChan <- task:
+		uc.updateQueueDepth()
+		return true
+	case <-waitCtx.Done():
+		uc.dropTask("queue full (enqueue timeout)")
+		return false
 	}
 }
 
@@ -256,6 +509,15 @@ func (uc *RedirectURLUseCase) dropTask(reason string) {
 		Msg("dropping redirect click analytics")
 }
 
+// abandonTask counts a task given up on by a timed-out shutdown. Unlike dropTask it
+// does not log: ShutdownContext reports the total instead.
+func (uc *RedirectURLUseCase) abandonTask() {
+	uc.abandoned.Add(1)
+	if uc.metrics != nil && uc.metrics.RedirectClickDroppedTotal != nil {
+		uc.metrics.RedirectClickDroppedTotal.Inc()
+	}
+}
+
 func (uc *RedirectURLUseCase) recordFailure(err error, msg string) {
 	if uc.metrics != nil && uc.metrics.RedirectClickRecordFailuresTotal != nil {
 		uc.metrics.RedirectClickRecordFailuresTotal.Inc()
@@ -34,11 +34,11 @@ func (m *slowMockClickRepository) Record(ctx context.Context, c *click.Click) er
 	return nil
 }
 
-func (m *slowMockClickRepository) GetStatsByURL(ctx context.Context, urlID int64) (*click.Stats, error) {
+func (m *slowMockClickRepository) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
 	return &click.Stats{}, nil
 }
 
-func (m *slowMockClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+func (m *slowMockClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
 	return &click.TimeRangeStats{}, nil
 }
 
@@ -48,10 +48,50 @@ func (m *slowMockClickRepository) GetTotalClickCount(ctx context.Context, urlID
 	return int64(len(m.clicks)), nil
 }
 
+func (m *slowMockClickRepository) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	return nil, nil
+}
+
+func (m *slowMockClickRepository) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	return nil, nil
+}
+
+func (m *slowMockClickRepository) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *slowMockClickRepository) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	return nil, nil
+}
+
+func (m *slowMockClickRepository) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	return 0, nil
+}
+
 func (m *slowMockClickRepository) GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error) {
 	return make(map[string]int64), nil
 }
 
+func (m *slowMockClickRepository) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	return make(map[string]int64), nil
+}
+
+func (m *slowMockClickRepository) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	return make(map[string]int64), nil
+}
+
+func (m *slowMockClickRepository) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	return make(map[string]int64), nil
+}
+
+func (m *slowMockClickRepository) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	return 0, nil
+}
+
+func (m *slowMockClickRepository) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	return 0, nil
+}
+
 func (m *slowMockClickRepository) getClickCount() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
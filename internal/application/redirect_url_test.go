@@ -51,6 +51,10 @@ func (m *mockURLRepository) FindByShortCode(ctx context.Context, shortCode strin
 	return u, nil
 }
 
+func (m *mockURLRepository) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*url.URL, error) {
+	return nil, url.ErrURLNotFound
+}
+
 func (m *mockURLRepository) Delete(ctx context.Context, shortCode string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -61,6 +65,22 @@ func (m *mockURLRepository) Delete(ctx context.Context, shortCode string) error
 	return nil
 }
 
+func (m *mockURLRepository) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockURLRepository) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	return nil
+}
+
+func (m *mockURLRepository) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	return nil, nil
+}
+
+func (m *mockURLRepository) Restore(ctx context.Context, shortCode string) error {
+	return nil
+}
+
 func (m *mockURLRepository) List(ctx context.Context, createdBy string, limit, offset int) ([]*url.URL, error) {
 	return []*url.URL{}, nil
 }
@@ -73,6 +93,18 @@ func (m *mockURLRepository) Count(ctx context.Context, createdBy string) (int, e
 	return 0, nil
 }
 
+func (m *mockURLRepository) CountAll(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *mockURLRepository) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*url.URL, error) {
+	return nil, nil
+}
+
+func (m *mockURLRepository) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	return 0, nil
+}
+
 type mockURLStatusRepository struct {
 	status   *urlstatus.URLStatus
 	getError error
@@ -116,11 +148,11 @@ func (m *mockClickRepository) Record(ctx context.Context, c *click.Click) error
 	return nil
 }
 
-func (m *mockClickRepository) GetStatsByURL(ctx context.Context, urlID int64) (*click.Stats, error) {
+func (m *mockClickRepository) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
 	return nil, nil
 }
 
-func (m *mockClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+func (m *mockClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
 	return nil, nil
 }
 
@@ -128,10 +160,50 @@ func (m *mockClickRepository) GetTotalClickCount(ctx context.Context, urlID int6
 	return 0, nil
 }
 
+func (m *mockClickRepository) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockClickRepository) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockClickRepository) GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error) {
 	return nil, nil
 }
 
+func (m *mockClickRepository) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	return nil, nil
+}
+
+func (m *mockClickRepository) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockClickRepository) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	return 0, nil
+}
+
 func (m *mockClickRepository) getRecordedClicksCount() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -321,6 +393,37 @@ func TestRedirectURLUseCase_Execute_URLNotFound(t *testing.T) {
 	}
 }
 
+func TestRedirectURLUseCase_Execute_SoftDeletedURL(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newMockClickRepository()
+
+	deletedAt := time.Now()
+	urlRepo.urls["gone123"] = &url.URL{
+		ID:          1,
+		ShortCode:   "gone123",
+		OriginalURL: "https://example.com",
+		CreatedBy:   "user1",
+		DeletedAt:   &deletedAt,
+	}
+
+	useCase := NewRedirectURLUseCase(urlRepo, clickRepo)
+	defer useCase.Shutdown()
+
+	resp, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "gone123"})
+
+	if !errors.Is(err, url.ErrURLNotFound) {
+		t.Errorf("Expected ErrURLNotFound, got %v", err)
+	}
+	if resp != nil {
+		t.Errorf("Expected nil response, got %v", resp)
+	}
+
+	useCase.Shutdown()
+	if clickRepo.getRecordedClicksCount() != 0 {
+		t.Errorf("Expected 0 clicks to be recorded, got %d", clickRepo.getRecordedClicksCount())
+	}
+}
+
 func TestRedirectURLUseCase_Execute_AsyncClickRecording(t *testing.T) {
 	// Setup
 	urlRepo := newMockURLRepository()
@@ -491,6 +594,164 @@ func TestRedirectURLUseCase_Execute_EmptyCountry(t *testing.T) {
 	useCase.Shutdown()
 }
 
+type fakeGeoResolver struct {
+	mu        sync.Mutex
+	countries map[string]string
+	err       error
+	calls     []string
+}
+
+func (f *fakeGeoResolver) CountryForIP(ip string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, ip)
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.countries[ip], nil
+}
+
+func (f *fakeGeoResolver) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+func TestRedirectURLUseCase_Execute_GeoResolver(t *testing.T) {
+	tests := []struct {
+		name        string
+		resolver    *fakeGeoResolver
+		req         RedirectRequest
+		wantCountry string
+		wantCalls   int
+	}{
+		{
+			name:        "country resolved from client IP",
+			resolver:    &fakeGeoResolver{countries: map[string]string{"203.0.113.7": "NZ"}},
+			req:         RedirectRequest{ShortCode: "geo", ClientIP: "203.0.113.7"},
+			wantCountry: "NZ",
+			wantCalls:   1,
+		},
+		{
+			name:        "explicit country takes precedence",
+			resolver:    &fakeGeoResolver{countries: map[string]string{"203.0.113.7": "NZ"}},
+			req:         RedirectRequest{ShortCode: "geo", Country: "GB", ClientIP: "203.0.113.7"},
+			wantCountry: "GB",
+			wantCalls:   0,
+		},
+		{
+			name:        "resolver error yields empty country",
+			resolver:    &fakeGeoResolver{err: errors.New("lookup failed")},
+			req:         RedirectRequest{ShortCode: "geo", ClientIP: "203.0.113.7"},
+			wantCountry: "",
+			wantCalls:   1,
+		},
+		{
+			name:        "missing client IP skips lookup",
+			resolver:    &fakeGeoResolver{},
+			req:         RedirectRequest{ShortCode: "geo"},
+			wantCountry: "",
+			wantCalls:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlRepo := newMockURLRepository()
+			clickRepo := newMockClickRepository()
+			urlRepo.urls["geo"] = &url.URL{
+				ID:          9,
+				ShortCode:   "geo",
+				OriginalURL: "https://example.com/geo",
+				CreatedAt:   time.Now(),
+				CreatedBy:   "user9",
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+				MaxWorkers:  1,
+				GeoResolver: tt.resolver,
+			}).WithClickCallback(func() {
+				wg.Done()
+			})
+			defer useCase.Shutdown()
+
+			if _, err := useCase.Execute(context.Background(), tt.req); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			wg.Wait()
+
+			clickRepo.mu.Lock()
+			defer clickRepo.mu.Unlock()
+			if len(clickRepo.clicks) != 1 {
+				t.Fatalf("expected 1 recorded click, got %d", len(clickRepo.clicks))
+			}
+			if got := clickRepo.clicks[0].Country; got != tt.wantCountry {
+				t.Errorf("recorded click country = %q, want %q", got, tt.wantCountry)
+			}
+			if got := tt.resolver.callCount(); got != tt.wantCalls {
+				t.Errorf("resolver calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRedirectURLUseCase_Execute_VisitorHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		salt     string
+		clientIP string
+		want     string
+	}{
+		{"hash stored when salt configured", "salt", "203.0.113.7", click.HashVisitor("salt", "203.0.113.7")},
+		{"no hash without salt", "", "203.0.113.7", ""},
+		{"no hash without client IP", "salt", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlRepo := newMockURLRepository()
+			clickRepo := newMockClickRepository()
+			urlRepo.urls["uv"] = &url.URL{
+				ID:          10,
+				ShortCode:   "uv",
+				OriginalURL: "https://example.com/uv",
+				CreatedAt:   time.Now(),
+				CreatedBy:   "user10",
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+
+			useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+				MaxWorkers:      1,
+				VisitorHashSalt: tt.salt,
+			}).WithClickCallback(func() {
+				wg.Done()
+			})
+			defer useCase.Shutdown()
+
+			if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "uv", ClientIP: tt.clientIP}); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			wg.Wait()
+
+			clickRepo.mu.Lock()
+			defer clickRepo.mu.Unlock()
+			if len(clickRepo.clicks) != 1 {
+				t.Fatalf("expected 1 recorded click, got %d", len(clickRepo.clicks))
+			}
+			if got := clickRepo.clicks[0].VisitorHash; got != tt.want {
+				t.Errorf("recorded click visitor hash = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRedirectURLUseCase_Execute_MultipleClicks(t *testing.T) {
 	// Setup
 	urlRepo := newMockURLRepository()
@@ -544,6 +805,180 @@ func TestRedirectURLUseCase_Execute_MultipleClicks(t *testing.T) {
 	useCase.Shutdown()
 }
 
+func TestRedirectURLUseCase_Execute_ClickDedupeWindow(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newMockClickRepository()
+	urlRepo.urls["dedupe"] = &url.URL{
+		ID:          11,
+		ShortCode:   "dedupe",
+		OriginalURL: "https://example.com/dedupe",
+		CreatedAt:   time.Now(),
+		CreatedBy:   "user11",
+	}
+
+	var wg sync.WaitGroup
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+		MaxWorkers:        1,
+		ClickDedupeWindow: time.Second,
+	}).WithClickCallback(wg.Done)
+	defer useCase.Shutdown()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	useCase.deduper.now = func() time.Time { return now }
+
+	redirect := func(clientIP, userAgent string) {
+		t.Helper()
+		if _, err := useCase.Execute(context.Background(), RedirectRequest{
+			ShortCode: "dedupe",
+			UserAgent: userAgent,
+			ClientIP:  clientIP,
+		}); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	// The first click is recorded, an identical one 50ms later is not
+	wg.Add(1)
+	redirect("203.0.113.7", "Bot/1.0")
+	now = now.Add(50 * time.Millisecond)
+	redirect("203.0.113.7", "Bot/1.0")
+	wg.Wait()
+	if got := clickRepo.getRecordedClicksCount(); got != 1 {
+		t.Fatalf("expected 1 click for two identical rapid requests, got %d", got)
+	}
+
+	// A different client or user agent is a different click
+	wg.Add(2)
+	redirect("198.51.100.1", "Bot/1.0")
+	redirect("203.0.113.7", "Mozilla/5.0")
+	wg.Wait()
+	if got := clickRepo.getRecordedClicksCount(); got != 3 {
+		t.Fatalf("expected 3 clicks after requests from other clients, got %d", got)
+	}
+
+	// Once the window has passed, the same request counts again
+	wg.Add(1)
+	now = now.Add(time.Second)
+	redirect("203.0.113.7", "Bot/1.0")
+	wg.Wait()
+	if got := clickRepo.getRecordedClicksCount(); got != 4 {
+		t.Fatalf("expected a second click outside the window, got %d clicks", got)
+	}
+}
+
+func TestRedirectURLUseCase_Execute_ClickDedupeForgetsDroppedClicks(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newBlockingClickRepository()
+	urlRepo.urls["full"] = &url.URL{
+		ID:          1,
+		ShortCode:   "full",
+		OriginalURL: "https://example.com",
+		CreatedAt:   time.Now(),
+		CreatedBy:   "user1",
+	}
+
+	var wg sync.WaitGroup
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+		MaxWorkers:         1,
+		QueueSize:          1,
+		BackpressurePolicy: ClickBackpressureDrop,
+		ClickDedupeWindow:  time.Minute,
+	}).WithClickCallback(wg.Done)
+
+	redirect := func(userAgent string) {
+		t.Helper()
+		if _, err := useCase.Execute(context.Background(), RedirectRequest{
+			ShortCode: "full",
+			UserAgent: userAgent,
+			ClientIP:  "203.0.113.7",
+		}); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+	}
+
+	// Occupy the worker and fill the buffer, so the third click is dropped
+	wg.Add(2)
+	redirect("a")
+	<-clickRepo.started
+	redirect("b")
+	redirect("c")
+
+	close(clickRepo.unblock)
+	wg.Wait()
+
+	// The dropped click was never recorded, so repeating it inside the window counts
+	wg.Add(1)
+	redirect("c")
+	useCase.Shutdown()
+
+	clickRepo.mu.Lock()
+	defer clickRepo.mu.Unlock()
+	if clickRepo.calls != 3 {
+		t.Errorf("expected 3 recorded clicks, got %d", clickRepo.calls)
+	}
+}
+
+func TestRedirectURLUseCase_Execute_FilterBotClicks(t *testing.T) {
+	const (
+		googlebot = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+		browser   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+	)
+
+	tests := []struct {
+		name       string
+		filter     bool
+		wantAgents []string
+	}{
+		{"filtering on skips the bot", true, []string{browser}},
+		{"filtering off records both", false, []string{googlebot, browser}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlRepo := newMockURLRepository()
+			clickRepo := newMockClickRepository()
+			urlRepo.urls["bots"] = &url.URL{
+				ID:          12,
+				ShortCode:   "bots",
+				OriginalURL: "https://example.com/bots",
+				CreatedAt:   time.Now(),
+				CreatedBy:   "user12",
+			}
+
+			var wg sync.WaitGroup
+			wg.Add(len(tt.wantAgents))
+			useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+				MaxWorkers:      1,
+				FilterBotClicks: tt.filter,
+			}).WithClickCallback(wg.Done)
+
+			for _, ua := range []string{googlebot, browser} {
+				resp, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "bots", UserAgent: ua})
+				if err != nil {
+					t.Fatalf("Execute() error = %v", err)
+				}
+				if resp.OriginalURL != "https://example.com/bots" {
+					t.Errorf("bots are still redirected, got OriginalURL %q", resp.OriginalURL)
+				}
+			}
+
+			wg.Wait()
+			useCase.Shutdown()
+
+			clickRepo.mu.Lock()
+			defer clickRepo.mu.Unlock()
+			if len(clickRepo.clicks) != len(tt.wantAgents) {
+				t.Fatalf("expected %d recorded clicks, got %d", len(tt.wantAgents), len(clickRepo.clicks))
+			}
+			for i, want := range tt.wantAgents {
+				if got := clickRepo.clicks[i].UserAgent; got != want {
+					t.Errorf("click %d user agent = %q, want %q", i, got, want)
+				}
+			}
+		})
+	}
+}
+
 func TestRedirectURLUseCase_Shutdown(t *testing.T) {
 	// Setup
 	urlRepo := newMockURLRepository()
@@ -646,6 +1081,193 @@ func TestRedirectURLUseCase_ShutdownDrainsPendingTasks(t *testing.T) {
 	}
 }
 
+func TestRedirectURLUseCase_ShutdownContext_DrainsWithinDeadline(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newSlowMockClickRepository(5 * time.Millisecond)
+	urlRepo.urls["drain"] = &url.URL{ID: 1, ShortCode: "drain", OriginalURL: "https://drain.com", CreatedBy: "user1"}
+
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{MaxWorkers: 2, QueueSize: 10})
+	for i := 0; i < 10; i++ {
+		if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "drain"}); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if dropped := useCase.ShutdownContext(ctx); dropped != 0 {
+		t.Errorf("ShutdownContext() dropped %d tasks, want 0", dropped)
+	}
+	if got := clickRepo.getClickCount(); got != 10 {
+		t.Errorf("Expected 10 clicks to be recorded, got %d", got)
+	}
+}
+
+func TestRedirectURLUseCase_ShutdownContext_DropsOnTimeout(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newBlockingClickRepository()
+	m := metrics.New()
+	urlRepo.urls["stuck"] = &url.URL{ID: 1, ShortCode: "stuck", OriginalURL: "https://stuck.com", CreatedBy: "user1"}
+
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{MaxWorkers: 1, QueueSize: 10, Metrics: m})
+	for i := 0; i < 5; i++ {
+		if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "stuck"}); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+	}
+	// The single worker is now stuck on the first record with four tasks queued behind it
+	<-clickRepo.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	done := make(chan int)
+	go func() { done <- useCase.ShutdownContext(ctx) }()
+
+	// The in-flight record is allowed to finish once shutdown has given up
+	<-useCase.abort
+	close(clickRepo.unblock)
+
+	select {
+	case dropped := <-done:
+		if dropped != 4 {
+			t.Errorf("ShutdownContext() dropped %d tasks, want 4", dropped)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ShutdownContext() did not return after the in-flight record finished")
+	}
+
+	clickRepo.mu.Lock()
+	calls := clickRepo.calls
+	clickRepo.mu.Unlock()
+	if calls != 1 {
+		t.Errorf("expected only the in-flight record to reach the repository, got %d calls", calls)
+	}
+	if got := testutil.ToFloat64(m.RedirectClickDroppedTotal); got != 4 {
+		t.Errorf("expected 4 dropped tasks in metrics, got %f", got)
+	}
+}
+
+// flakyClickRepository fails the first `failures` Record calls, then stores clicks normally
+type flakyClickRepository struct {
+	*mockClickRepository
+	failures int
+	calls    int
+}
+
+func (m *flakyClickRepository) Record(ctx context.Context, c *click.Click) error {
+	m.mu.Lock()
+	m.calls++
+	fail := m.calls <= m.failures
+	m.mu.Unlock()
+	if fail {
+		return errors.New("database is locked")
+	}
+	return m.mockClickRepository.Record(ctx, c)
+}
+
+func (m *flakyClickRepository) recorded() (calls, clicks int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls, len(m.clicks)
+}
+
+func TestRedirectURLUseCase_RecordRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempts     int
+		failures     int
+		wantCalls    int
+		wantClicks   int
+		wantFailures float64
+	}{
+		{name: "fails twice then succeeds", attempts: 3, failures: 2, wantCalls: 3, wantClicks: 1, wantFailures: 0},
+		{name: "gives up after attempts", attempts: 2, failures: 5, wantCalls: 2, wantClicks: 0, wantFailures: 1},
+		{name: "retries disabled", attempts: 1, failures: 1, wantCalls: 1, wantClicks: 0, wantFailures: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlRepo := newMockURLRepository()
+			clickRepo := &flakyClickRepository{mockClickRepository: newMockClickRepository(), failures: tt.failures}
+			m := metrics.New()
+			urlRepo.urls["retry"] = &url.URL{ID: 1, ShortCode: "retry", OriginalURL: "https://example.com", CreatedBy: "user1"}
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+				MaxWorkers:       1,
+				Metrics:          m,
+				RecordAttempts:   tt.attempts,
+				RecordRetryDelay: time.Millisecond,
+			}).WithClickCallback(wg.Done)
+			defer useCase.Shutdown()
+
+			if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "retry"}); err != nil {
+				t.Fatalf("Execute failed: %v", err)
+			}
+			wg.Wait()
+
+			calls, clicks := clickRepo.recorded()
+			if calls != tt.wantCalls {
+				t.Errorf("Record called %d times, want %d", calls, tt.wantCalls)
+			}
+			if clicks != tt.wantClicks {
+				t.Errorf("recorded %d clicks, want %d", clicks, tt.wantClicks)
+			}
+			if got := testutil.ToFloat64(m.RedirectClickRecordFailuresTotal); got != tt.wantFailures {
+				t.Errorf("expected %v record failures in metrics, got %f", tt.wantFailures, got)
+			}
+		})
+	}
+}
+
+func TestRedirectURLUseCase_RecordRetry_AbortedByShutdownDeadline(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := &flakyClickRepository{mockClickRepository: newMockClickRepository(), failures: 1}
+	m := metrics.New()
+	urlRepo.urls["retry"] = &url.URL{ID: 1, ShortCode: "retry", OriginalURL: "https://example.com", CreatedBy: "user1"}
+
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+		MaxWorkers:       1,
+		Metrics:          m,
+		RecordAttempts:   3,
+		RecordRetryDelay: time.Hour,
+	})
+
+	if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "retry"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	// Wait for the first attempt to fail so the worker is sleeping before its retry
+	for deadline := time.Now().Add(2 * time.Second); ; {
+		if calls, _ := clickRepo.recorded(); calls == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("first record attempt never ran")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	dropped := useCase.ShutdownContext(ctx)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("ShutdownContext() waited %v for a pending retry", elapsed)
+	}
+	if dropped != 1 {
+		t.Errorf("ShutdownContext() dropped %d tasks, want 1", dropped)
+	}
+	if calls, _ := clickRepo.recorded(); calls != 1 {
+		t.Errorf("expected no retry after the shutdown deadline, got %d calls", calls)
+	}
+	if got := testutil.ToFloat64(m.RedirectClickRecordFailuresTotal); got != 0 {
+		t.Errorf("expected an aborted retry to count as dropped, not failed; got %f failures", got)
+	}
+}
+
 func TestRedirectURLUseCase_ShutdownRejectsNewTasks(t *testing.T) {
 	// Setup
 	urlRepo := newMockURLRepository()
@@ -725,11 +1347,11 @@ func (m *blockingClickRepository) Record(ctx context.Context, c *click.Click) er
 	return nil
 }
 
-func (m *blockingClickRepository) GetStatsByURL(ctx context.Context, urlID int64) (*click.Stats, error) {
+func (m *blockingClickRepository) GetStatsByURL(ctx context.Context, urlID int64, topN int) (*click.Stats, error) {
 	return &click.Stats{}, nil
 }
 
-func (m *blockingClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*click.TimeRangeStats, error) {
+func (m *blockingClickRepository) GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*click.TimeRangeStats, error) {
 	return &click.TimeRangeStats{}, nil
 }
 
@@ -737,10 +1359,50 @@ func (m *blockingClickRepository) GetTotalClickCount(ctx context.Context, urlID
 	return 0, nil
 }
 
+func (m *blockingClickRepository) GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]click.URLClicks, error) {
+	return nil, nil
+}
+
+func (m *blockingClickRepository) ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*click.Click, error) {
+	return nil, nil
+}
+
+func (m *blockingClickRepository) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *blockingClickRepository) GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]click.DailyCount, error) {
+	return nil, nil
+}
+
+func (m *blockingClickRepository) GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error) {
+	return 0, nil
+}
+
 func (m *blockingClickRepository) GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error) {
 	return map[string]int64{}, nil
 }
 
+func (m *blockingClickRepository) GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error) {
+	return map[string]int64{}, nil
+}
+
+func (m *blockingClickRepository) GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error) {
+	return map[string]int64{}, nil
+}
+
+func (m *blockingClickRepository) GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity click.Granularity) (map[string]int64, error) {
+	return map[string]int64{}, nil
+}
+
+func (m *blockingClickRepository) GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error) {
+	return 0, nil
+}
+
+func (m *blockingClickRepository) GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error) {
+	return 0, nil
+}
+
 func TestRedirectURLUseCase_Metrics_DroppedOnFull_AndQueueDepthGauge(t *testing.T) {
 	urlRepo := newMockURLRepository()
 	clickRepo := newBlockingClickRepository()
@@ -792,6 +1454,197 @@ func TestRedirectURLUseCase_Metrics_DroppedOnFull_AndQueueDepthGauge(t *testing.
 	}
 }
 
+func TestRedirectURLUseCase_Backpressure_DropPolicyDoesNotWait(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newBlockingClickRepository()
+	m := metrics.New()
+
+	urlRepo.urls["full"] = &url.URL{
+		ID:          1,
+		ShortCode:   "full",
+		OriginalURL: "https://example.com",
+		CreatedAt:   time.Now(),
+		CreatedBy:   "user1",
+	}
+
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+		MaxWorkers:         1,
+		QueueSize:          1,
+		Metrics:            m,
+		BackpressurePolicy: ClickBackpressureDrop,
+		EnqueueTimeout:     time.Second,
+	})
+
+	if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	<-clickRepo.started
+
+	// Fill the buffer, then overflow it.
+	_, _ = useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"})
+
+	start := time.Now()
+	if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 500*time.Millisecond {
+		t.Fatalf("drop policy should not wait for queue space, took %v", elapsed)
+	}
+
+	if dropped := testutil.ToFloat64(m.RedirectClickDroppedTotal); dropped != 1 {
+		t.Fatalf("expected 1 dropped task, got %f", dropped)
+	}
+
+	close(clickRepo.unblock)
+	useCase.Shutdown()
+}
+
+func TestRedirectURLUseCase_Backpressure_BlockWithTimeout_DropsAfterTimeout(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newBlockingClickRepository()
+	m := metrics.New()
+
+	urlRepo.urls["full"] = &url.URL{
+		ID:          1,
+		ShortCode:   "full",
+		OriginalURL: "https://example.com",
+		CreatedAt:   time.Now(),
+		CreatedBy:   "user1",
+	}
+
+	timeout := 50 * time.Millisecond
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+		MaxWorkers:         1,
+		QueueSize:          1,
+		Metrics:            m,
+		BackpressurePolicy: ClickBackpressureBlockWithTimeout,
+		EnqueueTimeout:     timeout,
+	})
+
+	if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	<-clickRepo.started
+
+	_, _ = useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"})
+
+	start := time.Now()
+	resp, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"})
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if resp.OriginalURL != "https://example.com" {
+		t.Fatalf("expected redirect to succeed despite dropped click, got %q", resp.OriginalURL)
+	}
+	if elapsed := time.Since(start); elapsed < timeout {
+		t.Fatalf("expected enqueue to wait at least %v, took %v", timeout, elapsed)
+	}
+
+	if dropped := testutil.ToFloat64(m.RedirectClickDroppedTotal); dropped != 1 {
+		t.Fatalf("expected 1 dropped task after timeout, got %f", dropped)
+	}
+
+	close(clickRepo.unblock)
+	useCase.Shutdown()
+}
+
+func TestRedirectURLUseCase_Backpressure_BlockWithTimeout_EnqueuesWhenSpaceFrees(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newBlockingClickRepository()
+	m := metrics.New()
+
+	urlRepo.urls["full"] = &url.URL{
+		ID:          1,
+		ShortCode:   "full",
+		OriginalURL: "https://example.com",
+		CreatedAt:   time.Now(),
+		CreatedBy:   "user1",
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+		MaxWorkers:         1,
+		QueueSize:          1,
+		Metrics:            m,
+		BackpressurePolicy: ClickBackpressureBlockWithTimeout,
+		EnqueueTimeout:     5 * time.Second,
+	}).WithClickCallback(func() {
+		wg.Done()
+	})
+	defer useCase.Shutdown()
+
+	if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	<-clickRepo.started
+
+	_, _ = useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"})
+
+	// Free the worker shortly after the third click starts waiting for space.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(clickRepo.unblock)
+	}()
+
+	if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+
+	wg.Wait()
+
+	if dropped := testutil.ToFloat64(m.RedirectClickDroppedTotal); dropped != 0 {
+		t.Fatalf("expected no dropped tasks, got %f", dropped)
+	}
+}
+
+func TestRedirectURLUseCase_Backpressure_BlockWithTimeout_RespectsRequestContext(t *testing.T) {
+	urlRepo := newMockURLRepository()
+	clickRepo := newBlockingClickRepository()
+	m := metrics.New()
+
+	urlRepo.urls["full"] = &url.URL{
+		ID:          1,
+		ShortCode:   "full",
+		OriginalURL: "https://example.com",
+		CreatedAt:   time.Now(),
+		CreatedBy:   "user1",
+	}
+
+	useCase := NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, RedirectURLOptions{
+		MaxWorkers:         1,
+		QueueSize:          1,
+		Metrics:            m,
+		BackpressurePolicy: ClickBackpressureBlockWithTimeout,
+		EnqueueTimeout:     5 * time.Second,
+	})
+
+	if _, err := useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	<-clickRepo.started
+
+	_, _ = useCase.Execute(context.Background(), RedirectRequest{ShortCode: "full"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := useCase.Execute(ctx, RedirectRequest{ShortCode: "full"}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Fatalf("expected request context to bound the wait, took %v", elapsed)
+	}
+
+	if dropped := testutil.ToFloat64(m.RedirectClickDroppedTotal); dropped != 1 {
+		t.Fatalf("expected 1 dropped task, got %f", dropped)
+	}
+
+	close(clickRepo.unblock)
+	useCase.Shutdown()
+}
+
 func TestRedirectURLUseCase_Metrics_ShutdownDropIncrementsCounter(t *testing.T) {
 	urlRepo := newMockURLRepository()
 	clickRepo := newMockClickRepository()
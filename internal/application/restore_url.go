@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+// RestoreURLRequest represents the input for restoring a soft-deleted URL
+type RestoreURLRequest struct {
+	ShortCode   string
+	RequestedBy string
+}
+
+// RestoreURLResponse represents the output after restoring a soft-deleted URL
+type RestoreURLResponse struct {
+	Success bool
+}
+
+// RestoreURLUseCase handles restoring soft-deleted URLs with authorization
+type RestoreURLUseCase struct {
+	urlRepo url.Repository
+}
+
+// NewRestoreURLUseCase creates a new RestoreURLUseCase
+func NewRestoreURLUseCase(urlRepo url.Repository) *RestoreURLUseCase {
+	return &RestoreURLUseCase{
+		urlRepo: urlRepo,
+	}
+}
+
+// Execute restores a soft-deleted URL after verifying ownership.
+// Restoring a URL that is not deleted succeeds without changes.
+func (uc *RestoreURLUseCase) Execute(ctx context.Context, req RestoreURLRequest) (*RestoreURLResponse, error) {
+	// Validate short code
+	if err := url.ValidateShortCode(req.ShortCode); err != nil {
+		return nil, err
+	}
+
+	// Validate requested by
+	if req.RequestedBy == "" {
+		return nil, url.ErrInvalidCreatedBy
+	}
+
+	// Find the URL (soft-deleted URLs included) to verify it exists and check ownership
+	foundURL, err := uc.urlRepo.FindByShortCode(ctx, req.ShortCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify ownership - only the creator can restore the URL
+	if foundURL.CreatedBy != req.RequestedBy {
+		return nil, url.ErrUnauthorizedRestore
+	}
+
+	if foundURL.IsDeleted() {
+		if err := uc.urlRepo.Restore(ctx, req.ShortCode); err != nil {
+			return nil, fmt.Errorf("failed to restore URL: %w", err)
+		}
+	}
+
+	return &RestoreURLResponse{
+		Success: true,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+func TestRestoreURLUseCase_Execute(t *testing.T) {
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		request     RestoreURLRequest
+		setupMock   func(*mockRepository)
+		wantErr     error
+		wantDeleted bool
+	}{
+		{
+			name:    "restores soft-deleted URL",
+			request: RestoreURLRequest{ShortCode: "test123", RequestedBy: "user1"},
+			setupMock: func(repo *mockRepository) {
+				repo.urls["test123"] = &url.URL{ShortCode: "test123", CreatedBy: "user1", DeletedAt: &deletedAt}
+			},
+		},
+		{
+			name:    "live URL is a no-op",
+			request: RestoreURLRequest{ShortCode: "test123", RequestedBy: "user1"},
+			setupMock: func(repo *mockRepository) {
+				repo.urls["test123"] = &url.URL{ShortCode: "test123", CreatedBy: "user1"}
+			},
+		},
+		{
+			name:    "different user",
+			request: RestoreURLRequest{ShortCode: "test123", RequestedBy: "user2"},
+			setupMock: func(repo *mockRepository) {
+				repo.urls["test123"] = &url.URL{ShortCode: "test123", CreatedBy: "user1", DeletedAt: &deletedAt}
+			},
+			wantErr:     url.ErrUnauthorizedRestore,
+			wantDeleted: true,
+		},
+		{
+			name:      "URL not found",
+			request:   RestoreURLRequest{ShortCode: "missing", RequestedBy: "user1"},
+			setupMock: func(repo *mockRepository) {},
+			wantErr:   url.ErrURLNotFound,
+		},
+		{
+			name:      "invalid short code",
+			request:   RestoreURLRequest{ShortCode: "ab", RequestedBy: "user1"},
+			setupMock: func(repo *mockRepository) {},
+			wantErr:   url.ErrInvalidShortCode,
+		},
+		{
+			name:      "empty requested by",
+			request:   RestoreURLRequest{ShortCode: "test123", RequestedBy: ""},
+			setupMock: func(repo *mockRepository) {},
+			wantErr:   url.ErrInvalidCreatedBy,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMockRepository()
+			tt.setupMock(repo)
+
+			uc := NewRestoreURLUseCase(repo)
+			res, err := uc.Execute(context.Background(), tt.request)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Execute() unexpected error = %v", err)
+				}
+				if !res.Success {
+					t.Error("Execute() Success = false, want true")
+				}
+			}
+
+			if u, ok := repo.urls[tt.request.ShortCode]; ok && u.IsDeleted() != tt.wantDeleted {
+				t.Errorf("URL deleted = %v, want %v", u.IsDeleted(), tt.wantDeleted)
+			}
+		})
+	}
+}
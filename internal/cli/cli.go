@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/matt-riley/mjrwtf/internal/client"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+// connectionFlags are shared by every subcommand that talks to the API.
+// They resolve through tui_config, so MJR_BASE_URL/MJR_TOKEN and the config
+// file apply exactly as they do for the TUI.
+type connectionFlags struct {
+	baseURL *string
+	token   *string
+	timeout *string
+}
+
+func addConnectionFlags(fs *flag.FlagSet) *connectionFlags {
+	return &connectionFlags{
+		baseURL: fs.String("base-url", "", "API base URL"),
+		token:   fs.String("token", "", "API bearer token"),
+		timeout: fs.String("timeout", "", "Request timeout (e.g. 10s, default 5s)"),
+	}
+}
+
+// newClient resolves configuration and builds an API client.
+// Configuration warnings are written to stderr.
+func (f *connectionFlags) newClient(stderr io.Writer) (*client.Client, tui_config.Config, error) {
+	cfg, warnings, err := tui_config.Load(tui_config.LoadOptions{
+		FlagBaseURL: *f.baseURL,
+		FlagToken:   *f.token,
+		FlagTimeout: *f.timeout,
+	})
+	if err != nil {
+		return nil, tui_config.Config{}, err
+	}
+	for _, w := range warnings {
+		fmt.Fprintln(stderr, w)
+	}
+
+	c, err := client.New(cfg.BaseURL, client.WithToken(cfg.Token))
+	if err != nil {
+		return nil, tui_config.Config{}, err
+	}
+	return c, cfg, nil
+}
+
+// requestContext bounds a single subcommand's API call by the configured timeout.
+func requestContext(cfg tui_config.Config) (context.Context, context.CancelFunc) {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = tui_config.DefaultTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseInterspersed parses fs over args, allowing flags to appear after
+// positional arguments (e.g. `mjr create URL --custom-code x`), and returns the
+// positional arguments in order.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("parse flags: %w", err)
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+// Create implements `mjr create <url>`: it shortens url and prints only the
+// resulting short URL to stdout so the output can be piped.
+func Create(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("create", stderr)
+	conn := addConnectionFlags(fs)
+	customCode := fs.String("custom-code", "", "Use this short code instead of a generated one")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: mjr create <url> [--custom-code CODE]")
+	}
+
+	c, cfg, err := conn.newClient(stderr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := requestContext(cfg)
+	defer cancel()
+
+	resp, err := c.CreateURLWithCode(ctx, positional[0], *customCode)
+	if err != nil {
+		return fmt.Errorf("create url: %w", err)
+	}
+
+	_, err = fmt.Fprintln(stdout, resp.ShortURL)
+	return err
+}
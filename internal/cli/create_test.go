@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/client"
+)
+
+func createServer(t *testing.T, got *client.CreateURLRequest) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/urls" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if got.ShortCode == "taken" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"short code already exists","code":"duplicate_short_code"}`))
+			return
+		}
+		code := got.ShortCode
+		if code == "" {
+			code = "abc123"
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"short_code":"` + code + `","short_url":"https://mjr.wtf/` + code + `","original_url":"` + got.OriginalURL + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCreate_PrintsOnlyShortURL(t *testing.T) {
+	var got client.CreateURLRequest
+	setEnv(t, createServer(t, &got).URL)
+
+	var stdout, stderr bytes.Buffer
+	if err := Create([]string{"https://example.com"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if stdout.String() != "https://mjr.wtf/abc123\n" {
+		t.Fatalf("stdout=%q", stdout.String())
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("stderr=%q", stderr.String())
+	}
+	if got.OriginalURL != "https://example.com" || got.ShortCode != "" {
+		t.Fatalf("unexpected request body: %+v", got)
+	}
+}
+
+func TestCreate_CustomCodeFlagAfterURL(t *testing.T) {
+	var got client.CreateURLRequest
+	setEnv(t, createServer(t, &got).URL)
+
+	var stdout, stderr bytes.Buffer
+	if err := Create([]string{"https://example.com", "--custom-code", "my-link"}, &stdout, &stderr); err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if got.ShortCode != "my-link" {
+		t.Fatalf("ShortCode=%q", got.ShortCode)
+	}
+	if stdout.String() != "https://mjr.wtf/my-link\n" {
+		t.Fatalf("stdout=%q", stdout.String())
+	}
+}
+
+func TestCreate_ConflictReturnsAPIError(t *testing.T) {
+	var got client.CreateURLRequest
+	setEnv(t, createServer(t, &got).URL)
+
+	var stdout, stderr bytes.Buffer
+	err := Create([]string{"--custom-code", "taken", "https://example.com"}, &stdout, &stderr)
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != client.CodeDuplicateShortCode {
+		t.Fatalf("expected duplicate_short_code APIError, got %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("expected no stdout on error, got %q", stdout.String())
+	}
+}
+
+func TestCreate_RequiresExactlyOneURL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, args := range [][]string{nil, {"https://a.example", "https://b.example"}} {
+		var stdout, stderr bytes.Buffer
+		if err := Create(args, &stdout, &stderr); err == nil {
+			t.Errorf("Create(%v): expected error", args)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/matt-riley/mjrwtf/internal/client"
+)
+
+// stdinIsTerminal reports whether r is an interactive terminal. It is a
+// variable so tests can exercise the confirmation prompt.
+var stdinIsTerminal = func(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Delete implements `mjr delete <shortCode>`. Without --yes it asks for
+// confirmation on a terminal and refuses to run non-interactively. A URL that
+// no longer exists is reported as already gone and is not an error.
+func Delete(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("delete", stderr)
+	conn := addConnectionFlags(fs)
+	yes := fs.Bool("yes", false, "Delete without asking for confirmation")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: mjr delete <short-code> [--yes]")
+	}
+	shortCode := positional[0]
+
+	if !*yes {
+		if !stdinIsTerminal(stdin) {
+			return fmt.Errorf("refusing to delete %s without confirmation: pass --yes when not running in a terminal", shortCode)
+		}
+		ok, err := confirm(stdin, stderr, fmt.Sprintf("Delete %s? [y/N] ", shortCode))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			_, err := fmt.Fprintln(stderr, "Aborted.")
+			return err
+		}
+	}
+
+	c, cfg, err := conn.newClient(stderr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := requestContext(cfg)
+	defer cancel()
+
+	if err := c.DeleteURL(ctx, shortCode); err != nil {
+		if client.IsErrorCode(err, client.CodeURLNotFound, http.StatusNotFound) {
+			_, err := fmt.Fprintf(stdout, "%s is already gone\n", shortCode)
+			return err
+		}
+		return fmt.Errorf("delete url: %w", err)
+	}
+
+	_, err = fmt.Fprintf(stdout, "Deleted %s\n", shortCode)
+	return err
+}
+
+// confirm writes prompt to w and reports whether the answer read from r is yes.
+func confirm(r io.Reader, w io.Writer, prompt string) (bool, error) {
+	if _, err := fmt.Fprint(w, prompt); err != nil {
+		return false, err
+	}
+
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// deleteServer answers DELETE /api/urls/{code} with status and body, counting calls.
+func deleteServer(t *testing.T, status int, body string, calls *int) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/urls/abc123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if body != "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func fakeTerminal(t *testing.T, isTTY bool) {
+	t.Helper()
+	orig := stdinIsTerminal
+	stdinIsTerminal = func(io.Reader) bool { return isTTY }
+	t.Cleanup(func() { stdinIsTerminal = orig })
+}
+
+func TestDelete_YesSkipsPrompt(t *testing.T) {
+	var calls int
+	setEnv(t, deleteServer(t, http.StatusNoContent, "", &calls).URL)
+	fakeTerminal(t, true)
+
+	var stdout, stderr bytes.Buffer
+	if err := Delete([]string{"abc123", "--yes"}, strings.NewReader(""), &stdout, &stderr); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 request, got %d", calls)
+	}
+	if stdout.String() != "Deleted abc123\n" {
+		t.Fatalf("stdout=%q", stdout.String())
+	}
+	if strings.Contains(stderr.String(), "[y/N]") {
+		t.Fatalf("expected no prompt with --yes, stderr=%q", stderr.String())
+	}
+}
+
+func TestDelete_NotFoundIsAlreadyGone(t *testing.T) {
+	var calls int
+	setEnv(t, deleteServer(t, http.StatusNotFound, `{"error":"url not found","code":"url_not_found"}`, &calls).URL)
+
+	var stdout, stderr bytes.Buffer
+	if err := Delete([]string{"--yes", "abc123"}, strings.NewReader(""), &stdout, &stderr); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if stdout.String() != "abc123 is already gone\n" {
+		t.Fatalf("stdout=%q", stdout.String())
+	}
+}
+
+func TestDelete_OtherErrorsFail(t *testing.T) {
+	var calls int
+	setEnv(t, deleteServer(t, http.StatusForbidden, `{"error":"forbidden","code":"forbidden"}`, &calls).URL)
+
+	var stdout, stderr bytes.Buffer
+	err := Delete([]string{"--yes", "abc123"}, strings.NewReader(""), &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Fatalf("expected forbidden error, got %v", err)
+	}
+}
+
+func TestDelete_Prompt(t *testing.T) {
+	tests := []struct {
+		name      string
+		answer    string
+		wantCalls int
+		wantOut   string
+	}{
+		{name: "yes", answer: "y\n", wantCalls: 1, wantOut: "Deleted abc123\n"},
+		{name: "YES", answer: "YES\n", wantCalls: 1, wantOut: "Deleted abc123\n"},
+		{name: "no", answer: "n\n", wantCalls: 0},
+		{name: "empty", answer: "\n", wantCalls: 0},
+		{name: "eof", answer: "", wantCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			setEnv(t, deleteServer(t, http.StatusNoContent, "", &calls).URL)
+			fakeTerminal(t, true)
+
+			var stdout, stderr bytes.Buffer
+			if err := Delete([]string{"abc123"}, strings.NewReader(tt.answer), &stdout, &stderr); err != nil {
+				t.Fatalf("Delete() error: %v", err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls=%d, want %d", calls, tt.wantCalls)
+			}
+			if stdout.String() != tt.wantOut {
+				t.Errorf("stdout=%q, want %q", stdout.String(), tt.wantOut)
+			}
+			if !strings.Contains(stderr.String(), "Delete abc123? [y/N]") {
+				t.Errorf("expected prompt on stderr, got %q", stderr.String())
+			}
+		})
+	}
+}
+
+func TestDelete_NonInteractiveRequiresYes(t *testing.T) {
+	var calls int
+	setEnv(t, deleteServer(t, http.StatusNoContent, "", &calls).URL)
+	fakeTerminal(t, false)
+
+	var stdout, stderr bytes.Buffer
+	err := Delete([]string{"abc123"}, strings.NewReader("y\n"), &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Fatalf("expected --yes hint, got %v", err)
+	}
+	if calls != 0 {
+		t.Fatalf("expected no request, got %d", calls)
+	}
+}
@@ -0,0 +1,2 @@
+// Package cli implements the scriptable (non-interactive) mjr subcommands.
+package cli
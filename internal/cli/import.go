@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/matt-riley/mjrwtf/internal/client"
+)
+
+// Result statuses written to the import results CSV.
+const (
+	importCreated = "created"
+	importFailed  = "failed"
+	importSkipped = "skipped"
+)
+
+// importSummary counts the outcome of every data row in an import.
+type importSummary struct {
+	created int
+	failed  int
+	skipped int
+}
+
+func (s importSummary) total() int {
+	return s.created + s.failed + s.skipped
+}
+
+// Import implements `mjr import <file.csv>`. Each row is original_url with an
+// optional short_code column, and an optional header row naming them. Rows are
+// read and created one at a time, so large files are never held in memory.
+// Malformed rows are logged to stderr and skipped; every row's outcome is
+// written to a results CSV and a summary is printed to stdout.
+func Import(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("import", stderr)
+	conn := addConnectionFlags(fs)
+	resultsPath := fs.String("results", "", "Write per-row results here (default <file>-results.csv)")
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: mjr import <file.csv> [--results FILE]")
+	}
+	inputPath := positional[0]
+	if *resultsPath == "" {
+		*resultsPath = defaultResultsPath(inputPath)
+	}
+	if filepath.Clean(*resultsPath) == filepath.Clean(inputPath) {
+		return fmt.Errorf("results file must not be the input file")
+	}
+
+	in, err := os.Open(inputPath)
+	if err != nil {
+		return fmt.Errorf("open import file: %w", err)
+	}
+	defer in.Close()
+
+	c, cfg, err := conn.newClient(stderr)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(*resultsPath)
+	if err != nil {
+		return fmt.Errorf("create results file: %w", err)
+	}
+	defer out.Close()
+
+	results := csv.NewWriter(out)
+	if err := results.Write([]string{"line", "original_url", "short_code", "short_url", "status", "error"}); err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+
+	create := func(originalURL, shortCode string) (*client.CreateURLResponse, error) {
+		ctx, cancel := requestContext(cfg)
+		defer cancel()
+		return c.CreateURLWithCode(ctx, originalURL, shortCode)
+	}
+
+	summary, err := importRows(in, results, stderr, create)
+	if err != nil {
+		return err
+	}
+
+	results.Flush()
+	if err := results.Error(); err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(stdout, "Imported %d of %d rows: %d created, %d failed, %d skipped\nResults written to %s\n",
+		summary.created, summary.total(), summary.created, summary.failed, summary.skipped, *resultsPath); err != nil {
+		return err
+	}
+	if n := summary.failed + summary.skipped; n > 0 {
+		return fmt.Errorf("%d of %d rows were not imported", n, summary.total())
+	}
+	return nil
+}
+
+// importRows creates a URL for each row read from r, recording every outcome
+// in results. Only an unreadable input or unwritable results stop the import.
+func importRows(r io.Reader, results *csv.Writer, stderr io.Writer, create func(originalURL, shortCode string) (*client.CreateURLResponse, error)) (importSummary, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // field counts are checked per row, so one bad row can be skipped
+	reader.TrimLeadingSpace = true
+
+	var summary importSummary
+	first := true
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return summary, nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			first = false
+			summary.skipped++
+			fmt.Fprintf(stderr, "line %d: skipping malformed row: %v\n", parseErr.StartLine, parseErr.Err)
+			if err := results.Write([]string{strconv.Itoa(parseErr.StartLine), "", "", "", importSkipped, parseErr.Err.Error()}); err != nil {
+				return summary, fmt.Errorf("write results: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return summary, fmt.Errorf("read import file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		if first {
+			first = false
+			if isImportHeader(record) {
+				continue
+			}
+		}
+
+		originalURL, shortCode, reason := parseImportRow(record)
+		result := []string{strconv.Itoa(line), originalURL, shortCode, "", "", ""}
+		switch {
+		case reason != "":
+			summary.skipped++
+			fmt.Fprintf(stderr, "line %d: skipping malformed row: %s\n", line, reason)
+			result[4], result[5] = importSkipped, reason
+		default:
+			resp, err := create(originalURL, shortCode)
+			if err != nil {
+				summary.failed++
+				fmt.Fprintf(stderr, "line %d: create %s: %v\n", line, originalURL, err)
+				result[4], result[5] = importFailed, err.Error()
+			} else {
+				summary.created++
+				result[2], result[3], result[4] = resp.ShortCode, resp.ShortURL, importCreated
+			}
+		}
+
+		if err := results.Write(result); err != nil {
+			return summary, fmt.Errorf("write results: %w", err)
+		}
+	}
+}
+
+// parseImportRow returns a row's URL and optional short code, or the reason
+// the row is malformed.
+func parseImportRow(record []string) (originalURL, shortCode, reason string) {
+	if len(record) > 2 {
+		return "", "", fmt.Sprintf("expected 1 or 2 fields, got %d", len(record))
+	}
+	originalURL = strings.TrimSpace(record[0])
+	if len(record) == 2 {
+		shortCode = strings.TrimSpace(record[1])
+	}
+	if originalURL == "" {
+		return "", shortCode, "missing original_url"
+	}
+	return originalURL, shortCode, ""
+}
+
+// isImportHeader reports whether the first row names the columns rather than
+// holding a URL.
+func isImportHeader(record []string) bool {
+	switch strings.ToLower(strings.TrimSpace(record[0])) {
+	case "url", "original_url":
+		return true
+	}
+	return false
+}
+
+func defaultResultsPath(inputPath string) string {
+	return strings.TrimSuffix(inputPath, filepath.Ext(inputPath)) + "-results.csv"
+}
@@ -0,0 +1,163 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/client"
+)
+
+// importServer creates URLs like createServer, but decodes each request into
+// a fresh value so a row without a short code never inherits the previous one.
+// It returns the created original URLs in request order.
+func importServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var created []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req client.CreateURLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.ShortCode == "taken" {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"short code already exists","code":"duplicate_short_code"}`))
+			return
+		}
+		code := req.ShortCode
+		if code == "" {
+			code = fmt.Sprintf("gen%03d", len(created)+1)
+		}
+		created = append(created, req.OriginalURL)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(client.CreateURLResponse{ShortCode: code, ShortURL: "https://mjr.wtf/" + code, OriginalURL: req.OriginalURL})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &created
+}
+
+func TestImport_SummaryAndResults(t *testing.T) {
+	srv, created := importServer(t)
+	setEnv(t, srv.URL)
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "links.csv")
+	data := "original_url,short_code\n" +
+		"https://example.com/one\n" +
+		"https://example.com/two,my-link\n" +
+		"https://example.com/three,code,extra\n" +
+		"https://example.com/four,taken\n" +
+		"https://example.com/\"five\"\n" +
+		"https://example.com/six,\n"
+	if err := os.WriteFile(input, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err := Import([]string{input}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "3 of 6 rows were not imported") {
+		t.Fatalf("expected incomplete import error, got %v", err)
+	}
+
+	results := filepath.Join(dir, "links-results.csv")
+	wantSummary := "Imported 3 of 6 rows: 3 created, 1 failed, 2 skipped\nResults written to " + results + "\n"
+	if stdout.String() != wantSummary {
+		t.Fatalf("stdout=%q, want %q", stdout.String(), wantSummary)
+	}
+	for _, want := range []string{
+		"line 4: skipping malformed row: expected 1 or 2 fields, got 3",
+		"line 5: create https://example.com/four:",
+		"line 6: skipping malformed row:",
+	} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr missing %q:\n%s", want, stderr.String())
+		}
+	}
+
+	// Malformed rows never reach the API
+	if strings.Join(*created, " ") != "https://example.com/one https://example.com/two https://example.com/six" {
+		t.Errorf("created %v", *created)
+	}
+
+	f, err := os.Open(results)
+	if err != nil {
+		t.Fatalf("open results: %v", err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("read results: %v", err)
+	}
+	if len(rows) != 7 {
+		t.Fatalf("results has %d rows, want header + 6:\n%v", len(rows), rows)
+	}
+
+	want := [][]string{
+		{"2", "https://example.com/one", "gen001", "https://mjr.wtf/gen001", "created"},
+		{"3", "https://example.com/two", "my-link", "https://mjr.wtf/my-link", "created"},
+		{"4", "", "", "", "skipped"},
+		{"5", "https://example.com/four", "taken", "", "failed"},
+		{"6", "", "", "", "skipped"},
+		{"7", "https://example.com/six", "gen003", "https://mjr.wtf/gen003", "created"},
+	}
+	for i, w := range want {
+		row := rows[i+1]
+		if strings.Join(row[:5], ",") != strings.Join(w, ",") {
+			t.Errorf("results row %d = %v, want %v", i+1, row[:5], w)
+		}
+		if (w[4] == "created") != (row[5] == "") {
+			t.Errorf("results row %d error column = %q", i+1, row[5])
+		}
+	}
+}
+
+func TestImport_ResultsFlag(t *testing.T) {
+	var got client.CreateURLRequest
+	setEnv(t, createServer(t, &got).URL)
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "links.csv")
+	if err := os.WriteFile(input, []byte("https://example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	results := filepath.Join(dir, "out.csv")
+
+	var stdout, stderr bytes.Buffer
+	if err := Import([]string{input, "--results", results}, &stdout, &stderr); err != nil {
+		t.Fatalf("Import() error: %v", err)
+	}
+	if !strings.HasPrefix(stdout.String(), "Imported 1 of 1 rows: 1 created, 0 failed, 0 skipped\n") {
+		t.Fatalf("stdout=%q", stdout.String())
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("stderr=%q", stderr.String())
+	}
+	if got.OriginalURL != "https://example.com" {
+		t.Fatalf("unexpected request body: %+v", got)
+	}
+	if _, err := os.Stat(results); err != nil {
+		t.Fatalf("results file not written: %v", err)
+	}
+}
+
+func TestImport_RejectsBadArguments(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	input := filepath.Join(t.TempDir(), "links.csv")
+
+	for _, args := range [][]string{nil, {"a.csv", "b.csv"}, {input, "--results", input}, {filepath.Join(t.TempDir(), "missing.csv")}} {
+		var stdout, stderr bytes.Buffer
+		if err := Import(args, &stdout, &stderr); err == nil {
+			t.Errorf("Import(%v): expected error", args)
+		}
+	}
+}
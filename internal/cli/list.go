@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// List implements `mjr list`: it fetches one page of URLs and prints it to
+// stdout, either as a table or (with --json) as the API's JSON response.
+func List(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("list", stderr)
+	conn := addConnectionFlags(fs)
+	asJSON := fs.Bool("json", false, "Print the response as JSON")
+	limit := fs.Int("limit", 0, "Maximum number of URLs to return (server default 20, max 100)")
+	offset := fs.Int("offset", 0, "Number of URLs to skip")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if *limit < 0 || *offset < 0 {
+		return fmt.Errorf("--limit and --offset must not be negative")
+	}
+
+	c, cfg, err := conn.newClient(stderr)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := requestContext(cfg)
+	defer cancel()
+
+	resp, err := c.ListURLs(ctx, *limit, *offset)
+	if err != nil {
+		return fmt.Errorf("list urls: %w", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(resp)
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SHORT CODE\tCLICKS\tCREATED\tORIGINAL URL")
+	for _, u := range resp.URLs {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", u.ShortCode, u.ClickCount, u.CreatedAt.Format(time.RFC3339), u.OriginalURL)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(stderr, "Showing %d of %d (offset %d)\n", len(resp.URLs), resp.Total, resp.Offset)
+	return nil
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/client"
+)
+
+// setEnv points config resolution at baseURL with an isolated HOME.
+func setEnv(t *testing.T, baseURL string) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MJR_BASE_URL", baseURL)
+	t.Setenv("MJR_TOKEN", "envtoken")
+	t.Setenv("MJR_TIMEOUT", "")
+}
+
+func TestList_JSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/urls" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer envtoken" {
+			t.Errorf("Authorization=%q", got)
+		}
+		if got := r.URL.Query().Get("limit"); got != "2" {
+			t.Errorf("limit=%q", got)
+		}
+		if got := r.URL.Query().Get("offset"); got != "4" {
+			t.Errorf("offset=%q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"urls":[{"id":1,"short_code":"abc123","original_url":"https://example.com","created_at":"2025-01-02T03:04:05Z","created_by":"u","click_count":7}],"total":5,"limit":2,"offset":4}`))
+	}))
+	defer srv.Close()
+	setEnv(t, srv.URL)
+
+	var stdout, stderr bytes.Buffer
+	if err := List([]string{"--json", "--limit", "2", "--offset", "4"}, &stdout, &stderr); err != nil {
+		t.Fatalf("List() error: %v (stderr=%q)", err, stderr.String())
+	}
+
+	var got client.ListURLsResponse
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not JSON: %v\n%s", err, stdout.String())
+	}
+	if got.Total != 5 || got.Limit != 2 || got.Offset != 4 {
+		t.Fatalf("unexpected pagination: %+v", got)
+	}
+	if len(got.URLs) != 1 || got.URLs[0].ShortCode != "abc123" || got.URLs[0].ClickCount != 7 {
+		t.Fatalf("unexpected urls: %+v", got.URLs)
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected nothing on stderr in --json mode, got %q", stderr.String())
+	}
+}
+
+func TestList_Table(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"urls":[{"id":1,"short_code":"abc123","original_url":"https://example.com","created_at":"2025-01-02T03:04:05Z","created_by":"u","click_count":7}],"total":1,"limit":20,"offset":0}`))
+	}))
+	defer srv.Close()
+	setEnv(t, srv.URL)
+
+	var stdout, stderr bytes.Buffer
+	if err := List(nil, &stdout, &stderr); err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+
+	out := stdout.String()
+	for _, want := range []string{"SHORT CODE", "abc123", "7", "2025-01-02T03:04:05Z", "https://example.com"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestList_APIErrorFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":"Unauthorized: invalid token"}`))
+	}))
+	defer srv.Close()
+	setEnv(t, srv.URL)
+
+	var stdout, stderr bytes.Buffer
+	err := List([]string{"--json"}, &stdout, &stderr)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !strings.Contains(err.Error(), "invalid token") {
+		t.Fatalf("err=%v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Fatalf("expected no stdout on error, got %q", stdout.String())
+	}
+}
+
+func TestList_FlagsOverrideEnv(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer flagtoken" {
+			t.Errorf("Authorization=%q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"urls":[],"total":0,"limit":20,"offset":0}`))
+	}))
+	defer srv.Close()
+	setEnv(t, "http://unused.invalid")
+
+	var stdout, stderr bytes.Buffer
+	if err := List([]string{"--json", "--base-url", srv.URL, "--token", "flagtoken"}, &stdout, &stderr); err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+}
+
+func TestList_InvalidArgs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, args := range [][]string{{"--limit", "-1"}, {"extra"}, {"--nope"}} {
+		var stdout, stderr bytes.Buffer
+		if err := List(args, &stdout, &stderr); err == nil {
+			t.Errorf("List(%v): expected error", args)
+		}
+	}
+}
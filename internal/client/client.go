@@ -22,6 +22,7 @@ type Client struct {
 	token      string
 	httpClient *http.Client
 	timeout    time.Duration
+	retry      RetryPolicy
 }
 
 type Option func(*Client)
@@ -68,7 +69,14 @@ func New(baseURL string, opts ...Option) (*Client, error) {
 }
 
 func (c *Client) CreateURL(ctx context.Context, originalURL string) (*CreateURLResponse, error) {
-	reqBody, err := json.Marshal(CreateURLRequest{OriginalURL: originalURL})
+	return c.CreateURLWithCode(ctx, originalURL, "")
+}
+
+// CreateURLWithCode calls POST /api/urls with a caller-chosen short code.
+// An empty shortCode lets the server generate one. A taken code yields an
+// *APIError with Code CodeDuplicateShortCode.
+func (c *Client) CreateURLWithCode(ctx context.Context, originalURL, shortCode string) (*CreateURLResponse, error) {
+	reqBody, err := json.Marshal(CreateURLRequest{OriginalURL: originalURL, ShortCode: shortCode})
 	if err != nil {
 		return nil, fmt.Errorf("encode request: %w", err)
 	}
@@ -93,8 +101,21 @@ func (c *Client) CreateURL(ctx context.Context, originalURL string) (*CreateURLR
 // Note: passing limit=0 and/or offset=0 omits those query parameters so the server can apply
 // its defaults (limit defaults to 20; offset defaults to 0).
 func (c *Client) ListURLs(ctx context.Context, limit, offset int) (*ListURLsResponse, error) {
+	return c.listURLs(ctx, "", limit, offset)
+}
+
+// SearchURLs calls GET /api/urls?q=query, returning only URLs whose original URL or short code
+// contains query. Total counts the matching URLs, so limit and offset page through the matches.
+func (c *Client) SearchURLs(ctx context.Context, query string, limit, offset int) (*ListURLsResponse, error) {
+	return c.listURLs(ctx, query, limit, offset)
+}
+
+func (c *Client) listURLs(ctx context.Context, query string, limit, offset int) (*ListURLsResponse, error) {
 	u := c.resolve("/api/urls")
 	q := u.Query()
+	if query != "" {
+		q.Set("q", query)
+	}
 	if limit != 0 {
 		q.Set("limit", strconv.Itoa(limit))
 	}
@@ -116,6 +137,40 @@ func (c *Client) ListURLs(ctx context.Context, limit, offset int) (*ListURLsResp
 	return &out, nil
 }
 
+// listAllPageSize matches the server's maximum page size for GET /api/urls.
+const listAllPageSize = 100
+
+// maxListAllPages bounds ListAllURLs so a server reporting an inconsistent total
+// cannot keep it paging forever.
+const maxListAllPages = 10000
+
+// ListAllURLs pages through GET /api/urls until it has collected the reported total,
+// the server returns an empty page, or the context is cancelled.
+func (c *Client) ListAllURLs(ctx context.Context) ([]URLResponse, error) {
+	var all []URLResponse
+	offset := 0
+
+	for page := 0; page < maxListAllPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.ListURLs(ctx, listAllPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, resp.URLs...)
+		offset += len(resp.URLs)
+
+		if len(resp.URLs) == 0 || len(all) >= resp.Total {
+			return all, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w after %d pages", ErrPaginationLimitExceeded, maxListAllPages)
+}
+
 func (c *Client) DeleteURL(ctx context.Context, shortCode string) error {
 	u := c.resolve("/api/urls/" + url.PathEscape(shortCode))
 	req, cancel, err := c.newRequest(ctx, http.MethodDelete, u, nil)
@@ -127,7 +182,9 @@ func (c *Client) DeleteURL(ctx context.Context, shortCode string) error {
 	return c.do(req, http.StatusNoContent, nil)
 }
 
-func (c *Client) GetAnalytics(ctx context.Context, shortCode string, startTime, endTime *time.Time) (*GetAnalyticsResponse, error) {
+// GetAnalytics calls GET /api/urls/{shortCode}/analytics. granularity ("day" or "hour")
+// buckets time-range results into ByDate or ByHour; pass "" to omit it.
+func (c *Client) GetAnalytics(ctx context.Context, shortCode string, startTime, endTime *time.Time, granularity string) (*GetAnalyticsResponse, error) {
 	u := c.resolve("/api/urls/" + url.PathEscape(shortCode) + "/analytics")
 	q := u.Query()
 	if startTime != nil {
@@ -136,6 +193,9 @@ func (c *Client) GetAnalytics(ctx context.Context, shortCode string, startTime,
 	if endTime != nil {
 		q.Set("end_time", endTime.UTC().Format(time.RFC3339))
 	}
+	if granularity != "" {
+		q.Set("granularity", granularity)
+	}
 	u.RawQuery = q.Encode()
 
 	req, cancel, err := c.newRequest(ctx, http.MethodGet, u, nil)
@@ -151,6 +211,69 @@ func (c *Client) GetAnalytics(ctx context.Context, shortCode string, startTime,
 	return &out, nil
 }
 
+// GetTopURLs calls GET /api/stats/top, returning the caller's URLs most clicked first.
+// Passing limit=0 omits it so the server applies its default (10).
+func (c *Client) GetTopURLs(ctx context.Context, limit int) (*TopURLsResponse, error) {
+	u := c.resolve("/api/stats/top")
+	if limit != 0 {
+		q := u.Query()
+		q.Set("limit", strconv.Itoa(limit))
+		u.RawQuery = q.Encode()
+	}
+
+	req, cancel, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	var out TopURLsResponse
+	if err := c.do(req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListSessions calls GET /api/auth/sessions.
+func (c *Client) ListSessions(ctx context.Context) (*ListSessionsResponse, error) {
+	u := c.resolve("/api/auth/sessions")
+	req, cancel, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	var out ListSessionsResponse
+	if err := c.do(req, http.StatusOK, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RevokeSession calls DELETE /api/auth/sessions/{id}, where id is the redacted session ID from ListSessions.
+func (c *Client) RevokeSession(ctx context.Context, id string) error {
+	u := c.resolve("/api/auth/sessions/" + url.PathEscape(id))
+	req, cancel, err := c.newRequest(ctx, http.MethodDelete, u, nil)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return c.do(req, http.StatusNoContent, nil)
+}
+
+// Health calls GET /health, returning nil when the server reports it is up.
+func (c *Client) Health(ctx context.Context) error {
+	u := c.resolve("/health")
+	req, cancel, err := c.newRequest(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	return c.do(req, http.StatusOK, nil)
+}
+
 func (c *Client) resolve(path string) *url.URL {
 	u := *c.baseURL
 	u.Path = strings.TrimRight(c.baseURL.Path, "/") + path
@@ -178,7 +301,7 @@ func (c *Client) newRequest(ctx context.Context, method string, u *url.URL, body
 }
 
 func (c *Client) do(req *http.Request, expectedStatus int, out any) error {
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return err
 	}
@@ -202,13 +325,53 @@ func (c *Client) do(req *http.Request, expectedStatus int, out any) error {
 	return nil
 }
 
+// send performs req, retrying transient failures according to c.retry.
+// The response for the final attempt is returned as-is for do to handle.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if !c.retry.enabled() {
+		return c.httpClient.Do(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+
+		last := attempt >= c.retry.MaxAttempts
+		switch {
+		case err != nil:
+			if last || !isRetryableError(ctx, err) {
+				return nil, err
+			}
+		case !isRetryableStatus(resp.StatusCode) || last:
+			return resp, nil
+		default:
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(ctx, c.retry.delay(attempt)); err != nil {
+			return nil, err
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewind request body: %w", err)
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
 func decodeAPIError(resp *http.Response) error {
 	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
 
-	msg := ""
+	msg, code := "", ""
 	var er ErrorResponse
 	if err := json.Unmarshal(body, &er); err == nil {
 		msg = er.Error
+		code = er.Code
 	}
 	if msg == "" {
 		msg = strings.TrimSpace(string(body))
@@ -219,6 +382,7 @@ func decodeAPIError(resp *http.Response) error {
 
 	apiErr := &APIError{
 		StatusCode: resp.StatusCode,
+		Code:       code,
 		Message:    msg,
 	}
 
@@ -3,8 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -49,6 +52,35 @@ func TestClient_CreateURL_BuildsRequestAndDecodesResponse(t *testing.T) {
 	}
 }
 
+func TestClient_CreateURLWithCode_SendsShortCode(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body CreateURLRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.OriginalURL != "https://example.com" || body.ShortCode != "my-link" {
+			t.Fatalf("unexpected body: %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"short_code":"my-link","short_url":"https://mjr.wtf/my-link","original_url":"https://example.com"}`))
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	resp, err := c.CreateURLWithCode(context.Background(), "https://example.com", "my-link")
+	if err != nil {
+		t.Fatalf("CreateURLWithCode: %v", err)
+	}
+	if resp.ShortURL != "https://mjr.wtf/my-link" {
+		t.Fatalf("ShortURL=%q", resp.ShortURL)
+	}
+}
+
 func TestClient_ListURLs_AddsQueryParams(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -79,6 +111,159 @@ func TestClient_ListURLs_AddsQueryParams(t *testing.T) {
 	}
 }
 
+func TestClient_SearchURLs_AddsQueryParam(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/urls" {
+			t.Fatalf("expected path /api/urls, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("q"); got != "docs & more" {
+			t.Fatalf("expected q=%q, got %q", "docs & more", got)
+		}
+		if got := r.URL.Query().Get("limit"); got != "10" {
+			t.Fatalf("expected limit=10, got %q", got)
+		}
+		if r.URL.Query().Has("offset") {
+			t.Fatalf("expected offset to be omitted, got %q", r.URL.Query().Get("offset"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"urls":[],"total":0,"limit":10,"offset":0}`))
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := c.SearchURLs(context.Background(), "docs & more", 10, 0); err != nil {
+		t.Fatalf("SearchURLs: %v", err)
+	}
+}
+
+// pagedURLServer serves GET /api/urls from n URLs while reporting total as the total.
+func pagedURLServer(t *testing.T, n, total int, onPage func(offset int)) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if onPage != nil {
+			onPage(offset)
+		}
+
+		resp := ListURLsResponse{URLs: []URLResponse{}, Total: total, Limit: limit, Offset: offset}
+		for i := offset; i < n && i < offset+limit; i++ {
+			resp.URLs = append(resp.URLs, URLResponse{ID: int64(i + 1), ShortCode: fmt.Sprintf("c%d", i)})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestClient_ListAllURLs_CollectsEveryPage(t *testing.T) {
+	var offsets []int
+	ts := pagedURLServer(t, 250, 250, func(offset int) { offsets = append(offsets, offset) })
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	urls, err := c.ListAllURLs(context.Background())
+	if err != nil {
+		t.Fatalf("ListAllURLs: %v", err)
+	}
+	if len(urls) != 250 {
+		t.Fatalf("expected 250 urls, got %d", len(urls))
+	}
+	if urls[0].ShortCode != "c0" || urls[249].ShortCode != "c249" {
+		t.Fatalf("unexpected ordering: first=%q last=%q", urls[0].ShortCode, urls[249].ShortCode)
+	}
+	if want := []int{0, 100, 200}; fmt.Sprint(offsets) != fmt.Sprint(want) {
+		t.Fatalf("expected offsets %v, got %v", want, offsets)
+	}
+}
+
+func TestClient_ListAllURLs_StopsOnEmptyPage(t *testing.T) {
+	// The server claims more URLs than it actually returns.
+	requests := 0
+	ts := pagedURLServer(t, 120, 500, func(int) { requests++ })
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	urls, err := c.ListAllURLs(context.Background())
+	if err != nil {
+		t.Fatalf("ListAllURLs: %v", err)
+	}
+	if len(urls) != 120 {
+		t.Fatalf("expected 120 urls, got %d", len(urls))
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 requests (last one empty), got %d", requests)
+	}
+}
+
+func TestClient_ListAllURLs_RespectsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := 0
+	ts := pagedURLServer(t, 300, 300, func(int) {
+		requests++
+		cancel()
+	})
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	urls, err := c.ListAllURLs(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if urls != nil {
+		t.Fatalf("expected no urls on cancellation, got %d", len(urls))
+	}
+	if requests != 1 {
+		t.Fatalf("expected paging to stop after 1 request, got %d", requests)
+	}
+}
+
+func TestClient_WithTimeout_AbortsSlowRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL, WithTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	start := time.Now()
+	err = c.DeleteURL(context.Background(), "abc123")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected request to be abandoned quickly, took %v", elapsed)
+	}
+}
+
 func TestClient_DecodesErrorResponse(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -108,6 +293,74 @@ func TestClient_DecodesErrorResponse(t *testing.T) {
 	}
 }
 
+func TestClient_DecodesErrorCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantCode string
+		wantMsg  string
+	}{
+		{"duplicate short code", http.StatusConflict, `{"error":"short code already exists","code":"duplicate_short_code"}`, CodeDuplicateShortCode, "short code already exists"},
+		{"url not found", http.StatusNotFound, `{"error":"url not found","code":"url_not_found"}`, CodeURLNotFound, "url not found"},
+		{"no code from older servers", http.StatusBadRequest, `{"error":"invalid JSON"}`, "", "invalid JSON"},
+		{"non-JSON body", http.StatusBadGateway, `bad gateway`, "", "bad gateway"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			c, err := New(ts.URL)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			_, err = c.CreateURL(context.Background(), "https://example.com")
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *APIError, got %T", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, apiErr.StatusCode)
+			}
+			if apiErr.Code != tt.wantCode {
+				t.Errorf("expected code %q, got %q", tt.wantCode, apiErr.Code)
+			}
+			if apiErr.Message != tt.wantMsg {
+				t.Errorf("expected message %q, got %q", tt.wantMsg, apiErr.Message)
+			}
+		})
+	}
+}
+
+func TestIsErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"matching code", &APIError{StatusCode: http.StatusNotFound, Code: CodeURLNotFound}, true},
+		{"other code with the fallback status", &APIError{StatusCode: http.StatusNotFound, Code: CodeSessionNotFound}, false},
+		{"no code from older servers", &APIError{StatusCode: http.StatusNotFound}, true},
+		{"no code and another status", &APIError{StatusCode: http.StatusBadRequest}, false},
+		{"wrapped", fmt.Errorf("delete: %w", &APIError{StatusCode: http.StatusNotFound, Code: CodeURLNotFound}), true},
+		{"not an API error", errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsErrorCode(tt.err, CodeURLNotFound, http.StatusNotFound); got != tt.want {
+				t.Errorf("IsErrorCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestClient_RateLimit_SurfacesRetryAfter(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -156,6 +409,35 @@ func TestClient_DeleteURL_BuildsRequest(t *testing.T) {
 	}
 }
 
+func TestClient_Health(t *testing.T) {
+	status := http.StatusOK
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("expected method GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/health" {
+			t.Fatalf("expected path /health, got %s", r.URL.Path)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := c.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+
+	status = http.StatusServiceUnavailable
+	var apiErr *APIError
+	if err := c.Health(context.Background()); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503 APIError, got %v", err)
+	}
+}
+
 func TestClient_GetAnalytics_BuildsRequestAndDecodesResponse(t *testing.T) {
 	start := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
 	end := time.Date(2025, 11, 22, 23, 59, 59, 0, time.UTC)
@@ -173,6 +455,9 @@ func TestClient_GetAnalytics_BuildsRequestAndDecodesResponse(t *testing.T) {
 		if got := r.URL.Query().Get("end_time"); got != end.Format(time.RFC3339) {
 			t.Fatalf("expected end_time %q, got %q", end.Format(time.RFC3339), got)
 		}
+		if got := r.URL.Query().Get("granularity"); got != "hour" {
+			t.Fatalf("expected granularity hour, got %q", got)
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -184,7 +469,7 @@ func TestClient_GetAnalytics_BuildsRequestAndDecodesResponse(t *testing.T) {
 	if err != nil {
 		t.Fatalf("New: %v", err)
 	}
-	resp, err := c.GetAnalytics(context.Background(), "abc123", &start, &end)
+	resp, err := c.GetAnalytics(context.Background(), "abc123", &start, &end, "hour")
 	if err != nil {
 		t.Fatalf("GetAnalytics: %v", err)
 	}
@@ -195,3 +480,80 @@ func TestClient_GetAnalytics_BuildsRequestAndDecodesResponse(t *testing.T) {
 		t.Fatalf("expected total_clicks 150, got %d", resp.TotalClicks)
 	}
 }
+
+func TestClient_GetTopURLs_AddsLimit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/stats/top" {
+			t.Fatalf("expected path /api/stats/top, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("limit"); got != "5" {
+			t.Fatalf("expected limit=5, got %q", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"urls":[{"id":2,"short_code":"hot","click_count":9},{"id":1,"short_code":"cold","click_count":0}],"limit":5}`))
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	resp, err := c.GetTopURLs(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("GetTopURLs: %v", err)
+	}
+	if len(resp.URLs) != 2 || resp.URLs[0].ShortCode != "hot" || resp.URLs[0].ClickCount != 9 || resp.Limit != 5 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestClient_ListSessions_DecodesResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("expected method GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/auth/sessions" {
+			t.Fatalf("expected path /api/auth/sessions, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"sessions":[{"id":"abcdefghijkl","ip_address":"203.0.113.1","user_agent":"Firefox","created_at":"2025-11-20T10:00:00Z","last_activity_at":"2025-11-20T11:00:00Z","expires_at":"2025-11-21T11:00:00Z","current":true}]}`))
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	resp, err := c.ListSessions(context.Background())
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(resp.Sessions) != 1 || resp.Sessions[0].ID != "abcdefghijkl" || !resp.Sessions[0].Current {
+		t.Fatalf("unexpected sessions: %+v", resp.Sessions)
+	}
+}
+
+func TestClient_RevokeSession_UsesDelete(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Fatalf("expected method DELETE, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/auth/sessions/abcdefghijkl" {
+			t.Fatalf("expected session path, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := c.RevokeSession(context.Background(), "abcdefghijkl"); err != nil {
+		t.Fatalf("RevokeSession: %v", err)
+	}
+}
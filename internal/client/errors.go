@@ -1,15 +1,42 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"time"
 )
 
+// ErrPaginationLimitExceeded is returned by ListAllURLs when it gives up paging before
+// reaching the total reported by the server.
+var ErrPaginationLimitExceeded = errors.New("pagination limit exceeded")
+
+// Error codes the API may return in APIError.Code. Branch on these rather than
+// on Message, whose wording may change.
+const (
+	CodeURLNotFound            = "url_not_found"
+	CodeDuplicateShortCode     = "duplicate_short_code"
+	CodeInvalidShortCode       = "invalid_short_code"
+	CodeInvalidURL             = "invalid_url"
+	CodeDestinationNotAllowed  = "destination_not_allowed"
+	CodeInvalidCreatedBy       = "invalid_created_by"
+	CodeForbidden              = "forbidden"
+	CodeSessionNotFound        = "session_not_found"
+	CodeInvalidTimeRange       = "invalid_time_range"
+	CodeInvalidDateRange       = "invalid_date_range"
+	CodeInvalidIdempotencyKey  = "invalid_idempotency_key"
+	CodeIdempotencyKeyMismatch = "idempotency_key_mismatch"
+	CodeIdempotencyKeyInUse    = "idempotency_key_in_use"
+	CodeInvalidRequest         = "invalid_request"
+	CodeInternal               = "internal_error"
+)
+
 // APIError represents a non-success API response.
 //
+// Code is set when the server includes a machine-readable code in the error body.
 // For HTTP 429 responses, RetryAfter will be set when the server provides a valid Retry-After header.
 type APIError struct {
 	StatusCode int
+	Code       string
 	Message    string
 	RetryAfter time.Duration
 }
@@ -23,3 +50,17 @@ func (e *APIError) Error() string {
 	}
 	return fmt.Sprintf("api error (%d): %s", e.StatusCode, e.Message)
 }
+
+// IsErrorCode reports whether err is an *APIError with the given code. Servers
+// that predate error codes leave Code empty, so it falls back to matching
+// fallbackStatus.
+func IsErrorCode(err error, code string, fallbackStatus int) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Code != "" {
+		return apiErr.Code == code
+	}
+	return apiErr.StatusCode == fallbackStatus
+}
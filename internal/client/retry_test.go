@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+// statusSequenceServer replies with statuses in order, repeating the last one.
+func statusSequenceServer(t *testing.T, calls *int32, statuses ...int) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1))
+		status := statuses[len(statuses)-1]
+		if n <= len(statuses) {
+			status = statuses[n-1]
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		switch status {
+		case http.StatusOK:
+			_, _ = w.Write([]byte(`{"urls":[],"total":0,"limit":20,"offset":0}`))
+		case http.StatusCreated:
+			_, _ = w.Write([]byte(`{"short_code":"abc123","short_url":"https://mjr.wtf/abc123","original_url":"https://example.com"}`))
+		default:
+			_, _ = w.Write([]byte(`{"error":"` + http.StatusText(status) + `"}`))
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestClient_Retry_RetriesServiceUnavailableThenSucceeds(t *testing.T) {
+	var calls int32
+	ts := statusSequenceServer(t, &calls, http.StatusServiceUnavailable, http.StatusOK)
+
+	c, err := New(ts.URL, WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := c.ListURLs(context.Background(), 0, 0); err != nil {
+		t.Fatalf("ListURLs: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClient_Retry_DoesNotRetryConflict(t *testing.T) {
+	var calls int32
+	ts := statusSequenceServer(t, &calls, http.StatusConflict, http.StatusCreated)
+
+	c, err := New(ts.URL, WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	_, err = c.CreateURL(context.Background(), "https://example.com")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Fatalf("expected *APIError 409, got %T %v", err, err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestClient_Retry_ResendsPOSTBody(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		if string(b) != `{"original_url":"https://example.com"}` {
+			t.Errorf("attempt %d: unexpected body %q", atomic.LoadInt32(&calls)+1, b)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"short_code":"abc123","short_url":"https://mjr.wtf/abc123","original_url":"https://example.com"}`))
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL, WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	resp, err := c.CreateURL(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatalf("CreateURL: %v", err)
+	}
+	if resp.ShortCode != "abc123" {
+		t.Fatalf("ShortCode=%q", resp.ShortCode)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClient_Retry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	ts := statusSequenceServer(t, &calls, http.StatusGatewayTimeout)
+
+	c, err := New(ts.URL, WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	err = c.DeleteURL(context.Background(), "abc123")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected *APIError 504, got %T %v", err, err)
+	}
+	if got := atomic.LoadInt32(&calls); got != int32(fastRetry.MaxAttempts) {
+		t.Fatalf("expected %d attempts, got %d", fastRetry.MaxAttempts, got)
+	}
+}
+
+func TestClient_Retry_RetriesNetworkErrors(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("hijack: %v", err)
+				return
+			}
+			_ = conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	c, err := New(ts.URL, WithRetry(fastRetry))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := c.DeleteURL(context.Background(), "abc123"); err != nil {
+		t.Fatalf("DeleteURL: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestClient_Retry_StopsWhenContextEnds(t *testing.T) {
+	var calls int32
+	ts := statusSequenceServer(t, &calls, http.StatusServiceUnavailable)
+
+	c, err := New(ts.URL, WithRetry(RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour}))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = c.ListURLs(ctx, 0, 0)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected backoff to honor the context, took %v", elapsed)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestClient_Retry_DisabledByDefault(t *testing.T) {
+	var calls int32
+	ts := statusSequenceServer(t, &calls, http.StatusServiceUnavailable, http.StatusOK)
+
+	c, err := New(ts.URL)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	_, err = c.ListURLs(context.Background(), 0, 0)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected *APIError 503, got %T %v", err, err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond}
+	for n, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond} {
+		if got := p.delay(n); got != want {
+			t.Errorf("delay(%d) = %v, want %v", n, got, want)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.delay(1); got < 100*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("jittered delay(1) = %v, want within [100ms, 150ms]", got)
+		}
+	}
+}
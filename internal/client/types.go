@@ -4,12 +4,15 @@ import "time"
 
 type CreateURLRequest struct {
 	OriginalURL string `json:"original_url"`
+	ShortCode   string `json:"short_code,omitempty"`
 }
 
 type CreateURLResponse struct {
 	ShortCode   string `json:"short_code"`
 	ShortURL    string `json:"short_url"`
 	OriginalURL string `json:"original_url"`
+	// Reused is true when the server returned an existing URL for the same destination.
+	Reused bool `json:"reused,omitempty"`
 }
 
 type URLResponse struct {
@@ -28,17 +31,41 @@ type ListURLsResponse struct {
 	Offset int           `json:"offset"`
 }
 
+type TopURLsResponse struct {
+	URLs  []URLResponse `json:"urls"`
+	Limit int           `json:"limit"`
+}
+
 type GetAnalyticsResponse struct {
-	ShortCode   string           `json:"short_code"`
-	OriginalURL string           `json:"original_url"`
-	TotalClicks int64            `json:"total_clicks"`
-	ByCountry   map[string]int64 `json:"by_country"`
-	ByReferrer  map[string]int64 `json:"by_referrer"`
-	ByDate      map[string]int64 `json:"by_date,omitempty"`
-	StartTime   *time.Time       `json:"start_time,omitempty"`
-	EndTime     *time.Time       `json:"end_time,omitempty"`
+	ShortCode      string           `json:"short_code"`
+	OriginalURL    string           `json:"original_url"`
+	TotalClicks    int64            `json:"total_clicks"`
+	UniqueVisitors *int64           `json:"unique_visitors,omitempty"`
+	ByCountry      map[string]int64 `json:"by_country"`
+	ByReferrer     map[string]int64 `json:"by_referrer"`
+	ByBrowser      map[string]int64 `json:"by_browser"`
+	ByOS           map[string]int64 `json:"by_os"`
+	ByDate         map[string]int64 `json:"by_date,omitempty"`
+	ByHour         map[string]int64 `json:"by_hour,omitempty"`
+	StartTime      *time.Time       `json:"start_time,omitempty"`
+	EndTime        *time.Time       `json:"end_time,omitempty"`
+}
+
+type SessionResponse struct {
+	ID             string    `json:"id"`
+	IPAddress      string    `json:"ip_address"`
+	UserAgent      string    `json:"user_agent"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Current        bool      `json:"current"`
+}
+
+type ListSessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
 }
 
 type ErrorResponse struct {
 	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
 }
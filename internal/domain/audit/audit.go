@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"strings"
+	"time"
+)
+
+// Action identifies what an audit entry records
+type Action string
+
+const (
+	// ActionCreate records a newly created short URL
+	ActionCreate Action = "create"
+	// ActionDelete records the deletion of a single short URL
+	ActionDelete Action = "delete"
+)
+
+// Valid reports whether a is one of the known actions
+func (a Action) Valid() bool {
+	switch a {
+	case ActionCreate, ActionDelete:
+		return true
+	}
+	return false
+}
+
+// Entry is a single audit trail record
+type Entry struct {
+	ID        int64
+	Action    Action
+	ShortCode string
+	UserID    string
+	IPAddress string // Empty when the client IP is unknown
+	CreatedAt time.Time
+}
+
+// NewEntry creates a validated audit entry timestamped now
+func NewEntry(action Action, shortCode, userID, ipAddress string) (*Entry, error) {
+	if !action.Valid() {
+		return nil, ErrInvalidAction
+	}
+	if shortCode == "" {
+		return nil, ErrEmptyShortCode
+	}
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrEmptyUserID
+	}
+
+	return &Entry{
+		Action:    action,
+		ShortCode: shortCode,
+		UserID:    userID,
+		IPAddress: ipAddress,
+		CreatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,37 @@
+package audit
+
+import "testing"
+
+func TestNewEntry(t *testing.T) {
+	tests := []struct {
+		name      string
+		action    Action
+		shortCode string
+		userID    string
+		wantErr   error
+	}{
+		{name: "create", action: ActionCreate, shortCode: "abc123", userID: "user1"},
+		{name: "delete", action: ActionDelete, shortCode: "abc123", userID: "user1"},
+		{name: "unknown action", action: "update", shortCode: "abc123", userID: "user1", wantErr: ErrInvalidAction},
+		{name: "empty short code", action: ActionCreate, shortCode: "", userID: "user1", wantErr: ErrEmptyShortCode},
+		{name: "blank user", action: ActionCreate, shortCode: "abc123", userID: "  ", wantErr: ErrEmptyUserID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := NewEntry(tt.action, tt.shortCode, tt.userID, "192.0.2.1")
+			if err != tt.wantErr {
+				t.Fatalf("NewEntry() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if entry.Action != tt.action || entry.ShortCode != tt.shortCode || entry.UserID != tt.userID || entry.IPAddress != "192.0.2.1" {
+				t.Errorf("NewEntry() = %+v", entry)
+			}
+			if entry.CreatedAt.IsZero() {
+				t.Error("NewEntry() CreatedAt is zero")
+			}
+		})
+	}
+}
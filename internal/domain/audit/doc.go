@@ -0,0 +1,2 @@
+// Package audit contains the audit trail domain model and repository port for recording who created or deleted which short code.
+package audit
@@ -0,0 +1,15 @@
+package audit
+
+import "errors"
+
+// Domain errors for audit entries
+var (
+	// ErrInvalidAction is returned when an entry's action is not one of the known actions
+	ErrInvalidAction = errors.New("invalid audit action")
+
+	// ErrEmptyShortCode is returned when an entry has no short code
+	ErrEmptyShortCode = errors.New("audit short code cannot be empty")
+
+	// ErrEmptyUserID is returned when an entry has no user
+	ErrEmptyUserID = errors.New("audit user id cannot be empty")
+)
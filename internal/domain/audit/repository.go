@@ -0,0 +1,12 @@
+package audit
+
+import "context"
+
+// Repository defines persistence operations for audit entries
+type Repository interface {
+	// Record stores a new audit entry
+	Record(ctx context.Context, entry *Entry) error
+
+	// ListByShortCode retrieves up to limit entries for shortCode, newest first
+	ListByShortCode(ctx context.Context, shortCode string, limit int) ([]*Entry, error)
+}
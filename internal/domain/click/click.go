@@ -15,6 +15,7 @@ type Click struct {
 	ReferrerDomain string
 	Country        string
 	UserAgent      string
+	VisitorHash    string // Salted client IP hash (see HashVisitor); empty when unique visitors are disabled
 }
 
 // NewClick creates a new Click with validation
@@ -73,3 +74,34 @@ func (c *Click) Validate() error {
 
 	return nil
 }
+
+// MaxDailyRangeDays is the most days a daily click series may cover
+const MaxDailyRangeDays = 366
+
+// ValidateDateRange validates an inclusive range of UTC days: start must not be
+// after end, and together they may cover at most MaxDailyRangeDays days.
+func ValidateDateRange(start, end time.Time) error {
+	start, end = TruncateToDay(start), TruncateToDay(end)
+	if end.Before(start) || end.Sub(start) >= MaxDailyRangeDays*24*time.Hour {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// TruncateToDay returns midnight UTC on t's UTC day
+func TruncateToDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// ValidateTimeRange validates an optional time range. Both ends must be nil
+// (no range) or both set with start strictly before end.
+func ValidateTimeRange(start, end *time.Time) error {
+	if start == nil && end == nil {
+		return nil
+	}
+	if start == nil || end == nil || !start.Before(*end) {
+		return ErrInvalidTimeRange
+	}
+	return nil
+}
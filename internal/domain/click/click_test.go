@@ -2,6 +2,7 @@ package click
 
 import (
 	"testing"
+	"time"
 )
 
 func TestNewClick(t *testing.T) {
@@ -380,3 +381,56 @@ func TestNewClick_ReferrerDomainExtraction(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateTimeRange(t *testing.T) {
+	start := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		start   *time.Time
+		end     *time.Time
+		wantErr error
+	}{
+		{name: "no range", start: nil, end: nil, wantErr: nil},
+		{name: "valid range", start: &start, end: &end, wantErr: nil},
+		{name: "only start", start: &start, end: nil, wantErr: ErrInvalidTimeRange},
+		{name: "only end", start: nil, end: &end, wantErr: ErrInvalidTimeRange},
+		{name: "start equals end", start: &start, end: &start, wantErr: ErrInvalidTimeRange},
+		{name: "start after end", start: &end, end: &start, wantErr: ErrInvalidTimeRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTimeRange(tt.start, tt.end); err != tt.wantErr {
+				t.Errorf("ValidateTimeRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDateRange(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		start   time.Time
+		end     time.Time
+		wantErr error
+	}{
+		{name: "single day", start: day, end: day, wantErr: nil},
+		{name: "same day, different times", start: day.Add(20 * time.Hour), end: day.Add(time.Hour), wantErr: nil},
+		{name: "three days", start: day, end: day.AddDate(0, 0, 2), wantErr: nil},
+		{name: "maximum span", start: day, end: day.AddDate(0, 0, MaxDailyRangeDays-1), wantErr: nil},
+		{name: "too long", start: day, end: day.AddDate(0, 0, MaxDailyRangeDays), wantErr: ErrInvalidDateRange},
+		{name: "end before start", start: day.AddDate(0, 0, 1), end: day, wantErr: ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateDateRange(tt.start, tt.end); err != tt.wantErr {
+				t.Errorf("ValidateDateRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
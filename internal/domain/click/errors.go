@@ -12,4 +12,11 @@ var (
 
 	// ErrClickNotFound is returned when a click is not found
 	ErrClickNotFound = errors.New("click not found")
+
+	// ErrInvalidTimeRange is returned when only one end of a time range is given,
+	// or the start is not strictly before the end
+	ErrInvalidTimeRange = errors.New("invalid time range: start_time and end_time must be provided together and start_time must be strictly before end_time")
+
+	// ErrInvalidDateRange is returned when a daily range ends before it starts or spans more than MaxDailyRangeDays
+	ErrInvalidDateRange = errors.New("invalid date range: start_date must not be after end_date and the range may span at most 366 days")
 )
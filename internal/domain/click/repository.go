@@ -5,12 +5,31 @@ import (
 	"time"
 )
 
+const (
+	// DefaultTopN is the number of entries kept in capped breakdowns (ByReferrer) when topN is not positive
+	DefaultTopN = 10
+	// MaxTopN is the largest topN callers may request
+	MaxTopN = 100
+)
+
+// Granularity selects the bucket size for time-series click counts
+type Granularity string
+
+const (
+	// GranularityDay buckets clicks by UTC day, keyed YYYY-MM-DD
+	GranularityDay Granularity = "day"
+	// GranularityHour buckets clicks by UTC hour, keyed "YYYY-MM-DD HH:00"
+	GranularityHour Granularity = "hour"
+)
+
 // Stats represents analytics statistics for a URL
 type Stats struct {
 	URLID      int64
 	TotalCount int64
 	ByCountry  map[string]int64
 	ByReferrer map[string]int64
+	ByBrowser  map[string]int64 // Keyed by ParseBrowser bucket
+	ByOS       map[string]int64 // Keyed by ParseOS bucket
 	ByDate     map[string]int64 // Date in YYYY-MM-DD format
 }
 
@@ -22,6 +41,24 @@ type TimeRangeStats struct {
 	TotalCount int64
 	ByCountry  map[string]int64
 	ByReferrer map[string]int64
+	ByBrowser  map[string]int64 // Keyed by ParseBrowser bucket
+	ByOS       map[string]int64 // Keyed by ParseOS bucket
+}
+
+// DailyCount is the number of clicks on one UTC day
+type DailyCount struct {
+	Date  string // YYYY-MM-DD
+	Count int64
+}
+
+// URLClicks is one of a user's URLs with its total click count
+type URLClicks struct {
+	URLID       int64
+	ShortCode   string
+	OriginalURL string
+	CreatedAt   time.Time
+	CreatedBy   string
+	Count       int64
 }
 
 // Repository defines the interface for Click persistence operations
@@ -31,15 +68,48 @@ type Repository interface {
 	// Record records a new click event
 	Record(ctx context.Context, click *Click) error
 
-	// GetStatsByURL retrieves aggregate statistics for a specific URL
-	GetStatsByURL(ctx context.Context, urlID int64) (*Stats, error)
+	// DeleteOlderThan deletes clicks recorded before t and returns how many were removed
+	DeleteOlderThan(ctx context.Context, t time.Time) (int64, error)
+
+	// ListByURL returns a page of a URL's clicks, newest first; a limit of 0 returns them all.
+	// Only ID, URLID, ClickedAt, ReferrerDomain, Country and UserAgent are filled in.
+	ListByURL(ctx context.Context, urlID int64, limit, offset int) ([]*Click, error)
 
-	// GetStatsByURLAndTimeRange retrieves statistics for a URL within a time range
-	GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (*TimeRangeStats, error)
+	// GetStatsByURL retrieves aggregate statistics for a specific URL, keeping the topN referrers
+	GetStatsByURL(ctx context.Context, urlID int64, topN int) (*Stats, error)
+
+	// GetStatsByURLAndTimeRange retrieves statistics for a URL within a time range, keeping the topN referrers
+	GetStatsByURLAndTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (*TimeRangeStats, error)
 
 	// GetTotalClickCount returns the total number of clicks for a URL
 	GetTotalClickCount(ctx context.Context, urlID int64) (int64, error)
 
+	// GetTotalClicksByUser returns the total number of clicks across all live URLs created by a user
+	GetTotalClicksByUser(ctx context.Context, createdBy string) (int64, error)
+
+	// GetTopURLsByUser returns up to limit of a user's live URLs, most clicked first.
+	// Ties go to the most recently created URL.
+	GetTopURLsByUser(ctx context.Context, createdBy string, limit int) ([]URLClicks, error)
+
+	// GetUniqueVisitorCount returns the number of distinct visitor hashes recorded for a URL
+	GetUniqueVisitorCount(ctx context.Context, urlID int64) (int64, error)
+
+	// GetUniqueVisitorCountInTimeRange returns the number of distinct visitor hashes for a URL within a time range
+	GetUniqueVisitorCountInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time) (int64, error)
+
 	// GetClicksByCountry returns click counts grouped by country for a URL
 	GetClicksByCountry(ctx context.Context, urlID int64) (map[string]int64, error)
+
+	// GetClicksByReferrerDomain returns the topN referrer domains and their click counts for a URL
+	GetClicksByReferrerDomain(ctx context.Context, urlID int64, topN int) (map[string]int64, error)
+
+	// GetClicksByReferrerDomainInTimeRange returns the topN referrer domains for a URL within a time range
+	GetClicksByReferrerDomainInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, topN int) (map[string]int64, error)
+
+	// GetDailyClicksInRange returns one entry per UTC day from startDate to endDate inclusive,
+	// oldest first, with a zero count for days without clicks
+	GetDailyClicksInRange(ctx context.Context, urlID int64, startDate, endDate time.Time) ([]DailyCount, error)
+
+	// GetClicksOverTimeInTimeRange returns click counts for a URL within a time range, bucketed by granularity
+	GetClicksOverTimeInTimeRange(ctx context.Context, urlID int64, startTime, endTime time.Time, granularity Granularity) (map[string]int64, error)
 }
@@ -0,0 +1,103 @@
+package click
+
+import "strings"
+
+// Browser buckets reported by ParseBrowser
+const (
+	BrowserChrome  = "Chrome"
+	BrowserFirefox = "Firefox"
+	BrowserSafari  = "Safari"
+	BrowserEdge    = "Edge"
+	BrowserBot     = "Bot"
+	BrowserOther   = "Other"
+)
+
+// OS buckets reported by ParseOS
+const (
+	OSWindows  = "Windows"
+	OSMacOS    = "macOS"
+	OSiOS      = "iOS"
+	OSAndroid  = "Android"
+	OSLinux    = "Linux"
+	OSChromeOS = "ChromeOS"
+	OSOther    = "Other"
+)
+
+// botMarkers are lowercase substrings that identify crawlers and scripted clients
+var botMarkers = []string{"bot", "crawler", "spider", "slurp", "facebookexternalhit", "headlesschrome", "curl/", "wget/", "python-requests", "go-http-client"}
+
+// IsBot reports whether a User-Agent header belongs to a known crawler, link
+// previewer or scripted client (Googlebot, bingbot, curl, ...). An empty
+// User-Agent is not classified as a bot.
+func IsBot(userAgent string) bool {
+	lower := strings.ToLower(strings.TrimSpace(userAgent))
+	if lower == "" {
+		return false
+	}
+	for _, marker := range botMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseBrowser maps a User-Agent header to a coarse browser bucket.
+// Matching is order-sensitive: Edge and most Chromium forks also claim to be
+// Chrome and Safari, so the more specific tokens are checked first.
+func ParseBrowser(userAgent string) string {
+	ua := strings.TrimSpace(userAgent)
+	if ua == "" {
+		return BrowserOther
+	}
+
+	if IsBot(ua) {
+		return BrowserBot
+	}
+
+	switch {
+	case containsAny(ua, "Edg/", "Edge/", "EdgA/", "EdgiOS/"):
+		return BrowserEdge
+	case containsAny(ua, "OPR/", "Opera", "SamsungBrowser/"):
+		return BrowserOther
+	case containsAny(ua, "Firefox/", "FxiOS/"):
+		return BrowserFirefox
+	case containsAny(ua, "Chrome/", "CriOS/", "Chromium/"):
+		return BrowserChrome
+	case strings.Contains(ua, "Safari/"):
+		return BrowserSafari
+	}
+	return BrowserOther
+}
+
+// ParseOS maps a User-Agent header to a coarse operating system bucket.
+func ParseOS(userAgent string) string {
+	ua := strings.TrimSpace(userAgent)
+
+	switch {
+	case ua == "":
+		return OSOther
+	case containsAny(ua, "iPhone", "iPad", "iPod"):
+		return OSiOS
+	case strings.Contains(ua, "Android"):
+		return OSAndroid
+	case strings.Contains(ua, "Windows"):
+		return OSWindows
+	case containsAny(ua, "Macintosh", "Mac OS X"):
+		return OSMacOS
+	case strings.Contains(ua, "CrOS"):
+		return OSChromeOS
+	case strings.Contains(ua, "Linux"):
+		return OSLinux
+	}
+	return OSOther
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package click
+
+import "testing"
+
+func TestParseUserAgent(t *testing.T) {
+	tests := []struct {
+		name        string
+		userAgent   string
+		wantBrowser string
+		wantOS      string
+	}{
+		{
+			name:        "chrome on windows",
+			userAgent:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			wantBrowser: BrowserChrome,
+			wantOS:      OSWindows,
+		},
+		{
+			name:        "chrome on android",
+			userAgent:   "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Mobile Safari/537.36",
+			wantBrowser: BrowserChrome,
+			wantOS:      OSAndroid,
+		},
+		{
+			name:        "chrome on ios",
+			userAgent:   "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/124.0.6367.88 Mobile/15E148 Safari/604.1",
+			wantBrowser: BrowserChrome,
+			wantOS:      OSiOS,
+		},
+		{
+			name:        "firefox on linux",
+			userAgent:   "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+			wantBrowser: BrowserFirefox,
+			wantOS:      OSLinux,
+		},
+		{
+			name:        "safari on macos",
+			userAgent:   "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4_1) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Safari/605.1.15",
+			wantBrowser: BrowserSafari,
+			wantOS:      OSMacOS,
+		},
+		{
+			name:        "safari on ipad",
+			userAgent:   "Mozilla/5.0 (iPad; CPU OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			wantBrowser: BrowserSafari,
+			wantOS:      OSiOS,
+		},
+		{
+			name:        "edge on windows",
+			userAgent:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.67",
+			wantBrowser: BrowserEdge,
+			wantOS:      OSWindows,
+		},
+		{
+			name:        "opera is other",
+			userAgent:   "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 OPR/110.0.0.0",
+			wantBrowser: BrowserOther,
+			wantOS:      OSWindows,
+		},
+		{
+			name:        "chrome on chromeos",
+			userAgent:   "Mozilla/5.0 (X11; CrOS x86_64 14541.0.0) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			wantBrowser: BrowserChrome,
+			wantOS:      OSChromeOS,
+		},
+		{
+			name:        "googlebot",
+			userAgent:   "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			wantBrowser: BrowserBot,
+			wantOS:      OSOther,
+		},
+		{
+			name:        "curl",
+			userAgent:   "curl/8.7.1",
+			wantBrowser: BrowserBot,
+			wantOS:      OSOther,
+		},
+		{
+			name:        "unknown",
+			userAgent:   "SomeApp/1.0",
+			wantBrowser: BrowserOther,
+			wantOS:      OSOther,
+		},
+		{
+			name:        "empty",
+			userAgent:   "",
+			wantBrowser: BrowserOther,
+			wantOS:      OSOther,
+		},
+		{
+			name:        "whitespace only",
+			userAgent:   "   ",
+			wantBrowser: BrowserOther,
+			wantOS:      OSOther,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseBrowser(tt.userAgent); got != tt.wantBrowser {
+				t.Errorf("ParseBrowser() = %q, want %q", got, tt.wantBrowser)
+			}
+			if got := ParseOS(tt.userAgent); got != tt.wantOS {
+				t.Errorf("ParseOS() = %q, want %q", got, tt.wantOS)
+			}
+		})
+	}
+}
+
+func TestIsBot(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      bool
+	}{
+		{"googlebot", "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)", true},
+		{"bingbot", "Mozilla/5.0 (compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm)", true},
+		{"slack link preview", "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)", true},
+		{"facebook crawler", "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)", true},
+		{"headless chrome", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/124.0.0.0 Safari/537.36", true},
+		{"curl", "curl/8.5.0", true},
+		{"chrome", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36", false},
+		{"safari on ios", "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBot(tt.userAgent); got != tt.want {
+				t.Errorf("IsBot(%q) = %v, want %v", tt.userAgent, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package click
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// HashVisitor returns a salted HMAC-SHA256 of clientIP, hex-encoded, for
+// unique-visitor counting. The raw IP is never stored. It returns an empty
+// string when either the salt or the IP is empty, so such clicks are left out
+// of unique counts rather than all collapsing into one visitor.
+func HashVisitor(salt, clientIP string) string {
+	clientIP = strings.TrimSpace(clientIP)
+	if salt == "" || clientIP == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(clientIP))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,30 @@
+package click
+
+import "testing"
+
+func TestHashVisitor(t *testing.T) {
+	a := HashVisitor("salt", "203.0.113.7")
+	if len(a) != 64 {
+		t.Fatalf("HashVisitor() length = %d, want 64", len(a))
+	}
+	if a == "203.0.113.7" {
+		t.Fatal("HashVisitor() returned the raw IP")
+	}
+
+	if got := HashVisitor("salt", " 203.0.113.7 "); got != a {
+		t.Errorf("HashVisitor() should ignore surrounding whitespace, got %q want %q", got, a)
+	}
+	if got := HashVisitor("salt", "203.0.113.8"); got == a {
+		t.Error("HashVisitor() should differ for different IPs")
+	}
+	if got := HashVisitor("other-salt", "203.0.113.7"); got == a {
+		t.Error("HashVisitor() should differ for different salts")
+	}
+
+	if got := HashVisitor("", "203.0.113.7"); got != "" {
+		t.Errorf("HashVisitor() with empty salt = %q, want empty", got)
+	}
+	if got := HashVisitor("salt", ""); got != "" {
+		t.Errorf("HashVisitor() with empty IP = %q, want empty", got)
+	}
+}
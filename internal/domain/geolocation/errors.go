@@ -0,0 +1,9 @@
+package geolocation
+
+import "errors"
+
+// Domain errors for geolocation operations
+var (
+	// ErrInvalidIPAddress is returned when a lookup is attempted with a malformed IP address
+	ErrInvalidIPAddress = errors.New("invalid IP address")
+)
@@ -2,10 +2,22 @@ package geolocation
 
 import "context"
 
+// Resolver resolves the country for a client IP address.
+// It is the narrow port consumed by the redirect flow, allowing the GeoIP
+// provider to be swapped or faked in tests.
+type Resolver interface {
+	// CountryForIP returns the ISO 3166-1 alpha-2 country code for the given IP address.
+	// An empty string with a nil error means the provider has no data for the address.
+	// An error is returned when the address is invalid or the lookup itself fails.
+	CountryForIP(ip string) (string, error)
+}
+
 // LookupService defines the interface for IP geolocation lookup operations.
 // Following hexagonal architecture, this interface is defined in the domain layer
 // and implemented by adapters (e.g., MaxMind GeoIP2).
 type LookupService interface {
+	Resolver
+
 	// LookupCountry returns the ISO 3166-1 alpha-2 country code for the given IP address.
 	// Returns an empty string if the lookup fails or the IP is invalid.
 	// This is a best-effort operation - lookup failures are handled gracefully
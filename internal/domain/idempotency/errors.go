@@ -0,0 +1,21 @@
+package idempotency
+
+import "errors"
+
+// Domain errors for idempotency key operations
+var (
+	// ErrKeyNotFound is returned when no live record exists for a creator and key
+	ErrKeyNotFound = errors.New("idempotency key not found")
+
+	// ErrDuplicateKey is returned when saving a key that already has a live record
+	ErrDuplicateKey = errors.New("idempotency key already exists")
+
+	// ErrInvalidKey is returned when an Idempotency-Key value is malformed
+	ErrInvalidKey = errors.New("idempotency key must be 1-255 printable ASCII characters")
+
+	// ErrKeyMismatch is returned when a key is replayed with a different request
+	ErrKeyMismatch = errors.New("idempotency key was already used with a different request")
+
+	// ErrKeyInProgress is returned when a key is replayed before the request that claimed it has finished
+	ErrKeyInProgress = errors.New("idempotency key is in use by a request that has not finished")
+)
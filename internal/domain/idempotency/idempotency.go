@@ -0,0 +1,44 @@
+package idempotency
+
+import "time"
+
+// maxKeyLength matches the idempotency_key column size
+const maxKeyLength = 255
+
+// Record maps an idempotency key to the URL a create request produced.
+// A record is saved before the URL is created, claiming the key, and gets its
+// short code once the create succeeds.
+type Record struct {
+	CreatedBy   string
+	Key         string
+	OriginalURL string
+	ShortCode   string // Empty while the claiming request is still creating the URL
+	CreatedAt   time.Time
+}
+
+// Pending reports whether the request that claimed the key has not yet finished
+func (r *Record) Pending() bool {
+	return r.ShortCode == ""
+}
+
+// Matches reports whether a replayed create for originalURL (and optional custom
+// shortCode) is the same request that produced the record
+func (r *Record) Matches(originalURL, shortCode string) bool {
+	if r.OriginalURL != originalURL {
+		return false
+	}
+	return shortCode == "" || shortCode == r.ShortCode
+}
+
+// ValidateKey checks that key is 1-255 printable ASCII characters
+func ValidateKey(key string) error {
+	if key == "" || len(key) > maxKeyLength {
+		return ErrInvalidKey
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package idempotency
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "uuid", key: "4f9c2a64-3b1e-4d2a-9a57-0c1f8a8e6b2d"},
+		{name: "printable punctuation", key: "retry:create/2025-11-20 #1"},
+		{name: "max length", key: strings.Repeat("k", 255)},
+		{name: "empty", key: "", wantErr: true},
+		{name: "too long", key: strings.Repeat("k", 256), wantErr: true},
+		{name: "control character", key: "abc\n123", wantErr: true},
+		{name: "non-ascii", key: "clé", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRecord_Matches(t *testing.T) {
+	rec := &Record{OriginalURL: "https://example.com", ShortCode: "abc123"}
+
+	tests := []struct {
+		name        string
+		originalURL string
+		shortCode   string
+		want        bool
+	}{
+		{name: "same destination", originalURL: "https://example.com", want: true},
+		{name: "same destination and code", originalURL: "https://example.com", shortCode: "abc123", want: true},
+		{name: "different destination", originalURL: "https://example.org", want: false},
+		{name: "different custom code", originalURL: "https://example.com", shortCode: "other", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rec.Matches(tt.originalURL, tt.shortCode); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
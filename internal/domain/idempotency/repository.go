@@ -0,0 +1,23 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines persistence operations for idempotency records
+type Repository interface {
+	// Find retrieves the record for createdBy and key if it was created at or after notBefore
+	// Returns ErrKeyNotFound if there is no such record
+	Find(ctx context.Context, createdBy, key string, notBefore time.Time) (*Record, error)
+
+	// Save stores rec, first pruning the creator's records created before notBefore
+	// Returns ErrDuplicateKey if a live record already exists for the creator and key
+	Save(ctx context.Context, rec *Record, notBefore time.Time) error
+
+	// Complete sets the short code of the creator's record for key
+	Complete(ctx context.Context, createdBy, key, shortCode string) error
+
+	// Release deletes the creator's record for key, so the key can be used again
+	Release(ctx context.Context, createdBy, key string) error
+}
@@ -0,0 +1,68 @@
+package url
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// DefaultAlphabet is the base62 character set generated short codes use by default
+	DefaultAlphabet = base62Chars
+
+	// UnambiguousAlphabet is base62 without the characters that are easily
+	// confused when a code is read aloud or copied by hand: 0/O/o and 1/l/I
+	UnambiguousAlphabet = "23456789abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+
+	// MinAlphabetSize is the fewest distinct characters a short code alphabet may have
+	MinAlphabetSize = 16
+)
+
+// alphabetPresets are the alphabets ResolveAlphabet accepts by name
+var alphabetPresets = map[string]string{
+	"base62":      DefaultAlphabet,
+	"unambiguous": UnambiguousAlphabet,
+}
+
+// ResolveAlphabet returns the short code alphabet for value: a preset name
+// ("base62" or "unambiguous"), or a literal set of characters. Empty means
+// DefaultAlphabet. A literal alphabet needs at least MinAlphabetSize characters,
+// each used once and valid in a short code (a-z, A-Z, 0-9, _ or -), otherwise
+// ErrInvalidAlphabet is returned.
+func ResolveAlphabet(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultAlphabet, nil
+	}
+	if preset, ok := alphabetPresets[strings.ToLower(value)]; ok {
+		return preset, nil
+	}
+
+	seen := make(map[rune]bool, len(value))
+	for _, r := range value {
+		if !isShortCodeChar(r) {
+			return "", fmt.Errorf("%w: %q is not allowed in short codes", ErrInvalidAlphabet, r)
+		}
+		if seen[r] {
+			return "", fmt.Errorf("%w: %q appears more than once", ErrInvalidAlphabet, r)
+		}
+		seen[r] = true
+	}
+	if len(seen) < MinAlphabetSize {
+		return "", fmt.Errorf("%w: got %d characters", ErrInvalidAlphabet, len(seen))
+	}
+	return value, nil
+}
+
+// EntropyBits returns the entropy, in bits, of a random code of length
+// characters drawn uniformly from alphabet
+func EntropyBits(alphabet string, length int) float64 {
+	if len(alphabet) == 0 || length <= 0 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(len(alphabet)))
+}
+
+func isShortCodeChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '-'
+}
@@ -0,0 +1,69 @@
+package url
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestResolveAlphabet(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty uses default", value: "", want: DefaultAlphabet},
+		{name: "base62 preset", value: "base62", want: DefaultAlphabet},
+		{name: "unambiguous preset", value: "unambiguous", want: UnambiguousAlphabet},
+		{name: "preset name is case-insensitive", value: " Unambiguous ", want: UnambiguousAlphabet},
+		{name: "custom alphabet", value: "abcdefghjkmnpqrstuvwxyz23456789", want: "abcdefghjkmnpqrstuvwxyz23456789"},
+		{name: "custom alphabet with _ and -", value: "0123456789abcd_-", want: "0123456789abcd_-"},
+		{name: "too few characters", value: "abcdefghijklmno", wantErr: true},
+		{name: "repeated character", value: "abcdefghijklmnopp", wantErr: true},
+		{name: "character not allowed in short codes", value: "abcdefghijklmnop!", wantErr: true},
+		{name: "non-ASCII character", value: "abcdefghijklmnopé", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveAlphabet(tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidAlphabet) {
+					t.Fatalf("ResolveAlphabet(%q) error = %v, want ErrInvalidAlphabet", tt.value, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveAlphabet(%q) unexpected error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveAlphabet(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnambiguousAlphabet(t *testing.T) {
+	if _, err := ResolveAlphabet(UnambiguousAlphabet); err != nil {
+		t.Fatalf("UnambiguousAlphabet is not a valid alphabet: %v", err)
+	}
+	for _, c := range "0Oo1lI" {
+		if strings.ContainsRune(UnambiguousAlphabet, c) {
+			t.Errorf("UnambiguousAlphabet contains ambiguous character %c", c)
+		}
+	}
+}
+
+func TestEntropyBits(t *testing.T) {
+	if got := EntropyBits("0123456789abcdef", 6); got != 24 {
+		t.Errorf("EntropyBits(16 chars, 6) = %v, want 24", got)
+	}
+	if got, want := EntropyBits(DefaultAlphabet, 6), 6*math.Log2(62); math.Abs(got-want) > 1e-9 {
+		t.Errorf("EntropyBits(base62, 6) = %v, want %v", got, want)
+	}
+	if got := EntropyBits("", 6); got != 0 {
+		t.Errorf("EntropyBits(empty, 6) = %v, want 0", got)
+	}
+}
@@ -16,6 +16,15 @@ var (
 	// ErrInvalidShortCode is returned when a short code format is invalid
 	ErrInvalidShortCode = errors.New("short code must be 3-20 characters long and contain only alphanumeric characters, underscores, or hyphens")
 
+	// ErrReservedShortCode is returned when a custom short code is on the reserved list (e.g. a server route)
+	ErrReservedShortCode = errors.New("short code is reserved")
+
+	// ErrEmptyShortCodePrefix is returned when a bulk operation is given an empty short code prefix
+	ErrEmptyShortCodePrefix = errors.New("short code prefix cannot be empty")
+
+	// ErrInvalidShortCodePrefix is returned when a short code prefix format is invalid
+	ErrInvalidShortCodePrefix = errors.New("short code prefix must be 1-20 characters long and contain only alphanumeric characters, underscores, or hyphens")
+
 	// ErrEmptyOriginalURL is returned when an original URL is empty
 	ErrEmptyOriginalURL = errors.New("original URL cannot be empty")
 
@@ -25,15 +34,27 @@ var (
 	// ErrMissingURLScheme is returned when a URL doesn't have a scheme
 	ErrMissingURLScheme = errors.New("URL must have a scheme (http or https)")
 
-	// ErrInvalidURLScheme is returned when a URL has an unsupported scheme
-	ErrInvalidURLScheme = errors.New("URL scheme must be http or https")
+	// ErrInvalidURLScheme is returned when a URL has a scheme that isn't allowed
+	ErrInvalidURLScheme = errors.New("URL scheme is not allowed")
 
 	// ErrMissingURLHost is returned when a URL doesn't have a host
 	ErrMissingURLHost = errors.New("URL must have a host")
 
+	// ErrDestinationNotAllowed is returned when a destination host is blocked or not on the allowlist
+	ErrDestinationNotAllowed = errors.New("destination host is not allowed")
+
+	// ErrPrivateDestination is returned when a destination host is or resolves to a loopback, link-local or private address
+	ErrPrivateDestination = errors.New("destination resolves to a private or internal address")
+
+	// ErrUnresolvableDestination is returned when a destination host can't be resolved to check it isn't private
+	ErrUnresolvableDestination = errors.New("destination host could not be resolved")
+
 	// ErrInvalidCreatedBy is returned when created_by is empty
 	ErrInvalidCreatedBy = errors.New("created_by cannot be empty")
 
 	// ErrUnauthorizedDeletion is returned when a user attempts to delete a URL they didn't create
 	ErrUnauthorizedDeletion = errors.New("unauthorized: you can only delete URLs you created")
+
+	// ErrUnauthorizedRestore is returned when a user attempts to restore a URL they didn't create
+	ErrUnauthorizedRestore = errors.New("unauthorized: you can only restore URLs you created")
 )
@@ -4,33 +4,113 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"math"
 	"math/big"
+	"strings"
+	"sync"
+	"time"
 )
 
 // Base62 character set for short code generation
 const base62Chars = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
 
+// maxCodeLength is the longest short code NewGenerator accepts and auto length scaling grows to
+const maxCodeLength = 20
+
+// DefaultAutoLengthRefresh is how long auto length scaling reuses a stored URL count
+const DefaultAutoLengthRefresh = time.Minute
+
 var (
 	// ErrMaxRetriesExceeded is returned when collision resolution fails after maximum retries
 	ErrMaxRetriesExceeded = errors.New("maximum retries exceeded for short code generation")
 
 	// ErrInvalidCodeLength is returned when code length is invalid
 	ErrInvalidCodeLength = errors.New("code length must be between 3 and 20 characters")
+
+	// ErrInvalidAlphabet is returned when a short code alphabet is too small, repeats characters or has ones short codes can't contain
+	ErrInvalidAlphabet = errors.New("short code alphabet must have at least 16 distinct characters from a-z, A-Z, 0-9, _ and -")
 )
 
+// DefaultReservedShortCodes are the top-level route segments the server serves
+// itself; a short code equal to one of them could never be reached by redirect.
+var DefaultReservedShortCodes = []string{
+	"api",
+	"create",
+	"dashboard",
+	"health",
+	"login",
+	"logout",
+	"metrics",
+	"ready",
+	"version",
+}
+
+// GeneratorObserver receives short code generation events, e.g. to export metrics.
+// Implementations must be safe for concurrent use.
+type GeneratorObserver interface {
+	// RecordShortCodeGenerated is called for every candidate code generated.
+	RecordShortCodeGenerated()
+	// RecordShortCodeCollision is called when a candidate code is already taken.
+	RecordShortCodeCollision()
+	// RecordShortCodeMaxRetriesExceeded is called when generation gives up with ErrMaxRetriesExceeded.
+	RecordShortCodeMaxRetriesExceeded()
+}
+
 // Generator generates short codes for URLs
 type Generator struct {
 	codeLength int
+	alphabet   string
 	maxRetries int
 	repository Repository
+	observer   GeneratorObserver
+	reserved   map[string]struct{}
+	hosts      HostPolicy
+	schemes    schemeSet
+	// stripFragments drops the fragment from destinations when normalizing
+	stripFragments bool
+	// private, when set, rejects destinations on private networks
+	private *privateDestinationGuard
+	// nextCode produces candidate codes; nil means generateShortCode
+	nextCode func(length int) (string, error)
+	// autoLength, when set, lengthens codes as the namespace fills
+	autoLength *autoLengthScaler
 }
 
 // GeneratorConfig holds configuration for the Generator
 type GeneratorConfig struct {
 	// CodeLength is the length of generated short codes (default: 6)
 	CodeLength int
+	// Alphabet is the characters generated codes are drawn from: a preset name or
+	// a literal set, see ResolveAlphabet (default: DefaultAlphabet)
+	Alphabet string
 	// MaxRetries is the maximum number of retry attempts for collision resolution (default: 3)
 	MaxRetries int
+	// Observer is notified of generation events (optional)
+	Observer GeneratorObserver
+	// ReservedCodes are extra short codes to reserve on top of DefaultReservedShortCodes (optional)
+	ReservedCodes []string
+	// AllowedSchemes are the destination schemes that can be shortened (default: DefaultAllowedSchemes)
+	AllowedSchemes []string
+	// AllowedHosts, when set, are the only destination hosts that can be shortened (optional)
+	AllowedHosts []string
+	// BlockedHosts are destination hosts that can never be shortened (optional)
+	BlockedHosts []string
+	// BlockPrivateDestinations rejects destinations that are or resolve to
+	// loopback, link-local or private addresses (default: false)
+	BlockPrivateDestinations bool
+	// Resolver resolves destination hosts for BlockPrivateDestinations (default: net.DefaultResolver)
+	Resolver Resolver
+	// ResolveTimeout bounds each lookup for BlockPrivateDestinations (default: DefaultResolveTimeout)
+	ResolveTimeout time.Duration
+	// StripFragments drops the "#fragment" from destinations before they are stored (default: false)
+	StripFragments bool
+	// AutoLengthDensity, when above 0, generates codes one character longer than
+	// CodeLength, and so on, while the stored URLs would fill more than this
+	// fraction of the codes of that length (default: 0, disabled)
+	AutoLengthDensity float64
+	// AutoLengthRefresh is how long the stored URL count is reused before the
+	// repository is asked again (default: DefaultAutoLengthRefresh)
+	AutoLengthRefresh time.Duration
 }
 
 // DefaultGeneratorConfig returns the default configuration
@@ -43,45 +123,187 @@ func DefaultGeneratorConfig() GeneratorConfig {
 
 // NewGenerator creates a new Generator with the given repository and config
 func NewGenerator(repo Repository, config GeneratorConfig) (*Generator, error) {
-	if config.CodeLength < 3 || config.CodeLength > 20 {
+	if config.CodeLength < 3 || config.CodeLength > maxCodeLength {
 		return nil, ErrInvalidCodeLength
 	}
 
+	alphabet, err := ResolveAlphabet(config.Alphabet)
+	if err != nil {
+		return nil, err
+	}
+
 	if config.MaxRetries < 1 {
 		config.MaxRetries = 1
 	}
 
+	reserved := make(map[string]struct{}, len(DefaultReservedShortCodes)+len(config.ReservedCodes))
+	for _, code := range DefaultReservedShortCodes {
+		reserved[code] = struct{}{}
+	}
+	for _, code := range config.ReservedCodes {
+		if code = strings.ToLower(strings.TrimSpace(code)); code != "" {
+			reserved[code] = struct{}{}
+		}
+	}
+
+	schemes, err := newSchemeSet(config.AllowedSchemes)
+	if err != nil {
+		return nil, err
+	}
+
+	var private *privateDestinationGuard
+	if config.BlockPrivateDestinations {
+		private = &privateDestinationGuard{resolver: config.Resolver, timeout: config.ResolveTimeout}
+		if private.resolver == nil {
+			private.resolver = defaultResolver
+		}
+		if private.timeout <= 0 {
+			private.timeout = DefaultResolveTimeout
+		}
+	}
+
+	var autoLength *autoLengthScaler
+	if config.AutoLengthDensity > 0 {
+		autoLength = &autoLengthScaler{
+			density: config.AutoLengthDensity,
+			refresh: config.AutoLengthRefresh,
+			now:     time.Now,
+		}
+		if autoLength.refresh <= 0 {
+			autoLength.refresh = DefaultAutoLengthRefresh
+		}
+	}
+
 	return &Generator{
 		codeLength: config.CodeLength,
+		alphabet:   alphabet,
 		maxRetries: config.MaxRetries,
 		repository: repo,
+		observer:   config.Observer,
+		reserved:   reserved,
+		hosts:      NewHostPolicy(config.AllowedHosts, config.BlockedHosts),
+		schemes:    schemes,
+		private:    private,
+		autoLength: autoLength,
+
+		stripFragments: config.StripFragments,
 	}, nil
 }
 
-// GenerateShortCode generates a random base62 short code
+// IsReserved reports whether shortCode is reserved. Matching is case-insensitive
+// so that e.g. "API" cannot shadow "/api" on case-folding proxies.
+func (g *Generator) IsReserved(shortCode string) bool {
+	_, ok := g.reserved[strings.ToLower(shortCode)]
+	return ok
+}
+
+// Alphabet returns the characters generated short codes are drawn from
+func (g *Generator) Alphabet() string {
+	if g.alphabet == "" {
+		return DefaultAlphabet
+	}
+	return g.alphabet
+}
+
+// EntropyBits returns the entropy of a generated short code, which shrinks
+// with a smaller alphabet at the same code length
+func (g *Generator) EntropyBits() float64 {
+	return EntropyBits(g.Alphabet(), g.codeLength)
+}
+
+// GenerateShortCode generates a random short code from the configured alphabet
 func (g *Generator) GenerateShortCode() (string, error) {
-	code := make([]byte, g.codeLength)
-	charsetLen := big.NewInt(int64(len(base62Chars)))
+	return g.generateShortCode(g.codeLength)
+}
+
+func (g *Generator) generateShortCode(length int) (string, error) {
+	alphabet := g.Alphabet()
+	code := make([]byte, length)
+	charsetLen := big.NewInt(int64(len(alphabet)))
 
-	for i := 0; i < g.codeLength; i++ {
+	for i := 0; i < length; i++ {
 		// Use crypto/rand for cryptographically secure random number generation
 		randomIndex, err := rand.Int(rand.Reader, charsetLen)
 		if err != nil {
 			return "", err
 		}
-		code[i] = base62Chars[randomIndex.Int64()]
+		code[i] = alphabet[randomIndex.Int64()]
 	}
 
 	return string(code), nil
 }
 
+// CodeLength returns the length of the next generated short code. Without auto
+// length scaling this is the configured CodeLength; with it, the shortest length
+// from there whose namespace the stored URLs fill less than AutoLengthDensity of.
+func (g *Generator) CodeLength(ctx context.Context) int {
+	if g.autoLength == nil {
+		return g.codeLength
+	}
+	return g.autoLength.length(ctx, g.repository, g.codeLength, len(g.Alphabet()))
+}
+
+// autoLengthScaler picks a code length from a cached count of taken short codes
+type autoLengthScaler struct {
+	density float64
+	refresh time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	count     int
+	checkedAt time.Time
+	checked   bool
+}
+
+// length returns the shortest length from minLength whose namespace of
+// alphabetSize^length codes is filled less than density by the stored URLs,
+// soft-deleted ones included.
+// The count is refreshed at most once per refresh; if the repository can't be
+// counted, the last count (or none) is used until the next refresh.
+func (a *autoLengthScaler) length(ctx context.Context, repo Repository, minLength, alphabetSize int) int {
+	a.mu.Lock()
+	now := a.now()
+	if !a.checked || now.Sub(a.checkedAt) >= a.refresh {
+		if count, err := repo.CountAll(ctx); err == nil {
+			a.count = count
+		}
+		a.checkedAt = now
+		a.checked = true
+	}
+	count := a.count
+	a.mu.Unlock()
+
+	length := minLength
+	for length < maxCodeLength && float64(count) >= a.density*math.Pow(float64(alphabetSize), float64(length)) {
+		length++
+	}
+	return length
+}
+
 // GenerateUniqueShortCode generates a unique short code with collision detection
 func (g *Generator) GenerateUniqueShortCode(ctx context.Context) (string, error) {
+	nextCode := g.nextCode
+	if nextCode == nil {
+		nextCode = g.generateShortCode
+	}
+
+	length := g.CodeLength(ctx)
 	for attempt := 0; attempt < g.maxRetries; attempt++ {
-		code, err := g.GenerateShortCode()
+		code, err := nextCode(length)
 		if err != nil {
 			return "", err
 		}
+		if g.observer != nil {
+			g.observer.RecordShortCodeGenerated()
+		}
+
+		// Reserved codes are treated like a collision without asking the repository
+		if g.IsReserved(code) {
+			if g.observer != nil {
+				g.observer.RecordShortCodeCollision()
+			}
+			continue
+		}
 
 		// Check for collision by attempting to find existing URL with this code
 		_, err = g.repository.FindByShortCode(ctx, code)
@@ -95,15 +317,37 @@ func (g *Generator) GenerateUniqueShortCode(ctx context.Context) (string, error)
 		}
 
 		// Collision detected, retry
+		if g.observer != nil {
+			g.observer.RecordShortCodeCollision()
+		}
 	}
 
+	if g.observer != nil {
+		g.observer.RecordShortCodeMaxRetriesExceeded()
+	}
 	return "", ErrMaxRetriesExceeded
 }
 
-// ShortenURL creates a shortened URL with a unique short code
+// NormalizeURL returns originalURL as ShortenURL would store it: trimmed, with
+// its scheme and host lowercased and, when configured, its fragment stripped.
+// Returns ErrInvalidOriginalURL if it contains control characters.
+func (g *Generator) NormalizeURL(originalURL string) (string, error) {
+	return normalizeURL(originalURL, g.stripFragments)
+}
+
+// ShortenURL creates a shortened URL with a unique short code.
+// The destination is normalized first (see NormalizeURL).
+// Returns ErrDestinationNotAllowed if the destination host is not allowed and,
+// when private destinations are blocked, ErrPrivateDestination or
+// ErrUnresolvableDestination.
 func (g *Generator) ShortenURL(ctx context.Context, originalURL, createdBy string) (*URL, error) {
+	originalURL, err := g.NormalizeURL(originalURL)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate URL before generating short code
-	if err := ValidateOriginalURL(originalURL); err != nil {
+	if err := g.validateDestination(ctx, originalURL); err != nil {
 		return nil, err
 	}
 
@@ -113,8 +357,46 @@ func (g *Generator) ShortenURL(ctx context.Context, originalURL, createdBy strin
 		return nil, err
 	}
 
+	return g.create(ctx, shortCode, originalURL, createdBy)
+}
+
+// ShortenURLWithCode creates a shortened URL using a caller-chosen short code.
+// Returns ErrReservedShortCode if the code is reserved, ErrDuplicateShortCode
+// if it is already taken, and the same destination errors as ShortenURL.
+func (g *Generator) ShortenURLWithCode(ctx context.Context, originalURL, shortCode, createdBy string) (*URL, error) {
+	originalURL, err := g.NormalizeURL(originalURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.validateDestination(ctx, originalURL); err != nil {
+		return nil, err
+	}
+
+	if g.IsReserved(shortCode) {
+		return nil, ErrReservedShortCode
+	}
+
+	return g.create(ctx, shortCode, originalURL, createdBy)
+}
+
+// validateDestination checks originalURL is well-formed and its scheme and host are allowed
+func (g *Generator) validateDestination(ctx context.Context, originalURL string) error {
+	if err := validateOriginalURL(originalURL, g.schemes); err != nil {
+		return err
+	}
+	if err := g.hosts.Check(originalURL); err != nil {
+		return err
+	}
+	if g.private != nil {
+		return g.private.check(ctx, originalURL)
+	}
+	return nil
+}
+
+func (g *Generator) create(ctx context.Context, shortCode, originalURL, createdBy string) (*URL, error) {
 	// Create URL entity
-	url, err := NewURL(shortCode, originalURL, createdBy)
+	url, err := newURL(shortCode, originalURL, createdBy, g.schemes)
 	if err != nil {
 		return nil, err
 	}
@@ -43,11 +43,31 @@ func (m *MockRepository) FindByShortCode(ctx context.Context, shortCode string)
 	return nil, ErrURLNotFound
 }
 
+func (m *MockRepository) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*URL, error) {
+	return nil, ErrURLNotFound
+}
+
 func (m *MockRepository) Delete(ctx context.Context, shortCode string) error {
 	delete(m.urls, shortCode)
 	return nil
 }
 
+func (m *MockRepository) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	return errors.New("not implemented")
+}
+
+func (m *MockRepository) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *MockRepository) Restore(ctx context.Context, shortCode string) error {
+	return errors.New("not implemented")
+}
+
 func (m *MockRepository) List(ctx context.Context, createdBy string, limit, offset int) ([]*URL, error) {
 	return nil, errors.New("not implemented")
 }
@@ -57,6 +77,24 @@ func (m *MockRepository) ListByCreatedByAndTimeRange(ctx context.Context, create
 }
 
 func (m *MockRepository) Count(ctx context.Context, createdBy string) (int, error) {
+	count := 0
+	for _, u := range m.urls {
+		if !u.IsDeleted() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockRepository) CountAll(ctx context.Context) (int, error) {
+	return len(m.urls), nil
+}
+
+func (m *MockRepository) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*URL, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
 	return 0, nil
 }
 
@@ -116,6 +154,33 @@ func TestNewGenerator(t *testing.T) {
 			},
 			wantErr: ErrInvalidCodeLength,
 		},
+		{
+			name: "alphabet too small",
+			config: GeneratorConfig{
+				CodeLength: 6,
+				MaxRetries: 3,
+				Alphabet:   "abcdef",
+			},
+			wantErr: ErrInvalidAlphabet,
+		},
+		{
+			name: "alphabet with repeated characters",
+			config: GeneratorConfig{
+				CodeLength: 6,
+				MaxRetries: 3,
+				Alphabet:   "abcdefghijklmnopa",
+			},
+			wantErr: ErrInvalidAlphabet,
+		},
+		{
+			name: "unambiguous alphabet preset",
+			config: GeneratorConfig{
+				CodeLength: 6,
+				MaxRetries: 3,
+				Alphabet:   "unambiguous",
+			},
+			wantErr: nil,
+		},
 		{
 			name: "zero max retries gets set to 1",
 			config: GeneratorConfig{
@@ -131,7 +196,7 @@ func TestNewGenerator(t *testing.T) {
 			gen, err := NewGenerator(repo, tt.config)
 
 			if tt.wantErr != nil {
-				if err != tt.wantErr {
+				if !errors.Is(err, tt.wantErr) {
 					t.Errorf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
 				}
 				return
@@ -228,6 +293,71 @@ func TestGenerator_GenerateShortCode(t *testing.T) {
 	}
 }
 
+func TestGenerator_GenerateShortCode_Alphabet(t *testing.T) {
+	repo := NewMockRepository()
+
+	tests := []struct {
+		name     string
+		alphabet string
+		want     string
+	}{
+		{name: "default", alphabet: "", want: DefaultAlphabet},
+		{name: "unambiguous preset", alphabet: "unambiguous", want: UnambiguousAlphabet},
+		{name: "custom", alphabet: "0123456789abcdef", want: "0123456789abcdef"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(repo, GeneratorConfig{
+				CodeLength: 8,
+				MaxRetries: 3,
+				Alphabet:   tt.alphabet,
+			})
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+			if gen.Alphabet() != tt.want {
+				t.Fatalf("Alphabet() = %q, want %q", gen.Alphabet(), tt.want)
+			}
+
+			for i := 0; i < 200; i++ {
+				code, err := gen.GenerateShortCode()
+				if err != nil {
+					t.Fatalf("GenerateShortCode() error = %v", err)
+				}
+				for _, char := range code {
+					if !strings.ContainsRune(tt.want, char) {
+						t.Fatalf("GenerateShortCode() = %q, contains %c outside the alphabet", code, char)
+					}
+				}
+				if err := ValidateShortCode(code); err != nil {
+					t.Fatalf("GenerateShortCode() = %q, not a valid short code: %v", code, err)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerator_EntropyBits(t *testing.T) {
+	repo := NewMockRepository()
+
+	base62, err := NewGenerator(repo, GeneratorConfig{CodeLength: 6, MaxRetries: 3})
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	hex, err := NewGenerator(repo, GeneratorConfig{CodeLength: 6, MaxRetries: 3, Alphabet: "0123456789abcdef"})
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if got := hex.EntropyBits(); got != 24 {
+		t.Errorf("EntropyBits() with 16 characters = %v, want 24", got)
+	}
+	if base62.EntropyBits() <= hex.EntropyBits() {
+		t.Errorf("EntropyBits() base62 = %v should exceed hex = %v", base62.EntropyBits(), hex.EntropyBits())
+	}
+}
+
 func TestGenerator_GenerateShortCode_IsRandom(t *testing.T) {
 	repo := NewMockRepository()
 	gen, err := NewGenerator(repo, DefaultGeneratorConfig())
@@ -363,6 +493,108 @@ func TestGenerator_GenerateUniqueShortCode(t *testing.T) {
 	})
 }
 
+func TestGenerator_GenerateUniqueShortCode_SkipsReserved(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		want       string
+		wantErr    error
+	}{
+		{name: "reserved candidates are skipped", candidates: []string{"health", "promo1", "abc123"}, want: "abc123"},
+		{name: "only reserved candidates", candidates: []string{"login", "LOGOUT", "promo1"}, wantErr: ErrMaxRetriesExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := NewGenerator(NewMockRepository(), GeneratorConfig{
+				CodeLength:    6,
+				MaxRetries:    len(tt.candidates),
+				ReservedCodes: []string{"promo1"},
+			})
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+
+			next := 0
+			gen.nextCode = func(int) (string, error) {
+				code := tt.candidates[next]
+				next++
+				return code, nil
+			}
+
+			code, err := gen.GenerateUniqueShortCode(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GenerateUniqueShortCode() error = %v, want %v", err, tt.wantErr)
+			}
+			if code != tt.want {
+				t.Errorf("GenerateUniqueShortCode() = %q, want %q", code, tt.want)
+			}
+			if gen.IsReserved(code) {
+				t.Errorf("GenerateUniqueShortCode() returned reserved code %q", code)
+			}
+		})
+	}
+}
+
+// countingObserver records GeneratorObserver events
+type countingObserver struct {
+	generated          int
+	collisions         int
+	maxRetriesExceeded int
+}
+
+func (o *countingObserver) RecordShortCodeGenerated()          { o.generated++ }
+func (o *countingObserver) RecordShortCodeCollision()          { o.collisions++ }
+func (o *countingObserver) RecordShortCodeMaxRetriesExceeded() { o.maxRetriesExceeded++ }
+
+func TestGenerator_GenerateUniqueShortCode_Observer(t *testing.T) {
+	t.Run("collisions counted per retry", func(t *testing.T) {
+		repo := NewMockRepository()
+		obs := &countingObserver{}
+		attempts := 0
+
+		gen, err := NewGenerator(&mockAlwaysCollisionRepo{wrapped: repo, attempts: &attempts}, GeneratorConfig{
+			CodeLength: 6,
+			MaxRetries: 4,
+			Observer:   obs,
+		})
+		if err != nil {
+			t.Fatalf("NewGenerator() error = %v", err)
+		}
+
+		_, err = gen.GenerateUniqueShortCode(context.Background())
+		if !errors.Is(err, ErrMaxRetriesExceeded) {
+			t.Fatalf("GenerateUniqueShortCode() error = %v, want %v", err, ErrMaxRetriesExceeded)
+		}
+
+		if obs.collisions != attempts || obs.collisions != 4 {
+			t.Errorf("collisions = %d, want %d (attempts = %d)", obs.collisions, 4, attempts)
+		}
+		if obs.generated != 4 {
+			t.Errorf("generated = %d, want 4", obs.generated)
+		}
+		if obs.maxRetriesExceeded != 1 {
+			t.Errorf("maxRetriesExceeded = %d, want 1", obs.maxRetriesExceeded)
+		}
+	})
+
+	t.Run("no collision", func(t *testing.T) {
+		obs := &countingObserver{}
+		gen, err := NewGenerator(NewMockRepository(), GeneratorConfig{CodeLength: 6, MaxRetries: 3, Observer: obs})
+		if err != nil {
+			t.Fatalf("NewGenerator() error = %v", err)
+		}
+
+		if _, err := gen.GenerateUniqueShortCode(context.Background()); err != nil {
+			t.Fatalf("GenerateUniqueShortCode() error = %v", err)
+		}
+
+		if obs.generated != 1 || obs.collisions != 0 || obs.maxRetriesExceeded != 0 {
+			t.Errorf("got generated=%d collisions=%d maxRetriesExceeded=%d, want 1/0/0", obs.generated, obs.collisions, obs.maxRetriesExceeded)
+		}
+	})
+}
+
 // mockAlwaysCollisionRepo simulates a repository where all codes collide
 type mockAlwaysCollisionRepo struct {
 	wrapped  *MockRepository
@@ -385,10 +617,30 @@ func (m *mockAlwaysCollisionRepo) FindByShortCode(ctx context.Context, shortCode
 	}, nil
 }
 
+func (m *mockAlwaysCollisionRepo) FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*URL, error) {
+	return m.wrapped.FindByOriginalURL(ctx, createdBy, originalURL)
+}
+
 func (m *mockAlwaysCollisionRepo) Delete(ctx context.Context, shortCode string) error {
 	return m.wrapped.Delete(ctx, shortCode)
 }
 
+func (m *mockAlwaysCollisionRepo) DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error) {
+	return m.wrapped.DeleteByShortCodePrefix(ctx, createdBy, prefix)
+}
+
+func (m *mockAlwaysCollisionRepo) SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error {
+	return m.wrapped.SoftDelete(ctx, shortCode, deletedAt)
+}
+
+func (m *mockAlwaysCollisionRepo) SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error) {
+	return m.wrapped.SoftDeleteByShortCodePrefix(ctx, createdBy, prefix, deletedAt)
+}
+
+func (m *mockAlwaysCollisionRepo) Restore(ctx context.Context, shortCode string) error {
+	return m.wrapped.Restore(ctx, shortCode)
+}
+
 func (m *mockAlwaysCollisionRepo) List(ctx context.Context, createdBy string, limit, offset int) ([]*URL, error) {
 	return m.wrapped.List(ctx, createdBy, limit, offset)
 }
@@ -401,6 +653,175 @@ func (m *mockAlwaysCollisionRepo) Count(ctx context.Context, createdBy string) (
 	return m.wrapped.Count(ctx, createdBy)
 }
 
+func (m *mockAlwaysCollisionRepo) CountAll(ctx context.Context) (int, error) {
+	return m.wrapped.CountAll(ctx)
+}
+
+func (m *mockAlwaysCollisionRepo) Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*URL, error) {
+	return m.wrapped.Search(ctx, createdBy, query, limit, offset)
+}
+
+func (m *mockAlwaysCollisionRepo) CountMatching(ctx context.Context, createdBy, query string) (int, error) {
+	return m.wrapped.CountMatching(ctx, createdBy, query)
+}
+
+// countingRepo counts calls to CountAll
+type countingRepo struct {
+	*MockRepository
+	counts   int
+	countErr error
+}
+
+func (m *countingRepo) CountAll(ctx context.Context) (int, error) {
+	m.counts++
+	if m.countErr != nil {
+		return 0, m.countErr
+	}
+	return m.MockRepository.CountAll(ctx)
+}
+
+// fillNamespace stores a URL for every code of length over alphabet
+func fillNamespace(t *testing.T, repo *MockRepository, alphabet string, length int) {
+	t.Helper()
+	var fill func(prefix string)
+	fill = func(prefix string) {
+		if len(prefix) == length {
+			if err := repo.Create(context.Background(), &URL{ShortCode: prefix, OriginalURL: "https://example.com"}); err != nil {
+				t.Fatalf("Create(%q) error = %v", prefix, err)
+			}
+			return
+		}
+		for _, c := range alphabet {
+			fill(prefix + string(c))
+		}
+	}
+	fill("")
+}
+
+func TestGenerator_AutoLengthScaling_FullNamespace(t *testing.T) {
+	const alphabet = "0123456789abcdef"
+	repo := NewMockRepository()
+	fillNamespace(t, repo, alphabet, 3)
+
+	fixed, err := NewGenerator(repo, GeneratorConfig{CodeLength: 3, MaxRetries: 3, Alphabet: alphabet})
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if _, err := fixed.GenerateUniqueShortCode(context.Background()); !errors.Is(err, ErrMaxRetriesExceeded) {
+		t.Fatalf("GenerateUniqueShortCode() without auto length error = %v, want ErrMaxRetriesExceeded", err)
+	}
+
+	scaling, err := NewGenerator(repo, GeneratorConfig{CodeLength: 3, MaxRetries: 3, Alphabet: alphabet, AutoLengthDensity: 0.5})
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if got := scaling.CodeLength(context.Background()); got != 4 {
+		t.Errorf("CodeLength() = %d, want 4", got)
+	}
+	code, err := scaling.GenerateUniqueShortCode(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateUniqueShortCode() with auto length error = %v", err)
+	}
+	if len(code) != 4 {
+		t.Errorf("GenerateUniqueShortCode() = %q, want a 4 character code", code)
+	}
+}
+
+func TestGenerator_AutoLengthScaling_Threshold(t *testing.T) {
+	const alphabet = "0123456789abcdef"
+	repo := NewMockRepository()
+	gen, err := NewGenerator(repo, GeneratorConfig{CodeLength: 3, MaxRetries: 3, Alphabet: alphabet, AutoLengthDensity: 0.25})
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	gen.autoLength.now = func() time.Time { return now }
+
+	tests := []struct {
+		name   string
+		stored int
+		want   int
+	}{
+		{name: "empty", stored: 0, want: 3},
+		{name: "just under a quarter of 16^3", stored: 1023, want: 3},
+		{name: "a quarter of 16^3", stored: 1024, want: 4},
+		{name: "a quarter of 16^4", stored: 16384, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := len(repo.urls); i < tt.stored; i++ {
+				code := fmt.Sprintf("c%d", i)
+				repo.urls[code] = &URL{ShortCode: code}
+			}
+			// Step past the refresh interval so the new count is seen
+			now = now.Add(DefaultAutoLengthRefresh)
+			if got := gen.CodeLength(context.Background()); got != tt.want {
+				t.Errorf("CodeLength() with %d stored = %d, want %d", tt.stored, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_AutoLengthScaling_CountsSoftDeleted(t *testing.T) {
+	const alphabet = "0123456789abcdef"
+	repo := NewMockRepository()
+	fillNamespace(t, repo, alphabet, 3)
+	deletedAt := time.Now()
+	for _, u := range repo.urls {
+		u.DeletedAt = &deletedAt
+	}
+
+	// Soft-deleted codes stay taken, so they still fill the namespace
+	gen, err := NewGenerator(repo, GeneratorConfig{CodeLength: 3, MaxRetries: 3, Alphabet: alphabet, AutoLengthDensity: 0.5})
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	if got := gen.CodeLength(context.Background()); got != 4 {
+		t.Errorf("CodeLength() with every code soft-deleted = %d, want 4", got)
+	}
+}
+
+func TestGenerator_AutoLengthScaling_CachesCount(t *testing.T) {
+	repo := &countingRepo{MockRepository: NewMockRepository()}
+	gen, err := NewGenerator(repo, GeneratorConfig{CodeLength: 3, MaxRetries: 3, Alphabet: "0123456789abcdef", AutoLengthDensity: 0.5})
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	gen.autoLength.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		if _, err := gen.GenerateUniqueShortCode(context.Background()); err != nil {
+			t.Fatalf("GenerateUniqueShortCode() error = %v", err)
+		}
+	}
+	if repo.counts != 1 {
+		t.Errorf("CountAll called %d times within the refresh interval, want 1", repo.counts)
+	}
+
+	now = now.Add(DefaultAutoLengthRefresh)
+	gen.CodeLength(context.Background())
+	if repo.counts != 2 {
+		t.Errorf("CountAll called %d times after the refresh interval, want 2", repo.counts)
+	}
+}
+
+func TestGenerator_AutoLengthScaling_CountError(t *testing.T) {
+	repo := &countingRepo{MockRepository: NewMockRepository(), countErr: errors.New("database unavailable")}
+	gen, err := NewGenerator(repo, GeneratorConfig{CodeLength: 6, MaxRetries: 3, AutoLengthDensity: 0.5})
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	code, err := gen.GenerateUniqueShortCode(context.Background())
+	if err != nil {
+		t.Fatalf("GenerateUniqueShortCode() error = %v", err)
+	}
+	if len(code) != 6 {
+		t.Errorf("GenerateUniqueShortCode() = %q, want the configured length when CountAll fails", code)
+	}
+}
+
 func TestGenerator_ShortenURL(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -573,6 +994,205 @@ func TestGenerator_ShortenURL_CreateError(t *testing.T) {
 	}
 }
 
+func TestGenerator_ShortenURLWithCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		originalURL string
+		shortCode   string
+		wantErr     error
+	}{
+		{name: "custom code", originalURL: "https://example.com", shortCode: "my-link"},
+		{name: "invalid code", originalURL: "https://example.com", shortCode: "no spaces", wantErr: ErrInvalidShortCode},
+		{name: "too short", originalURL: "https://example.com", shortCode: "ab", wantErr: ErrInvalidShortCode},
+		{name: "invalid URL", originalURL: "ftp://example.com", shortCode: "my-link", wantErr: ErrInvalidURLScheme},
+		{name: "taken code", originalURL: "https://example.com", shortCode: "taken", wantErr: ErrDuplicateShortCode},
+		{name: "reserved route", originalURL: "https://example.com", shortCode: "api", wantErr: ErrReservedShortCode},
+		{name: "reserved route any case", originalURL: "https://example.com", shortCode: "Health", wantErr: ErrReservedShortCode},
+		{name: "configured reserved code", originalURL: "https://example.com", shortCode: "promo", wantErr: ErrReservedShortCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepository()
+			repo.urls["taken"] = &URL{ShortCode: "taken", OriginalURL: "https://other.example", CreatedBy: "user2"}
+
+			config := DefaultGeneratorConfig()
+			config.ReservedCodes = []string{" Promo "}
+			gen, err := NewGenerator(repo, config)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+
+			u, err := gen.ShortenURLWithCode(context.Background(), tt.originalURL, tt.shortCode, "user1")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ShortenURLWithCode() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ShortenURLWithCode() error = %v", err)
+			}
+			if u.ShortCode != tt.shortCode {
+				t.Errorf("ShortCode = %q, want %q", u.ShortCode, tt.shortCode)
+			}
+			if _, ok := repo.urls[tt.shortCode]; !ok {
+				t.Error("expected URL to be persisted")
+			}
+		})
+	}
+}
+
+func TestGenerator_HostPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		allowed     []string
+		blocked     []string
+		originalURL string
+		wantErr     error
+	}{
+		{name: "no policy", originalURL: "https://anything.test/"},
+		{name: "allowlisted only", allowed: []string{"example.com"}, originalURL: "https://example.com/page"},
+		{name: "not on allowlist", allowed: []string{"example.com"}, originalURL: "https://other.com/", wantErr: ErrDestinationNotAllowed},
+		{name: "wildcard subdomain", allowed: []string{"*.example.com"}, originalURL: "https://docs.example.com/"},
+		{name: "blocklisted host", blocked: []string{"evil.com"}, originalURL: "https://evil.com/", wantErr: ErrDestinationNotAllowed},
+		{name: "blocklisted wildcard", blocked: []string{"*.evil.com"}, originalURL: "http://cdn.evil.com/x", wantErr: ErrDestinationNotAllowed},
+		{name: "invalid URL checked first", allowed: []string{"example.com"}, originalURL: "ftp://example.com", wantErr: ErrInvalidURLScheme},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepository()
+			config := DefaultGeneratorConfig()
+			config.AllowedHosts = tt.allowed
+			config.BlockedHosts = tt.blocked
+			gen, err := NewGenerator(repo, config)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+
+			_, err = gen.ShortenURL(context.Background(), tt.originalURL, "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ShortenURL() error = %v, want %v", err, tt.wantErr)
+			}
+
+			_, err = gen.ShortenURLWithCode(context.Background(), tt.originalURL, "custom", "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ShortenURLWithCode() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil && len(repo.urls) != 0 {
+				t.Errorf("expected nothing persisted, got %d URLs", len(repo.urls))
+			}
+		})
+	}
+}
+
+func TestGenerator_AllowedSchemes(t *testing.T) {
+	tests := []struct {
+		name        string
+		schemes     []string
+		originalURL string
+		wantErr     error
+	}{
+		{name: "default rejects ftp", originalURL: "ftp://files.example.com/pub", wantErr: ErrInvalidURLScheme},
+		{name: "default rejects mailto", originalURL: "mailto:someone@example.com", wantErr: ErrInvalidURLScheme},
+		{name: "default allows https", originalURL: "https://example.com"},
+		{name: "ftp allowed", schemes: []string{"http", "https", "ftp"}, originalURL: "ftp://files.example.com/pub"},
+		{name: "allowed schemes are case-insensitive", schemes: []string{"FTP"}, originalURL: "FTP://files.example.com/pub"},
+		{name: "mailto allowed without a host", schemes: []string{"mailto"}, originalURL: "mailto:someone@example.com"},
+		{name: "ftp still needs a host", schemes: []string{"ftp"}, originalURL: "ftp:///pub", wantErr: ErrMissingURLHost},
+		{name: "http dropped from the list", schemes: []string{"https"}, originalURL: "http://example.com", wantErr: ErrInvalidURLScheme},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepository()
+			config := DefaultGeneratorConfig()
+			config.AllowedSchemes = tt.schemes
+			gen, err := NewGenerator(repo, config)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+
+			_, err = gen.ShortenURL(context.Background(), tt.originalURL, "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ShortenURL() error = %v, want %v", err, tt.wantErr)
+			}
+
+			_, err = gen.ShortenURLWithCode(context.Background(), tt.originalURL, "custom", "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ShortenURLWithCode() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("NewURL keeps the defaults", func(t *testing.T) {
+		if _, err := NewURL("abc123", "ftp://files.example.com/pub", "user1"); !errors.Is(err, ErrInvalidURLScheme) {
+			t.Errorf("NewURL() error = %v, want %v", err, ErrInvalidURLScheme)
+		}
+	})
+
+	t.Run("rejects unsafe schemes", func(t *testing.T) {
+		for _, scheme := range []string{"javascript", "data", "not a scheme"} {
+			config := DefaultGeneratorConfig()
+			config.AllowedSchemes = []string{"https", scheme}
+			if _, err := NewGenerator(NewMockRepository(), config); !errors.Is(err, ErrInvalidURLScheme) {
+				t.Errorf("NewGenerator() with %q error = %v, want %v", scheme, err, ErrInvalidURLScheme)
+			}
+		}
+	})
+}
+
+func TestGenerator_NormalizesDestination(t *testing.T) {
+	tests := []struct {
+		name           string
+		stripFragments bool
+		originalURL    string
+		want           string
+		wantErr        error
+	}{
+		{name: "lowercases scheme and host", originalURL: " HTTPS://Example.COM/Path?Q=1 ", want: "https://example.com/Path?Q=1"},
+		{name: "keeps fragment by default", originalURL: "https://example.com/page#Section", want: "https://example.com/page#Section"},
+		{name: "strips fragment", stripFragments: true, originalURL: "https://example.com/page#section", want: "https://example.com/page"},
+		{name: "rejects control characters", originalURL: "https://example.com/\r\nLocation: x", wantErr: ErrInvalidOriginalURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepository()
+			config := DefaultGeneratorConfig()
+			config.StripFragments = tt.stripFragments
+			gen, err := NewGenerator(repo, config)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+
+			got, err := gen.ShortenURL(context.Background(), tt.originalURL, "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ShortenURL() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.urls) != 0 {
+					t.Errorf("expected nothing persisted, got %d URLs", len(repo.urls))
+				}
+				return
+			}
+			if got.OriginalURL != tt.want {
+				t.Errorf("ShortenURL() OriginalURL = %q, want %q", got.OriginalURL, tt.want)
+			}
+
+			withCode, err := gen.ShortenURLWithCode(context.Background(), tt.originalURL, "custom", "user1")
+			if err != nil {
+				t.Fatalf("ShortenURLWithCode() error = %v", err)
+			}
+			if withCode.OriginalURL != tt.want {
+				t.Errorf("ShortenURLWithCode() OriginalURL = %q, want %q", withCode.OriginalURL, tt.want)
+			}
+		})
+	}
+}
+
 // Benchmark tests
 func BenchmarkGenerator_GenerateShortCode(b *testing.B) {
 	repo := NewMockRepository()
@@ -0,0 +1,79 @@
+package url
+
+import (
+	"net/url"
+	"strings"
+)
+
+// HostPolicy restricts which destination hosts can be shortened. A pattern is
+// either a host ("example.com"), matching only that host, or a wildcard
+// ("*.example.com"), matching any subdomain of it but not the domain itself.
+// The zero value allows every host.
+type HostPolicy struct {
+	allowed []string
+	blocked []string
+}
+
+// NewHostPolicy creates a policy from allowed and blocked host patterns.
+// When allowed is non-empty, only hosts matching it can be shortened; blocked
+// hosts are always rejected, even if they are also allowed.
+func NewHostPolicy(allowed, blocked []string) HostPolicy {
+	return HostPolicy{allowed: normalizeHostPatterns(allowed), blocked: normalizeHostPatterns(blocked)}
+}
+
+// Allows reports whether host may be shortened. Matching is case-insensitive
+// and ignores a trailing dot.
+func (p HostPolicy) Allows(host string) bool {
+	host = normalizeHost(host)
+
+	for _, pattern := range p.blocked {
+		if matchHost(pattern, host) {
+			return false
+		}
+	}
+
+	if len(p.allowed) == 0 {
+		return true
+	}
+	for _, pattern := range p.allowed {
+		if matchHost(pattern, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns ErrDestinationNotAllowed unless the host of originalURL is
+// allowed. originalURL must already have passed ValidateOriginalURL.
+func (p HostPolicy) Check(originalURL string) error {
+	if len(p.allowed) == 0 && len(p.blocked) == 0 {
+		return nil
+	}
+
+	parsed, err := url.Parse(originalURL)
+	if err != nil || !p.Allows(parsed.Hostname()) {
+		return ErrDestinationNotAllowed
+	}
+	return nil
+}
+
+func matchHost(pattern, host string) bool {
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return host == pattern
+}
+
+func normalizeHostPatterns(patterns []string) []string {
+	var normalized []string
+	for _, pattern := range patterns {
+		if pattern = normalizeHost(pattern); pattern != "" {
+			normalized = append(normalized, pattern)
+		}
+	}
+	return normalized
+}
+
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
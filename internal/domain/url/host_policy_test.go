@@ -0,0 +1,54 @@
+package url
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHostPolicy_Allows(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		blocked []string
+		host    string
+		want    bool
+	}{
+		{name: "empty policy allows everything", host: "example.com", want: true},
+		{name: "allowlisted host", allowed: []string{"example.com"}, host: "example.com", want: true},
+		{name: "host not on allowlist", allowed: []string{"example.com"}, host: "other.com", want: false},
+		{name: "allowlist is exact without wildcard", allowed: []string{"example.com"}, host: "www.example.com", want: false},
+		{name: "wildcard matches subdomain", allowed: []string{"*.example.com"}, host: "www.example.com", want: true},
+		{name: "wildcard matches nested subdomain", allowed: []string{"*.example.com"}, host: "a.b.example.com", want: true},
+		{name: "wildcard does not match apex", allowed: []string{"*.example.com"}, host: "example.com", want: false},
+		{name: "wildcard does not match suffix lookalike", allowed: []string{"*.example.com"}, host: "badexample.com", want: false},
+		{name: "blocklisted host", blocked: []string{"evil.com"}, host: "evil.com", want: false},
+		{name: "host not on blocklist", blocked: []string{"evil.com"}, host: "example.com", want: true},
+		{name: "blocklisted subdomain wildcard", blocked: []string{"*.evil.com"}, host: "cdn.evil.com", want: false},
+		{name: "blocklist wins over allowlist", allowed: []string{"*.example.com"}, blocked: []string{"spam.example.com"}, host: "spam.example.com", want: false},
+		{name: "matching ignores case and trailing dot", allowed: []string{" Example.COM "}, host: "EXAMPLE.com.", want: true},
+		{name: "blank patterns ignored", allowed: []string{"", "  "}, host: "example.com", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewHostPolicy(tt.allowed, tt.blocked)
+			if got := p.Allows(tt.host); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostPolicy_Check(t *testing.T) {
+	p := NewHostPolicy([]string{"*.example.com"}, nil)
+
+	if err := p.Check("https://www.example.com:8443/path?q=1"); err != nil {
+		t.Errorf("Check() error = %v, want nil (port ignored)", err)
+	}
+	if err := p.Check("https://user@other.com/"); !errors.Is(err, ErrDestinationNotAllowed) {
+		t.Errorf("Check() error = %v, want %v", err, ErrDestinationNotAllowed)
+	}
+	if err := (HostPolicy{}).Check("https://anything.test/"); err != nil {
+		t.Errorf("zero HostPolicy Check() error = %v, want nil", err)
+	}
+}
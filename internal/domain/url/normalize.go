@@ -0,0 +1,66 @@
+package url
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// normalizeURL trims surrounding whitespace from originalURL and lowercases
+// its scheme and host, which are case-insensitive, so near-identical inputs
+// store the same destination. When stripFragment is set the fragment is
+// dropped. The path, query and userinfo are left exactly as given. URLs with
+// control characters (e.g. an embedded newline) are rejected.
+func normalizeURL(originalURL string, stripFragment bool) (string, error) {
+	s := strings.TrimSpace(originalURL)
+	if s == "" {
+		return "", ErrEmptyOriginalURL
+	}
+	if strings.IndexFunc(s, unicode.IsControl) >= 0 {
+		return "", fmt.Errorf("%w: contains control characters", ErrInvalidOriginalURL)
+	}
+
+	if stripFragment {
+		s, _, _ = strings.Cut(s, "#")
+	}
+
+	scheme, rest, ok := strings.Cut(s, ":")
+	if !ok || !isScheme(scheme) {
+		// Not an absolute URL; ValidateOriginalURL reports why
+		return s, nil
+	}
+
+	authority, ok := strings.CutPrefix(rest, "//")
+	if !ok {
+		// No host to fold, e.g. mailto:
+		return strings.ToLower(scheme) + ":" + rest, nil
+	}
+
+	end := strings.IndexAny(authority, "/?#")
+	if end < 0 {
+		end = len(authority)
+	}
+	authority, tail := authority[:end], authority[end:]
+
+	// Userinfo before the last "@" is case-sensitive
+	at := strings.LastIndex(authority, "@") + 1
+	authority = authority[:at] + strings.ToLower(authority[at:])
+
+	return strings.ToLower(scheme) + "://" + authority + tail, nil
+}
+
+// isScheme reports whether s is a syntactically valid URL scheme (RFC 3986)
+func isScheme(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case i > 0 && ('0' <= r && r <= '9' || r == '+' || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
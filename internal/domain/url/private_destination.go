@@ -0,0 +1,89 @@
+package url
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+// DefaultResolveTimeout bounds the DNS lookup made for each destination when
+// private destinations are blocked.
+const DefaultResolveTimeout = 2 * time.Second
+
+// Resolver looks up the IP addresses of a host. *net.Resolver satisfies it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// sharedAddressSpace is the RFC 6598 carrier-grade NAT range, which netip
+// doesn't treat as private but which routes only inside a provider's network
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// defaultResolver is used when private destinations are blocked without an explicit Resolver
+var defaultResolver Resolver = net.DefaultResolver
+
+// privateDestinationGuard rejects destinations that resolve to addresses on
+// the server's own network, so short links can't be used to reach internal
+// services (e.g. cloud metadata at 169.254.169.254) through anything that
+// follows them server-side.
+type privateDestinationGuard struct {
+	resolver Resolver
+	timeout  time.Duration
+}
+
+// check returns ErrPrivateDestination if the host of originalURL is, or
+// resolves to, a private address, and ErrUnresolvableDestination if it can't
+// be resolved. originalURL must already have passed ValidateOriginalURL.
+func (g privateDestinationGuard) check(ctx context.Context, originalURL string) error {
+	parsed, err := url.Parse(originalURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidOriginalURL, err)
+	}
+	host := parsed.Hostname()
+	if host == "" {
+		// Opaque URLs such as mailto: don't name a network destination
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if isPrivateAddr(addr) {
+			return ErrPrivateDestination
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+
+	addrs, err := g.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnresolvableDestination, err)
+	}
+	if len(addrs) == 0 {
+		return ErrUnresolvableDestination
+	}
+
+	// Any private answer is enough, since a client may get that one
+	for _, addr := range addrs {
+		if isPrivateAddr(addr) {
+			return ErrPrivateDestination
+		}
+	}
+	return nil
+}
+
+// isPrivateAddr reports whether addr is loopback, link-local, private
+// (RFC 1918, RFC 6598 shared address space or IPv6 unique local) or
+// unspecified. IPv4-mapped IPv6 addresses are checked as IPv4.
+func isPrivateAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsPrivate() ||
+		sharedAddressSpace.Contains(addr) ||
+		addr.IsUnspecified()
+}
@@ -0,0 +1,134 @@
+package url
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+// fakeResolver maps hostnames to fixed addresses; unknown hosts fail to resolve
+type fakeResolver struct {
+	hosts map[string][]string
+	calls []string
+}
+
+func (r *fakeResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	r.calls = append(r.calls, host)
+	raw, ok := r.hosts[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	addrs := make([]netip.Addr, 0, len(raw))
+	for _, a := range raw {
+		addrs = append(addrs, netip.MustParseAddr(a))
+	}
+	return addrs, nil
+}
+
+func TestGenerator_BlockPrivateDestinations(t *testing.T) {
+	resolver := &fakeResolver{hosts: map[string][]string{
+		"public.example":   {"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"},
+		"internal.example": {"10.1.2.3"},
+		"mixed.example":    {"93.184.216.34", "192.168.1.10"},
+		"metadata.example": {"169.254.169.254"},
+		"loopback.example": {"::1"},
+		"ula.example":      {"fd12:3456::1"},
+		"cgnat.example":    {"100.100.1.2"},
+		"mapped.example":   {"::ffff:127.0.0.1"},
+		"empty.example":    {},
+	}}
+
+	tests := []struct {
+		name        string
+		originalURL string
+		wantErr     error
+	}{
+		{name: "public host", originalURL: "https://public.example/page"},
+		{name: "public IP literal", originalURL: "http://93.184.216.34/"},
+		{name: "RFC 1918 host", originalURL: "https://internal.example/", wantErr: ErrPrivateDestination},
+		{name: "any private answer", originalURL: "https://mixed.example/", wantErr: ErrPrivateDestination},
+		{name: "link-local host", originalURL: "http://metadata.example/latest", wantErr: ErrPrivateDestination},
+		{name: "IPv6 loopback host", originalURL: "http://loopback.example/", wantErr: ErrPrivateDestination},
+		{name: "IPv6 unique local host", originalURL: "http://ula.example/", wantErr: ErrPrivateDestination},
+		{name: "CGNAT host", originalURL: "http://cgnat.example/", wantErr: ErrPrivateDestination},
+		{name: "CGNAT IP literal", originalURL: "http://100.64.0.1/", wantErr: ErrPrivateDestination},
+		{name: "just outside CGNAT", originalURL: "http://100.128.0.1/"},
+		{name: "IPv4-mapped loopback", originalURL: "http://mapped.example/", wantErr: ErrPrivateDestination},
+		{name: "metadata IP literal", originalURL: "http://169.254.169.254/latest/meta-data", wantErr: ErrPrivateDestination},
+		{name: "loopback IP literal", originalURL: "http://127.0.0.1:8080/", wantErr: ErrPrivateDestination},
+		{name: "IPv6 loopback literal", originalURL: "http://[::1]/", wantErr: ErrPrivateDestination},
+		{name: "unspecified IP literal", originalURL: "http://0.0.0.0/", wantErr: ErrPrivateDestination},
+		{name: "unresolvable host", originalURL: "https://nowhere.example/", wantErr: ErrUnresolvableDestination},
+		{name: "no addresses", originalURL: "https://empty.example/", wantErr: ErrUnresolvableDestination},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepository()
+			config := DefaultGeneratorConfig()
+			config.BlockPrivateDestinations = true
+			config.Resolver = resolver
+			gen, err := NewGenerator(repo, config)
+			if err != nil {
+				t.Fatalf("NewGenerator() error = %v", err)
+			}
+
+			_, err = gen.ShortenURL(context.Background(), tt.originalURL, "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ShortenURL() error = %v, want %v", err, tt.wantErr)
+			}
+
+			_, err = gen.ShortenURLWithCode(context.Background(), tt.originalURL, "custom", "user1")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ShortenURLWithCode() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerator_BlockPrivateDestinations_Disabled(t *testing.T) {
+	resolver := &fakeResolver{}
+	config := DefaultGeneratorConfig()
+	config.Resolver = resolver
+	gen, err := NewGenerator(NewMockRepository(), config)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	if _, err := gen.ShortenURL(context.Background(), "http://localhost/", "user1"); err != nil {
+		t.Fatalf("ShortenURL() error = %v, want nil when the guard is off", err)
+	}
+	if len(resolver.calls) != 0 {
+		t.Errorf("expected no DNS lookups, got %v", resolver.calls)
+	}
+}
+
+// slowResolver blocks until the lookup context is done
+type slowResolver struct{}
+
+func (slowResolver) LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestGenerator_BlockPrivateDestinations_ResolveTimeout(t *testing.T) {
+	config := DefaultGeneratorConfig()
+	config.BlockPrivateDestinations = true
+	config.Resolver = slowResolver{}
+	config.ResolveTimeout = 10 * time.Millisecond
+	gen, err := NewGenerator(NewMockRepository(), config)
+	if err != nil {
+		t.Fatalf("NewGenerator() error = %v", err)
+	}
+
+	start := time.Now()
+	_, err = gen.ShortenURL(context.Background(), "https://slow.example/", "user1")
+	if !errors.Is(err, ErrUnresolvableDestination) {
+		t.Fatalf("ShortenURL() error = %v, want %v", err, ErrUnresolvableDestination)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("lookup took %v, expected the resolve timeout to cut it short", elapsed)
+	}
+}
@@ -13,24 +13,58 @@ type Repository interface {
 	// Returns ErrDuplicateShortCode if the short code already exists
 	Create(ctx context.Context, url *URL) error
 
-	// FindByShortCode retrieves a URL by its short code
+	// FindByShortCode retrieves a URL by its short code, including soft-deleted URLs
+	// (check URL.IsDeleted)
 	// Returns ErrURLNotFound if the URL doesn't exist
 	FindByShortCode(ctx context.Context, shortCode string) (*URL, error)
 
+	// FindByOriginalURL retrieves the oldest live URL created by createdBy that points at originalURL
+	// Returns ErrURLNotFound if there is none
+	FindByOriginalURL(ctx context.Context, createdBy, originalURL string) (*URL, error)
+
 	// Delete removes a URL by its short code
 	// Returns ErrURLNotFound if the URL doesn't exist
 	Delete(ctx context.Context, shortCode string) error
 
-	// List retrieves URLs with optional filtering and pagination
+	// DeleteByShortCodePrefix removes every URL created by createdBy whose short code
+	// starts with prefix, and returns the removed short codes in sorted order
+	// Returns ErrEmptyShortCodePrefix if prefix is empty
+	DeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string) ([]string, error)
+
+	// SoftDelete marks a URL as deleted at deletedAt, keeping the row and its clicks
+	// Returns ErrURLNotFound if no live URL has the short code
+	SoftDelete(ctx context.Context, shortCode string, deletedAt time.Time) error
+
+	// SoftDeleteByShortCodePrefix marks every live URL created by createdBy whose short code
+	// starts with prefix as deleted, and returns the marked short codes in sorted order
+	// Returns ErrEmptyShortCodePrefix if prefix is empty
+	SoftDeleteByShortCodePrefix(ctx context.Context, createdBy, prefix string, deletedAt time.Time) ([]string, error)
+
+	// Restore clears the soft-delete marker on a URL; restoring a live URL is a no-op
+	// Returns ErrURLNotFound if the URL doesn't exist
+	Restore(ctx context.Context, shortCode string) error
+
+	// List retrieves live URLs with optional filtering and pagination
 	// createdBy: filter by creator (empty string means no filter)
 	// limit: maximum number of results to return (0 means no limit)
 	// offset: number of results to skip
 	List(ctx context.Context, createdBy string, limit, offset int) ([]*URL, error)
 
-	// ListByCreatedByAndTimeRange retrieves URLs created by a specific user within a time range
+	// Search retrieves live URLs whose original URL or short code contains query,
+	// ignoring ASCII case, with the same creator filter and pagination as List
+	Search(ctx context.Context, createdBy, query string, limit, offset int) ([]*URL, error)
+
+	// ListByCreatedByAndTimeRange retrieves live URLs created by a specific user within a time range
 	ListByCreatedByAndTimeRange(ctx context.Context, createdBy string, startTime, endTime time.Time) ([]*URL, error)
 
-	// Count returns the total count of URLs for a specific user
+	// Count returns the total count of live URLs for a specific user
 	// createdBy: filter by creator (empty string returns count of all URLs)
 	Count(ctx context.Context, createdBy string) (int, error)
+
+	// CountAll returns the count of all stored URLs across users, soft-deleted ones
+	// included, since their short codes stay taken
+	CountAll(ctx context.Context) (int, error)
+
+	// CountMatching returns the total count of live URLs Search matches across all pages
+	CountMatching(ctx context.Context, createdBy, query string) (int, error)
 }
@@ -0,0 +1,66 @@
+package url
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DefaultAllowedSchemes are the destination schemes accepted when none are configured
+var DefaultAllowedSchemes = []string{"http", "https"}
+
+// unsafeSchemes run script or expose local content when followed from a
+// dashboard link, so they can never be allowed
+var unsafeSchemes = []string{"data", "file", "javascript", "vbscript"}
+
+// defaultSchemes is DefaultAllowedSchemes as a set, used by NewURL and ValidateOriginalURL
+var defaultSchemes = schemeSet{"http": {}, "https": {}}
+
+// ValidateAllowedScheme returns ErrInvalidURLScheme if scheme isn't a
+// syntactically valid URL scheme or is one that can never be allowed
+func ValidateAllowedScheme(scheme string) error {
+	scheme = strings.ToLower(strings.TrimSpace(scheme))
+	if !isScheme(scheme) {
+		return fmt.Errorf("%w: %q is not a valid scheme", ErrInvalidURLScheme, scheme)
+	}
+	if slices.Contains(unsafeSchemes, scheme) {
+		return fmt.Errorf("%w: %q is unsafe", ErrInvalidURLScheme, scheme)
+	}
+	return nil
+}
+
+// schemeSet holds lowercase destination schemes
+type schemeSet map[string]struct{}
+
+// newSchemeSet builds a set from schemes, or the defaults when it is empty
+func newSchemeSet(schemes []string) (schemeSet, error) {
+	set := make(schemeSet, len(schemes))
+	for _, scheme := range schemes {
+		if strings.TrimSpace(scheme) == "" {
+			continue
+		}
+		if err := ValidateAllowedScheme(scheme); err != nil {
+			return nil, err
+		}
+		set[strings.ToLower(strings.TrimSpace(scheme))] = struct{}{}
+	}
+	if len(set) == 0 {
+		return defaultSchemes, nil
+	}
+	return set, nil
+}
+
+func (s schemeSet) contains(scheme string) bool {
+	_, ok := s[scheme]
+	return ok
+}
+
+// String lists the schemes in order, e.g. "http, https"
+func (s schemeSet) String() string {
+	schemes := make([]string, 0, len(s))
+	for scheme := range s {
+		schemes = append(schemes, scheme)
+	}
+	slices.Sort(schemes)
+	return strings.Join(schemes, ", ")
+}
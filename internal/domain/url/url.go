@@ -15,15 +15,29 @@ type URL struct {
 	OriginalURL string
 	CreatedAt   time.Time
 	CreatedBy   string
+	DeletedAt   *time.Time // Set when the URL has been soft-deleted
 }
 
 var (
 	// shortCodeRegex validates short codes: alphanumeric characters, underscores, hyphens, 3-20 characters
 	shortCodeRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{3,20}$`)
+
+	// shortCodePrefixRegex validates short code prefixes: the short code alphabet, 1-20 characters
+	shortCodePrefixRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,20}$`)
 )
 
-// NewURL creates a new URL with validation
+// NewURL creates a new URL with validation. Only http and https destinations
+// are accepted; a Generator can be configured to allow other schemes.
 func NewURL(shortCode, originalURL, createdBy string) (*URL, error) {
+	return newURL(shortCode, originalURL, createdBy, defaultSchemes)
+}
+
+func newURL(shortCode, originalURL, createdBy string, schemes schemeSet) (*URL, error) {
+	originalURL, err := normalizeURL(originalURL, false)
+	if err != nil {
+		return nil, err
+	}
+
 	u := &URL{
 		ShortCode:   shortCode,
 		OriginalURL: originalURL,
@@ -31,20 +45,24 @@ func NewURL(shortCode, originalURL, createdBy string) (*URL, error) {
 		CreatedAt:   time.Now(),
 	}
 
-	if err := u.Validate(); err != nil {
+	if err := u.validate(schemes); err != nil {
 		return nil, err
 	}
 
 	return u, nil
 }
 
-// Validate validates the URL entity
+// Validate validates the URL entity, accepting only http and https destinations
 func (u *URL) Validate() error {
+	return u.validate(defaultSchemes)
+}
+
+func (u *URL) validate(schemes schemeSet) error {
 	if err := ValidateShortCode(u.ShortCode); err != nil {
 		return err
 	}
 
-	if err := ValidateOriginalURL(u.OriginalURL); err != nil {
+	if err := validateOriginalURL(u.OriginalURL, schemes); err != nil {
 		return err
 	}
 
@@ -55,6 +73,11 @@ func (u *URL) Validate() error {
 	return nil
 }
 
+// IsDeleted reports whether the URL has been soft-deleted
+func (u *URL) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
 // ValidateShortCode validates a short code format
 func ValidateShortCode(shortCode string) error {
 	if shortCode == "" {
@@ -68,8 +91,25 @@ func ValidateShortCode(shortCode string) error {
 	return nil
 }
 
-// ValidateOriginalURL validates an original URL
+// ValidateShortCodePrefix validates a prefix used to match short codes in bulk operations
+func ValidateShortCodePrefix(prefix string) error {
+	if prefix == "" {
+		return ErrEmptyShortCodePrefix
+	}
+
+	if !shortCodePrefixRegex.MatchString(prefix) {
+		return ErrInvalidShortCodePrefix
+	}
+
+	return nil
+}
+
+// ValidateOriginalURL validates an original URL with an http or https scheme
 func ValidateOriginalURL(originalURL string) error {
+	return validateOriginalURL(originalURL, defaultSchemes)
+}
+
+func validateOriginalURL(originalURL string, schemes schemeSet) error {
 	if originalURL == "" {
 		return ErrEmptyOriginalURL
 	}
@@ -84,13 +124,13 @@ func ValidateOriginalURL(originalURL string) error {
 		return ErrMissingURLScheme
 	}
 
-	// Only allow http and https schemes
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-		return ErrInvalidURLScheme
+	if !schemes.contains(parsedURL.Scheme) {
+		return fmt.Errorf("%w (allowed: %s)", ErrInvalidURLScheme, schemes)
 	}
 
-	// URL must have a host
-	if parsedURL.Host == "" {
+	// Web URLs must have a host; other schemes may be opaque, e.g. mailto:someone@example.com
+	isWeb := parsedURL.Scheme == "http" || parsedURL.Scheme == "https"
+	if parsedURL.Host == "" && (isWeb || parsedURL.Opaque == "") {
 		return ErrMissingURLHost
 	}
 
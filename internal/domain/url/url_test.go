@@ -244,6 +244,54 @@ func TestValidateShortCode(t *testing.T) {
 	}
 }
 
+func TestValidateShortCodePrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		wantErr error
+	}{
+		{
+			name:    "valid single character",
+			prefix:  "a",
+			wantErr: nil,
+		},
+		{
+			name:    "valid with hyphen",
+			prefix:  "launch-",
+			wantErr: nil,
+		},
+		{
+			name:    "empty",
+			prefix:  "",
+			wantErr: ErrEmptyShortCodePrefix,
+		},
+		{
+			name:    "too long",
+			prefix:  "123456789012345678901",
+			wantErr: ErrInvalidShortCodePrefix,
+		},
+		{
+			name:    "with glob wildcard",
+			prefix:  "abc*",
+			wantErr: ErrInvalidShortCodePrefix,
+		},
+		{
+			name:    "with like wildcard",
+			prefix:  "abc%",
+			wantErr: ErrInvalidShortCodePrefix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateShortCodePrefix(tt.prefix)
+			if err != tt.wantErr {
+				t.Errorf("ValidateShortCodePrefix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestValidateOriginalURL(t *testing.T) {
 	tests := []struct {
 		name        string
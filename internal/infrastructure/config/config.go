@@ -2,26 +2,46 @@ package config
 
 import (
 	"fmt"
+	"net"
+	neturl "net/url"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/database"
 )
 
+// DefaultRobotsTxt asks every crawler to stay away: short links only lead elsewhere.
+const DefaultRobotsTxt = "User-agent: *\nDisallow: /\n"
+
 // Config holds all configuration for the application
 type Config struct {
 	// Database configuration
 	DatabaseURL string
+	AutoMigrate bool // Apply pending migrations when the server starts (default: false)
 
 	// Server configuration
 	ServerPort int
 	BaseURL    string
 
+	// MaxRequestBodyBytes caps request bodies; larger ones get 413 (MAX_REQUEST_BODY_BYTES, default: 65536)
+	MaxRequestBodyBytes int
+
+	// ValidateOpenAPI checks /api requests against openapi.yaml before they reach handlers (VALIDATE_OPENAPI, default: false)
+	ValidateOpenAPI bool
+
 	// CORS configuration
 	AllowedOrigins string
 
+	// Empty AllowedMethods/AllowedHeaders use the CORS middleware's defaults
+	AllowedMethods   []string // Methods cross-origin requests may use (ALLOWED_METHODS)
+	AllowedHeaders   []string // Request headers cross-origin requests may send (ALLOWED_HEADERS)
+	AllowCredentials bool     // Let browsers send cookies on cross-origin requests (ALLOW_CREDENTIALS, default: false)
+
 	// Authentication
 	// AuthToken is kept for backward compatibility (legacy AUTH_TOKEN).
 	// Prefer AuthTokens/ActiveAuthTokens for validation.
@@ -34,13 +54,55 @@ type Config struct {
 	// Rate limiting configuration
 	RedirectRateLimitPerMinute int
 	APIRateLimitPerMinute      int
+	// RateLimitAllowlist holds client networks that bypass rate limiting (RATE_LIMIT_ALLOWLIST)
+	RateLimitAllowlist []*net.IPNet
+	// RateLimitBackend is memory (per instance, default) or redis (shared across instances)
+	RateLimitBackend string
+	// RedisURL is the redis:// or rediss:// URL used when RateLimitBackend is redis
+	RedisURL string
 
 	// Redirect click recording configuration
 	RedirectClickWorkers   int // Worker goroutines for async click recording (default: 100)
 	RedirectClickQueueSize int // Queue size for async click recording (default: RedirectClickWorkers*2)
+	// RedirectClickBackpressurePolicy is drop or block-with-timeout (default: drop)
+	RedirectClickBackpressurePolicy string
+	// RedirectClickEnqueueTimeout bounds the wait for queue space under block-with-timeout (default: 50ms)
+	RedirectClickEnqueueTimeout time.Duration
+	// RedirectClickRetryAttempts is the total attempts per click record, including the first (default: 3; 1 disables retries)
+	RedirectClickRetryAttempts int
+	// RedirectClickRetryDelay is the wait before the first retry; it doubles for each retry after that (default: 100ms)
+	RedirectClickRetryDelay time.Duration
+	// ClickDedupeWindow skips a click identical (short code, client, user agent) to one recorded within it (default: 0, disabled)
+	ClickDedupeWindow time.Duration
+	// FilterBotClicks skips recording clicks from crawler user agents such as Googlebot (default: false)
+	FilterBotClicks bool
 
-	// Discord webhook configuration
+	// Notifications: NotifierKind selects discord (default), slack or none
+	NotifierKind      string
 	DiscordWebhookURL string
+	SlackWebhookURL   string
+	// NotFoundAlertThreshold sends a Discord alert when this many redirects for unknown
+	// short codes arrive within NotFoundAlertWindow (default: 0, disabled)
+	NotFoundAlertThreshold int
+	NotFoundAlertWindow    time.Duration // Window for NotFoundAlertThreshold (default: 5m)
+
+	// NotFoundRedirectURL is where unknown short codes are redirected (302) instead of a 404 page (default: empty, 404)
+	NotFoundRedirectURL string
+
+	// RobotsTxt is served at /robots.txt (ROBOTS_TXT, default: disallow everything)
+	RobotsTxt string
+	// DiscordCreateWebhookURL / SlackCreateWebhookURL receive a post for every newly
+	// created URL; separate from the error webhook (default: disabled)
+	DiscordCreateWebhookURL string
+	SlackCreateWebhookURL   string
+	// EventWebhookURL receives a signed JSON event (e.g. url.created) for integrations (default: disabled)
+	EventWebhookURL string
+	// EventWebhookSecret signs event bodies with HMAC-SHA256 in X-Mjrwtf-Signature (default: unsigned)
+	EventWebhookSecret string
+	// NotifyEvents lists the notification events to send: panic, created, 404-spike (default: all)
+	NotifyEvents []string
+	// NotifyCreateFullURL shows the full destination in created-URL posts instead of only its domain (default: false)
+	NotifyCreateFullURL bool
 
 	// GeoIP configuration
 	GeoIPEnabled  bool
@@ -50,15 +112,91 @@ type Config struct {
 	LogLevel  string // debug, info, warn, error (default: info)
 	LogFormat string // json, pretty (default: json)
 
+	// LogRedirectSampleRate is the fraction of successful redirects written to the access log, 0.0-1.0 (default: 1.0).
+	// Redirects that fail or 404 are always logged.
+	LogRedirectSampleRate float64
+
+	// AccessLogPath sends access log lines to this file ("-" for stdout) instead of the main logger (default: empty)
+	AccessLogPath string
+
 	// Metrics configuration
 	MetricsAuthEnabled bool // Enable authentication for /metrics endpoint (default: false)
 
+	// Profiling configuration
+	PprofEnabled bool // Serve net/http/pprof at /debug/pprof/, behind the auth token (default: false)
+
+	// Tracing configuration
+	OTelEnabled          bool   // Export OpenTelemetry traces over OTLP/HTTP (default: false)
+	OTelExporterEndpoint string // OTLP/HTTP collector base URL, e.g. http://localhost:4318 (required when OTelEnabled)
+	OTelServiceName      string // service.name reported on spans (default: mjrwtf)
+
 	// Security headers configuration
 	EnableHSTS bool // Enable Strict-Transport-Security header (default: false, only enable when behind TLS)
 
+	HSTSMaxAge            int    // Strict-Transport-Security max-age in seconds (HSTS_MAX_AGE, default: 31536000)
+	ContentSecurityPolicy string // Replaces the baseline Content-Security-Policy when set (CONTENT_SECURITY_POLICY)
+	FrameOptions          string // X-Frame-Options: DENY or SAMEORIGIN (FRAME_OPTIONS, default: DENY)
+
+	// Reverse proxy configuration
+	TrustProxyHeaders bool // Trust X-Forwarded-For/X-Real-IP for client IPs (default: false, only enable behind a proxy)
+	// TrustedProxies holds the reverse proxies' networks, skipped when walking X-Forwarded-For (TRUSTED_PROXIES)
+	TrustedProxies []*net.IPNet
+
 	// Database operation timeout configuration
 	DBTimeout time.Duration // Timeout for database operations (default: 5s)
 
+	// Database connection pool configuration
+	DBMaxOpenConns    int           // Maximum open connections (default: 1, SQLite serializes writes)
+	DBMaxIdleConns    int           // Maximum idle connections kept for reuse (default: DBMaxOpenConns)
+	DBConnMaxLifetime time.Duration // Maximum time a connection is reused (default: 0, no limit)
+
+	// Short code lookup cache configuration
+	URLCacheSize int           // Short codes kept in the in-memory lookup cache (default: 0, disabled)
+	URLCacheTTL  time.Duration // How long a cached lookup is served before it is re-read (default: 1m)
+
+	// Analytics configuration
+	AnalyticsTopN int // Referrers returned per analytics breakdown when ?top is not given (default: 10, max: 100)
+
+	ClickRetentionDays int // Delete clicks older than this many days in the background (default: 0, keep forever)
+
+	UniqueVisitorsEnabled bool   // Store a salted client IP hash per click and report unique visitors (default: false)
+	VisitorHashSalt       string // HMAC key for visitor hashes; required when UniqueVisitorsEnabled
+
+	// Short code configuration
+	ReservedCodes      []string      // Extra short codes to reserve on top of the server's route names (RESERVED_CODES)
+	DedupeDestinations bool          // Return the caller's existing URL when they shorten the same destination again (default: false)
+	IdempotencyKeyTTL  time.Duration // How long an Idempotency-Key on POST /api/urls replays its first result (default: 24h)
+
+	// ShortCodeAlphabet is the characters generated short codes are drawn from: "base62",
+	// "unambiguous" or a literal set of at least 16 distinct characters (SHORTCODE_ALPHABET, default: base62)
+	ShortCodeAlphabet string
+
+	// ShortCodeAutoLengthDensity, when above 0, makes generated codes one character longer
+	// each time stored URLs fill this fraction of the codes at the current length
+	// (SHORTCODE_AUTO_LENGTH_DENSITY, default: 0, disabled)
+	ShortCodeAutoLengthDensity float64
+	ShortCodeAutoLengthRefresh time.Duration // How long the stored URL count is cached (SHORTCODE_AUTO_LENGTH_REFRESH, default: 1m)
+
+	// AllowedURLSchemes are the destination schemes that can be shortened (ALLOWED_URL_SCHEMES, default: http,https)
+	AllowedURLSchemes []string
+
+	// Destination host restrictions; "*.example.com" matches subdomains (default: empty, every host allowed)
+	AllowedDestinationHosts []string // Only these hosts can be shortened (ALLOWED_DESTINATION_HOSTS)
+	BlockedDestinationHosts []string // These hosts can never be shortened, even if allowed (BLOCKED_DESTINATION_HOSTS)
+	// BlockPrivateDestinations rejects destinations resolving to loopback, link-local or private addresses (default: false)
+	BlockPrivateDestinations  bool
+	DestinationResolveTimeout time.Duration // DNS lookup timeout for BlockPrivateDestinations (default: 2s)
+	// StripURLFragments drops the "#fragment" from destinations before they are stored (default: false)
+	StripURLFragments bool
+
+	// URL deletion configuration
+	SoftDeleteEnabled bool // Mark deleted URLs with deleted_at instead of removing them, so they can be restored (default: false)
+
+	// Session configuration
+	SessionDuration        time.Duration // How long a session stays valid after its last activity (default: 24h)
+	SessionIdleTimeout     time.Duration // Revoke sessions idle for longer than this (default: 0, disabled)
+	SessionCleanupInterval time.Duration // How often expired/idle sessions are pruned (default: 15m)
+
 	// URL status checker configuration
 	URLStatusCheckerEnabled                bool
 	URLStatusCheckerPollInterval           time.Duration
@@ -88,6 +226,14 @@ func LoadConfig() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	maxRequestBodyBytes, err := getEnvAsInt("MAX_REQUEST_BODY_BYTES", 64<<10)
+	if err != nil {
+		return nil, err
+	}
+	validateOpenAPI, err := getEnvAsBool("VALIDATE_OPENAPI", false)
+	if err != nil {
+		return nil, err
+	}
 	secureCookies, err := getEnvAsBool("SECURE_COOKIES", false)
 	if err != nil {
 		return nil, err
@@ -108,22 +254,154 @@ func LoadConfig() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	redirectClickEnqueueTimeout, err := getEnvAsDuration("REDIRECT_CLICK_ENQUEUE_TIMEOUT", 50*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	redirectClickRetryAttempts, err := getEnvAsInt("REDIRECT_CLICK_RETRY_ATTEMPTS", 3)
+	if err != nil {
+		return nil, err
+	}
+	redirectClickRetryDelay, err := getEnvAsDuration("REDIRECT_CLICK_RETRY_DELAY", 100*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	clickDedupeWindow, err := getEnvAsDuration("CLICK_DEDUPE_WINDOW", 0)
+	if err != nil {
+		return nil, err
+	}
+	filterBotClicks, err := getEnvAsBool("FILTER_BOT_CLICKS", false)
+	if err != nil {
+		return nil, err
+	}
 	geoIPEnabled, err := getEnvAsBool("GEOIP_ENABLED", false)
 	if err != nil {
 		return nil, err
 	}
+	uniqueVisitorsEnabled, err := getEnvAsBool("UNIQUE_VISITORS_ENABLED", false)
+	if err != nil {
+		return nil, err
+	}
+	dedupeDestinations, err := getEnvAsBool("DEDUPE_DESTINATIONS", false)
+	if err != nil {
+		return nil, err
+	}
+	idempotencyKeyTTL, err := getEnvAsDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	softDeleteEnabled, err := getEnvAsBool("SOFT_DELETE_ENABLED", false)
+	if err != nil {
+		return nil, err
+	}
+	notifyCreateFullURL, err := getEnvAsBool("NOTIFY_CREATE_FULL_URL", false)
+	if err != nil {
+		return nil, err
+	}
+	notFoundAlertThreshold, err := getEnvAsInt("NOT_FOUND_ALERT_THRESHOLD", 0)
+	if err != nil {
+		return nil, err
+	}
+	notFoundAlertWindow, err := getEnvAsDuration("NOT_FOUND_ALERT_WINDOW", 5*time.Minute)
+	if err != nil {
+		return nil, err
+	}
 	metricsAuthEnabled, err := getEnvAsBool("METRICS_AUTH_ENABLED", false)
 	if err != nil {
 		return nil, err
 	}
+	pprofEnabled, err := getEnvAsBool("PPROF_ENABLED", false)
+	if err != nil {
+		return nil, err
+	}
+	otelEnabled, err := getEnvAsBool("OTEL_ENABLED", false)
+	if err != nil {
+		return nil, err
+	}
 	enableHSTS, err := getEnvAsBool("ENABLE_HSTS", false)
 	if err != nil {
 		return nil, err
 	}
+	trustProxyHeaders, err := getEnvAsBool("TRUST_PROXY_HEADERS", false)
+	if err != nil {
+		return nil, err
+	}
+	trustedProxies, err := getEnvAsCIDRs("TRUSTED_PROXIES")
+	if err != nil {
+		return nil, err
+	}
+	hstsMaxAge, err := getEnvAsInt("HSTS_MAX_AGE", 31536000)
+	if err != nil {
+		return nil, err
+	}
+	rateLimitAllowlist, err := getEnvAsCIDRs("RATE_LIMIT_ALLOWLIST")
+	if err != nil {
+		return nil, err
+	}
 	dbTimeout, err := getEnvAsDuration("DB_TIMEOUT", 5*time.Second)
 	if err != nil {
 		return nil, err
 	}
+	blockPrivateDestinations, err := getEnvAsBool("BLOCK_PRIVATE_DESTINATIONS", false)
+	if err != nil {
+		return nil, err
+	}
+	destinationResolveTimeout, err := getEnvAsDuration("DESTINATION_RESOLVE_TIMEOUT", 2*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	allowedURLSchemes := getEnvAsList("ALLOWED_URL_SCHEMES")
+	if len(allowedURLSchemes) == 0 {
+		allowedURLSchemes = slices.Clone(url.DefaultAllowedSchemes)
+	}
+	allowCredentials, err := getEnvAsBool("ALLOW_CREDENTIALS", false)
+	if err != nil {
+		return nil, err
+	}
+	allowedMethods := getEnvAsList("ALLOWED_METHODS")
+	for i, method := range allowedMethods {
+		allowedMethods[i] = strings.ToUpper(method)
+	}
+	stripURLFragments, err := getEnvAsBool("STRIP_URL_FRAGMENTS", false)
+	if err != nil {
+		return nil, err
+	}
+	autoMigrate, err := getEnvAsBool("AUTO_MIGRATE", false)
+	if err != nil {
+		return nil, err
+	}
+	dbMaxOpenConns, err := getEnvAsInt("DB_MAX_OPEN_CONNS", database.SQLiteMaxOpenConns)
+	if err != nil {
+		return nil, err
+	}
+	dbMaxIdleConns, err := getEnvAsInt("DB_MAX_IDLE_CONNS", dbMaxOpenConns)
+	if err != nil {
+		return nil, err
+	}
+	dbConnMaxLifetime, err := getEnvAsDuration("DB_CONN_MAX_LIFETIME", 0)
+	if err != nil {
+		return nil, err
+	}
+	urlCacheSize, err := getEnvAsInt("URL_CACHE_SIZE", 0)
+	if err != nil {
+		return nil, err
+	}
+	urlCacheTTL, err := getEnvAsDuration("URL_CACHE_TTL", time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	sessionDuration, err := getEnvAsDuration("SESSION_DURATION", 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	sessionIdleTimeout, err := getEnvAsDuration("SESSION_IDLE_TIMEOUT", 0)
+	if err != nil {
+		return nil, err
+	}
+	sessionCleanupInterval, err := getEnvAsDuration("SESSION_CLEANUP_INTERVAL", 15*time.Minute)
+	if err != nil {
+		return nil, err
+	}
 
 	urlStatusCheckerEnabled, err := getEnvAsBool("URL_STATUS_CHECKER_ENABLED", false)
 	if err != nil {
@@ -168,6 +446,31 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	logRedirectSampleRate, err := getEnvAsFloat("LOG_REDIRECT_SAMPLE_RATE", 1.0)
+	if err != nil {
+		return nil, err
+	}
+
+	shortCodeAutoLengthDensity, err := getEnvAsFloat("SHORTCODE_AUTO_LENGTH_DENSITY", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	shortCodeAutoLengthRefresh, err := getEnvAsDuration("SHORTCODE_AUTO_LENGTH_REFRESH", time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	analyticsTopN, err := getEnvAsInt("ANALYTICS_TOP_N", 10)
+	if err != nil {
+		return nil, err
+	}
+
+	clickRetentionDays, err := getEnvAsInt("CLICK_RETENTION_DAYS", 0)
+	if err != nil {
+		return nil, err
+	}
+
 	authTokens, err := getEnvAuthTokens()
 	if err != nil {
 		return nil, err
@@ -175,6 +478,7 @@ func LoadConfig() (*Config, error) {
 
 	config := &Config{
 		DatabaseURL:                getEnv("DATABASE_URL", ""),
+		AutoMigrate:                autoMigrate,
 		ServerPort:                 serverPort,
 		BaseURL:                    getEnv("BASE_URL", "http://localhost:8080"),
 		AllowedOrigins:             getEnv("ALLOWED_ORIGINS", "*"),
@@ -191,8 +495,94 @@ func LoadConfig() (*Config, error) {
 		LogLevel:                   getEnv("LOG_LEVEL", "info"),
 		LogFormat:                  getEnv("LOG_FORMAT", "json"),
 		MetricsAuthEnabled:         metricsAuthEnabled,
+		OTelEnabled:                otelEnabled,
+		OTelExporterEndpoint:       getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		OTelServiceName:            getEnv("OTEL_SERVICE_NAME", "mjrwtf"),
 		EnableHSTS:                 enableHSTS,
+		TrustProxyHeaders:          trustProxyHeaders,
+		TrustedProxies:             trustedProxies,
 		DBTimeout:                  dbTimeout,
+		DBMaxOpenConns:             dbMaxOpenConns,
+		DBMaxIdleConns:             dbMaxIdleConns,
+		DBConnMaxLifetime:          dbConnMaxLifetime,
+		URLCacheSize:               urlCacheSize,
+		URLCacheTTL:                urlCacheTTL,
+
+		HSTSMaxAge:            hstsMaxAge,
+		ContentSecurityPolicy: strings.TrimSpace(getEnv("CONTENT_SECURITY_POLICY", "")),
+		FrameOptions:          strings.ToUpper(getEnv("FRAME_OPTIONS", "DENY")),
+
+		MaxRequestBodyBytes: maxRequestBodyBytes,
+
+		ValidateOpenAPI: validateOpenAPI,
+
+		AllowedMethods:   allowedMethods,
+		AllowedHeaders:   getEnvAsList("ALLOWED_HEADERS"),
+		AllowCredentials: allowCredentials,
+
+		RateLimitAllowlist: rateLimitAllowlist,
+		RateLimitBackend:   getEnv("RATE_LIMIT_BACKEND", "memory"),
+		RedisURL:           getEnv("REDIS_URL", ""),
+
+		LogRedirectSampleRate: logRedirectSampleRate,
+		AccessLogPath:         getEnv("ACCESS_LOG_PATH", ""),
+
+		PprofEnabled: pprofEnabled,
+
+		AnalyticsTopN: analyticsTopN,
+
+		ClickRetentionDays: clickRetentionDays,
+
+		UniqueVisitorsEnabled: uniqueVisitorsEnabled,
+		VisitorHashSalt:       getEnv("VISITOR_HASH_SALT", ""),
+
+		ReservedCodes:      getEnvAsList("RESERVED_CODES"),
+		DedupeDestinations: dedupeDestinations,
+		IdempotencyKeyTTL:  idempotencyKeyTTL,
+
+		ShortCodeAlphabet: getEnv("SHORTCODE_ALPHABET", ""),
+
+		ShortCodeAutoLengthDensity: shortCodeAutoLengthDensity,
+		ShortCodeAutoLengthRefresh: shortCodeAutoLengthRefresh,
+
+		AllowedURLSchemes: allowedURLSchemes,
+
+		AllowedDestinationHosts: getEnvAsList("ALLOWED_DESTINATION_HOSTS"),
+		BlockedDestinationHosts: getEnvAsList("BLOCKED_DESTINATION_HOSTS"),
+
+		BlockPrivateDestinations:  blockPrivateDestinations,
+		DestinationResolveTimeout: destinationResolveTimeout,
+
+		StripURLFragments: stripURLFragments,
+
+		SoftDeleteEnabled: softDeleteEnabled,
+
+		NotFoundAlertThreshold: notFoundAlertThreshold,
+		NotFoundAlertWindow:    notFoundAlertWindow,
+
+		NotFoundRedirectURL: getEnv("NOT_FOUND_REDIRECT_URL", ""),
+
+		RobotsTxt: getEnv("ROBOTS_TXT", DefaultRobotsTxt),
+
+		NotifierKind:            getEnv("NOTIFIER_KIND", "discord"),
+		SlackWebhookURL:         getEnv("SLACK_WEBHOOK_URL", ""),
+		DiscordCreateWebhookURL: getEnv("DISCORD_CREATE_WEBHOOK_URL", ""),
+		SlackCreateWebhookURL:   getEnv("SLACK_CREATE_WEBHOOK_URL", ""),
+		NotifyCreateFullURL:     notifyCreateFullURL,
+		NotifyEvents:            getEnvAsList("NOTIFY_EVENTS"),
+		EventWebhookURL:         getEnv("EVENT_WEBHOOK_URL", ""),
+		EventWebhookSecret:      getEnv("EVENT_WEBHOOK_SECRET", ""),
+
+		RedirectClickBackpressurePolicy: getEnv("REDIRECT_CLICK_BACKPRESSURE_POLICY", "drop"),
+		RedirectClickEnqueueTimeout:     redirectClickEnqueueTimeout,
+		RedirectClickRetryAttempts:      redirectClickRetryAttempts,
+		RedirectClickRetryDelay:         redirectClickRetryDelay,
+		ClickDedupeWindow:               clickDedupeWindow,
+		FilterBotClicks:                 filterBotClicks,
+
+		SessionDuration:        sessionDuration,
+		SessionIdleTimeout:     sessionIdleTimeout,
+		SessionCleanupInterval: sessionCleanupInterval,
 
 		URLStatusCheckerEnabled:                urlStatusCheckerEnabled,
 		URLStatusCheckerPollInterval:           urlStatusCheckerPollInterval,
@@ -240,6 +630,10 @@ func (c *Config) Validate() error {
 		return ErrInvalidServerPortRange
 	}
 
+	if c.MaxRequestBodyBytes < 1 {
+		return ErrInvalidMaxRequestBodyBytes
+	}
+
 	if c.RedirectRateLimitPerMinute < 1 {
 		return ErrInvalidRedirectRateLimit
 	}
@@ -248,6 +642,16 @@ func (c *Config) Validate() error {
 		return ErrInvalidAPIRateLimit
 	}
 
+	switch c.RateLimitBackend {
+	case "", "memory":
+	case "redis":
+		if c.RedisURL == "" {
+			return ErrMissingRedisURL
+		}
+	default:
+		return fmt.Errorf("%w (got %q)", ErrInvalidRateLimitBackend, c.RateLimitBackend)
+	}
+
 	if c.RedirectClickWorkers < 1 {
 		return ErrInvalidRedirectClickWorkers
 	}
@@ -256,6 +660,155 @@ func (c *Config) Validate() error {
 		return ErrInvalidRedirectClickQueueSize
 	}
 
+	switch c.RedirectClickBackpressurePolicy {
+	case "", "drop":
+	case "block-with-timeout":
+		if c.RedirectClickEnqueueTimeout <= 0 {
+			return ErrInvalidRedirectClickEnqueueTimeout
+		}
+	default:
+		return fmt.Errorf("%w (got %q)", ErrInvalidRedirectClickBackpressurePolicy, c.RedirectClickBackpressurePolicy)
+	}
+
+	if c.RedirectClickRetryAttempts < 1 {
+		return ErrInvalidRedirectClickRetryAttempts
+	}
+
+	if c.RedirectClickRetryAttempts > 1 && c.RedirectClickRetryDelay <= 0 {
+		return ErrInvalidRedirectClickRetryDelay
+	}
+
+	if c.ClickDedupeWindow < 0 {
+		return ErrInvalidClickDedupeWindow
+	}
+
+	if c.SessionDuration <= 0 {
+		return ErrInvalidSessionDuration
+	}
+
+	if c.DBMaxOpenConns < 1 {
+		return ErrInvalidDBMaxOpenConns
+	}
+
+	if c.DBMaxIdleConns < 0 || c.DBMaxIdleConns > c.DBMaxOpenConns {
+		return fmt.Errorf("%w (got %d idle, %d open)", ErrInvalidDBMaxIdleConns, c.DBMaxIdleConns, c.DBMaxOpenConns)
+	}
+
+	if c.DBConnMaxLifetime < 0 {
+		return ErrInvalidDBConnMaxLifetime
+	}
+
+	if c.URLCacheSize < 0 {
+		return ErrInvalidURLCacheSize
+	}
+
+	if c.URLCacheSize > 0 && c.URLCacheTTL <= 0 {
+		return ErrInvalidURLCacheTTL
+	}
+
+	if c.EnableHSTS && c.HSTSMaxAge < 1 {
+		return ErrInvalidHSTSMaxAge
+	}
+
+	if c.FrameOptions != "" && c.FrameOptions != "DENY" && c.FrameOptions != "SAMEORIGIN" {
+		return ErrInvalidFrameOptions
+	}
+
+	for _, method := range c.AllowedMethods {
+		if !isHTTPToken(method) {
+			return fmt.Errorf("%w (got %q)", ErrInvalidAllowedMethod, method)
+		}
+	}
+
+	for _, header := range c.AllowedHeaders {
+		if header != "*" && !isHTTPToken(header) {
+			return fmt.Errorf("%w (got %q)", ErrInvalidAllowedHeader, header)
+		}
+	}
+
+	if c.AllowCredentials {
+		for _, origin := range strings.Split(c.AllowedOrigins, ",") {
+			if strings.TrimSpace(origin) == "*" {
+				return ErrCredentialsWithWildcardOrigin
+			}
+		}
+	}
+
+	for _, scheme := range c.AllowedURLSchemes {
+		if err := url.ValidateAllowedScheme(scheme); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidAllowedURLScheme, err)
+		}
+	}
+
+	for _, pattern := range slices.Concat(c.AllowedDestinationHosts, c.BlockedDestinationHosts) {
+		if strings.Contains(strings.TrimPrefix(pattern, "*."), "*") {
+			return fmt.Errorf("%w (got %q)", ErrInvalidDestinationHostPattern, pattern)
+		}
+	}
+
+	if c.BlockPrivateDestinations && c.DestinationResolveTimeout <= 0 {
+		return ErrInvalidDestinationResolveTimeout
+	}
+
+	if c.AnalyticsTopN < 1 || c.AnalyticsTopN > 100 {
+		return fmt.Errorf("%w (got %d)", ErrInvalidAnalyticsTopN, c.AnalyticsTopN)
+	}
+
+	if c.ClickRetentionDays < 0 {
+		return fmt.Errorf("%w (got %d)", ErrInvalidClickRetentionDays, c.ClickRetentionDays)
+	}
+
+	if c.IdempotencyKeyTTL <= 0 {
+		return ErrInvalidIdempotencyKeyTTL
+	}
+
+	switch c.NotifierKind {
+	case "", "discord", "slack", "none":
+	default:
+		return fmt.Errorf("%w (got %q)", ErrInvalidNotifierKind, c.NotifierKind)
+	}
+
+	for _, event := range c.NotifyEvents {
+		if !slices.Contains(notifyEvents, event) {
+			return fmt.Errorf("%w (got %q)", ErrUnknownNotifyEvent, event)
+		}
+	}
+
+	if c.NotFoundAlertThreshold < 0 {
+		return ErrInvalidNotFoundAlertThreshold
+	}
+
+	if c.NotFoundAlertThreshold > 0 && c.NotFoundAlertWindow <= 0 {
+		return ErrInvalidNotFoundAlertWindow
+	}
+
+	if _, err := url.ResolveAlphabet(c.ShortCodeAlphabet); err != nil {
+		return fmt.Errorf("%w (got %q)", ErrInvalidShortCodeAlphabet, c.ShortCodeAlphabet)
+	}
+
+	if c.ShortCodeAutoLengthDensity < 0 || c.ShortCodeAutoLengthDensity > 1 {
+		return ErrInvalidShortCodeAutoLengthDensity
+	}
+
+	if c.ShortCodeAutoLengthDensity > 0 && c.ShortCodeAutoLengthRefresh <= 0 {
+		return ErrInvalidShortCodeAutoLengthRefresh
+	}
+
+	if c.NotFoundRedirectURL != "" {
+		u, err := neturl.Parse(c.NotFoundRedirectURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w (got %q)", ErrInvalidNotFoundRedirectURL, c.NotFoundRedirectURL)
+		}
+	}
+
+	if c.SessionIdleTimeout < 0 {
+		return ErrInvalidSessionIdleTimeout
+	}
+
+	if c.SessionCleanupInterval <= 0 {
+		return ErrInvalidSessionCleanupInterval
+	}
+
 	if c.URLStatusCheckerEnabled {
 		if c.URLStatusCheckerPollInterval <= 0 || c.URLStatusCheckerAliveRecheckInterval <= 0 || c.URLStatusCheckerGoneRecheckInterval <= 0 {
 			return fmt.Errorf("URL status checker intervals (poll, alive recheck, gone recheck) must be > 0")
@@ -269,6 +822,20 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("archive recheck interval must be > 0 when archive lookup is enabled")
 	}
 
+	// If unique visitors are enabled, a salt is required so hashes can't be reversed by rainbow table
+	if c.UniqueVisitorsEnabled && c.VisitorHashSalt == "" {
+		return ErrMissingVisitorHashSalt
+	}
+
+	// If tracing is enabled, a collector endpoint is required
+	if c.OTelEnabled && c.OTelExporterEndpoint == "" {
+		return ErrMissingOTelEndpoint
+	}
+
+	if c.LogRedirectSampleRate < 0 || c.LogRedirectSampleRate > 1 {
+		return ErrInvalidLogRedirectSampleRate
+	}
+
 	// If GeoIP is enabled, database path is required
 	if c.GeoIPEnabled && c.GeoIPDatabase == "" {
 		return ErrMissingGeoIPDatabase
@@ -302,6 +869,31 @@ func (c *Config) ActiveAuthTokens() []string {
 	return nil
 }
 
+// NotifierWebhookURL returns the error/alert webhook URL for the selected NotifierKind
+func (c *Config) NotifierWebhookURL() string {
+	if c.NotifierKind == "slack" {
+		return c.SlackWebhookURL
+	}
+	return c.DiscordWebhookURL
+}
+
+// NotifierCreateWebhookURL returns the created-URL webhook URL for the selected NotifierKind
+func (c *Config) NotifierCreateWebhookURL() string {
+	if c.NotifierKind == "slack" {
+		return c.SlackCreateWebhookURL
+	}
+	return c.DiscordCreateWebhookURL
+}
+
+// notifyEvents are the event names accepted in NOTIFY_EVENTS
+var notifyEvents = []string{"panic", "created", "404-spike"}
+
+// NotifyEventEnabled reports whether notifications for event should be sent.
+// An empty NotifyEvents enables every event.
+func (c *Config) NotifyEventEnabled(event string) bool {
+	return len(c.NotifyEvents) == 0 || slices.Contains(c.NotifyEvents, event)
+}
+
 func getEnvAuthTokens() ([]string, error) {
 	if raw, ok := os.LookupEnv("AUTH_TOKENS"); ok {
 		parts := strings.Split(raw, ",")
@@ -377,6 +969,25 @@ func getEnvAsBool(key string, defaultValue bool) (bool, error) {
 	return value, nil
 }
 
+// getEnvAsFloat gets an environment variable as a float.
+// Defaults apply only when the env var is unset.
+func getEnvAsFloat(key string, defaultValue float64) (float64, error) {
+	valueStr, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue, nil
+	}
+	if valueStr == "" {
+		return 0, fmt.Errorf("%w: %s", ErrEnvVarEmpty, key)
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s (got %q)", ErrEnvVarNotFloat, key, valueStr)
+	}
+
+	return value, nil
+}
+
 // getEnvAsDuration gets an environment variable as a duration.
 // Defaults apply only when the env var is unset.
 // Duration should be specified as a string like "5s", "100ms", "1m30s".
@@ -396,3 +1007,56 @@ func getEnvAsDuration(key string, defaultValue time.Duration) (time.Duration, er
 
 	return value, nil
 }
+
+// getEnvAsList parses a comma-separated list, trimming whitespace around entries.
+// Empty entries are ignored; an unset or empty variable yields nil.
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, p := range strings.Split(os.Getenv(key), ",") {
+		if entry := strings.TrimSpace(p); entry != "" {
+			values = append(values, entry)
+		}
+	}
+	return values
+}
+
+// getEnvAsCIDRs parses a comma-separated list of CIDRs (e.g. "10.0.0.0/8,fd00::/8").
+// Empty entries are ignored; an unset or empty variable yields nil.
+func getEnvAsCIDRs(key string) ([]*net.IPNet, error) {
+	raw := os.Getenv(key)
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var nets []*net.IPNet
+	for _, p := range strings.Split(raw, ",") {
+		entry := strings.TrimSpace(p)
+		if entry == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s (got %q)", ErrInvalidCIDR, key, entry)
+		}
+		nets = append(nets, ipNet)
+	}
+
+	return nets, nil
+}
+
+// isHTTPToken reports whether s is an RFC 9110 token, the syntax of method and header names
+func isHTTPToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"os"
+	"slices"
 	"testing"
 	"time"
 )
@@ -306,6 +307,435 @@ func TestLoadConfig_GeoIPEnabledWithoutDatabase(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_UniqueVisitors(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.UniqueVisitorsEnabled {
+		t.Error("Expected UniqueVisitorsEnabled to default to false")
+	}
+
+	os.Setenv("UNIQUE_VISITORS_ENABLED", "true")
+	if _, err := LoadConfig(); !errors.Is(err, ErrMissingVisitorHashSalt) {
+		t.Fatalf("Expected ErrMissingVisitorHashSalt, got: %v", err)
+	}
+
+	os.Setenv("VISITOR_HASH_SALT", "s3cret")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.UniqueVisitorsEnabled || cfg.VisitorHashSalt != "s3cret" {
+		t.Errorf("Expected unique visitors enabled with salt, got enabled=%v salt=%q", cfg.UniqueVisitorsEnabled, cfg.VisitorHashSalt)
+	}
+}
+
+func TestLoadConfig_SoftDeleteEnabled(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.SoftDeleteEnabled {
+		t.Error("Expected SoftDeleteEnabled to default to false")
+	}
+
+	os.Setenv("SOFT_DELETE_ENABLED", "true")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.SoftDeleteEnabled {
+		t.Error("Expected SoftDeleteEnabled to be true")
+	}
+
+	os.Setenv("SOFT_DELETE_ENABLED", "sometimes")
+	if _, err := LoadConfig(); err == nil {
+		t.Error("Expected error for invalid SOFT_DELETE_ENABLED, got nil")
+	}
+}
+
+func TestLoadConfig_ReservedCodes(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(cfg.ReservedCodes) != 0 {
+		t.Errorf("Expected no ReservedCodes by default, got: %#v", cfg.ReservedCodes)
+	}
+
+	os.Setenv("RESERVED_CODES", " docs, ,status ")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(cfg.ReservedCodes) != 2 || cfg.ReservedCodes[0] != "docs" || cfg.ReservedCodes[1] != "status" {
+		t.Errorf("Expected ReservedCodes to be [docs status], got: %#v", cfg.ReservedCodes)
+	}
+}
+
+func TestLoadConfig_DestinationHosts(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(cfg.AllowedDestinationHosts) != 0 || len(cfg.BlockedDestinationHosts) != 0 {
+		t.Errorf("Expected no destination host lists by default, got: %#v / %#v", cfg.AllowedDestinationHosts, cfg.BlockedDestinationHosts)
+	}
+
+	os.Setenv("ALLOWED_DESTINATION_HOSTS", "example.com, *.example.com")
+	os.Setenv("BLOCKED_DESTINATION_HOSTS", "spam.example.com")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(cfg.AllowedDestinationHosts) != 2 || cfg.AllowedDestinationHosts[1] != "*.example.com" {
+		t.Errorf("Expected AllowedDestinationHosts to be [example.com *.example.com], got: %#v", cfg.AllowedDestinationHosts)
+	}
+	if len(cfg.BlockedDestinationHosts) != 1 || cfg.BlockedDestinationHosts[0] != "spam.example.com" {
+		t.Errorf("Expected BlockedDestinationHosts to be [spam.example.com], got: %#v", cfg.BlockedDestinationHosts)
+	}
+
+	for _, invalid := range []string{"*", "ex*ample.com", "*.*.example.com"} {
+		os.Setenv("BLOCKED_DESTINATION_HOSTS", invalid)
+		if _, err := LoadConfig(); !errors.Is(err, ErrInvalidDestinationHostPattern) {
+			t.Errorf("BLOCKED_DESTINATION_HOSTS=%q: expected ErrInvalidDestinationHostPattern, got: %v", invalid, err)
+		}
+	}
+}
+
+func TestLoadConfig_AllowedURLSchemes(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr error
+	}{
+		{name: "defaults to http and https", want: []string{"http", "https"}},
+		{name: "extra schemes", value: "http, https, ftp, mailto", want: []string{"http", "https", "ftp", "mailto"}},
+		{name: "invalid scheme", value: "https,not a scheme", wantErr: ErrInvalidAllowedURLScheme},
+		{name: "unsafe scheme", value: "https,javascript", wantErr: ErrInvalidAllowedURLScheme},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			if tt.value != "" {
+				os.Setenv("ALLOWED_URL_SCHEMES", tt.value)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !slices.Equal(cfg.AllowedURLSchemes, tt.want) {
+				t.Errorf("Expected AllowedURLSchemes %v, got %v", tt.want, cfg.AllowedURLSchemes)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_CORS(t *testing.T) {
+	tests := []struct {
+		name            string
+		env             map[string]string
+		wantMethods     []string
+		wantHeaders     []string
+		wantCredentials bool
+		wantErr         error
+	}{
+		{name: "defaults"},
+		{
+			name:        "methods and headers",
+			env:         map[string]string{"ALLOWED_METHODS": "get, post", "ALLOWED_HEADERS": "Authorization, X-Custom"},
+			wantMethods: []string{"GET", "POST"},
+			wantHeaders: []string{"Authorization", "X-Custom"},
+		},
+		{name: "any header", env: map[string]string{"ALLOWED_HEADERS": "*"}, wantHeaders: []string{"*"}},
+		{
+			name:            "credentials with specific origins",
+			env:             map[string]string{"ALLOW_CREDENTIALS": "true", "ALLOWED_ORIGINS": "https://app.example.com"},
+			wantCredentials: true,
+		},
+		{name: "credentials with wildcard origin", env: map[string]string{"ALLOW_CREDENTIALS": "true"}, wantErr: ErrCredentialsWithWildcardOrigin},
+		{
+			name:    "credentials with wildcard among origins",
+			env:     map[string]string{"ALLOW_CREDENTIALS": "true", "ALLOWED_ORIGINS": "https://app.example.com, *"},
+			wantErr: ErrCredentialsWithWildcardOrigin,
+		},
+		{name: "invalid method", env: map[string]string{"ALLOWED_METHODS": "GET,NOT A METHOD"}, wantErr: ErrInvalidAllowedMethod},
+		{name: "invalid header", env: map[string]string{"ALLOWED_HEADERS": "X-Good,X Bad"}, wantErr: ErrInvalidAllowedHeader},
+		{name: "invalid credentials flag", env: map[string]string{"ALLOW_CREDENTIALS": "maybe"}, wantErr: ErrEnvVarNotBool},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if !slices.Equal(cfg.AllowedMethods, tt.wantMethods) {
+				t.Errorf("Expected AllowedMethods %v, got %v", tt.wantMethods, cfg.AllowedMethods)
+			}
+			if !slices.Equal(cfg.AllowedHeaders, tt.wantHeaders) {
+				t.Errorf("Expected AllowedHeaders %v, got %v", tt.wantHeaders, cfg.AllowedHeaders)
+			}
+			if cfg.AllowCredentials != tt.wantCredentials {
+				t.Errorf("Expected AllowCredentials %v, got %v", tt.wantCredentials, cfg.AllowCredentials)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_SecurityHeaders(t *testing.T) {
+	tests := []struct {
+		name             string
+		env              map[string]string
+		wantMaxAge       int
+		wantCSP          string
+		wantFrameOptions string
+		wantErr          error
+	}{
+		{name: "defaults", wantMaxAge: 31536000, wantFrameOptions: "DENY"},
+		{
+			name:             "configured",
+			env:              map[string]string{"ENABLE_HSTS": "true", "HSTS_MAX_AGE": "600", "CONTENT_SECURITY_POLICY": " default-src 'none' ", "FRAME_OPTIONS": "sameorigin"},
+			wantMaxAge:       600,
+			wantCSP:          "default-src 'none'",
+			wantFrameOptions: "SAMEORIGIN",
+		},
+		{name: "zero max age ignored without HSTS", env: map[string]string{"HSTS_MAX_AGE": "0"}, wantFrameOptions: "DENY"},
+		{name: "zero max age with HSTS", env: map[string]string{"ENABLE_HSTS": "true", "HSTS_MAX_AGE": "0"}, wantErr: ErrInvalidHSTSMaxAge},
+		{name: "invalid max age", env: map[string]string{"HSTS_MAX_AGE": "a year"}, wantErr: ErrEnvVarNotInt},
+		{name: "invalid frame options", env: map[string]string{"FRAME_OPTIONS": "ALLOW-FROM https://example.com"}, wantErr: ErrInvalidFrameOptions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if cfg.HSTSMaxAge != tt.wantMaxAge {
+				t.Errorf("Expected HSTSMaxAge %d, got %d", tt.wantMaxAge, cfg.HSTSMaxAge)
+			}
+			if cfg.ContentSecurityPolicy != tt.wantCSP {
+				t.Errorf("Expected ContentSecurityPolicy %q, got %q", tt.wantCSP, cfg.ContentSecurityPolicy)
+			}
+			if cfg.FrameOptions != tt.wantFrameOptions {
+				t.Errorf("Expected FrameOptions %q, got %q", tt.wantFrameOptions, cfg.FrameOptions)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ValidateOpenAPI(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    bool
+		wantErr error
+	}{
+		{name: "off by default"},
+		{name: "enabled", env: map[string]string{"VALIDATE_OPENAPI": "true"}, want: true},
+		{name: "invalid flag", env: map[string]string{"VALIDATE_OPENAPI": "strict"}, wantErr: ErrEnvVarNotBool},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if cfg.ValidateOpenAPI != tt.want {
+				t.Errorf("Expected ValidateOpenAPI %v, got %v", tt.want, cfg.ValidateOpenAPI)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_BlockPrivateDestinations(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		wantBlock   bool
+		wantTimeout time.Duration
+		wantErr     error
+	}{
+		{name: "off by default", wantTimeout: 2 * time.Second},
+		{name: "enabled", env: map[string]string{"BLOCK_PRIVATE_DESTINATIONS": "true"}, wantBlock: true, wantTimeout: 2 * time.Second},
+		{name: "custom timeout", env: map[string]string{"BLOCK_PRIVATE_DESTINATIONS": "true", "DESTINATION_RESOLVE_TIMEOUT": "500ms"}, wantBlock: true, wantTimeout: 500 * time.Millisecond},
+		{name: "zero timeout ignored while off", env: map[string]string{"DESTINATION_RESOLVE_TIMEOUT": "0s"}, wantTimeout: 0},
+		{name: "zero timeout", env: map[string]string{"BLOCK_PRIVATE_DESTINATIONS": "true", "DESTINATION_RESOLVE_TIMEOUT": "0s"}, wantErr: ErrInvalidDestinationResolveTimeout},
+		{name: "invalid flag", env: map[string]string{"BLOCK_PRIVATE_DESTINATIONS": "maybe"}, wantErr: ErrEnvVarNotBool},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if cfg.BlockPrivateDestinations != tt.wantBlock {
+				t.Errorf("Expected BlockPrivateDestinations %v, got %v", tt.wantBlock, cfg.BlockPrivateDestinations)
+			}
+			if cfg.DestinationResolveTimeout != tt.wantTimeout {
+				t.Errorf("Expected DestinationResolveTimeout %v, got %v", tt.wantTimeout, cfg.DestinationResolveTimeout)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_StripURLFragments(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.StripURLFragments {
+		t.Error("Expected StripURLFragments to default to false")
+	}
+
+	os.Setenv("STRIP_URL_FRAGMENTS", "true")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.StripURLFragments {
+		t.Error("Expected StripURLFragments to be true")
+	}
+}
+
+func TestLoadConfig_DedupeDestinations(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.DedupeDestinations {
+		t.Error("Expected DedupeDestinations to default to false")
+	}
+
+	os.Setenv("DEDUPE_DESTINATIONS", "true")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.DedupeDestinations {
+		t.Error("Expected DedupeDestinations to be true")
+	}
+}
+
+func TestLoadConfig_IdempotencyKeyTTL(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.IdempotencyKeyTTL != 24*time.Hour {
+		t.Errorf("Expected IdempotencyKeyTTL to default to 24h, got %v", cfg.IdempotencyKeyTTL)
+	}
+
+	os.Setenv("IDEMPOTENCY_KEY_TTL", "1h")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.IdempotencyKeyTTL != time.Hour {
+		t.Errorf("Expected IdempotencyKeyTTL 1h, got %v", cfg.IdempotencyKeyTTL)
+	}
+
+	os.Setenv("IDEMPOTENCY_KEY_TTL", "0s")
+	if _, err := LoadConfig(); err != ErrInvalidIdempotencyKeyTTL {
+		t.Errorf("Expected ErrInvalidIdempotencyKeyTTL, got: %v", err)
+	}
+}
+
 func TestLoadConfig_DefaultValues(t *testing.T) {
 	// Set only required environment variables
 	os.Setenv("DATABASE_URL", "./database.db")
@@ -351,240 +781,1270 @@ func TestLoadConfig_DefaultValues(t *testing.T) {
 	}
 }
 
-func TestLoadConfig_CustomRateLimits(t *testing.T) {
+func TestLoadConfig_CustomRateLimits(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_RATE_LIMIT_PER_MINUTE", "10")
+	os.Setenv("API_RATE_LIMIT_PER_MINUTE", "5")
+	defer cleanEnv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if config.RedirectRateLimitPerMinute != 10 {
+		t.Errorf("Expected RedirectRateLimitPerMinute to be 10, got: %d", config.RedirectRateLimitPerMinute)
+	}
+
+	if config.APIRateLimitPerMinute != 5 {
+		t.Errorf("Expected APIRateLimitPerMinute to be 5, got: %d", config.APIRateLimitPerMinute)
+	}
+}
+
+func TestLoadConfig_InvalidRateLimits(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_RATE_LIMIT_PER_MINUTE", "0")
+	os.Setenv("API_RATE_LIMIT_PER_MINUTE", "-1")
+	defer cleanEnv()
+
+	_, err := LoadConfig()
+	if err == nil {
+		t.Fatal("Expected error for invalid rate limits, got nil")
+	}
+
+	if !errors.Is(err, ErrInvalidRedirectRateLimit) {
+		t.Fatalf("Expected ErrInvalidRedirectRateLimit, got: %v", err)
+	}
+}
+
+func TestLoadConfig_RateLimitsInvalidStringsFallbackToDefaults(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_RATE_LIMIT_PER_MINUTE", "abc")
+	os.Setenv("API_RATE_LIMIT_PER_MINUTE", "def")
+	defer cleanEnv()
+
+	_, err := LoadConfig()
+	if err == nil {
+		t.Fatal("Expected error for invalid rate limit values, got nil")
+	}
+
+	if !errors.Is(err, ErrEnvVarNotInt) {
+		t.Fatalf("Expected ErrEnvVarNotInt, got: %v", err)
+	}
+}
+
+func TestLoadConfig_InvalidRedirectClickWorkers(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_CLICK_WORKERS", "0")
+	defer cleanEnv()
+
+	_, err := LoadConfig()
+	if err == nil {
+		t.Fatal("Expected error for invalid REDIRECT_CLICK_WORKERS, got nil")
+	}
+
+	if !errors.Is(err, ErrInvalidRedirectClickWorkers) {
+		t.Fatalf("Expected ErrInvalidRedirectClickWorkers, got: %v", err)
+	}
+}
+
+func TestLoadConfig_InvalidRedirectClickQueueSize(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_CLICK_QUEUE_SIZE", "0")
+	defer cleanEnv()
+
+	_, err := LoadConfig()
+	if err == nil {
+		t.Fatal("Expected error for invalid REDIRECT_CLICK_QUEUE_SIZE, got nil")
+	}
+
+	if !errors.Is(err, ErrInvalidRedirectClickQueueSize) {
+		t.Fatalf("Expected ErrInvalidRedirectClickQueueSize, got: %v", err)
+	}
+}
+
+func TestLoadConfig_RedirectClickBackpressureDefaults(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if cfg.RedirectClickBackpressurePolicy != "drop" {
+		t.Errorf("Expected default backpressure policy drop, got %q", cfg.RedirectClickBackpressurePolicy)
+	}
+	if cfg.RedirectClickEnqueueTimeout != 50*time.Millisecond {
+		t.Errorf("Expected default enqueue timeout 50ms, got %v", cfg.RedirectClickEnqueueTimeout)
+	}
+}
+
+func TestLoadConfig_RedirectClickBackpressureBlockWithTimeout(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_CLICK_BACKPRESSURE_POLICY", "block-with-timeout")
+	os.Setenv("REDIRECT_CLICK_ENQUEUE_TIMEOUT", "25ms")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if cfg.RedirectClickBackpressurePolicy != "block-with-timeout" {
+		t.Errorf("Expected block-with-timeout, got %q", cfg.RedirectClickBackpressurePolicy)
+	}
+	if cfg.RedirectClickEnqueueTimeout != 25*time.Millisecond {
+		t.Errorf("Expected enqueue timeout 25ms, got %v", cfg.RedirectClickEnqueueTimeout)
+	}
+}
+
+func TestLoadConfig_InvalidRedirectClickBackpressurePolicy(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_CLICK_BACKPRESSURE_POLICY", "block")
+	defer cleanEnv()
+
+	_, err := LoadConfig()
+	if !errors.Is(err, ErrInvalidRedirectClickBackpressurePolicy) {
+		t.Fatalf("Expected ErrInvalidRedirectClickBackpressurePolicy, got: %v", err)
+	}
+}
+
+func TestLoadConfig_InvalidRedirectClickEnqueueTimeout(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_CLICK_BACKPRESSURE_POLICY", "block-with-timeout")
+	os.Setenv("REDIRECT_CLICK_ENQUEUE_TIMEOUT", "0s")
+	defer cleanEnv()
+
+	_, err := LoadConfig()
+	if !errors.Is(err, ErrInvalidRedirectClickEnqueueTimeout) {
+		t.Fatalf("Expected ErrInvalidRedirectClickEnqueueTimeout, got: %v", err)
+	}
+}
+
+func TestLoadConfig_RedirectClickRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		attempts     string
+		delay        string
+		wantAttempts int
+		wantDelay    time.Duration
+		wantErr      error
+	}{
+		{name: "defaults", wantAttempts: 3, wantDelay: 100 * time.Millisecond},
+		{name: "custom", attempts: "5", delay: "20ms", wantAttempts: 5, wantDelay: 20 * time.Millisecond},
+		{name: "disabled ignores delay", attempts: "1", delay: "0s", wantAttempts: 1, wantDelay: 0},
+		{name: "zero attempts", attempts: "0", wantErr: ErrInvalidRedirectClickRetryAttempts},
+		{name: "zero delay with retries", attempts: "3", delay: "0s", wantErr: ErrInvalidRedirectClickRetryDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			if tt.attempts != "" {
+				os.Setenv("REDIRECT_CLICK_RETRY_ATTEMPTS", tt.attempts)
+			}
+			if tt.delay != "" {
+				os.Setenv("REDIRECT_CLICK_RETRY_DELAY", tt.delay)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if cfg.RedirectClickRetryAttempts != tt.wantAttempts {
+				t.Errorf("Expected RedirectClickRetryAttempts %d, got %d", tt.wantAttempts, cfg.RedirectClickRetryAttempts)
+			}
+			if cfg.RedirectClickRetryDelay != tt.wantDelay {
+				t.Errorf("Expected RedirectClickRetryDelay %v, got %v", tt.wantDelay, cfg.RedirectClickRetryDelay)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_NotFoundAlert(t *testing.T) {
+	tests := []struct {
+		name          string
+		threshold     string
+		window        string
+		wantThreshold int
+		wantWindow    time.Duration
+		wantErr       error
+	}{
+		{name: "defaults to disabled", wantThreshold: 0, wantWindow: 5 * time.Minute},
+		{name: "custom", threshold: "50", window: "1m", wantThreshold: 50, wantWindow: time.Minute},
+		{name: "negative threshold", threshold: "-1", wantErr: ErrInvalidNotFoundAlertThreshold},
+		{name: "zero window when enabled", threshold: "10", window: "0s", wantErr: ErrInvalidNotFoundAlertWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			if tt.threshold != "" {
+				os.Setenv("NOT_FOUND_ALERT_THRESHOLD", tt.threshold)
+			}
+			if tt.window != "" {
+				os.Setenv("NOT_FOUND_ALERT_WINDOW", tt.window)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if cfg.NotFoundAlertThreshold != tt.wantThreshold {
+				t.Errorf("Expected NotFoundAlertThreshold %d, got %d", tt.wantThreshold, cfg.NotFoundAlertThreshold)
+			}
+			if cfg.NotFoundAlertWindow != tt.wantWindow {
+				t.Errorf("Expected NotFoundAlertWindow %v, got %v", tt.wantWindow, cfg.NotFoundAlertWindow)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_DiscordCreateWebhook(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.DiscordCreateWebhookURL != "" || cfg.NotifyCreateFullURL {
+		t.Errorf("Expected created-URL notifications disabled by default, got %q (full URL: %v)", cfg.DiscordCreateWebhookURL, cfg.NotifyCreateFullURL)
+	}
+
+	os.Setenv("DISCORD_CREATE_WEBHOOK_URL", "https://discord.com/api/webhooks/links")
+	os.Setenv("NOTIFY_CREATE_FULL_URL", "true")
+
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.DiscordCreateWebhookURL != "https://discord.com/api/webhooks/links" {
+		t.Errorf("Expected DiscordCreateWebhookURL to be set, got %q", cfg.DiscordCreateWebhookURL)
+	}
+	if !cfg.NotifyCreateFullURL {
+		t.Error("Expected NotifyCreateFullURL to be true")
+	}
+}
+
+func TestLoadConfig_NotifyEvents(t *testing.T) {
+	tests := []struct {
+		name        string
+		events      string
+		wantEvents  []string
+		wantEnabled map[string]bool
+		wantErr     error
+	}{
+		{
+			name:        "unset enables every event",
+			wantEnabled: map[string]bool{"panic": true, "created": true, "404-spike": true},
+		},
+		{
+			name:        "panic only",
+			events:      "panic",
+			wantEvents:  []string{"panic"},
+			wantEnabled: map[string]bool{"panic": true, "created": false, "404-spike": false},
+		},
+		{
+			name:        "trims entries",
+			events:      " created, ,404-spike ",
+			wantEvents:  []string{"created", "404-spike"},
+			wantEnabled: map[string]bool{"panic": false, "created": true, "404-spike": true},
+		},
+		{name: "unknown event", events: "panic,deleted", wantErr: ErrUnknownNotifyEvent},
+		{name: "names are case sensitive", events: "Panic", wantErr: ErrUnknownNotifyEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			if tt.events != "" {
+				os.Setenv("NOTIFY_EVENTS", tt.events)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if !slices.Equal(cfg.NotifyEvents, tt.wantEvents) {
+				t.Errorf("Expected NotifyEvents %#v, got %#v", tt.wantEvents, cfg.NotifyEvents)
+			}
+			for event, want := range tt.wantEnabled {
+				if got := cfg.NotifyEventEnabled(event); got != want {
+					t.Errorf("NotifyEventEnabled(%q) = %v, want %v", event, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfig_URLCache(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     string
+		ttl      string
+		wantSize int
+		wantTTL  time.Duration
+		wantErr  error
+	}{
+		{name: "disabled by default", wantSize: 0, wantTTL: time.Minute},
+		{name: "enabled", size: "10000", ttl: "30s", wantSize: 10000, wantTTL: 30 * time.Second},
+		{name: "zero ttl ignored while disabled", size: "0", ttl: "0s", wantSize: 0, wantTTL: 0},
+		{name: "negative size", size: "-1", wantErr: ErrInvalidURLCacheSize},
+		{name: "zero ttl", size: "100", ttl: "0s", wantErr: ErrInvalidURLCacheTTL},
+		{name: "invalid size", size: "lots", wantErr: ErrEnvVarNotInt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			if tt.size != "" {
+				os.Setenv("URL_CACHE_SIZE", tt.size)
+			}
+			if tt.ttl != "" {
+				os.Setenv("URL_CACHE_TTL", tt.ttl)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if cfg.URLCacheSize != tt.wantSize {
+				t.Errorf("Expected URLCacheSize %d, got %d", tt.wantSize, cfg.URLCacheSize)
+			}
+			if cfg.URLCacheTTL != tt.wantTTL {
+				t.Errorf("Expected URLCacheTTL %v, got %v", tt.wantTTL, cfg.URLCacheTTL)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_OTel(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		wantErr  error
+		validate func(*testing.T, *Config)
+	}{
+		{
+			name: "disabled by default",
+			validate: func(t *testing.T, cfg *Config) {
+				if cfg.OTelEnabled {
+					t.Error("Expected OTelEnabled to default to false")
+				}
+				if cfg.OTelServiceName != "mjrwtf" {
+					t.Errorf("Expected OTelServiceName to default to mjrwtf, got %q", cfg.OTelServiceName)
+				}
+			},
+		},
+		{
+			name: "enabled with endpoint",
+			env: map[string]string{
+				"OTEL_ENABLED":                "true",
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://collector:4318",
+				"OTEL_SERVICE_NAME":           "short-links",
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				if !cfg.OTelEnabled || cfg.OTelExporterEndpoint != "http://collector:4318" || cfg.OTelServiceName != "short-links" {
+					t.Errorf("Unexpected tracing config: %+v", cfg)
+				}
+			},
+		},
+		{
+			name:    "enabled without endpoint",
+			env:     map[string]string{"OTEL_ENABLED": "true"},
+			wantErr: ErrMissingOTelEndpoint,
+		},
+		{
+			name:    "invalid bool",
+			env:     map[string]string{"OTEL_ENABLED": "sometimes"},
+			wantErr: ErrEnvVarNotBool,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			tt.validate(t, cfg)
+		})
+	}
+}
+
+func TestLoadConfig_EventWebhook(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("EVENT_WEBHOOK_URL", "https://hooks.example.com/mjrwtf")
+	os.Setenv("EVENT_WEBHOOK_SECRET", "s3cret")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.EventWebhookURL != "https://hooks.example.com/mjrwtf" {
+		t.Errorf("Expected EventWebhookURL to be set, got %q", cfg.EventWebhookURL)
+	}
+	if cfg.EventWebhookSecret != "s3cret" {
+		t.Errorf("Expected EventWebhookSecret to be set, got %q", cfg.EventWebhookSecret)
+	}
+}
+
+func TestLoadConfig_NotifierKind(t *testing.T) {
+	tests := []struct {
+		name           string
+		kind           string
+		wantKind       string
+		wantWebhook    string
+		wantCreateHook string
+		wantErr        error
+	}{
+		{name: "defaults to discord", wantKind: "discord", wantWebhook: "https://discord.example/errors", wantCreateHook: "https://discord.example/links"},
+		{name: "slack", kind: "slack", wantKind: "slack", wantWebhook: "https://slack.example/errors", wantCreateHook: "https://slack.example/links"},
+		{name: "none", kind: "none", wantKind: "none", wantWebhook: "https://discord.example/errors", wantCreateHook: "https://discord.example/links"},
+		{name: "unknown", kind: "teams", wantErr: ErrInvalidNotifierKind},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			os.Setenv("DISCORD_WEBHOOK_URL", "https://discord.example/errors")
+			os.Setenv("DISCORD_CREATE_WEBHOOK_URL", "https://discord.example/links")
+			os.Setenv("SLACK_WEBHOOK_URL", "https://slack.example/errors")
+			os.Setenv("SLACK_CREATE_WEBHOOK_URL", "https://slack.example/links")
+			if tt.kind != "" {
+				os.Setenv("NOTIFIER_KIND", tt.kind)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+
+			if cfg.NotifierKind != tt.wantKind {
+				t.Errorf("Expected NotifierKind %q, got %q", tt.wantKind, cfg.NotifierKind)
+			}
+			// "none" keeps the Discord URLs; the notifier itself discards messages
+			if got := cfg.NotifierWebhookURL(); got != tt.wantWebhook {
+				t.Errorf("Expected NotifierWebhookURL %q, got %q", tt.wantWebhook, got)
+			}
+			if got := cfg.NotifierCreateWebhookURL(); got != tt.wantCreateHook {
+				t.Errorf("Expected NotifierCreateWebhookURL %q, got %q", tt.wantCreateHook, got)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_TrustProxyHeaders(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.TrustProxyHeaders {
+		t.Error("Expected TrustProxyHeaders to default to false")
+	}
+
+	os.Setenv("TRUST_PROXY_HEADERS", "true")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.TrustProxyHeaders {
+		t.Error("Expected TrustProxyHeaders to be true")
+	}
+
+	os.Setenv("TRUST_PROXY_HEADERS", "maybe")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotBool) {
+		t.Fatalf("Expected ErrEnvVarNotBool, got: %v", err)
+	}
+}
+
+func TestLoadConfig_TrustedProxies(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.TrustedProxies != nil {
+		t.Errorf("Expected no trusted proxies by default, got %v", cfg.TrustedProxies)
+	}
+
+	os.Setenv("TRUSTED_PROXIES", "10.0.0.0/8, 172.16.0.0/12")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(cfg.TrustedProxies) != 2 || cfg.TrustedProxies[1].String() != "172.16.0.0/12" {
+		t.Errorf("Expected two trusted proxy networks, got %v", cfg.TrustedProxies)
+	}
+
+	os.Setenv("TRUSTED_PROXIES", "10.0.0.1")
+	if _, err := LoadConfig(); !errors.Is(err, ErrInvalidCIDR) {
+		t.Fatalf("Expected ErrInvalidCIDR, got: %v", err)
+	}
+}
+
+func TestLoadConfig_SessionDurationAndIdleTimeout(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.SessionDuration != 24*time.Hour {
+		t.Errorf("Expected SessionDuration to default to 24h, got %v", cfg.SessionDuration)
+	}
+	if cfg.SessionIdleTimeout != 0 {
+		t.Errorf("Expected SessionIdleTimeout to default to 0 (disabled), got %v", cfg.SessionIdleTimeout)
+	}
+
+	os.Setenv("SESSION_DURATION", "8h")
+	os.Setenv("SESSION_IDLE_TIMEOUT", "30m")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.SessionDuration != 8*time.Hour {
+		t.Errorf("Expected SessionDuration 8h, got %v", cfg.SessionDuration)
+	}
+	if cfg.SessionIdleTimeout != 30*time.Minute {
+		t.Errorf("Expected SessionIdleTimeout 30m, got %v", cfg.SessionIdleTimeout)
+	}
+
+	os.Setenv("SESSION_DURATION", "0s")
+	if _, err := LoadConfig(); !errors.Is(err, ErrInvalidSessionDuration) {
+		t.Fatalf("Expected ErrInvalidSessionDuration, got: %v", err)
+	}
+	os.Setenv("SESSION_DURATION", "8h")
+
+	os.Setenv("SESSION_IDLE_TIMEOUT", "-1m")
+	if _, err := LoadConfig(); !errors.Is(err, ErrInvalidSessionIdleTimeout) {
+		t.Fatalf("Expected ErrInvalidSessionIdleTimeout, got: %v", err)
+	}
+
+	os.Setenv("SESSION_IDLE_TIMEOUT", "later")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotDuration) {
+		t.Fatalf("Expected ErrEnvVarNotDuration, got: %v", err)
+	}
+}
+
+func TestLoadConfig_SessionCleanupInterval(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.SessionCleanupInterval != 15*time.Minute {
+		t.Errorf("Expected SessionCleanupInterval to default to 15m, got %v", cfg.SessionCleanupInterval)
+	}
+
+	os.Setenv("SESSION_CLEANUP_INTERVAL", "1m")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.SessionCleanupInterval != time.Minute {
+		t.Errorf("Expected SessionCleanupInterval 1m, got %v", cfg.SessionCleanupInterval)
+	}
+
+	os.Setenv("SESSION_CLEANUP_INTERVAL", "0s")
+	if _, err := LoadConfig(); !errors.Is(err, ErrInvalidSessionCleanupInterval) {
+		t.Fatalf("Expected ErrInvalidSessionCleanupInterval, got: %v", err)
+	}
+
+	os.Setenv("SESSION_CLEANUP_INTERVAL", "soon")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotDuration) {
+		t.Fatalf("Expected ErrEnvVarNotDuration, got: %v", err)
+	}
+}
+
+func TestLoadConfig_RedirectClickConfigInvalidStrings(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	os.Setenv("REDIRECT_CLICK_WORKERS", "abc")
+	defer cleanEnv()
+
+	_, err := LoadConfig()
+	if err == nil {
+		t.Fatal("Expected error for invalid redirect click config values, got nil")
+	}
+
+	if !errors.Is(err, ErrEnvVarNotInt) {
+		t.Fatalf("Expected ErrEnvVarNotInt, got: %v", err)
+	}
+}
+
+func TestGetEnvAsInt_InvalidValue(t *testing.T) {
+	os.Setenv("TEST_INT", "not-a-number")
+	defer os.Unsetenv("TEST_INT")
+
+	_, err := getEnvAsInt("TEST_INT", 42)
+	if err == nil {
+		t.Fatal("Expected error for invalid int, got nil")
+	}
+
+	if !errors.Is(err, ErrEnvVarNotInt) {
+		t.Fatalf("Expected ErrEnvVarNotInt, got: %v", err)
+	}
+}
+
+func TestGetEnvAsInt_EmptyString(t *testing.T) {
+	os.Setenv("TEST_INT", "")
+	defer os.Unsetenv("TEST_INT")
+
+	_, err := getEnvAsInt("TEST_INT", 42)
+	if err == nil {
+		t.Fatal("Expected error for empty int env var, got nil")
+	}
+
+	if !errors.Is(err, ErrEnvVarEmpty) {
+		t.Fatalf("Expected ErrEnvVarEmpty, got: %v", err)
+	}
+}
+
+func TestGetEnvAsBool_InvalidValue(t *testing.T) {
+	os.Setenv("TEST_BOOL", "not-a-bool")
+	defer os.Unsetenv("TEST_BOOL")
+
+	_, err := getEnvAsBool("TEST_BOOL", true)
+	if err == nil {
+		t.Fatal("Expected error for invalid bool, got nil")
+	}
+
+	if !errors.Is(err, ErrEnvVarNotBool) {
+		t.Fatalf("Expected ErrEnvVarNotBool, got: %v", err)
+	}
+}
+
+func TestGetEnvAsBool_EmptyString(t *testing.T) {
+	os.Setenv("TEST_BOOL", "")
+	defer os.Unsetenv("TEST_BOOL")
+
+	_, err := getEnvAsBool("TEST_BOOL", true)
+	if err == nil {
+		t.Fatal("Expected error for empty bool env var, got nil")
+	}
+
+	if !errors.Is(err, ErrEnvVarEmpty) {
+		t.Fatalf("Expected ErrEnvVarEmpty, got: %v", err)
+	}
+}
+
+func TestGetEnvAsBool_ValidValues(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected bool
+	}{
+		{"true", true},
+		{"false", false},
+		{"1", true},
+		{"0", false},
+		{"t", true},
+		{"f", false},
+		{"T", true},
+		{"F", false},
+		{"TRUE", true},
+		{"FALSE", false},
+	}
+
+	for _, tc := range testCases {
+		os.Setenv("TEST_BOOL", tc.value)
+		result, err := getEnvAsBool("TEST_BOOL", false)
+		if err != nil {
+			t.Fatalf("Unexpected error for value '%s': %v", tc.value, err)
+		}
+		if result != tc.expected {
+			t.Errorf("For value '%s', expected %v, got %v", tc.value, tc.expected, result)
+		}
+		os.Unsetenv("TEST_BOOL")
+	}
+}
+
+func TestGetEnvAsDuration_InvalidValue(t *testing.T) {
+	os.Setenv("TEST_DURATION", "not-a-duration")
+	defer os.Unsetenv("TEST_DURATION")
+
+	_, err := getEnvAsDuration("TEST_DURATION", 5*time.Second)
+	if err == nil {
+		t.Fatal("Expected error for invalid duration, got nil")
+	}
+
+	if !errors.Is(err, ErrEnvVarNotDuration) {
+		t.Fatalf("Expected ErrEnvVarNotDuration, got: %v", err)
+	}
+}
+
+func TestGetEnvAsDuration_EmptyString(t *testing.T) {
+	os.Setenv("TEST_DURATION", "")
+	defer os.Unsetenv("TEST_DURATION")
+
+	_, err := getEnvAsDuration("TEST_DURATION", 5*time.Second)
+	if err == nil {
+		t.Fatal("Expected error for empty duration env var, got nil")
+	}
+
+	if !errors.Is(err, ErrEnvVarEmpty) {
+		t.Fatalf("Expected ErrEnvVarEmpty, got: %v", err)
+	}
+}
+
+// cleanEnv clears all environment variables used in tests
+func TestLoadConfig_RateLimitAllowlist(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.RateLimitAllowlist != nil {
+		t.Errorf("Expected no allowlist by default, got %v", cfg.RateLimitAllowlist)
+	}
+
+	os.Setenv("RATE_LIMIT_ALLOWLIST", " 10.0.0.0/8, ,192.168.1.5/32,fd00::/8 ")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := []string{"10.0.0.0/8", "192.168.1.5/32", "fd00::/8"}
+	if len(cfg.RateLimitAllowlist) != len(want) {
+		t.Fatalf("Expected %d allowlist entries, got %d", len(want), len(cfg.RateLimitAllowlist))
+	}
+	for i, n := range cfg.RateLimitAllowlist {
+		if n.String() != want[i] {
+			t.Errorf("Expected allowlist[%d] = %s, got %s", i, want[i], n.String())
+		}
+	}
+
+	for _, invalid := range []string{"10.0.0.0", "10.0.0.0/33", "not-a-cidr", "10.0.0.0/8,bogus"} {
+		os.Setenv("RATE_LIMIT_ALLOWLIST", invalid)
+		if _, err := LoadConfig(); !errors.Is(err, ErrInvalidCIDR) {
+			t.Errorf("RATE_LIMIT_ALLOWLIST=%q: expected ErrInvalidCIDR, got: %v", invalid, err)
+		}
+	}
+}
+
+func TestLoadConfig_RateLimitBackend(t *testing.T) {
+	tests := []struct {
+		name        string
+		backend     string
+		redisURL    string
+		wantBackend string
+		wantErr     error
+	}{
+		{name: "memory by default", wantBackend: "memory"},
+		{name: "redis", backend: "redis", redisURL: "redis://localhost:6379/0", wantBackend: "redis"},
+		{name: "redis without url", backend: "redis", wantErr: ErrMissingRedisURL},
+		{name: "unknown backend", backend: "memcached", wantErr: ErrInvalidRateLimitBackend},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			if tt.backend != "" {
+				os.Setenv("RATE_LIMIT_BACKEND", tt.backend)
+			}
+			if tt.redisURL != "" {
+				os.Setenv("REDIS_URL", tt.redisURL)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if cfg.RateLimitBackend != tt.wantBackend {
+				t.Errorf("Expected RateLimitBackend %q, got %q", tt.wantBackend, cfg.RateLimitBackend)
+			}
+			if cfg.RedisURL != tt.redisURL {
+				t.Errorf("Expected RedisURL %q, got %q", tt.redisURL, cfg.RedisURL)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_AnalyticsTopN(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.AnalyticsTopN != 10 {
+		t.Errorf("Expected default AnalyticsTopN 10, got %d", cfg.AnalyticsTopN)
+	}
+
+	os.Setenv("ANALYTICS_TOP_N", "25")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.AnalyticsTopN != 25 {
+		t.Errorf("Expected AnalyticsTopN 25, got %d", cfg.AnalyticsTopN)
+	}
+
+	for _, invalid := range []string{"0", "-5", "101"} {
+		os.Setenv("ANALYTICS_TOP_N", invalid)
+		if _, err := LoadConfig(); !errors.Is(err, ErrInvalidAnalyticsTopN) {
+			t.Errorf("ANALYTICS_TOP_N=%q: expected ErrInvalidAnalyticsTopN, got: %v", invalid, err)
+		}
+	}
+
+	os.Setenv("ANALYTICS_TOP_N", "ten")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotInt) {
+		t.Errorf("ANALYTICS_TOP_N=ten: expected ErrEnvVarNotInt, got: %v", err)
+	}
+}
+
+func TestLoadConfig_ClickRetentionDays(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.ClickRetentionDays != 0 {
+		t.Errorf("Expected default ClickRetentionDays 0 (keep forever), got %d", cfg.ClickRetentionDays)
+	}
+
+	os.Setenv("CLICK_RETENTION_DAYS", "90")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.ClickRetentionDays != 90 {
+		t.Errorf("Expected ClickRetentionDays 90, got %d", cfg.ClickRetentionDays)
+	}
+
+	os.Setenv("CLICK_RETENTION_DAYS", "-1")
+	if _, err := LoadConfig(); !errors.Is(err, ErrInvalidClickRetentionDays) {
+		t.Errorf("CLICK_RETENTION_DAYS=-1: expected ErrInvalidClickRetentionDays, got: %v", err)
+	}
+
+	os.Setenv("CLICK_RETENTION_DAYS", "forever")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotInt) {
+		t.Errorf("CLICK_RETENTION_DAYS=forever: expected ErrEnvVarNotInt, got: %v", err)
+	}
+}
+
+func TestLoadConfig_LogRedirectSampleRate(t *testing.T) {
 	os.Setenv("DATABASE_URL", "./database.db")
 	os.Setenv("AUTH_TOKEN", "test-token")
-	os.Setenv("REDIRECT_RATE_LIMIT_PER_MINUTE", "10")
-	os.Setenv("API_RATE_LIMIT_PER_MINUTE", "5")
 	defer cleanEnv()
 
-	config, err := LoadConfig()
+	cfg, err := LoadConfig()
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
+	if cfg.LogRedirectSampleRate != 1.0 {
+		t.Errorf("Expected default LogRedirectSampleRate 1.0, got %v", cfg.LogRedirectSampleRate)
+	}
 
-	if config.RedirectRateLimitPerMinute != 10 {
-		t.Errorf("Expected RedirectRateLimitPerMinute to be 10, got: %d", config.RedirectRateLimitPerMinute)
+	for _, valid := range []string{"0", "0.25", "1"} {
+		os.Setenv("LOG_REDIRECT_SAMPLE_RATE", valid)
+		if _, err := LoadConfig(); err != nil {
+			t.Errorf("LOG_REDIRECT_SAMPLE_RATE=%q: expected no error, got: %v", valid, err)
+		}
 	}
 
-	if config.APIRateLimitPerMinute != 5 {
-		t.Errorf("Expected APIRateLimitPerMinute to be 5, got: %d", config.APIRateLimitPerMinute)
+	for _, invalid := range []string{"-0.1", "1.5"} {
+		os.Setenv("LOG_REDIRECT_SAMPLE_RATE", invalid)
+		if _, err := LoadConfig(); !errors.Is(err, ErrInvalidLogRedirectSampleRate) {
+			t.Errorf("LOG_REDIRECT_SAMPLE_RATE=%q: expected ErrInvalidLogRedirectSampleRate, got: %v", invalid, err)
+		}
+	}
+
+	os.Setenv("LOG_REDIRECT_SAMPLE_RATE", "half")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotFloat) {
+		t.Errorf("LOG_REDIRECT_SAMPLE_RATE=half: expected ErrEnvVarNotFloat, got: %v", err)
 	}
 }
 
-func TestLoadConfig_InvalidRateLimits(t *testing.T) {
+func TestLoadConfig_AccessLogPath(t *testing.T) {
 	os.Setenv("DATABASE_URL", "./database.db")
 	os.Setenv("AUTH_TOKEN", "test-token")
-	os.Setenv("REDIRECT_RATE_LIMIT_PER_MINUTE", "0")
-	os.Setenv("API_RATE_LIMIT_PER_MINUTE", "-1")
 	defer cleanEnv()
 
-	_, err := LoadConfig()
-	if err == nil {
-		t.Fatal("Expected error for invalid rate limits, got nil")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.AccessLogPath != "" {
+		t.Errorf("Expected AccessLogPath to default to empty, got %q", cfg.AccessLogPath)
 	}
 
-	if !errors.Is(err, ErrInvalidRedirectRateLimit) {
-		t.Fatalf("Expected ErrInvalidRedirectRateLimit, got: %v", err)
+	os.Setenv("ACCESS_LOG_PATH", "/var/log/mjrwtf/access.log")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.AccessLogPath != "/var/log/mjrwtf/access.log" {
+		t.Errorf("Expected AccessLogPath from env, got %q", cfg.AccessLogPath)
 	}
 }
 
-func TestLoadConfig_RateLimitsInvalidStringsFallbackToDefaults(t *testing.T) {
+func TestLoadConfig_ClickDedupeWindow(t *testing.T) {
 	os.Setenv("DATABASE_URL", "./database.db")
 	os.Setenv("AUTH_TOKEN", "test-token")
-	os.Setenv("REDIRECT_RATE_LIMIT_PER_MINUTE", "abc")
-	os.Setenv("API_RATE_LIMIT_PER_MINUTE", "def")
 	defer cleanEnv()
 
-	_, err := LoadConfig()
-	if err == nil {
-		t.Fatal("Expected error for invalid rate limit values, got nil")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
 	}
-
-	if !errors.Is(err, ErrEnvVarNotInt) {
-		t.Fatalf("Expected ErrEnvVarNotInt, got: %v", err)
+	if cfg.ClickDedupeWindow != 0 {
+		t.Errorf("Expected ClickDedupeWindow to default to 0, got %v", cfg.ClickDedupeWindow)
 	}
-}
 
-func TestLoadConfig_InvalidRedirectClickWorkers(t *testing.T) {
-	os.Setenv("DATABASE_URL", "./database.db")
-	os.Setenv("AUTH_TOKEN", "test-token")
-	os.Setenv("REDIRECT_CLICK_WORKERS", "0")
-	defer cleanEnv()
+	os.Setenv("CLICK_DEDUPE_WINDOW", "2s")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.ClickDedupeWindow != 2*time.Second {
+		t.Errorf("Expected ClickDedupeWindow 2s, got %v", cfg.ClickDedupeWindow)
+	}
 
-	_, err := LoadConfig()
-	if err == nil {
-		t.Fatal("Expected error for invalid REDIRECT_CLICK_WORKERS, got nil")
+	os.Setenv("CLICK_DEDUPE_WINDOW", "-1s")
+	if _, err := LoadConfig(); !errors.Is(err, ErrInvalidClickDedupeWindow) {
+		t.Errorf("CLICK_DEDUPE_WINDOW=-1s: expected ErrInvalidClickDedupeWindow, got: %v", err)
 	}
 
-	if !errors.Is(err, ErrInvalidRedirectClickWorkers) {
-		t.Fatalf("Expected ErrInvalidRedirectClickWorkers, got: %v", err)
+	os.Setenv("CLICK_DEDUPE_WINDOW", "soon")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotDuration) {
+		t.Errorf("CLICK_DEDUPE_WINDOW=soon: expected ErrEnvVarNotDuration, got: %v", err)
 	}
 }
 
-func TestLoadConfig_InvalidRedirectClickQueueSize(t *testing.T) {
+func TestLoadConfig_FilterBotClicks(t *testing.T) {
 	os.Setenv("DATABASE_URL", "./database.db")
 	os.Setenv("AUTH_TOKEN", "test-token")
-	os.Setenv("REDIRECT_CLICK_QUEUE_SIZE", "0")
 	defer cleanEnv()
 
-	_, err := LoadConfig()
-	if err == nil {
-		t.Fatal("Expected error for invalid REDIRECT_CLICK_QUEUE_SIZE, got nil")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.FilterBotClicks {
+		t.Error("Expected default FilterBotClicks to be false")
 	}
 
-	if !errors.Is(err, ErrInvalidRedirectClickQueueSize) {
-		t.Fatalf("Expected ErrInvalidRedirectClickQueueSize, got: %v", err)
+	os.Setenv("FILTER_BOT_CLICKS", "true")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.FilterBotClicks {
+		t.Error("Expected FilterBotClicks to be true")
 	}
 }
 
-func TestLoadConfig_RedirectClickConfigInvalidStrings(t *testing.T) {
+func TestLoadConfig_NotFoundRedirectURL(t *testing.T) {
 	os.Setenv("DATABASE_URL", "./database.db")
 	os.Setenv("AUTH_TOKEN", "test-token")
-	os.Setenv("REDIRECT_CLICK_WORKERS", "abc")
 	defer cleanEnv()
 
-	_, err := LoadConfig()
-	if err == nil {
-		t.Fatal("Expected error for invalid redirect click config values, got nil")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
 	}
-
-	if !errors.Is(err, ErrEnvVarNotInt) {
-		t.Fatalf("Expected ErrEnvVarNotInt, got: %v", err)
+	if cfg.NotFoundRedirectURL != "" {
+		t.Errorf("Expected NotFoundRedirectURL to default to empty, got %q", cfg.NotFoundRedirectURL)
 	}
-}
-
-func TestGetEnvAsInt_InvalidValue(t *testing.T) {
-	os.Setenv("TEST_INT", "not-a-number")
-	defer os.Unsetenv("TEST_INT")
 
-	_, err := getEnvAsInt("TEST_INT", 42)
-	if err == nil {
-		t.Fatal("Expected error for invalid int, got nil")
+	os.Setenv("NOT_FOUND_REDIRECT_URL", "https://example.com/landing")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.NotFoundRedirectURL != "https://example.com/landing" {
+		t.Errorf("Expected NotFoundRedirectURL from env, got %q", cfg.NotFoundRedirectURL)
 	}
 
-	if !errors.Is(err, ErrEnvVarNotInt) {
-		t.Fatalf("Expected ErrEnvVarNotInt, got: %v", err)
+	for _, invalid := range []string{"/landing", "example.com", "ftp://example.com", "javascript:alert(1)", "https://"} {
+		os.Setenv("NOT_FOUND_REDIRECT_URL", invalid)
+		if _, err := LoadConfig(); !errors.Is(err, ErrInvalidNotFoundRedirectURL) {
+			t.Errorf("NOT_FOUND_REDIRECT_URL=%q: expected ErrInvalidNotFoundRedirectURL, got: %v", invalid, err)
+		}
 	}
 }
 
-func TestGetEnvAsInt_EmptyString(t *testing.T) {
-	os.Setenv("TEST_INT", "")
-	defer os.Unsetenv("TEST_INT")
+func TestLoadConfig_RobotsTxt(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
 
-	_, err := getEnvAsInt("TEST_INT", 42)
-	if err == nil {
-		t.Fatal("Expected error for empty int env var, got nil")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
 	}
-
-	if !errors.Is(err, ErrEnvVarEmpty) {
-		t.Fatalf("Expected ErrEnvVarEmpty, got: %v", err)
+	if cfg.RobotsTxt != DefaultRobotsTxt {
+		t.Errorf("Expected default RobotsTxt %q, got %q", DefaultRobotsTxt, cfg.RobotsTxt)
 	}
-}
 
-func TestGetEnvAsBool_InvalidValue(t *testing.T) {
-	os.Setenv("TEST_BOOL", "not-a-bool")
-	defer os.Unsetenv("TEST_BOOL")
-
-	_, err := getEnvAsBool("TEST_BOOL", true)
-	if err == nil {
-		t.Fatal("Expected error for invalid bool, got nil")
+	os.Setenv("ROBOTS_TXT", "User-agent: *\nAllow: /\n")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
 	}
-
-	if !errors.Is(err, ErrEnvVarNotBool) {
-		t.Fatalf("Expected ErrEnvVarNotBool, got: %v", err)
+	if cfg.RobotsTxt != "User-agent: *\nAllow: /\n" {
+		t.Errorf("Expected RobotsTxt from env, got %q", cfg.RobotsTxt)
 	}
 }
 
-func TestGetEnvAsBool_EmptyString(t *testing.T) {
-	os.Setenv("TEST_BOOL", "")
-	defer os.Unsetenv("TEST_BOOL")
-
-	_, err := getEnvAsBool("TEST_BOOL", true)
-	if err == nil {
-		t.Fatal("Expected error for empty bool env var, got nil")
-	}
+func TestLoadConfig_ShortCodeAlphabet(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
 
-	if !errors.Is(err, ErrEnvVarEmpty) {
-		t.Fatalf("Expected ErrEnvVarEmpty, got: %v", err)
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
 	}
-}
-
-func TestGetEnvAsBool_ValidValues(t *testing.T) {
-	testCases := []struct {
-		value    string
-		expected bool
-	}{
-		{"true", true},
-		{"false", false},
-		{"1", true},
-		{"0", false},
-		{"t", true},
-		{"f", false},
-		{"T", true},
-		{"F", false},
-		{"TRUE", true},
-		{"FALSE", false},
+	if cfg.ShortCodeAlphabet != "" {
+		t.Errorf("Expected ShortCodeAlphabet to default to empty (base62), got %q", cfg.ShortCodeAlphabet)
 	}
 
-	for _, tc := range testCases {
-		os.Setenv("TEST_BOOL", tc.value)
-		result, err := getEnvAsBool("TEST_BOOL", false)
+	for _, valid := range []string{"unambiguous", "base62", "0123456789abcdef"} {
+		os.Setenv("SHORTCODE_ALPHABET", valid)
+		cfg, err = LoadConfig()
 		if err != nil {
-			t.Fatalf("Unexpected error for value '%s': %v", tc.value, err)
+			t.Fatalf("SHORTCODE_ALPHABET=%q: expected no error, got: %v", valid, err)
 		}
-		if result != tc.expected {
-			t.Errorf("For value '%s', expected %v, got %v", tc.value, tc.expected, result)
+		if cfg.ShortCodeAlphabet != valid {
+			t.Errorf("Expected ShortCodeAlphabet %q, got %q", valid, cfg.ShortCodeAlphabet)
+		}
+	}
+
+	for _, invalid := range []string{"abc", "abcdefghijklmnopa", "abcdefghijklmnop!"} {
+		os.Setenv("SHORTCODE_ALPHABET", invalid)
+		if _, err := LoadConfig(); !errors.Is(err, ErrInvalidShortCodeAlphabet) {
+			t.Errorf("SHORTCODE_ALPHABET=%q: expected ErrInvalidShortCodeAlphabet, got: %v", invalid, err)
 		}
-		os.Unsetenv("TEST_BOOL")
 	}
 }
 
-func TestGetEnvAsDuration_InvalidValue(t *testing.T) {
-	os.Setenv("TEST_DURATION", "not-a-duration")
-	defer os.Unsetenv("TEST_DURATION")
+func TestLoadConfig_ShortCodeAutoLength(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
 
-	_, err := getEnvAsDuration("TEST_DURATION", 5*time.Second)
-	if err == nil {
-		t.Fatal("Expected error for invalid duration, got nil")
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.ShortCodeAutoLengthDensity != 0 {
+		t.Errorf("Expected ShortCodeAutoLengthDensity to default to 0, got %v", cfg.ShortCodeAutoLengthDensity)
+	}
+	if cfg.ShortCodeAutoLengthRefresh != time.Minute {
+		t.Errorf("Expected ShortCodeAutoLengthRefresh to default to 1m, got %v", cfg.ShortCodeAutoLengthRefresh)
 	}
 
-	if !errors.Is(err, ErrEnvVarNotDuration) {
-		t.Fatalf("Expected ErrEnvVarNotDuration, got: %v", err)
+	os.Setenv("SHORTCODE_AUTO_LENGTH_DENSITY", "0.001")
+	os.Setenv("SHORTCODE_AUTO_LENGTH_REFRESH", "30s")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.ShortCodeAutoLengthDensity != 0.001 {
+		t.Errorf("Expected ShortCodeAutoLengthDensity 0.001, got %v", cfg.ShortCodeAutoLengthDensity)
+	}
+	if cfg.ShortCodeAutoLengthRefresh != 30*time.Second {
+		t.Errorf("Expected ShortCodeAutoLengthRefresh 30s, got %v", cfg.ShortCodeAutoLengthRefresh)
 	}
-}
 
-func TestGetEnvAsDuration_EmptyString(t *testing.T) {
-	os.Setenv("TEST_DURATION", "")
-	defer os.Unsetenv("TEST_DURATION")
+	os.Setenv("SHORTCODE_AUTO_LENGTH_REFRESH", "0s")
+	if _, err := LoadConfig(); !errors.Is(err, ErrInvalidShortCodeAutoLengthRefresh) {
+		t.Errorf("Expected ErrInvalidShortCodeAutoLengthRefresh, got: %v", err)
+	}
+	os.Setenv("SHORTCODE_AUTO_LENGTH_REFRESH", "30s")
 
-	_, err := getEnvAsDuration("TEST_DURATION", 5*time.Second)
-	if err == nil {
-		t.Fatal("Expected error for empty duration env var, got nil")
+	for _, invalid := range []string{"-0.1", "1.5"} {
+		os.Setenv("SHORTCODE_AUTO_LENGTH_DENSITY", invalid)
+		if _, err := LoadConfig(); !errors.Is(err, ErrInvalidShortCodeAutoLengthDensity) {
+			t.Errorf("SHORTCODE_AUTO_LENGTH_DENSITY=%q: expected ErrInvalidShortCodeAutoLengthDensity, got: %v", invalid, err)
+		}
 	}
 
-	if !errors.Is(err, ErrEnvVarEmpty) {
-		t.Fatalf("Expected ErrEnvVarEmpty, got: %v", err)
+	os.Setenv("SHORTCODE_AUTO_LENGTH_DENSITY", "lots")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotFloat) {
+		t.Errorf("Expected ErrEnvVarNotFloat, got: %v", err)
 	}
 }
 
-// cleanEnv clears all environment variables used in tests
 func cleanEnv() {
 	os.Unsetenv("DATABASE_URL")
+	os.Unsetenv("SHORTCODE_AUTO_LENGTH_DENSITY")
+	os.Unsetenv("SHORTCODE_AUTO_LENGTH_REFRESH")
+	os.Unsetenv("SHORTCODE_ALPHABET")
+	os.Unsetenv("ROBOTS_TXT")
+	os.Unsetenv("NOT_FOUND_REDIRECT_URL")
+	os.Unsetenv("FILTER_BOT_CLICKS")
+	os.Unsetenv("CLICK_DEDUPE_WINDOW")
+	os.Unsetenv("ACCESS_LOG_PATH")
+	os.Unsetenv("LOG_REDIRECT_SAMPLE_RATE")
+	os.Unsetenv("ANALYTICS_TOP_N")
+	os.Unsetenv("CLICK_RETENTION_DAYS")
 	os.Unsetenv("SERVER_PORT")
 	os.Unsetenv("AUTH_TOKEN")
 	os.Unsetenv("AUTH_TOKENS")
 	os.Unsetenv("DISCORD_WEBHOOK_URL")
 	os.Unsetenv("GEOIP_ENABLED")
 	os.Unsetenv("GEOIP_DATABASE")
+	os.Unsetenv("UNIQUE_VISITORS_ENABLED")
+	os.Unsetenv("VISITOR_HASH_SALT")
+	os.Unsetenv("SOFT_DELETE_ENABLED")
+	os.Unsetenv("RESERVED_CODES")
+	os.Unsetenv("ALLOWED_URL_SCHEMES")
+	os.Unsetenv("ALLOWED_ORIGINS")
+	os.Unsetenv("ALLOWED_METHODS")
+	os.Unsetenv("ALLOWED_HEADERS")
+	os.Unsetenv("ALLOW_CREDENTIALS")
+	os.Unsetenv("ENABLE_HSTS")
+	os.Unsetenv("HSTS_MAX_AGE")
+	os.Unsetenv("CONTENT_SECURITY_POLICY")
+	os.Unsetenv("FRAME_OPTIONS")
+	os.Unsetenv("VALIDATE_OPENAPI")
+	os.Unsetenv("ALLOWED_DESTINATION_HOSTS")
+	os.Unsetenv("BLOCKED_DESTINATION_HOSTS")
+	os.Unsetenv("BLOCK_PRIVATE_DESTINATIONS")
+	os.Unsetenv("STRIP_URL_FRAGMENTS")
+	os.Unsetenv("DESTINATION_RESOLVE_TIMEOUT")
+	os.Unsetenv("DEDUPE_DESTINATIONS")
+	os.Unsetenv("IDEMPOTENCY_KEY_TTL")
 	os.Unsetenv("LOG_LEVEL")
 	os.Unsetenv("LOG_FORMAT")
 	os.Unsetenv("METRICS_AUTH_ENABLED")
+	os.Unsetenv("PPROF_ENABLED")
 	os.Unsetenv("REDIRECT_RATE_LIMIT_PER_MINUTE")
 	os.Unsetenv("API_RATE_LIMIT_PER_MINUTE")
 	os.Unsetenv("REDIRECT_CLICK_WORKERS")
 	os.Unsetenv("REDIRECT_CLICK_QUEUE_SIZE")
+	os.Unsetenv("REDIRECT_CLICK_BACKPRESSURE_POLICY")
+	os.Unsetenv("REDIRECT_CLICK_ENQUEUE_TIMEOUT")
+	os.Unsetenv("REDIRECT_CLICK_RETRY_ATTEMPTS")
+	os.Unsetenv("REDIRECT_CLICK_RETRY_DELAY")
+	os.Unsetenv("NOT_FOUND_ALERT_THRESHOLD")
+	os.Unsetenv("NOT_FOUND_ALERT_WINDOW")
+	os.Unsetenv("DISCORD_CREATE_WEBHOOK_URL")
+	os.Unsetenv("NOTIFY_CREATE_FULL_URL")
+	os.Unsetenv("NOTIFIER_KIND")
+	os.Unsetenv("SLACK_WEBHOOK_URL")
+	os.Unsetenv("SLACK_CREATE_WEBHOOK_URL")
+	os.Unsetenv("EVENT_WEBHOOK_URL")
+	os.Unsetenv("NOTIFY_EVENTS")
+	os.Unsetenv("OTEL_ENABLED")
+	os.Unsetenv("URL_CACHE_SIZE")
+	os.Unsetenv("URL_CACHE_TTL")
+	os.Unsetenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	os.Unsetenv("OTEL_SERVICE_NAME")
+	os.Unsetenv("EVENT_WEBHOOK_SECRET")
 	os.Unsetenv("DB_TIMEOUT")
+	os.Unsetenv("AUTO_MIGRATE")
+	os.Unsetenv("DB_MAX_OPEN_CONNS")
+	os.Unsetenv("DB_MAX_IDLE_CONNS")
+	os.Unsetenv("DB_CONN_MAX_LIFETIME")
+	os.Unsetenv("TRUST_PROXY_HEADERS")
+	os.Unsetenv("TRUSTED_PROXIES")
+	os.Unsetenv("RATE_LIMIT_ALLOWLIST")
+	os.Unsetenv("RATE_LIMIT_BACKEND")
+	os.Unsetenv("REDIS_URL")
+	os.Unsetenv("SESSION_DURATION")
+	os.Unsetenv("SESSION_IDLE_TIMEOUT")
+	os.Unsetenv("SESSION_CLEANUP_INTERVAL")
 	os.Unsetenv("URL_STATUS_CHECKER_ENABLED")
 	os.Unsetenv("URL_STATUS_CHECKER_POLL_INTERVAL")
 	os.Unsetenv("URL_STATUS_CHECKER_ALIVE_RECHECK_INTERVAL")
@@ -785,6 +2245,34 @@ func TestLoadConfig_MetricsAuthEnabled(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_PprofEnabled(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if config.PprofEnabled {
+		t.Error("Expected default PprofEnabled to be false")
+	}
+
+	os.Setenv("PPROF_ENABLED", "true")
+	config, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !config.PprofEnabled {
+		t.Error("Expected PprofEnabled to be true")
+	}
+
+	os.Setenv("PPROF_ENABLED", "sometimes")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotBool) {
+		t.Errorf("PPROF_ENABLED=sometimes: expected ErrEnvVarNotBool, got: %v", err)
+	}
+}
+
 func TestLoadConfig_MetricsAuthDefaultFalse(t *testing.T) {
 	os.Setenv("DATABASE_URL", "./database.db")
 	os.Setenv("AUTH_TOKEN", "test-token")
@@ -800,6 +2288,34 @@ func TestLoadConfig_MetricsAuthDefaultFalse(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_AutoMigrate(t *testing.T) {
+	os.Setenv("DATABASE_URL", "./database.db")
+	os.Setenv("AUTH_TOKEN", "test-token")
+	defer cleanEnv()
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if cfg.AutoMigrate {
+		t.Error("Expected AutoMigrate to default to false")
+	}
+
+	os.Setenv("AUTO_MIGRATE", "true")
+	cfg, err = LoadConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if !cfg.AutoMigrate {
+		t.Error("Expected AutoMigrate to be true")
+	}
+
+	os.Setenv("AUTO_MIGRATE", "sometimes")
+	if _, err := LoadConfig(); !errors.Is(err, ErrEnvVarNotBool) {
+		t.Fatalf("Expected ErrEnvVarNotBool, got: %v", err)
+	}
+}
+
 func TestLoadConfig_DBTimeoutDefault(t *testing.T) {
 	os.Setenv("DATABASE_URL", "./database.db")
 	os.Setenv("AUTH_TOKEN", "test-token")
@@ -848,3 +2364,61 @@ func TestLoadConfig_InvalidDBTimeout(t *testing.T) {
 		t.Fatalf("Expected ErrEnvVarNotDuration, got: %v", err)
 	}
 }
+
+func TestLoadConfig_DBPool(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          map[string]string
+		wantOpen     int
+		wantIdle     int
+		wantLifetime time.Duration
+		wantErr      error
+	}{
+		{name: "sqlite defaults", wantOpen: 1, wantIdle: 1},
+		{
+			name:         "custom values",
+			env:          map[string]string{"DB_MAX_OPEN_CONNS": "8", "DB_MAX_IDLE_CONNS": "2", "DB_CONN_MAX_LIFETIME": "30m"},
+			wantOpen:     8,
+			wantIdle:     2,
+			wantLifetime: 30 * time.Minute,
+		},
+		{name: "idle defaults to open", env: map[string]string{"DB_MAX_OPEN_CONNS": "4"}, wantOpen: 4, wantIdle: 4},
+		{name: "zero idle", env: map[string]string{"DB_MAX_IDLE_CONNS": "0"}, wantOpen: 1, wantIdle: 0},
+		{name: "idle above open", env: map[string]string{"DB_MAX_OPEN_CONNS": "2", "DB_MAX_IDLE_CONNS": "3"}, wantErr: ErrInvalidDBMaxIdleConns},
+		{name: "negative idle", env: map[string]string{"DB_MAX_IDLE_CONNS": "-1"}, wantErr: ErrInvalidDBMaxIdleConns},
+		{name: "zero open", env: map[string]string{"DB_MAX_OPEN_CONNS": "0"}, wantErr: ErrInvalidDBMaxOpenConns},
+		{name: "negative lifetime", env: map[string]string{"DB_CONN_MAX_LIFETIME": "-1s"}, wantErr: ErrInvalidDBConnMaxLifetime},
+		{name: "invalid open", env: map[string]string{"DB_MAX_OPEN_CONNS": "many"}, wantErr: ErrEnvVarNotInt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("DATABASE_URL", "./database.db")
+			os.Setenv("AUTH_TOKEN", "test-token")
+			for k, v := range tt.env {
+				os.Setenv(k, v)
+			}
+			defer cleanEnv()
+
+			cfg, err := LoadConfig()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Expected %v, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if cfg.DBMaxOpenConns != tt.wantOpen {
+				t.Errorf("Expected DBMaxOpenConns %d, got %d", tt.wantOpen, cfg.DBMaxOpenConns)
+			}
+			if cfg.DBMaxIdleConns != tt.wantIdle {
+				t.Errorf("Expected DBMaxIdleConns %d, got %d", tt.wantIdle, cfg.DBMaxIdleConns)
+			}
+			if cfg.DBConnMaxLifetime != tt.wantLifetime {
+				t.Errorf("Expected DBConnMaxLifetime %v, got %v", tt.wantLifetime, cfg.DBConnMaxLifetime)
+			}
+		})
+	}
+}
@@ -11,6 +11,8 @@ var (
 	ErrMissingAuthToken = errors.New("AUTH_TOKEN is required")
 	// ErrInvalidServerPortRange is returned when SERVER_PORT is outside the range 1..65535.
 	ErrInvalidServerPortRange = errors.New("SERVER_PORT must be between 1 and 65535")
+	// ErrInvalidMaxRequestBodyBytes is returned when MAX_REQUEST_BODY_BYTES is < 1.
+	ErrInvalidMaxRequestBodyBytes = errors.New("MAX_REQUEST_BODY_BYTES must be greater than 0")
 	// ErrInvalidRedirectRateLimit is returned when REDIRECT_RATE_LIMIT_PER_MINUTE is < 1.
 	ErrInvalidRedirectRateLimit = errors.New("REDIRECT_RATE_LIMIT_PER_MINUTE must be greater than 0")
 	// ErrInvalidAPIRateLimit is returned when API_RATE_LIMIT_PER_MINUTE is < 1.
@@ -19,8 +21,82 @@ var (
 	ErrInvalidRedirectClickWorkers = errors.New("REDIRECT_CLICK_WORKERS must be greater than 0")
 	// ErrInvalidRedirectClickQueueSize is returned when REDIRECT_CLICK_QUEUE_SIZE is < 1.
 	ErrInvalidRedirectClickQueueSize = errors.New("REDIRECT_CLICK_QUEUE_SIZE must be greater than 0")
+	// ErrInvalidRedirectClickBackpressurePolicy is returned when REDIRECT_CLICK_BACKPRESSURE_POLICY is not a known policy.
+	ErrInvalidRedirectClickBackpressurePolicy = errors.New("REDIRECT_CLICK_BACKPRESSURE_POLICY must be one of: drop, block-with-timeout")
+	// ErrInvalidRedirectClickEnqueueTimeout is returned when REDIRECT_CLICK_ENQUEUE_TIMEOUT is <= 0 under block-with-timeout.
+	ErrInvalidRedirectClickEnqueueTimeout = errors.New("REDIRECT_CLICK_ENQUEUE_TIMEOUT must be greater than 0")
+	// ErrInvalidRedirectClickRetryAttempts is returned when REDIRECT_CLICK_RETRY_ATTEMPTS is < 1.
+	ErrInvalidRedirectClickRetryAttempts = errors.New("REDIRECT_CLICK_RETRY_ATTEMPTS must be at least 1")
+	// ErrInvalidRedirectClickRetryDelay is returned when REDIRECT_CLICK_RETRY_DELAY is <= 0 while retries are enabled.
+	ErrInvalidRedirectClickRetryDelay = errors.New("REDIRECT_CLICK_RETRY_DELAY must be greater than 0")
+	// ErrInvalidClickDedupeWindow is returned when CLICK_DEDUPE_WINDOW is negative.
+	ErrInvalidClickDedupeWindow = errors.New("CLICK_DEDUPE_WINDOW must be 0 (disabled) or greater")
+	// ErrInvalidAnalyticsTopN is returned when ANALYTICS_TOP_N is outside 1-100.
+	ErrInvalidAnalyticsTopN = errors.New("ANALYTICS_TOP_N must be between 1 and 100")
+	// ErrInvalidClickRetentionDays is returned when CLICK_RETENTION_DAYS is negative.
+	ErrInvalidClickRetentionDays = errors.New("CLICK_RETENTION_DAYS must be 0 (keep forever) or greater")
+	// ErrInvalidSessionDuration is returned when SESSION_DURATION is <= 0.
+	ErrInvalidSessionDuration = errors.New("SESSION_DURATION must be greater than 0")
+	// ErrInvalidSessionIdleTimeout is returned when SESSION_IDLE_TIMEOUT is negative.
+	ErrInvalidSessionIdleTimeout = errors.New("SESSION_IDLE_TIMEOUT must be 0 (disabled) or greater")
+	// ErrInvalidSessionCleanupInterval is returned when SESSION_CLEANUP_INTERVAL is <= 0.
+	ErrInvalidSessionCleanupInterval = errors.New("SESSION_CLEANUP_INTERVAL must be greater than 0")
+	// ErrInvalidIdempotencyKeyTTL is returned when IDEMPOTENCY_KEY_TTL is <= 0.
+	ErrInvalidIdempotencyKeyTTL = errors.New("IDEMPOTENCY_KEY_TTL must be greater than 0")
+	// ErrInvalidDBMaxOpenConns is returned when DB_MAX_OPEN_CONNS is < 1.
+	ErrInvalidDBMaxOpenConns = errors.New("DB_MAX_OPEN_CONNS must be at least 1")
+	// ErrInvalidDBMaxIdleConns is returned when DB_MAX_IDLE_CONNS is negative or greater than DB_MAX_OPEN_CONNS.
+	ErrInvalidDBMaxIdleConns = errors.New("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+	// ErrInvalidDBConnMaxLifetime is returned when DB_CONN_MAX_LIFETIME is negative.
+	ErrInvalidDBConnMaxLifetime = errors.New("DB_CONN_MAX_LIFETIME must be 0 (no limit) or greater")
+	// ErrInvalidURLCacheSize is returned when URL_CACHE_SIZE is negative.
+	ErrInvalidURLCacheSize = errors.New("URL_CACHE_SIZE must be 0 (disabled) or greater")
+	// ErrInvalidURLCacheTTL is returned when URL_CACHE_TTL is <= 0 while the cache is enabled.
+	ErrInvalidURLCacheTTL = errors.New("URL_CACHE_TTL must be greater than 0")
+	// ErrInvalidRateLimitBackend is returned when RATE_LIMIT_BACKEND is not memory or redis.
+	ErrInvalidRateLimitBackend = errors.New("RATE_LIMIT_BACKEND must be one of: memory, redis")
+	// ErrMissingRedisURL is returned when RATE_LIMIT_BACKEND is redis but REDIS_URL is not set.
+	ErrMissingRedisURL = errors.New("REDIS_URL is required when RATE_LIMIT_BACKEND is redis")
+	// ErrInvalidHSTSMaxAge is returned when HSTS_MAX_AGE is < 1 while ENABLE_HSTS is true.
+	ErrInvalidHSTSMaxAge = errors.New("HSTS_MAX_AGE must be greater than 0")
+	// ErrInvalidFrameOptions is returned when FRAME_OPTIONS is not DENY or SAMEORIGIN.
+	ErrInvalidFrameOptions = errors.New("FRAME_OPTIONS must be one of: DENY, SAMEORIGIN")
+	// ErrInvalidAllowedMethod is returned when an ALLOWED_METHODS entry is not a valid HTTP method name.
+	ErrInvalidAllowedMethod = errors.New("ALLOWED_METHODS entries must be HTTP method names")
+	// ErrInvalidAllowedHeader is returned when an ALLOWED_HEADERS entry is not a valid header name or "*".
+	ErrInvalidAllowedHeader = errors.New("ALLOWED_HEADERS entries must be header names or *")
+	// ErrCredentialsWithWildcardOrigin is returned when ALLOW_CREDENTIALS is true while ALLOWED_ORIGINS includes "*".
+	ErrCredentialsWithWildcardOrigin = errors.New("ALLOW_CREDENTIALS requires ALLOWED_ORIGINS to list specific origins, not *")
+	// ErrInvalidAllowedURLScheme is returned when an ALLOWED_URL_SCHEMES entry is not a valid scheme or is unsafe (data, file, javascript, vbscript).
+	ErrInvalidAllowedURLScheme = errors.New("ALLOWED_URL_SCHEMES entries must be valid, safe URL schemes")
+	// ErrInvalidDestinationHostPattern is returned when an ALLOWED_/BLOCKED_DESTINATION_HOSTS entry uses "*" other than as a leading "*." wildcard.
+	ErrInvalidDestinationHostPattern = errors.New("destination host patterns must be a host or *.domain")
+	// ErrInvalidDestinationResolveTimeout is returned when DESTINATION_RESOLVE_TIMEOUT is <= 0 while BLOCK_PRIVATE_DESTINATIONS is on.
+	ErrInvalidDestinationResolveTimeout = errors.New("DESTINATION_RESOLVE_TIMEOUT must be greater than 0")
+	// ErrInvalidNotifierKind is returned when NOTIFIER_KIND is not discord, slack or none.
+	ErrInvalidNotifierKind = errors.New("NOTIFIER_KIND must be one of: discord, slack, none")
+	// ErrUnknownNotifyEvent is returned when NOTIFY_EVENTS names an event that doesn't exist.
+	ErrUnknownNotifyEvent = errors.New("NOTIFY_EVENTS entries must be one of: panic, created, 404-spike")
+	// ErrInvalidNotFoundAlertThreshold is returned when NOT_FOUND_ALERT_THRESHOLD is negative.
+	ErrInvalidNotFoundAlertThreshold = errors.New("NOT_FOUND_ALERT_THRESHOLD must be 0 (disabled) or greater")
+	// ErrInvalidNotFoundAlertWindow is returned when NOT_FOUND_ALERT_WINDOW is <= 0 while alerts are enabled.
+	ErrInvalidNotFoundAlertWindow = errors.New("NOT_FOUND_ALERT_WINDOW must be greater than 0")
+	// ErrInvalidNotFoundRedirectURL is returned when NOT_FOUND_REDIRECT_URL is not an absolute http(s) URL.
+	ErrInvalidNotFoundRedirectURL = errors.New("NOT_FOUND_REDIRECT_URL must be an absolute http or https URL")
+	// ErrInvalidShortCodeAlphabet is returned when SHORTCODE_ALPHABET is neither a preset nor a valid set of characters.
+	ErrInvalidShortCodeAlphabet = errors.New("SHORTCODE_ALPHABET must be base62, unambiguous or at least 16 distinct characters from a-z, A-Z, 0-9, _ and -")
+	// ErrInvalidShortCodeAutoLengthDensity is returned when SHORTCODE_AUTO_LENGTH_DENSITY is outside 0.0-1.0.
+	ErrInvalidShortCodeAutoLengthDensity = errors.New("SHORTCODE_AUTO_LENGTH_DENSITY must be between 0.0 (disabled) and 1.0")
+	// ErrInvalidShortCodeAutoLengthRefresh is returned when SHORTCODE_AUTO_LENGTH_REFRESH is <= 0 while auto length scaling is enabled.
+	ErrInvalidShortCodeAutoLengthRefresh = errors.New("SHORTCODE_AUTO_LENGTH_REFRESH must be greater than 0")
+	// ErrMissingOTelEndpoint is returned when OTEL_ENABLED is true but OTEL_EXPORTER_OTLP_ENDPOINT is not set.
+	ErrMissingOTelEndpoint = errors.New("OTEL_EXPORTER_OTLP_ENDPOINT is required when OTEL_ENABLED is true")
+	// ErrInvalidLogRedirectSampleRate is returned when LOG_REDIRECT_SAMPLE_RATE is outside 0.0-1.0.
+	ErrInvalidLogRedirectSampleRate = errors.New("LOG_REDIRECT_SAMPLE_RATE must be between 0.0 and 1.0")
 	// ErrMissingGeoIPDatabase is returned when GEOIP_ENABLED is true but GEOIP_DATABASE is not set.
 	ErrMissingGeoIPDatabase = errors.New("GEOIP_DATABASE is required when GEOIP_ENABLED is true")
+	// ErrMissingVisitorHashSalt is returned when UNIQUE_VISITORS_ENABLED is true but VISITOR_HASH_SALT is not set.
+	ErrMissingVisitorHashSalt = errors.New("VISITOR_HASH_SALT is required when UNIQUE_VISITORS_ENABLED is true")
 
 	// ErrMissingTailscaleHostname is returned when TAILSCALE_ENABLED is true but TAILSCALE_HOSTNAME is not set.
 	ErrMissingTailscaleHostname = errors.New("TAILSCALE_HOSTNAME is required when TAILSCALE_ENABLED is true")
@@ -33,6 +109,10 @@ var (
 	ErrEnvVarNotInt = errors.New("must be an integer")
 	// ErrEnvVarNotBool is wrapped when an env var cannot be parsed as a boolean.
 	ErrEnvVarNotBool = errors.New("must be a boolean")
+	// ErrEnvVarNotFloat is wrapped when an env var cannot be parsed as a number.
+	ErrEnvVarNotFloat = errors.New("must be a number")
+	// ErrInvalidCIDR is wrapped when an env var contains an entry that is not a valid CIDR.
+	ErrInvalidCIDR = errors.New("must be a comma-separated list of CIDRs")
 	// ErrEnvVarNotDuration is wrapped when an env var cannot be parsed as a duration.
 	ErrEnvVarNotDuration = errors.New("must be a duration")
 )
@@ -3,10 +3,12 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/matt-riley/mjrwtf/internal/application"
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
 )
 
@@ -15,15 +17,29 @@ type GetAnalyticsUseCase interface {
 	Execute(ctx context.Context, req application.GetAnalyticsRequest) (*application.GetAnalyticsResponse, error)
 }
 
+// GetDailyClicksUseCase defines the interface for a URL's clicks per day
+type GetDailyClicksUseCase interface {
+	Execute(ctx context.Context, req application.GetDailyClicksRequest) ([]application.DailyClicks, error)
+}
+
+// ListClicksUseCase defines the interface for paging through a URL's individual clicks
+type ListClicksUseCase interface {
+	Execute(ctx context.Context, req application.ListClicksRequest) (*application.ListClicksResponse, error)
+}
+
 // AnalyticsHandler handles HTTP requests for analytics operations
 type AnalyticsHandler struct {
-	getAnalyticsUseCase GetAnalyticsUseCase
+	getAnalyticsUseCase   GetAnalyticsUseCase
+	getDailyClicksUseCase GetDailyClicksUseCase
+	listClicksUseCase     ListClicksUseCase
 }
 
 // NewAnalyticsHandler creates a new AnalyticsHandler
-func NewAnalyticsHandler(getAnalyticsUseCase GetAnalyticsUseCase) *AnalyticsHandler {
+func NewAnalyticsHandler(getAnalyticsUseCase GetAnalyticsUseCase, getDailyClicksUseCase GetDailyClicksUseCase, listClicksUseCase ListClicksUseCase) *AnalyticsHandler {
 	return &AnalyticsHandler{
-		getAnalyticsUseCase: getAnalyticsUseCase,
+		getAnalyticsUseCase:   getAnalyticsUseCase,
+		getDailyClicksUseCase: getDailyClicksUseCase,
+		listClicksUseCase:     listClicksUseCase,
 	}
 }
 
@@ -42,6 +58,7 @@ func (h *AnalyticsHandler) GetAnalytics(w http.ResponseWriter, r *http.Request)
 		respondError(w, "short_code is required", http.StatusBadRequest)
 		return
 	}
+	r = withShortCodeLogger(r, shortCode)
 
 	// Parse optional time range parameters
 	var startTime, endTime *time.Time
@@ -66,24 +83,149 @@ func (h *AnalyticsHandler) GetAnalytics(w http.ResponseWriter, r *http.Request)
 		endTime = &t
 	}
 
-	// Both start_time and end_time must be provided together
-	if (startTime != nil && endTime == nil) || (startTime == nil && endTime != nil) {
-		respondError(w, "both start_time and end_time must be provided for time range queries", http.StatusBadRequest)
+	// start_time and end_time must be provided together, with start strictly before end
+	if err := click.ValidateTimeRange(startTime, endTime); err != nil {
+		handleDomainError(w, err)
 		return
 	}
 
-	// Validate that start_time is strictly before end_time (equality not allowed)
-	if startTime != nil && endTime != nil && !startTime.Before(*endTime) {
-		respondError(w, "start_time must be strictly before end_time (equality not allowed)", http.StatusBadRequest)
+	// Optional referrer grouping: full referrer URL (default) or referrer domain
+	group := r.URL.Query().Get("group")
+	if group != "" && group != application.ReferrerGroupURL && group != application.ReferrerGroupDomain {
+		respondError(w, "invalid group, use 'url' or 'domain'", http.StatusBadRequest)
 		return
 	}
 
+	// Optional number of referrers to return; values above the maximum are clamped by the use case
+	var topN int
+	if topStr := r.URL.Query().Get("top"); topStr != "" {
+		n, err := strconv.Atoi(topStr)
+		if err != nil || n < 1 {
+			respondError(w, "invalid top, must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		topN = n
+	}
+
+	// Optional time-range bucketing: per day or per hour
+	granularity := click.Granularity(r.URL.Query().Get("granularity"))
+	if granularity != "" {
+		if granularity != click.GranularityDay && granularity != click.GranularityHour {
+			respondError(w, "invalid granularity, use 'day' or 'hour'", http.StatusBadRequest)
+			return
+		}
+		if startTime == nil {
+			respondError(w, "granularity requires start_time and end_time", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Execute use case
 	resp, err := h.getAnalyticsUseCase.Execute(r.Context(), application.GetAnalyticsRequest{
+		ShortCode:        shortCode,
+		RequestedBy:      userID,
+		StartTime:        startTime,
+		EndTime:          endTime,
+		GroupReferrersBy: group,
+		TopN:             topN,
+		Granularity:      granularity,
+	})
+
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	// Respond with success; dashboards poll this, so support conditional requests
+	respondWithETag(w, r, userID, resp)
+}
+
+// GetDailyClicks handles GET /api/urls/{shortCode}/analytics/daily - Clicks per day for charting
+func (h *AnalyticsHandler) GetDailyClicks(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Extract short code from URL path
+	shortCode := chi.URLParam(r, "shortCode")
+	if shortCode == "" {
+		respondError(w, "short_code is required", http.StatusBadRequest)
+		return
+	}
+	r = withShortCodeLogger(r, shortCode)
+
+	// Parse optional UTC dates; the use case fills in the last 30 days
+	var startDate, endDate *time.Time
+	if s := r.URL.Query().Get("start_date"); s != "" {
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			respondError(w, "invalid start_date format, use YYYY-MM-DD (e.g., 2025-01-01)", http.StatusBadRequest)
+			return
+		}
+		startDate = &t
+	}
+	if s := r.URL.Query().Get("end_date"); s != "" {
+		t, err := time.Parse(time.DateOnly, s)
+		if err != nil {
+			respondError(w, "invalid end_date format, use YYYY-MM-DD (e.g., 2025-01-31)", http.StatusBadRequest)
+			return
+		}
+		endDate = &t
+	}
+
+	// Execute use case
+	series, err := h.getDailyClicksUseCase.Execute(r.Context(), application.GetDailyClicksRequest{
+		ShortCode:   shortCode,
+		RequestedBy: userID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+	})
+
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	// Respond with success
+	respond(w, r, http.StatusOK, series)
+}
+
+// ListClicks handles GET /api/urls/{shortCode}/clicks - Page through a URL's individual clicks
+func (h *AnalyticsHandler) ListClicks(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Extract short code from URL path
+	shortCode := chi.URLParam(r, "shortCode")
+	if shortCode == "" {
+		respondError(w, "short_code is required", http.StatusBadRequest)
+		return
+	}
+	r = withShortCodeLogger(r, shortCode)
+
+	// Parse pagination parameters
+	limit := parseQueryInt(r, "limit", 20)
+	offset := parseQueryInt(r, "offset", 0)
+
+	// Validate pagination parameters
+	if limit < 0 || offset < 0 {
+		respondError(w, "limit and offset must be non-negative", http.StatusBadRequest)
+		return
+	}
+
+	// Execute use case
+	resp, err := h.listClicksUseCase.Execute(r.Context(), application.ListClicksRequest{
 		ShortCode:   shortCode,
 		RequestedBy: userID,
-		StartTime:   startTime,
-		EndTime:     endTime,
+		Limit:       limit,
+		Offset:      offset,
 	})
 
 	if err != nil {
@@ -92,5 +234,5 @@ func (h *AnalyticsHandler) GetAnalytics(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Respond with success
-	respondJSON(w, resp, http.StatusOK)
+	respond(w, r, http.StatusOK, resp)
 }
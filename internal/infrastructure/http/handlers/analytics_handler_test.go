@@ -6,11 +6,13 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/matt-riley/mjrwtf/internal/application"
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
 )
@@ -27,6 +29,28 @@ func (m *mockGetAnalyticsUseCase) Execute(ctx context.Context, req application.G
 	return nil, errors.New("not implemented")
 }
 
+type mockGetDailyClicksUseCase struct {
+	executeFunc func(ctx context.Context, req application.GetDailyClicksRequest) ([]application.DailyClicks, error)
+}
+
+func (m *mockGetDailyClicksUseCase) Execute(ctx context.Context, req application.GetDailyClicksRequest) ([]application.DailyClicks, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+type mockListClicksUseCase struct {
+	executeFunc func(ctx context.Context, req application.ListClicksRequest) (*application.ListClicksResponse, error)
+}
+
+func (m *mockListClicksUseCase) Execute(ctx context.Context, req application.ListClicksRequest) (*application.ListClicksResponse, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
 // Helper function to add user ID to context
 func withUserIDForAnalytics(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, middleware.UserIDKey, userID)
@@ -61,7 +85,7 @@ func TestAnalyticsHandler_GetAnalytics_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewAnalyticsHandler(useCase)
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
 
 	// Create router with chi
 	r := chi.NewRouter()
@@ -128,7 +152,7 @@ func TestAnalyticsHandler_GetAnalytics_WithTimeRange(t *testing.T) {
 		},
 	}
 
-	handler := NewAnalyticsHandler(useCase)
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
 
 	// Create router with chi
 	r := chi.NewRouter()
@@ -170,7 +194,7 @@ func TestAnalyticsHandler_GetAnalytics_NotFound(t *testing.T) {
 		},
 	}
 
-	handler := NewAnalyticsHandler(useCase)
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
 
 	// Create router with chi
 	r := chi.NewRouter()
@@ -197,7 +221,7 @@ func TestAnalyticsHandler_GetAnalytics_Unauthorized(t *testing.T) {
 		},
 	}
 
-	handler := NewAnalyticsHandler(useCase)
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
 
 	// Create router with chi
 	r := chi.NewRouter()
@@ -224,7 +248,7 @@ func TestAnalyticsHandler_GetAnalytics_NoUserInContext(t *testing.T) {
 		},
 	}
 
-	handler := NewAnalyticsHandler(useCase)
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
 
 	// Create router with chi
 	r := chi.NewRouter()
@@ -250,7 +274,7 @@ func TestAnalyticsHandler_GetAnalytics_InvalidTimeFormat(t *testing.T) {
 		},
 	}
 
-	handler := NewAnalyticsHandler(useCase)
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
 
 	// Create router with chi
 	r := chi.NewRouter()
@@ -269,13 +293,70 @@ func TestAnalyticsHandler_GetAnalytics_InvalidTimeFormat(t *testing.T) {
 			url:  "/api/urls/abc123/analytics?start_time=2025-11-20T00:00:00Z&end_time=2025-11-22",
 		},
 		{
-			name: "missing end_time",
+			name: "unknown group",
+			url:  "/api/urls/abc123/analytics?group=path",
+		},
+		{
+			name: "zero top",
+			url:  "/api/urls/abc123/analytics?top=0",
+		},
+		{
+			name: "non-numeric top",
+			url:  "/api/urls/abc123/analytics?top=abc",
+		},
+		{
+			name: "unknown granularity",
+			url:  "/api/urls/abc123/analytics?start_time=2025-11-20T00:00:00Z&end_time=2025-11-22T23:59:59Z&granularity=minute",
+		},
+		{
+			name: "granularity without time range",
+			url:  "/api/urls/abc123/analytics?granularity=hour",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req = req.WithContext(withUserIDForAnalytics(req.Context(), "test-user"))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestAnalyticsHandler_GetAnalytics_InvalidTimeRange(t *testing.T) {
+	useCase := &mockGetAnalyticsUseCase{
+		executeFunc: func(ctx context.Context, req application.GetAnalyticsRequest) (*application.GetAnalyticsResponse, error) {
+			return nil, errors.New("should not be called")
+		},
+	}
+
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
+
+	r := chi.NewRouter()
+	r.Get("/api/urls/{shortCode}/analytics", handler.GetAnalytics)
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{
+			name: "only start_time",
 			url:  "/api/urls/abc123/analytics?start_time=2025-11-20T00:00:00Z",
 		},
 		{
-			name: "missing start_time",
+			name: "only end_time",
 			url:  "/api/urls/abc123/analytics?end_time=2025-11-22T23:59:59Z",
 		},
+		{
+			name: "start_time equal to end_time",
+			url:  "/api/urls/abc123/analytics?start_time=2025-11-20T00:00:00Z&end_time=2025-11-20T00:00:00Z",
+		},
 		{
 			name: "start_time after end_time",
 			url:  "/api/urls/abc123/analytics?start_time=2025-11-22T23:59:59Z&end_time=2025-11-20T00:00:00Z",
@@ -291,7 +372,436 @@ func TestAnalyticsHandler_GetAnalytics_InvalidTimeFormat(t *testing.T) {
 			r.ServeHTTP(w, req)
 
 			if w.Code != http.StatusBadRequest {
-				t.Errorf("expected status 400, got %d", w.Code)
+				t.Fatalf("expected status 400, got %d", w.Code)
+			}
+
+			var resp ErrorResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Code != ErrCodeInvalidTimeRange {
+				t.Errorf("expected code %q, got %q", ErrCodeInvalidTimeRange, resp.Code)
+			}
+		})
+	}
+}
+
+func TestAnalyticsHandler_GetAnalytics_GroupByDomain(t *testing.T) {
+	var got application.GetAnalyticsRequest
+	useCase := &mockGetAnalyticsUseCase{
+		executeFunc: func(ctx context.Context, req application.GetAnalyticsRequest) (*application.GetAnalyticsResponse, error) {
+			got = req
+			return &application.GetAnalyticsResponse{
+				ShortCode:  req.ShortCode,
+				ByReferrer: map[string]int64{"twitter.com": 3},
+			}, nil
+		},
+	}
+
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
+
+	r := chi.NewRouter()
+	r.Get("/api/urls/{shortCode}/analytics", handler.GetAnalytics)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/urls/abc123/analytics?group=domain", nil)
+	req = req.WithContext(withUserIDForAnalytics(req.Context(), "test-user"))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got.GroupReferrersBy != application.ReferrerGroupDomain {
+		t.Errorf("expected GroupReferrersBy %q, got %q", application.ReferrerGroupDomain, got.GroupReferrersBy)
+	}
+
+	var resp application.GetAnalyticsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ByReferrer["twitter.com"] != 3 {
+		t.Errorf("expected by_referrer twitter.com=3, got %v", resp.ByReferrer)
+	}
+}
+
+func TestAnalyticsHandler_GetAnalytics_TopN(t *testing.T) {
+	var got application.GetAnalyticsRequest
+	useCase := &mockGetAnalyticsUseCase{
+		executeFunc: func(ctx context.Context, req application.GetAnalyticsRequest) (*application.GetAnalyticsResponse, error) {
+			got = req
+			return &application.GetAnalyticsResponse{ShortCode: req.ShortCode}, nil
+		},
+	}
+
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
+
+	r := chi.NewRouter()
+	r.Get("/api/urls/{shortCode}/analytics", handler.GetAnalytics)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/urls/abc123/analytics?top=25", nil)
+	req = req.WithContext(withUserIDForAnalytics(req.Context(), "test-user"))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got.TopN != 25 {
+		t.Errorf("expected TopN 25, got %d", got.TopN)
+	}
+}
+
+func TestAnalyticsHandler_GetAnalytics_HourlyGranularity(t *testing.T) {
+	var got application.GetAnalyticsRequest
+	useCase := &mockGetAnalyticsUseCase{
+		executeFunc: func(ctx context.Context, req application.GetAnalyticsRequest) (*application.GetAnalyticsResponse, error) {
+			got = req
+			return &application.GetAnalyticsResponse{
+				ShortCode: req.ShortCode,
+				ByHour:    map[string]int64{"2025-11-20 09:00": 2},
+			}, nil
+		},
+	}
+
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
+
+	r := chi.NewRouter()
+	r.Get("/api/urls/{shortCode}/analytics", handler.GetAnalytics)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/urls/abc123/analytics?start_time=2025-11-20T00:00:00Z&end_time=2025-11-20T23:59:59Z&granularity=hour", nil)
+	req = req.WithContext(withUserIDForAnalytics(req.Context(), "test-user"))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if got.Granularity != click.GranularityHour {
+		t.Errorf("expected Granularity %q, got %q", click.GranularityHour, got.Granularity)
+	}
+
+	var resp application.GetAnalyticsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ByHour["2025-11-20 09:00"] != 2 {
+		t.Errorf("expected by_hour 2025-11-20 09:00=2, got %v", resp.ByHour)
+	}
+}
+
+func TestAnalyticsHandler_GetAnalytics_ETag(t *testing.T) {
+	totalClicks := int64(3)
+	useCase := &mockGetAnalyticsUseCase{
+		executeFunc: func(ctx context.Context, req application.GetAnalyticsRequest) (*application.GetAnalyticsResponse, error) {
+			return &application.GetAnalyticsResponse{
+				ShortCode:   "abc123",
+				OriginalURL: "https://example.com",
+				TotalClicks: totalClicks,
+				ByCountry:   map[string]int64{"US": 2, "UK": 1},
+			}, nil
+		},
+	}
+
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
+
+	r := chi.NewRouter()
+	r.Get("/api/urls/{shortCode}/analytics", handler.GetAnalytics)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/urls/abc123/analytics", nil)
+		req = req.WithContext(withUserIDForAnalytics(req.Context(), "test-user"))
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("")
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", first.Code)
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+
+	if w := get(`"unrelated", ` + etag); w.Code != http.StatusNotModified {
+		t.Fatalf("expected status 304 for a matching If-None-Match, got %d", w.Code)
+	}
+
+	totalClicks++
+	w := get(etag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 after new clicks, got %d", w.Code)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Error("expected ETag to change after new clicks")
+	}
+}
+
+func TestAnalyticsHandler_GetAnalytics_ContentNegotiation(t *testing.T) {
+	startTime := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	useCase := &mockGetAnalyticsUseCase{
+		executeFunc: func(ctx context.Context, req application.GetAnalyticsRequest) (*application.GetAnalyticsResponse, error) {
+			return &application.GetAnalyticsResponse{
+				ShortCode:   "2025",
+				OriginalURL: "https://example.com",
+				TotalClicks: 3,
+				ByCountry:   map[string]int64{"US": 2, "UK": 1},
+				ByReferrer:  map[string]int64{},
+				StartTime:   &startTime,
+			}, nil
+		},
+	}
+
+	handler := NewAnalyticsHandler(useCase, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{})
+
+	r := chi.NewRouter()
+	r.Get("/api/urls/{shortCode}/analytics", handler.GetAnalytics)
+
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/urls/2025/analytics", nil)
+		req = req.WithContext(withUserIDForAnalytics(req.Context(), "test-user"))
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("yaml when requested", func(t *testing.T) {
+		w := get("application/yaml")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); got != "application/yaml" {
+			t.Errorf("expected Content-Type application/yaml, got %q", got)
+		}
+
+		want := `short_code: "2025"
+original_url: https://example.com
+total_clicks: 3
+by_country:
+  UK: 1
+  US: 2
+by_referrer: {}
+by_browser: null
+by_os: null
+start_time: "2025-11-20T00:00:00Z"
+`
+		if got := w.Body.String(); got != want {
+			t.Errorf("unexpected YAML body:\n%s\nwant:\n%s", got, want)
+		}
+	})
+
+	t.Run("json by default", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "application/json"} {
+			w := get(accept)
+			if got := w.Header().Get("Content-Type"); got != "application/json" {
+				t.Errorf("Accept %q: expected Content-Type application/json, got %q", accept, got)
+			}
+			var resp application.GetAnalyticsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Accept %q: expected a JSON body, got %v", accept, err)
+			}
+			if resp.ShortCode != "2025" {
+				t.Errorf("Accept %q: expected short_code 2025, got %q", accept, resp.ShortCode)
+			}
+		}
+	})
+
+	t.Run("formats get different ETags", func(t *testing.T) {
+		if get("application/yaml").Header().Get("ETag") == get("application/json").Header().Get("ETag") {
+			t.Error("expected YAML and JSON responses to have different ETags")
+		}
+	})
+}
+
+func TestAnalyticsHandler_GetDailyClicks(t *testing.T) {
+	var gotReq application.GetDailyClicksRequest
+	handler := NewAnalyticsHandler(&mockGetAnalyticsUseCase{}, &mockGetDailyClicksUseCase{
+		executeFunc: func(ctx context.Context, req application.GetDailyClicksRequest) ([]application.DailyClicks, error) {
+			gotReq = req
+			return []application.DailyClicks{
+				{Date: "2025-01-01", Count: 3},
+				{Date: "2025-01-02", Count: 0},
+				{Date: "2025-01-03", Count: 1},
+			}, nil
+		},
+	}, &mockListClicksUseCase{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/urls/abc123/analytics/daily?start_date=2025-01-01&end_date=2025-01-03", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("shortCode", "abc123")
+	req = req.WithContext(context.WithValue(withUserIDForAnalytics(req.Context(), "test-user"), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+
+	handler.GetDailyClicks(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if gotReq.ShortCode != "abc123" || gotReq.RequestedBy != "test-user" {
+		t.Errorf("use case request = %+v", gotReq)
+	}
+	if gotReq.StartDate == nil || !gotReq.StartDate.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) ||
+		gotReq.EndDate == nil || !gotReq.EndDate.Equal(time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("use case range = %v to %v, want 2025-01-01 to 2025-01-03", gotReq.StartDate, gotReq.EndDate)
+	}
+
+	// The body is a bare array, compact enough to feed a chart directly
+	want := `[{"date":"2025-01-01","count":3},{"date":"2025-01-02","count":0},{"date":"2025-01-03","count":1}]`
+	if got := strings.TrimSpace(rec.Body.String()); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestAnalyticsHandler_GetDailyClicks_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		useCaseErr error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "invalid start_date", query: "?start_date=2025-01-01T00:00:00Z", wantStatus: http.StatusBadRequest},
+		{name: "invalid end_date", query: "?end_date=01/03/2025", wantStatus: http.StatusBadRequest},
+		{name: "invalid range", query: "?start_date=2025-01-03&end_date=2025-01-01", useCaseErr: click.ErrInvalidDateRange, wantStatus: http.StatusBadRequest, wantCode: ErrCodeInvalidDateRange},
+		{name: "not found", useCaseErr: url.ErrURLNotFound, wantStatus: http.StatusNotFound, wantCode: ErrCodeURLNotFound},
+		{name: "not the owner", useCaseErr: url.ErrUnauthorizedDeletion, wantStatus: http.StatusForbidden, wantCode: ErrCodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAnalyticsHandler(&mockGetAnalyticsUseCase{}, &mockGetDailyClicksUseCase{
+				executeFunc: func(ctx context.Context, req application.GetDailyClicksRequest) ([]application.DailyClicks, error) {
+					if tt.useCaseErr == nil {
+						t.Error("use case should not run for an invalid date")
+					}
+					return nil, tt.useCaseErr
+				},
+			}, &mockListClicksUseCase{})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/urls/abc123/analytics/daily"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("shortCode", "abc123")
+			req = req.WithContext(context.WithValue(withUserIDForAnalytics(req.Context(), "test-user"), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			handler.GetDailyClicks(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if resp.Code != tt.wantCode {
+					t.Errorf("expected code %q, got %q", tt.wantCode, resp.Code)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyticsHandler_ListClicks(t *testing.T) {
+	var gotReq application.ListClicksRequest
+	clickedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	handler := NewAnalyticsHandler(&mockGetAnalyticsUseCase{}, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{
+		executeFunc: func(ctx context.Context, req application.ListClicksRequest) (*application.ListClicksResponse, error) {
+			gotReq = req
+			return &application.ListClicksResponse{
+				Clicks: []application.ClickResponse{
+					{ID: 9, ClickedAt: clickedAt, ReferrerDomain: "example.org", Country: "GB", Browser: "Firefox", OS: "Linux"},
+				},
+				Total:  6,
+				Limit:  5,
+				Offset: 5,
+			}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/urls/abc123/clicks?limit=5&offset=5", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("shortCode", "abc123")
+	req = req.WithContext(context.WithValue(withUserIDForAnalytics(req.Context(), "test-user"), chi.RouteCtxKey, rctx))
+	rec := httptest.NewRecorder()
+
+	handler.ListClicks(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	want := application.ListClicksRequest{ShortCode: "abc123", RequestedBy: "test-user", Limit: 5, Offset: 5}
+	if gotReq != want {
+		t.Errorf("use case request = %+v, want %+v", gotReq, want)
+	}
+
+	var resp application.ListClicksResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Total != 6 || len(resp.Clicks) != 1 || resp.Clicks[0].Browser != "Firefox" || !resp.Clicks[0].ClickedAt.Equal(clickedAt) {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestAnalyticsHandler_ListClicks_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		shortCode  string
+		query      string
+		useCaseErr error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "negative limit", shortCode: "abc123", query: "?limit=-1", wantStatus: http.StatusBadRequest},
+		{name: "negative offset", shortCode: "abc123", query: "?offset=-5", wantStatus: http.StatusBadRequest},
+		{name: "unknown short code", shortCode: "nope12", useCaseErr: url.ErrURLNotFound, wantStatus: http.StatusNotFound, wantCode: ErrCodeURLNotFound},
+		{name: "another user's URL", shortCode: "abc123", useCaseErr: url.ErrUnauthorizedDeletion, wantStatus: http.StatusForbidden, wantCode: ErrCodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAnalyticsHandler(&mockGetAnalyticsUseCase{}, &mockGetDailyClicksUseCase{}, &mockListClicksUseCase{
+				executeFunc: func(ctx context.Context, req application.ListClicksRequest) (*application.ListClicksResponse, error) {
+					if tt.useCaseErr == nil {
+						t.Error("use case should not run for invalid pagination")
+					}
+					if req.ShortCode != tt.shortCode || req.RequestedBy != "test-user" {
+						t.Errorf("use case request = %+v", req)
+					}
+					return nil, tt.useCaseErr
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/urls/"+tt.shortCode+"/clicks"+tt.query, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("shortCode", tt.shortCode)
+			req = req.WithContext(context.WithValue(withUserIDForAnalytics(req.Context(), "test-user"), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			handler.ListClicks(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode != "" {
+				var resp ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if resp.Code != tt.wantCode {
+					t.Errorf("expected code %q, got %q", tt.wantCode, resp.Code)
+				}
 			}
 		})
 	}
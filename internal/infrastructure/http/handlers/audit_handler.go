@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/matt-riley/mjrwtf/internal/application"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
+)
+
+// ListAuditEntriesUseCase defines the interface for listing audit entries
+type ListAuditEntriesUseCase interface {
+	Execute(ctx context.Context, req application.ListAuditEntriesRequest) (*application.ListAuditEntriesResponse, error)
+}
+
+// AuditHandler handles HTTP requests for the audit trail
+type AuditHandler struct {
+	listUseCase ListAuditEntriesUseCase
+}
+
+// NewAuditHandler creates a new AuditHandler
+func NewAuditHandler(listUseCase ListAuditEntriesUseCase) *AuditHandler {
+	return &AuditHandler{
+		listUseCase: listUseCase,
+	}
+}
+
+// List handles GET /api/audit?short_code=... - List the audit trail for a short code
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	if _, ok := middleware.GetUserID(r.Context()); !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	shortCode := r.URL.Query().Get("short_code")
+	if shortCode == "" {
+		respondError(w, "short_code is required", http.StatusBadRequest)
+		return
+	}
+
+	limit := parseQueryInt(r, "limit", 20)
+	if limit < 0 {
+		respondError(w, "limit must be non-negative", http.StatusBadRequest)
+		return
+	}
+
+	// Execute use case
+	resp, err := h.listUseCase.Execute(r.Context(), application.ListAuditEntriesRequest{
+		ShortCode: shortCode,
+		Limit:     limit,
+	})
+
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	// Respond with success
+	respond(w, r, http.StatusOK, resp)
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/application"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
+)
+
+type mockListAuditEntriesUseCase struct {
+	executeFunc func(ctx context.Context, req application.ListAuditEntriesRequest) (*application.ListAuditEntriesResponse, error)
+}
+
+func (m *mockListAuditEntriesUseCase) Execute(ctx context.Context, req application.ListAuditEntriesRequest) (*application.ListAuditEntriesResponse, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestAuditHandler_List(t *testing.T) {
+	tests := []struct {
+		name           string
+		hasUserID      bool
+		query          string
+		mockError      error
+		expectedStatus int
+		expectedLimit  int
+	}{
+		{
+			name:           "successful list",
+			hasUserID:      true,
+			query:          "?short_code=abc123",
+			expectedStatus: http.StatusOK,
+			expectedLimit:  20,
+		},
+		{
+			name:           "custom limit",
+			hasUserID:      true,
+			query:          "?short_code=abc123&limit=5",
+			expectedStatus: http.StatusOK,
+			expectedLimit:  5,
+		},
+		{
+			name:           "missing short code",
+			hasUserID:      true,
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "negative limit",
+			hasUserID:      true,
+			query:          "?short_code=abc123&limit=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid short code",
+			hasUserID:      true,
+			query:          "?short_code=bad!",
+			mockError:      url.ErrInvalidShortCode,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unauthorized",
+			hasUserID:      false,
+			query:          "?short_code=abc123",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got application.ListAuditEntriesRequest
+			mockList := &mockListAuditEntriesUseCase{
+				executeFunc: func(ctx context.Context, req application.ListAuditEntriesRequest) (*application.ListAuditEntriesResponse, error) {
+					got = req
+					if tt.mockError != nil {
+						return nil, tt.mockError
+					}
+					return &application.ListAuditEntriesResponse{
+						Entries: []application.AuditEntryResponse{
+							{ID: 1, Action: "create", ShortCode: req.ShortCode, UserID: "test-user", CreatedAt: time.Now()},
+						},
+						Limit: req.Limit,
+					}, nil
+				},
+			}
+			handler := NewAuditHandler(mockList)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/audit"+tt.query, nil)
+			if tt.hasUserID {
+				req = req.WithContext(withUserID(req.Context(), "test-user"))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.List(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if got.ShortCode != "abc123" || got.Limit != tt.expectedLimit {
+				t.Errorf("unexpected use case request: %+v", got)
+			}
+			var resp application.ListAuditEntriesResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Entries) != 1 || resp.Entries[0].Action != "create" {
+				t.Errorf("unexpected response: %+v", resp)
+			}
+		})
+	}
+}
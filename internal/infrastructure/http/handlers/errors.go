@@ -9,14 +9,36 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/idempotency"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 )
 
 // ErrorResponse represents a JSON error response
 type ErrorResponse struct {
 	Error string `json:"error"`
+	// Code is a stable, machine-readable identifier; Error may change wording.
+	Code string `json:"code,omitempty"`
 }
 
+// Error codes returned in ErrorResponse.Code.
+const (
+	ErrCodeURLNotFound            = "url_not_found"
+	ErrCodeDuplicateShortCode     = "duplicate_short_code"
+	ErrCodeInvalidShortCode       = "invalid_short_code"
+	ErrCodeInvalidURL             = "invalid_url"
+	ErrCodeDestinationNotAllowed  = "destination_not_allowed"
+	ErrCodeInvalidCreatedBy       = "invalid_created_by"
+	ErrCodeForbidden              = "forbidden"
+	ErrCodeSessionNotFound        = "session_not_found"
+	ErrCodeInvalidTimeRange       = "invalid_time_range"
+	ErrCodeInvalidDateRange       = "invalid_date_range"
+	ErrCodeInvalidIdempotencyKey  = "invalid_idempotency_key"
+	ErrCodeIdempotencyKeyMismatch = "idempotency_key_mismatch"
+	ErrCodeIdempotencyKeyInUse    = "idempotency_key_in_use"
+	ErrCodeInternal               = "internal_error"
+)
+
 // respondJSON writes a JSON response
 func respondJSON(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
@@ -39,34 +61,63 @@ func respondError(w http.ResponseWriter, message string, statusCode int) {
 	respondJSON(w, ErrorResponse{Error: message}, statusCode)
 }
 
+// respondErrorCode writes a JSON error response carrying a machine-readable code
+func respondErrorCode(w http.ResponseWriter, code, message string, statusCode int) {
+	respondJSON(w, ErrorResponse{Error: message, Code: code}, statusCode)
+}
+
 // handleDomainError maps domain errors to HTTP status codes
 // This is a shared helper function used across handlers to maintain consistent error responses
 func handleDomainError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, url.ErrURLNotFound):
-		respondError(w, err.Error(), http.StatusNotFound)
+		respondErrorCode(w, ErrCodeURLNotFound, err.Error(), http.StatusNotFound)
 	case errors.Is(err, url.ErrDuplicateShortCode):
-		respondError(w, err.Error(), http.StatusConflict)
+		respondErrorCode(w, ErrCodeDuplicateShortCode, err.Error(), http.StatusConflict)
 	case errors.Is(err, url.ErrInvalidShortCode):
-		respondError(w, err.Error(), http.StatusBadRequest)
+		respondErrorCode(w, ErrCodeInvalidShortCode, err.Error(), http.StatusBadRequest)
 	case errors.Is(err, url.ErrEmptyShortCode):
-		respondError(w, err.Error(), http.StatusBadRequest)
+		respondErrorCode(w, ErrCodeInvalidShortCode, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, url.ErrReservedShortCode):
+		respondErrorCode(w, ErrCodeInvalidShortCode, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, url.ErrInvalidShortCodePrefix):
+		respondErrorCode(w, ErrCodeInvalidShortCode, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, url.ErrEmptyShortCodePrefix):
+		respondErrorCode(w, ErrCodeInvalidShortCode, err.Error(), http.StatusBadRequest)
 	case errors.Is(err, url.ErrInvalidOriginalURL):
-		respondError(w, err.Error(), http.StatusBadRequest)
+		respondErrorCode(w, ErrCodeInvalidURL, err.Error(), http.StatusBadRequest)
 	case errors.Is(err, url.ErrEmptyOriginalURL):
-		respondError(w, err.Error(), http.StatusBadRequest)
+		respondErrorCode(w, ErrCodeInvalidURL, err.Error(), http.StatusBadRequest)
 	case errors.Is(err, url.ErrInvalidCreatedBy):
-		respondError(w, err.Error(), http.StatusBadRequest)
+		respondErrorCode(w, ErrCodeInvalidCreatedBy, err.Error(), http.StatusBadRequest)
 	case errors.Is(err, url.ErrUnauthorizedDeletion):
-		respondError(w, err.Error(), http.StatusForbidden)
+		respondErrorCode(w, ErrCodeForbidden, err.Error(), http.StatusForbidden)
+	case errors.Is(err, url.ErrUnauthorizedRestore):
+		respondErrorCode(w, ErrCodeForbidden, err.Error(), http.StatusForbidden)
+	case errors.Is(err, click.ErrInvalidTimeRange):
+		respondErrorCode(w, ErrCodeInvalidTimeRange, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, click.ErrInvalidDateRange):
+		respondErrorCode(w, ErrCodeInvalidDateRange, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, idempotency.ErrInvalidKey):
+		respondErrorCode(w, ErrCodeInvalidIdempotencyKey, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, idempotency.ErrKeyMismatch):
+		respondErrorCode(w, ErrCodeIdempotencyKeyMismatch, err.Error(), http.StatusUnprocessableEntity)
+	case errors.Is(err, idempotency.ErrKeyInProgress):
+		respondErrorCode(w, ErrCodeIdempotencyKeyInUse, err.Error(), http.StatusConflict)
 	case errors.Is(err, url.ErrMissingURLScheme):
-		respondError(w, err.Error(), http.StatusBadRequest)
+		respondErrorCode(w, ErrCodeInvalidURL, err.Error(), http.StatusBadRequest)
 	case errors.Is(err, url.ErrInvalidURLScheme):
-		respondError(w, err.Error(), http.StatusBadRequest)
+		respondErrorCode(w, ErrCodeInvalidURL, err.Error(), http.StatusBadRequest)
 	case errors.Is(err, url.ErrMissingURLHost):
-		respondError(w, err.Error(), http.StatusBadRequest)
+		respondErrorCode(w, ErrCodeInvalidURL, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, url.ErrDestinationNotAllowed):
+		respondErrorCode(w, ErrCodeDestinationNotAllowed, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, url.ErrPrivateDestination):
+		respondErrorCode(w, ErrCodeDestinationNotAllowed, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, url.ErrUnresolvableDestination):
+		respondErrorCode(w, ErrCodeDestinationNotAllowed, err.Error(), http.StatusBadRequest)
 	default:
-		respondError(w, "internal server error", http.StatusInternalServerError)
+		respondErrorCode(w, ErrCodeInternal, "internal server error", http.StatusInternalServerError)
 	}
 }
 
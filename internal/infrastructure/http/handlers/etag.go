@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// respondWithETag writes a 200 response in the negotiated format (see respond)
+// carrying a weak ETag, or a 304 with no body when the request's If-None-Match
+// already names it. The ETag hashes scope together with the body, so two users
+// whose responses happen to be identical (say, both empty) never share one.
+func respondWithETag(w http.ResponseWriter, r *http.Request, scope string, data interface{}) {
+	contentType, body, err := encodeResponse(r, data)
+	if err != nil {
+		respondJSON(w, data, http.StatusOK)
+		return
+	}
+
+	sum := sha256.New()
+	sum.Write([]byte(scope))
+	sum.Write([]byte{0})
+	sum.Write(body)
+	etag := `W/"` + hex.EncodeToString(sum.Sum(nil)[:16]) + `"`
+
+	// Responses depend on who is asking, so shared caches must not reuse them
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Add("Vary", "Accept, Authorization, Cookie")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// etagMatches reports whether an If-None-Match header names etag, using the
+// weak comparison RFC 9110 requires for If-None-Match
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
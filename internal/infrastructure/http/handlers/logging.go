@@ -0,0 +1,17 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/logging"
+)
+
+// withShortCodeLogger returns r with its request logger enriched with the
+// short code, so every log written for the rest of the request (including by
+// use cases reading logging.FromContext) carries it. The destination URL is
+// deliberately never added: it is user data and may contain secrets.
+func withShortCodeLogger(r *http.Request, shortCode string) *http.Request {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx).With().Str("short_code", shortCode).Logger()
+	return r.WithContext(logging.WithLogger(ctx, logger))
+}
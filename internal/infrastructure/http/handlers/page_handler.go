@@ -5,11 +5,13 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/matt-riley/mjrwtf/internal/adapters/http/templates/pages"
 	"github.com/matt-riley/mjrwtf/internal/application"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/logging"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/session"
 )
 
@@ -92,12 +94,22 @@ func (h *PageHandler) CreatePage(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusMethodNotAllowed)
 }
 
+// formErrorStatus maps a ParseForm error to a status and message for the page
+func formErrorStatus(err error) (int, string) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge, "Form data too large"
+	}
+	return http.StatusBadRequest, "Invalid form data"
+}
+
 // handleCreateURLForm processes the URL creation form submission
 func (h *PageHandler) handleCreateURLForm(w http.ResponseWriter, r *http.Request) {
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		if err := pages.CreateWithError("Invalid form data").Render(r.Context(), w); err != nil {
+		status, message := formErrorStatus(err)
+		w.WriteHeader(status)
+		if err := pages.CreateWithError(message).Render(r.Context(), w); err != nil {
 			w.Write([]byte("Error rendering page"))
 		}
 		return
@@ -150,6 +162,7 @@ func (h *PageHandler) handleCreateURLForm(w http.ResponseWriter, r *http.Request
 	resp, err := h.createUseCase.Execute(ctx, application.CreateURLRequest{
 		OriginalURL: originalURL,
 		CreatedBy:   userID,
+		ClientIP:    middleware.GetClientIP(r),
 	})
 
 	if err != nil {
@@ -183,10 +196,16 @@ func (h *PageHandler) renderErrorPage(w http.ResponseWriter, r *http.Request, er
 		errorMsg = "URL must include http:// or https://"
 	case errors.Is(err, url.ErrInvalidURLScheme):
 		statusCode = http.StatusBadRequest
-		errorMsg = "URL must use HTTP or HTTPS protocol"
+		errorMsg = "URL scheme is not allowed"
 	case errors.Is(err, url.ErrMissingURLHost):
 		statusCode = http.StatusBadRequest
 		errorMsg = "URL must include a valid host"
+	case errors.Is(err, url.ErrDestinationNotAllowed), errors.Is(err, url.ErrPrivateDestination):
+		statusCode = http.StatusBadRequest
+		errorMsg = "Links to this destination are not allowed"
+	case errors.Is(err, url.ErrUnresolvableDestination):
+		statusCode = http.StatusBadRequest
+		errorMsg = "Could not look up the destination host"
 	case errors.Is(err, url.ErrInvalidShortCode):
 		statusCode = http.StatusBadRequest
 		errorMsg = "Invalid short code format"
@@ -318,8 +337,9 @@ func (h *PageHandler) Login(w http.ResponseWriter, r *http.Request) {
 func (h *PageHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		if err := pages.Login("Invalid form data").Render(r.Context(), w); err != nil {
+		status, message := formErrorStatus(err)
+		w.WriteHeader(status)
+		if err := pages.Login(message).Render(r.Context(), w); err != nil {
 			w.Write([]byte("Error rendering page"))
 		}
 		return
@@ -348,6 +368,10 @@ func (h *PageHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if !match {
+		logger := logging.FromContext(r.Context())
+		logger.Warn().
+			Str("client_ip", middleware.GetClientIP(r)).
+			Msg("login failed: invalid authentication token")
 		w.WriteHeader(http.StatusUnauthorized)
 		if err := pages.Login("Invalid authentication token").Render(r.Context(), w); err != nil {
 			w.Write([]byte("Error rendering page"))
@@ -363,7 +387,7 @@ func (h *PageHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
 
 	// Create session
 	userID := "authenticated-user"
-	sess, err := h.sessionStore.Create(userID)
+	sess, err := h.sessionStore.CreateForClient(userID, middleware.GetClientIP(r), r.UserAgent())
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		if err := pages.Login("Failed to create session").Render(r.Context(), w); err != nil {
@@ -372,8 +396,8 @@ func (h *PageHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Set session cookie (24 hours)
-	middleware.SetSessionCookie(w, sess.ID, 24*60*60, h.secureCookies)
+	// Set session cookie to match the configured session duration
+	middleware.SetSessionCookie(w, sess.ID, int(h.sessionStore.TTL()/time.Second), h.secureCookies)
 
 	// Redirect to dashboard
 	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
@@ -17,11 +17,8 @@ import (
 )
 
 func newTestSessionStore(t *testing.T) *session.Store {
-	store := session.NewStore(24 * time.Hour)
-	t.Cleanup(func() {
-		store.Shutdown()
-	})
-	return store
+	t.Helper()
+	return session.NewStore(24 * time.Hour)
 }
 
 func TestPageHandler_Home(t *testing.T) {
@@ -241,6 +238,30 @@ func TestPageHandler_CreatePage_POST_MissingURL(t *testing.T) {
 	}
 }
 
+func TestPageHandler_CreatePage_POST_BodyTooLarge(t *testing.T) {
+	mockUseCase := &mockCreateURLUseCase{}
+	handler := NewPageHandler(mockUseCase, &mockListURLsUseCase{}, []string{"test-token"}, newTestSessionStore(t), false)
+
+	form := url.Values{}
+	form.Add("original_url", "https://example.com/"+strings.Repeat("a", 512))
+	form.Add("auth_token", "test-token")
+
+	req := httptest.NewRequest(http.MethodPost, "/create", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	// As the MaxRequestBody middleware would for a streamed body
+	req.Body = http.MaxBytesReader(w, req.Body, 64)
+
+	handler.CreatePage(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Form data too large") {
+		t.Error("expected body to explain the form was too large")
+	}
+}
+
 func TestPageHandler_CreatePage_POST_MissingToken(t *testing.T) {
 	mockUseCase := &mockCreateURLUseCase{}
 	handler := NewPageHandler(mockUseCase, &mockListURLsUseCase{}, []string{"test-token"}, newTestSessionStore(t), false)
@@ -476,6 +497,48 @@ func TestPageHandler_Login_POST_Success_PrimaryToken(t *testing.T) {
 	}
 }
 
+func TestPageHandler_Login_POST_CookieMatchesSessionDuration(t *testing.T) {
+	store := session.NewStore(2 * time.Hour)
+	h := NewPageHandler(&mockCreateURLUseCase{}, &mockListURLsUseCase{}, []string{"tokenA"}, store, false)
+
+	form := url.Values{}
+	form.Add("auth_token", "tokenA")
+
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "test-browser")
+	w := httptest.NewRecorder()
+
+	h.Login(w, req)
+
+	resp := w.Result()
+	defer resp.Body.Close()
+
+	var cookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == middleware.SessionCookieName {
+			cookie = c
+		}
+	}
+	if cookie == nil {
+		t.Fatal("expected session cookie to be set")
+	}
+	if cookie.MaxAge != int((2 * time.Hour).Seconds()) {
+		t.Errorf("expected cookie MaxAge %d, got %d", int((2 * time.Hour).Seconds()), cookie.MaxAge)
+	}
+
+	sess, ok := store.Get(cookie.Value)
+	if !ok {
+		t.Fatal("expected session to exist")
+	}
+	if got := sess.ExpiresAt.Sub(sess.CreatedAt); got != 2*time.Hour {
+		t.Errorf("expected session to expire after 2h, got %v", got)
+	}
+	if sess.UserAgent != "test-browser" {
+		t.Errorf("expected user agent to be recorded, got %q", sess.UserAgent)
+	}
+}
+
 func TestPageHandler_Login_POST_InvalidToken(t *testing.T) {
 	h := NewPageHandler(&mockCreateURLUseCase{}, &mockListURLsUseCase{}, []string{"tokenA"}, newTestSessionStore(t), false)
 
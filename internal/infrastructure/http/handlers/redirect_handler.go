@@ -10,6 +10,8 @@ import (
 	"github.com/matt-riley/mjrwtf/internal/adapters/http/templates/pages"
 	"github.com/matt-riley/mjrwtf/internal/application"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/logging"
 )
 
 // RedirectUseCase defines the interface for redirect operations
@@ -20,6 +22,8 @@ type RedirectUseCase interface {
 // RedirectHandler handles HTTP redirect requests
 type RedirectHandler struct {
 	redirectUseCase RedirectUseCase
+	onNotFound      func(ctx context.Context)
+	notFoundURL     string
 }
 
 // NewRedirectHandler creates a new RedirectHandler
@@ -29,7 +33,21 @@ func NewRedirectHandler(redirectUseCase RedirectUseCase) *RedirectHandler {
 	}
 }
 
-// Redirect handles GET /:shortCode - Redirect to original URL
+// WithNotFoundHook sets a callback invoked for each redirect to an unknown short code,
+// e.g. to alert on 404 spikes
+func (h *RedirectHandler) WithNotFoundHook(hook func(ctx context.Context)) *RedirectHandler {
+	h.onNotFound = hook
+	return h
+}
+
+// WithNotFoundRedirect sends requests for unknown short codes to target with a 302
+// instead of the 404 page. Malformed short codes still get a 404.
+func (h *RedirectHandler) WithNotFoundRedirect(target string) *RedirectHandler {
+	h.notFoundURL = target
+	return h
+}
+
+// Redirect handles GET /:shortCode - Redirect to original URL, or show a preview page with ?preview=1
 func (h *RedirectHandler) Redirect(w http.ResponseWriter, r *http.Request) {
 	// Extract short code from URL path
 	shortCode := chi.URLParam(r, "shortCode")
@@ -37,22 +55,30 @@ func (h *RedirectHandler) Redirect(w http.ResponseWriter, r *http.Request) {
 		http.NotFound(w, r)
 		return
 	}
+	r = withShortCodeLogger(r, shortCode)
 
 	// Extract analytics data from request
-	// Note: IP address extraction is intentionally deferred until GeoIP integration
 	referrer := r.Header.Get("Referer")
 	userAgent := r.Header.Get("User-Agent")
-	country := "" // Country is empty for now (GeoIP not implemented yet)
 
-	// Execute redirect use case
+	// Execute redirect use case; the country is resolved from ClientIP by the use case
 	resp, err := h.redirectUseCase.Execute(r.Context(), application.RedirectRequest{
 		ShortCode: shortCode,
 		Referrer:  referrer,
 		UserAgent: userAgent,
-		Country:   country,
+		ClientIP:  middleware.GetClientIP(r),
 	})
 
 	if err != nil {
+		if errors.Is(err, url.ErrURLNotFound) {
+			if h.onNotFound != nil {
+				h.onNotFound(r.Context())
+			}
+			if h.notFoundURL != "" && url.ValidateShortCode(shortCode) == nil {
+				http.Redirect(w, r, h.notFoundURL, http.StatusFound)
+				return
+			}
+		}
 		handleRedirectError(w, r, err)
 		return
 	}
@@ -69,6 +95,20 @@ func (h *RedirectHandler) Redirect(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// ?preview=1 shows an interstitial with the destination instead of redirecting;
+	// the click has already been recorded by the use case
+	if r.URL.Query().Get("preview") == "1" {
+		var buf bytes.Buffer
+		if renderErr := pages.Preview(shortCode, resp.OriginalURL).Render(r.Context(), &buf); renderErr != nil {
+			handleRedirectError(w, r, renderErr)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.Bytes())
+		return
+	}
+
 	// Redirect to original URL with 302 status code
 	http.Redirect(w, r, resp.OriginalURL, http.StatusFound)
 }
@@ -86,6 +126,8 @@ func handleRedirectError(w http.ResponseWriter, r *http.Request, err error) {
 		return
 	}
 	// For other errors, render HTML 500 page
+	logger := logging.FromContext(r.Context())
+	logger.Error().Err(err).Msg("redirect failed")
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	w.WriteHeader(http.StatusInternalServerError)
 	if renderErr := pages.InternalError("An error occurred while processing your request").Render(r.Context(), w); renderErr != nil {
@@ -1,15 +1,21 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/matt-riley/mjrwtf/internal/application"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/logging"
 )
 
 func ptrString(s string) *string { return &s }
@@ -31,12 +37,14 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 	tests := []struct {
 		name             string
 		shortCode        string
+		query            string
 		referrer         string
 		userAgent        string
 		mockResponse     *application.RedirectResponse
 		mockError        error
 		expectedStatus   int
 		expectedLocation string
+		expectedBody     string
 		checkRequestData func(t *testing.T, req application.RedirectRequest)
 	}{
 		{
@@ -116,6 +124,26 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 			},
 			expectedStatus: http.StatusGone,
 		},
+		{
+			name:      "preview renders interstitial instead of redirecting",
+			shortCode: "abc123",
+			query:     "?preview=1",
+			mockResponse: &application.RedirectResponse{
+				OriginalURL: "https://example.com/preview",
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "https://example.com/preview",
+		},
+		{
+			name:      "preview with other value still redirects",
+			shortCode: "abc123",
+			query:     "?preview=0",
+			mockResponse: &application.RedirectResponse{
+				OriginalURL: "https://example.com/preview",
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "https://example.com/preview",
+		},
 		{
 			name:      "redirect preserves original URL intact",
 			shortCode: "abc123",
@@ -151,7 +179,7 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 
 			handler := NewRedirectHandler(mockRedirect)
 
-			req := httptest.NewRequest(http.MethodGet, "/"+tt.shortCode, nil)
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.shortCode+tt.query, nil)
 			if tt.referrer != "" {
 				req.Header.Set("Referer", tt.referrer)
 			}
@@ -179,6 +207,10 @@ func TestRedirectHandler_Redirect(t *testing.T) {
 				}
 			}
 
+			if tt.expectedBody != "" && !strings.Contains(rec.Body.String(), tt.expectedBody) {
+				t.Errorf("expected body to contain %q", tt.expectedBody)
+			}
+
 			if tt.mockResponse != nil && tt.mockResponse.IsGone {
 				if ct := rec.Header().Get("Content-Type"); ct == "" {
 					t.Error("expected Content-Type to be set")
@@ -221,3 +253,136 @@ func TestRedirectHandler_EmptyShortCode(t *testing.T) {
 		t.Errorf("expected status %d for empty short code, got %d", http.StatusNotFound, rec.Code)
 	}
 }
+
+func TestRedirectHandler_Redirect_ClientIP(t *testing.T) {
+	tests := []struct {
+		name              string
+		trustProxyHeaders bool
+		wantIP            string
+	}{
+		{"spoofed header ignored when untrusted", false, "10.0.0.2"},
+		{"forwarded header used when trusted", true, "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured application.RedirectRequest
+			handler := NewRedirectHandler(&mockRedirectUseCase{
+				executeFunc: func(ctx context.Context, req application.RedirectRequest) (*application.RedirectResponse, error) {
+					captured = req
+					return &application.RedirectResponse{OriginalURL: "https://example.com"}, nil
+				},
+			})
+
+			r := chi.NewRouter()
+			_, proxies, _ := net.ParseCIDR("10.0.0.0/8")
+			r.Use(middleware.ClientIP(tt.trustProxyHeaders, []*net.IPNet{proxies}))
+			r.Get("/{shortCode}", handler.Redirect)
+
+			req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+			req.RemoteAddr = "10.0.0.2:4567"
+			req.Header.Set("X-Forwarded-For", "198.51.100.1, 203.0.113.7, 10.0.0.3")
+			rec := httptest.NewRecorder()
+
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusFound {
+				t.Fatalf("expected status %d, got %d", http.StatusFound, rec.Code)
+			}
+			if captured.ClientIP != tt.wantIP {
+				t.Errorf("expected client IP %q, got %q", tt.wantIP, captured.ClientIP)
+			}
+		})
+	}
+}
+
+func TestRedirectHandler_Redirect_NotFoundHook(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantCalls int
+	}{
+		{"unknown short code", url.ErrURLNotFound, 1},
+		{"other error", errors.New("database error"), 0},
+		{"successful redirect", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			handler := NewRedirectHandler(&mockRedirectUseCase{
+				executeFunc: func(ctx context.Context, req application.RedirectRequest) (*application.RedirectResponse, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &application.RedirectResponse{OriginalURL: "https://example.com"}, nil
+				},
+			}).WithNotFoundHook(func(ctx context.Context) { calls++ })
+
+			r := chi.NewRouter()
+			r.Get("/{shortCode}", handler.Redirect)
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/abc123", nil))
+
+			if calls != tt.wantCalls {
+				t.Errorf("expected not-found hook to run %d times, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRedirectHandler_Redirect_LogsShortCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"successful redirect", nil},
+		{"internal error", errors.New("database error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := logging.New("debug", "json", logging.WithOutput(&buf))
+
+			handler := NewRedirectHandler(&mockRedirectUseCase{
+				executeFunc: func(ctx context.Context, req application.RedirectRequest) (*application.RedirectResponse, error) {
+					// A downstream log written with the request's logger
+					downstream := logging.FromContext(ctx)
+					downstream.Info().Msg("looking up short code")
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &application.RedirectResponse{OriginalURL: "https://secret.example/?token=abc"}, nil
+				},
+			})
+
+			r := chi.NewRouter()
+			r.Use(middleware.InjectLogger(logger))
+			r.Get("/{shortCode}", handler.Redirect)
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abc123", nil))
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			wantLines := 1
+			if tt.err != nil {
+				wantLines = 2 // plus "redirect failed"
+			}
+			if len(lines) != wantLines {
+				t.Fatalf("expected %d log lines, got %d:\n%s", wantLines, len(lines), buf.String())
+			}
+			for _, line := range lines {
+				var entry map[string]any
+				if err := json.Unmarshal([]byte(line), &entry); err != nil {
+					t.Fatalf("invalid log line %q: %v", line, err)
+				}
+				if entry["short_code"] != "abc123" {
+					t.Errorf("expected short_code=abc123 in %s", line)
+				}
+				if strings.Contains(line, "secret.example") {
+					t.Errorf("log must not include the destination: %s", line)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	contentTypeJSON = "application/json"
+	contentTypeYAML = "application/yaml"
+)
+
+// yamlMediaTypes are the Accept values that ask for YAML
+var yamlMediaTypes = map[string]bool{
+	"application/yaml":   true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+}
+
+// respond writes v with status code as YAML when the request's Accept header
+// prefers it, and as JSON otherwise
+func respond(w http.ResponseWriter, r *http.Request, code int, v any) {
+	contentType, body, err := encodeResponse(r, v)
+	if err != nil {
+		respondJSON(w, v, code)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept")
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	w.Write(body)
+}
+
+// encodeResponse encodes v in the format negotiated from r's Accept header.
+//
+// YAML is produced from the JSON encoding, so field names, omitempty and
+// custom marshalers behave exactly as they do for JSON.
+func encodeResponse(r *http.Request, v any) (string, []byte, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		return "", nil, err
+	}
+	if !prefersYAML(r.Header.Get("Accept")) {
+		return contentTypeJSON, buf.Bytes(), nil
+	}
+
+	// JSON is valid YAML, so parse it into a node tree (keeping key order and
+	// number literals) and re-emit it in block style
+	var doc yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		return "", nil, err
+	}
+	clearYAMLStyle(&doc)
+
+	var out bytes.Buffer
+	enc := yaml.NewEncoder(&out)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return "", nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return "", nil, err
+	}
+	return contentTypeYAML, out.Bytes(), nil
+}
+
+// clearYAMLStyle drops the flow and quoting styles parsed from JSON; the
+// encoder still quotes strings that would otherwise read as another type
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearYAMLStyle(c)
+	}
+}
+
+// prefersYAML reports whether an Accept header ranks a YAML media type above
+// JSON. Ties go to whichever is listed first; JSON is the default.
+func prefersYAML(accept string) bool {
+	if accept == "" {
+		return false
+	}
+
+	yamlQ, jsonQ := -1.0, -1.0
+	yamlFirst := false
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil {
+				continue
+			}
+		}
+
+		switch {
+		case yamlMediaTypes[mediaType]:
+			if q > yamlQ {
+				yamlFirst = yamlFirst || jsonQ < 0
+				yamlQ = q
+			}
+		case mediaType == contentTypeJSON || mediaType == "application/*" || mediaType == "*/*":
+			if q > jsonQ {
+				jsonQ = q
+			}
+		}
+	}
+
+	if yamlQ <= 0 {
+		return false
+	}
+	return yamlQ > jsonQ || (yamlQ == jsonQ && yamlFirst)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import "testing"
+
+func TestPrefersYAML(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{accept: "", want: false},
+		{accept: "application/json", want: false},
+		{accept: "*/*", want: false},
+		{accept: "application/yaml", want: true},
+		{accept: "application/x-yaml", want: true},
+		{accept: "text/yaml; charset=utf-8", want: true},
+		{accept: "application/yaml, */*", want: true},
+		{accept: "application/json, application/yaml", want: false},
+		{accept: "application/yaml, application/json", want: true},
+		{accept: "application/json;q=0.5, application/yaml", want: true},
+		{accept: "application/yaml;q=0.5, application/json", want: false},
+		{accept: "application/yaml;q=0", want: false},
+		{accept: "application/yaml;q=nope", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			if got := prefersYAML(tt.accept); got != tt.want {
+				t.Errorf("prefersYAML(%q) = %v, want %v", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/session"
+)
+
+// SessionHandler handles HTTP requests for managing the caller's login sessions
+type SessionHandler struct {
+	sessionStore  *session.Store
+	secureCookies bool
+}
+
+// NewSessionHandler creates a new SessionHandler
+func NewSessionHandler(sessionStore *session.Store, secureCookies bool) *SessionHandler {
+	return &SessionHandler{
+		sessionStore:  sessionStore,
+		secureCookies: secureCookies,
+	}
+}
+
+// SessionResponse represents a single session in API responses.
+// ID is a redacted prefix of the session ID, never the full credential.
+type SessionResponse struct {
+	ID             string    `json:"id"`
+	IPAddress      string    `json:"ip_address"`
+	UserAgent      string    `json:"user_agent"`
+	CreatedAt      time.Time `json:"created_at"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Current        bool      `json:"current"`
+}
+
+// ListSessionsResponse represents the JSON response for listing sessions
+type ListSessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// RevokeAllSessionsResponse represents the JSON response for logging out everywhere
+type RevokeAllSessionsResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// List handles GET /api/auth/sessions - List the current user's active sessions
+func (h *SessionHandler) List(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	currentID := currentSessionID(r)
+	sessions := h.sessionStore.FindByUserID(userID)
+
+	resp := ListSessionsResponse{Sessions: make([]SessionResponse, 0, len(sessions))}
+	for _, sess := range sessions {
+		resp.Sessions = append(resp.Sessions, SessionResponse{
+			ID:             sess.IDPrefix(),
+			IPAddress:      sess.IPAddress,
+			UserAgent:      sess.UserAgent,
+			CreatedAt:      sess.CreatedAt,
+			LastActivityAt: sess.LastActivityAt,
+			ExpiresAt:      sess.ExpiresAt,
+			Current:        sess.ID == currentID,
+		})
+	}
+
+	respond(w, r, http.StatusOK, resp)
+}
+
+// Revoke handles DELETE /api/auth/sessions/{id} - Revoke one of the current user's sessions
+func (h *SessionHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Extract session ID prefix from URL path
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		respondError(w, "session id is required", http.StatusBadRequest)
+		return
+	}
+
+	sess, found := h.sessionStore.FindByIDPrefix(id)
+	if !found {
+		respondErrorCode(w, ErrCodeSessionNotFound, "session not found", http.StatusNotFound)
+		return
+	}
+	if sess.UserID != userID {
+		respondErrorCode(w, ErrCodeForbidden, "forbidden: you can only revoke your own sessions", http.StatusForbidden)
+		return
+	}
+
+	h.sessionStore.Delete(sess.ID)
+
+	// Revoking the session this request was made with also logs the caller out
+	if sess.ID == currentSessionID(r) {
+		middleware.ClearSessionCookie(w, h.secureCookies)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAll handles DELETE /api/auth/sessions - Revoke every session of the current user ("log out everywhere").
+// Unlike Revoke, this also ends the session used for the request and always clears its cookie.
+func (h *SessionHandler) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	deleted := h.sessionStore.DeleteByUserID(userID)
+	middleware.ClearSessionCookie(w, h.secureCookies)
+
+	respondJSON(w, RevokeAllSessionsResponse{Deleted: deleted}, http.StatusOK)
+}
+
+// currentSessionID returns the session ID from the request cookie, if any
+func currentSessionID(r *http.Request) string {
+	cookie, err := r.Cookie(middleware.SessionCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
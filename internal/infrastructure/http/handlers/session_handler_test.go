@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/session"
+)
+
+func TestSessionHandler_List(t *testing.T) {
+	store := newTestSessionStore(t)
+	handler := NewSessionHandler(store, false)
+
+	current, err := store.CreateForClient("alice", "203.0.113.10", "Firefox")
+	if err != nil {
+		t.Fatalf("CreateForClient() error = %v", err)
+	}
+	if _, err := store.CreateForClient("alice", "198.51.100.2", "curl/8.0"); err != nil {
+		t.Fatalf("CreateForClient() error = %v", err)
+	}
+	if _, err := store.CreateForClient("bob", "192.0.2.1", "Safari"); err != nil {
+		t.Fatalf("CreateForClient() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil)
+	req.AddCookie(&http.Cookie{Name: middleware.SessionCookieName, Value: current.ID})
+	req = req.WithContext(withUserID(req.Context(), "alice"))
+	rec := httptest.NewRecorder()
+
+	handler.List(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), current.ID) {
+		t.Fatal("response must not contain the full session ID")
+	}
+
+	var resp ListSessionsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Sessions) != 2 {
+		t.Fatalf("expected 2 sessions for alice, got %d", len(resp.Sessions))
+	}
+
+	var sawCurrent bool
+	for _, s := range resp.Sessions {
+		if len(s.ID) != session.IDPrefixLength {
+			t.Errorf("expected redacted ID of length %d, got %q", session.IDPrefixLength, s.ID)
+		}
+		if s.UserAgent == "Safari" {
+			t.Error("listed a session belonging to another user")
+		}
+		if s.Current {
+			sawCurrent = true
+			if s.ID != current.IDPrefix() || s.IPAddress != "203.0.113.10" || s.UserAgent != "Firefox" {
+				t.Errorf("unexpected current session: %+v", s)
+			}
+		}
+		if s.CreatedAt.IsZero() || s.LastActivityAt.IsZero() {
+			t.Errorf("expected created/last-activity timestamps, got %+v", s)
+		}
+	}
+	if !sawCurrent {
+		t.Error("expected the cookie's session to be flagged as current")
+	}
+}
+
+func TestSessionHandler_Revoke(t *testing.T) {
+	tests := []struct {
+		name           string
+		owner          string
+		userID         string
+		hasUserID      bool
+		id             func(*session.Session) string
+		expectedStatus int
+		expectedCode   string
+		expectDeleted  bool
+	}{
+		{
+			name:           "revoke own session",
+			owner:          "alice",
+			userID:         "alice",
+			hasUserID:      true,
+			id:             func(s *session.Session) string { return s.IDPrefix() },
+			expectedStatus: http.StatusNoContent,
+			expectDeleted:  true,
+		},
+		{
+			name:           "revoke another user's session",
+			owner:          "bob",
+			userID:         "alice",
+			hasUserID:      true,
+			id:             func(s *session.Session) string { return s.IDPrefix() },
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   ErrCodeForbidden,
+		},
+		{
+			name:           "unknown session",
+			owner:          "alice",
+			userID:         "alice",
+			hasUserID:      true,
+			id:             func(*session.Session) string { return "doesnotexist" },
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   ErrCodeSessionNotFound,
+		},
+		{
+			name:           "prefix too short",
+			owner:          "alice",
+			userID:         "alice",
+			hasUserID:      true,
+			id:             func(s *session.Session) string { return s.ID[:4] },
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unauthorized",
+			owner:          "alice",
+			hasUserID:      false,
+			id:             func(s *session.Session) string { return s.IDPrefix() },
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newTestSessionStore(t)
+			handler := NewSessionHandler(store, false)
+
+			target, err := store.CreateForClient(tt.owner, "203.0.113.10", "Firefox")
+			if err != nil {
+				t.Fatalf("CreateForClient() error = %v", err)
+			}
+
+			id := tt.id(target)
+			req := httptest.NewRequest(http.MethodDelete, "/api/auth/sessions/"+id, nil)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			if tt.hasUserID {
+				req = req.WithContext(withUserID(req.Context(), tt.userID))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.Revoke(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.expectedCode != "" {
+				var errResp ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&errResp); err != nil {
+					t.Fatalf("decode error response: %v", err)
+				}
+				if errResp.Code != tt.expectedCode {
+					t.Errorf("expected code %q, got %q", tt.expectedCode, errResp.Code)
+				}
+			}
+
+			_, exists := store.Get(target.ID)
+			if tt.expectDeleted && exists {
+				t.Error("expected session to be revoked")
+			}
+			if !tt.expectDeleted && !exists {
+				t.Error("expected session to remain")
+			}
+		})
+	}
+}
+
+func TestSessionHandler_RevokeCurrentClearsCookie(t *testing.T) {
+	store := newTestSessionStore(t)
+	handler := NewSessionHandler(store, false)
+
+	sess, err := store.Create("alice")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/auth/sessions/"+sess.IDPrefix(), nil)
+	req.AddCookie(&http.Cookie{Name: middleware.SessionCookieName, Value: sess.ID})
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", sess.IDPrefix())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(withUserID(req.Context(), "alice"))
+	rec := httptest.NewRecorder()
+
+	handler.Revoke(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", rec.Code)
+	}
+
+	var cleared bool
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == middleware.SessionCookieName && c.MaxAge < 0 {
+			cleared = true
+		}
+	}
+	if !cleared {
+		t.Error("expected session cookie to be cleared when revoking the current session")
+	}
+}
+
+func TestSessionHandler_ListUnauthorized(t *testing.T) {
+	handler := NewSessionHandler(newTestSessionStore(t), false)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil)
+	rec := httptest.NewRecorder()
+
+	handler.List(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+}
+
+func TestSessionHandler_RevokeAll(t *testing.T) {
+	store := newTestSessionStore(t)
+	handler := NewSessionHandler(store, false)
+
+	var aliceSessions []*session.Session
+	for i := 0; i < 3; i++ {
+		sess, err := store.Create("alice")
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		aliceSessions = append(aliceSessions, sess)
+	}
+	bob, err := store.Create("bob")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/auth/sessions", nil)
+	req.AddCookie(&http.Cookie{Name: middleware.SessionCookieName, Value: aliceSessions[0].ID})
+	req = req.WithContext(withUserID(req.Context(), "alice"))
+	rec := httptest.NewRecorder()
+
+	handler.RevokeAll(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp RevokeAllSessionsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Deleted != len(aliceSessions) {
+		t.Errorf("expected deleted=%d, got %d", len(aliceSessions), resp.Deleted)
+	}
+
+	for _, sess := range aliceSessions {
+		if _, exists := store.Get(sess.ID); exists {
+			t.Errorf("expected session %s to be revoked", sess.IDPrefix())
+		}
+	}
+	if _, exists := store.Get(bob.ID); !exists {
+		t.Error("expected another user's session to remain")
+	}
+	if remaining := store.FindByUserID("alice"); len(remaining) != 0 {
+		t.Errorf("expected no sessions left for alice, got %d", len(remaining))
+	}
+
+	var cleared bool
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == middleware.SessionCookieName && c.MaxAge < 0 {
+			cleared = true
+		}
+	}
+	if !cleared {
+		t.Error("expected the current session cookie to be cleared")
+	}
+}
+
+func TestSessionHandler_RevokeAllUnauthorized(t *testing.T) {
+	handler := NewSessionHandler(newTestSessionStore(t), false)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/auth/sessions", nil)
+	rec := httptest.NewRecorder()
+
+	handler.RevokeAll(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+}
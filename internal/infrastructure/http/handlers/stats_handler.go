@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/matt-riley/mjrwtf/internal/application"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
+)
+
+// GetStatsSummaryUseCase defines the interface for a user's overview stats
+type GetStatsSummaryUseCase interface {
+	Execute(ctx context.Context, req application.GetStatsSummaryRequest) (*application.GetStatsSummaryResponse, error)
+}
+
+// GetTopURLsUseCase defines the interface for a user's click leaderboard
+type GetTopURLsUseCase interface {
+	Execute(ctx context.Context, req application.GetTopURLsRequest) (*application.GetTopURLsResponse, error)
+}
+
+// StatsHandler handles HTTP requests for stats across all of a user's URLs
+type StatsHandler struct {
+	summaryUseCase GetStatsSummaryUseCase
+	topUseCase     GetTopURLsUseCase
+}
+
+// NewStatsHandler creates a new StatsHandler
+func NewStatsHandler(summaryUseCase GetStatsSummaryUseCase, topUseCase GetTopURLsUseCase) *StatsHandler {
+	return &StatsHandler{
+		summaryUseCase: summaryUseCase,
+		topUseCase:     topUseCase,
+	}
+}
+
+// Summary handles GET /api/stats/summary - Total URLs and clicks for the authenticated user
+func (h *StatsHandler) Summary(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Execute use case
+	resp, err := h.summaryUseCase.Execute(r.Context(), application.GetStatsSummaryRequest{
+		CreatedBy: scopedCreatedBy(r, userID),
+	})
+
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	// Respond with success
+	respond(w, r, http.StatusOK, resp)
+}
+
+// Top handles GET /api/stats/top - The authenticated user's URLs, most clicked first
+func (h *StatsHandler) Top(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Omitted or zero uses the default; the use case caps large values
+	limit := parseQueryInt(r, "limit", 0)
+	if limit < 0 {
+		respondError(w, "limit must be non-negative", http.StatusBadRequest)
+		return
+	}
+
+	// Execute use case
+	resp, err := h.topUseCase.Execute(r.Context(), application.GetTopURLsRequest{
+		CreatedBy: scopedCreatedBy(r, userID),
+		Limit:     limit,
+	})
+
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	// Respond with success
+	respond(w, r, http.StatusOK, resp)
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/application"
+)
+
+type mockGetStatsSummaryUseCase struct {
+	executeFunc func(ctx context.Context, req application.GetStatsSummaryRequest) (*application.GetStatsSummaryResponse, error)
+}
+
+func (m *mockGetStatsSummaryUseCase) Execute(ctx context.Context, req application.GetStatsSummaryRequest) (*application.GetStatsSummaryResponse, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+type mockGetTopURLsUseCase struct {
+	executeFunc func(ctx context.Context, req application.GetTopURLsRequest) (*application.GetTopURLsResponse, error)
+}
+
+func (m *mockGetTopURLsUseCase) Execute(ctx context.Context, req application.GetTopURLsRequest) (*application.GetTopURLsResponse, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, req)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestStatsHandler_Summary(t *testing.T) {
+	totals := map[string]application.GetStatsSummaryResponse{
+		"alice": {TotalURLs: 2, TotalClicks: 17},
+		"bob":   {TotalURLs: 1, TotalClicks: 0},
+	}
+	handler := NewStatsHandler(&mockGetStatsSummaryUseCase{
+		executeFunc: func(ctx context.Context, req application.GetStatsSummaryRequest) (*application.GetStatsSummaryResponse, error) {
+			resp := totals[req.CreatedBy]
+			return &resp, nil
+		},
+	}, &mockGetTopURLsUseCase{})
+
+	for userID, want := range totals {
+		req := httptest.NewRequest(http.MethodGet, "/api/stats/summary", nil)
+		req = req.WithContext(withUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+
+		handler.Summary(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("summary for %s: expected status %d, got %d: %s", userID, http.StatusOK, rec.Code, rec.Body.String())
+		}
+		var got application.GetStatsSummaryResponse
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got != want {
+			t.Errorf("summary for %s = %+v, want %+v", userID, got, want)
+		}
+	}
+
+	t.Run("requires authentication", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.Summary(rec, httptest.NewRequest(http.MethodGet, "/api/stats/summary", nil))
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+		}
+	})
+
+	t.Run("use case error", func(t *testing.T) {
+		failing := NewStatsHandler(&mockGetStatsSummaryUseCase{
+			executeFunc: func(ctx context.Context, req application.GetStatsSummaryRequest) (*application.GetStatsSummaryResponse, error) {
+				return nil, errors.New("database error")
+			},
+		}, &mockGetTopURLsUseCase{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/stats/summary", nil)
+		req = req.WithContext(withUserID(req.Context(), "alice"))
+		rec := httptest.NewRecorder()
+
+		failing.Summary(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+		}
+	})
+}
+
+func TestStatsHandler_Top(t *testing.T) {
+	var gotReq application.GetTopURLsRequest
+	handler := NewStatsHandler(&mockGetStatsSummaryUseCase{}, &mockGetTopURLsUseCase{
+		executeFunc: func(ctx context.Context, req application.GetTopURLsRequest) (*application.GetTopURLsResponse, error) {
+			gotReq = req
+			return &application.GetTopURLsResponse{
+				URLs: []application.URLResponse{
+					{ID: 2, ShortCode: "hot", CreatedBy: req.CreatedBy, ClickCount: 9},
+					{ID: 3, ShortCode: "newer", CreatedBy: req.CreatedBy, ClickCount: 4},
+					{ID: 1, ShortCode: "older", CreatedBy: req.CreatedBy, ClickCount: 4},
+				},
+				Limit: 3,
+			}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/stats/top?limit=3", nil)
+	req = req.WithContext(withUserID(req.Context(), "alice"))
+	rec := httptest.NewRecorder()
+
+	handler.Top(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if gotReq.CreatedBy != "alice" || gotReq.Limit != 3 {
+		t.Errorf("use case request = %+v, want alice with limit 3", gotReq)
+	}
+
+	var got application.GetTopURLsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	var codes []string
+	for _, u := range got.URLs {
+		codes = append(codes, u.ShortCode)
+	}
+	if want := []string{"hot", "newer", "older"}; len(codes) != len(want) || codes[0] != want[0] || codes[1] != want[1] || codes[2] != want[2] {
+		t.Errorf("short codes = %v, want %v in use case order", codes, want)
+	}
+	if got.Limit != 3 {
+		t.Errorf("limit = %d, want 3", got.Limit)
+	}
+}
+
+func TestStatsHandler_Top_Limit(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantLimit  int
+	}{
+		{name: "omitted uses default", query: "", wantStatus: http.StatusOK, wantLimit: 0},
+		{name: "explicit", query: "?limit=25", wantStatus: http.StatusOK, wantLimit: 25},
+		{name: "large passed to use case", query: "?limit=1000", wantStatus: http.StatusOK, wantLimit: 1000},
+		{name: "negative", query: "?limit=-1", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			var gotLimit int
+			handler := NewStatsHandler(&mockGetStatsSummaryUseCase{}, &mockGetTopURLsUseCase{
+				executeFunc: func(ctx context.Context, req application.GetTopURLsRequest) (*application.GetTopURLsResponse, error) {
+					called = true
+					gotLimit = req.Limit
+					return &application.GetTopURLsResponse{URLs: []application.URLResponse{}}, nil
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/stats/top"+tt.query, nil)
+			req = req.WithContext(withUserID(req.Context(), "alice"))
+			rec := httptest.NewRecorder()
+
+			handler.Top(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if called {
+					t.Error("use case should not run for an invalid limit")
+				}
+				return
+			}
+			if gotLimit != tt.wantLimit {
+				t.Errorf("use case limit = %d, want %d", gotLimit, tt.wantLimit)
+			}
+		})
+	}
+
+	t.Run("requires authentication", func(t *testing.T) {
+		handler := NewStatsHandler(&mockGetStatsSummaryUseCase{}, &mockGetTopURLsUseCase{})
+		rec := httptest.NewRecorder()
+		handler.Top(rec, httptest.NewRequest(http.MethodGet, "/api/stats/top", nil))
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/matt-riley/mjrwtf/internal/application"
@@ -19,34 +20,59 @@ type ListURLsUseCase interface {
 	Execute(ctx context.Context, req application.ListURLsRequest) (*application.ListURLsResponse, error)
 }
 
+// CountURLsUseCase defines the interface for counting URLs
+type CountURLsUseCase interface {
+	Execute(ctx context.Context, req application.CountURLsRequest) (*application.CountURLsResponse, error)
+}
+
 // DeleteURLUseCase defines the interface for deleting URLs
 type DeleteURLUseCase interface {
 	Execute(ctx context.Context, req application.DeleteURLRequest) (*application.DeleteURLResponse, error)
 }
 
+// DeleteURLsByPrefixUseCase defines the interface for bulk deleting URLs by short code prefix
+type DeleteURLsByPrefixUseCase interface {
+	Execute(ctx context.Context, req application.DeleteURLsByPrefixRequest) (*application.DeleteURLsByPrefixResponse, error)
+}
+
+// RestoreURLUseCase defines the interface for restoring soft-deleted URLs
+type RestoreURLUseCase interface {
+	Execute(ctx context.Context, req application.RestoreURLRequest) (*application.RestoreURLResponse, error)
+}
+
 // URLHandler handles HTTP requests for URL operations
 type URLHandler struct {
-	createUseCase CreateURLUseCase
-	listUseCase   ListURLsUseCase
-	deleteUseCase DeleteURLUseCase
+	createUseCase         CreateURLUseCase
+	listUseCase           ListURLsUseCase
+	countUseCase          CountURLsUseCase
+	deleteUseCase         DeleteURLUseCase
+	deleteByPrefixUseCase DeleteURLsByPrefixUseCase
+	restoreUseCase        RestoreURLUseCase
 }
 
 // NewURLHandler creates a new URLHandler
 func NewURLHandler(
 	createUseCase CreateURLUseCase,
 	listUseCase ListURLsUseCase,
+	countUseCase CountURLsUseCase,
 	deleteUseCase DeleteURLUseCase,
+	deleteByPrefixUseCase DeleteURLsByPrefixUseCase,
+	restoreUseCase RestoreURLUseCase,
 ) *URLHandler {
 	return &URLHandler{
-		createUseCase: createUseCase,
-		listUseCase:   listUseCase,
-		deleteUseCase: deleteUseCase,
+		createUseCase:         createUseCase,
+		listUseCase:           listUseCase,
+		countUseCase:          countUseCase,
+		deleteUseCase:         deleteUseCase,
+		deleteByPrefixUseCase: deleteByPrefixUseCase,
+		restoreUseCase:        restoreUseCase,
 	}
 }
 
 // CreateURLRequest represents the JSON request body for creating a URL
 type CreateURLRequest struct {
 	OriginalURL string `json:"original_url"`
+	ShortCode   string `json:"short_code,omitempty"`
 }
 
 // CreateURLResponse represents the JSON response for creating a URL
@@ -54,6 +80,8 @@ type CreateURLResponse struct {
 	ShortCode   string `json:"short_code"`
 	ShortURL    string `json:"short_url"`
 	OriginalURL string `json:"original_url"`
+	// Reused is set when DEDUPE_DESTINATIONS returned an existing URL instead of creating one
+	Reused bool `json:"reused,omitempty"`
 }
 
 // Create handles POST /api/urls - Create shortened URL
@@ -79,9 +107,13 @@ func (h *URLHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Execute use case
+	// An optional Idempotency-Key makes retries return the first result instead of a new URL
 	resp, err := h.createUseCase.Execute(r.Context(), application.CreateURLRequest{
-		OriginalURL: req.OriginalURL,
-		CreatedBy:   userID,
+		OriginalURL:    req.OriginalURL,
+		CreatedBy:      userID,
+		ShortCode:      req.ShortCode,
+		IdempotencyKey: r.Header.Get("Idempotency-Key"),
+		ClientIP:       middleware.GetClientIP(r),
 	})
 
 	if err != nil {
@@ -94,6 +126,7 @@ func (h *URLHandler) Create(w http.ResponseWriter, r *http.Request) {
 		ShortCode:   resp.ShortCode,
 		ShortURL:    resp.ShortURL,
 		OriginalURL: resp.OriginalURL,
+		Reused:      resp.Reused,
 	}, http.StatusCreated)
 }
 
@@ -115,11 +148,35 @@ func (h *URLHandler) List(w http.ResponseWriter, r *http.Request) {
 		respondError(w, "limit and offset must be non-negative", http.StatusBadRequest)
 		return
 	}
-	// Execute use case
+	// Execute use case; ?q= searches original URLs and short codes
 	resp, err := h.listUseCase.Execute(r.Context(), application.ListURLsRequest{
-		CreatedBy: userID,
+		CreatedBy: scopedCreatedBy(r, userID),
 		Limit:     limit,
 		Offset:    offset,
+		Query:     strings.TrimSpace(r.URL.Query().Get("q")),
+	})
+
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	// Respond with success; dashboards poll this, so support conditional requests
+	respondWithETag(w, r, userID, resp)
+}
+
+// Count handles GET /api/urls/count - Count the user's URLs
+func (h *URLHandler) Count(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Execute use case
+	resp, err := h.countUseCase.Execute(r.Context(), application.CountURLsRequest{
+		CreatedBy: scopedCreatedBy(r, userID),
 	})
 
 	if err != nil {
@@ -128,7 +185,20 @@ func (h *URLHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Respond with success
-	respondJSON(w, resp, http.StatusOK)
+	respond(w, r, http.StatusOK, resp)
+}
+
+// scopedCreatedBy returns the creator a read should be scoped to. Requests
+// authenticated with an admin token may pick another user with ?created_by=;
+// everyone else only ever sees their own URLs.
+func scopedCreatedBy(r *http.Request, userID string) string {
+	if !middleware.IsAdminToken(r.Context()) {
+		return userID
+	}
+	if createdBy := strings.TrimSpace(r.URL.Query().Get("created_by")); createdBy != "" {
+		return createdBy
+	}
+	return userID
 }
 
 // Delete handles DELETE /api/urls/{shortCode} - Delete URL
@@ -146,11 +216,77 @@ func (h *URLHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		respondError(w, "short_code is required", http.StatusBadRequest)
 		return
 	}
+	r = withShortCodeLogger(r, shortCode)
 
 	// Execute use case
 	_, err := h.deleteUseCase.Execute(r.Context(), application.DeleteURLRequest{
 		ShortCode:   shortCode,
 		RequestedBy: userID,
+		ClientIP:    middleware.GetClientIP(r),
+	})
+
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	// Respond with no content on success
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteByPrefix handles DELETE /api/urls?prefix=... - Delete the user's URLs whose short code starts with prefix
+func (h *URLHandler) DeleteByPrefix(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Require an explicit prefix so a bare DELETE /api/urls can never wipe every URL
+	prefix := r.URL.Query().Get("prefix")
+	if prefix == "" {
+		respondError(w, "prefix is required", http.StatusBadRequest)
+		return
+	}
+
+	// Execute use case
+	resp, err := h.deleteByPrefixUseCase.Execute(r.Context(), application.DeleteURLsByPrefixRequest{
+		Prefix:      prefix,
+		RequestedBy: userID,
+		ClientIP:    middleware.GetClientIP(r),
+	})
+
+	if err != nil {
+		handleDomainError(w, err)
+		return
+	}
+
+	// Respond with the number of URLs deleted
+	respondJSON(w, resp, http.StatusOK)
+}
+
+// Restore handles POST /api/urls/{shortCode}/restore - Restore a soft-deleted URL
+func (h *URLHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	// Extract user ID from context (set by auth middleware)
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		respondError(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Extract short code from URL path
+	shortCode := chi.URLParam(r, "shortCode")
+	if shortCode == "" {
+		respondError(w, "short_code is required", http.StatusBadRequest)
+		return
+	}
+	r = withShortCodeLogger(r, shortCode)
+
+	// Execute use case
+	_, err := h.restoreUseCase.Execute(r.Context(), application.RestoreURLRequest{
+		ShortCode:   shortCode,
+		RequestedBy: userID,
 	})
 
 	if err != nil {
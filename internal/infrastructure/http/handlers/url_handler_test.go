@@ -5,12 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/matt-riley/mjrwtf/internal/application"
+	"github.com/matt-riley/mjrwtf/internal/domain/idempotency"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
 )
@@ -49,6 +52,39 @@ func (m *mockDeleteURLUseCase) Execute(ctx context.Context, req application.Dele
 	return nil, nil
 }
 
+type mockDeleteURLsByPrefixUseCase struct {
+	executeFunc func(ctx context.Context, req application.DeleteURLsByPrefixRequest) (*application.DeleteURLsByPrefixResponse, error)
+}
+
+func (m *mockDeleteURLsByPrefixUseCase) Execute(ctx context.Context, req application.DeleteURLsByPrefixRequest) (*application.DeleteURLsByPrefixResponse, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, req)
+	}
+	return nil, nil
+}
+
+type mockCountURLsUseCase struct {
+	executeFunc func(ctx context.Context, req application.CountURLsRequest) (*application.CountURLsResponse, error)
+}
+
+func (m *mockCountURLsUseCase) Execute(ctx context.Context, req application.CountURLsRequest) (*application.CountURLsResponse, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, req)
+	}
+	return nil, nil
+}
+
+type mockRestoreURLUseCase struct {
+	executeFunc func(ctx context.Context, req application.RestoreURLRequest) (*application.RestoreURLResponse, error)
+}
+
+func (m *mockRestoreURLUseCase) Execute(ctx context.Context, req application.RestoreURLRequest) (*application.RestoreURLResponse, error) {
+	if m.executeFunc != nil {
+		return m.executeFunc(ctx, req)
+	}
+	return nil, nil
+}
+
 // Helper function to add user ID to context
 func withUserID(ctx context.Context, userID string) context.Context {
 	return context.WithValue(ctx, middleware.UserIDKey, userID)
@@ -142,7 +178,7 @@ func TestURLHandler_Create(t *testing.T) {
 			hasUserID:      true,
 			mockError:      url.ErrInvalidOriginalURL,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"error":"invalid original URL format"}`,
+			expectedBody:   `{"error":"invalid original URL format","code":"invalid_url"}`,
 		},
 		{
 			name:           "duplicate short code",
@@ -151,7 +187,7 @@ func TestURLHandler_Create(t *testing.T) {
 			hasUserID:      true,
 			mockError:      url.ErrDuplicateShortCode,
 			expectedStatus: http.StatusConflict,
-			expectedBody:   `{"error":"short code already exists"}`,
+			expectedBody:   `{"error":"short code already exists","code":"duplicate_short_code"}`,
 		},
 	}
 
@@ -166,7 +202,7 @@ func TestURLHandler_Create(t *testing.T) {
 				},
 			}
 
-			handler := NewURLHandler(mockCreate, nil, nil)
+			handler := NewURLHandler(mockCreate, nil, nil, nil, nil, nil)
 
 			var reqBody *bytes.Reader
 			if tt.requestBody == "__OVERSIZED__" {
@@ -203,6 +239,113 @@ func TestURLHandler_Create(t *testing.T) {
 	}
 }
 
+// TestURLHandler_Create_PassesShortCode verifies an optional short_code reaches the use case
+func TestURLHandler_Create_PassesShortCode(t *testing.T) {
+	var got application.CreateURLRequest
+	mockCreate := &mockCreateURLUseCase{
+		executeFunc: func(ctx context.Context, req application.CreateURLRequest) (*application.CreateURLResponse, error) {
+			got = req
+			return &application.CreateURLResponse{
+				ShortCode:   req.ShortCode,
+				ShortURL:    "http://localhost:8080/" + req.ShortCode,
+				OriginalURL: req.OriginalURL,
+			}, nil
+		},
+	}
+	handler := NewURLHandler(mockCreate, nil, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", bytes.NewReader([]byte(`{"original_url":"https://example.com","short_code":"my-link"}`)))
+	req = req.WithContext(withUserID(req.Context(), "test-user"))
+	rec := httptest.NewRecorder()
+
+	handler.Create(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	if got.ShortCode != "my-link" || got.OriginalURL != "https://example.com" || got.CreatedBy != "test-user" {
+		t.Errorf("unexpected use case request: %+v", got)
+	}
+}
+
+func TestURLHandler_Create_Reused(t *testing.T) {
+	mockCreate := &mockCreateURLUseCase{
+		executeFunc: func(ctx context.Context, req application.CreateURLRequest) (*application.CreateURLResponse, error) {
+			return &application.CreateURLResponse{
+				ShortCode:   "abc123",
+				ShortURL:    "http://localhost:8080/abc123",
+				OriginalURL: req.OriginalURL,
+				Reused:      true,
+			}, nil
+		},
+	}
+	handler := NewURLHandler(mockCreate, nil, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", bytes.NewReader([]byte(`{"original_url":"https://example.com"}`)))
+	req = req.WithContext(withUserID(req.Context(), "test-user"))
+	rec := httptest.NewRecorder()
+
+	handler.Create(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var resp CreateURLResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Reused || resp.ShortCode != "abc123" {
+		t.Errorf("expected reused abc123, got %+v", resp)
+	}
+}
+
+func TestURLHandler_Create_IdempotencyKey(t *testing.T) {
+	// Stands in for the use case's key store: the first request per key mints a code
+	codes := map[string]string{}
+	mockCreate := &mockCreateURLUseCase{
+		executeFunc: func(ctx context.Context, req application.CreateURLRequest) (*application.CreateURLResponse, error) {
+			code, ok := codes[req.IdempotencyKey]
+			if !ok {
+				code = fmt.Sprintf("code%d", len(codes)+1)
+				codes[req.IdempotencyKey] = code
+			}
+			return &application.CreateURLResponse{
+				ShortCode:   code,
+				ShortURL:    "http://localhost:8080/" + code,
+				OriginalURL: req.OriginalURL,
+			}, nil
+		},
+	}
+	handler := NewURLHandler(mockCreate, nil, nil, nil, nil, nil)
+
+	create := func(key string) string {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/api/urls", bytes.NewReader([]byte(`{"original_url":"https://example.com"}`)))
+		req.Header.Set("Idempotency-Key", key)
+		req = req.WithContext(withUserID(req.Context(), "test-user"))
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+		var resp CreateURLResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp.ShortCode
+	}
+
+	first := create("key-1")
+	if again := create("key-1"); again != first {
+		t.Errorf("same key returned %q, want %q", again, first)
+	}
+	if other := create("key-2"); other == first {
+		t.Errorf("different key returned the same short code %q", other)
+	}
+}
+
 // TestURLHandler_List tests the List endpoint
 func TestURLHandler_List(t *testing.T) {
 	tests := []struct {
@@ -321,7 +464,7 @@ func TestURLHandler_List(t *testing.T) {
 				},
 			}
 
-			handler := NewURLHandler(nil, mockList, nil)
+			handler := NewURLHandler(nil, mockList, nil, nil, nil, nil)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/urls"+tt.queryParams, nil)
 
@@ -344,6 +487,231 @@ func TestURLHandler_List(t *testing.T) {
 	}
 }
 
+// TestURLHandler_List_Query tests that the q parameter reaches the use case trimmed
+func TestURLHandler_List_Query(t *testing.T) {
+	var got application.ListURLsRequest
+	mockList := &mockListURLsUseCase{
+		executeFunc: func(ctx context.Context, req application.ListURLsRequest) (*application.ListURLsResponse, error) {
+			got = req
+			return &application.ListURLsResponse{URLs: []application.URLResponse{}, Limit: 20}, nil
+		},
+	}
+
+	handler := NewURLHandler(nil, mockList, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/urls?q=%20Docs%20&limit=5", nil)
+	req = req.WithContext(withUserID(req.Context(), "test-user"))
+	rec := httptest.NewRecorder()
+
+	handler.List(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got.Query != "Docs" {
+		t.Errorf("expected query %q, got %q", "Docs", got.Query)
+	}
+	if got.CreatedBy != "test-user" || got.Limit != 5 {
+		t.Errorf("unexpected request: %+v", got)
+	}
+}
+
+// TestURLHandler_List_ETag tests conditional GET /api/urls requests
+func TestURLHandler_List_ETag(t *testing.T) {
+	clicks := int64(1)
+	mockList := &mockListURLsUseCase{
+		executeFunc: func(ctx context.Context, req application.ListURLsRequest) (*application.ListURLsResponse, error) {
+			return &application.ListURLsResponse{
+				URLs:  []application.URLResponse{{ShortCode: "abc123", OriginalURL: "https://example.com", ClickCount: clicks}},
+				Total: 1,
+				Limit: 20,
+			}, nil
+		},
+	}
+
+	handler := NewURLHandler(nil, mockList, nil, nil, nil, nil)
+
+	get := func(userID, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/urls", nil)
+		req = req.WithContext(withUserID(req.Context(), userID))
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		handler.List(rec, req)
+		return rec
+	}
+
+	first := get("test-user", "")
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, first.Code)
+	}
+	etag := first.Header().Get("ETag")
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected a weak ETag, got %q", etag)
+	}
+
+	second := get("test-user", etag)
+	if second.Code != http.StatusNotModified {
+		t.Fatalf("expected status %d, got %d", http.StatusNotModified, second.Code)
+	}
+	if second.Body.Len() != 0 {
+		t.Errorf("expected empty 304 body, got %q", second.Body.String())
+	}
+	if got := second.Header().Get("ETag"); got != etag {
+		t.Errorf("expected 304 to repeat ETag %q, got %q", etag, got)
+	}
+
+	// Another user's identical response must not revalidate against this ETag
+	if rec := get("other-user", etag); rec.Code != http.StatusOK {
+		t.Errorf("expected another user to get status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	// A new click changes the body, and so the ETag
+	clicks++
+	third := get("test-user", etag)
+	if third.Code != http.StatusOK {
+		t.Fatalf("expected status %d after a change, got %d", http.StatusOK, third.Code)
+	}
+	if got := third.Header().Get("ETag"); got == etag {
+		t.Errorf("expected ETag to change after the response changed, still %q", got)
+	}
+}
+
+// TestURLHandler_CreatedByFilter tests that only admin token requests can scope
+// list and count to another user with ?created_by=
+func TestURLHandler_CreatedByFilter(t *testing.T) {
+	var listedFor, countedFor string
+	mockList := &mockListURLsUseCase{
+		executeFunc: func(ctx context.Context, req application.ListURLsRequest) (*application.ListURLsResponse, error) {
+			listedFor = req.CreatedBy
+			return &application.ListURLsResponse{URLs: []application.URLResponse{}, Limit: 20}, nil
+		},
+	}
+	mockCount := &mockCountURLsUseCase{
+		executeFunc: func(ctx context.Context, req application.CountURLsRequest) (*application.CountURLsResponse, error) {
+			countedFor = req.CreatedBy
+			return &application.CountURLsResponse{}, nil
+		},
+	}
+
+	handler := NewURLHandler(nil, mockList, mockCount, nil, nil, nil)
+	auth := middleware.SessionOrBearerAuth([]string{"admin-token"})
+	list := auth(http.HandlerFunc(handler.List))
+	count := auth(http.HandlerFunc(handler.Count))
+
+	tests := []struct {
+		name    string
+		query   string
+		session string
+		want    string
+	}{
+		{name: "admin filters by another user", query: "?created_by=alice", want: "alice"},
+		{name: "admin without filter sees own URLs", query: "", want: "authenticated-user"},
+		{name: "admin blank filter is ignored", query: "?created_by=%20", want: "authenticated-user"},
+		{name: "session cannot filter by another user", query: "?created_by=alice", session: "bob", want: "bob"},
+		{name: "session without filter sees own URLs", query: "", session: "bob", want: "bob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for path, h := range map[string]http.Handler{"/api/urls": list, "/api/urls/count": count} {
+				listedFor, countedFor = "", ""
+
+				req := httptest.NewRequest(http.MethodGet, path+tt.query, nil)
+				req.Header.Set("Authorization", "Bearer admin-token")
+				if tt.session != "" {
+					req = req.WithContext(context.WithValue(req.Context(), middleware.SessionUserIDKey, tt.session))
+				}
+				rec := httptest.NewRecorder()
+
+				h.ServeHTTP(rec, req)
+
+				if rec.Code != http.StatusOK {
+					t.Fatalf("%s: expected status %d, got %d", path, http.StatusOK, rec.Code)
+				}
+				if got := listedFor + countedFor; got != tt.want {
+					t.Errorf("%s: expected scope %q, got %q", path, tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+// TestURLHandler_Count tests the Count endpoint
+func TestURLHandler_Count(t *testing.T) {
+	// URLs created through the handler, by creator
+	created := map[string]int{}
+	mockCreate := &mockCreateURLUseCase{
+		executeFunc: func(ctx context.Context, req application.CreateURLRequest) (*application.CreateURLResponse, error) {
+			created[req.CreatedBy]++
+			code := fmt.Sprintf("%s%d", req.CreatedBy, created[req.CreatedBy])
+			return &application.CreateURLResponse{ShortCode: code, ShortURL: "https://mjr.wtf/" + code, OriginalURL: req.OriginalURL}, nil
+		},
+	}
+	mockCount := &mockCountURLsUseCase{
+		executeFunc: func(ctx context.Context, req application.CountURLsRequest) (*application.CountURLsResponse, error) {
+			return &application.CountURLsResponse{Total: created[req.CreatedBy]}, nil
+		},
+	}
+	handler := NewURLHandler(mockCreate, nil, mockCount, nil, nil, nil)
+
+	for _, userID := range []string{"alice", "alice", "bob"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/urls", strings.NewReader(`{"original_url":"https://example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = req.WithContext(withUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+
+		handler.Create(rec, req)
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create for %s: expected status %d, got %d: %s", userID, http.StatusCreated, rec.Code, rec.Body.String())
+		}
+	}
+
+	for userID, want := range map[string]int{"alice": 2, "bob": 1, "carol": 0} {
+		req := httptest.NewRequest(http.MethodGet, "/api/urls/count", nil)
+		req = req.WithContext(withUserID(req.Context(), userID))
+		rec := httptest.NewRecorder()
+
+		handler.Count(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("count for %s: expected status %d, got %d", userID, http.StatusOK, rec.Code)
+		}
+		if body := strings.TrimSpace(rec.Body.String()); body != fmt.Sprintf(`{"total":%d}`, want) {
+			t.Errorf("count for %s: expected {\"total\":%d}, got %s", userID, want, body)
+		}
+	}
+
+	t.Run("requires authentication", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.Count(rec, httptest.NewRequest(http.MethodGet, "/api/urls/count", nil))
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+		}
+	})
+
+	t.Run("use case error", func(t *testing.T) {
+		failing := NewURLHandler(nil, nil, &mockCountURLsUseCase{
+			executeFunc: func(ctx context.Context, req application.CountURLsRequest) (*application.CountURLsResponse, error) {
+				return nil, errors.New("database error")
+			},
+		}, nil, nil, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/urls/count", nil)
+		req = req.WithContext(withUserID(req.Context(), "alice"))
+		rec := httptest.NewRecorder()
+
+		failing.Count(rec, req)
+
+		if rec.Code != http.StatusInternalServerError {
+			t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+		}
+	})
+}
+
 // TestURLHandler_Delete tests the Delete endpoint
 func TestURLHandler_Delete(t *testing.T) {
 	tests := []struct {
@@ -382,7 +750,7 @@ func TestURLHandler_Delete(t *testing.T) {
 			shortCode:      "notfound",
 			mockError:      url.ErrURLNotFound,
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   `{"error":"url not found"}`,
+			expectedBody:   `{"error":"url not found","code":"url_not_found"}`,
 		},
 		{
 			name:           "unauthorized deletion",
@@ -391,7 +759,7 @@ func TestURLHandler_Delete(t *testing.T) {
 			shortCode:      "abc123",
 			mockError:      url.ErrUnauthorizedDeletion,
 			expectedStatus: http.StatusForbidden,
-			expectedBody:   `{"error":"unauthorized: you can only delete URLs you created"}`,
+			expectedBody:   `{"error":"unauthorized: you can only delete URLs you created","code":"forbidden"}`,
 		},
 		{
 			name:           "invalid short code",
@@ -400,7 +768,7 @@ func TestURLHandler_Delete(t *testing.T) {
 			shortCode:      "ab",
 			mockError:      url.ErrInvalidShortCode,
 			expectedStatus: http.StatusBadRequest,
-			expectedBody:   `{"error":"short code must be 3-20 characters long and contain only alphanumeric characters, underscores, or hyphens"}`,
+			expectedBody:   `{"error":"short code must be 3-20 characters long and contain only alphanumeric characters, underscores, or hyphens","code":"invalid_short_code"}`,
 		},
 	}
 
@@ -415,7 +783,7 @@ func TestURLHandler_Delete(t *testing.T) {
 				},
 			}
 
-			handler := NewURLHandler(nil, nil, mockDelete)
+			handler := NewURLHandler(nil, nil, nil, mockDelete, nil, nil)
 
 			req := httptest.NewRequest(http.MethodDelete, "/api/urls/"+tt.shortCode, nil)
 
@@ -447,6 +815,185 @@ func TestURLHandler_Delete(t *testing.T) {
 	}
 }
 
+// TestURLHandler_Restore tests the Restore endpoint
+func TestURLHandler_Restore(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		hasUserID      bool
+		shortCode      string
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "successful restore",
+			userID:         "test-user",
+			hasUserID:      true,
+			shortCode:      "abc123",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "missing user ID",
+			hasUserID:      false,
+			shortCode:      "abc123",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name:           "URL not found",
+			userID:         "test-user",
+			hasUserID:      true,
+			shortCode:      "notfound",
+			mockError:      url.ErrURLNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"url not found","code":"url_not_found"}`,
+		},
+		{
+			name:           "unauthorized restore",
+			userID:         "test-user",
+			hasUserID:      true,
+			shortCode:      "abc123",
+			mockError:      url.ErrUnauthorizedRestore,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"unauthorized: you can only restore URLs you created","code":"forbidden"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRestore := &mockRestoreURLUseCase{
+				executeFunc: func(ctx context.Context, req application.RestoreURLRequest) (*application.RestoreURLResponse, error) {
+					if tt.mockError != nil {
+						return nil, tt.mockError
+					}
+					return &application.RestoreURLResponse{Success: true}, nil
+				},
+			}
+
+			handler := NewURLHandler(nil, nil, nil, nil, nil, mockRestore)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/urls/"+tt.shortCode+"/restore", nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("shortCode", tt.shortCode)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			if tt.hasUserID {
+				req = req.WithContext(withUserID(req.Context(), tt.userID))
+			}
+
+			rec := httptest.NewRecorder()
+
+			handler.Restore(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			body := strings.TrimSuffix(rec.Body.String(), "\n")
+			if body != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+			}
+		})
+	}
+}
+
+// TestURLHandler_DeleteByPrefix tests the bulk DeleteByPrefix endpoint
+func TestURLHandler_DeleteByPrefix(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		hasUserID      bool
+		query          string
+		mockResponse   *application.DeleteURLsByPrefixResponse
+		mockError      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "successful deletion",
+			userID:         "test-user",
+			hasUserID:      true,
+			query:          "?prefix=launch-",
+			mockResponse:   &application.DeleteURLsByPrefixResponse{Deleted: 3},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"deleted":3}`,
+		},
+		{
+			name:           "missing user ID",
+			hasUserID:      false,
+			query:          "?prefix=launch-",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name:           "missing prefix",
+			userID:         "test-user",
+			hasUserID:      true,
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"prefix is required"}`,
+		},
+		{
+			name:           "empty prefix",
+			userID:         "test-user",
+			hasUserID:      true,
+			query:          "?prefix=",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"prefix is required"}`,
+		},
+		{
+			name:           "invalid prefix",
+			userID:         "test-user",
+			hasUserID:      true,
+			query:          "?prefix=launch%2A",
+			mockError:      url.ErrInvalidShortCodePrefix,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"short code prefix must be 1-20 characters long and contain only alphanumeric characters, underscores, or hyphens","code":"invalid_short_code"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotReq application.DeleteURLsByPrefixRequest
+			mockDeleteByPrefix := &mockDeleteURLsByPrefixUseCase{
+				executeFunc: func(ctx context.Context, req application.DeleteURLsByPrefixRequest) (*application.DeleteURLsByPrefixResponse, error) {
+					gotReq = req
+					if tt.mockError != nil {
+						return nil, tt.mockError
+					}
+					return tt.mockResponse, nil
+				},
+			}
+
+			handler := NewURLHandler(nil, nil, nil, nil, mockDeleteByPrefix, nil)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/urls"+tt.query, nil)
+			if tt.hasUserID {
+				req = req.WithContext(withUserID(req.Context(), tt.userID))
+			}
+
+			rec := httptest.NewRecorder()
+
+			handler.DeleteByPrefix(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			body := strings.TrimSuffix(rec.Body.String(), "\n")
+			if body != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, body)
+			}
+
+			if tt.expectedStatus == http.StatusOK && (gotReq.Prefix != "launch-" || gotReq.RequestedBy != tt.userID) {
+				t.Errorf("use case got request %+v", gotReq)
+			}
+		})
+	}
+}
+
 // TestHandleUseCaseError tests error mapping
 func TestHandleUseCaseError(t *testing.T) {
 	tests := []struct {
@@ -459,29 +1006,77 @@ func TestHandleUseCaseError(t *testing.T) {
 			name:           "URL not found",
 			err:            url.ErrURLNotFound,
 			expectedStatus: http.StatusNotFound,
-			expectedBody:   `{"error":"url not found"}`,
+			expectedBody:   `{"error":"url not found","code":"url_not_found"}`,
 		},
 		{
 			name:           "duplicate short code",
 			err:            url.ErrDuplicateShortCode,
 			expectedStatus: http.StatusConflict,
-			expectedBody:   `{"error":"short code already exists"}`,
+			expectedBody:   `{"error":"short code already exists","code":"duplicate_short_code"}`,
 		},
 		{
 			name:           "invalid short code",
 			err:            url.ErrInvalidShortCode,
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:           "reserved short code",
+			err:            url.ErrReservedShortCode,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"short code is reserved","code":"invalid_short_code"}`,
+		},
+		{
+			name:           "invalid idempotency key",
+			err:            idempotency.ErrInvalidKey,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"idempotency key must be 1-255 printable ASCII characters","code":"invalid_idempotency_key"}`,
+		},
+		{
+			name:           "idempotency key reused for a different request",
+			err:            idempotency.ErrKeyMismatch,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error":"` + idempotency.ErrKeyMismatch.Error() + `","code":"idempotency_key_mismatch"}`,
+		},
+		{
+			name:           "idempotency key still in use",
+			err:            idempotency.ErrKeyInProgress,
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"` + idempotency.ErrKeyInProgress.Error() + `","code":"idempotency_key_in_use"}`,
+		},
 		{
 			name:           "unauthorized deletion",
 			err:            url.ErrUnauthorizedDeletion,
 			expectedStatus: http.StatusForbidden,
 		},
+		{
+			name:           "missing URL scheme",
+			err:            url.ErrMissingURLScheme,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"URL must have a scheme (http or https)","code":"invalid_url"}`,
+		},
+		{
+			name:           "destination not allowed",
+			err:            url.ErrDestinationNotAllowed,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"destination host is not allowed","code":"destination_not_allowed"}`,
+		},
+		{
+			name:           "private destination",
+			err:            url.ErrPrivateDestination,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"destination resolves to a private or internal address","code":"destination_not_allowed"}`,
+		},
+		{
+			name:           "wrapped domain error keeps its code",
+			err:            fmt.Errorf("create: %w", url.ErrEmptyShortCode),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"create: short code cannot be empty","code":"invalid_short_code"}`,
+		},
 		{
 			name:           "unknown error",
 			err:            errors.New("unknown error"),
 			expectedStatus: http.StatusInternalServerError,
-			expectedBody:   `{"error":"internal server error"}`,
+			expectedBody:   `{"error":"internal server error","code":"internal_error"}`,
 		},
 	}
 
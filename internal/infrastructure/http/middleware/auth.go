@@ -13,6 +13,8 @@ type contextKey string
 const (
 	// UserIDKey is the context key for storing user identity
 	UserIDKey contextKey = "userID"
+	// AdminTokenKey marks requests authenticated with a configured AUTH_TOKEN
+	AdminTokenKey contextKey = "adminToken"
 )
 
 // Auth returns a middleware that validates Bearer token authentication.
@@ -57,6 +59,7 @@ func Auth(authTokens []string) func(http.Handler) http.Handler {
 
 			// Add user identity to request context
 			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+			ctx = context.WithValue(ctx, AdminTokenKey, true)
 
 			// Continue with authenticated request
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -86,3 +89,10 @@ func GetUserID(ctx context.Context) (string, bool) {
 	userID, ok := ctx.Value(UserIDKey).(string)
 	return userID, ok
 }
+
+// IsAdminToken reports whether the request was authenticated with a configured
+// AUTH_TOKEN rather than a session or Tailscale identity
+func IsAdminToken(ctx context.Context) bool {
+	admin, _ := ctx.Value(AdminTokenKey).(bool)
+	return admin
+}
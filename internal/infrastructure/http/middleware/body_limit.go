@@ -0,0 +1,29 @@
+package middleware
+
+import "net/http"
+
+// MaxRequestBody returns a middleware capping request bodies at limit bytes.
+//
+// Requests declaring a larger Content-Length are refused with 413 before any
+// handler runs. Other bodies are wrapped in http.MaxBytesReader, so reading
+// past the limit fails with *http.MaxBytesError, which handlers report as 413.
+// A limit <= 0 disables the cap.
+func MaxRequestBody(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if limit <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				respondJSONError(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
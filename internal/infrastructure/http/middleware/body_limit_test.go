@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxRequestBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		hideLength    bool
+		wantStatus    int
+		wantReached   bool
+		wantReadError bool
+	}{
+		{name: "under the limit", body: "0123456789", wantStatus: http.StatusOK, wantReached: true},
+		{name: "declared length over the limit", body: "0123456789abcdef", wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "streamed body over the limit", body: "0123456789abcdef", hideLength: true, wantStatus: http.StatusRequestEntityTooLarge, wantReached: true, wantReadError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached := false
+			var readErr error
+			handler := MaxRequestBody(10)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reached = true
+				if _, readErr = io.ReadAll(r.Body); readErr != nil {
+					var maxBytesErr *http.MaxBytesError
+					if !errors.As(readErr, &maxBytesErr) {
+						t.Errorf("expected *http.MaxBytesError, got %v", readErr)
+					}
+					w.WriteHeader(http.StatusRequestEntityTooLarge)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/urls", strings.NewReader(tt.body))
+			if tt.hideLength {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if reached != tt.wantReached {
+				t.Errorf("expected handler reached = %v, got %v", tt.wantReached, reached)
+			}
+			if (readErr != nil) != tt.wantReadError {
+				t.Errorf("expected read error = %v, got %v", tt.wantReadError, readErr)
+			}
+		})
+	}
+}
+
+func TestMaxRequestBody_DisabledWhenZero(t *testing.T) {
+	handler := MaxRequestBody(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			t.Errorf("expected no read error, got %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", strings.NewReader(strings.Repeat("a", 1<<16)))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
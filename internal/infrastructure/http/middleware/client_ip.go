@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ClientIPKey is the context key for storing the resolved client IP
+	ClientIPKey contextKey = "clientIP"
+
+	// verifiedClientIPKey is the context key for the client IP without the X-Real-IP fallback
+	verifiedClientIPKey contextKey = "verifiedClientIP"
+)
+
+// ClientIP resolves the client IP once per request and stores it in the context.
+//
+// When trustProxyHeaders is false, only r.RemoteAddr is used, so clients cannot
+// spoof their address via X-Forwarded-For/X-Real-IP. Enable it only when the
+// server sits behind a reverse proxy that sets (or appends to) those headers.
+// trustedProxies lists the proxies' networks; with it empty, the direct peer
+// is taken to be the only proxy.
+func ClientIP(trustProxyHeaders bool, trustedProxies []*net.IPNet) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip, verified := resolveClientIP(r, trustProxyHeaders, trustedProxies)
+			ctx := context.WithValue(r.Context(), ClientIPKey, ip)
+			ctx = context.WithValue(ctx, verifiedClientIPKey, verified)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetClientIP returns the client IP stored by the ClientIP middleware.
+// If the middleware did not run, it falls back to the host part of r.RemoteAddr.
+func GetClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(ClientIPKey).(string); ok && ip != "" {
+		return ip
+	}
+	return remoteAddrIP(r.RemoteAddr)
+}
+
+// GetVerifiedClientIP returns the client IP as far as the proxy chain vouches
+// for it: the X-Forwarded-For walk when headers are trusted, otherwise the peer.
+// Unlike GetClientIP it never falls back to X-Real-IP, which a proxy may pass
+// through unchanged, so it is safe for decisions that grant extra access.
+func GetVerifiedClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(verifiedClientIPKey).(string); ok && ip != "" {
+		return ip
+	}
+	return remoteAddrIP(r.RemoteAddr)
+}
+
+// resolveClientIP extracts the client IP from the request.
+//
+// With trustProxyHeaders, and the peer at RemoteAddr being a trusted proxy, the
+// X-Forwarded-For chain is walked right to left, skipping hops inside
+// trustedProxies, and the first hop outside them is the client. Entries further
+// left were written by the client and are never used, so a spoofed
+// X-Forwarded-For cannot pick the address. With no trustedProxies, the rightmost
+// entry (the one the proxy appended) is used. A malformed entry ends the walk at
+// the last trusted hop. Without X-Forwarded-For, X-Real-IP is used; otherwise,
+// or if the headers aren't trusted, the host part of RemoteAddr is returned.
+// verified is the same address, except that it is the peer when the client IP
+// came from X-Real-IP.
+func resolveClientIP(r *http.Request, trustProxyHeaders bool, trustedProxies []*net.IPNet) (ip, verified string) {
+	peer := remoteAddrIP(r.RemoteAddr)
+	if !trustProxyHeaders || !isTrustedProxy(peer, trustedProxies) {
+		return peer, peer
+	}
+
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		client := peer
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop == "" {
+				continue
+			}
+			if net.ParseIP(hop) == nil {
+				break
+			}
+			client = hop
+			if len(trustedProxies) == 0 || !isTrustedProxy(hop, trustedProxies) {
+				break
+			}
+		}
+		return client, client
+	}
+
+	if xrip := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(xrip) != nil {
+		return xrip, peer
+	}
+
+	return peer, peer
+}
+
+// isTrustedProxy reports whether ip is inside trustedProxies. With no list,
+// every address is trusted, since the operator has vouched for the peer.
+func isTrustedProxy(ip string, trustedProxies []*net.IPNet) bool {
+	if len(trustedProxies) == 0 {
+		return true
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, n := range trustedProxies {
+		if n.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+func remoteAddrIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err == nil {
+		return host
+	}
+	return remoteAddr
+}
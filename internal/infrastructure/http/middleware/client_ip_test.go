@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveClientIP(t *testing.T) {
+	proxies := []string{"10.0.0.0/8", "192.0.2.0/24"}
+
+	tests := []struct {
+		name       string
+		trust      bool
+		proxies    []string
+		remoteAddr string
+		xff        string
+		xRealIP    string
+		want       string
+	}{
+		{"untrusted ignores spoofed XFF", false, nil, "192.0.2.50:1234", "203.0.113.9", "", "192.0.2.50"},
+		{"untrusted ignores spoofed X-Real-IP", false, nil, "192.0.2.50:1234", "", "203.0.113.55", "192.0.2.50"},
+		{"trusted uses rightmost XFF without proxy list", true, nil, "192.0.2.50:1234", "203.0.113.9, 10.0.0.1", "", "10.0.0.1"},
+		{"trusted skips proxy hops", true, proxies, "192.0.2.50:1234", "203.0.113.9, 10.0.0.1", "", "203.0.113.9"},
+		{"spoofed leftmost XFF ignored", true, proxies, "192.0.2.50:1234", "1.2.3.4, 203.0.113.9, 10.0.0.1", "", "203.0.113.9"},
+		{"spoofed leftmost XFF ignored without proxy list", true, nil, "192.0.2.50:1234", "1.2.3.4, 203.0.113.9", "", "203.0.113.9"},
+		{"headers from an untrusted peer ignored", true, proxies, "198.51.100.7:1234", "203.0.113.9", "", "198.51.100.7"},
+		{"trusted XFF skips empty segments", true, nil, "192.0.2.50:1234", "203.0.113.9, ", "", "203.0.113.9"},
+		{"malformed hop ends the walk", true, proxies, "192.0.2.50:1234", "203.0.113.9, unknown, 10.0.0.1", "", "10.0.0.1"},
+		{"all hops trusted uses leftmost", true, proxies, "192.0.2.50:1234", "10.0.0.2, 10.0.0.1", "", "10.0.0.2"},
+		{"multiple XFF headers form one chain", true, proxies, "192.0.2.50:1234", "", "", "203.0.113.9"},
+		{"trusted XFF wins over X-Real-IP", true, nil, "192.0.2.50:1234", "203.0.113.9", "203.0.113.55", "203.0.113.9"},
+		{"trusted uses X-Real-IP", true, nil, "192.0.2.50:1234", "", "203.0.113.55", "203.0.113.55"},
+		{"trusted falls back to RemoteAddr", true, nil, "192.0.2.50:1234", "", "", "192.0.2.50"},
+		{"trusted IPv6 XFF", true, nil, "192.0.2.50:1234", "2001:db8::1", "", "2001:db8::1"},
+		{"RemoteAddr without port", false, nil, "192.0.2.77", "", "", "192.0.2.77"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.xff != "" {
+				req.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if tt.name == "multiple XFF headers form one chain" {
+				req.Header.Add("X-Forwarded-For", "1.2.3.4, 203.0.113.9")
+				req.Header.Add("X-Forwarded-For", "10.0.0.1")
+			}
+			if tt.xRealIP != "" {
+				req.Header.Set("X-Real-IP", tt.xRealIP)
+			}
+
+			if got, _ := resolveClientIP(req, tt.trust, mustParseCIDRs(t, tt.proxies...)); got != tt.want {
+				t.Fatalf("resolveClientIP()=%q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientIP_StoresIPInContext(t *testing.T) {
+	var got string
+	handler := ClientIP(true, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = GetClientIP(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.50:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "203.0.113.9" {
+		t.Fatalf("GetClientIP()=%q, want %q", got, "203.0.113.9")
+	}
+}
+
+func TestGetClientIP_WithoutMiddlewareUsesRemoteAddr(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.50:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+
+	if got := GetClientIP(req); got != "192.0.2.50" {
+		t.Fatalf("GetClientIP()=%q, want %q", got, "192.0.2.50")
+	}
+}
+
+func TestGetVerifiedClientIP_IgnoresXRealIP(t *testing.T) {
+	var ip, verified string
+	handler := ClientIP(true, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, verified = GetClientIP(r), GetVerifiedClientIP(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "192.0.2.50:1234"
+	req.Header.Set("X-Real-IP", "10.0.0.9")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if ip != "10.0.0.9" || verified != "192.0.2.50" {
+		t.Fatalf("GetClientIP()=%q GetVerifiedClientIP()=%q, want 10.0.0.9 and 192.0.2.50", ip, verified)
+	}
+
+	req.Header.Del("X-Real-IP")
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if verified != "203.0.113.9" {
+		t.Fatalf("GetVerifiedClientIP()=%q, want the X-Forwarded-For hop 203.0.113.9", verified)
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/cors"
+)
+
+// DefaultCORSAllowedMethods are the methods cross-origin requests may use when none are configured
+var DefaultCORSAllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+
+// DefaultCORSAllowedHeaders are the request headers cross-origin requests may send when none are configured
+var DefaultCORSAllowedHeaders = []string{"Accept", "Authorization", "Content-Type", "Idempotency-Key", "If-None-Match", "X-Request-ID"}
+
+// CORSConfig configures the CORS middleware
+type CORSConfig struct {
+	AllowedOrigins   []string // "*" allows every origin; "https://*.example.com" matches subdomains
+	AllowedMethods   []string // Defaults to DefaultCORSAllowedMethods
+	AllowedHeaders   []string // Defaults to DefaultCORSAllowedHeaders
+	ExposedHeaders   []string // Response headers scripts may read
+	AllowCredentials bool     // Send Access-Control-Allow-Credentials so browsers include cookies
+	MaxAge           int      // Seconds browsers may cache a preflight response
+}
+
+// CORS returns a middleware answering preflight OPTIONS requests and adding
+// Access-Control-* headers to cross-origin requests.
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	methods := cfg.AllowedMethods
+	if len(methods) == 0 {
+		methods = DefaultCORSAllowedMethods
+	}
+	headers := cfg.AllowedHeaders
+	if len(headers) == 0 {
+		headers = DefaultCORSAllowedHeaders
+	}
+
+	return cors.Handler(cors.Options{
+		AllowedOrigins:   cfg.AllowedOrigins,
+		AllowedMethods:   methods,
+		AllowedHeaders:   headers,
+		ExposedHeaders:   cfg.ExposedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAge,
+	})
+}
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func serveCORS(cfg CORSConfig, req *http.Request) (*httptest.ResponseRecorder, bool) {
+	reached := false
+	handler := CORS(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, reached
+}
+
+func preflight(origin, method, headers string) *http.Request {
+	req := httptest.NewRequest(http.MethodOptions, "/api/urls", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	return req
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	cfg := CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		MaxAge:         300,
+	}
+
+	rec, reached := serveCORS(cfg, preflight("https://app.example.com", "POST", "authorization, content-type"))
+	if reached {
+		t.Error("expected the preflight to be answered by the middleware")
+	}
+	if rec.Code != http.StatusOK && rec.Code != http.StatusNoContent {
+		t.Fatalf("expected a successful preflight, got %d", rec.Code)
+	}
+
+	want := map[string]string{
+		"Access-Control-Allow-Origin":  "https://app.example.com",
+		"Access-Control-Allow-Methods": "POST",
+		"Access-Control-Allow-Headers": "Authorization, Content-Type",
+		"Access-Control-Max-Age":       "300",
+	}
+	for header, value := range want {
+		if got := rec.Header().Get(header); got != value {
+			t.Errorf("expected %s %q, got %q", header, value, got)
+		}
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("expected no Access-Control-Allow-Credentials, got %q", got)
+	}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+	}{
+		{name: "method not allowed", req: preflight("https://app.example.com", "DELETE", "")},
+		{name: "header not allowed", req: preflight("https://app.example.com", "POST", "x-custom")},
+		{name: "origin not allowed", req: preflight("https://evil.example.com", "POST", "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, _ := serveCORS(cfg, tt.req)
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+				t.Errorf("expected no Access-Control-Allow-Origin, got %q", got)
+			}
+		})
+	}
+}
+
+func TestCORS_DefaultsMethodsAndHeaders(t *testing.T) {
+	rec, _ := serveCORS(CORSConfig{AllowedOrigins: []string{"*"}}, preflight("https://app.example.com", "DELETE", "idempotency-key"))
+
+	if got := rec.Header().Get("Access-Control-Allow-Methods"); got != "DELETE" {
+		t.Errorf("expected DELETE to be allowed by default, got %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Headers"); got != "Idempotency-Key" {
+		t.Errorf("expected Idempotency-Key to be allowed by default, got %q", got)
+	}
+}
+
+func TestCORS_SimpleRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/urls", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+
+	rec, reached := serveCORS(CORSConfig{
+		AllowedOrigins: []string{"https://app.example.com"},
+		ExposedHeaders: []string{"ETag", "X-Request-ID"},
+	}, req)
+
+	if !reached {
+		t.Fatal("expected the request to reach the handler")
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("expected Access-Control-Allow-Origin to echo the origin, got %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Expose-Headers"); got != "Etag, X-Request-Id" {
+		t.Errorf("expected Access-Control-Expose-Headers %q, got %q", "Etag, X-Request-Id", got)
+	}
+	if got := rec.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("expected Vary: Origin, got %q", got)
+	}
+}
+
+func TestCORS_Credentials(t *testing.T) {
+	tests := []struct {
+		name            string
+		origin          string
+		wantAllowOrigin string
+		wantCredentials string
+	}{
+		{name: "allowed origin is echoed", origin: "https://app.example.com", wantAllowOrigin: "https://app.example.com", wantCredentials: "true"},
+		{name: "disallowed origin gets no CORS headers", origin: "https://evil.example.net"},
+	}
+
+	cfg := CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/urls", nil)
+			req.Header.Set("Origin", tt.origin)
+			for _, r := range []*http.Request{req, preflight(tt.origin, "GET", "")} {
+				rec, _ := serveCORS(cfg, r)
+				if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantAllowOrigin {
+					t.Errorf("%s: expected Access-Control-Allow-Origin %q, got %q", r.Method, tt.wantAllowOrigin, got)
+				}
+				if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCredentials {
+					t.Errorf("%s: expected Access-Control-Allow-Credentials %q, got %q", r.Method, tt.wantCredentials, got)
+				}
+			}
+		})
+	}
+}
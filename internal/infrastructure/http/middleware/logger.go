@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"bufio"
+	"context"
+	"math/rand/v2"
 	"net"
 	"net/http"
 	"time"
@@ -10,15 +12,17 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// responseWriter wraps http.ResponseWriter to capture status code and response size
-type responseWriter struct {
+// statusRecorder wraps http.ResponseWriter to capture status code and response size.
+// Only the first WriteHeader call is recorded (and forwarded), so handlers that
+// call it more than once don't skew the access log.
+type statusRecorder struct {
 	http.ResponseWriter
 	status      int
 	size        int
 	wroteHeader bool
 }
 
-func (rw *responseWriter) WriteHeader(status int) {
+func (rw *statusRecorder) WriteHeader(status int) {
 	if !rw.wroteHeader {
 		rw.status = status
 		rw.wroteHeader = true
@@ -26,7 +30,7 @@ func (rw *responseWriter) WriteHeader(status int) {
 	}
 }
 
-func (rw *responseWriter) Write(b []byte) (int, error) {
+func (rw *statusRecorder) Write(b []byte) (int, error) {
 	if !rw.wroteHeader {
 		rw.WriteHeader(http.StatusOK)
 	}
@@ -36,36 +40,89 @@ func (rw *responseWriter) Write(b []byte) (int, error) {
 }
 
 // Flush implements http.Flusher
-func (rw *responseWriter) Flush() {
+func (rw *statusRecorder) Flush() {
 	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
 		f.Flush()
 	}
 }
 
 // Hijack implements http.Hijacker
-func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+func (rw *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	if h, ok := rw.ResponseWriter.(http.Hijacker); ok {
 		return h.Hijack()
 	}
 	return nil, nil, http.ErrNotSupported
 }
 
+// Unwrap returns the underlying ResponseWriter for http.ResponseController
+func (rw *statusRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Push implements http.Pusher
-func (rw *responseWriter) Push(target string, opts *http.PushOptions) error {
+func (rw *statusRecorder) Push(target string, opts *http.PushOptions) error {
 	if p, ok := rw.ResponseWriter.(http.Pusher); ok {
 		return p.Push(target, opts)
 	}
 	return http.ErrNotSupported
 }
 
+type accessLogKey struct{}
+
+// accessLogState is shared between Logger and the middleware it wraps, so
+// route-level middleware can change how the request's access log is written.
+type accessLogState struct {
+	sampler zerolog.Sampler
+}
+
+// SampleAccessLog samples the access log entries of the requests it wraps:
+// an entry is only written when sampler keeps it. Responses with a status of
+// 400 or above are never sampled out. It has no effect outside Logger.
+func SampleAccessLog(sampler zerolog.Sampler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if state, ok := r.Context().Value(accessLogKey{}).(*accessLogState); ok {
+				state.sampler = sampler
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RateSampler returns a sampler that keeps a random fraction rate (0.0-1.0) of events.
+func RateSampler(rate float64) zerolog.Sampler {
+	return rateSampler(rate)
+}
+
+type rateSampler float64
+
+func (s rateSampler) Sample(zerolog.Level) bool {
+	return rand.Float64() < float64(s)
+}
+
 // Logger logs HTTP requests with structured logging using zerolog.
 // It logs method, path, status, response size, and duration.
 func Logger(next http.Handler) http.Handler {
+	return logRequests(next, nil)
+}
+
+// AccessLog is Logger writing to sink instead of the request's logger, so
+// access logs can be shipped separately from application logs. Entries carry
+// the request ID alongside the usual fields.
+func AccessLog(sink zerolog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return logRequests(next, &sink)
+	}
+}
+
+func logRequests(next http.Handler, sink *zerolog.Logger) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
+		state := &accessLogState{}
+		r = r.WithContext(context.WithValue(r.Context(), accessLogKey{}, state))
 
-		// Wrap the response writer to capture status
-		wrapped := &responseWriter{
+		// Wrap the response writer to capture status and bytes written
+		wrapped := &statusRecorder{
 			ResponseWriter: w,
 			status:         http.StatusOK,
 		}
@@ -74,8 +131,15 @@ func Logger(next http.Handler) http.Handler {
 
 		duration := time.Since(start)
 
+		if state.sampler != nil && wrapped.status < 400 && !state.sampler.Sample(zerolog.InfoLevel) {
+			return
+		}
+
 		// Get logger from context (or use a disabled logger if not present)
 		logger := logging.FromContext(r.Context())
+		if sink != nil {
+			logger = sink.With().Str("request_id", logging.GetRequestID(r.Context())).Logger()
+		}
 
 		// Determine log level based on status code
 		var event *zerolog.Event
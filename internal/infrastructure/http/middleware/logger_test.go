@@ -101,6 +101,99 @@ func TestLogger_StructuredOutput(t *testing.T) {
 	}
 }
 
+func TestLogger_AccessLogFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantLevel string
+	}{
+		{"ok", http.StatusOK, "hello world", "info"},
+		{"not found", http.StatusNotFound, "nope", "warn"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := zerolog.New(&buf)
+
+			handler := Logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				// Split writes must be summed, not counted once.
+				_, _ = w.Write([]byte(tt.body[:2]))
+				_, _ = w.Write([]byte(tt.body[2:]))
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/urls", nil)
+			req = req.WithContext(logging.WithLogger(req.Context(), logger))
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+			if len(lines) != 1 {
+				t.Fatalf("expected exactly one log line, got %d: %s", len(lines), buf.String())
+			}
+
+			var logEntry map[string]interface{}
+			if err := json.Unmarshal(lines[0], &logEntry); err != nil {
+				t.Fatalf("expected JSON log output, got: %s", buf.String())
+			}
+
+			if logEntry["level"] != tt.wantLevel {
+				t.Errorf("expected level %s, got %v", tt.wantLevel, logEntry["level"])
+			}
+			if logEntry["method"] != http.MethodPost {
+				t.Errorf("expected method POST, got %v", logEntry["method"])
+			}
+			if logEntry["path"] != "/api/urls" {
+				t.Errorf("expected path /api/urls, got %v", logEntry["path"])
+			}
+			if logEntry["status"].(float64) != float64(tt.status) {
+				t.Errorf("expected status %d, got %v", tt.status, logEntry["status"])
+			}
+			if logEntry["size"].(float64) != float64(len(tt.body)) {
+				t.Errorf("expected size %d, got %v", len(tt.body), logEntry["size"])
+			}
+			if _, ok := logEntry["duration"]; !ok {
+				t.Error("expected duration field in log")
+			}
+		})
+	}
+}
+
+func TestLogger_MultipleWriteHeaderRecordsFirstStatus(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	handler := Logger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("x"))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	req = req.WithContext(logging.WithLogger(req.Context(), logger))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected client to receive %d, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("expected a single JSON log line, got: %s", buf.String())
+	}
+	if logEntry["status"].(float64) != http.StatusNotFound {
+		t.Errorf("expected logged status 404, got %v", logEntry["status"])
+	}
+	if logEntry["size"].(float64) != 1 {
+		t.Errorf("expected size 1, got %v", logEntry["size"])
+	}
+}
+
 func TestLogger_LogLevel_ByStatusCode(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -140,3 +233,76 @@ func TestLogger_LogLevel_ByStatusCode(t *testing.T) {
 		})
 	}
 }
+
+func TestLogger_SampleAccessLog(t *testing.T) {
+	serve := func(sampler zerolog.Sampler, status, n int) int {
+		var buf bytes.Buffer
+		logger := zerolog.New(&buf)
+
+		handler := Logger(SampleAccessLog(sampler)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		})))
+
+		for i := 0; i < n; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+			req = req.WithContext(logging.WithLogger(req.Context(), logger))
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}
+		return bytes.Count(buf.Bytes(), []byte("\n"))
+	}
+
+	t.Run("successful requests are sampled", func(t *testing.T) {
+		// BasicSampler keeps every Nth event, so the fraction is exact
+		if got := serve(&zerolog.BasicSampler{N: 4}, http.StatusFound, 100); got != 25 {
+			t.Errorf("expected 25 of 100 requests logged, got %d", got)
+		}
+	})
+
+	t.Run("errors are never sampled out", func(t *testing.T) {
+		for _, status := range []int{http.StatusNotFound, http.StatusTooManyRequests, http.StatusInternalServerError} {
+			if got := serve(RateSampler(0), status, 20); got != 20 {
+				t.Errorf("status %d: expected all 20 requests logged, got %d", status, got)
+			}
+		}
+	})
+
+	t.Run("rate bounds", func(t *testing.T) {
+		if got := serve(RateSampler(0), http.StatusFound, 50); got != 0 {
+			t.Errorf("rate 0: expected no requests logged, got %d", got)
+		}
+		if got := serve(RateSampler(1), http.StatusFound, 50); got != 50 {
+			t.Errorf("rate 1: expected all 50 requests logged, got %d", got)
+		}
+	})
+}
+
+func TestAccessLog_WritesToSink(t *testing.T) {
+	var appBuf, sinkBuf bytes.Buffer
+
+	handler := AccessLog(zerolog.New(&sinkBuf))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", nil)
+	ctx := logging.WithLogger(req.Context(), zerolog.New(&appBuf))
+	ctx = logging.WithRequestID(ctx, "req-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+
+	if appBuf.Len() != 0 {
+		t.Errorf("expected nothing on the request logger, got %s", appBuf.String())
+	}
+
+	var logEntry map[string]interface{}
+	if err := json.Unmarshal(sinkBuf.Bytes(), &logEntry); err != nil {
+		t.Fatalf("expected JSON log output, got: %s", sinkBuf.String())
+	}
+	if logEntry["request_id"] != "req-1" {
+		t.Errorf("expected request_id req-1, got %v", logEntry["request_id"])
+	}
+	if logEntry["method"] != "POST" || logEntry["path"] != "/api/urls" {
+		t.Errorf("expected POST /api/urls, got %v %v", logEntry["method"], logEntry["path"])
+	}
+	if logEntry["status"].(float64) != 201 {
+		t.Errorf("expected status 201, got %v", logEntry["status"])
+	}
+}
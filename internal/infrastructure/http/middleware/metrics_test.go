@@ -88,6 +88,7 @@ func TestNormalizePath(t *testing.T) {
 		{"root path", "/", "/"},
 		{"health endpoint", "/health", "/health"},
 		{"ready endpoint", "/ready", "/ready"},
+		{"health ready endpoint", "/health/ready", "/health/ready"},
 		{"metrics endpoint", "/metrics", "/metrics"},
 		{"dashboard", "/dashboard", "/dashboard"},
 		{"create page", "/create", "/create"},
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"math"
 	"net"
 	"net/http"
@@ -9,6 +10,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
 	"golang.org/x/time/rate"
 )
 
@@ -30,32 +33,110 @@ type rateLimiter struct {
 // RateLimiterMiddleware is a per-client (IP-based) rate limiting middleware.
 // Call Shutdown when the owning server is shutting down to stop background cleanup.
 type RateLimiterMiddleware struct {
-	rl *rateLimiter
+	rl        rateLimitBackend
+	allowlist []*net.IPNet
+
+	redis          redis.UniversalClient
+	redisKeyPrefix string
+	logger         zerolog.Logger
+}
+
+// rateLimitBackend decides whether a client may make another request
+type rateLimitBackend interface {
+	take(ctx context.Context, key string) rateLimitDecision
+	shutdown()
+}
+
+// rateLimitDecision is the outcome of taking one request from a client's budget
+type rateLimitDecision struct {
+	allowed    bool
+	limit      int
+	remaining  int
+	reset      time.Duration // Until the budget is fully replenished
+	retryAfter time.Duration // Set only when the request is not allowed
+}
+
+// RateLimiterOption configures a RateLimiterMiddleware.
+type RateLimiterOption func(*RateLimiterMiddleware)
+
+// WithRateLimitAllowlist exempts clients whose IP falls within any of nets
+// from rate limiting. The IP matched is GetVerifiedClientIP, so only a proxy
+// verified address can skip the limit; a client-supplied header cannot.
+func WithRateLimitAllowlist(nets []*net.IPNet) RateLimiterOption {
+	return func(m *RateLimiterMiddleware) {
+		m.allowlist = nets
+	}
+}
+
+// WithRedisRateLimit keeps client budgets in Redis under keyPrefix, so every
+// instance sharing client enforces the same limit. If a Redis call fails the
+// request is limited in memory instead, and logger records the outage.
+func WithRedisRateLimit(client redis.UniversalClient, keyPrefix string, logger zerolog.Logger) RateLimiterOption {
+	return func(m *RateLimiterMiddleware) {
+		m.redis = client
+		m.redisKeyPrefix = keyPrefix
+		m.logger = logger
+	}
 }
 
 // NewRateLimiterMiddleware creates a per-client (IP-based) rate limiting middleware.
-func NewRateLimiterMiddleware(requestsPerMinute int, window time.Duration) *RateLimiterMiddleware {
-	return &RateLimiterMiddleware{rl: newRateLimiter(requestsPerMinute, window)}
+func NewRateLimiterMiddleware(requestsPerMinute int, window time.Duration, opts ...RateLimiterOption) *RateLimiterMiddleware {
+	m := &RateLimiterMiddleware{}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	memory := newRateLimiter(requestsPerMinute, window)
+	if m.redis != nil {
+		m.rl = newRedisRateLimiter(m.redis, m.redisKeyPrefix, window, memory, m.logger)
+	} else {
+		m.rl = memory
+	}
+	return m
 }
 
 // Middleware returns a chi-compatible middleware function.
 func (m *RateLimiterMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		limiter := m.rl.getLimiter(clientIP(r))
+		clientIP := GetClientIP(r)
+		if m.isAllowlisted(GetVerifiedClientIP(r)) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		decision := m.rl.take(r.Context(), clientIP)
+		setRateLimitHeaders(w.Header(), decision)
 
-		if limiter.Allow() {
+		if decision.allowed {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		retryAfter := m.rl.retryAfter(limiter)
 		// Retry-After should be in whole seconds
-		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(decision.retryAfter)))
 
 		respondRateLimitExceeded(w, r)
 	})
 }
 
+func (m *RateLimiterMiddleware) isAllowlisted(clientIP string) bool {
+	if len(m.allowlist) == 0 {
+		return false
+	}
+
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range m.allowlist {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // Shutdown stops the background cleanup goroutine for the rate limiter.
 func (m *RateLimiterMiddleware) Shutdown() {
 	m.rl.shutdown()
@@ -148,6 +229,40 @@ func (rl *rateLimiter) retryAfter(limiter *rate.Limiter) time.Duration {
 	return delay
 }
 
+// take spends one token from key's in-memory bucket
+func (rl *rateLimiter) take(_ context.Context, key string) rateLimitDecision {
+	limiter := rl.getLimiter(key)
+
+	now := time.Now()
+	decision := rateLimitDecision{
+		allowed: limiter.AllowN(now, 1),
+		limit:   rl.requestsPerMinute,
+	}
+
+	tokens := limiter.TokensAt(now)
+	decision.remaining = max(int(math.Floor(tokens)), 0)
+	if missing := float64(limiter.Burst()) - tokens; missing > 0 {
+		decision.reset = time.Duration(missing / float64(limiter.Limit()) * float64(time.Second))
+	}
+
+	if !decision.allowed {
+		decision.retryAfter = rl.retryAfter(limiter)
+	}
+	return decision
+}
+
+// setRateLimitHeaders reports the client's current budget. X-RateLimit-Reset is
+// the number of seconds until the bucket is fully replenished.
+func setRateLimitHeaders(h http.Header, decision rateLimitDecision) {
+	h.Set("X-RateLimit-Limit", strconv.Itoa(decision.limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(decision.remaining))
+	h.Set("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.reset)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
 func (rl *rateLimiter) maybeCleanup(now time.Time) {
 	// Avoid frequent cleanup on hot paths
 	if now.Sub(rl.lastCleanup) < rl.window {
@@ -180,28 +295,3 @@ func (rl *rateLimiter) shutdown() {
 		close(rl.stop)
 	})
 }
-
-func clientIP(r *http.Request) string {
-	// NOTE: X-Forwarded-For and X-Real-IP can be spoofed by clients.
-	// Only trust these headers if they are set/overwritten by a trusted reverse proxy.
-	xff := r.Header.Get("X-Forwarded-For")
-	if xff != "" {
-		parts := strings.Split(xff, ",")
-		for _, p := range parts {
-			if ip := strings.TrimSpace(p); ip != "" {
-				return ip
-			}
-		}
-	}
-
-	if xrip := r.Header.Get("X-Real-IP"); xrip != "" {
-		return strings.TrimSpace(xrip)
-	}
-
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err == nil {
-		return host
-	}
-
-	return r.RemoteAddr
-}
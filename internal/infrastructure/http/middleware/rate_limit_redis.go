@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// tokenBucketScript spends one token from the bucket at KEYS[1], refilling it
+// at ARGV[1] tokens per ARGV[2] milliseconds. It uses the Redis clock so every
+// instance agrees on elapsed time, and the key expires once the bucket would
+// be full again, which is the same as it not existing.
+//
+// Returns {allowed (0|1), tokens left as a string}.
+var tokenBucketScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * capacity / window)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], window)
+
+return {allowed, tostring(tokens)}
+`)
+
+// redisRateLimiter keeps token buckets in Redis so they are shared by every
+// instance. Requests fall back to the in-memory limiter while Redis is failing.
+type redisRateLimiter struct {
+	client            redis.UniversalClient
+	keyPrefix         string
+	requestsPerMinute int
+	window            time.Duration
+	fallback          *rateLimiter
+	logger            zerolog.Logger
+	degraded          atomic.Bool
+}
+
+func newRedisRateLimiter(client redis.UniversalClient, keyPrefix string, window time.Duration, fallback *rateLimiter, logger zerolog.Logger) *redisRateLimiter {
+	return &redisRateLimiter{
+		client:            client,
+		keyPrefix:         keyPrefix,
+		requestsPerMinute: fallback.requestsPerMinute,
+		window:            window,
+		fallback:          fallback,
+		logger:            logger,
+	}
+}
+
+// take spends one token from key's bucket in Redis
+func (rl *redisRateLimiter) take(ctx context.Context, key string) rateLimitDecision {
+	decision, err := rl.takeRedis(ctx, key)
+	if err != nil {
+		// A client that went away isn't a Redis outage
+		if ctx.Err() == nil && rl.degraded.CompareAndSwap(false, true) {
+			rl.logger.Warn().Err(err).Msg("redis rate limiting failed; falling back to in-memory limits")
+		}
+		return rl.fallback.take(ctx, key)
+	}
+
+	if rl.degraded.CompareAndSwap(true, false) {
+		rl.logger.Info().Msg("redis rate limiting recovered")
+	}
+	return decision
+}
+
+func (rl *redisRateLimiter) takeRedis(ctx context.Context, key string) (rateLimitDecision, error) {
+	res, err := tokenBucketScript.Run(ctx, rl.client, []string{rl.keyPrefix + key}, rl.requestsPerMinute, rl.window.Milliseconds()).Slice()
+	if err != nil {
+		return rateLimitDecision{}, err
+	}
+	if len(res) != 2 {
+		return rateLimitDecision{}, fmt.Errorf("unexpected rate limit script result: %v", res)
+	}
+
+	allowed, ok := res[0].(int64)
+	if !ok {
+		return rateLimitDecision{}, fmt.Errorf("unexpected rate limit script result: %v", res)
+	}
+	tokensStr, ok := res[1].(string)
+	if !ok {
+		return rateLimitDecision{}, fmt.Errorf("unexpected rate limit script result: %v", res)
+	}
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return rateLimitDecision{}, fmt.Errorf("unexpected rate limit script result: %w", err)
+	}
+
+	perToken := rl.window / time.Duration(rl.requestsPerMinute)
+	decision := rateLimitDecision{
+		allowed:   allowed == 1,
+		limit:     rl.requestsPerMinute,
+		remaining: max(int(math.Floor(tokens)), 0),
+		reset:     time.Duration((float64(rl.requestsPerMinute) - tokens) * float64(perToken)),
+	}
+	if !decision.allowed {
+		decision.retryAfter = max(time.Duration((1-tokens)*float64(perToken)), time.Second)
+	}
+	return decision, nil
+}
+
+// shutdown stops the fallback limiter's cleanup; the Redis client belongs to the caller
+func (rl *redisRateLimiter) shutdown() {
+	rl.fallback.shutdown()
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	mr := miniredis.RunT(t)
+	mr.SetTime(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return mr, client
+}
+
+func serveLimited(handler http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+	req.RemoteAddr = remoteAddr
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRedisRateLimit_SharedAcrossInstances(t *testing.T) {
+	_, client := newTestRedis(t)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Two "instances" of the same limiter behind a load balancer
+	first := NewRateLimiterMiddleware(3, time.Minute, WithRedisRateLimit(client, "test:", zerolog.Nop()))
+	defer first.Shutdown()
+	second := NewRateLimiterMiddleware(3, time.Minute, WithRedisRateLimit(client, "test:", zerolog.Nop()))
+	defer second.Shutdown()
+
+	instances := []http.Handler{first.Middleware(ok), second.Middleware(ok)}
+
+	for i, wantRemaining := range []string{"2", "1", "0"} {
+		rec := serveLimited(instances[i%2], "192.0.2.1:1234")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, http.StatusOK, rec.Code)
+		}
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "3" {
+			t.Errorf("request %d: expected X-RateLimit-Limit 3, got %q", i+1, got)
+		}
+		if got := rec.Header().Get("X-RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("request %d: expected X-RateLimit-Remaining %s, got %q", i+1, wantRemaining, got)
+		}
+	}
+
+	for i, handler := range instances {
+		rec := serveLimited(handler, "192.0.2.1:1234")
+		if rec.Code != http.StatusTooManyRequests {
+			t.Fatalf("instance %d: expected status %d once the shared budget is spent, got %d", i+1, http.StatusTooManyRequests, rec.Code)
+		}
+		// One token refills every 20s
+		if got := rec.Header().Get("Retry-After"); got != "20" {
+			t.Errorf("instance %d: expected Retry-After 20, got %q", i+1, got)
+		}
+		if got := rec.Header().Get("X-RateLimit-Reset"); got != "60" {
+			t.Errorf("instance %d: expected X-RateLimit-Reset 60, got %q", i+1, got)
+		}
+	}
+
+	// Budgets are per client
+	if rec := serveLimited(instances[0], "192.0.2.2:1234"); rec.Code != http.StatusOK {
+		t.Fatalf("expected another client to be allowed, got %d", rec.Code)
+	}
+}
+
+func TestRedisRateLimit_Refills(t *testing.T) {
+	mr, client := newTestRedis(t)
+
+	limiter := NewRateLimiterMiddleware(2, time.Minute, WithRedisRateLimit(client, "test:", zerolog.Nop()))
+	defer limiter.Shutdown()
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 2; i++ {
+		serveLimited(handler, "192.0.2.1:1234")
+	}
+	if rec := serveLimited(handler, "192.0.2.1:1234"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, rec.Code)
+	}
+
+	mr.SetTime(time.Date(2026, 1, 1, 12, 0, 30, 0, time.UTC))
+
+	rec := serveLimited(handler, "192.0.2.1:1234")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected a refilled token after 30s, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("expected X-RateLimit-Remaining 0, got %q", got)
+	}
+}
+
+func TestRedisRateLimit_KeysExpire(t *testing.T) {
+	mr, client := newTestRedis(t)
+
+	limiter := NewRateLimiterMiddleware(2, time.Minute, WithRedisRateLimit(client, "test:", zerolog.Nop()))
+	defer limiter.Shutdown()
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serveLimited(handler, "192.0.2.1:1234")
+
+	if !mr.Exists("test:192.0.2.1") {
+		t.Fatal("expected a bucket key for the client")
+	}
+	if ttl := mr.TTL("test:192.0.2.1"); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("expected bucket TTL within (0, 1m], got %v", ttl)
+	}
+}
+
+func TestRedisRateLimit_FallsBackToMemoryWhenRedisFails(t *testing.T) {
+	mr, client := newTestRedis(t)
+
+	limiter := NewRateLimiterMiddleware(1, time.Minute, WithRedisRateLimit(client, "test:", zerolog.Nop()))
+	defer limiter.Shutdown()
+
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	mr.Close()
+
+	if rec := serveLimited(handler, "192.0.2.1:1234"); rec.Code != http.StatusOK {
+		t.Fatalf("expected first request to be allowed by the fallback, got %d", rec.Code)
+	}
+	if rec := serveLimited(handler, "192.0.2.1:1234"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected the fallback to enforce the limit, got %d", rec.Code)
+	}
+}
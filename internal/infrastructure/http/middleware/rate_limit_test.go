@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -75,14 +76,89 @@ func TestRateLimit_BlocksWhenExceeded(t *testing.T) {
 	}
 }
 
-func TestRateLimit_UsesForwardedFor(t *testing.T) {
-	ratelimiter := NewRateLimiterMiddleware(1, time.Minute)
+func TestRateLimit_SetsRateLimitHeaders(t *testing.T) {
+	ratelimiter := NewRateLimiterMiddleware(3, time.Minute)
+	defer ratelimiter.Shutdown()
+
+	handler := ratelimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/budget", nil)
+	req.RemoteAddr = "192.0.2.20:6060"
+
+	for i, wantRemaining := range []string{"2", "1", "0"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, http.StatusOK, rec.Code)
+		}
+		if got := rec.Header().Get("X-RateLimit-Limit"); got != "3" {
+			t.Errorf("request %d: expected X-RateLimit-Limit 3, got %q", i+1, got)
+		}
+		if got := rec.Header().Get("X-RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("request %d: expected X-RateLimit-Remaining %s, got %q", i+1, wantRemaining, got)
+		}
+		reset, err := strconv.Atoi(rec.Header().Get("X-RateLimit-Reset"))
+		if err != nil || reset < 1 || reset > 60 {
+			t.Errorf("request %d: expected X-RateLimit-Reset within (0, 60], got %q", i+1, rec.Header().Get("X-RateLimit-Reset"))
+		}
+		if rec.Header().Get("Retry-After") != "" {
+			t.Errorf("request %d: expected no Retry-After on allowed response", i+1)
+		}
+	}
+
+	blocked := httptest.NewRecorder()
+	handler.ServeHTTP(blocked, req)
+
+	if blocked.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, blocked.Code)
+	}
+	if got := blocked.Header().Get("X-RateLimit-Limit"); got != "3" {
+		t.Errorf("expected X-RateLimit-Limit 3 on blocked response, got %q", got)
+	}
+	if got := blocked.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("expected X-RateLimit-Remaining 0 on blocked response, got %q", got)
+	}
+	if got := blocked.Header().Get("X-RateLimit-Reset"); got == "" {
+		t.Error("expected X-RateLimit-Reset on blocked response")
+	}
+	if got := blocked.Header().Get("Retry-After"); got == "" {
+		t.Error("expected Retry-After on blocked response")
+	}
+}
+
+func TestRateLimit_HeadersArePerClient(t *testing.T) {
+	ratelimiter := NewRateLimiterMiddleware(2, time.Minute)
 	defer ratelimiter.Shutdown()
 
 	handler := ratelimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
+	first := httptest.NewRequest(http.MethodGet, "/budget", nil)
+	first.RemoteAddr = "192.0.2.30:1111"
+	handler.ServeHTTP(httptest.NewRecorder(), first)
+
+	second := httptest.NewRequest(http.MethodGet, "/budget", nil)
+	second.RemoteAddr = "192.0.2.31:2222"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, second)
+
+	if got := rec.Header().Get("X-RateLimit-Remaining"); got != "1" {
+		t.Fatalf("expected independent budget for second client (remaining 1), got %q", got)
+	}
+}
+
+func TestRateLimit_UsesForwardedFor(t *testing.T) {
+	ratelimiter := NewRateLimiterMiddleware(1, time.Minute)
+	defer ratelimiter.Shutdown()
+
+	handler := ClientIP(true, nil)(ratelimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
 	req1 := httptest.NewRequest(http.MethodGet, "/xff", nil)
 	req1.RemoteAddr = "192.0.2.100:1234"
 	req1.Header.Set("X-Forwarded-For", "203.0.113.1")
@@ -104,14 +180,162 @@ func TestRateLimit_UsesForwardedFor(t *testing.T) {
 	}
 }
 
-func TestClientIP_ForwardedForSkipsEmptySegments(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/", nil)
-	req.RemoteAddr = "192.0.2.50:1234"
-	req.Header.Set("X-Forwarded-For", " , 203.0.113.9")
+func TestRateLimit_IgnoresSpoofedForwardedForWhenUntrusted(t *testing.T) {
+	ratelimiter := NewRateLimiterMiddleware(1, time.Minute)
+	defer ratelimiter.Shutdown()
+
+	handler := ClientIP(false, nil)(ratelimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	req1 := httptest.NewRequest(http.MethodGet, "/xff", nil)
+	req1.RemoteAddr = "192.0.2.100:1234"
+	req1.Header.Set("X-Forwarded-For", "203.0.113.1")
+
+	req2 := httptest.NewRequest(http.MethodGet, "/xff", nil)
+	req2.RemoteAddr = "192.0.2.100:5678"
+	req2.Header.Set("X-Forwarded-For", "203.0.113.2")
+
+	rec1 := httptest.NewRecorder()
+	handler.ServeHTTP(rec1, req1)
+	if rec1.Code != http.StatusOK {
+		t.Fatalf("expected status %d for first request, got %d", http.StatusOK, rec1.Code)
+	}
+
+	rec2 := httptest.NewRecorder()
+	handler.ServeHTTP(rec2, req2)
+	if rec2.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected spoofed X-Forwarded-For to share the RemoteAddr limit, got %d", rec2.Code)
+	}
+}
+
+func mustParseCIDRs(t *testing.T, cidrs ...string) []*net.IPNet {
+	t.Helper()
+
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			t.Fatalf("invalid CIDR %q: %v", c, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+func TestRateLimit_Allowlist(t *testing.T) {
+	ratelimiter := NewRateLimiterMiddleware(1, time.Minute,
+		WithRateLimitAllowlist(mustParseCIDRs(t, "10.0.0.0/8", "2001:db8::/32")))
+	defer ratelimiter.Shutdown()
+
+	handler := ratelimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		wantSecond int
+	}{
+		{name: "allowlisted IPv4 bypasses limit", remoteAddr: "10.1.2.3:1234", wantSecond: http.StatusOK},
+		{name: "allowlisted IPv6 bypasses limit", remoteAddr: "[2001:db8::1]:1234", wantSecond: http.StatusOK},
+		{name: "non-allowlisted client is throttled", remoteAddr: "192.0.2.50:1234", wantSecond: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/allowlist", nil)
+			req.RemoteAddr = tt.remoteAddr
+
+			first := httptest.NewRecorder()
+			handler.ServeHTTP(first, req)
+			if first.Code != http.StatusOK {
+				t.Fatalf("expected first request to succeed, got %d", first.Code)
+			}
+
+			second := httptest.NewRecorder()
+			handler.ServeHTTP(second, req)
+			if second.Code != tt.wantSecond {
+				t.Fatalf("expected status %d, got %d", tt.wantSecond, second.Code)
+			}
+
+			if tt.wantSecond == http.StatusOK && second.Header().Get("X-RateLimit-Limit") != "" {
+				t.Error("expected no rate-limit headers for allowlisted client")
+			}
+		})
+	}
+}
+
+func TestRateLimit_AllowlistUsesResolvedClientIP(t *testing.T) {
+	ratelimiter := NewRateLimiterMiddleware(1, time.Minute,
+		WithRateLimitAllowlist(mustParseCIDRs(t, "10.0.0.0/8")))
+	defer ratelimiter.Shutdown()
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		trust      bool
+		wantSecond int
+	}{
+		{name: "trusted forwarded-for is allowlisted", trust: true, wantSecond: http.StatusOK},
+		{name: "untrusted forwarded-for is ignored", trust: false, wantSecond: http.StatusTooManyRequests},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := ClientIP(tt.trust, nil)(ratelimiter.Middleware(next))
+
+			req := httptest.NewRequest(http.MethodGet, "/allowlist", nil)
+			req.RemoteAddr = "192.0.2." + strconv.Itoa(60+i) + ":1234"
+			req.Header.Set("X-Forwarded-For", "10.9.8.7")
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			second := httptest.NewRecorder()
+			handler.ServeHTTP(second, req)
+			if second.Code != tt.wantSecond {
+				t.Fatalf("expected status %d, got %d", tt.wantSecond, second.Code)
+			}
+		})
+	}
+}
+
+func TestRateLimit_AllowlistIgnoresSpoofedHeaders(t *testing.T) {
+	ratelimiter := NewRateLimiterMiddleware(1, time.Minute,
+		WithRateLimitAllowlist(mustParseCIDRs(t, "10.0.0.0/8")))
+	defer ratelimiter.Shutdown()
+
+	handler := ClientIP(true, mustParseCIDRs(t, "192.0.2.0/24"))(ratelimiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	tests := []struct {
+		name   string
+		header string
+		value  string
+	}{
+		// The proxy at 192.0.2.70 appended the real client, 203.0.113.5, after the spoofed entry
+		{"spoofed X-Forwarded-For", "X-Forwarded-For", "10.9.8.7, 203.0.113.5"},
+		{"spoofed X-Real-IP", "X-Real-IP", "10.9.8.7"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/allowlist", nil)
+			req.RemoteAddr = "192.0.2." + strconv.Itoa(70+i) + ":1234"
+			req.Header.Set(tt.header, tt.value)
 
-	ip := clientIP(req)
-	if ip != "203.0.113.9" {
-		t.Fatalf("expected IP %q, got %q", "203.0.113.9", ip)
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			second := httptest.NewRecorder()
+			handler.ServeHTTP(second, req)
+			if second.Code != http.StatusTooManyRequests {
+				t.Fatalf("expected a spoofed allowlisted address to stay limited, got %d", second.Code)
+			}
+		})
 	}
 }
 
@@ -136,27 +360,6 @@ func TestRateLimiter_Cleanup_RemovesStaleVisitors(t *testing.T) {
 	}
 }
 
-func TestClientIP_UsesRealIP(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/", nil)
-	req.RemoteAddr = "192.0.2.50:1234"
-	req.Header.Set("X-Real-IP", "203.0.113.55")
-
-	ip := clientIP(req)
-	if ip != "203.0.113.55" {
-		t.Fatalf("expected IP %q, got %q", "203.0.113.55", ip)
-	}
-}
-
-func TestClientIP_RemoteAddrWithoutPort(t *testing.T) {
-	req := httptest.NewRequest(http.MethodGet, "/", nil)
-	req.RemoteAddr = "192.0.2.77"
-
-	ip := clientIP(req)
-	if ip != "192.0.2.77" {
-		t.Fatalf("expected IP %q, got %q", "192.0.2.77", ip)
-	}
-}
-
 func TestRateLimiter_ConcurrentAccess(t *testing.T) {
 	ratelimiter := NewRateLimiterMiddleware(1000, time.Minute)
 	defer ratelimiter.Shutdown()
@@ -104,9 +104,9 @@ func RecoveryWithLogger(logger zerolog.Logger) func(http.Handler) http.Handler {
 	return RecoveryWithNotifier(logger, nil)
 }
 
-// RecoveryWithNotifier returns a recovery middleware that uses the provided logger and Discord notifier.
-// This ensures panics are logged and optionally sent to Discord for critical errors.
-func RecoveryWithNotifier(logger zerolog.Logger, notifier *notification.DiscordNotifier) func(http.Handler) http.Handler {
+// RecoveryWithNotifier returns a recovery middleware that uses the provided logger and notifier.
+// This ensures panics are logged and optionally sent to chat (Discord, Slack) for critical errors.
+func RecoveryWithNotifier(logger zerolog.Logger, notifier notification.Notifier) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			rw := &recoveryWriter{ResponseWriter: w}
@@ -137,8 +137,8 @@ func RecoveryWithNotifier(logger zerolog.Logger, notifier *notification.DiscordN
 					}
 					logEvent.Msg("panic recovered")
 
-					// Send notification to Discord if notifier is configured
-					if notifier != nil && notifier.IsEnabled() {
+					// Send notification if a notifier is configured
+					if notification.Enabled(notifier) {
 						userID, _ := GetUserID(r.Context())
 						errCtx := notification.ErrorContext{
 							ErrorMessage: errorMsg,
@@ -149,7 +149,7 @@ func RecoveryWithNotifier(logger zerolog.Logger, notifier *notification.DiscordN
 							UserID:       userID,
 							Timestamp:    time.Now(),
 						}
-						notifier.NotifyError(r.Context(), errCtx)
+						notifier.Send(r.Context(), notification.ErrorMessage(errCtx))
 					}
 
 					if !rw.wroteHeader {
@@ -295,6 +295,60 @@ func TestRecoveryWithNotifier_WithUserID(t *testing.T) {
 	}
 }
 
+// recordingNotifier captures messages sent through the notification.Notifier interface
+type recordingNotifier struct {
+	messages []notification.Message
+}
+
+func (n *recordingNotifier) Send(ctx context.Context, msg notification.Message) {
+	n.messages = append(n.messages, msg)
+}
+
+func TestRecoveryWithNotifier_UsesNotifierInterface(t *testing.T) {
+	resetStackTracesEnabledCache()
+
+	notifier := &recordingNotifier{}
+	handler := RecoveryWithNotifier(zerolog.Nop(), notifier)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("interface panic")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	req = req.WithContext(context.WithValue(req.Context(), UserIDKey, "user-1"))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	if len(notifier.messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(notifier.messages))
+	}
+
+	fields := map[string]string{}
+	for _, f := range notifier.messages[0].Fields {
+		fields[f.Name] = f.Value
+	}
+	if fields["Error Message"] != "interface panic" || fields["Request"] != "GET /boom" || fields["User ID"] != "user-1" {
+		t.Errorf("unexpected message fields: %v", fields)
+	}
+}
+
+func TestRecoveryWithNotifier_NopNotifier(t *testing.T) {
+	resetStackTracesEnabledCache()
+
+	handler := RecoveryWithNotifier(zerolog.Nop(), notification.Nop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("ignored panic")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
 func TestRecoveryWithNotifier_NoNotifierConfigured(t *testing.T) {
 	resetStackTracesEnabledCache()
 
@@ -8,12 +8,17 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// maxRequestIDLength bounds incoming X-Request-ID values kept for logs and responses
+const maxRequestIDLength = 128
+
 // RequestID is a middleware that injects a request ID into the request context.
 // If a X-Request-ID header is present, it uses that value; otherwise, it generates a new UUID.
+// Incoming IDs that are too long or contain anything but visible ASCII are replaced
+// with a new UUID, so clients can't smuggle arbitrary text into logs.
 func RequestID(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestID := r.Header.Get("X-Request-ID")
-		if requestID == "" {
+		if !validRequestID(requestID) {
 			requestID = uuid.New().String()
 		}
 
@@ -27,6 +32,19 @@ func RequestID(next http.Handler) http.Handler {
 	})
 }
 
+// validRequestID reports whether an incoming request ID is safe to propagate
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
 // InjectLogger is a middleware that injects a logger with request-specific fields into the context.
 func InjectLogger(logger zerolog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/logging"
@@ -33,6 +34,56 @@ func TestRequestID_GeneratesNewID(t *testing.T) {
 	}
 }
 
+func TestRequestID_EchoesGeneratedID(t *testing.T) {
+	var contextID string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contextID = logging.GetRequestID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Request-ID"); got == "" || got != contextID {
+		t.Errorf("expected X-Request-ID header to echo the generated ID %q, got %q", contextID, got)
+	}
+}
+
+func TestRequestID_ReplacesInvalidHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{name: "too long", id: strings.Repeat("a", maxRequestIDLength+1)},
+		{name: "contains spaces", id: "my request id"},
+		{name: "contains control characters", id: "id\x1b[31m"},
+		{name: "non-ascii", id: "id-é"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contextID string
+			handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contextID = logging.GetRequestID(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("X-Request-ID", tt.id)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if contextID == tt.id || len(contextID) != 36 {
+				t.Errorf("expected a generated UUID, got %q", contextID)
+			}
+			if got := rec.Header().Get("X-Request-ID"); got != contextID {
+				t.Errorf("expected X-Request-ID header %q, got %q", contextID, got)
+			}
+		})
+	}
+}
+
 func TestRequestID_UsesExistingHeader(t *testing.T) {
 	existingID := "my-custom-request-id"
 	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
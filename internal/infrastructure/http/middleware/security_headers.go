@@ -1,60 +1,124 @@
 package middleware
 
-import "net/http"
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// DefaultHSTSMaxAge is the Strict-Transport-Security max-age in seconds (one year)
+const DefaultHSTSMaxAge = 31536000
+
+// X-Frame-Options values
+const (
+	FrameOptionsDeny       = "DENY"
+	FrameOptionsSameOrigin = "SAMEORIGIN"
+)
+
+// SecurityHeadersConfig configures the SecurityHeaders middleware
+type SecurityHeadersConfig struct {
+	// EnableHSTS sends Strict-Transport-Security on HTTPS requests.
+	// Only enable this when the application is behind TLS/HTTPS.
+	EnableHSTS bool
+	// HSTSMaxAge is the HSTS max-age in seconds (DefaultHSTSMaxAge when 0 and HSTS is enabled)
+	HSTSMaxAge int
+	// TrustProxyHeaders treats requests with X-Forwarded-Proto: https as HTTPS,
+	// for TLS terminated by a reverse proxy
+	TrustProxyHeaders bool
+	// ContentSecurityPolicy replaces the baseline policy when set
+	ContentSecurityPolicy string
+	// FrameOptions is the X-Frame-Options value: FrameOptionsDeny (default) or FrameOptionsSameOrigin
+	FrameOptions string
+}
 
 // SecurityHeaders returns a middleware that sets security-related HTTP headers
 // to protect against common web vulnerabilities.
 //
 // Headers set:
 //   - X-Content-Type-Options: nosniff (prevents MIME sniffing)
-//   - X-Frame-Options: DENY (prevents clickjacking)
+//   - X-Frame-Options: DENY or SAMEORIGIN (prevents clickjacking)
 //   - Referrer-Policy: strict-origin-when-cross-origin (controls referrer info)
-//   - Content-Security-Policy: baseline policy for templ-based pages
-//   - Strict-Transport-Security: HSTS (only if enableHSTS is true)
+//   - Content-Security-Policy: configured policy, or a baseline policy for templ-based pages
+//   - Strict-Transport-Security: HSTS (only if enabled, and only on HTTPS requests)
 //
-// The CSP policy allows:
+// The baseline CSP policy allows:
 //   - Scripts from self, Tailwind CDN, and unpkg.com (unsafe-inline for templ inline scripts)
 //   - Styles from self (unsafe-inline for templ inline styles)
 //   - Images from self and data URIs
-//   - Frame ancestors: none (same as X-Frame-Options: DENY)
+//   - Frame ancestors: matching X-Frame-Options ('none' for DENY, 'self' for SAMEORIGIN)
 //
-// Parameters:
-//   - enableHSTS: Set to true to enable Strict-Transport-Security header.
-//     Only enable this when the application is behind TLS/HTTPS.
-func SecurityHeaders(enableHSTS bool) func(http.Handler) http.Handler {
+// Browsers ignore HSTS on plain HTTP, so it is only sent when the request
+// arrived over TLS or, with TrustProxyHeaders, a proxy reports it did.
+func SecurityHeaders(cfg SecurityHeadersConfig) func(http.Handler) http.Handler {
+	frameOptions := cfg.FrameOptions
+	if frameOptions == "" {
+		frameOptions = FrameOptionsDeny
+	}
+
+	csp := cfg.ContentSecurityPolicy
+	if csp == "" {
+		csp = defaultContentSecurityPolicy(frameOptions)
+	}
+
+	// Note: The 'preload' directive is intentionally omitted.
+	// HSTS preload is difficult to undo, so add it at the proxy if you need it.
+	maxAge := cfg.HSTSMaxAge
+	if maxAge == 0 {
+		maxAge = DefaultHSTSMaxAge
+	}
+	hsts := "max-age=" + strconv.Itoa(maxAge) + "; includeSubDomains"
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Prevent MIME type sniffing
 			w.Header().Set("X-Content-Type-Options", "nosniff")
 
 			// Prevent clickjacking
-			w.Header().Set("X-Frame-Options", "DENY")
+			w.Header().Set("X-Frame-Options", frameOptions)
 
 			// Control referrer information
 			w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
 
 			// Content Security Policy
-			// Note: 'unsafe-inline' is required for templ-generated inline scripts/styles.
-			// The Tailwind CDN and unpkg.com (for HTMX) are explicitly allowed via their URLs.
-			w.Header().Set("Content-Security-Policy",
-				"default-src 'self'; "+
-					"script-src 'self' 'unsafe-inline' https://cdn.tailwindcss.com https://unpkg.com; "+
-					"style-src 'self' 'unsafe-inline'; "+
-					"img-src 'self' data:; "+
-					"font-src 'self'; "+
-					"connect-src 'self'; "+
-					"frame-ancestors 'none'")
+			w.Header().Set("Content-Security-Policy", csp)
 
 			// HSTS (only when behind TLS - configurable via environment)
-			// Note: The 'preload' directive is intentionally omitted by default.
-			// To enable HSTS preload, add '; preload' after verifying HTTPS works
-			// across all subdomains and understanding it's difficult to undo.
-			if enableHSTS {
-				w.Header().Set("Strict-Transport-Security",
-					"max-age=31536000; includeSubDomains")
+			if cfg.EnableHSTS && isHTTPS(r, cfg.TrustProxyHeaders) {
+				w.Header().Set("Strict-Transport-Security", hsts)
 			}
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// defaultContentSecurityPolicy returns the baseline policy for templ-based pages.
+// Note: 'unsafe-inline' is required for templ-generated inline scripts/styles.
+// The Tailwind CDN and unpkg.com (for HTMX) are explicitly allowed via their URLs.
+func defaultContentSecurityPolicy(frameOptions string) string {
+	frameAncestors := "'none'"
+	if frameOptions == FrameOptionsSameOrigin {
+		frameAncestors = "'self'"
+	}
+
+	return "default-src 'self'; " +
+		"script-src 'self' 'unsafe-inline' https://cdn.tailwindcss.com https://unpkg.com; " +
+		"style-src 'self' 'unsafe-inline'; " +
+		"img-src 'self' data:; " +
+		"font-src 'self'; " +
+		"connect-src 'self'; " +
+		"frame-ancestors " + frameAncestors
+}
+
+// isHTTPS reports whether r reached the client over HTTPS
+func isHTTPS(r *http.Request, trustProxyHeaders bool) bool {
+	if r.TLS != nil {
+		return true
+	}
+	if !trustProxyHeaders {
+		return false
+	}
+	// The first proxy hop is the one the client connected to
+	proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+	return strings.EqualFold(strings.TrimSpace(proto), "https")
+}
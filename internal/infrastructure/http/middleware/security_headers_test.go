@@ -3,11 +3,12 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
 func TestSecurityHeaders_AllHeadersSet(t *testing.T) {
-	handler := SecurityHeaders(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := SecurityHeaders(SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -32,11 +33,12 @@ func TestSecurityHeaders_AllHeadersSet(t *testing.T) {
 }
 
 func TestSecurityHeaders_HSTS_Enabled(t *testing.T) {
-	handler := SecurityHeaders(true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := SecurityHeaders(SecurityHeadersConfig{EnableHSTS: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
-	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	// An https target gives the request TLS state
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/test", nil)
 	rec := httptest.NewRecorder()
 
 	handler.ServeHTTP(rec, req)
@@ -53,7 +55,7 @@ func TestSecurityHeaders_HSTS_Enabled(t *testing.T) {
 }
 
 func TestSecurityHeaders_HSTS_Disabled(t *testing.T) {
-	handler := SecurityHeaders(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := SecurityHeaders(SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -68,8 +70,87 @@ func TestSecurityHeaders_HSTS_Disabled(t *testing.T) {
 	}
 }
 
+func TestSecurityHeaders_HSTS_OnlyOverHTTPS(t *testing.T) {
+	tests := []struct {
+		name       string
+		trustProxy bool
+		target     string
+		proto      string
+		wantHSTS   bool
+	}{
+		{name: "plain http", target: "http://example.com/test"},
+		{name: "forwarded https without trusting proxy", target: "http://example.com/test", proto: "https"},
+		{name: "forwarded https from trusted proxy", trustProxy: true, target: "http://example.com/test", proto: "https", wantHSTS: true},
+		{name: "forwarded http from trusted proxy", trustProxy: true, target: "http://example.com/test", proto: "http"},
+		{name: "first forwarded hop wins", trustProxy: true, target: "http://example.com/test", proto: "HTTPS, http", wantHSTS: true},
+		{name: "tls", target: "https://example.com/test", wantHSTS: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := SecurityHeaders(SecurityHeadersConfig{EnableHSTS: true, TrustProxyHeaders: tt.trustProxy})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.proto != "" {
+				req.Header.Set("X-Forwarded-Proto", tt.proto)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("Strict-Transport-Security") != ""; got != tt.wantHSTS {
+				t.Errorf("expected HSTS sent = %v, got %v", tt.wantHSTS, got)
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders_Configured(t *testing.T) {
+	handler := SecurityHeaders(SecurityHeadersConfig{
+		EnableHSTS:            true,
+		HSTSMaxAge:            600,
+		ContentSecurityPolicy: "default-src 'none'",
+		FrameOptions:          FrameOptionsSameOrigin,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	want := map[string]string{
+		"Strict-Transport-Security": "max-age=600; includeSubDomains",
+		"Content-Security-Policy":   "default-src 'none'",
+		"X-Frame-Options":           "SAMEORIGIN",
+	}
+	for header, value := range want {
+		if got := rec.Header().Get(header); got != value {
+			t.Errorf("expected %s %q, got %q", header, value, got)
+		}
+	}
+}
+
+func TestSecurityHeaders_SameOriginDefaultCSP(t *testing.T) {
+	handler := SecurityHeaders(SecurityHeadersConfig{FrameOptions: FrameOptionsSameOrigin})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if csp := rec.Header().Get("Content-Security-Policy"); !strings.HasSuffix(csp, "frame-ancestors 'self'") {
+		t.Errorf("expected the baseline CSP to allow same-origin framing, got %q", csp)
+	}
+}
+
 func TestSecurityHeaders_ValuesCorrect(t *testing.T) {
-	handler := SecurityHeaders(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := SecurityHeaders(SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -132,7 +213,7 @@ func TestSecurityHeaders_AppliedToAllMethods(t *testing.T) {
 
 	for _, method := range methods {
 		t.Run(method, func(t *testing.T) {
-			handler := SecurityHeaders(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler := SecurityHeaders(SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
 			}))
 
@@ -151,7 +232,7 @@ func TestSecurityHeaders_AppliedToAllMethods(t *testing.T) {
 
 func TestSecurityHeaders_NextHandlerCalled(t *testing.T) {
 	handlerCalled := false
-	handler := SecurityHeaders(false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := SecurityHeaders(SecurityHeadersConfig{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		handlerCalled = true
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/session"
 )
@@ -15,8 +16,9 @@ const (
 	SessionUserIDKey contextKey = "sessionUserID"
 )
 
-// SessionMiddleware creates a middleware that manages user sessions
-func SessionMiddleware(store *session.Store) func(http.Handler) http.Handler {
+// SessionMiddleware creates a middleware that manages user sessions.
+// Sessions idle for longer than idleTimeout are revoked; a non-positive idleTimeout disables the check.
+func SessionMiddleware(store *session.Store, idleTimeout time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			// Try to get session cookie
@@ -24,6 +26,11 @@ func SessionMiddleware(store *session.Store) func(http.Handler) http.Handler {
 			if err == nil && cookie.Value != "" {
 				// Validate session
 				sess, exists := store.Get(cookie.Value)
+				if exists && sess.IsIdle(time.Now(), idleTimeout) {
+					// Idle too long: revoke rather than refresh it
+					store.Delete(cookie.Value)
+					exists = false
+				}
 				if exists {
 					// Refresh session on each request
 					store.Refresh(cookie.Value)
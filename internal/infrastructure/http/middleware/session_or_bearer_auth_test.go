@@ -76,3 +76,28 @@ func TestSessionOrBearerAuth_UnauthorizedWhenNoSessionOrBearer(t *testing.T) {
 		t.Fatalf("expected status 401, got %d", w.Code)
 	}
 }
+
+func TestSessionOrBearerAuth_OnlyBearerIsAdmin(t *testing.T) {
+	mw := SessionOrBearerAuth([]string{"test-token"})
+
+	var admin bool
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		admin = IsAdminToken(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if !admin {
+		t.Fatalf("expected bearer token request to be admin")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(context.WithValue(req.Context(), SessionUserIDKey, "session-user"))
+	req.Header.Set("Authorization", "Bearer test-token")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if admin {
+		t.Fatalf("expected session request not to be admin")
+	}
+}
@@ -11,7 +11,6 @@ import (
 
 func TestSessionMiddleware_ValidSession(t *testing.T) {
 	store := session.NewStore(24 * time.Hour)
-	defer store.Shutdown()
 
 	// Create a session
 	sess, err := store.Create("test-user")
@@ -20,7 +19,7 @@ func TestSessionMiddleware_ValidSession(t *testing.T) {
 	}
 
 	// Create middleware
-	middleware := SessionMiddleware(store)
+	middleware := SessionMiddleware(store, 0)
 
 	// Create test handler that checks for user ID in context
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -51,10 +50,9 @@ func TestSessionMiddleware_ValidSession(t *testing.T) {
 
 func TestSessionMiddleware_NoSession(t *testing.T) {
 	store := session.NewStore(24 * time.Hour)
-	defer store.Shutdown()
 
 	// Create middleware
-	middleware := SessionMiddleware(store)
+	middleware := SessionMiddleware(store, 0)
 
 	// Create test handler that checks for user ID in context
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -78,7 +76,6 @@ func TestSessionMiddleware_NoSession(t *testing.T) {
 
 func TestSessionMiddleware_ExpiredSession(t *testing.T) {
 	store := session.NewStore(1 * time.Millisecond)
-	defer store.Shutdown()
 
 	// Create a session
 	sess, err := store.Create("test-user")
@@ -90,7 +87,7 @@ func TestSessionMiddleware_ExpiredSession(t *testing.T) {
 	time.Sleep(10 * time.Millisecond)
 
 	// Create middleware
-	middleware := SessionMiddleware(store)
+	middleware := SessionMiddleware(store, 0)
 
 	// Create test handler that checks for user ID in context
 	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -116,9 +113,43 @@ func TestSessionMiddleware_ExpiredSession(t *testing.T) {
 	}
 }
 
+func TestSessionMiddleware_IdleSessionRevoked(t *testing.T) {
+	store := session.NewStore(24 * time.Hour)
+
+	// Create a session
+	sess, err := store.Create("test-user")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Let the session go idle for longer than the configured idle timeout
+	time.Sleep(20 * time.Millisecond)
+
+	middleware := SessionMiddleware(store, 5*time.Millisecond)
+
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetSessionUserID(r.Context()); ok {
+			t.Error("expected no user ID in context for idle session")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.AddCookie(&http.Cookie{
+		Name:  SessionCookieName,
+		Value: sess.ID,
+	})
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if _, exists := store.Get(sess.ID); exists {
+		t.Error("expected idle session to be revoked")
+	}
+}
+
 func TestRequireSession_ValidSession(t *testing.T) {
 	store := session.NewStore(24 * time.Hour)
-	defer store.Shutdown()
 
 	// Create a session
 	sess, err := store.Create("test-user")
@@ -127,7 +158,7 @@ func TestRequireSession_ValidSession(t *testing.T) {
 	}
 
 	// Create middleware chain
-	sessionMiddleware := SessionMiddleware(store)
+	sessionMiddleware := SessionMiddleware(store, 0)
 	requireMiddleware := RequireSession(store, "/login")
 
 	// Create test handler
@@ -157,10 +188,9 @@ func TestRequireSession_ValidSession(t *testing.T) {
 
 func TestRequireSession_NoSession(t *testing.T) {
 	store := session.NewStore(24 * time.Hour)
-	defer store.Shutdown()
 
 	// Create middleware chain
-	sessionMiddleware := SessionMiddleware(store)
+	sessionMiddleware := SessionMiddleware(store, 0)
 	requireMiddleware := RequireSession(store, "/login")
 
 	// Create test handler
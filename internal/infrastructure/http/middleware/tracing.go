@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing returns a middleware that records a server span for each request,
+// continuing any W3C trace context sent by the caller. The span is named after
+// the matched chi route and carries the short code route parameter, if any;
+// query strings and destination URLs are never recorded.
+func Tracing(tracer trace.Tracer) func(http.Handler) http.Handler {
+	propagator := propagation.TraceContext{}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attribute.String("http.request.method", r.Method)),
+			)
+			defer span.End()
+
+			// Reuse the metrics wrapper to capture the status code
+			wrapped := &metricsResponseWriter{
+				ResponseWriter: w,
+				status:         http.StatusOK,
+			}
+
+			next.ServeHTTP(wrapped, r.WithContext(ctx))
+
+			// chi fills in the route context while routing, so the pattern
+			// and parameters are only known once the handler has run
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				if pattern := rctx.RoutePattern(); pattern != "" {
+					span.SetName(r.Method + " " + pattern)
+					span.SetAttributes(attribute.String("http.route", pattern))
+				}
+				if shortCode := rctx.URLParam("shortCode"); shortCode != "" {
+					span.SetAttributes(tracing.ShortCode(shortCode))
+				}
+			}
+
+			span.SetAttributes(attribute.Int("http.response.status_code", wrapped.status))
+			if wrapped.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(wrapped.status))
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracing_RecordsRouteAndShortCode(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	r := chi.NewRouter()
+	r.Use(Tracing(provider.Tracer(tracing.TracerName)))
+	r.Get("/{shortCode}", func(w http.ResponseWriter, r *http.Request) {
+		if !trace.SpanFromContext(r.Context()).SpanContext().IsValid() {
+			t.Error("expected the handler context to carry the request span")
+		}
+		w.WriteHeader(http.StatusFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/abc123?utm_source=secret", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /{shortCode}" {
+		t.Errorf("span name = %q, want %q", span.Name(), "GET /{shortCode}")
+	}
+	if span.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span kind = %v, want server", span.SpanKind())
+	}
+
+	attrs := spanAttributes(span)
+	if got := attrs[tracing.ShortCodeKey].AsString(); got != "abc123" {
+		t.Errorf("short code attribute = %q, want abc123", got)
+	}
+	if got := attrs["http.route"].AsString(); got != "/{shortCode}" {
+		t.Errorf("http.route = %q, want /{shortCode}", got)
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != http.StatusFound {
+		t.Errorf("status code attribute = %d, want %d", got, http.StatusFound)
+	}
+	for key, value := range attrs {
+		if value.Type() == attribute.STRING && value.AsString() == req.URL.RawQuery {
+			t.Errorf("attribute %s leaks the query string", key)
+		}
+	}
+}
+
+func TestTracing_ServerErrorSetsStatus(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	handler := Tracing(provider.Tracer(tracing.TracerName))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", spans[0].Status().Code)
+	}
+	// Without a chi route the span keeps the method as its name
+	if spans[0].Name() != http.MethodGet {
+		t.Errorf("span name = %q, want %q", spans[0].Name(), http.MethodGet)
+	}
+}
+
+func TestTracing_ContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	handler := Tracing(provider.Tracer(tracing.TracerName))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if got := spans[0].SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace id = %s, want the caller's trace", got)
+	}
+	if got := spans[0].Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span id = %s, want the caller's span", got)
+	}
+}
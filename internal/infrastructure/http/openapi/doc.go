@@ -0,0 +1,3 @@
+// Package openapi validates API requests against the OpenAPI specification
+// embedded from openapi.yaml, for servers run with VALIDATE_OPENAPI=true.
+package openapi
@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// ErrorCode is the ErrorResponse code for requests rejected by the validator
+const ErrorCode = "invalid_request"
+
+// ValidationErrorResponse is the body of a 400 from the validator
+type ValidationErrorResponse struct {
+	Error  string       `json:"error"`
+	Code   string       `json:"code"`
+	Fields []FieldError `json:"fields"`
+}
+
+// Middleware returns a middleware that validates requests against spec
+// before they reach a handler.
+//
+// Path, query and header parameters and JSON request bodies are checked
+// against the matching operation; any mismatch is answered with 400 and a
+// list of field errors. Requests for routes the spec doesn't describe, and
+// bodies that aren't valid JSON at all, are passed through so the handler
+// reports them as it always has.
+func Middleware(spec *Spec) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			op, pathParams := spec.match(r.Method, r.URL.Path)
+			if op == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			errs := validateParameters(op, r, pathParams)
+
+			if op.bodySchema != nil && r.Body != nil {
+				body, err := io.ReadAll(r.Body)
+				r.Body.Close()
+				if err != nil {
+					var maxBytesErr *http.MaxBytesError
+					if errors.As(err, &maxBytesErr) {
+						writeJSON(w, http.StatusRequestEntityTooLarge, map[string]string{"error": "request body too large"})
+						return
+					}
+					writeJSON(w, http.StatusBadRequest, map[string]string{"error": "failed to read request body"})
+					return
+				}
+				// Handlers decode the body again, so hand them an unread copy
+				r.Body = io.NopCloser(bytes.NewReader(body))
+
+				if len(bytes.TrimSpace(body)) == 0 {
+					if op.bodyRequired {
+						errs = append(errs, FieldError{Field: "body", In: "body", Message: "is required"})
+					}
+				} else {
+					dec := json.NewDecoder(bytes.NewReader(body))
+					dec.UseNumber()
+					var decoded any
+					if err := dec.Decode(&decoded); err == nil {
+						errs = append(errs, validateBody(op.bodySchema, decoded)...)
+					}
+				}
+			}
+
+			if len(errs) > 0 {
+				writeJSON(w, http.StatusBadRequest, ValidationErrorResponse{
+					Error:  "request does not match the API specification",
+					Code:   ErrorCode,
+					Fields: errs,
+				})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
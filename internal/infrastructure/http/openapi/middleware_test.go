@@ -0,0 +1,179 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf"
+)
+
+func loadSpec(t *testing.T) *Spec {
+	t.Helper()
+	spec, err := Load(mjrwtf.OpenAPISpec)
+	if err != nil {
+		t.Fatalf("failed to load embedded spec: %v", err)
+	}
+	return spec
+}
+
+func serve(t *testing.T, req *http.Request) (*httptest.ResponseRecorder, string, bool) {
+	t.Helper()
+	reached := false
+	var gotBody string
+	handler := Middleware(loadSpec(t))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		if r.Body != nil {
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Fatalf("failed to read body in handler: %v", err)
+			}
+			gotBody = string(body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, gotBody, reached
+}
+
+func TestLoad_InvalidSpec(t *testing.T) {
+	if _, err := Load([]byte("paths: [")); err == nil {
+		t.Error("expected an error for malformed YAML")
+	}
+
+	spec := `
+paths:
+  /api/urls:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Missing'
+`
+	if _, err := Load([]byte(spec)); err == nil {
+		t.Error("expected an error for an unresolvable $ref")
+	}
+}
+
+func TestMiddleware_CreateURLBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantReached bool
+		wantFields  []FieldError
+	}{
+		{
+			name:        "valid body passes through",
+			body:        `{"original_url":"https://example.com","short_code":"docs"}`,
+			wantReached: true,
+		},
+		{
+			name:       "original_url of the wrong type",
+			body:       `{"original_url":42}`,
+			wantFields: []FieldError{{Field: "original_url", In: "body", Message: "must be a string"}},
+		},
+		{
+			name:       "missing original_url",
+			body:       `{"short_code":"docs"}`,
+			wantFields: []FieldError{{Field: "original_url", In: "body", Message: "is required"}},
+		},
+		{
+			name: "several invalid fields",
+			body: `{"original_url":"","short_code":"a b"}`,
+			wantFields: []FieldError{
+				{Field: "original_url", In: "body", Message: "must not be empty"},
+				{Field: "short_code", In: "body", Message: "must match pattern ^[a-zA-Z0-9_-]{3,20}$"},
+			},
+		},
+		{
+			name:       "empty body",
+			body:       "",
+			wantFields: []FieldError{{Field: "body", In: "body", Message: "is required"}},
+		},
+		{
+			name:        "malformed JSON is left to the handler",
+			body:        `{"original_url":`,
+			wantReached: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/urls", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec, gotBody, reached := serve(t, req)
+			if reached != tt.wantReached {
+				t.Fatalf("expected reached=%v, got %v (status %d, body %s)", tt.wantReached, reached, rec.Code, rec.Body.String())
+			}
+			if reached {
+				if gotBody != tt.body {
+					t.Errorf("expected handler to read body %q, got %q", tt.body, gotBody)
+				}
+				return
+			}
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d", rec.Code)
+			}
+			var resp ValidationErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Code != ErrorCode {
+				t.Errorf("expected code %q, got %q", ErrorCode, resp.Code)
+			}
+			if len(resp.Fields) != len(tt.wantFields) {
+				t.Fatalf("expected fields %+v, got %+v", tt.wantFields, resp.Fields)
+			}
+			for i, want := range tt.wantFields {
+				if resp.Fields[i] != want {
+					t.Errorf("field %d: expected %+v, got %+v", i, want, resp.Fields[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMiddleware_Parameters(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		wantReached bool
+		wantField   FieldError
+	}{
+		{name: "valid query", method: http.MethodGet, target: "/api/urls?limit=10&offset=0", wantReached: true},
+		{name: "non-integer limit", method: http.MethodGet, target: "/api/urls?limit=ten", wantField: FieldError{Field: "limit", In: "query", Message: "must be an integer"}},
+		{name: "limit above maximum", method: http.MethodGet, target: "/api/urls?limit=1000", wantField: FieldError{Field: "limit", In: "query", Message: "must be at most 100"}},
+		{name: "literal route wins over template", method: http.MethodGet, target: "/api/urls/count", wantReached: true},
+		{name: "invalid path parameter", method: http.MethodDelete, target: "/api/urls/a!", wantField: FieldError{Field: "shortCode", In: "path", Message: "must match pattern ^[a-zA-Z0-9_-]{3,20}$"}},
+		{name: "route not in spec", method: http.MethodGet, target: "/api/unknown?limit=ten", wantReached: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, _, reached := serve(t, httptest.NewRequest(tt.method, tt.target, nil))
+			if reached != tt.wantReached {
+				t.Fatalf("expected reached=%v, got %v (status %d, body %s)", tt.wantReached, reached, rec.Code, rec.Body.String())
+			}
+			if reached {
+				return
+			}
+
+			var resp ValidationErrorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Fields) != 1 || resp.Fields[0] != tt.wantField {
+				t.Errorf("expected fields [%+v], got %+v", tt.wantField, resp.Fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the subset of an OpenAPI 3 document needed to validate requests:
+// each operation's parameters and JSON request body schema
+type Spec struct {
+	routes []*route
+}
+
+type route struct {
+	method   string
+	segments []string // "{name}" segments match any single path segment
+	op       *operation
+}
+
+type document struct {
+	Paths      map[string]map[string]*operation `yaml:"paths"`
+	Components struct {
+		Schemas    map[string]*Schema    `yaml:"schemas"`
+		Parameters map[string]*Parameter `yaml:"parameters"`
+	} `yaml:"components"`
+}
+
+type operation struct {
+	Parameters  []*Parameter `yaml:"parameters"`
+	RequestBody *struct {
+		Required bool `yaml:"required"`
+		Content  map[string]struct {
+			Schema *Schema `yaml:"schema"`
+		} `yaml:"content"`
+	} `yaml:"requestBody"`
+
+	bodyRequired bool
+	bodySchema   *Schema
+}
+
+// Parameter is an operation parameter in the query, path or headers
+type Parameter struct {
+	Ref      string  `yaml:"$ref"`
+	Name     string  `yaml:"name"`
+	In       string  `yaml:"in"`
+	Required bool    `yaml:"required"`
+	Schema   *Schema `yaml:"schema"`
+}
+
+// Schema is the subset of JSON Schema keywords the spec uses
+type Schema struct {
+	Ref        string             `yaml:"$ref"`
+	Type       string             `yaml:"type"`
+	Format     string             `yaml:"format"`
+	Enum       []string           `yaml:"enum"`
+	Required   []string           `yaml:"required"`
+	Properties map[string]*Schema `yaml:"properties"`
+	Items      *Schema            `yaml:"items"`
+	Minimum    *float64           `yaml:"minimum"`
+	Maximum    *float64           `yaml:"maximum"`
+	MinLength  *int               `yaml:"minLength"`
+	MaxLength  *int               `yaml:"maxLength"`
+	Pattern    string             `yaml:"pattern"`
+	Nullable   bool               `yaml:"nullable"`
+
+	pattern *regexp.Regexp
+}
+
+var methods = map[string]string{
+	"get":    http.MethodGet,
+	"put":    http.MethodPut,
+	"post":   http.MethodPost,
+	"delete": http.MethodDelete,
+	"patch":  http.MethodPatch,
+	"head":   http.MethodHead,
+}
+
+// Load parses an OpenAPI 3 YAML document, resolving local $refs and
+// compiling patterns up front so validation never fails on the spec itself
+func Load(data []byte) (*Spec, error) {
+	var doc document
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	r := &resolver{doc: &doc, resolved: map[*Schema]bool{}}
+	spec := &Spec{}
+	for path, item := range doc.Paths {
+		for name, op := range item {
+			method, ok := methods[name]
+			if !ok || op == nil {
+				continue
+			}
+
+			for i, p := range op.Parameters {
+				param, err := r.parameter(p)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %w", method, path, err)
+				}
+				op.Parameters[i] = param
+			}
+			if op.RequestBody != nil {
+				op.bodyRequired = op.RequestBody.Required
+				if media, ok := op.RequestBody.Content["application/json"]; ok {
+					schema, err := r.schema(media.Schema)
+					if err != nil {
+						return nil, fmt.Errorf("%s %s: %w", method, path, err)
+					}
+					op.bodySchema = schema
+				}
+			}
+
+			spec.routes = append(spec.routes, &route{
+				method:   method,
+				segments: strings.Split(strings.Trim(path, "/"), "/"),
+				op:       op,
+			})
+		}
+	}
+	return spec, nil
+}
+
+// match returns the operation for method and path, preferring literal
+// segments over templated ones (so /api/urls/count beats /api/urls/{shortCode}),
+// and the values of its path parameters
+func (s *Spec) match(method, path string) (*operation, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var best *route
+	bestLiterals := -1
+	for _, rt := range s.routes {
+		if rt.method != method || len(rt.segments) != len(segments) {
+			continue
+		}
+		literals, ok := 0, true
+		for i, seg := range rt.segments {
+			if isTemplate(seg) {
+				continue
+			}
+			if seg != segments[i] {
+				ok = false
+				break
+			}
+			literals++
+		}
+		if ok && literals > bestLiterals {
+			best, bestLiterals = rt, literals
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	params := map[string]string{}
+	for i, seg := range best.segments {
+		if isTemplate(seg) {
+			params[seg[1:len(seg)-1]] = segments[i]
+		}
+	}
+	return best.op, params
+}
+
+func isTemplate(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// resolver replaces $refs to components with their targets
+type resolver struct {
+	doc      *document
+	resolved map[*Schema]bool
+}
+
+func (r *resolver) parameter(p *Parameter) (*Parameter, error) {
+	if p.Ref != "" {
+		name, ok := strings.CutPrefix(p.Ref, "#/components/parameters/")
+		target := r.doc.Components.Parameters[name]
+		if !ok || target == nil {
+			return nil, fmt.Errorf("unresolvable parameter $ref %q", p.Ref)
+		}
+		p = target
+	}
+
+	schema, err := r.schema(p.Schema)
+	if err != nil {
+		return nil, fmt.Errorf("parameter %q: %w", p.Name, err)
+	}
+	p.Schema = schema
+	return p, nil
+}
+
+func (r *resolver) schema(s *Schema) (*Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		target := r.doc.Components.Schemas[name]
+		if !ok || target == nil {
+			return nil, fmt.Errorf("unresolvable schema $ref %q", s.Ref)
+		}
+		s = target
+	}
+	// Shared component schemas are only resolved once, which also stops cycles
+	if r.resolved[s] {
+		return s, nil
+	}
+	r.resolved[s] = true
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	for name, prop := range s.Properties {
+		resolved, err := r.schema(prop)
+		if err != nil {
+			return nil, fmt.Errorf("property %q: %w", name, err)
+		}
+		s.Properties[name] = resolved
+	}
+	items, err := r.schema(s.Items)
+	if err != nil {
+		return nil, fmt.Errorf("items: %w", err)
+	}
+	s.Items = items
+	return s, nil
+}
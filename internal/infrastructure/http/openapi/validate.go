@@ -0,0 +1,252 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FieldError describes one request field that does not match the spec
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in"`
+	Message string `json:"message"`
+}
+
+// validateParameters checks path, query and header parameters against op
+func validateParameters(op *operation, r *http.Request, pathParams map[string]string) []FieldError {
+	var errs []FieldError
+	query := r.URL.Query()
+
+	for _, p := range op.Parameters {
+		var raw string
+		var present bool
+		switch p.In {
+		case "path":
+			raw, present = pathParams[p.Name]
+		case "query":
+			present = query.Has(p.Name)
+			raw = query.Get(p.Name)
+		case "header":
+			present = r.Header.Get(p.Name) != ""
+			raw = r.Header.Get(p.Name)
+		default:
+			continue
+		}
+
+		if !present {
+			if p.Required {
+				errs = append(errs, FieldError{Field: p.Name, In: p.In, Message: "is required"})
+			}
+			continue
+		}
+		if p.Schema == nil {
+			continue
+		}
+
+		value, msg := parseParameter(p.Schema, raw)
+		if msg == "" {
+			msg = firstMessage(validateValue(p.Schema, value, p.Name))
+		}
+		if msg != "" {
+			errs = append(errs, FieldError{Field: p.Name, In: p.In, Message: msg})
+		}
+	}
+	return errs
+}
+
+// parseParameter converts a raw parameter string to the type its schema
+// declares, so it can be checked like a body value
+func parseParameter(s *Schema, raw string) (any, string) {
+	switch s.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, "must be " + article(s.Type)
+		}
+		return json.Number(raw), ""
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, "must be a boolean"
+		}
+		return b, ""
+	default:
+		return raw, ""
+	}
+}
+
+// validateBody checks a decoded JSON body against schema
+func validateBody(s *Schema, body any) []FieldError {
+	var errs []FieldError
+	for _, v := range validateValue(s, body, "") {
+		field := v.field
+		if field == "" {
+			field = "body"
+		}
+		errs = append(errs, FieldError{Field: field, In: "body", Message: v.message})
+	}
+	return errs
+}
+
+type violation struct {
+	field   string
+	message string
+}
+
+func firstMessage(vs []violation) string {
+	if len(vs) == 0 {
+		return ""
+	}
+	return vs[0].message
+}
+
+// validateValue checks a JSON value (as decoded with UseNumber) against s,
+// reporting violations with dotted field paths rooted at field
+func validateValue(s *Schema, v any, field string) []violation {
+	if s == nil {
+		return nil
+	}
+	if v == nil {
+		if s.Nullable {
+			return nil
+		}
+		return []violation{{field, "must not be null"}}
+	}
+
+	switch s.Type {
+	case "object":
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return []violation{{field, "must be an object"}}
+		}
+		return validateObject(s, obj, field)
+
+	case "array":
+		items, ok := v.([]any)
+		if !ok {
+			return []violation{{field, "must be an array"}}
+		}
+		var vs []violation
+		for i, item := range items {
+			vs = append(vs, validateValue(s.Items, item, fmt.Sprintf("%s[%d]", field, i))...)
+		}
+		return vs
+
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return []violation{{field, "must be a string"}}
+		}
+		if msg := validateString(s, str); msg != "" {
+			return []violation{{field, msg}}
+		}
+
+	case "integer", "number":
+		n, ok := v.(json.Number)
+		if !ok {
+			return []violation{{field, "must be " + article(s.Type)}}
+		}
+		if msg := validateNumber(s, n); msg != "" {
+			return []violation{{field, msg}}
+		}
+
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return []violation{{field, "must be a boolean"}}
+		}
+	}
+	return nil
+}
+
+func validateObject(s *Schema, obj map[string]any, field string) []violation {
+	var vs []violation
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			vs = append(vs, violation{join(field, name), "is required"})
+		}
+	}
+
+	// Sorted so the order of field errors is stable
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if prop, ok := s.Properties[name]; ok {
+			vs = append(vs, validateValue(prop, obj[name], join(field, name))...)
+		}
+	}
+	return vs
+}
+
+func validateString(s *Schema, str string) string {
+	n := utf8.RuneCountInString(str)
+	if s.MinLength != nil && n < *s.MinLength {
+		if *s.MinLength == 1 {
+			return "must not be empty"
+		}
+		return fmt.Sprintf("must be at least %d characters", *s.MinLength)
+	}
+	if s.MaxLength != nil && n > *s.MaxLength {
+		return fmt.Sprintf("must be at most %d characters", *s.MaxLength)
+	}
+	if len(s.Enum) > 0 && !contains(s.Enum, str) {
+		return "must be one of " + strings.Join(s.Enum, ", ")
+	}
+	if s.pattern != nil && !s.pattern.MatchString(str) {
+		return "must match pattern " + s.Pattern
+	}
+	if s.Format == "date-time" {
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			return "must be an RFC 3339 date-time"
+		}
+	}
+	return ""
+}
+
+func validateNumber(s *Schema, n json.Number) string {
+	f, err := n.Float64()
+	if err != nil {
+		return "must be " + article(s.Type)
+	}
+	if s.Type == "integer" && f != math.Trunc(f) {
+		return "must be an integer"
+	}
+	if s.Minimum != nil && f < *s.Minimum {
+		return "must be at least " + strconv.FormatFloat(*s.Minimum, 'f', -1, 64)
+	}
+	if s.Maximum != nil && f > *s.Maximum {
+		return "must be at most " + strconv.FormatFloat(*s.Maximum, 'f', -1, 64)
+	}
+	return ""
+}
+
+func join(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func article(typ string) string {
+	if typ == "integer" {
+		return "an integer"
+	}
+	return "a " + typ
+}
+
+func contains(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
@@ -2,10 +2,19 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/adapters/repository"
+	"github.com/matt-riley/mjrwtf/internal/application"
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
 )
 
 // TestAPIEndpoints_CreateURL tests the POST /api/urls endpoint
@@ -246,7 +255,229 @@ func TestAPIEndpoints_ListURLs(t *testing.T) {
 	}
 }
 
+// TestAPIEndpoints_CountURLs tests the GET /api/urls/count endpoint
+func TestAPIEndpoints_CountURLs(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(testConfig(), db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	// Two URLs for the token's user and one for someone else
+	urlRepo := repository.NewSQLiteURLRepository(db)
+	for _, u := range []struct{ shortCode, createdBy string }{
+		{"mine01", "authenticated-user"},
+		{"mine02", "authenticated-user"},
+		{"theirs", "someone-else"},
+	} {
+		if err := urlRepo.Create(context.Background(), &url.URL{ShortCode: u.shortCode, OriginalURL: "https://example.com/" + u.shortCode, CreatedBy: u.createdBy, CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("failed to create URL: %v", err)
+		}
+	}
+
+	count := func(authToken string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, "/api/urls/count", nil)
+		if authToken != "" {
+			req.Header.Set("Authorization", "Bearer "+authToken)
+		}
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return rec.Code, body
+	}
+
+	status, body := count("test-token")
+	if status != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+	}
+	if body["total"] != float64(2) {
+		t.Errorf("expected total 2 for the caller's own URLs, got %v", body["total"])
+	}
+
+	// The list total is scoped the same way
+	req := httptest.NewRequest(http.MethodGet, "/api/urls", nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+	var list map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal list response: %v", err)
+	}
+	if list["total"] != body["total"] {
+		t.Errorf("expected list total %v to match count, got %v", body["total"], list["total"])
+	}
+
+	if status, _ := count(""); status != http.StatusUnauthorized {
+		t.Errorf("expected status %d without auth, got %d", http.StatusUnauthorized, status)
+	}
+}
+
+func TestAPIEndpoints_StatsSummary(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(testConfig(), db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	// Two URLs for the token's user with 3 clicks between them, and one for someone else
+	urlRepo := repository.NewSQLiteURLRepository(db)
+	clickRepo := repository.NewSQLiteClickRepository(db)
+	for _, u := range []struct {
+		shortCode, createdBy string
+		clicks               int
+	}{
+		{"mine01", "authenticated-user", 2},
+		{"mine02", "authenticated-user", 1},
+		{"theirs", "someone-else", 5},
+	} {
+		created := &url.URL{ShortCode: u.shortCode, OriginalURL: "https://example.com/" + u.shortCode, CreatedBy: u.createdBy, CreatedAt: time.Now()}
+		if err := urlRepo.Create(context.Background(), created); err != nil {
+			t.Fatalf("failed to create URL: %v", err)
+		}
+		for i := 0; i < u.clicks; i++ {
+			c, _ := click.NewClick(created.ID, "", "", "")
+			if err := clickRepo.Record(context.Background(), c); err != nil {
+				t.Fatalf("failed to record click: %v", err)
+			}
+		}
+	}
+
+	summary := func(authToken string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, "/api/stats/summary", nil)
+		if authToken != "" {
+			req.Header.Set("Authorization", "Bearer "+authToken)
+		}
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return rec.Code, body
+	}
+
+	status, body := summary("test-token")
+	if status != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+	}
+	if body["total_urls"] != float64(2) || body["total_clicks"] != float64(3) {
+		t.Errorf("expected 2 URLs and 3 clicks for the caller, got %v", body)
+	}
+
+	if status, _ := summary(""); status != http.StatusUnauthorized {
+		t.Errorf("expected status %d without auth, got %d", http.StatusUnauthorized, status)
+	}
+}
+
+// TestAPIEndpoints_CreateURL_BodyTooLarge tests that MAX_REQUEST_BODY_BYTES rejects oversized bodies with 413
+func TestAPIEndpoints_CreateURL_BodyTooLarge(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	cfg.MaxRequestBodyBytes = 256
+
+	srv, err := New(cfg, db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	oversized := `{"original_url":"https://example.com/` + strings.Repeat("a", 512) + `"}`
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64 // -1 hides the length, as with a chunked upload
+		wantStatus    int
+	}{
+		{name: "fits", body: `{"original_url":"https://example.com"}`, contentLength: 0, wantStatus: http.StatusCreated},
+		{name: "declared length too large", body: oversized, contentLength: int64(len(oversized)), wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "streamed body too large", body: oversized, contentLength: -1, wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/urls", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer test-token")
+			req.Header.Set("Content-Type", "application/json")
+			if tt.contentLength != 0 {
+				req.ContentLength = tt.contentLength
+			}
+			rec := httptest.NewRecorder()
+
+			srv.router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusRequestEntityTooLarge {
+				if got := strings.TrimSpace(rec.Body.String()); got != `{"error":"request body too large"}` {
+					t.Errorf("expected a clear body-size error, got %s", got)
+				}
+			}
+		})
+	}
+}
+
 // TestAPIEndpoints_DeleteURL tests the DELETE /api/urls/{shortCode} endpoint
+
+func TestAPIEndpoints_ValidateOpenAPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		validate   bool
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "valid body passes validation", validate: true, body: `{"original_url":"https://example.com"}`, wantStatus: http.StatusCreated},
+		{name: "wrong type rejected by validator", validate: true, body: `{"original_url":42}`, wantStatus: http.StatusBadRequest, wantCode: "invalid_request"},
+		{name: "wrong type reaches handler when disabled", validate: false, body: `{"original_url":42}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			defer db.Close()
+
+			cfg := testConfig()
+			cfg.ValidateOpenAPI = tt.validate
+
+			srv, err := New(cfg, db, testLogger())
+			if err != nil {
+				t.Fatalf("failed to create server: %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/urls", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer test-token")
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			srv.router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			var resp struct {
+				Code string `json:"code"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Code != tt.wantCode {
+				t.Errorf("expected code %q, got %q", tt.wantCode, resp.Code)
+			}
+		})
+	}
+}
 func TestAPIEndpoints_DeleteURL(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -415,3 +646,329 @@ func TestAPIEndpoints_FullWorkflow(t *testing.T) {
 		t.Errorf("expected status %d when deleting non-existent URL, got %d", http.StatusNotFound, deleteRec2.Code)
 	}
 }
+
+// TestAPIEndpoints_SoftDeleteRestore tests delete -> restore with SOFT_DELETE_ENABLED,
+// checking that the URL disappears while deleted and comes back with its analytics
+func TestAPIEndpoints_SoftDeleteRestore(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	cfg.SoftDeleteEnabled = true
+
+	srv, err := New(cfg, db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	defer srv.Shutdown(context.Background())
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	createReq := httptest.NewRequest(http.MethodPost, "/api/urls",
+		bytes.NewBufferString(`{"original_url":"https://example.com","short_code":"keepme"}`))
+	createReq.Header.Set("Content-Type", "application/json")
+	createReq.Header.Set("Authorization", "Bearer test-token")
+	createRec := httptest.NewRecorder()
+	srv.router.ServeHTTP(createRec, createReq)
+	if createRec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d when creating URL, got %d", http.StatusCreated, createRec.Code)
+	}
+
+	// Record clicks directly so the analytics check doesn't race the async click workers
+	ctx := context.Background()
+	found, err := repository.NewSQLiteURLRepository(db).FindByShortCode(ctx, "keepme")
+	if err != nil {
+		t.Fatalf("failed to find URL: %v", err)
+	}
+	clickRepo := repository.NewSQLiteClickRepository(db)
+	for i := 0; i < 2; i++ {
+		c, _ := click.NewClick(found.ID, "", "US", "")
+		if err := clickRepo.Record(ctx, c); err != nil {
+			t.Fatalf("failed to record click: %v", err)
+		}
+	}
+
+	if rec := do(http.MethodDelete, "/api/urls/keepme"); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d when deleting URL, got %d", http.StatusNoContent, rec.Code)
+	}
+
+	// While soft-deleted: redirect, analytics and a second delete all 404, and the list is empty
+	if rec := do(http.MethodGet, "/keepme"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d redirecting soft-deleted URL, got %d", http.StatusNotFound, rec.Code)
+	}
+	if rec := do(http.MethodGet, "/api/urls/keepme/analytics"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for soft-deleted URL analytics, got %d", http.StatusNotFound, rec.Code)
+	}
+	if rec := do(http.MethodDelete, "/api/urls/keepme"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d deleting soft-deleted URL, got %d", http.StatusNotFound, rec.Code)
+	}
+	var listResp application.ListURLsResponse
+	json.Unmarshal(do(http.MethodGet, "/api/urls").Body.Bytes(), &listResp)
+	if listResp.Total != 0 || len(listResp.URLs) != 0 {
+		t.Errorf("expected soft-deleted URL to be hidden from list, got total=%d urls=%d", listResp.Total, len(listResp.URLs))
+	}
+
+	// The short code stays reserved while soft-deleted
+	dupReq := httptest.NewRequest(http.MethodPost, "/api/urls",
+		bytes.NewBufferString(`{"original_url":"https://other.example","short_code":"keepme"}`))
+	dupReq.Header.Set("Content-Type", "application/json")
+	dupReq.Header.Set("Authorization", "Bearer test-token")
+	dupRec := httptest.NewRecorder()
+	srv.router.ServeHTTP(dupRec, dupReq)
+	if dupRec.Code != http.StatusConflict {
+		t.Errorf("expected status %d reusing a soft-deleted short code, got %d", http.StatusConflict, dupRec.Code)
+	}
+
+	if rec := do(http.MethodPost, "/api/urls/keepme/restore"); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d when restoring URL, got %d: %s", http.StatusNoContent, rec.Code, rec.Body.String())
+	}
+
+	if rec := do(http.MethodGet, "/keepme"); rec.Code != http.StatusFound {
+		t.Errorf("expected status %d redirecting restored URL, got %d", http.StatusFound, rec.Code)
+	}
+
+	analyticsRec := do(http.MethodGet, "/api/urls/keepme/analytics")
+	if analyticsRec.Code != http.StatusOK {
+		t.Fatalf("expected status %d for restored URL analytics, got %d", http.StatusOK, analyticsRec.Code)
+	}
+	var analytics application.GetAnalyticsResponse
+	json.Unmarshal(analyticsRec.Body.Bytes(), &analytics)
+	if analytics.TotalClicks < 2 {
+		t.Errorf("expected clicks recorded before the delete to survive, got total_clicks=%d", analytics.TotalClicks)
+	}
+
+	if rec := do(http.MethodPost, "/api/urls/missing/restore"); rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d restoring unknown URL, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+// TestAPIEndpoints_CreateURL_IdempotencyKey tests that POST /api/urls replays results per Idempotency-Key
+func TestAPIEndpoints_CreateURL_DiscordNotification(t *testing.T) {
+	posts := make(chan []byte, 4)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		posts <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	cfg.DiscordCreateWebhookURL = webhook.URL
+
+	srv, err := New(cfg, db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	defer srv.Shutdown(context.Background())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", bytes.NewBufferString(`{"original_url":"https://example.com/secret/path","short_code":"notify1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-token")
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	var body []byte
+	select {
+	case body = <-posts:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a created-URL post to the webhook")
+	}
+
+	var payload struct {
+		Embeds []struct {
+			Fields []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"fields"`
+		} `json:"embeds"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("failed to decode webhook payload: %v", err)
+	}
+	if len(payload.Embeds) != 1 {
+		t.Fatalf("expected one embed, got %d", len(payload.Embeds))
+	}
+
+	fields := map[string]string{}
+	for _, f := range payload.Embeds[0].Fields {
+		fields[f.Name] = f.Value
+	}
+	want := map[string]string{
+		"Short Code":  "notify1",
+		"Destination": "example.com",
+		"Created By":  "authenticated-user",
+	}
+	for name, value := range want {
+		if fields[name] != value {
+			t.Errorf("embed field %q = %q, want %q", name, fields[name], value)
+		}
+	}
+}
+
+func TestAPIEndpoints_CreateURL_NotifyEventDisabled(t *testing.T) {
+	posts := make(chan []byte, 4)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		posts <- body
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	cfg.DiscordCreateWebhookURL = webhook.URL
+	cfg.EventWebhookURL = webhook.URL
+	cfg.NotifyEvents = []string{"panic"}
+
+	srv, err := New(cfg, db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	defer srv.Shutdown(context.Background())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", bytes.NewBufferString(`{"original_url":"https://example.com","short_code":"quiet1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-token")
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	select {
+	case body := <-posts:
+		t.Fatalf("expected no webhook post with created disabled, got %s", body)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestAPIEndpoints_CreateURL_IdempotencyKey(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(testConfig(), db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	defer srv.Shutdown(context.Background())
+
+	create := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/urls", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer test-token")
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		return rec
+	}
+	shortCode := func(rec *httptest.ResponseRecorder) string {
+		t.Helper()
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+		}
+		var resp map[string]interface{}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp["short_code"].(string)
+	}
+
+	body := `{"original_url":"https://example.com"}`
+	first := shortCode(create("retry-1", body))
+	if again := shortCode(create("retry-1", body)); again != first {
+		t.Errorf("expected same key to return %q, got %q", first, again)
+	}
+	if other := shortCode(create("retry-2", body)); other == first {
+		t.Errorf("expected a different key to create a new URL, got %q again", other)
+	}
+
+	count, err := repository.NewSQLiteURLRepository(db).Count(context.Background(), "authenticated-user")
+	if err != nil {
+		t.Fatalf("failed to count URLs: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 URLs to be stored, got %d", count)
+	}
+
+	if rec := create("retry-1", `{"original_url":"https://example.org"}`); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status %d reusing a key for another URL, got %d", http.StatusUnprocessableEntity, rec.Code)
+	}
+	if rec := create("bad\nkey", body); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for an invalid key, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+// TestAPIEndpoints_Audit tests that creates and deletes are recorded and listed by GET /api/audit
+func TestAPIEndpoints_Audit(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(testConfig(), db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	defer srv.Shutdown(context.Background())
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer test-token")
+		req.RemoteAddr = "192.0.2.10:54321"
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := do(http.MethodPost, "/api/urls", `{"original_url":"https://example.com","short_code":"audited"}`); rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d when creating URL, got %d", http.StatusCreated, rec.Code)
+	}
+	if rec := do(http.MethodDelete, "/api/urls/audited", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("expected status %d when deleting URL, got %d", http.StatusNoContent, rec.Code)
+	}
+
+	rec := do(http.MethodGet, "/api/audit?short_code=audited", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp application.ListAuditEntriesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(resp.Entries))
+	}
+	if resp.Entries[0].Action != "delete" || resp.Entries[1].Action != "create" {
+		t.Errorf("expected delete then create, got %q then %q", resp.Entries[0].Action, resp.Entries[1].Action)
+	}
+	for _, e := range resp.Entries {
+		if e.UserID != "authenticated-user" || e.IPAddress != "192.0.2.10" {
+			t.Errorf("unexpected audit entry: %+v", e)
+		}
+	}
+
+	// The audit trail requires the bearer token
+	req := httptest.NewRequest(http.MethodGet, "/api/audit?short_code=audited", nil)
+	unauth := httptest.NewRecorder()
+	srv.router.ServeHTTP(unauth, req)
+	if unauth.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d without a token, got %d", http.StatusUnauthorized, unauth.Code)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/config"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/rs/zerolog"
@@ -246,6 +247,78 @@ func TestServer_RateLimitAPI(t *testing.T) {
 	}
 }
 
+func TestServer_RateLimitRedisSharedAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	newInstance := func() *Server {
+		cfg := testConfig()
+		cfg.APIRateLimitPerMinute = 2
+		cfg.RateLimitBackend = "redis"
+		cfg.RedisURL = "redis://" + mr.Addr()
+
+		db := setupTestDB(t)
+		t.Cleanup(func() { _ = db.Close() })
+
+		srv, err := New(cfg, db, testLogger())
+		if err != nil {
+			t.Fatalf("failed to create server: %v", err)
+		}
+		t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+		return srv
+	}
+	instances := []*Server{newInstance(), newInstance()}
+
+	listURLs := func(srv *Server) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/urls", nil)
+		req.RemoteAddr = "192.0.2.202:1234"
+		req.Header.Set("Authorization", "Bearer test-token")
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests} {
+		if got := listURLs(instances[i%2]); got != want {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, want, got)
+		}
+	}
+}
+
+func TestServer_RateLimitRedisUnreachableFallsBackToMemory(t *testing.T) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	mr.Close()
+
+	cfg := testConfig()
+	cfg.APIRateLimitPerMinute = 1
+	cfg.RateLimitBackend = "redis"
+	cfg.RedisURL = "redis://" + addr
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(cfg, db, testLogger())
+	if err != nil {
+		t.Fatalf("expected server to start without redis, got: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	if srv.redisClient != nil {
+		t.Fatal("expected no redis client when redis is unreachable")
+	}
+
+	for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/api/urls", nil)
+		req.RemoteAddr = "192.0.2.203:1234"
+		req.Header.Set("Authorization", "Bearer test-token")
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Fatalf("request %d: expected status %d, got %d", i+1, want, rec.Code)
+		}
+	}
+}
+
 // TestServer_ConcurrentRequests tests the server handles concurrent requests
 func TestServer_ConcurrentRequests(t *testing.T) {
 	cfg := testConfig()
@@ -1,6 +1,11 @@
 package server
 
 import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -32,3 +37,61 @@ func TestMigrationCreatesBothTables(t *testing.T) {
 		t.Error("clicks table not found")
 	}
 }
+
+func TestServer_AutoMigrate(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	cfg := testConfig()
+	cfg.AutoMigrate = true
+
+	srv, err := New(cfg, db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	verifyTablesExist(t, db)
+
+	// Starting again against an up-to-date schema is a no-op
+	again, err := New(cfg, db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server against migrated database: %v", err)
+	}
+	t.Cleanup(func() { _ = again.Shutdown(context.Background()) })
+
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", strings.NewReader(`{"original_url":"https://example.com"}`))
+	req.Header.Set("Authorization", "Bearer test-token")
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+}
+
+func TestServer_AutoMigrateDisabledLeavesSchemaAlone(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	srv, err := New(testConfig(), db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	t.Cleanup(func() { _ = srv.Shutdown(context.Background()) })
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='urls'").Scan(&count); err != nil {
+		t.Fatalf("failed to query tables: %v", err)
+	}
+	if count != 0 {
+		t.Fatal("expected no urls table without AUTO_MIGRATE")
+	}
+}
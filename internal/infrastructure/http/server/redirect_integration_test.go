@@ -1,10 +1,14 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +16,8 @@ import (
 	"github.com/matt-riley/mjrwtf/internal/adapters/repository"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 	"github.com/matt-riley/mjrwtf/internal/domain/urlstatus"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -215,6 +221,223 @@ func TestServer_RedirectWithAnalytics(t *testing.T) {
 	}
 }
 
+func TestServer_RedirectWithUnloadableGeoIPDatabase(t *testing.T) {
+	corrupt := filepath.Join(t.TempDir(), "corrupt.mmdb")
+	require.NoError(t, os.WriteFile(corrupt, []byte("not a maxmind database"), 0o644))
+
+	for name, path := range map[string]string{
+		"missing": filepath.Join(t.TempDir(), "missing.mmdb"),
+		"corrupt": corrupt,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.GeoIPEnabled = true
+			cfg.GeoIPDatabase = path
+
+			db := setupTestDB(t)
+			defer db.Close()
+
+			var buf bytes.Buffer
+			srv, err := New(cfg, db, logging.New("info", "json", logging.WithOutput(&buf)))
+			require.NoError(t, err, "server should start without a usable GeoIP database")
+			defer srv.Shutdown(context.Background())
+
+			assert.Equal(t, 1, strings.Count(buf.String(), "failed to open GeoIP database"))
+
+			urlRepo := repository.NewSQLiteURLRepository(db)
+			require.NoError(t, urlRepo.Create(context.Background(), &url.URL{
+				ShortCode:   "geo123",
+				OriginalURL: "https://example.com/geo",
+				CreatedBy:   "test-user",
+				CreatedAt:   time.Now(),
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/geo123", nil)
+			req.RemoteAddr = "8.8.8.8:1234"
+			rec := httptest.NewRecorder()
+			srv.router.ServeHTTP(rec, req)
+			require.Equal(t, http.StatusFound, rec.Code)
+
+			var country sql.NullString
+			require.Eventually(t, func() bool {
+				return db.QueryRow(`SELECT country FROM clicks`).Scan(&country) == nil
+			}, time.Second, 10*time.Millisecond, "expected the click to be recorded")
+			assert.Empty(t, country.String)
+		})
+	}
+}
+
+func TestServer_RedirectUnknownCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		notFoundURL  string
+		path         string
+		wantStatus   int
+		wantLocation string
+	}{
+		{"default 404", "", "/nosuchcode", http.StatusNotFound, ""},
+		{"redirects to landing page", "https://example.com/landing", "/nosuchcode", http.StatusFound, "https://example.com/landing"},
+		{"malformed code still 404s", "https://example.com/landing", "/ab", http.StatusNotFound, ""},
+		{"malformed characters still 404", "https://example.com/landing", "/bad.code", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.NotFoundRedirectURL = tt.notFoundURL
+
+			db := setupTestDB(t)
+			defer db.Close()
+
+			srv, err := New(cfg, db, testLogger())
+			require.NoError(t, err)
+			defer srv.Shutdown(context.Background())
+
+			// Existing codes redirect as usual
+			urlRepo := repository.NewSQLiteURLRepository(db)
+			require.NoError(t, urlRepo.Create(context.Background(), &url.URL{
+				ShortCode:   "known1",
+				OriginalURL: "https://example.com/known",
+				CreatedBy:   "test-user",
+				CreatedAt:   time.Now(),
+			}))
+			rec := httptest.NewRecorder()
+			srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/known1", nil))
+			require.Equal(t, http.StatusFound, rec.Code)
+			assert.Equal(t, "https://example.com/known", rec.Header().Get("Location"))
+
+			rec = httptest.NewRecorder()
+			srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLocation, rec.Header().Get("Location"))
+		})
+	}
+}
+
+// TestServer_RedirectPreview tests that ?preview=1 renders the interstitial and still records the click
+func TestServer_RedirectPreview(t *testing.T) {
+	cfg := testConfig()
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(cfg, db, testLogger())
+	require.NoError(t, err)
+	defer srv.Shutdown(context.Background())
+
+	urlRepo := repository.NewSQLiteURLRepository(db)
+	clickRepo := repository.NewSQLiteClickRepository(db)
+
+	ctx := context.Background()
+	testURL := &url.URL{
+		ShortCode:   "preview123",
+		OriginalURL: "https://example.com/preview",
+		CreatedBy:   "test-user",
+		CreatedAt:   time.Now(),
+	}
+	require.NoError(t, urlRepo.Create(ctx, testURL))
+
+	req := httptest.NewRequest(http.MethodGet, "/preview123?preview=1", nil)
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Location"))
+	assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rec.Body.String(), "https://example.com/preview")
+
+	// Wait a moment for async analytics to be recorded
+	time.Sleep(100 * time.Millisecond)
+
+	savedURL, err := urlRepo.FindByShortCode(ctx, "preview123")
+	require.NoError(t, err)
+
+	clickCount, err := clickRepo.GetTotalClickCount(ctx, savedURL.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), clickCount)
+}
+
+// TestServer_RedirectCache checks that the lookup cache serves repeat redirects
+// and that deleting through the API evicts the cached entry
+func TestServer_RedirectCache(t *testing.T) {
+	cfg := testConfig()
+	cfg.URLCacheSize = 10
+	cfg.URLCacheTTL = time.Hour
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(cfg, db, testLogger())
+	require.NoError(t, err)
+	defer srv.Shutdown(context.Background())
+
+	ctx := context.Background()
+	urlRepo := repository.NewSQLiteURLRepository(db)
+	require.NoError(t, urlRepo.Create(ctx, &url.URL{
+		ShortCode:   "cached1",
+		OriginalURL: "https://example.com/cached",
+		CreatedBy:   "authenticated-user",
+		CreatedAt:   time.Now(),
+	}))
+
+	redirect := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/cached1", nil))
+		return rec
+	}
+
+	rec := redirect()
+	require.Equal(t, http.StatusFound, rec.Code)
+
+	// A change made behind the server's back is not seen until the entry expires
+	_, err = db.Exec(`UPDATE urls SET original_url = ? WHERE short_code = ?`, "https://example.com/changed", "cached1")
+	require.NoError(t, err)
+	rec = redirect()
+	require.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://example.com/cached", rec.Header().Get("Location"))
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/urls/cached1", nil)
+	req.Header.Set("Authorization", "Bearer test-token")
+	rec = httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, redirect().Code)
+}
+
+func TestServer_RedirectAccessLogSampling(t *testing.T) {
+	cfg := testConfig()
+	cfg.LogRedirectSampleRate = 0
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	var buf bytes.Buffer
+	srv, err := New(cfg, db, logging.New("info", "json", logging.WithOutput(&buf)))
+	require.NoError(t, err)
+	defer srv.Shutdown(context.Background())
+
+	urlRepo := repository.NewSQLiteURLRepository(db)
+	require.NoError(t, urlRepo.Create(context.Background(), &url.URL{
+		ShortCode:   "sampled",
+		OriginalURL: "https://example.com/sampled",
+		CreatedBy:   "authenticated-user",
+		CreatedAt:   time.Now(),
+	}))
+
+	accessLogged := func(path string, wantStatus int) bool {
+		buf.Reset()
+		rec := httptest.NewRecorder()
+		srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, wantStatus, rec.Code)
+		return strings.Contains(buf.String(), `"message":"request completed"`)
+	}
+
+	assert.False(t, accessLogged("/sampled", http.StatusFound), "successful redirect should be sampled out")
+	assert.True(t, accessLogged("/missing", http.StatusNotFound), "404 should always be logged")
+	assert.True(t, accessLogged("/health", http.StatusOK), "non-redirect routes should not be sampled")
+}
+
 // TestServer_RedirectPreservesURL tests that redirects preserve the original URL intact
 func TestServer_RedirectPreservesURL(t *testing.T) {
 	tests := []struct {
@@ -337,3 +560,62 @@ func TestServer_RedirectVsAPIRoutes(t *testing.T) {
 		})
 	}
 }
+
+// TestServer_ShutdownFlushesClicks tests that Shutdown drains queued click records before returning
+func TestServer_ShutdownFlushesClicks(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+	}{
+		{name: "drains within deadline", timeout: 5 * time.Second},
+		{name: "expired deadline drops the rest", timeout: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.RedirectClickWorkers = 1
+			cfg.RedirectClickQueueSize = 50
+
+			db := setupTestDB(t)
+			defer db.Close()
+
+			srv, err := New(cfg, db, testLogger())
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			urlRepo := repository.NewSQLiteURLRepository(db)
+			require.NoError(t, urlRepo.Create(ctx, &url.URL{
+				ShortCode:   "flush123",
+				OriginalURL: "https://example.com/flush",
+				CreatedBy:   "test-user",
+				CreatedAt:   time.Now(),
+			}))
+			saved, err := urlRepo.FindByShortCode(ctx, "flush123")
+			require.NoError(t, err)
+
+			const redirects = 20
+			for i := 0; i < redirects; i++ {
+				rec := httptest.NewRecorder()
+				srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flush123", nil))
+				require.Equal(t, http.StatusFound, rec.Code)
+			}
+
+			shutdownCtx, cancel := context.WithTimeout(ctx, tt.timeout)
+			defer cancel()
+			_ = srv.Shutdown(shutdownCtx)
+
+			// No sleep: Shutdown must not return while clicks are still being written
+			recorded, err := repository.NewSQLiteClickRepository(db).GetTotalClickCount(ctx, saved.ID)
+			require.NoError(t, err)
+			dropped := testutil.ToFloat64(srv.Metrics().RedirectClickDroppedTotal)
+
+			if tt.timeout > 0 {
+				assert.Equal(t, int64(redirects), recorded)
+				assert.Zero(t, dropped)
+			} else {
+				assert.Equal(t, float64(redirects), float64(recorded)+dropped, "every click is either recorded or counted as dropped")
+			}
+		})
+	}
+}
@@ -3,26 +3,36 @@ package server
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/pprof"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/cors"
+	"github.com/matt-riley/mjrwtf"
+	adaptergeo "github.com/matt-riley/mjrwtf/internal/adapters/geolocation"
 	"github.com/matt-riley/mjrwtf/internal/adapters/notification"
 	"github.com/matt-riley/mjrwtf/internal/adapters/repository"
 	"github.com/matt-riley/mjrwtf/internal/application"
 	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/geolocation"
 	"github.com/matt-riley/mjrwtf/internal/domain/url"
 	"github.com/matt-riley/mjrwtf/internal/domain/urlstatus"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/config"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/handlers"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/middleware"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/http/openapi"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/logging"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/metrics"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/session"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/tailscale"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/tracing"
+	"github.com/matt-riley/mjrwtf/internal/migrations"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -36,6 +46,9 @@ const (
 
 	defaultRedirectRateLimitPerMinute = 120
 	defaultAPIRateLimitPerMinute      = 60
+
+	// redisConnectTimeout bounds the startup check that Redis is reachable
+	redisConnectTimeout = 5 * time.Second
 )
 
 // Server represents the HTTP server
@@ -47,11 +60,22 @@ type Server struct {
 	logger           zerolog.Logger
 	metrics          *metrics.Metrics
 	sessionStore     *session.Store
+	sessionCleaner   *session.Cleaner
 	rateLimiters     []*middleware.RateLimiterMiddleware
+	redisClient      *redis.Client // Shared rate limit store when RATE_LIMIT_BACKEND is redis
 	redirectUseCase  *application.RedirectURLUseCase
+	geoLookup        geolocation.LookupService
 	urlStatusChecker *application.URLStatusChecker
+	clickPurger      *application.ClickPurger
+	notifier         notification.Notifier
+	tracerProvider   trace.TracerProvider
+	tracer           trace.Tracer
+	shutdownTracing  func(context.Context) error // Flushes spans when the server created tracerProvider itself
+	closeAccessLog   func() error                // Closes the ACCESS_LOG_PATH file, when set
 	tailscaleServer  *tailscale.Server
 	tailscaleClient  middleware.WhoIsClient
+	apiValidator     func(http.Handler) http.Handler // OpenAPI request validation when VALIDATE_OPENAPI is set
+	buildInfo        BuildInfo
 
 	bgCtx    context.Context
 	bgCancel context.CancelFunc
@@ -74,39 +98,120 @@ func WithTailscaleServer(tsServer *tailscale.Server) ServerOption {
 	}
 }
 
+// WithTracerProvider traces requests and repository calls with tp instead of
+// the OTLP exporter configured by OTEL_ENABLED. The caller owns tp's lifecycle.
+func WithTracerProvider(tp trace.TracerProvider) ServerOption {
+	return func(s *Server) {
+		s.tracerProvider = tp
+	}
+}
+
+// BuildInfo identifies the running build, as reported by GET /version.
+// Empty fields are reported as "dev" (Version) or "unknown".
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// WithBuildInfo sets the build details served by GET /version.
+func WithBuildInfo(info BuildInfo) ServerOption {
+	return func(s *Server) {
+		s.buildInfo = info
+	}
+}
+
 // New creates a new HTTP server with configured middleware and dependencies
 // Returns an error if the server cannot be initialized properly
 func New(cfg *config.Config, db *sql.DB, logger zerolog.Logger, opts ...ServerOption) (*Server, error) {
 	r := chi.NewRouter()
 
+	server := &Server{
+		router: r,
+		config: cfg,
+		db:     db,
+		logger: logger,
+	}
+
+	// Apply functional options
+	for _, opt := range opts {
+		opt(server)
+	}
+
+	// Bring the schema up to date before anything reads it
+	if cfg.AutoMigrate {
+		if err := server.migrate(); err != nil {
+			return nil, err
+		}
+	}
+
 	// Initialize Prometheus metrics
 	m := metrics.New()
 
-	// Initialize Discord notifier for error notifications
-	var discordNotifier *notification.DiscordNotifier
-	if cfg.DiscordWebhookURL != "" {
-		discordNotifier = notification.NewDiscordNotifier(
-			cfg.DiscordWebhookURL,
-			notification.WithLogger(logger),
-		)
-		logger.Info().Msg("Discord error notifications enabled")
+	// Initialize the chat notifier (Discord or Slack, per NOTIFIER_KIND) for error notifications
+	notifier, err := notification.New(cfg.NotifierKind, cfg.NotifierWebhookURL(),
+		notification.WithLogger(logger),
+		notification.WithEvents(cfg.NotifyEvents...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notifier: %w", err)
+	}
+	if notification.Enabled(notifier) {
+		logger.Info().Str("kind", cfg.NotifierKind).Msg("error notifications enabled")
 	} else {
-		logger.Info().Msg("Discord error notifications disabled (no webhook URL configured)")
+		logger.Info().Msg("error notifications disabled (no webhook URL configured)")
 	}
 
-	// Middleware stack (order matters)
-	r.Use(middleware.RecoveryWithNotifier(logger, discordNotifier)) // Recover from panics first, with Discord notifications
-	r.Use(middleware.RequestID)                                     // Generate/propagate request ID
-	r.Use(middleware.SecurityHeaders(cfg.EnableHSTS))               // Set security headers
-	r.Use(middleware.InjectLogger(logger))                          // Inject logger with request context
-	r.Use(middleware.Logger)                                        // Log all requests
-	r.Use(middleware.PrometheusMetrics(m))                          // Record Prometheus metrics
+	// Initialize tracing (OTEL_ENABLED or WithTracerProvider)
+	if err := server.setupTracing(); err != nil {
+		return nil, err
+	}
 
-	// Initialize session store (24 hour session TTL)
-	sessionStore := session.NewStore(24 * time.Hour)
+	// Trace outside everything else, so spans cover the whole middleware stack
+	// and recovered panics show up as 500s
+	if server.tracer != nil {
+		r.Use(middleware.Tracing(server.tracer))
+	}
+
+	securityHeaders := middleware.SecurityHeadersConfig{
+		EnableHSTS:            cfg.EnableHSTS,
+		HSTSMaxAge:            cfg.HSTSMaxAge,
+		TrustProxyHeaders:     cfg.TrustProxyHeaders,
+		ContentSecurityPolicy: cfg.ContentSecurityPolicy,
+		FrameOptions:          cfg.FrameOptions,
+	}
+	maxRequestBody := int64(cfg.MaxRequestBodyBytes)
+
+	// Access logs go to the main logger unless ACCESS_LOG_PATH sends them elsewhere
+	accessLog := middleware.Logger
+	if cfg.AccessLogPath != "" {
+		sink, closeAccessLog, err := logging.NewAccessLogger(cfg.AccessLogPath)
+		if err != nil {
+			return nil, err
+		}
+		server.closeAccessLog = closeAccessLog
+		accessLog = middleware.AccessLog(sink)
+	}
+
+	// Middleware stack (order matters)
+	r.Use(middleware.RecoveryWithNotifier(logger, notifier))              // Recover from panics first, with chat notifications
+	r.Use(middleware.RequestID)                                           // Generate/propagate request ID
+	r.Use(middleware.ClientIP(cfg.TrustProxyHeaders, cfg.TrustedProxies)) // Resolve client IP (optionally from proxy headers)
+	r.Use(middleware.SecurityHeaders(securityHeaders))                    // Set security headers
+	r.Use(middleware.InjectLogger(logger))                                // Inject logger with request context
+	r.Use(accessLog)                                                      // Log all requests
+	r.Use(middleware.PrometheusMetrics(m))                                // Record Prometheus metrics
+	r.Use(middleware.MaxRequestBody(maxRequestBody))                      // Refuse oversized request bodies with 413
+
+	// Initialize session store (defaults to a 24 hour session TTL when unset)
+	sessionDuration := cfg.SessionDuration
+	if sessionDuration <= 0 {
+		sessionDuration = session.DefaultDuration
+	}
+	sessionStore := session.NewStore(sessionDuration)
 
 	// Add session middleware globally (checks for session, but doesn't require it)
-	r.Use(middleware.SessionMiddleware(sessionStore))
+	r.Use(middleware.SessionMiddleware(sessionStore, cfg.SessionIdleTimeout))
 
 	// Parse CORS allowed origins (supports comma-separated list)
 	origins := strings.Split(cfg.AllowedOrigins, ",")
@@ -114,40 +219,30 @@ func New(cfg *config.Config, db *sql.DB, logger zerolog.Logger, opts ...ServerOp
 		origins[i] = strings.TrimSpace(origins[i])
 	}
 
-	r.Use(cors.Handler(cors.Options{
+	r.Use(middleware.CORS(middleware.CORSConfig{
 		// Note: Using "*" for allowed origins is a security risk in production.
 		// Configure ALLOWED_ORIGINS environment variable to restrict access to known domains.
 		AllowedOrigins:   origins,
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
-		ExposedHeaders:   []string{"Link", "X-Request-ID"},
-		AllowCredentials: false,
+		AllowedMethods:   cfg.AllowedMethods,
+		AllowedHeaders:   cfg.AllowedHeaders,
+		ExposedHeaders:   []string{"ETag", "Link", "X-Request-ID"},
+		AllowCredentials: cfg.AllowCredentials,
 		MaxAge:           300,
 	}))
 
 	bgCtx, bgCancel := context.WithCancel(context.Background())
 
-	server := &Server{
-		router:       r,
-		config:       cfg,
-		db:           db,
-		logger:       logger,
-		metrics:      m,
-		sessionStore: sessionStore,
-		bgCtx:        bgCtx,
-		bgCancel:     bgCancel,
-		httpServer: &http.Server{
-			Addr:         fmt.Sprintf(":%d", cfg.ServerPort),
-			Handler:      r,
-			ReadTimeout:  readTimeout,
-			WriteTimeout: writeTimeout,
-			IdleTimeout:  idleTimeout,
-		},
-	}
-
-	// Apply functional options
-	for _, opt := range opts {
-		opt(server)
+	server.metrics = m
+	server.sessionStore = sessionStore
+	server.notifier = notifier
+	server.bgCtx = bgCtx
+	server.bgCancel = bgCancel
+	server.httpServer = &http.Server{
+		Addr:         fmt.Sprintf(":%d", cfg.ServerPort),
+		Handler:      r,
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+		IdleTimeout:  idleTimeout,
 	}
 
 	// If a Tailscale server is provided but no WhoIs client, create one.
@@ -167,15 +262,63 @@ func New(cfg *config.Config, db *sql.DB, logger zerolog.Logger, opts ...ServerOp
 		return nil, err
 	}
 
+	// Periodically prune expired sessions; stopped in Shutdown
+	server.sessionCleaner = session.NewCleaner(sessionStore, session.CleanerConfig{
+		Interval:    cfg.SessionCleanupInterval,
+		IdleTimeout: cfg.SessionIdleTimeout,
+	}, logger)
+	server.sessionCleaner.Start()
+
 	return server, nil
 }
 
+// setupTracing resolves the tracer for requests and repositories. Without
+// WithTracerProvider it exports to OTEL_EXPORTER_OTLP_ENDPOINT when OTEL_ENABLED
+// is set, and leaves tracing off otherwise.
+func (s *Server) setupTracing() error {
+	if s.tracerProvider == nil {
+		if !s.config.OTelEnabled {
+			return nil
+		}
+
+		exporter, err := tracing.NewOTLPExporter(context.Background(), s.config.OTelExporterEndpoint)
+		if err != nil {
+			return fmt.Errorf("failed to set up tracing: %w", err)
+		}
+
+		// Defensive default: server.New can be called with a manually-constructed config
+		serviceName := s.config.OTelServiceName
+		if serviceName == "" {
+			serviceName = "mjrwtf"
+		}
+
+		provider := tracing.NewProvider(exporter, serviceName)
+		s.tracerProvider = provider
+		s.shutdownTracing = provider.Shutdown
+		s.logger.Info().Str("endpoint", s.config.OTelExporterEndpoint).Str("service", serviceName).Msg("tracing enabled")
+	}
+
+	s.tracer = s.tracerProvider.Tracer(tracing.TracerName)
+	return nil
+}
+
 // setupRoutes configures the HTTP routes
 func (s *Server) setupRoutes() error {
 	redirectRateLimiter, apiRateLimiter := s.setupRateLimiters()
 
 	s.setupHealthRoutes()
+	s.setupCrawlerRoutes()
 	s.setupMetricsRoutes()
+	s.setupPprofRoutes()
+
+	if s.config.ValidateOpenAPI {
+		spec, err := openapi.Load(mjrwtf.OpenAPISpec)
+		if err != nil {
+			return fmt.Errorf("failed to load OpenAPI spec: %w", err)
+		}
+		s.apiValidator = openapi.Middleware(spec)
+		s.logger.Info().Msg("OpenAPI request validation enabled")
+	}
 
 	h, err := s.buildHandlers()
 	if err != nil {
@@ -188,7 +331,7 @@ func (s *Server) setupRoutes() error {
 	// Must come after specific routes to avoid capturing them
 	s.setupRedirectRoutes(h.redirectHandler, redirectRateLimiter)
 
-	s.setupAPIRoutes(h.urlHandler, h.analyticsHandler, apiRateLimiter)
+	s.setupAPIRoutes(h.urlHandler, h.analyticsHandler, h.statsHandler, h.sessionHandler, h.auditHandler, apiRateLimiter)
 
 	return nil
 }
@@ -198,6 +341,9 @@ type routeHandlers struct {
 	analyticsHandler *handlers.AnalyticsHandler
 	redirectHandler  *handlers.RedirectHandler
 	pageHandler      *handlers.PageHandler
+	sessionHandler   *handlers.SessionHandler
+	auditHandler     *handlers.AuditHandler
+	statsHandler     *handlers.StatsHandler
 }
 
 func (s *Server) setupRateLimiters() (*middleware.RateLimiterMiddleware, *middleware.RateLimiterMiddleware) {
@@ -213,14 +359,62 @@ func (s *Server) setupRateLimiters() (*middleware.RateLimiterMiddleware, *middle
 		apiRateLimit = defaultAPIRateLimitPerMinute
 	}
 
-	redirectRateLimiter := middleware.NewRateLimiterMiddleware(redirectRateLimit, time.Minute)
-	apiRateLimiter := middleware.NewRateLimiterMiddleware(apiRateLimit, time.Minute)
+	redirectOpts := []middleware.RateLimiterOption{middleware.WithRateLimitAllowlist(s.config.RateLimitAllowlist)}
+	apiOpts := []middleware.RateLimiterOption{middleware.WithRateLimitAllowlist(s.config.RateLimitAllowlist)}
+	if s.config.RateLimitBackend == "redis" {
+		if client := s.connectRedis(); client != nil {
+			s.redisClient = client
+			redirectOpts = append(redirectOpts, middleware.WithRedisRateLimit(client, "mjrwtf:ratelimit:redirect:", s.logger))
+			apiOpts = append(apiOpts, middleware.WithRedisRateLimit(client, "mjrwtf:ratelimit:api:", s.logger))
+		}
+	}
+
+	redirectRateLimiter := middleware.NewRateLimiterMiddleware(redirectRateLimit, time.Minute, redirectOpts...)
+	apiRateLimiter := middleware.NewRateLimiterMiddleware(apiRateLimit, time.Minute, apiOpts...)
 
 	s.rateLimiters = []*middleware.RateLimiterMiddleware{redirectRateLimiter, apiRateLimiter}
 
 	return redirectRateLimiter, apiRateLimiter
 }
 
+// migrate applies pending embedded migrations (AUTO_MIGRATE)
+func (s *Server) migrate() error {
+	versions, err := migrations.Up(context.Background(), s.db)
+	if err != nil {
+		return fmt.Errorf("auto-migrate failed: %w", err)
+	}
+
+	if len(versions) == 0 {
+		s.logger.Info().Msg("database schema is up to date")
+		return nil
+	}
+	s.logger.Info().Ints64("versions", versions).Msg("applied database migrations")
+	return nil
+}
+
+// connectRedis opens the client for REDIS_URL and checks Redis is reachable.
+// It returns nil, so rate limits stay in memory, when it isn't.
+func (s *Server) connectRedis() *redis.Client {
+	opts, err := redis.ParseURL(s.config.RedisURL)
+	if err != nil {
+		s.logger.Warn().Err(err).Msg("invalid REDIS_URL; falling back to in-memory rate limiting")
+		return nil
+	}
+
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisConnectTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		s.logger.Warn().Err(err).Msg("redis unreachable; falling back to in-memory rate limiting")
+		return nil
+	}
+
+	s.logger.Info().Str("addr", opts.Addr).Msg("rate limiting with redis")
+	return client
+}
+
 func (s *Server) setupHealthRoutes() {
 	// Health check endpoint (liveness)
 	// This is a lightweight check that does not validate external dependencies.
@@ -228,7 +422,22 @@ func (s *Server) setupHealthRoutes() {
 
 	// Readiness endpoint
 	// This validates required dependencies (e.g. database connectivity).
+	// /health/ready is an alias for probes that expect readiness under /health.
 	s.router.Get("/ready", s.readyCheckHandler)
+	s.router.Get("/health/ready", s.readyCheckHandler)
+
+	// Build information (public; holds nothing beyond what the binary was built from)
+	s.router.Get("/version", s.versionHandler)
+}
+
+// setupCrawlerRoutes answers the requests browsers and crawlers make on their
+// own, so they get a cheap response instead of a short code lookup and 404.
+func (s *Server) setupCrawlerRoutes() {
+	s.router.Get("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s.router.Get("/robots.txt", s.robotsTxtHandler)
 }
 
 func (s *Server) setupMetricsRoutes() {
@@ -245,6 +454,35 @@ func (s *Server) setupMetricsRoutes() {
 	s.router.Handle("/metrics", s.metrics.Handler())
 }
 
+// setupPprofRoutes serves the net/http/pprof handlers under /debug/pprof/
+// when PPROF_ENABLED is set. They are always behind the auth token, since
+// profiles expose command lines, memory contents and goroutine stacks.
+func (s *Server) setupPprofRoutes() {
+	if !s.config.PprofEnabled {
+		return
+	}
+
+	s.router.Route("/debug/pprof", func(r chi.Router) {
+		r.Use(middleware.Auth(s.config.ActiveAuthTokens()))
+		r.HandleFunc("/cmdline", pprof.Cmdline)
+		r.HandleFunc("/profile", withoutWriteDeadline(pprof.Profile))
+		r.HandleFunc("/symbol", pprof.Symbol)
+		r.HandleFunc("/trace", withoutWriteDeadline(pprof.Trace))
+		// Index serves the profile list and named profiles (heap, goroutine, ...)
+		r.HandleFunc("/*", pprof.Index)
+	})
+	s.logger.Warn().Msg("pprof enabled at /debug/pprof/")
+}
+
+// withoutWriteDeadline lifts the server's write timeout for h, so CPU profiles
+// and traces can run for longer than it (?seconds=30 is the default).
+func withoutWriteDeadline(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+		h(w, r)
+	}
+}
+
 func (s *Server) buildHandlers() (*routeHandlers, error) {
 	// Initialize repositories (SQLite only)
 	var urlRepo url.Repository = repository.NewSQLiteURLRepository(s.db)
@@ -262,23 +500,132 @@ func (s *Server) buildHandlers() (*routeHandlers, error) {
 	urlRepo = repository.NewURLRepositoryWithTimeout(urlRepo, dbTimeout)
 	clickRepo = repository.NewClickRepositoryWithTimeout(clickRepo, dbTimeout)
 
+	// Trace repository calls outside the timeout, so spans include time spent waiting on it
+	if s.tracer != nil {
+		urlRepo = repository.NewURLRepositoryWithTracing(urlRepo, s.tracer)
+		clickRepo = repository.NewClickRepositoryWithTracing(clickRepo, s.tracer)
+	}
+
+	// Serve repeat lookups of hot short codes from memory (URL_CACHE_SIZE); cache hits skip the database entirely
+	if s.config.URLCacheSize > 0 && s.config.URLCacheTTL > 0 {
+		urlRepo = repository.NewCachingURLRepository(urlRepo, s.config.URLCacheSize, s.config.URLCacheTTL)
+		s.logger.Info().Int("size", s.config.URLCacheSize).Dur("ttl", s.config.URLCacheTTL).Msg("short code lookup cache enabled")
+	}
+
+	// Initialize GeoIP lookup (no-op when disabled). A database that can't be
+	// opened only costs country data, so redirects keep working without it.
+	s.geoLookup = adaptergeo.NewNoopService()
+	if s.config.GeoIPEnabled {
+		geoLookup, err := adaptergeo.NewGeoIP2Service(s.config.GeoIPDatabase)
+		if err != nil {
+			s.logger.Warn().Err(err).Str("path", s.config.GeoIPDatabase).Msg("failed to open GeoIP database; clicks will be recorded without a country")
+		} else {
+			s.geoLookup = geoLookup
+		}
+	}
+
 	// Initialize URL generator
-	generator, err := url.NewGenerator(urlRepo, url.DefaultGeneratorConfig())
+	generatorConfig := url.DefaultGeneratorConfig()
+	generatorConfig.Observer = s.metrics
+	generatorConfig.ReservedCodes = s.config.ReservedCodes
+	generatorConfig.Alphabet = s.config.ShortCodeAlphabet
+	generatorConfig.AutoLengthDensity = s.config.ShortCodeAutoLengthDensity
+	generatorConfig.AutoLengthRefresh = s.config.ShortCodeAutoLengthRefresh
+	generatorConfig.AllowedSchemes = s.config.AllowedURLSchemes
+	generatorConfig.AllowedHosts = s.config.AllowedDestinationHosts
+	generatorConfig.BlockedHosts = s.config.BlockedDestinationHosts
+	generatorConfig.BlockPrivateDestinations = s.config.BlockPrivateDestinations
+	generatorConfig.ResolveTimeout = s.config.DestinationResolveTimeout
+	generatorConfig.StripFragments = s.config.StripURLFragments
+	generator, err := url.NewGenerator(urlRepo, generatorConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create URL generator: %w", err)
 	}
+	if generator.Alphabet() != url.DefaultAlphabet {
+		s.logger.Info().
+			Int("alphabet_size", len(generator.Alphabet())).
+			Float64("entropy_bits", generator.EntropyBits()).
+			Msg("generating short codes from a custom alphabet")
+	}
 
 	// Initialize use cases
-	createUseCase := application.NewCreateURLUseCase(generator, s.config.BaseURL)
+	// Defensive default, as for dbTimeout above
+	idempotencyKeyTTL := s.config.IdempotencyKeyTTL
+	if idempotencyKeyTTL <= 0 {
+		idempotencyKeyTTL = 24 * time.Hour
+	}
+	idempotencyRepo := repository.NewSQLiteIdempotencyRepository(s.db)
+	auditRepo := repository.NewSQLiteAuditRepository(s.db)
+	auditLogger := application.NewAuditLogger(auditRepo, s.logger)
+	createOpts := []application.CreateOption{
+		application.WithIdempotencyKeys(idempotencyRepo, idempotencyKeyTTL),
+		application.WithAuditLogger(auditLogger),
+	}
+	if s.config.DedupeDestinations {
+		createOpts = append(createOpts, application.WithDedupeDestinations(urlRepo))
+	}
+	createdNotifier, err := notification.New(s.config.NotifierKind, s.config.NotifierCreateWebhookURL(),
+		notification.WithLogger(s.logger),
+		notification.WithAsyncSend(true),
+		notification.WithEvents(s.config.NotifyEvents...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notifier: %w", err)
+	}
+	createdEnabled := s.config.NotifyEventEnabled(notification.EventCreated)
+	if createdEnabled && notification.Enabled(createdNotifier) {
+		createOpts = append(createOpts, application.WithCreatedNotifier(notification.NewURLCreatedAnnouncer(createdNotifier, s.config.NotifyCreateFullURL)))
+		s.logger.Info().Msg("created-URL notifications enabled")
+	}
+	if createdEnabled && s.config.EventWebhookURL != "" {
+		eventWebhook := notification.NewWebhookNotifier(s.config.EventWebhookURL, s.config.EventWebhookSecret,
+			notification.WithLogger(s.logger),
+			notification.WithAsyncSend(true),
+			notification.WithEvents(s.config.NotifyEvents...),
+		)
+		createOpts = append(createOpts, application.WithCreatedNotifier(eventWebhook))
+		s.logger.Info().Bool("signed", s.config.EventWebhookSecret != "").Msg("event webhook enabled")
+	}
+	createUseCase := application.NewCreateURLUseCase(generator, s.config.BaseURL, createOpts...)
 	listUseCase := application.NewListURLsUseCase(urlRepo, clickRepo)
-	deleteUseCase := application.NewDeleteURLUseCase(urlRepo)
-	getAnalyticsUseCase := application.NewGetAnalyticsUseCase(urlRepo, clickRepo)
+	countUseCase := application.NewCountURLsUseCase(urlRepo)
+	deleteOpts := []application.DeleteOption{application.WithDeleteAuditLogger(auditLogger)}
+	if s.config.SoftDeleteEnabled {
+		deleteOpts = append(deleteOpts, application.WithSoftDelete())
+	}
+	deleteUseCase := application.NewDeleteURLUseCase(urlRepo, deleteOpts...)
+	deleteByPrefixUseCase := application.NewDeleteURLsByPrefixUseCase(urlRepo, deleteOpts...)
+	restoreUseCase := application.NewRestoreURLUseCase(urlRepo)
+	listAuditUseCase := application.NewListAuditEntriesUseCase(auditRepo)
+	analyticsOpts := []application.GetAnalyticsOption{application.WithAnalyticsTopN(s.config.AnalyticsTopN)}
+	var visitorHashSalt string
+	if s.config.UniqueVisitorsEnabled {
+		analyticsOpts = append(analyticsOpts, application.WithUniqueVisitors())
+		visitorHashSalt = s.config.VisitorHashSalt
+	}
+	getAnalyticsUseCase := application.NewGetAnalyticsUseCase(urlRepo, clickRepo, analyticsOpts...)
+	statsSummaryUseCase := application.NewGetStatsSummaryUseCase(urlRepo, clickRepo)
+	getDailyClicksUseCase := application.NewGetDailyClicksUseCase(urlRepo, clickRepo)
+	listClicksUseCase := application.NewListClicksUseCase(urlRepo, clickRepo)
+	topURLsUseCase := application.NewGetTopURLsUseCase(clickRepo)
 	s.redirectUseCase = application.NewRedirectURLUseCaseWithOptions(urlRepo, clickRepo, application.RedirectURLOptions{
 		MaxWorkers: s.config.RedirectClickWorkers,
 		QueueSize:  s.config.RedirectClickQueueSize,
 		Logger:     &s.logger,
 		Metrics:    s.metrics,
 		StatusRepo: urlStatusRepo,
+
+		BackpressurePolicy: application.ClickBackpressurePolicy(s.config.RedirectClickBackpressurePolicy),
+		EnqueueTimeout:     s.config.RedirectClickEnqueueTimeout,
+
+		RecordAttempts:   s.config.RedirectClickRetryAttempts,
+		RecordRetryDelay: s.config.RedirectClickRetryDelay,
+
+		GeoResolver:     s.geoLookup,
+		VisitorHashSalt: visitorHashSalt,
+
+		ClickDedupeWindow: s.config.ClickDedupeWindow,
+		FilterBotClicks:   s.config.FilterBotClicks,
 	})
 
 	s.urlStatusChecker = application.NewURLStatusChecker(urlStatusRepo, application.URLStatusCheckerConfig{
@@ -292,17 +639,43 @@ func (s *Server) buildHandlers() (*routeHandlers, error) {
 		ArchiveRecheckInterval: s.config.URLStatusCheckerArchiveRecheckInterval,
 	}, s.logger)
 
+	// Expire old clicks in the background; a no-op unless CLICK_RETENTION_DAYS is set
+	s.clickPurger = application.NewClickPurger(clickRepo, application.ClickPurgerConfig{
+		RetentionDays: s.config.ClickRetentionDays,
+	}, s.logger)
+	if s.clickPurger.Enabled() {
+		s.logger.Info().Int("retention_days", s.config.ClickRetentionDays).Msg("click retention enabled")
+	}
+
 	// Initialize handlers
-	urlHandler := handlers.NewURLHandler(createUseCase, listUseCase, deleteUseCase)
-	analyticsHandler := handlers.NewAnalyticsHandler(getAnalyticsUseCase)
+	urlHandler := handlers.NewURLHandler(createUseCase, listUseCase, countUseCase, deleteUseCase, deleteByPrefixUseCase, restoreUseCase)
+	analyticsHandler := handlers.NewAnalyticsHandler(getAnalyticsUseCase, getDailyClicksUseCase, listClicksUseCase)
+	statsHandler := handlers.NewStatsHandler(statsSummaryUseCase, topURLsUseCase)
 	redirectHandler := handlers.NewRedirectHandler(s.redirectUseCase)
+	if s.config.NotFoundRedirectURL != "" {
+		redirectHandler.WithNotFoundRedirect(s.config.NotFoundRedirectURL)
+	}
+	if s.config.NotFoundAlertThreshold > 0 && s.config.NotifyEventEnabled(notification.EventNotFoundSpike) {
+		if notification.Enabled(s.notifier) {
+			detector := notification.NewNotFoundSpikeDetector(s.notifier, s.config.NotFoundAlertThreshold, s.config.NotFoundAlertWindow)
+			redirectHandler.WithNotFoundHook(detector.Record)
+			s.logger.Info().Int("threshold", s.config.NotFoundAlertThreshold).Dur("window", s.config.NotFoundAlertWindow).Msg("404 spike alerts enabled")
+		} else {
+			s.logger.Warn().Msg("NOT_FOUND_ALERT_THRESHOLD is set but no notifier webhook URL is configured; 404 spike alerts disabled")
+		}
+	}
 	pageHandler := handlers.NewPageHandler(createUseCase, listUseCase, s.config.ActiveAuthTokens(), s.sessionStore, s.config.SecureCookies)
+	sessionHandler := handlers.NewSessionHandler(s.sessionStore, s.config.SecureCookies)
+	auditHandler := handlers.NewAuditHandler(listAuditUseCase)
 
 	return &routeHandlers{
 		urlHandler:       urlHandler,
 		analyticsHandler: analyticsHandler,
 		redirectHandler:  redirectHandler,
 		pageHandler:      pageHandler,
+		sessionHandler:   sessionHandler,
+		auditHandler:     auditHandler,
+		statsHandler:     statsHandler,
 	}, nil
 }
 
@@ -328,13 +701,20 @@ func (s *Server) setupPageRoutes(pageHandler *handlers.PageHandler) {
 }
 
 func (s *Server) setupRedirectRoutes(redirectHandler *handlers.RedirectHandler, redirectRateLimiter *middleware.RateLimiterMiddleware) {
-	s.router.With(redirectRateLimiter.Middleware).Get("/{shortCode}", redirectHandler.Redirect)
+	mws := []func(http.Handler) http.Handler{redirectRateLimiter.Middleware}
+	if rate := s.config.LogRedirectSampleRate; rate < 1 {
+		mws = append(mws, middleware.SampleAccessLog(middleware.RateSampler(rate)))
+	}
+	s.router.With(mws...).Get("/{shortCode}", redirectHandler.Redirect)
 }
 
-func (s *Server) setupAPIRoutes(urlHandler *handlers.URLHandler, analyticsHandler *handlers.AnalyticsHandler, apiRateLimiter *middleware.RateLimiterMiddleware) {
+func (s *Server) setupAPIRoutes(urlHandler *handlers.URLHandler, analyticsHandler *handlers.AnalyticsHandler, statsHandler *handlers.StatsHandler, sessionHandler *handlers.SessionHandler, auditHandler *handlers.AuditHandler, apiRateLimiter *middleware.RateLimiterMiddleware) {
 	// API routes with authentication
 	s.router.Route("/api", func(r chi.Router) {
 		r.Use(apiRateLimiter.Middleware)
+		if s.apiValidator != nil {
+			r.Use(s.apiValidator)
+		}
 
 		r.Route("/urls", func(r chi.Router) {
 			// Apply auth middleware based on mode (presence of Tailscale server is the source of truth)
@@ -348,9 +728,47 @@ func (s *Server) setupAPIRoutes(urlHandler *handlers.URLHandler, analyticsHandle
 
 			r.Post("/", urlHandler.Create)
 			r.Get("/", urlHandler.List)
+			r.Get("/count", urlHandler.Count)
+			r.Delete("/", urlHandler.DeleteByPrefix)
 			r.Delete("/{shortCode}", urlHandler.Delete)
+			r.Post("/{shortCode}/restore", urlHandler.Restore)
 			r.Get("/{shortCode}/analytics", analyticsHandler.GetAnalytics)
+			r.Get("/{shortCode}/analytics/daily", analyticsHandler.GetDailyClicks)
+			r.Get("/{shortCode}/clicks", analyticsHandler.ListClicks)
 		})
+
+		// Stats across all of the caller's URLs, authenticated as for /urls
+		r.Route("/stats", func(r chi.Router) {
+			if s.tailscaleServer != nil {
+				r.Use(middleware.TailscaleAuth(s.tailscaleClient, s.logger))
+			} else {
+				r.Use(middleware.SessionOrBearerAuth(s.config.ActiveAuthTokens()))
+			}
+
+			r.Get("/summary", statsHandler.Summary)
+			r.Get("/top", statsHandler.Top)
+		})
+
+		// Audit trail - an admin view gated by the auth token, so it accepts neither dashboard
+		// sessions nor Tailscale identities, which would let any user read everyone's entries
+		if s.tailscaleServer == nil {
+			r.Route("/audit", func(r chi.Router) {
+				r.Use(middleware.Auth(s.config.ActiveAuthTokens()))
+
+				r.Get("/", auditHandler.List)
+			})
+		}
+
+		// Session management - sessions only exist in standard auth mode (login page)
+		if s.tailscaleServer == nil {
+			r.Route("/auth/sessions", func(r chi.Router) {
+				r.Use(middleware.SessionOrBearerAuth(s.config.ActiveAuthTokens()))
+
+				r.Get("/", sessionHandler.List)
+				r.Delete("/", sessionHandler.RevokeAll)
+				r.Delete("/{id}", sessionHandler.Revoke)
+			})
+		}
 	})
 }
 
@@ -361,6 +779,41 @@ func (s *Server) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte(`{"status":"ok"}`))
 }
 
+// robotsTxtHandler serves the configured robots.txt
+func (s *Server) robotsTxtHandler(w http.ResponseWriter, r *http.Request) {
+	// A config built by hand (e.g. in tests) skips LoadConfig's default
+	body := s.config.RobotsTxt
+	if body == "" {
+		body = config.DefaultRobotsTxt
+	}
+	if !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(body))
+}
+
+// versionHandler reports which build is running
+func (s *Server) versionHandler(w http.ResponseWriter, r *http.Request) {
+	info := s.buildInfo
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = "unknown"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(info)
+}
+
 // readyCheckHandler returns readiness status and validates external dependencies.
 func (s *Server) readyCheckHandler(w http.ResponseWriter, r *http.Request) {
 	timeout := s.config.DBTimeout
@@ -394,6 +847,9 @@ func (s *Server) Start() error {
 	if s.urlStatusChecker != nil {
 		s.urlStatusChecker.Start(s.bgCtx)
 	}
+	if s.clickPurger != nil {
+		s.clickPurger.Start(s.bgCtx)
+	}
 
 	s.logger.Info().Str("addr", s.httpServer.Addr).Msg("starting HTTP server")
 	return s.httpServer.ListenAndServe()
@@ -409,23 +865,57 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	if s.urlStatusChecker != nil {
 		s.urlStatusChecker.Shutdown()
 	}
+	if s.clickPurger != nil {
+		s.clickPurger.Shutdown()
+	}
 
-	// Shutdown session store cleanup goroutine
-	if s.sessionStore != nil {
-		s.sessionStore.Shutdown()
+	// Shutdown session cleanup goroutine
+	if s.sessionCleaner != nil {
+		s.sessionCleaner.Shutdown()
 	}
 
-	// Shutdown redirect use case workers
+	// Stop accepting requests and wait for in-flight ones first, so every
+	// redirect served has queued its click before the queue is drained
+	httpErr := s.httpServer.Shutdown(ctx)
+
+	// Flush queued clicks within whatever is left of ctx
 	if s.redirectUseCase != nil {
-		s.redirectUseCase.Shutdown()
+		if dropped := s.redirectUseCase.ShutdownContext(ctx); dropped > 0 {
+			s.logger.Warn().Err(ctx.Err()).Int("dropped", dropped).Msg("click queue did not drain before shutdown deadline; dropped pending clicks")
+		}
 	}
 
 	for _, limiter := range s.rateLimiters {
 		limiter.Shutdown()
 	}
+	if s.redisClient != nil {
+		if err := s.redisClient.Close(); err != nil {
+			s.logger.Warn().Err(err).Msg("failed to close redis client")
+		}
+	}
+
+	// Release the GeoIP database once click workers have drained
+	if s.geoLookup != nil {
+		if err := s.geoLookup.Close(); err != nil {
+			s.logger.Warn().Err(err).Msg("failed to close GeoIP database")
+		}
+	}
+
+	if s.closeAccessLog != nil {
+		if err := s.closeAccessLog(); err != nil {
+			s.logger.Warn().Err(err).Msg("failed to close access log")
+		}
+	}
+
+	// Flush spans last, so those from drained click writes are exported too
+	if s.shutdownTracing != nil {
+		if err := s.shutdownTracing(ctx); err != nil {
+			s.logger.Warn().Err(err).Msg("failed to flush traces")
+		}
+	}
 
-	if err := s.httpServer.Shutdown(ctx); err != nil {
-		return fmt.Errorf("failed to shutdown server: %w", err)
+	if httpErr != nil {
+		return fmt.Errorf("failed to shutdown server: %w", httpErr)
 	}
 
 	s.logger.Info().Msg("HTTP server stopped")
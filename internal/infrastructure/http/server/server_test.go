@@ -1,17 +1,27 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/matt-riley/mjrwtf/internal/adapters/repository"
+	"github.com/matt-riley/mjrwtf/internal/domain/click"
+	"github.com/matt-riley/mjrwtf/internal/domain/url"
 	"github.com/matt-riley/mjrwtf/internal/infrastructure/config"
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/logging"
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // testLogger returns a disabled logger for tests
@@ -74,6 +84,63 @@ func TestHealthCheckHandler(t *testing.T) {
 	}
 }
 
+func TestVersionHandler(t *testing.T) {
+	tests := []struct {
+		name string
+		info BuildInfo
+		want BuildInfo
+	}{
+		{
+			name: "defaults when ldflags are unset",
+			want: BuildInfo{Version: "dev", Commit: "unknown", BuildTime: "unknown"},
+		},
+		{
+			name: "build info from options",
+			info: BuildInfo{Version: "v1.4.0", Commit: "abc1234", BuildTime: "2026-01-02T03:04:05Z"},
+			want: BuildInfo{Version: "v1.4.0", Commit: "abc1234", BuildTime: "2026-01-02T03:04:05Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			defer db.Close()
+
+			srv, err := New(testConfig(), db, testLogger(), WithBuildInfo(tt.info))
+			if err != nil {
+				t.Fatalf("failed to create server: %v", err)
+			}
+
+			// No Authorization header: the endpoint is public
+			req := httptest.NewRequest(http.MethodGet, "/version", nil)
+			rec := httptest.NewRecorder()
+
+			srv.router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+			}
+			if contentType := rec.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("expected Content-Type application/json, got %s", contentType)
+			}
+
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			want := map[string]string{"version": tt.want.Version, "commit": tt.want.Commit, "build_time": tt.want.BuildTime}
+			if len(body) != len(want) {
+				t.Errorf("expected exactly the fields %v, got %v", want, body)
+			}
+			for k, v := range want {
+				if body[k] != v {
+					t.Errorf("expected %s %q, got %q", k, v, body[k])
+				}
+			}
+		})
+	}
+}
+
 func TestReadyCheckHandler_Ready(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -233,6 +300,41 @@ func TestServer_GracefulShutdown(t *testing.T) {
 	}
 }
 
+func TestServer_ClickRetention_PurgesUntilShutdown(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	cfg.ServerPort = 0
+	cfg.ClickRetentionDays = 30
+
+	srv, err := New(cfg, db, testLogger())
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	urlRepo := repository.NewSQLiteURLRepository(db)
+	clickRepo := repository.NewSQLiteClickRepository(db)
+	u := &url.URL{ShortCode: "purge1", OriginalURL: "https://example.com", CreatedBy: "authenticated-user", CreatedAt: time.Now()}
+	require.NoError(t, urlRepo.Create(ctx, u))
+	for _, age := range []time.Duration{60 * 24 * time.Hour, time.Hour} {
+		c, _ := click.NewClick(u.ID, "", "", "")
+		c.ClickedAt = time.Now().Add(-age)
+		require.NoError(t, clickRepo.Record(ctx, c))
+	}
+
+	go func() { _ = srv.Start() }()
+
+	// The purger runs once on startup, removing only the click outside the window
+	require.Eventually(t, func() bool {
+		n, err := clickRepo.GetTotalClickCount(ctx, u.ID)
+		return err == nil && n == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	require.NoError(t, srv.Shutdown(shutdownCtx))
+}
+
 func TestServer_Timeouts(t *testing.T) {
 	db := setupTestDB(t)
 	defer db.Close()
@@ -349,6 +451,9 @@ func TestMetricsEndpoint(t *testing.T) {
 		"mjrwtf_http_requests_total",
 		"mjrwtf_http_request_duration_seconds",
 		"mjrwtf_urls_active_total",
+		"mjrwtf_redirect_click_queue_depth",
+		"mjrwtf_redirect_click_dropped_total",
+		"mjrwtf_redirect_click_record_failures_total",
 		"go_goroutines",
 		"go_gc_duration_seconds",
 	}
@@ -482,6 +587,74 @@ func TestMetricsEndpoint_WithAuthDisabled(t *testing.T) {
 	}
 }
 
+func TestPprofEndpoints_Disabled(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(testConfig(), db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/heap", "/debug/pprof/cmdline"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer test-token")
+		rec := httptest.NewRecorder()
+
+		srv.router.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s: expected status %d with PPROF_ENABLED off, got %d", path, http.StatusNotFound, rec.Code)
+		}
+	}
+}
+
+func TestPprofEndpoints_Enabled(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	cfg.PprofEnabled = true
+
+	srv, err := New(cfg, db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		authHeader     string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"index without auth header", "/debug/pprof/", "", http.StatusUnauthorized, ""},
+		{"profile with invalid auth token", "/debug/pprof/heap", "Bearer invalid-token", http.StatusUnauthorized, ""},
+		{"index with valid auth token", "/debug/pprof/", "Bearer test-token", http.StatusOK, "goroutine"},
+		{"named profile with valid auth token", "/debug/pprof/goroutine?debug=1", "Bearer test-token", http.StatusOK, "goroutine profile"},
+		{"cmdline with valid auth token", "/debug/pprof/cmdline", "Bearer test-token", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+
+			srv.router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if tt.expectedBody != "" && !strings.Contains(rec.Body.String(), tt.expectedBody) {
+				t.Errorf("expected body to contain %q, got %q", tt.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
 func ExampleServer_Start() {
 	cfg := &config.Config{
 		ServerPort:     8080,
@@ -512,6 +685,51 @@ func ExampleServer_Start() {
 	srv.Shutdown(ctx)
 }
 
+func TestHealthReadyEndpoint(t *testing.T) {
+	tests := []struct {
+		name       string
+		closeDB    bool
+		path       string
+		wantStatus int
+		wantBody   string
+	}{
+		{"ready healthy db", false, "/health/ready", http.StatusOK, `{"status":"ready"}`},
+		{"ready closed db", true, "/health/ready", http.StatusServiceUnavailable, `{"status":"unavailable"}`},
+		{"liveness healthy db", false, "/health", http.StatusOK, `{"status":"ok"}`},
+		{"liveness closed db", true, "/health", http.StatusOK, `{"status":"ok"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			defer db.Close()
+
+			srv, err := New(testConfig(), db, testLogger())
+			if err != nil {
+				t.Fatalf("failed to create server: %v", err)
+			}
+			if tt.closeDB {
+				db.Close()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			srv.router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("expected response %s, got %s", tt.wantBody, rec.Body.String())
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected Content-Type application/json, got %s", ct)
+			}
+		})
+	}
+}
+
 func TestReadyCheckHandler_UnavailableWhenDBNil(t *testing.T) {
 	cfg := testConfig()
 
@@ -622,7 +840,8 @@ func TestServer_SecurityHeaders_HSTS_Enabled(t *testing.T) {
 		t.Fatalf("failed to create server: %v", err)
 	}
 
-	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	// HSTS is only sent over HTTPS; an https target gives the request TLS state
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/health", nil)
 	rec := httptest.NewRecorder()
 
 	srv.router.ServeHTTP(rec, req)
@@ -658,3 +877,83 @@ func TestServer_SecurityHeaders_HSTS_Disabled(t *testing.T) {
 		t.Errorf("expected no HSTS header when disabled, got %q", hsts)
 	}
 }
+
+func TestServer_AccessLogPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	cfg := testConfig()
+	cfg.AccessLogPath = path
+
+	db := setupTestDB(t)
+	defer db.Close()
+
+	var appBuf bytes.Buffer
+	srv, err := New(cfg, db, logging.New("info", "json", logging.WithOutput(&appBuf)))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("X-Request-ID", "access-log-test")
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, srv.Shutdown(context.Background()))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 1, string(b))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry), lines[0])
+	assert.Equal(t, "request completed", entry["message"])
+	assert.Equal(t, "access-log-test", entry["request_id"])
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "/health", entry["path"])
+	assert.EqualValues(t, http.StatusOK, entry["status"])
+	assert.Contains(t, entry, "duration")
+	assert.Contains(t, entry, "time")
+
+	// App logs stay on the main logger
+	assert.NotContains(t, appBuf.String(), "request completed")
+}
+
+func TestServer_CrawlerRoutes(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	srv, err := New(cfg, db, testLogger())
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/favicon.ico", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/robots.txt", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, config.DefaultRobotsTxt, rec.Body.String())
+
+	// Draining the click queue on shutdown makes any recorded click visible
+	require.NoError(t, srv.Shutdown(context.Background()))
+	var clicks int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM clicks`).Scan(&clicks))
+	assert.Zero(t, clicks)
+}
+
+func TestServer_RobotsTxtFromConfig(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	cfg.RobotsTxt = "User-agent: *\nAllow: /"
+	srv, err := New(cfg, db, testLogger())
+	require.NoError(t, err)
+	defer srv.Shutdown(context.Background())
+
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/robots.txt", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "User-agent: *\nAllow: /\n", rec.Body.String())
+}
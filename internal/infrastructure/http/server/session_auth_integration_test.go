@@ -96,6 +96,31 @@ func TestSessionBasedAPIAuthentication(t *testing.T) {
 		}
 	})
 
+	// Test 3b: Session management routes accept session cookies and reject anonymous callers
+	t.Run("list sessions route", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil)
+		req.AddCookie(&http.Cookie{
+			Name:  middleware.SessionCookieName,
+			Value: sess.ID,
+		})
+
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if !strings.Contains(w.Body.String(), `"current":true`) {
+			t.Errorf("expected the cookie's session to be listed as current: %s", w.Body.String())
+		}
+
+		anon := httptest.NewRecorder()
+		server.router.ServeHTTP(anon, httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil))
+		if anon.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401 without credentials, got %d", anon.Code)
+		}
+	})
+
 	// Test 4: Delete URL with session cookie
 	t.Run("delete URL with session cookie", func(t *testing.T) {
 		// First, create a URL to delete using Bearer token
@@ -135,7 +160,6 @@ func TestSessionBasedAPIAuthentication(t *testing.T) {
 	t.Run("expired session is rejected", func(t *testing.T) {
 		// Create a session with very short TTL
 		shortTTLStore := session.NewStore(1 * time.Millisecond)
-		defer shortTTLStore.Shutdown()
 
 		expiredSess, err := shortTTLStore.Create("test-user")
 		if err != nil {
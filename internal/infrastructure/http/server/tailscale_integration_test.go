@@ -349,6 +349,44 @@ func TestTailscaleMode_LoginRouteHidden(t *testing.T) {
 	}
 }
 
+// TestTailscaleMode_AuditTrailHidden verifies that one tailnet identity can't
+// read another's audit trail, since the audit route is not mounted in Tailscale mode.
+func TestTailscaleMode_AuditTrailHidden(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	cfg := testConfig()
+	cfg.TailscaleEnabled = true
+	tsServer := &tailscale.Server{}
+
+	mockClient := &mockWhoIsClientForIntegration{
+		Profile: &middleware.TailscaleUserProfile{LoginName: "alice@example.com"},
+	}
+
+	srv, err := New(cfg, db, testLogger(), WithTailscaleServer(tsServer), WithTailscaleClient(mockClient))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", strings.NewReader(`{"original_url":"https://example.com","short_code":"alices"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected alice's create to return 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	mockClient.Profile = &middleware.TailscaleUserProfile{LoginName: "bob@example.com"}
+	rec = httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/audit?short_code=alices", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for bob reading alice's audit trail, got %d", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "alice@example.com") {
+		t.Errorf("audit response leaked alice's identity: %s", rec.Body.String())
+	}
+}
+
 // TestTailscaleMode_UserIdentityInContext verifies that the Tailscale user
 // identity is properly propagated to handlers.
 func TestTailscaleMode_UserIdentityInContext(t *testing.T) {
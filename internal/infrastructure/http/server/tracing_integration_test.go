@@ -0,0 +1,106 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/matt-riley/mjrwtf/internal/infrastructure/tracing"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing_CreateURLProducesSpans(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	srv, err := New(testConfig(), db, testLogger(), WithTracerProvider(provider))
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	defer srv.Shutdown(context.Background())
+
+	const destination = "https://example.com/private/path?token=secret"
+	req := httptest.NewRequest(http.MethodPost, "/api/urls", bytes.NewBufferString(`{"original_url":"`+destination+`","short_code":"traced1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer test-token")
+	rec := httptest.NewRecorder()
+	srv.router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+
+	spans := exporter.GetSpans()
+	byName := map[string]tracetest.SpanStub{}
+	for _, span := range spans {
+		byName[span.Name] = span
+		for _, attr := range span.Attributes {
+			if strings.Contains(attr.Value.Emit(), "example.com") {
+				t.Errorf("span %q attribute %s leaks the destination URL", span.Name, attr.Key)
+			}
+		}
+	}
+
+	httpSpan, ok := byName["POST /api/urls"]
+	if !ok {
+		t.Fatalf("expected a POST /api/urls span, got %d spans: %v", len(spans), spanNames(spans))
+	}
+	if httpSpan.SpanKind != trace.SpanKindServer {
+		t.Errorf("request span kind = %v, want server", httpSpan.SpanKind)
+	}
+	if got := spanAttribute(httpSpan, "http.response.status_code"); got != "201" {
+		t.Errorf("request span status code = %q, want 201", got)
+	}
+
+	createSpan, ok := byName["URLRepository.Create"]
+	if !ok {
+		t.Fatalf("expected a URLRepository.Create span, got: %v", spanNames(spans))
+	}
+	if got := spanAttribute(createSpan, string(tracing.ShortCodeKey)); got != "traced1" {
+		t.Errorf("create span short code = %q, want traced1", got)
+	}
+	if createSpan.SpanContext.TraceID() != httpSpan.SpanContext.TraceID() {
+		t.Error("expected the repository span to belong to the request's trace")
+	}
+}
+
+func TestTracing_DisabledByDefault(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	srv, err := New(testConfig(), db, testLogger())
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	defer srv.Shutdown(context.Background())
+
+	if srv.tracer != nil {
+		t.Error("expected no tracer without OTEL_ENABLED or WithTracerProvider")
+	}
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+func spanAttribute(span tracetest.SpanStub, key string) string {
+	for _, attr := range span.Attributes {
+		if string(attr.Key) == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
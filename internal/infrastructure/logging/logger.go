@@ -2,6 +2,7 @@ package logging
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"time"
@@ -66,6 +67,22 @@ func New(level, format string, opts ...Option) zerolog.Logger {
 		Logger()
 }
 
+// NewAccessLogger returns a JSON logger for access log lines written to path,
+// or to stdout when path is "-". The file is created if missing and opened in
+// append mode, so it can be rotated with copytruncate. The returned func closes
+// the file; it is a no-op for stdout.
+func NewAccessLogger(path string) (zerolog.Logger, func() error, error) {
+	if path == "-" {
+		return zerolog.New(os.Stdout).With().Timestamp().Logger(), func() error { return nil }, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return zerolog.Nop(), nil, fmt.Errorf("open access log: %w", err)
+	}
+	return zerolog.New(f).With().Timestamp().Logger(), f.Close, nil
+}
+
 // WithLogger adds a logger to the context.
 func WithLogger(ctx context.Context, logger zerolog.Logger) context.Context {
 	return context.WithValue(ctx, LoggerKey, logger)
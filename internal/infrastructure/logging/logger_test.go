@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -131,3 +134,41 @@ func TestGetRequestID_ReturnsEmptyStringWhenNotSet(t *testing.T) {
 		t.Errorf("expected empty string, got %q", requestID)
 	}
 }
+
+func TestNewAccessLogger_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+	if err := os.WriteFile(path, []byte("{\"existing\":true}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	logger, closeFn, err := NewAccessLogger(path)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	logger.Info().Str("path", "/abc").Msg("request completed")
+	if err := closeFn(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected the existing line to be kept and one appended, got:\n%s", b)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &entry); err != nil {
+		t.Fatalf("expected a JSON line, got %q", lines[1])
+	}
+	if entry["path"] != "/abc" || entry["time"] == nil {
+		t.Errorf("unexpected entry %v", entry)
+	}
+}
+
+func TestNewAccessLogger_InvalidPath(t *testing.T) {
+	if _, _, err := NewAccessLogger(filepath.Join(t.TempDir(), "missing", "access.log")); err == nil {
+		t.Error("expected an error for a path in a missing directory")
+	}
+}
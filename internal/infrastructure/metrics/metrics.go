@@ -29,6 +29,11 @@ type Metrics struct {
 	RedirectClickQueueDepth          prometheus.Gauge
 	RedirectClickDroppedTotal        prometheus.Counter
 	RedirectClickRecordFailuresTotal prometheus.Counter
+
+	// Short code generator metrics
+	ShortCodesGeneratedTotal         prometheus.Counter
+	ShortCodeCollisionsTotal         prometheus.Counter
+	ShortCodeMaxRetriesExceededTotal prometheus.Counter
 }
 
 // New creates and registers all Prometheus metrics with a new registry
@@ -99,6 +104,30 @@ func New() *Metrics {
 		},
 	)
 
+	shortCodesGeneratedTotal := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "short_codes_generated_total",
+			Help:      "Total number of candidate short codes generated (including ones that collided)",
+		},
+	)
+
+	shortCodeCollisionsTotal := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "short_code_collisions_total",
+			Help:      "Total number of generated short codes that collided with an existing code",
+		},
+	)
+
+	shortCodeMaxRetriesExceededTotal := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "short_code_max_retries_exceeded_total",
+			Help:      "Total number of short code generations that failed after exhausting collision retries",
+		},
+	)
+
 	// Register all custom metrics
 	registry.MustRegister(httpRequestsTotal)
 	registry.MustRegister(httpRequestDuration)
@@ -107,6 +136,9 @@ func New() *Metrics {
 	registry.MustRegister(redirectClickQueueDepth)
 	registry.MustRegister(redirectClickDroppedTotal)
 	registry.MustRegister(redirectClickRecordFailuresTotal)
+	registry.MustRegister(shortCodesGeneratedTotal)
+	registry.MustRegister(shortCodeCollisionsTotal)
+	registry.MustRegister(shortCodeMaxRetriesExceededTotal)
 
 	return &Metrics{
 		Registry:                         registry,
@@ -117,6 +149,9 @@ func New() *Metrics {
 		RedirectClickQueueDepth:          redirectClickQueueDepth,
 		RedirectClickDroppedTotal:        redirectClickDroppedTotal,
 		RedirectClickRecordFailuresTotal: redirectClickRecordFailuresTotal,
+		ShortCodesGeneratedTotal:         shortCodesGeneratedTotal,
+		ShortCodeCollisionsTotal:         shortCodeCollisionsTotal,
+		ShortCodeMaxRetriesExceededTotal: shortCodeMaxRetriesExceededTotal,
 	}
 }
 
@@ -156,3 +191,19 @@ func (m *Metrics) IncrementActiveURLs() {
 func (m *Metrics) DecrementActiveURLs() {
 	m.URLsActiveTotal.Dec()
 }
+
+// RecordShortCodeGenerated increments the generated short codes counter.
+func (m *Metrics) RecordShortCodeGenerated() {
+	m.ShortCodesGeneratedTotal.Inc()
+}
+
+// RecordShortCodeCollision increments the short code collisions counter.
+// A rising collision rate suggests the generator's code length should be increased.
+func (m *Metrics) RecordShortCodeCollision() {
+	m.ShortCodeCollisionsTotal.Inc()
+}
+
+// RecordShortCodeMaxRetriesExceeded increments the counter of generations that ran out of retries.
+func (m *Metrics) RecordShortCodeMaxRetriesExceeded() {
+	m.ShortCodeMaxRetriesExceededTotal.Inc()
+}
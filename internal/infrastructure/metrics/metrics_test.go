@@ -35,6 +35,15 @@ func TestNew_CreatesMetrics(t *testing.T) {
 	if m.RedirectClickRecordFailuresTotal == nil {
 		t.Error("expected RedirectClickRecordFailuresTotal to be initialized")
 	}
+	if m.ShortCodesGeneratedTotal == nil {
+		t.Error("expected ShortCodesGeneratedTotal to be initialized")
+	}
+	if m.ShortCodeCollisionsTotal == nil {
+		t.Error("expected ShortCodeCollisionsTotal to be initialized")
+	}
+	if m.ShortCodeMaxRetriesExceededTotal == nil {
+		t.Error("expected ShortCodeMaxRetriesExceededTotal to be initialized")
+	}
 }
 
 func TestMetrics_RecordHTTPRequest(t *testing.T) {
@@ -86,6 +95,27 @@ func TestMetrics_IncrementDecrementActiveURLs(t *testing.T) {
 	}
 }
 
+func TestMetrics_ShortCodeGenerator(t *testing.T) {
+	m := New()
+
+	m.RecordShortCodeGenerated()
+	m.RecordShortCodeGenerated()
+	m.RecordShortCodeGenerated()
+	m.RecordShortCodeCollision()
+	m.RecordShortCodeCollision()
+	m.RecordShortCodeMaxRetriesExceeded()
+
+	if got := testutil.ToFloat64(m.ShortCodesGeneratedTotal); got != 3 {
+		t.Errorf("expected 3 generated short codes, got %f", got)
+	}
+	if got := testutil.ToFloat64(m.ShortCodeCollisionsTotal); got != 2 {
+		t.Errorf("expected 2 collisions, got %f", got)
+	}
+	if got := testutil.ToFloat64(m.ShortCodeMaxRetriesExceededTotal); got != 1 {
+		t.Errorf("expected 1 max retries exceeded, got %f", got)
+	}
+}
+
 func TestMetrics_Handler(t *testing.T) {
 	m := New()
 
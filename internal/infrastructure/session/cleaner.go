@@ -0,0 +1,116 @@
+package session
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultCleanupInterval is how often the Cleaner prunes sessions when no interval is configured
+const DefaultCleanupInterval = 15 * time.Minute
+
+// CleanupStore is the subset of session storage used by Cleaner
+type CleanupStore interface {
+	DeleteExpired(now time.Time) int
+	DeleteIdle(now time.Time, idleTimeout time.Duration) int
+}
+
+// CleanerConfig configures a Cleaner
+type CleanerConfig struct {
+	// Interval between cleanup passes (default: DefaultCleanupInterval)
+	Interval time.Duration
+	// IdleTimeout prunes sessions without activity for this long; non-positive disables idle pruning
+	IdleTimeout time.Duration
+}
+
+// CleanerOption is a functional option for configuring a Cleaner
+type CleanerOption func(*Cleaner)
+
+// WithCleanerNow overrides the clock used to evaluate expiry and idleness
+func WithCleanerNow(now func() time.Time) CleanerOption {
+	return func(c *Cleaner) {
+		if now != nil {
+			c.now = now
+		}
+	}
+}
+
+// Cleaner periodically removes expired and idle sessions from a store
+type Cleaner struct {
+	store  CleanupStore
+	cfg    CleanerConfig
+	logger zerolog.Logger
+	now    func() time.Time
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewCleaner creates a Cleaner for the given store. Call Start to begin pruning.
+func NewCleaner(store CleanupStore, cfg CleanerConfig, logger zerolog.Logger, opts ...CleanerOption) *Cleaner {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultCleanupInterval
+	}
+
+	c := &Cleaner{
+		store:  store,
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+		stopCh: make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+
+	return c
+}
+
+// Start launches the background cleanup goroutine. Subsequent calls are no-ops.
+func (c *Cleaner) Start() {
+	c.startOnce.Do(func() {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+
+			ticker := time.NewTicker(c.cfg.Interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-c.stopCh:
+					return
+				case <-ticker.C:
+					c.RunOnce()
+				}
+			}
+		}()
+	})
+}
+
+// Shutdown stops the cleanup goroutine and waits for an in-flight pass to finish
+func (c *Cleaner) Shutdown() {
+	c.stopOnce.Do(func() { close(c.stopCh) })
+	c.wg.Wait()
+}
+
+// RunOnce performs a single cleanup pass and returns the number of expired and idle sessions removed
+func (c *Cleaner) RunOnce() (expired, idle int) {
+	now := c.now()
+	expired = c.store.DeleteExpired(now)
+	idle = c.store.DeleteIdle(now, c.cfg.IdleTimeout)
+
+	event := c.logger.Debug()
+	if expired > 0 || idle > 0 {
+		event = c.logger.Info()
+	}
+	event.Int("expired", expired).Int("idle", idle).Msg("session cleanup completed")
+
+	return expired, idle
+}
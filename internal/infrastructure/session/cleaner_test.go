@@ -0,0 +1,132 @@
+package session
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type fakeCleanupStore struct {
+	mu           sync.Mutex
+	expiredCalls []time.Time
+	idleCalls    []time.Duration
+	expired      int
+	idle         int
+	called       chan struct{}
+}
+
+func newFakeCleanupStore() *fakeCleanupStore {
+	return &fakeCleanupStore{called: make(chan struct{}, 16)}
+}
+
+func (f *fakeCleanupStore) DeleteExpired(now time.Time) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.expiredCalls = append(f.expiredCalls, now)
+	return f.expired
+}
+
+func (f *fakeCleanupStore) DeleteIdle(now time.Time, idleTimeout time.Duration) int {
+	f.mu.Lock()
+	f.idleCalls = append(f.idleCalls, idleTimeout)
+	f.mu.Unlock()
+
+	select {
+	case f.called <- struct{}{}:
+	default:
+	}
+	return f.idle
+}
+
+func (f *fakeCleanupStore) counts() (expired, idle int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.expiredCalls), len(f.idleCalls)
+}
+
+func TestCleaner_InvokesBothCleanupMethods(t *testing.T) {
+	store := newFakeCleanupStore()
+	fixedNow := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	cleaner := NewCleaner(store, CleanerConfig{
+		Interval:    5 * time.Millisecond,
+		IdleTimeout: 30 * time.Minute,
+	}, zerolog.Nop(), WithCleanerNow(func() time.Time { return fixedNow }))
+	cleaner.Start()
+	defer cleaner.Shutdown()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-store.called:
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for cleanup pass %d", i+1)
+		}
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if len(store.expiredCalls) < 2 {
+		t.Fatalf("expected DeleteExpired to be called at least twice, got %d", len(store.expiredCalls))
+	}
+	if !store.expiredCalls[0].Equal(fixedNow) {
+		t.Errorf("DeleteExpired called with now = %v, want %v", store.expiredCalls[0], fixedNow)
+	}
+	if store.idleCalls[0] != 30*time.Minute {
+		t.Errorf("DeleteIdle called with idleTimeout = %v, want %v", store.idleCalls[0], 30*time.Minute)
+	}
+}
+
+func TestCleaner_ShutdownStopsGoroutine(t *testing.T) {
+	store := newFakeCleanupStore()
+	cleaner := NewCleaner(store, CleanerConfig{Interval: time.Millisecond}, zerolog.Nop())
+	cleaner.Start()
+
+	select {
+	case <-store.called:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for first cleanup pass")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		cleaner.Shutdown()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown did not return")
+	}
+
+	expired, idle := store.counts()
+	time.Sleep(20 * time.Millisecond)
+	gotExpired, gotIdle := store.counts()
+	if gotExpired != expired || gotIdle != idle {
+		t.Errorf("cleanup ran after Shutdown: expired calls %d -> %d, idle calls %d -> %d", expired, gotExpired, idle, gotIdle)
+	}
+
+	// Shutdown is idempotent
+	cleaner.Shutdown()
+}
+
+func TestCleaner_DefaultInterval(t *testing.T) {
+	cleaner := NewCleaner(newFakeCleanupStore(), CleanerConfig{}, zerolog.Nop())
+	if cleaner.cfg.Interval != DefaultCleanupInterval {
+		t.Errorf("expected default interval %v, got %v", DefaultCleanupInterval, cleaner.cfg.Interval)
+	}
+}
+
+func TestCleaner_RunOnceReturnsCounts(t *testing.T) {
+	store := newFakeCleanupStore()
+	store.expired = 3
+	store.idle = 2
+
+	cleaner := NewCleaner(store, CleanerConfig{}, zerolog.Nop())
+	expired, idle := cleaner.RunOnce()
+	if expired != 3 || idle != 2 {
+		t.Errorf("RunOnce() = (%d, %d), want (3, 2)", expired, idle)
+	}
+}
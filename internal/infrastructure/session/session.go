@@ -3,21 +3,54 @@ package session
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
 const (
-	// CleanupInterval is how often expired sessions are removed from the store
-	CleanupInterval = 1 * time.Hour
+	// DefaultDuration is how long a session stays valid after its last activity
+	DefaultDuration = 24 * time.Hour
+	// DefaultIdleTimeout disables the separate idle cutoff; sessions only end at ExpiresAt
+	DefaultIdleTimeout time.Duration = 0
 )
 
+// IDPrefixLength is the number of session ID characters exposed when listing sessions.
+// Full session IDs are bearer credentials and are never returned by the API.
+const IDPrefixLength = 12
+
 // Session represents a user session
 type Session struct {
-	ID        string
-	UserID    string
-	CreatedAt time.Time
-	ExpiresAt time.Time
+	ID             string
+	UserID         string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+	LastActivityAt time.Time
+	IPAddress      string
+	UserAgent      string
+}
+
+// IDPrefix returns the redacted form of the session ID used to identify it in API responses
+func (s *Session) IDPrefix() string {
+	if len(s.ID) <= IDPrefixLength {
+		return s.ID
+	}
+	return s.ID[:IDPrefixLength]
+}
+
+// IsExpired reports whether the session has passed its expiry time
+func (s *Session) IsExpired(now time.Time) bool {
+	return now.After(s.ExpiresAt)
+}
+
+// IsIdle reports whether the session has seen no activity for longer than idleTimeout.
+// A non-positive idleTimeout disables the idle check.
+func (s *Session) IsIdle(now time.Time, idleTimeout time.Duration) bool {
+	if idleTimeout <= 0 {
+		return false
+	}
+	return now.Sub(s.LastActivityAt) > idleTimeout
 }
 
 // Store manages user sessions
@@ -25,26 +58,30 @@ type Store struct {
 	sessions map[string]*Session
 	mu       sync.RWMutex
 	ttl      time.Duration
-	done     chan struct{}
-	once     sync.Once
 }
 
-// NewStore creates a new session store with the given TTL
+// NewStore creates a new session store with the given TTL.
+// Expired sessions are rejected on lookup; use a Cleaner to evict them from memory.
 func NewStore(ttl time.Duration) *Store {
-	store := &Store{
+	return &Store{
 		sessions: make(map[string]*Session),
 		ttl:      ttl,
-		done:     make(chan struct{}),
 	}
+}
 
-	// Start background cleanup goroutine
-	go store.cleanup()
-
-	return store
+// TTL returns how long sessions stay valid after creation or last activity
+func (s *Store) TTL() time.Duration {
+	return s.ttl
 }
 
 // Create creates a new session for the given user ID
 func (s *Store) Create(userID string) (*Session, error) {
+	return s.CreateForClient(userID, "", "")
+}
+
+// CreateForClient creates a new session for the given user ID, recording the
+// client IP address and user agent it was created from
+func (s *Store) CreateForClient(userID, ipAddress, userAgent string) (*Session, error) {
 	sessionID, err := generateSessionID()
 	if err != nil {
 		return nil, err
@@ -52,10 +89,13 @@ func (s *Store) Create(userID string) (*Session, error) {
 
 	now := time.Now()
 	session := &Session{
-		ID:        sessionID,
-		UserID:    userID,
-		CreatedAt: now,
-		ExpiresAt: now.Add(s.ttl),
+		ID:             sessionID,
+		UserID:         userID,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(s.ttl),
+		LastActivityAt: now,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
 	}
 
 	s.mu.Lock()
@@ -78,7 +118,7 @@ func (s *Store) Get(sessionID string) (*Session, bool) {
 	}
 
 	// Check if session is expired
-	if time.Now().After(session.ExpiresAt) {
+	if session.IsExpired(time.Now()) {
 		return nil, false
 	}
 
@@ -87,6 +127,57 @@ func (s *Store) Get(sessionID string) (*Session, bool) {
 	return &sessionCopy, true
 }
 
+// FindByUserID returns the user's unexpired sessions, most recently active first
+func (s *Store) FindByUserID(userID string) []*Session {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	out := make([]*Session, 0)
+	for _, session := range s.sessions {
+		if session.UserID != userID || session.IsExpired(now) {
+			continue
+		}
+		sessionCopy := *session
+		out = append(out, &sessionCopy)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].LastActivityAt.After(out[j].LastActivityAt)
+	})
+	return out
+}
+
+// FindByIDPrefix retrieves an unexpired session whose ID starts with prefix.
+// The prefix must be at least IDPrefixLength characters and match exactly one session.
+func (s *Store) FindByIDPrefix(prefix string) (*Session, bool) {
+	if len(prefix) < IDPrefixLength {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	var found *Session
+	for id, session := range s.sessions {
+		if !strings.HasPrefix(id, prefix) || session.IsExpired(now) {
+			continue
+		}
+		if found != nil {
+			// Ambiguous prefix; refuse to guess which session was meant
+			return nil, false
+		}
+		found = session
+	}
+	if found == nil {
+		return nil, false
+	}
+
+	sessionCopy := *found
+	return &sessionCopy, true
+}
+
 // Delete removes a session by ID
 func (s *Store) Delete(sessionID string) {
 	s.mu.Lock()
@@ -94,7 +185,23 @@ func (s *Store) Delete(sessionID string) {
 	s.mu.Unlock()
 }
 
-// Refresh extends the expiration time of a session
+// DeleteByUserID removes every session belonging to the given user ID.
+// Returns the number of sessions removed.
+func (s *Store) DeleteByUserID(userID string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, session := range s.sessions {
+		if session.UserID == userID {
+			delete(s.sessions, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Refresh extends the expiration time of a session and records activity
 func (s *Store) Refresh(sessionID string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -104,37 +211,46 @@ func (s *Store) Refresh(sessionID string) error {
 		return nil
 	}
 
-	session.ExpiresAt = time.Now().Add(s.ttl)
+	now := time.Now()
+	session.ExpiresAt = now.Add(s.ttl)
+	session.LastActivityAt = now
 	return nil
 }
 
-// cleanup removes expired sessions periodically
-func (s *Store) cleanup() {
-	ticker := time.NewTicker(CleanupInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			s.mu.Lock()
-			now := time.Now()
-			for id, session := range s.sessions {
-				if now.After(session.ExpiresAt) {
-					delete(s.sessions, id)
-				}
-			}
-			s.mu.Unlock()
-		case <-s.done:
-			return
+// DeleteExpired removes all sessions that have expired as of now.
+// Returns the number of sessions removed.
+func (s *Store) DeleteExpired(now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, session := range s.sessions {
+		if session.IsExpired(now) {
+			delete(s.sessions, id)
+			removed++
 		}
 	}
+	return removed
 }
 
-// Shutdown stops the cleanup goroutine
-func (s *Store) Shutdown() {
-	s.once.Do(func() {
-		close(s.done)
-	})
+// DeleteIdle removes all sessions with no activity for longer than idleTimeout.
+// A non-positive idleTimeout removes nothing. Returns the number of sessions removed.
+func (s *Store) DeleteIdle(now time.Time, idleTimeout time.Duration) int {
+	if idleTimeout <= 0 {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	removed := 0
+	for id, session := range s.sessions {
+		if session.IsIdle(now, idleTimeout) {
+			delete(s.sessions, id)
+			removed++
+		}
+	}
+	return removed
 }
 
 // generateSessionID generates a cryptographically secure random session ID
@@ -7,7 +7,6 @@ import (
 
 func TestSession_Create(t *testing.T) {
 	store := NewStore(24 * time.Hour)
-	defer store.Shutdown()
 
 	session, err := store.Create("user123")
 	if err != nil {
@@ -29,7 +28,6 @@ func TestSession_Create(t *testing.T) {
 
 func TestSession_Get(t *testing.T) {
 	store := NewStore(24 * time.Hour)
-	defer store.Shutdown()
 
 	created, err := store.Create("user456")
 	if err != nil {
@@ -52,7 +50,6 @@ func TestSession_Get(t *testing.T) {
 
 func TestSession_Get_NotFound(t *testing.T) {
 	store := NewStore(24 * time.Hour)
-	defer store.Shutdown()
 
 	_, exists := store.Get("nonexistent")
 	if exists {
@@ -62,7 +59,6 @@ func TestSession_Get_NotFound(t *testing.T) {
 
 func TestSession_Get_Expired(t *testing.T) {
 	store := NewStore(1 * time.Millisecond)
-	defer store.Shutdown()
 
 	created, err := store.Create("user789")
 	if err != nil {
@@ -80,7 +76,6 @@ func TestSession_Get_Expired(t *testing.T) {
 
 func TestSession_Delete(t *testing.T) {
 	store := NewStore(24 * time.Hour)
-	defer store.Shutdown()
 
 	created, err := store.Create("user999")
 	if err != nil {
@@ -105,7 +100,6 @@ func TestSession_Delete(t *testing.T) {
 
 func TestSession_Refresh(t *testing.T) {
 	store := NewStore(1 * time.Second)
-	defer store.Shutdown()
 
 	created, err := store.Create("user111")
 	if err != nil {
@@ -153,3 +147,157 @@ func TestGenerateSessionID(t *testing.T) {
 		t.Error("expected non-empty session ID")
 	}
 }
+
+func TestStore_DeleteExpired(t *testing.T) {
+	store := NewStore(time.Hour)
+
+	live, err := store.Create("live")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	removed := store.DeleteExpired(time.Now())
+	if removed != 0 {
+		t.Errorf("expected 0 sessions removed, got %d", removed)
+	}
+
+	removed = store.DeleteExpired(time.Now().Add(2 * time.Hour))
+	if removed != 1 {
+		t.Errorf("expected 1 session removed, got %d", removed)
+	}
+
+	store.mu.RLock()
+	_, exists := store.sessions[live.ID]
+	store.mu.RUnlock()
+	if exists {
+		t.Error("expected expired session to be evicted from the store")
+	}
+}
+
+func TestStore_DeleteIdle(t *testing.T) {
+	store := NewStore(24 * time.Hour)
+
+	if _, err := store.Create("user"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	later := time.Now().Add(time.Hour)
+
+	if removed := store.DeleteIdle(later, 0); removed != 0 {
+		t.Errorf("expected non-positive idle timeout to remove nothing, got %d", removed)
+	}
+	if removed := store.DeleteIdle(later, 2*time.Hour); removed != 0 {
+		t.Errorf("expected 0 sessions removed within idle timeout, got %d", removed)
+	}
+	if removed := store.DeleteIdle(later, 30*time.Minute); removed != 1 {
+		t.Errorf("expected 1 idle session removed, got %d", removed)
+	}
+}
+
+func TestStore_FindByUserID(t *testing.T) {
+	store := NewStore(time.Hour)
+
+	first, err := store.CreateForClient("alice", "203.0.113.1", "Firefox")
+	if err != nil {
+		t.Fatalf("CreateForClient() error = %v", err)
+	}
+	second, err := store.CreateForClient("alice", "203.0.113.2", "curl/8.0")
+	if err != nil {
+		t.Fatalf("CreateForClient() error = %v", err)
+	}
+	if _, err := store.Create("bob"); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Make the first session the most recently active
+	time.Sleep(2 * time.Millisecond)
+	if err := store.Refresh(first.ID); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	sessions := store.FindByUserID("alice")
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	if sessions[0].ID != first.ID || sessions[1].ID != second.ID {
+		t.Error("expected sessions ordered by most recent activity")
+	}
+	if sessions[0].IPAddress != "203.0.113.1" || sessions[0].UserAgent != "Firefox" {
+		t.Errorf("expected client metadata to be recorded, got %+v", sessions[0])
+	}
+}
+
+func TestStore_FindByIDPrefix(t *testing.T) {
+	store := NewStore(time.Hour)
+
+	created, err := store.Create("alice")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, found := store.FindByIDPrefix(created.IDPrefix()); !found {
+		t.Error("expected session to be found by its ID prefix")
+	}
+	if _, found := store.FindByIDPrefix(created.ID); !found {
+		t.Error("expected session to be found by its full ID")
+	}
+	if _, found := store.FindByIDPrefix(created.ID[:IDPrefixLength-1]); found {
+		t.Error("expected prefixes shorter than IDPrefixLength to be rejected")
+	}
+}
+
+func TestStore_DeleteByUserID(t *testing.T) {
+	store := NewStore(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := store.Create("alice"); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	bob, err := store.Create("bob")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if removed := store.DeleteByUserID("alice"); removed != 2 {
+		t.Errorf("expected 2 sessions removed, got %d", removed)
+	}
+	if _, exists := store.Get(bob.ID); !exists {
+		t.Error("expected other users' sessions to remain")
+	}
+	if removed := store.DeleteByUserID("alice"); removed != 0 {
+		t.Errorf("expected 0 sessions removed on second call, got %d", removed)
+	}
+}
+
+func TestStore_CreateUsesConfiguredDuration(t *testing.T) {
+	duration := 90 * time.Minute
+	store := NewStore(duration)
+
+	if store.TTL() != duration {
+		t.Errorf("expected TTL %v, got %v", duration, store.TTL())
+	}
+
+	sess, err := store.Create("user")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got := sess.ExpiresAt.Sub(sess.CreatedAt); got != duration {
+		t.Errorf("expected ExpiresAt to be CreatedAt+%v, got +%v", duration, got)
+	}
+}
+
+func TestSession_IsIdle(t *testing.T) {
+	now := time.Now()
+	sess := &Session{LastActivityAt: now.Add(-time.Hour)}
+
+	if sess.IsIdle(now, 0) {
+		t.Error("expected a zero idle timeout to disable the idle check")
+	}
+	if sess.IsIdle(now, 2*time.Hour) {
+		t.Error("expected session within the idle timeout to not be idle")
+	}
+	if !sess.IsIdle(now, 30*time.Minute) {
+		t.Error("expected session past the idle timeout to be idle")
+	}
+}
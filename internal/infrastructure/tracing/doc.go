@@ -0,0 +1,2 @@
+// Package tracing sets up OpenTelemetry tracing and exports spans over OTLP/HTTP.
+package tracing
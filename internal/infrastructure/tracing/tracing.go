@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TracerName names the tracer used for every span the server creates
+const TracerName = "github.com/matt-riley/mjrwtf"
+
+// Attribute keys set on spans. Destination URLs are never recorded, since
+// they can carry tokens or other private data in their path or query.
+const (
+	ShortCodeKey       = attribute.Key("mjrwtf.short_code")
+	ShortCodePrefixKey = attribute.Key("mjrwtf.short_code_prefix")
+	URLIDKey           = attribute.Key("mjrwtf.url_id")
+)
+
+// ShortCode returns the span attribute for a short code
+func ShortCode(code string) attribute.KeyValue {
+	return ShortCodeKey.String(code)
+}
+
+// NewOTLPExporter returns an exporter sending spans to an OTLP/HTTP collector.
+// endpoint is the collector's base URL (e.g. http://localhost:4318), as for
+// OTEL_EXPORTER_OTLP_ENDPOINT; the /v1/traces path is appended.
+func NewOTLPExporter(ctx context.Context, endpoint string) (sdktrace.SpanExporter, error) {
+	u, err := neturl.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q", endpoint)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/v1/traces"
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(u.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// NewProvider returns a tracer provider that batches spans to exporter and
+// reports them as serviceName. Call Shutdown on it to flush pending spans.
+func NewProvider(exporter sdktrace.SpanExporter, serviceName string) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+}
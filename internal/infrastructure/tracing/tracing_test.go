@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewOTLPExporter_PostsToTracesPath(t *testing.T) {
+	paths := make(chan string, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case paths <- r.URL.Path:
+		default:
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	ctx := context.Background()
+	exporter, err := NewOTLPExporter(ctx, collector.URL+"/")
+	if err != nil {
+		t.Fatalf("NewOTLPExporter() error = %v", err)
+	}
+
+	provider := NewProvider(exporter, "mjrwtf-test")
+	_, span := provider.Tracer(TracerName).Start(ctx, "test")
+	span.End()
+	if err := provider.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	select {
+	case path := <-paths:
+		if path != "/v1/traces" {
+			t.Errorf("collector got path %q, want /v1/traces", path)
+		}
+	default:
+		t.Fatal("expected the span to be exported on shutdown")
+	}
+}
+
+func TestNewOTLPExporter_InvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"", "localhost:4318", "://bad"} {
+		if _, err := NewOTLPExporter(context.Background(), endpoint); err == nil {
+			t.Errorf("NewOTLPExporter(%q) = nil error, want an error", endpoint)
+		}
+	}
+}
@@ -1,2 +1,3 @@
-// Package migrations embeds database migration files for use by the migrate CLI and tests.
+// Package migrations embeds database migration files for use by the migrate CLI,
+// AUTO_MIGRATE on server startup, and tests.
 package migrations
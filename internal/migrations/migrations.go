@@ -1,7 +1,13 @@
 package migrations
 
 import (
+	"context"
+	"database/sql"
 	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/pressly/goose/v3"
 )
 
 // SQLiteMigrations contains embedded SQLite migration files.
@@ -13,3 +19,29 @@ const (
 	// SQLiteDir is the directory path for SQLite migrations.
 	SQLiteDir = "sqlite"
 )
+
+// Up applies every pending embedded SQLite migration to db and returns the
+// versions it applied, oldest first. It records versions in the same table as
+// cmd/migrate, so the two can be mixed freely.
+func Up(ctx context.Context, db *sql.DB) ([]int64, error) {
+	fsys, err := fs.Sub(SQLiteMigrations, SQLiteDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open embedded migrations: %w", err)
+	}
+
+	provider, err := goose.NewProvider(goose.DialectSQLite3, db, fsys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration provider: %w", err)
+	}
+
+	results, err := provider.Up(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	versions := make([]int64, 0, len(results))
+	for _, result := range results {
+		versions = append(versions, result.Source.Version)
+	}
+	return versions, nil
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add visitor_hash column holding a salted hash of the client IP (never the raw IP)
+-- This enables unique-visitor estimates via COUNT(DISTINCT visitor_hash)
+ALTER TABLE clicks ADD COLUMN visitor_hash VARCHAR(64);
+
+-- Create index for efficient per-URL distinct visitor counts
+CREATE INDEX IF NOT EXISTS idx_clicks_url_id_visitor_hash ON clicks(url_id, visitor_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop the index first
+DROP INDEX IF EXISTS idx_clicks_url_id_visitor_hash;
+
+-- Drop the visitor_hash column
+ALTER TABLE clicks DROP COLUMN visitor_hash;
+-- +goose StatementEnd
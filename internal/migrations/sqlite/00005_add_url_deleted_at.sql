@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Add deleted_at column for soft deletes; NULL means the URL is live
+-- Soft-deleted URLs keep their clicks so analytics survive a restore
+ALTER TABLE urls ADD COLUMN deleted_at TIMESTAMP;
+
+-- Create index for efficient filtering of live URLs per user
+CREATE INDEX IF NOT EXISTS idx_urls_created_by_deleted_at ON urls(created_by, deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Drop the index first
+DROP INDEX IF EXISTS idx_urls_created_by_deleted_at;
+
+-- Drop the deleted_at column
+ALTER TABLE urls DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Maps an Idempotency-Key sent with POST /api/urls to the URL it created,
+-- so retries of the same request return the original result.
+-- Keys are scoped per creator; rows older than the TTL are pruned on write.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    created_by VARCHAR(255) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    original_url TEXT NOT NULL,
+    short_code VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (created_by, idempotency_key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Audit trail of URL creations and deletions: who did what to which short code, and when.
+-- Rows are not tied to urls by a foreign key so they outlive the URLs they describe.
+CREATE TABLE IF NOT EXISTS audit (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+
+    -- Action taken: "create" or "delete"
+    action VARCHAR(32) NOT NULL,
+
+    -- Short code acted on
+    short_code VARCHAR(255) NOT NULL,
+
+    -- Identity that performed the action
+    user_id VARCHAR(255) NOT NULL,
+
+    -- Client IP of the request (empty when unknown)
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_short_code ON audit(short_code);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_audit_short_code;
+DROP TABLE IF EXISTS audit;
+-- +goose StatementEnd
@@ -1,9 +1,6 @@
 package tui
 
 import (
-	"context"
-	"fmt"
-	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -16,24 +13,19 @@ type getAnalyticsMsg struct {
 	err  error
 }
 
-func getAnalyticsCmd(cfg tui_config.Config, shortCode string, startTime, endTime *time.Time) tea.Cmd {
+func getAnalyticsCmd(cfg tui_config.Config, shortCode string, startTime, endTime *time.Time, granularity string) tea.Cmd {
 	return func() tea.Msg {
-		base := strings.TrimSpace(cfg.BaseURL)
-		if base == "" {
-			return getAnalyticsMsg{err: fmt.Errorf("base URL not set")}
-		}
-
-		c, err := client.New(base, client.WithToken(cfg.Token), client.WithTimeout(5*time.Second))
+		c, err := newAPIClient(cfg)
 		if err != nil {
 			return getAnalyticsMsg{err: err}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+		ctx, cancel := requestContext(cfg)
 		defer cancel()
 
-		resp, err := c.GetAnalytics(ctx, shortCode, startTime, endTime)
+		resp, err := c.GetAnalytics(ctx, shortCode, startTime, endTime, granularity)
 		if err != nil {
-			return getAnalyticsMsg{err: err}
+			return getAnalyticsMsg{err: requestError(err)}
 		}
 		return getAnalyticsMsg{resp: resp}
 	}
@@ -1,12 +1,16 @@
 package tui
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/client"
 	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
 )
 
@@ -32,11 +36,11 @@ func TestGetAnalyticsCmd(t *testing.T) {
 			return
 		}
 		w.Header().Set("Content-Type", "application/json")
-		_, _ = io.WriteString(w, `{"short_code":"abc123","original_url":"https://example.com","total_clicks":3,"by_country":{"US":2},"by_referrer":{"direct":3},"start_time":"2025-11-20T00:00:00Z","end_time":"2025-11-22T23:59:59Z"}`)
+		_, _ = io.WriteString(w, `{"short_code":"abc123","original_url":"https://example.com","total_clicks":3,"by_country":{"US":2},"by_referrer":{"direct":3},"by_browser":{"Chrome":2,"Bot":1},"by_os":{"Windows":2,"Other":1},"start_time":"2025-11-20T00:00:00Z","end_time":"2025-11-22T23:59:59Z"}`)
 	}))
 	t.Cleanup(srv.Close)
 
-	msg := getAnalyticsCmd(tui_config.Config{BaseURL: srv.URL, Token: "t"}, "abc123", &start, &end)().(getAnalyticsMsg)
+	msg := getAnalyticsCmd(tui_config.Config{BaseURL: srv.URL, Token: "t"}, "abc123", &start, &end, "")().(getAnalyticsMsg)
 	if msg.err != nil {
 		t.Fatalf("expected nil err, got %v", msg.err)
 	}
@@ -49,4 +53,116 @@ func TestGetAnalyticsCmd(t *testing.T) {
 	if msg.resp.TotalClicks != 3 {
 		t.Fatalf("TotalClicks=%d", msg.resp.TotalClicks)
 	}
+	if msg.resp.ByBrowser["Chrome"] != 2 || msg.resp.ByOS["Windows"] != 2 {
+		t.Fatalf("ByBrowser=%v ByOS=%v", msg.resp.ByBrowser, msg.resp.ByOS)
+	}
+}
+
+func TestFormatDateMapSection_BarsProportionalToCounts(t *testing.T) {
+	in := map[string]int64{
+		"2025-11-20": 10,
+		"2025-11-21": 5,
+		"2025-11-22": 1,
+	}
+
+	lines := formatDateMapSection("By date", in, 0, 20)
+
+	bars := map[string]int{}
+	for _, line := range lines {
+		for date := range in {
+			if strings.Contains(line, date) {
+				bars[date] = strings.Count(line, "█")
+			}
+		}
+	}
+
+	if bars["2025-11-20"] != 20 {
+		t.Fatalf("busiest day bar=%d, want full width 20", bars["2025-11-20"])
+	}
+	if bars["2025-11-21"] != 10 {
+		t.Fatalf("half-count day bar=%d, want 10", bars["2025-11-21"])
+	}
+	if bars["2025-11-22"] != 2 {
+		t.Fatalf("low-count day bar=%d, want 2", bars["2025-11-22"])
+	}
+
+	for _, line := range lines {
+		if strings.Contains(line, "2025-11-20") && !strings.Contains(line, "10") {
+			t.Fatalf("expected numeric count to remain in row, got %q", line)
+		}
+	}
+}
+
+func TestFormatDateMapSection_NarrowWidthTruncatesBars(t *testing.T) {
+	in := map[string]int64{"2025-11-20": 100, "2025-11-21": 1}
+
+	lines := formatDateMapSection("By date", in, 0, 0)
+	for _, line := range lines {
+		if strings.Contains(line, "█") {
+			t.Fatalf("expected no bars at zero width, got %q", line)
+		}
+	}
+
+	m := newModel(tui_config.Config{}, nil)
+	m.width = 30
+	m.analytics = &client.GetAnalyticsResponse{ShortCode: "abc", ByDate: in}
+	for _, line := range m.analyticsLines() {
+		if strings.Contains(line, "2025-11-20") && strings.Count(line, "█") > 0 {
+			t.Fatalf("expected bars to be truncated on narrow terminal, got %q", line)
+		}
+	}
+}
+
+func TestAnalyticsLines_UniqueVisitorsOnlyWhenReported(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.analyticsShortCode = "abc123"
+	m.analytics = &client.GetAnalyticsResponse{ShortCode: "abc123", TotalClicks: 5}
+
+	if view := strings.Join(m.analyticsLines(), "\n"); strings.Contains(view, "Unique visitors") {
+		t.Fatalf("expected no unique visitors line when not reported:\n%s", view)
+	}
+
+	unique := int64(3)
+	m.analytics.UniqueVisitors = &unique
+	if view := strings.Join(m.analyticsLines(), "\n"); !strings.Contains(view, "Unique visitors:") || !strings.Contains(view, "3") {
+		t.Fatalf("expected unique visitors line:\n%s", view)
+	}
+}
+
+func TestModel_Update_ResizeClampsAnalyticsScroll(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.mode = modeViewingAnalytics
+	m.analyticsShortCode = "abc123"
+	byCountry := map[string]int64{}
+	for i := 0; i < 40; i++ {
+		byCountry[fmt.Sprintf("C%02d", i)] = int64(i + 1)
+	}
+	m.analytics = &client.GetAnalyticsResponse{ShortCode: "abc123", TotalClicks: 820, ByCountry: byCountry}
+
+	m2, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	mm := m2.(model)
+	// Scroll to the bottom of the short window
+	for i := 0; i < 100; i++ {
+		m2, _ = mm.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+		mm = m2.(model)
+	}
+	bottom := mm.analyticsScroll
+	if bottom == 0 || bottom != mm.analyticsMaxScroll() {
+		t.Fatalf("scroll=%d want max %d", bottom, mm.analyticsMaxScroll())
+	}
+
+	// A taller window fits more lines, so the old offset is out of range
+	m2, _ = mm.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	mm = m2.(model)
+	if mm.analyticsScroll >= bottom || mm.analyticsScroll != mm.analyticsMaxScroll() {
+		t.Fatalf("scroll=%d after resize, want clamped to %d", mm.analyticsScroll, mm.analyticsMaxScroll())
+	}
+
+	// An offset past the end (e.g. from before the data shrank) is clamped too
+	mm.analyticsScroll = 1000
+	m2, _ = mm.Update(tea.WindowSizeMsg{Width: 80, Height: 15})
+	mm = m2.(model)
+	if mm.analyticsScroll != mm.analyticsMaxScroll() {
+		t.Fatalf("scroll=%d want %d", mm.analyticsScroll, mm.analyticsMaxScroll())
+	}
 }
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/client"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+// retryPolicy retries transient failures (network errors, 502/503/504) a couple of
+// times; requestContext still bounds the total time spent across attempts.
+var retryPolicy = client.RetryPolicy{MaxAttempts: 3, BaseDelay: 250 * time.Millisecond, Jitter: 0.2}
+
+// errRequestTimedOut replaces deadline errors so status lines read clearly.
+var errRequestTimedOut = errors.New("request timed out")
+
+func requestTimeout(cfg tui_config.Config) time.Duration {
+	if cfg.Timeout > 0 {
+		return cfg.Timeout
+	}
+	return tui_config.DefaultTimeout
+}
+
+// newAPIClient builds a client for cfg whose underlying http.Client gives up
+// after the configured request timeout.
+func newAPIClient(cfg tui_config.Config) (*client.Client, error) {
+	base := strings.TrimSpace(cfg.BaseURL)
+	if base == "" {
+		return nil, fmt.Errorf("base URL not set")
+	}
+
+	return client.New(base,
+		client.WithToken(cfg.Token),
+		client.WithHTTPClient(&http.Client{Timeout: requestTimeout(cfg)}),
+		client.WithRetry(retryPolicy),
+	)
+}
+
+// requestContext derives the context a single TUI command runs its API call under.
+func requestContext(cfg tui_config.Config) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), requestTimeout(cfg))
+}
+
+// requestError maps timeouts (context deadline or http.Client.Timeout) to
+// errRequestTimedOut and returns every other error unchanged.
+func requestError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errRequestTimedOut
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return errRequestTimedOut
+	}
+	return err
+}
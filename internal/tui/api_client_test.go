@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+func slowServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"urls":[],"total":0,"limit":20,"offset":0}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCommands_TimeOutAgainstSlowServer(t *testing.T) {
+	srv := slowServer(t, 2*time.Second)
+	cfg := tui_config.Config{BaseURL: srv.URL, Token: "t", Timeout: 50 * time.Millisecond}
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{"list", func() error { return listURLsCmd(cfg, 20, 0)().(listURLsMsg).err }},
+		{"create", func() error { return createURLCmd(cfg, "https://example.com")().(createURLMsg).err }},
+		{"delete", func() error { return deleteURLCmd(cfg, "abc123")().(deleteURLMsg).err }},
+		{"analytics", func() error { return getAnalyticsCmd(cfg, "abc123", nil, nil, "")().(getAnalyticsMsg).err }},
+		{"sessions", func() error { return listSessionsCmd(cfg)().(listSessionsMsg).err }},
+		{"revoke", func() error { return revokeSessionCmd(cfg, "abc")().(revokeSessionMsg).err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			err := tt.run()
+			if !errors.Is(err, errRequestTimedOut) {
+				t.Fatalf("expected errRequestTimedOut, got %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Fatalf("expected request to be abandoned quickly, took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestCommands_SucceedWithinTimeout(t *testing.T) {
+	srv := slowServer(t, 10*time.Millisecond)
+	cfg := tui_config.Config{BaseURL: srv.URL, Token: "t", Timeout: 2 * time.Second}
+
+	if msg := listURLsCmd(cfg, 20, 0)().(listURLsMsg); msg.err != nil {
+		t.Fatalf("expected nil err, got %v", msg.err)
+	}
+}
+
+func TestModel_ListTimeoutShowsStatus(t *testing.T) {
+	srv := slowServer(t, 2*time.Second)
+	cfg := tui_config.Config{BaseURL: srv.URL, Token: "t", Timeout: 50 * time.Millisecond}
+
+	m := newModel(cfg, nil)
+	updated, _ := m.Update(listURLsCmd(cfg, 20, 0)())
+	status := updated.(model).status
+
+	if status != "List failed: request timed out" {
+		t.Fatalf("status=%q", status)
+	}
+	if statusKindFromText(status) != statusKindError {
+		t.Fatalf("expected timeout status to render as an error")
+	}
+	if !strings.Contains(updated.(model).View().Content, "request timed out") {
+		t.Fatalf("expected view to show timeout status")
+	}
+}
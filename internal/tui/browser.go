@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// openInBrowser is swapped out in tests so they never launch a browser.
+var openInBrowser = openURL
+
+// openURL hands u to the platform's default URL handler without waiting for it.
+func openURL(u string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", u)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", u)
+	default:
+		cmd = exec.Command("xdg-open", u)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// Reap the launcher in the background; it exits once the browser has the URL
+	go cmd.Wait()
+	return nil
+}
+
+func (m model) openSelected() (tea.Model, tea.Cmd) {
+	if len(m.filtered) == 0 {
+		m.status = "No URLs to open"
+		return m, nil
+	}
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		m.status = "No selected URL"
+		return m, nil
+	}
+	u := shortURL(m.cfg.BaseURL, m.filtered[m.cursor].ShortCode)
+	if err := openInBrowser(u); err != nil {
+		m.status = fmt.Sprintf("Open failed: %v", err)
+	} else {
+		m.status = fmt.Sprintf("Opened: %s", u)
+	}
+	return m, nil
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+func TestModel_Update_OpenSelectedInBrowser(t *testing.T) {
+	old := openInBrowser
+	defer func() { openInBrowser = old }()
+	var opened string
+	openInBrowser = func(u string) error {
+		opened = u
+		return nil
+	}
+
+	m := newModel(tui_config.Config{BaseURL: "https://mjr.wtf/", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{{ShortCode: "abc123"}, {ShortCode: "def456"}}
+	m.filtered = m.urls
+	m.cursor = 1
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	mm := m2.(model)
+	if opened != "https://mjr.wtf/def456" {
+		t.Fatalf("opened=%q", opened)
+	}
+	if mm.status != "Opened: https://mjr.wtf/def456" || statusKindFromText(mm.status) != statusKindSuccess {
+		t.Fatalf("status=%q", mm.status)
+	}
+
+	openInBrowser = func(string) error { return errors.New("xdg-open not found") }
+	m3, _ := mm.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	status := m3.(model).status
+	if !strings.Contains(status, "Open failed: xdg-open not found") || statusKindFromText(status) != statusKindError {
+		t.Fatalf("status=%q", status)
+	}
+}
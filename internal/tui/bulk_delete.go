@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
+)
+
+// bulkDeletePreviewMax bounds how many short codes the confirmation lists.
+const bulkDeletePreviewMax = 10
+
+// toggleSelected marks or unmarks the URL under the cursor for bulk actions.
+func (m *model) toggleSelected() {
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		m.status = "No selected URL"
+		return
+	}
+	code := m.filtered[m.cursor].ShortCode
+	if m.selected[code] {
+		delete(m.selected, code)
+	} else {
+		if m.selected == nil {
+			m.selected = map[string]bool{}
+		}
+		m.selected[code] = true
+	}
+	m.status = fmt.Sprintf("%d selected", len(m.selected))
+}
+
+// pruneSelection drops marks for URLs that are no longer loaded, so a bulk
+// delete only ever touches rows the user can see.
+func (m *model) pruneSelection() {
+	loaded := make(map[string]bool, len(m.urls))
+	for _, u := range m.urls {
+		loaded[u.ShortCode] = true
+	}
+	for code := range m.selected {
+		if !loaded[code] {
+			delete(m.selected, code)
+		}
+	}
+}
+
+// selectedCodes returns the marked short codes in a stable order.
+func (m model) selectedCodes() []string {
+	codes := make([]string, 0, len(m.selected))
+	for code := range m.selected {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func (m model) startBulkDelete() (tea.Model, tea.Cmd) {
+	m.mode = modeDeleteConfirm
+	m.deleteLoading = false
+	m.deleteConfirmCodes = m.selectedCodes()
+	m.status = fmt.Sprintf("Confirm delete: %d URLs", len(m.deleteConfirmCodes))
+	return m, nil
+}
+
+// confirmBulkDelete deletes each confirmed URL in turn; the results arrive as
+// deleteURLMsgs and are tallied by handleBulkDeleteResult.
+func (m model) confirmBulkDelete() (tea.Model, tea.Cmd) {
+	codes := m.deleteConfirmCodes
+	m.deleteLoading = true
+	m.bulkDeleteTotal = len(codes)
+	m.bulkDeleteDone = 0
+	m.bulkDeleteFailed = 0
+	m.bulkDeleteErr = nil
+	m.status = fmt.Sprintf("Deleting %d URLs...", len(codes))
+
+	cmds := make([]tea.Cmd, 0, len(codes))
+	for _, code := range codes {
+		cmds = append(cmds, deleteURLCmd(m.cfg, code))
+	}
+	return m, tea.Batch(m.spinner.Tick, tea.Sequence(cmds...))
+}
+
+func (m model) handleBulkDeleteResult(msg deleteURLMsg) (tea.Model, tea.Cmd) {
+	m.bulkDeleteDone++
+	if msg.err != nil {
+		// Failed URLs stay selected so the delete can be retried
+		m.bulkDeleteFailed++
+		m.bulkDeleteErr = msg.err
+	} else {
+		delete(m.selected, msg.shortCode)
+		m.removeURL(msg.shortCode)
+	}
+
+	if m.bulkDeleteDone < m.bulkDeleteTotal {
+		m.status = fmt.Sprintf("Deleting %d URLs... (%d/%d)", m.bulkDeleteTotal, m.bulkDeleteDone, m.bulkDeleteTotal)
+		return m, nil
+	}
+
+	deleted := m.bulkDeleteTotal - m.bulkDeleteFailed
+	if m.bulkDeleteFailed > 0 {
+		m.status = fmt.Sprintf("Delete failed for %d of %d URLs (%d deleted): %v", m.bulkDeleteFailed, m.bulkDeleteTotal, deleted, m.bulkDeleteErr)
+	} else {
+		m.status = fmt.Sprintf("Deleted: %d URLs", deleted)
+	}
+	m.deleteLoading = false
+	m.mode = modeBrowsing
+	m.deleteConfirmCodes = nil
+	m.bulkDeleteTotal = 0
+	m.bulkDeleteErr = nil
+	return m, nil
+}
+
+// removeURL drops a deleted URL from the loaded page and keeps the cursor in range.
+func (m *model) removeURL(shortCode string) {
+	if m.total > 0 {
+		m.total--
+	}
+
+	remaining := make([]tuiURL, 0, len(m.urls))
+	for _, u := range m.urls {
+		if u.ShortCode == shortCode {
+			continue
+		}
+		remaining = append(remaining, u)
+	}
+	m.urls = remaining
+
+	remainingFiltered := make([]tuiURL, 0, len(m.filtered))
+	for _, u := range m.filtered {
+		if u.ShortCode == shortCode {
+			continue
+		}
+		remainingFiltered = append(remainingFiltered, u)
+	}
+	m.filtered = remainingFiltered
+	m.clampCursor()
+}
+
+func (m model) bulkDeleteConfirmView() string {
+	codes := m.deleteConfirmCodes
+	preview := codes
+	if len(preview) > bulkDeletePreviewMax {
+		preview = preview[:bulkDeletePreviewMax]
+	}
+	list := styles.TitleStyle.Copy().Foreground(styles.Lavender).Render(strings.Join(preview, ", "))
+	if more := len(codes) - len(preview); more > 0 {
+		list += styles.MutedStyle.Render(fmt.Sprintf(" and %d more", more))
+	}
+
+	lines := []string{
+		styles.WarningStyle.Render(fmt.Sprintf("Confirm Delete: %d URLs", len(codes))),
+		"",
+		fmt.Sprintf("%s %s", styles.MutedStyle.Render("Short codes:"), list),
+	}
+	if m.deleteLoading {
+		loading := styles.MutedStyle.Render(fmt.Sprintf("%s Deleting %d/%d...", m.spinner.View(), m.bulkDeleteDone, m.bulkDeleteTotal))
+		lines = append(lines, "", loading)
+	}
+
+	return styles.WarningPanelStyle.Render(strings.Join(lines, "\n"))
+}
@@ -1,11 +1,9 @@
 package tui
 
 import (
-	"context"
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/matt-riley/mjrwtf/internal/client"
@@ -35,22 +33,17 @@ func validateHTTPURL(s string) error {
 
 func createURLCmd(cfg tui_config.Config, originalURL string) tea.Cmd {
 	return func() tea.Msg {
-		base := strings.TrimSpace(cfg.BaseURL)
-		if base == "" {
-			return createURLMsg{err: fmt.Errorf("base URL not set")}
-		}
-
-		c, err := client.New(base, client.WithToken(cfg.Token), client.WithTimeout(5*time.Second))
+		c, err := newAPIClient(cfg)
 		if err != nil {
 			return createURLMsg{err: err}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+		ctx, cancel := requestContext(cfg)
 		defer cancel()
 
 		resp, err := c.CreateURL(ctx, originalURL)
 		if err != nil {
-			return createURLMsg{err: err}
+			return createURLMsg{err: requestError(err)}
 		}
 		return createURLMsg{resp: resp}
 	}
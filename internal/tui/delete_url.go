@@ -1,13 +1,7 @@
 package tui
 
 import (
-	"context"
-	"fmt"
-	"strings"
-	"time"
-
 	tea "charm.land/bubbletea/v2"
-	"github.com/matt-riley/mjrwtf/internal/client"
 	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
 )
 
@@ -18,21 +12,16 @@ type deleteURLMsg struct {
 
 func deleteURLCmd(cfg tui_config.Config, shortCode string) tea.Cmd {
 	return func() tea.Msg {
-		base := strings.TrimSpace(cfg.BaseURL)
-		if base == "" {
-			return deleteURLMsg{shortCode: shortCode, err: fmt.Errorf("base URL not set")}
-		}
-
-		c, err := client.New(base, client.WithToken(cfg.Token), client.WithTimeout(5*time.Second))
+		c, err := newAPIClient(cfg)
 		if err != nil {
 			return deleteURLMsg{shortCode: shortCode, err: err}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+		ctx, cancel := requestContext(cfg)
 		defer cancel()
 
 		if err := c.DeleteURL(ctx, shortCode); err != nil {
-			return deleteURLMsg{shortCode: shortCode, err: err}
+			return deleteURLMsg{shortCode: shortCode, err: requestError(err)}
 		}
 		return deleteURLMsg{shortCode: shortCode}
 	}
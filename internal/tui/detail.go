@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
+)
+
+func (m model) openDetail() (tea.Model, tea.Cmd) {
+	if m.loading {
+		return m, nil
+	}
+	if m.cursor < 0 || m.cursor >= len(m.filtered) {
+		m.status = "No selected URL"
+		return m, nil
+	}
+	m.detailURL = m.filtered[m.cursor]
+	m.mode = modeDetail
+	m.status = fmt.Sprintf("Details: %s", m.detailURL.ShortCode)
+	return m, nil
+}
+
+func (m model) updateDetailKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "b", "esc", "i", "enter":
+		m.mode = modeBrowsing
+		m.status = "Back to list"
+	}
+	return m, nil
+}
+
+func (m model) detailView() string {
+	u := m.detailURL
+	width := maxDetailURLWidth
+	if m.width > 0 {
+		width = m.width - detailURLWidthMargin
+		if width < minDetailURLWidth {
+			width = minDetailURLWidth
+		}
+	}
+
+	created := "unknown"
+	if u.CreatedAt != nil {
+		created = u.CreatedAt.Local().Format("2006-01-02 15:04:05 MST")
+	}
+	createdBy := u.CreatedBy
+	if createdBy == "" {
+		createdBy = "unknown"
+	}
+
+	label := func(s string) string { return styles.MutedStyle.Render(s) }
+	lines := []string{
+		styles.TitleStyle.Render(fmt.Sprintf("URL details: %s", u.ShortCode)),
+		"",
+		fmt.Sprintf("%s %s", label("Short URL:"), styles.LinkStyle.Render(shortURL(m.cfg.BaseURL, u.ShortCode))),
+		fmt.Sprintf("%s %d", label("Clicks:"), u.ClickCount),
+		fmt.Sprintf("%s %s", label("Created:"), created),
+		fmt.Sprintf("%s %s", label("Created by:"), createdBy),
+		"",
+		label("Original URL:"),
+	}
+	for _, chunk := range wrapRunes(u.OriginalURL, width) {
+		lines = append(lines, styles.LinkStyle.Render(chunk))
+	}
+	return styles.PanelStyle.Render(strings.Join(lines, "\n"))
+}
+
+// wrapRunes hard-wraps s into lines of at most width runes. URLs rarely have
+// spaces to break on, so word wrapping wouldn't help.
+func wrapRunes(s string, width int) []string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return []string{s}
+	}
+	var lines []string
+	for len(runes) > width {
+		lines = append(lines, string(runes[:width]))
+		runes = runes[width:]
+	}
+	return append(lines, string(runes))
+}
@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+func TestModel_Update_DetailShowsUntruncatedURL(t *testing.T) {
+	long := "https://example.com/" + strings.Repeat("segment/", 30) + "end?q=1"
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	m := newModel(tui_config.Config{BaseURL: "https://mjr.wtf", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{{ShortCode: "abc123", OriginalURL: long, CreatedAt: &created, CreatedBy: "alice", ClickCount: 42}}
+	m.filtered = m.urls
+
+	if strings.Contains(m.View().Content, "end?q=1") {
+		t.Fatalf("expected the list to truncate the long URL")
+	}
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'i', Text: "i"})
+	mm := m2.(model)
+	if mm.mode != modeDetail {
+		t.Fatalf("mode=%v want modeDetail", mm.mode)
+	}
+
+	out := mm.View().Content
+	// The URL is wrapped across lines, so check each chunk appears in full.
+	for _, chunk := range wrapRunes(long, maxDetailURLWidth) {
+		if !strings.Contains(out, chunk) {
+			t.Fatalf("expected detail view to contain %q, got:\n%s", chunk, out)
+		}
+	}
+	for _, want := range []string{"https://mjr.wtf/abc123", "alice", "42"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected detail view to contain %q", want)
+		}
+	}
+
+	m3, _ := mm.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if got := m3.(model).mode; got != modeBrowsing {
+		t.Fatalf("mode=%v want modeBrowsing", got)
+	}
+
+	// enter opens the pane too
+	m4, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if got := m4.(model).mode; got != modeDetail {
+		t.Fatalf("mode=%v want modeDetail", got)
+	}
+}
+
+func TestWrapRunes(t *testing.T) {
+	got := wrapRunes("abcdefgh", 3)
+	if strings.Join(got, "|") != "abc|def|gh" {
+		t.Fatalf("wrapRunes=%q", got)
+	}
+	if got := wrapRunes("abc", 10); len(got) != 1 || got[0] != "abc" {
+		t.Fatalf("wrapRunes=%q", got)
+	}
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"charm.land/lipgloss/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
+)
+
+// Fuzzy match scoring: every matched character scores fuzzyMatchScore, with
+// bonuses for runs of consecutive characters and for matches at the start of
+// a word, and a small penalty for each character skipped before the match
+// ends, so tighter matches rank first.
+const (
+	fuzzyMatchScore       = 1
+	fuzzyConsecutiveBonus = 5
+	fuzzyWordStartBonus   = 3
+	fuzzyGapPenalty       = 1
+)
+
+// fuzzyMatch reports whether every rune of query appears in target in order
+// (case-insensitively) and, if so, its score and the matched rune indices.
+// Each occurrence of the first query rune is tried as a starting point and
+// the best-scoring match is kept.
+func fuzzyMatch(query, target string) (int, []int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+
+	bestScore := 0
+	var best []int
+	for start := range t {
+		if t[start] != q[0] {
+			continue
+		}
+		score, indices, ok := fuzzyMatchFrom(q, t, start)
+		if ok && (best == nil || score > bestScore) {
+			bestScore, best = score, indices
+		}
+	}
+	return bestScore, best, best != nil
+}
+
+// fuzzyMatchFrom greedily matches q against t starting at t[start].
+func fuzzyMatchFrom(q, t []rune, start int) (int, []int, bool) {
+	indices := make([]int, 0, len(q))
+	score := 0
+	qi := 0
+	for ti := start; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			continue
+		}
+		score += fuzzyMatchScore
+		if n := len(indices); n > 0 {
+			if indices[n-1] == ti-1 {
+				score += fuzzyConsecutiveBonus
+			} else {
+				score -= (ti - indices[n-1] - 1) * fuzzyGapPenalty
+			}
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += fuzzyWordStartBonus
+		}
+		indices = append(indices, ti)
+		qi++
+	}
+	return score, indices, qi == len(q)
+}
+
+// fuzzyHit is a URL matched by the fuzzy filter, with the matched rune
+// indices of its short code and original URL for highlighting.
+type fuzzyHit struct {
+	url       tuiURL
+	score     int
+	codeIdx   []int
+	originIdx []int
+}
+
+// fuzzyFilter returns the URLs matching query, best match first. A URL scores
+// the better of its short code and original URL matches.
+func fuzzyFilter(urls []tuiURL, query string) []fuzzyHit {
+	hits := make([]fuzzyHit, 0, len(urls))
+	for _, u := range urls {
+		codeScore, codeIdx, codeOK := fuzzyMatch(query, u.ShortCode)
+		urlScore, urlIdx, urlOK := fuzzyMatch(query, u.OriginalURL)
+
+		hit := fuzzyHit{url: u, codeIdx: codeIdx, originIdx: urlIdx}
+		switch {
+		case codeOK && urlOK:
+			hit.score = max(codeScore, urlScore)
+		case codeOK:
+			hit.score = codeScore
+		case urlOK:
+			hit.score = urlScore
+		default:
+			continue
+		}
+		hits = append(hits, hit)
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	return hits
+}
+
+// highlightMatches renders s in base with the runes at indices emphasised.
+func highlightMatches(s string, indices []int, base lipgloss.Style) string {
+	if len(indices) == 0 {
+		return s
+	}
+	return lipgloss.StyleRunes(s, indices, styles.MatchStyle.Inherit(base), base)
+}
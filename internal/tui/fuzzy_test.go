@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		query, target string
+		wantOK        bool
+		wantIdx       []int
+	}{
+		{"exmpl", "example.com", true, []int{0, 1, 3, 4, 5}},
+		{"EXMPL", "Example.com", true, []int{0, 1, 3, 4, 5}},
+		{"moc", "example.com", false, nil},
+		{"", "example.com", true, nil},
+		// The later, consecutive "ab" beats the scattered early one
+		{"ab", "a-x-b ab", true, []int{6, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query+"/"+tt.target, func(t *testing.T) {
+			_, idx, ok := fuzzyMatch(tt.query, tt.target)
+			if ok != tt.wantOK {
+				t.Fatalf("ok=%v want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(idx, tt.wantIdx) {
+				t.Fatalf("indices=%v want %v", idx, tt.wantIdx)
+			}
+		})
+	}
+}
+
+func TestFuzzyFilter_OrdersByScore(t *testing.T) {
+	urls := []tuiURL{
+		{ShortCode: "aaa111", OriginalURL: "https://e.x.m.p.l.net"},
+		{ShortCode: "bbb222", OriginalURL: "https://other.org"},
+		{ShortCode: "ccc333", OriginalURL: "https://example.com"},
+		{ShortCode: "exmpl1", OriginalURL: "https://unrelated.dev"},
+	}
+
+	var got []string
+	for _, hit := range fuzzyFilter(urls, "exmpl") {
+		got = append(got, hit.url.ShortCode)
+	}
+	if want := []string{"exmpl1", "ccc333", "aaa111"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("order=%v want %v", got, want)
+	}
+}
+
+func TestModel_Update_TabTogglesFuzzyFilter(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{
+		{ShortCode: "abc123", OriginalURL: "https://other.org"},
+		{ShortCode: "def456", OriginalURL: "https://example.com"},
+	}
+	m.applyFilter()
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	mm := m2.(model)
+	for _, r := range "exmpl" {
+		m2, _ = mm.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		mm = m2.(model)
+	}
+	// Substring matching is the default
+	if len(mm.filtered) != 0 {
+		t.Fatalf("substring filter matched %d URLs, want 0", len(mm.filtered))
+	}
+
+	m2, _ = mm.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	mm = m2.(model)
+	if !mm.fuzzyFilter {
+		t.Fatalf("expected fuzzy filter to be on")
+	}
+	if len(mm.filtered) != 1 || mm.filtered[0].ShortCode != "def456" {
+		t.Fatalf("filtered=%v", mm.filtered)
+	}
+	if !strings.Contains(mm.footer(), "Fuzzy filter: exmpl") {
+		t.Fatalf("expected the footer to show fuzzy mode, got %q", mm.footer())
+	}
+
+	m2, _ = mm.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if got := len(m2.(model).filtered); got != 0 {
+		t.Fatalf("after toggling back filtered=%d want 0", got)
+	}
+}
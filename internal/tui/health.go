@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/matt-riley/mjrwtf/internal/client"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+// healthPingInterval is how often the footer's connectivity indicator pings /health.
+const healthPingInterval = 15 * time.Second
+
+type healthState int
+
+const (
+	healthUnknown healthState = iota
+	healthUp
+	healthDown
+)
+
+type healthTickMsg struct{}
+
+type healthPingMsg struct {
+	latency time.Duration
+	err     error
+}
+
+func healthTickCmd() tea.Cmd {
+	return tea.Tick(healthPingInterval, func(time.Time) tea.Msg {
+		return healthTickMsg{}
+	})
+}
+
+// healthPingCmd times a single GET /health. It skips the retry policy so the
+// latency shown is one round trip, and a down server reads as down promptly.
+func healthPingCmd(cfg tui_config.Config) tea.Cmd {
+	return func() tea.Msg {
+		base := strings.TrimSpace(cfg.BaseURL)
+		if base == "" {
+			return healthPingMsg{err: fmt.Errorf("base URL not set")}
+		}
+		c, err := client.New(base, client.WithHTTPClient(&http.Client{Timeout: requestTimeout(cfg)}))
+		if err != nil {
+			return healthPingMsg{err: err}
+		}
+
+		ctx, cancel := requestContext(cfg)
+		defer cancel()
+
+		start := time.Now()
+		if err := c.Health(ctx); err != nil {
+			return healthPingMsg{err: requestError(err)}
+		}
+		return healthPingMsg{latency: time.Since(start)}
+	}
+}
+
+// busy reports whether a blocking action is in flight; pings wait until it finishes.
+func (m model) busy() bool {
+	return m.loading || m.createLoading || m.analyticsLoading || m.deleteLoading || m.sessionsLoading || m.sessionRevoking || m.leaderboardLoading
+}
+
+// handleHealthTick pings unless an action is loading. Either way exactly one
+// tick or ping stays outstanding, so the ticker never multiplies.
+func (m model) handleHealthTick() (tea.Model, tea.Cmd) {
+	if m.busy() {
+		return m, healthTickCmd()
+	}
+	return m, healthPingCmd(m.cfg)
+}
+
+func (m model) handleHealthPing(msg healthPingMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.health = healthDown
+	} else {
+		m.health = healthUp
+		m.healthLatency = msg.latency
+	}
+	return m, healthTickCmd()
+}
+
+// healthStyle is the style of the footer's connectivity indicator.
+func (m model) healthStyle() lipgloss.Style {
+	switch m.health {
+	case healthUp:
+		return styles.SuccessStyle
+	case healthDown:
+		return styles.ErrorStyle
+	default:
+		return styles.MutedStyle
+	}
+}
+
+// healthIndicator renders a dot plus the last round trip ("● 42ms"), "● down"
+// after a failed ping, or a muted dot before the first ping completes.
+func (m model) healthIndicator() string {
+	text := "●"
+	switch m.health {
+	case healthUp:
+		text = fmt.Sprintf("● %s", formatLatency(m.healthLatency))
+	case healthDown:
+		text = "● down"
+	}
+	return m.healthStyle().Render(text)
+}
+
+func formatLatency(d time.Duration) string {
+	if d < time.Millisecond {
+		return "<1ms"
+	}
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+func TestModel_Update_HealthPingFailedSetsErrorStyle(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "abcdef"}, nil)
+
+	m2, cmd := m.Update(healthPingMsg{err: errors.New("connection refused")})
+	mm := m2.(model)
+	if cmd == nil {
+		t.Fatalf("expected the next health tick to be scheduled")
+	}
+	if mm.health != healthDown {
+		t.Fatalf("health=%v want healthDown", mm.health)
+	}
+	if got, want := mm.healthStyle().Render("●"), styles.ErrorStyle.Render("●"); got != want {
+		t.Fatalf("indicator style=%q want error style %q", got, want)
+	}
+	if !strings.Contains(mm.footer(), styles.ErrorStyle.Render("● down")) {
+		t.Fatalf("expected footer to show the down indicator, got %q", mm.footer())
+	}
+
+	// A later successful ping recovers the indicator and shows the latency.
+	m3, _ := mm.Update(healthPingMsg{latency: 42 * time.Millisecond})
+	mm = m3.(model)
+	if got, want := mm.healthStyle().Render("●"), styles.SuccessStyle.Render("●"); got != want {
+		t.Fatalf("indicator style=%q want success style %q", got, want)
+	}
+	if !strings.Contains(mm.footer(), styles.SuccessStyle.Render("● 42ms")) {
+		t.Fatalf("expected footer to show the latency, got %q", mm.footer())
+	}
+}
+
+func TestModel_Update_HealthTickPausedWhileLoading(t *testing.T) {
+	var pinged atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pinged.Store(true)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	m := newModel(tui_config.Config{BaseURL: ts.URL, Token: "abcdef"}, nil)
+	m.loading = true
+
+	_, cmd := m.Update(healthTickMsg{})
+	if cmd == nil {
+		t.Fatalf("expected the tick to be rescheduled")
+	}
+	// The rescheduled tick waits healthPingInterval; the ping itself would
+	// return immediately, so a cmd that blocks means no ping was sent.
+	done := make(chan struct{})
+	go func(tick func() tea.Msg) {
+		tick()
+		close(done)
+	}(cmd)
+	select {
+	case <-done:
+		t.Fatalf("expected a delayed tick, not an immediate ping")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if pinged.Load() {
+		t.Fatalf("expected no ping while loading")
+	}
+
+	m.loading = false
+	_, cmd = m.Update(healthTickMsg{})
+	msg, ok := cmd().(healthPingMsg)
+	if !ok {
+		t.Fatalf("expected a healthPingMsg, got %T", msg)
+	}
+	if msg.err != nil || !pinged.Load() {
+		t.Fatalf("expected a successful ping, got err=%v pinged=%v", msg.err, pinged.Load())
+	}
+}
+
+func TestHealthPingCmd_ServerDown(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	msg := healthPingCmd(tui_config.Config{BaseURL: ts.URL})().(healthPingMsg)
+	if msg.err == nil {
+		t.Fatalf("expected an error for a 503 health check")
+	}
+}
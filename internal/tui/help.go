@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
+)
+
+type helpBinding struct {
+	keys   string
+	action string
+}
+
+type helpSection struct {
+	title    string
+	bindings []helpBinding
+}
+
+// helpSections lists every keybinding, grouped by the mode it applies in.
+var helpSections = []helpSection{
+	{"Global", []helpBinding{
+		{"q / ctrl+c", "quit"},
+		{"?", "toggle this help"},
+	}},
+	{"URL list", []helpBinding{
+		{"j/k ↑/↓", "move selection"},
+		{"n / p", "next / previous page"},
+		{"g", "go to page"},
+		{"/", "filter the loaded page"},
+		{"i / enter", "details for selected URL"},
+		{"S", "cycle sort: created, clicks, code"},
+		{"t", "toggle relative / absolute times"},
+		{"space", "mark / unmark for bulk delete"},
+		{"c", "create URL"},
+		{"d", "delete marked URLs, or the selected one"},
+		{"m", "copy Markdown link"},
+		{"o", "open short URL in browser"},
+		{"a", "analytics for selected URL"},
+		{"s", "session manager"},
+		{"L", "most clicked URLs leaderboard"},
+		{"r", "refresh"},
+	}},
+	{"Filter", []helpBinding{
+		{"type", "narrow the list live"},
+		{"enter", "keep filter"},
+		{"esc", "clear filter, restore selection"},
+		{"tab", "toggle fuzzy / substring matching"},
+	}},
+	{"Create", []helpBinding{
+		{"enter", "submit"},
+		{"esc", "cancel"},
+	}},
+	{"Delete confirmation", []helpBinding{
+		{"enter / y", "confirm delete"},
+		{"esc / n", "cancel"},
+	}},
+	{"URL details", []helpBinding{
+		{"b / esc / i", "back to list"},
+	}},
+	{"Analytics", []helpBinding{
+		{"j/k ↑/↓", "scroll"},
+		{"t", "set time range"},
+		{"h", "toggle hourly/daily buckets"},
+		{"r", "refresh"},
+		{"b / esc", "back to list"},
+	}},
+	{"Analytics time range", []helpBinding{
+		{"tab", "switch field"},
+		{"enter", "next field / apply"},
+		{"esc", "cancel"},
+	}},
+	{"Go to page", []helpBinding{
+		{"0-9", "page number"},
+		{"enter", "jump"},
+		{"esc", "cancel"},
+	}},
+	{"Session manager", []helpBinding{
+		{"j/k ↑/↓", "move selection"},
+		{"d", "revoke selected session"},
+		{"enter / y", "confirm revoke"},
+		{"esc / n", "cancel revoke"},
+		{"r", "refresh"},
+		{"b / esc", "back to list"},
+	}},
+	{"Leaderboard", []helpBinding{
+		{"r", "refresh"},
+		{"b / esc", "back to list"},
+	}},
+}
+
+// helpAvailable reports whether ? opens help in the current mode. Modes with
+// a text input are excluded, since ? is a valid character there (e.g. in URLs),
+// as is the delete confirmation, which a finishing delete leaves on its own.
+func (m model) helpAvailable() bool {
+	switch m.mode {
+	case modeBrowsing, modeViewingAnalytics, modeDetail, modeLeaderboard:
+		return true
+	case modeSessions:
+		return m.sessionRevokeID == ""
+	default:
+		return false
+	}
+}
+
+func (m model) openHelp() (tea.Model, tea.Cmd) {
+	m.helpReturnMode = m.mode
+	m.mode = modeHelp
+	return m, nil
+}
+
+func (m model) updateHelpKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "?", "esc":
+		m.mode = m.helpReturnMode
+	}
+	return m, nil
+}
+
+func (m model) helpView() string {
+	keyWidth := 0
+	for _, section := range helpSections {
+		for _, b := range section.bindings {
+			if w := lipgloss.Width(b.keys); w > keyWidth {
+				keyWidth = w
+			}
+		}
+	}
+
+	lines := []string{styles.TitleStyle.Render("Keyboard shortcuts")}
+	for _, section := range helpSections {
+		lines = append(lines, "", styles.TitleStyle.Copy().Bold(false).Render(section.title))
+		for _, b := range section.bindings {
+			key := styles.LinkStyle.Render(fmt.Sprintf("%-*s", keyWidth, b.keys))
+			lines = append(lines, fmt.Sprintf("  %s  %s", key, b.action))
+		}
+	}
+	return styles.PanelStyle.Render(strings.Join(lines, "\n"))
+}
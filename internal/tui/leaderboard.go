@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/client"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+// leaderboardLimit is how many URLs the leaderboard asks the server for.
+const leaderboardLimit = 25
+
+type topURLsMsg struct {
+	urls []client.URLResponse
+	err  error
+}
+
+func topURLsCmd(cfg tui_config.Config) tea.Cmd {
+	return func() tea.Msg {
+		c, err := newAPIClient(cfg)
+		if err != nil {
+			return topURLsMsg{err: err}
+		}
+
+		ctx, cancel := requestContext(cfg)
+		defer cancel()
+
+		resp, err := c.GetTopURLs(ctx, leaderboardLimit)
+		if err != nil {
+			return topURLsMsg{err: requestError(err)}
+		}
+		return topURLsMsg{urls: resp.URLs}
+	}
+}
+
+func (m model) openLeaderboard() (tea.Model, tea.Cmd) {
+	m.mode = modeLeaderboard
+	m.leaderboardLoading = true
+	m.leaderboard = nil
+	m.status = "Loading leaderboard..."
+	return m, tea.Batch(m.spinner.Tick, topURLsCmd(m.cfg))
+}
+
+func (m model) updateLeaderboardKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "b", "esc":
+		m.mode = modeBrowsing
+		m.status = "Back to list"
+	case "r":
+		if m.leaderboardLoading {
+			return m, nil
+		}
+		return m.openLeaderboard()
+	}
+	return m, nil
+}
+
+func (m model) handleTopURLs(msg topURLsMsg) (tea.Model, tea.Cmd) {
+	m.leaderboardLoading = false
+	if msg.err != nil {
+		if apiErr, ok := msg.err.(*client.APIError); ok {
+			m.status = fmt.Sprintf("Leaderboard failed (%d): %s", apiErr.StatusCode, apiErr.Message)
+		} else {
+			m.status = fmt.Sprintf("Leaderboard failed: %v", msg.err)
+		}
+		return m, nil
+	}
+	m.leaderboard = msg.urls
+	m.status = fmt.Sprintf("Loaded top %d URLs", len(m.leaderboard))
+	return m, nil
+}
+
+func (m model) leaderboardView() string {
+	if m.leaderboardLoading {
+		return styles.MutedStyle.Render(fmt.Sprintf("%s Loading leaderboard...", m.spinner.View()))
+	}
+
+	// The server breaks click ties by creation time, so keep its order rather than re-sorting
+	items := make([]kv, 0, len(m.leaderboard))
+	for _, u := range m.leaderboard {
+		items = append(items, kv{k: u.ShortCode, v: u.ClickCount})
+	}
+	return strings.Join(formatTopSection("Most clicked", items, 0), "\n")
+}
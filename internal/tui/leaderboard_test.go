@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/client"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+func TestTopURLsCmd(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/stats/top" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"urls":[{"id":2,"short_code":"hot","click_count":9},{"id":1,"short_code":"cold","click_count":0}],"limit":25}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	msg := topURLsCmd(tui_config.Config{BaseURL: srv.URL, Token: "t"})().(topURLsMsg)
+	if msg.err != nil {
+		t.Fatalf("expected nil err, got %v", msg.err)
+	}
+	if len(msg.urls) != 2 || msg.urls[0].ShortCode != "hot" {
+		t.Fatalf("urls=%+v", msg.urls)
+	}
+}
+
+func TestModel_Update_OpenLeaderboard(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+
+	m2, cmd := m.Update(tea.KeyPressMsg{Code: 'L', Text: "L"})
+	mm := m2.(model)
+	if mm.mode != modeLeaderboard {
+		t.Fatalf("mode=%v", mm.mode)
+	}
+	if !mm.leaderboardLoading {
+		t.Fatalf("expected leaderboardLoading=true")
+	}
+	if cmd == nil {
+		t.Fatalf("expected cmd")
+	}
+
+	m3, _ := mm.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m3.(model).mode != modeBrowsing {
+		t.Fatalf("expected esc to return to the list, mode=%v", m3.(model).mode)
+	}
+}
+
+func TestModel_Update_LeaderboardLoadingSpins(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.mode = modeLeaderboard
+	m.leaderboardLoading = true
+
+	if !m.busy() {
+		t.Fatalf("expected a leaderboard load to pause health pings")
+	}
+	_, cmd := m.Update(m.spinner.Tick())
+	if cmd == nil {
+		t.Fatalf("expected the spinner to keep ticking while the leaderboard loads")
+	}
+}
+
+func TestModel_LeaderboardView_KeepsServerOrder(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.mode = modeLeaderboard
+	m.leaderboardLoading = true
+
+	// Tied counts arrive newest first; the view must not re-sort them by code
+	m2, _ := m.Update(topURLsMsg{urls: []client.URLResponse{
+		{ShortCode: "hot", ClickCount: 9},
+		{ShortCode: "zeta", ClickCount: 4},
+		{ShortCode: "alpha", ClickCount: 4},
+	}})
+	mm := m2.(model)
+	if mm.leaderboardLoading {
+		t.Fatalf("expected leaderboardLoading=false")
+	}
+
+	view := mm.leaderboardView()
+	hot, zeta, alpha := strings.Index(view, "hot"), strings.Index(view, "zeta"), strings.Index(view, "alpha")
+	if hot < 0 || zeta < 0 || alpha < 0 {
+		t.Fatalf("expected every short code in view:\n%s", view)
+	}
+	if !(hot < zeta && zeta < alpha) {
+		t.Fatalf("expected server order hot, zeta, alpha:\n%s", view)
+	}
+}
+
+func TestModel_Update_LeaderboardError(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.mode = modeLeaderboard
+	m.leaderboardLoading = true
+
+	m2, _ := m.Update(topURLsMsg{err: &client.APIError{StatusCode: 500, Message: "boom"}})
+	mm := m2.(model)
+	if mm.leaderboardLoading {
+		t.Fatalf("expected leaderboardLoading=false")
+	}
+	if statusKindFromText(mm.status) != statusKindError {
+		t.Fatalf("expected error status, got %q", mm.status)
+	}
+}
@@ -1,13 +1,11 @@
 package tui
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
-	"github.com/matt-riley/mjrwtf/internal/client"
 	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
 )
 
@@ -20,12 +18,18 @@ const (
 	modeViewingAnalytics
 	modeAnalyticsTimeRange
 	modeDeleteConfirm
+	modeJumpToPage
+	modeSessions
+	modeHelp
+	modeDetail
+	modeLeaderboard
 )
 
 type tuiURL struct {
 	ShortCode   string
 	OriginalURL string
 	CreatedAt   *time.Time
+	CreatedBy   string
 	ClickCount  int64
 }
 
@@ -37,21 +41,16 @@ type listURLsMsg struct {
 
 func listURLsCmd(cfg tui_config.Config, limit, offset int) tea.Cmd {
 	return func() tea.Msg {
-		base := strings.TrimSpace(cfg.BaseURL)
-		if base == "" {
-			return listURLsMsg{err: fmt.Errorf("base URL not set")}
-		}
-
-		c, err := client.New(base, client.WithToken(cfg.Token), client.WithTimeout(5*time.Second))
+		c, err := newAPIClient(cfg)
 		if err != nil {
 			return listURLsMsg{err: err}
 		}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+		ctx, cancel := requestContext(cfg)
 		defer cancel()
 		resp, err := c.ListURLs(ctx, limit, offset)
 		if err != nil {
-			return listURLsMsg{err: err}
+			return listURLsMsg{err: requestError(err)}
 		}
 
 		out := make([]tuiURL, 0, len(resp.URLs))
@@ -61,6 +60,7 @@ func listURLsCmd(cfg tui_config.Config, limit, offset int) tea.Cmd {
 				ShortCode:   u.ShortCode,
 				OriginalURL: u.OriginalURL,
 				CreatedAt:   &created,
+				CreatedBy:   u.CreatedBy,
 				ClickCount:  u.ClickCount,
 			})
 		}
@@ -74,6 +74,56 @@ func listURLsCmd(cfg tui_config.Config, limit, offset int) tea.Cmd {
 	}
 }
 
+// markdownLinkTextMax bounds the link text so long URLs stay readable in docs.
+const markdownLinkTextMax = 60
+
+// markdownLink formats u as [original URL](short URL), truncating the text and
+// escaping the brackets Markdown would otherwise treat as link syntax.
+func markdownLink(baseURL string, u tuiURL) string {
+	text := truncate(u.OriginalURL, markdownLinkTextMax)
+	text = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`).Replace(text)
+	return fmt.Sprintf("[%s](%s)", text, shortURL(baseURL, u.ShortCode))
+}
+
+// shortURL joins the configured base URL and a short code.
+func shortURL(baseURL, shortCode string) string {
+	return strings.TrimRight(strings.TrimSpace(baseURL), "/") + "/" + shortCode
+}
+
+// relativeTime formats t relative to now, e.g. "3m ago" or "in 2h". Anything
+// within a minute either way is "just now", so small clock skew between the
+// server and this machine doesn't show as a future time.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Second || future && d < time.Minute {
+		return "just now"
+	}
+
+	var s string
+	switch {
+	case d < time.Minute:
+		s = fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		s = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		s = fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		s = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d < 365*24*time.Hour:
+		s = fmt.Sprintf("%dmo", int(d/(30*24*time.Hour)))
+	default:
+		s = fmt.Sprintf("%dy", int(d/(365*24*time.Hour)))
+	}
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
+
 func truncate(s string, max int) string {
 	s = strings.TrimSpace(s)
 	if max <= 0 || len(s) <= max {
@@ -2,9 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"net/http"
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"charm.land/bubbles/v2/spinner"
 	"charm.land/bubbles/v2/textinput"
@@ -39,6 +41,10 @@ const (
 
 	statusWidthMargin = 4
 	minStatusWidth    = 10
+
+	defaultDateBarWidth = 40
+	dateBarWidthMargin  = 40
+	maxDateBarWidth     = 60
 )
 
 type model struct {
@@ -56,6 +62,14 @@ type model struct {
 	deleteConfirmShortCode   string
 	deleteConfirmOriginalURL string
 
+	// Multi-select and bulk delete state; selected is keyed by short code
+	selected           map[string]bool
+	deleteConfirmCodes []string // non-empty while confirming a bulk delete
+	bulkDeleteTotal    int
+	bulkDeleteDone     int
+	bulkDeleteFailed   int
+	bulkDeleteErr      error
+
 	mode viewMode
 
 	urls        []tuiURL
@@ -64,6 +78,16 @@ type model struct {
 	filterQuery string
 	total       int
 
+	// Show created_at as "3m ago" instead of an absolute timestamp
+	relativeTimes bool
+
+	// Short code under the cursor when filtering started, restored on cancel
+	filterPrevShortCode string
+
+	// Fuzzy filter state; filterMatches holds each match's highlights by short code
+	fuzzyFilter   bool
+	filterMatches map[string]fuzzyHit
+
 	// Analytics view state
 	analyticsLoading   bool
 	analytics          *client.GetAnalyticsResponse
@@ -76,12 +100,39 @@ type model struct {
 
 	analyticsStartTime *time.Time
 	analyticsEndTime   *time.Time
+	analyticsHourly    bool // bucket time-range analytics per hour instead of per day
 
 	width  int
 	height int
 
 	pageSize int
 	offset   int
+
+	jumpPageInput string
+
+	// Session manager state
+	sessions        []client.SessionResponse
+	sessionsCursor  int
+	sessionsLoading bool
+	sessionRevokeID string // non-empty while awaiting revoke confirmation
+	sessionRevoking bool
+
+	// Leaderboard state
+	leaderboard        []client.URLResponse
+	leaderboardLoading bool
+
+	// Report mouse wheel and click events (--mouse)
+	mouse bool
+
+	// URL shown in the detail pane
+	detailURL tuiURL
+
+	// Mode to return to when the help overlay is dismissed
+	helpReturnMode viewMode
+
+	// Connectivity indicator, refreshed by periodic /health pings
+	health        healthState
+	healthLatency time.Duration
 }
 
 func newModel(cfg tui_config.Config, warnings []string) model {
@@ -104,6 +155,11 @@ func newModel(cfg tui_config.Config, warnings []string) model {
 	end.CharLimit = 64
 	end.SetWidth(32)
 
+	pageSize := cfg.PageSize
+	if pageSize <= 0 {
+		pageSize = tui_config.DefaultPageSize
+	}
+
 	m := model{
 		cfg:      cfg,
 		warnings: warnings,
@@ -112,7 +168,7 @@ func newModel(cfg tui_config.Config, warnings []string) model {
 		status:   "Starting...",
 		mode:     modeBrowsing,
 		filtered: []tuiURL{},
-		pageSize: 20,
+		pageSize: pageSize,
 		offset:   0,
 
 		createInput: create,
@@ -127,7 +183,7 @@ func newModel(cfg tui_config.Config, warnings []string) model {
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, listURLsCmd(m.cfg, m.pageSize, m.offset))
+	return tea.Batch(m.spinner.Tick, listURLsCmd(m.cfg, m.pageSize, m.offset), healthTickCmd())
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -155,14 +211,31 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.analyticsStartInput.SetWidth(tw)
 			m.analyticsEndInput.SetWidth(tw)
 		}
+		// A smaller window shows fewer analytics lines, so the old offset may
+		// now scroll past the end.
+		m.clampAnalyticsScroll()
+		m.clampCursor()
 		return m, nil
+	case tea.MouseWheelMsg:
+		return m.handleMouseWheel(msg)
+
+	case tea.MouseClickMsg:
+		return m.handleMouseClick(msg)
+
 	case tea.KeyPressMsg:
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "?":
+			if m.helpAvailable() {
+				return m.openHelp()
+			}
 		}
 
 		switch m.mode {
+		case modeHelp:
+			return m.updateHelpKey(msg)
+
 		case modeCreating:
 			switch msg.String() {
 			case "esc":
@@ -253,7 +326,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.analytics = nil
 				m.analyticsScroll = 0
 				m.status = "Loading analytics..."
-				return m, tea.Batch(m.spinner.Tick, getAnalyticsCmd(m.cfg, m.analyticsShortCode, m.analyticsStartTime, m.analyticsEndTime))
+				return m, tea.Batch(m.spinner.Tick, getAnalyticsCmd(m.cfg, m.analyticsShortCode, m.analyticsStartTime, m.analyticsEndTime, m.analyticsGranularity()))
 			default:
 				var cmd tea.Cmd
 				if m.analyticsRangeFocus == 0 {
@@ -289,6 +362,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmd := m.analyticsStartInput.Focus()
 				m.status = "Set time range: start_time"
 				return m, cmd
+			case "h":
+				if m.analyticsStartTime == nil || m.analyticsEndTime == nil {
+					m.status = "Hourly view needs a time range: press t to set one"
+					return m, nil
+				}
+				if m.analyticsLoading {
+					return m, nil
+				}
+				m.analyticsHourly = !m.analyticsHourly
+				m.analyticsLoading = true
+				m.analytics = nil
+				m.analyticsScroll = 0
+				if m.analyticsHourly {
+					m.status = "Loading hourly analytics..."
+				} else {
+					m.status = "Loading daily analytics..."
+				}
+				return m, tea.Batch(m.spinner.Tick, getAnalyticsCmd(m.cfg, m.analyticsShortCode, m.analyticsStartTime, m.analyticsEndTime, m.analyticsGranularity()))
 			case "r":
 				if m.analyticsLoading {
 					return m, nil
@@ -297,15 +388,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.analytics = nil
 				m.analyticsScroll = 0
 				m.status = "Refreshing analytics..."
-				return m, tea.Batch(m.spinner.Tick, getAnalyticsCmd(m.cfg, m.analyticsShortCode, m.analyticsStartTime, m.analyticsEndTime))
+				return m, tea.Batch(m.spinner.Tick, getAnalyticsCmd(m.cfg, m.analyticsShortCode, m.analyticsStartTime, m.analyticsEndTime, m.analyticsGranularity()))
 			case "j", "down":
-				lines := m.analyticsLines()
-				visible := m.analyticsVisibleLines()
-				maxScroll := 0
-				if len(lines) > visible {
-					maxScroll = len(lines) - visible
-				}
-				if m.analyticsScroll < maxScroll {
+				if m.analyticsScroll < m.analyticsMaxScroll() {
 					m.analyticsScroll++
 				}
 				return m, nil
@@ -316,6 +401,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case modeSessions:
+			return m.updateSessionsKey(msg)
+
+		case modeLeaderboard:
+			return m.updateLeaderboardKey(msg)
+
+		case modeFiltering:
+			return m.updateFilterKey(msg)
+
+		case modeDetail:
+			return m.updateDetailKey(msg)
+
+		case modeJumpToPage:
+			switch msg.String() {
+			case "esc":
+				m.cancelJumpToPage()
+				return m, nil
+			case "enter":
+				return m.submitJumpToPage()
+			default:
+				m.jumpPageInputKey(msg)
+				return m, nil
+			}
+
 		case modeDeleteConfirm:
 			switch msg.String() {
 			case "esc", "n":
@@ -323,12 +432,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.deleteLoading = false
 				m.deleteConfirmShortCode = ""
 				m.deleteConfirmOriginalURL = ""
+				m.deleteConfirmCodes = nil
 				m.status = "Delete cancelled"
 				return m, nil
 			case "enter", "y":
 				if m.deleteLoading {
 					return m, nil
 				}
+				if len(m.deleteConfirmCodes) > 0 {
+					return m.confirmBulkDelete()
+				}
 				if strings.TrimSpace(m.deleteConfirmShortCode) == "" {
 					m.mode = modeBrowsing
 					m.status = "No selected URL"
@@ -371,12 +484,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.analyticsShortCode = m.filtered[m.cursor].ShortCode
 				m.analyticsStartTime = nil
 				m.analyticsEndTime = nil
+				m.analyticsHourly = false
 				m.status = "Loading analytics..."
-				return m, tea.Batch(m.spinner.Tick, getAnalyticsCmd(m.cfg, m.analyticsShortCode, nil, nil))
+				return m, tea.Batch(m.spinner.Tick, getAnalyticsCmd(m.cfg, m.analyticsShortCode, nil, nil, ""))
 			case "d":
 				if m.loading {
 					return m, nil
 				}
+				if len(m.selected) > 0 {
+					return m.startBulkDelete()
+				}
 				if len(m.filtered) == 0 {
 					m.status = "No URLs to delete"
 					return m, nil
@@ -392,50 +509,81 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.deleteConfirmOriginalURL = u.OriginalURL
 				m.status = fmt.Sprintf("Confirm delete: %s", u.ShortCode)
 				return m, nil
-			case "j", "down":
-				if m.mode != modeFiltering {
-					m.cursorDown()
+			case "m":
+				if len(m.filtered) == 0 {
+					m.status = "No URLs to copy"
+					return m, nil
 				}
+				if m.cursor < 0 || m.cursor >= len(m.filtered) {
+					m.status = "No selected URL"
+					return m, nil
+				}
+				link := markdownLink(m.cfg.BaseURL, m.filtered[m.cursor])
+				if err := clipboardWriteAll(link); err != nil {
+					m.status = fmt.Sprintf("Copy failed: %v", err)
+				} else {
+					m.status = fmt.Sprintf("Copied Markdown link: %s", link)
+				}
+				return m, nil
+			case "space":
+				m.toggleSelected()
+				return m, nil
+			case "j", "down":
+				m.cursorDown()
 				return m, nil
 			case "k", "up":
-				if m.mode != modeFiltering {
-					m.cursorUp()
-				}
+				m.cursorUp()
 				return m, nil
 			case "n":
 				return m.nextPage()
 			case "p":
 				return m.prevPage()
+			case "s":
+				return m.openSessions()
+			case "L":
+				return m.openLeaderboard()
+			case "S":
+				return m.cycleSort()
+			case "o":
+				return m.openSelected()
+			case "g":
+				m.startJumpToPage()
+				return m, nil
 			case "/":
 				m.startFilter()
 				return m, nil
-			case "esc":
-				if m.mode == modeFiltering {
-					m.cancelFilter()
-					return m, nil
-				}
-			case "enter":
-				if m.mode == modeFiltering {
-					m.applyFilter()
-					m.status = fmt.Sprintf("Filtered to %d/%d", len(m.filtered), len(m.urls))
-					return m, nil
-				}
-			default:
-				if m.mode == modeFiltering {
-					m.filterInput(msg)
-					return m, nil
+			case "i", "enter":
+				return m.openDetail()
+			case "t":
+				m.relativeTimes = !m.relativeTimes
+				m.status = "Showing absolute times"
+				if m.relativeTimes {
+					m.status = "Showing relative times"
 				}
+				return m, nil
 			}
 		}
 
 	case spinner.TickMsg:
-		if !(m.loading || m.createLoading || m.analyticsLoading || m.deleteLoading) {
+		if !m.busy() {
 			return m, nil
 		}
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
+	case savePreferencesMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("Warning: could not save preferences: %v", msg.err)
+		}
+		return m, nil
+
+	case healthTickMsg:
+		return m.handleHealthTick()
+
+	case healthPingMsg:
+		return m.handleHealthPing(msg)
+
 	case listURLsMsg:
 		m.loading = false
 		if msg.err != nil {
@@ -444,6 +592,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.urls = msg.urls
 		m.total = msg.total
+		m.pruneSelection()
 		m.applyFilter() // reapply current filter after refresh/page change
 		m.status = fmt.Sprintf("Loaded %d/%d", len(m.filtered), m.total)
 		return m, nil
@@ -462,14 +611,26 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.status = "Analytics loaded"
 		return m, nil
 
+	case listSessionsMsg:
+		return m.handleListSessions(msg)
+
+	case revokeSessionMsg:
+		return m.handleRevokeSession(msg)
+
+	case topURLsMsg:
+		return m.handleTopURLs(msg)
+
 	case deleteURLMsg:
+		if m.bulkDeleteTotal > 0 {
+			return m.handleBulkDeleteResult(msg)
+		}
 		m.deleteLoading = false
 		m.mode = modeBrowsing
 		m.deleteConfirmShortCode = ""
 		m.deleteConfirmOriginalURL = ""
 		if msg.err != nil {
 			if apiErr, ok := msg.err.(*client.APIError); ok {
-				if apiErr.StatusCode == 404 {
+				if client.IsErrorCode(apiErr, client.CodeURLNotFound, http.StatusNotFound) {
 					m.status = fmt.Sprintf("Delete: %s not found (already deleted?)", msg.shortCode)
 					m.loading = true
 					return m, tea.Batch(m.spinner.Tick, listURLsCmd(m.cfg, m.pageSize, m.offset))
@@ -481,34 +642,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		if m.total > 0 {
-			m.total--
-		}
-
-		remaining := make([]tuiURL, 0, len(m.urls))
-		for _, u := range m.urls {
-			if u.ShortCode == msg.shortCode {
-				continue
-			}
-			remaining = append(remaining, u)
-		}
-		m.urls = remaining
-
-		remainingFiltered := make([]tuiURL, 0, len(m.filtered))
-		for _, u := range m.filtered {
-			if u.ShortCode == msg.shortCode {
-				continue
-			}
-			remainingFiltered = append(remainingFiltered, u)
-		}
-		m.filtered = remainingFiltered
-		if m.cursor >= len(m.filtered) {
-			m.cursor = len(m.filtered) - 1
-		}
-		if m.cursor < 0 {
-			m.cursor = 0
-		}
-
+		delete(m.selected, msg.shortCode)
+		m.removeURL(msg.shortCode)
 		m.status = fmt.Sprintf("Deleted: %s", msg.shortCode)
 		return m, nil
 
@@ -563,6 +698,16 @@ func (m model) View() tea.View {
 		modeLabel = "Time Range"
 	case modeDeleteConfirm:
 		modeLabel = "Delete"
+	case modeJumpToPage:
+		modeLabel = "Jump"
+	case modeSessions:
+		modeLabel = "Sessions"
+	case modeLeaderboard:
+		modeLabel = "Leaderboard"
+	case modeHelp:
+		modeLabel = "Help"
+	case modeDetail:
+		modeLabel = "Detail"
 	}
 
 	title := styles.TitleStyle.Render(fmt.Sprintf("mjr.wtf TUI · %s", modeLabel))
@@ -587,6 +732,9 @@ func (m model) View() tea.View {
 
 	v := tea.NewView(body + "\n")
 	v.AltScreen = true
+	if m.mouse {
+		v.MouseMode = tea.MouseModeCellMotion
+	}
 	return v
 }
 
@@ -599,7 +747,18 @@ func (m model) mainLine() string {
 	case modeViewingAnalytics:
 		return m.analyticsView()
 	case modeDeleteConfirm:
+		if len(m.deleteConfirmCodes) > 0 {
+			return m.bulkDeleteConfirmView()
+		}
 		return m.deleteConfirmView()
+	case modeSessions:
+		return m.sessionsView()
+	case modeLeaderboard:
+		return m.leaderboardView()
+	case modeHelp:
+		return m.helpView()
+	case modeDetail:
+		return m.detailView()
 	default:
 		if m.loading {
 			return styles.MutedStyle.Render(fmt.Sprintf("%s Loading URLs...", m.spinner.View()))
@@ -627,25 +786,39 @@ func (m model) mainLine() string {
 			}
 		}
 
+		now := time.Now()
 		rows := make([][]string, 0, len(m.filtered))
-		for _, u := range m.filtered {
+		for i, u := range m.filtered {
+			base := styles.UnselectedRowStyle
+			if i == m.cursor {
+				base = styles.SelectedRowStyle
+			}
+			hit := m.filterMatches[u.ShortCode]
 			created := ""
 			if u.CreatedAt != nil {
 				created = u.CreatedAt.Format("2006-01-02 15:04:05")
+				if m.relativeTimes {
+					created = relativeTime(*u.CreatedAt, now)
+				}
+			}
+			mark := " "
+			if m.selected[u.ShortCode] {
+				mark = "✓"
 			}
 			rows = append(rows, []string{
-				u.ShortCode,
+				mark,
+				highlightMatches(u.ShortCode, hit.codeIdx, base),
 				created,
 				fmt.Sprintf("%d", u.ClickCount),
-				truncate(u.OriginalURL, urlMax),
+				highlightMatches(truncate(u.OriginalURL, urlMax), hit.originIdx, base),
 			})
 		}
 
 		t := table.New().
-			Headers("short_code", "created_at", "click_count", "original_url").
+			Headers(" ", "short_code", "created_at", "click_count", "original_url").
 			Rows(rows...).
 			Border(lipgloss.RoundedBorder()).
-			BorderStyle(styles.BorderStyle).
+			BorderStyle(styles.TableBorderStyle).
 			Wrap(false).
 			StyleFunc(func(row, col int) lipgloss.Style {
 				cell := styles.UnselectedRowStyle
@@ -655,7 +828,10 @@ func (m model) mainLine() string {
 					cell = styles.SelectedRowStyle
 				}
 				cell = cell.Padding(0, 1)
-				if col == 2 {
+				if col == 0 && row != table.HeaderRow && m.selected[m.filtered[row].ShortCode] {
+					cell = cell.Foreground(styles.Green).Bold(true)
+				}
+				if col == 3 {
 					cell = cell.Align(lipgloss.Right)
 				}
 				return cell
@@ -728,7 +904,7 @@ func statusKindFromText(status string) statusKind {
 	}
 
 	// Prefer errors/warnings first so non-status text (e.g. URLs) can't accidentally override them.
-	if strings.HasPrefix(lower, "create failed") || strings.HasPrefix(lower, "delete failed") || strings.HasPrefix(lower, "list failed") || strings.HasPrefix(lower, "analytics failed") || strings.HasPrefix(lower, "failed:") || strings.HasPrefix(lower, "error:") {
+	if strings.HasPrefix(lower, "create failed") || strings.HasPrefix(lower, "delete failed") || strings.HasPrefix(lower, "list failed") || strings.HasPrefix(lower, "analytics failed") || strings.HasPrefix(lower, "sessions failed") || strings.HasPrefix(lower, "revoke failed") || strings.HasPrefix(lower, "leaderboard failed") || strings.HasPrefix(lower, "open failed") || strings.HasPrefix(lower, "failed:") || strings.HasPrefix(lower, "error:") {
 		return statusKindError
 	}
 	if strings.Contains(lower, "not found") {
@@ -738,7 +914,7 @@ func statusKindFromText(status string) statusKind {
 		return statusKindWarning
 	}
 
-	if strings.HasPrefix(lower, "created:") || strings.HasPrefix(lower, "deleted:") {
+	if strings.HasPrefix(lower, "created:") || strings.HasPrefix(lower, "deleted:") || strings.HasPrefix(lower, "revoked:") || strings.HasPrefix(lower, "opened:") {
 		return statusKindSuccess
 	}
 	if strings.Contains(lower, "success") || strings.Contains(lower, "copied") {
@@ -762,28 +938,50 @@ func statusStyleForText(status string) lipgloss.Style {
 }
 
 func (m model) footer() string {
-	hintsLine := "[j/k/↑/↓] move  [n/p] page  [/] filter  [c] create  [d] delete  [a] analytics  [r] refresh  [q] quit"
+	hintsLine := fmt.Sprintf("Page %d/%d  [j/k/↑/↓] move  [n/p] page  [g] go to page  [/] filter  [i] details  [t] time format  [space] select  [c] create  [d] delete  [S] sort  [m] copy md link  [o] open  [a] analytics  [s] sessions  [L] leaderboard  [r] refresh  [?] help  [q] quit", m.currentPage(), m.totalPages())
 	switch m.mode {
 	case modeCreating:
 		hintsLine = "[enter] submit  [esc] cancel  [q] quit"
 	case modeViewingAnalytics:
-		hintsLine = "[j/k/↑/↓] scroll  [t] time range  [r] refresh  [b/esc] back  [q] quit"
+		hintsLine = "[j/k/↑/↓] scroll  [t] time range  [h] hourly/daily  [r] refresh  [b/esc] back  [q] quit"
 	case modeAnalyticsTimeRange:
 		hintsLine = "[tab] switch field  [enter] next/apply  [esc] cancel  [q] quit"
 	case modeDeleteConfirm:
 		hintsLine = "[enter/y] confirm  [esc/n] cancel  [q] quit"
+	case modeJumpToPage:
+		hintsLine = "[0-9] page number  [enter] jump  [esc] cancel  [q] quit"
+	case modeSessions:
+		hintsLine = "[j/k/↑/↓] move  [d] revoke  [r] refresh  [b/esc] back  [q] quit"
+		if m.sessionRevokeID != "" {
+			hintsLine = "[enter/y] confirm revoke  [esc/n] cancel  [q] quit"
+		}
+	case modeLeaderboard:
+		hintsLine = "[r] refresh  [b/esc] back  [?] help  [q] quit"
+	case modeFiltering:
+		hintsLine = "[enter] keep filter  [esc] clear  [tab] fuzzy/substring  [q] quit"
+	case modeHelp:
+		hintsLine = "[?/esc] close help  [q] quit"
+	case modeDetail:
+		hintsLine = "[b/esc/i] back  [?] help  [q] quit"
 	}
 
 	hints := styles.HintStyle.Render(hintsLine)
 	status := m.status
 	if m.mode == modeFiltering {
 		status = fmt.Sprintf("Filter: %s", m.filterQuery)
+		if m.fuzzyFilter {
+			status = fmt.Sprintf("Fuzzy filter: %s", m.filterQuery)
+		}
+	}
+	if m.mode == modeJumpToPage {
+		status = fmt.Sprintf("Go to page (1-%d): %s", m.totalPages(), m.jumpPageInput)
 	}
 	if status == "" {
 		status = " "
 	}
+	indicator := m.healthIndicator()
 	if m.width > 0 {
-		max := m.width - statusWidthMargin
+		max := m.width - statusWidthMargin - lipgloss.Width(indicator) - 1
 		if max < minStatusWidth {
 			max = minStatusWidth
 		}
@@ -792,7 +990,18 @@ func (m model) footer() string {
 
 	statusRendered := statusStyleForText(status).Render(status)
 	statusBox := styles.StatusBarStyle.Render(statusRendered)
-	return fmt.Sprintf("%s\n%s", hints, statusBox)
+	return fmt.Sprintf("%s\n%s %s", hints, statusBox, indicator)
+}
+
+// analyticsGranularity is the bucket size to request: none for all-time, otherwise day or hour.
+func (m model) analyticsGranularity() string {
+	if m.analyticsStartTime == nil || m.analyticsEndTime == nil {
+		return ""
+	}
+	if m.analyticsHourly {
+		return "hour"
+	}
+	return "day"
 }
 
 func (m model) analyticsVisibleLines() int {
@@ -807,6 +1016,23 @@ func (m model) analyticsVisibleLines() int {
 	return v
 }
 
+// analyticsMaxScroll is the largest scroll offset that still fills the view.
+func (m model) analyticsMaxScroll() int {
+	if n, visible := len(m.analyticsLines()), m.analyticsVisibleLines(); n > visible {
+		return n - visible
+	}
+	return 0
+}
+
+func (m *model) clampAnalyticsScroll() {
+	if maxScroll := m.analyticsMaxScroll(); m.analyticsScroll > maxScroll {
+		m.analyticsScroll = maxScroll
+	}
+	if m.analyticsScroll < 0 {
+		m.analyticsScroll = 0
+	}
+}
+
 func (m model) analyticsTimeRangeView() string {
 	startBox := styles.InputBoxStyle
 	startLabel := styles.MutedStyle.Render("start_time:")
@@ -850,15 +1076,11 @@ func (m model) analyticsView() string {
 	}
 
 	visible := m.analyticsVisibleLines()
-	maxScroll := 0
-	if len(lines) > visible {
-		maxScroll = len(lines) - visible
-	}
 	scroll := m.analyticsScroll
 	if scroll < 0 {
 		scroll = 0
 	}
-	if scroll > maxScroll {
+	if maxScroll := m.analyticsMaxScroll(); scroll > maxScroll {
 		scroll = maxScroll
 	}
 
@@ -894,6 +1116,9 @@ func (m model) analyticsLines() []string {
 	rangeLabel := "Time range: all-time"
 	if m.analyticsStartTime != nil && m.analyticsEndTime != nil {
 		rangeLabel = fmt.Sprintf("Time range: %s → %s", m.analyticsStartTime.UTC().Format(time.RFC3339), m.analyticsEndTime.UTC().Format(time.RFC3339))
+		if m.analyticsHourly {
+			rangeLabel += " (hourly)"
+		}
 	}
 
 	headerLines := []string{
@@ -902,8 +1127,11 @@ func (m model) analyticsLines() []string {
 		fmt.Sprintf("%s %s", styles.MutedStyle.Render("Short code:"), shortCode),
 		fmt.Sprintf("%s %s", styles.MutedStyle.Render("Original URL:"), originalURL),
 		fmt.Sprintf("%s %s", styles.MutedStyle.Render("Total clicks:"), totalClicks),
-		styles.MutedStyle.Render(rangeLabel),
 	}
+	if m.analytics.UniqueVisitors != nil {
+		headerLines = append(headerLines, fmt.Sprintf("%s %s", styles.MutedStyle.Render("Unique visitors:"), styles.SuccessStyle.Render(fmt.Sprintf("%d", *m.analytics.UniqueVisitors))))
+	}
+	headerLines = append(headerLines, styles.MutedStyle.Render(rangeLabel))
 	box := styles.BorderStyle.Copy().BorderForeground(styles.Mauve).Padding(1, 2).Render(strings.Join(headerLines, "\n"))
 
 	lines := splitRenderedLines(box)
@@ -912,9 +1140,27 @@ func (m model) analyticsLines() []string {
 	lines = append(lines, formatTopMapSection("By country", m.analytics.ByCountry, 50)...)
 	lines = append(lines, "")
 	lines = append(lines, formatTopMapSection("By referrer", m.analytics.ByReferrer, 50)...)
+	lines = append(lines, "")
+	lines = append(lines, formatTopMapSection("By browser", m.analytics.ByBrowser, 10)...)
+	lines = append(lines, "")
+	lines = append(lines, formatTopMapSection("By OS", m.analytics.ByOS, 10)...)
+	barWidth := defaultDateBarWidth
+	if m.width > 0 {
+		barWidth = m.width - dateBarWidthMargin
+		if barWidth < 0 {
+			barWidth = 0
+		}
+		if barWidth > maxDateBarWidth {
+			barWidth = maxDateBarWidth
+		}
+	}
 	if len(m.analytics.ByDate) > 0 {
 		lines = append(lines, "")
-		lines = append(lines, formatDateMapSection("By date", m.analytics.ByDate, 60)...)
+		lines = append(lines, formatDateMapSection("By date", m.analytics.ByDate, 60, barWidth)...)
+	}
+	if len(m.analytics.ByHour) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, formatDateMapSection("By hour (UTC)", m.analytics.ByHour, 72, barWidth)...)
 	}
 
 	return lines
@@ -933,12 +1179,6 @@ func splitRenderedLines(s string) []string {
 }
 
 func formatTopMapSection(title string, in map[string]int64, maxItems int) []string {
-	inner := []string{styles.TitleStyle.Copy().Bold(true).Render(title), ""}
-	if len(in) == 0 {
-		inner = append(inner, styles.MutedStyle.Render("(none)"))
-		return splitRenderedLines(styles.BorderStyle.Copy().Padding(1, 2).Render(strings.Join(inner, "\n")))
-	}
-
 	items := make([]kv, 0, len(in))
 	for k, v := range in {
 		items = append(items, kv{k: k, v: v})
@@ -950,6 +1190,18 @@ func formatTopMapSection(title string, in map[string]int64, maxItems int) []stri
 		return items[i].v > items[j].v
 	})
 
+	return formatTopSection(title, items, maxItems)
+}
+
+// formatTopSection renders items in the order given, highlighting the first row.
+// Callers sort them; formatTopMapSection ranks by count, the leaderboard keeps the server's order.
+func formatTopSection(title string, items []kv, maxItems int) []string {
+	inner := []string{styles.TitleStyle.Copy().Bold(true).Render(title), ""}
+	if len(items) == 0 {
+		inner = append(inner, styles.MutedStyle.Render("(none)"))
+		return splitRenderedLines(styles.BorderStyle.Copy().Padding(1, 2).Render(strings.Join(inner, "\n")))
+	}
+
 	shown := items
 	if maxItems > 0 && len(items) > maxItems {
 		shown = items[:maxItems]
@@ -972,7 +1224,9 @@ func formatTopMapSection(title string, in map[string]int64, maxItems int) []stri
 	return splitRenderedLines(styles.BorderStyle.Copy().Padding(1, 2).Render(strings.Join(inner, "\n")))
 }
 
-func formatDateMapSection(title string, in map[string]int64, maxItems int) []string {
+// formatDateMapSection renders per-day counts as a horizontal bar chart, scaled
+// so the busiest day fills barWidth cells. A barWidth of 0 hides the bars.
+func formatDateMapSection(title string, in map[string]int64, maxItems int, barWidth int) []string {
 	inner := []string{styles.TitleStyle.Copy().Bold(true).Render(title), ""}
 	if len(in) == 0 {
 		inner = append(inner, styles.MutedStyle.Render("(none)"))
@@ -990,13 +1244,49 @@ func formatDateMapSection(title string, in map[string]int64, maxItems int) []str
 		shown = keys[:maxItems]
 	}
 
-	rowStyle := lipgloss.NewStyle().Background(styles.Surface0).Foreground(styles.Text).Padding(0, 1)
+	var maxCount int64
 	for _, k := range shown {
-		row := fmt.Sprintf("%s  %10d", k, in[k])
-		inner = append(inner, rowStyle.Render(row))
+		if in[k] > maxCount {
+			maxCount = in[k]
+		}
+	}
+
+	labelStyle := lipgloss.NewStyle().Background(styles.Surface0).Foreground(styles.Text).Padding(0, 1)
+	barStyle := lipgloss.NewStyle().Background(styles.Surface0).Foreground(styles.Sapphire)
+	peakBarStyle := barStyle.Copy().Foreground(styles.Lavender)
+	countStyle := lipgloss.NewStyle().Background(styles.Surface0).Foreground(styles.Text).Padding(0, 1)
+	for _, k := range shown {
+		v := in[k]
+		row := labelStyle.Render(k)
+		if barWidth > 0 {
+			bar := dateBar(v, maxCount, barWidth)
+			style := barStyle
+			if v == maxCount {
+				style = peakBarStyle
+			}
+			row += style.Render(bar + strings.Repeat(" ", barWidth-utf8.RuneCountInString(bar)))
+		}
+		row += countStyle.Render(fmt.Sprintf("%10d", v))
+		inner = append(inner, row)
 	}
 	if len(shown) < len(keys) {
 		inner = append(inner, styles.MutedStyle.Render(fmt.Sprintf("…and %d more", len(keys)-len(shown))))
 	}
 	return splitRenderedLines(styles.BorderStyle.Copy().Padding(1, 2).Render(strings.Join(inner, "\n")))
 }
+
+// dateBar returns a bar of block characters proportional to v/maxCount.
+// Non-zero counts always get at least one cell so quiet days remain visible.
+func dateBar(v, maxCount int64, width int) string {
+	if width <= 0 || maxCount <= 0 || v <= 0 {
+		return ""
+	}
+	n := int((v*int64(width) + maxCount/2) / maxCount)
+	if n < 1 {
+		n = 1
+	}
+	if n > width {
+		n = width
+	}
+	return strings.Repeat("█", n)
+}
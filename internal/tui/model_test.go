@@ -1,11 +1,14 @@
 package tui
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/matt-riley/mjrwtf/internal/client"
@@ -258,6 +261,115 @@ func TestModel_View_EmptyState_FilterNotMisleading(t *testing.T) {
 	}
 }
 
+func TestModel_PageComputation(t *testing.T) {
+	tests := []struct {
+		name      string
+		offset    int
+		total     int
+		wantPage  int
+		wantPages int
+	}{
+		{"first_page", 0, 45, 1, 3},
+		{"last_partial_page", 40, 45, 3, 3},
+		{"exact_multiple", 20, 40, 2, 2},
+		{"empty", 0, 0, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+			m.offset = tt.offset
+			m.total = tt.total
+			if got := m.currentPage(); got != tt.wantPage {
+				t.Fatalf("currentPage()=%d want %d", got, tt.wantPage)
+			}
+			if got := m.totalPages(); got != tt.wantPages {
+				t.Fatalf("totalPages()=%d want %d", got, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestModel_Footer_ShowsPageIndicator(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.offset = 20
+	m.total = 45
+
+	if out := m.footer(); !strings.Contains(out, "Page 2/3") {
+		t.Fatalf("expected page indicator in footer, got:\n%s", out)
+	}
+}
+
+func TestModel_Update_JumpToPage_ClampsBeyondLastPage(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.total = 45
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	mm := m2.(model)
+	if mm.mode != modeJumpToPage {
+		t.Fatalf("expected jump mode, got %v", mm.mode)
+	}
+
+	for _, r := range "99" {
+		m2, _ = mm.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		mm = m2.(model)
+	}
+	// Non-digits are ignored.
+	m2, _ = mm.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	mm = m2.(model)
+	if mm.jumpPageInput != "99" {
+		t.Fatalf("jumpPageInput=%q", mm.jumpPageInput)
+	}
+
+	m2, cmd := mm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	mm = m2.(model)
+	if cmd == nil {
+		t.Fatalf("expected list cmd")
+	}
+	if mm.mode != modeBrowsing {
+		t.Fatalf("expected browsing mode after jump, got %v", mm.mode)
+	}
+	if mm.offset != 40 {
+		t.Fatalf("expected offset clamped to last page (40), got %d", mm.offset)
+	}
+	if !mm.loading {
+		t.Fatalf("expected loading=true")
+	}
+}
+
+func TestModel_Update_JumpToPage_ClampsBelowFirstPage(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.offset = 20
+	m.total = 45
+
+	m2, _ := m.jumpToPage(0)
+	mm := m2.(model)
+	if mm.offset != 0 {
+		t.Fatalf("expected offset 0, got %d", mm.offset)
+	}
+}
+
+func TestModel_Update_JumpToPage_EscCancels(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.offset = 20
+	m.total = 45
+	m.mode = modeJumpToPage
+	m.jumpPageInput = "1"
+
+	m2, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	mm := m2.(model)
+	if cmd != nil {
+		t.Fatalf("expected no cmd")
+	}
+	if mm.mode != modeBrowsing || mm.offset != 20 {
+		t.Fatalf("mode=%v offset=%d", mm.mode, mm.offset)
+	}
+}
+
 func TestListURLsCmd(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/api/urls" {
@@ -383,6 +495,45 @@ func TestModel_Update_AnalyticsTimeRange_SuccessFetches(t *testing.T) {
 	}
 }
 
+func TestModel_Update_AnalyticsHourlyToggle(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.mode = modeViewingAnalytics
+	m.analyticsShortCode = "abc123"
+
+	m2, cmd := m.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	mm := m2.(model)
+	if cmd != nil || mm.analyticsHourly {
+		t.Fatalf("expected no fetch without a time range, hourly=%v", mm.analyticsHourly)
+	}
+	if !strings.Contains(mm.status, "time range") {
+		t.Fatalf("status=%q", mm.status)
+	}
+
+	start := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 11, 20, 23, 59, 59, 0, time.UTC)
+	mm.analyticsStartTime = &start
+	mm.analyticsEndTime = &end
+	if got := mm.analyticsGranularity(); got != "day" {
+		t.Fatalf("granularity=%q, want day", got)
+	}
+
+	m3, cmd := mm.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	mm = m3.(model)
+	if !mm.analyticsHourly || !mm.analyticsLoading || cmd == nil {
+		t.Fatalf("expected hourly fetch, hourly=%v loading=%v", mm.analyticsHourly, mm.analyticsLoading)
+	}
+	if got := mm.analyticsGranularity(); got != "hour" {
+		t.Fatalf("granularity=%q, want hour", got)
+	}
+
+	mm.analyticsLoading = false
+	mm.analytics = &client.GetAnalyticsResponse{ShortCode: "abc123", ByHour: map[string]int64{"2025-11-20 09:00": 4}}
+	view := strings.Join(mm.analyticsLines(), "\n")
+	if !strings.Contains(view, "By hour") || !strings.Contains(view, "2025-11-20 09:00") {
+		t.Fatalf("expected hourly section in view:\n%s", view)
+	}
+}
+
 func TestDeleteURLCmd(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodDelete {
@@ -496,6 +647,34 @@ func TestModel_Update_DeleteURLMsg_NotFoundRefreshes(t *testing.T) {
 	}
 }
 
+func TestModel_Update_DeleteURLMsg_BranchesOnErrorCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         *client.APIError
+		wantRefresh bool
+	}{
+		{"url_not_found code", &client.APIError{StatusCode: 404, Code: client.CodeURLNotFound, Message: "url not found"}, true},
+		{"other code on 404", &client.APIError{StatusCode: 404, Code: "route_not_found", Message: "no such route"}, false},
+		{"forbidden code", &client.APIError{StatusCode: 403, Code: client.CodeForbidden, Message: "forbidden"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+			m.loading = false
+
+			m2, cmd := m.Update(deleteURLMsg{shortCode: "abc123", err: tt.err})
+			mm := m2.(model)
+			if got := cmd != nil; got != tt.wantRefresh {
+				t.Fatalf("refresh=%v, want %v (status=%q)", got, tt.wantRefresh, mm.status)
+			}
+			if !tt.wantRefresh && !strings.HasPrefix(mm.status, "Delete failed") {
+				t.Fatalf("status=%q", mm.status)
+			}
+		})
+	}
+}
+
 func TestModel_Update_DeleteURLMsg_APIErrorDoesNotRefresh(t *testing.T) {
 	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
 	m.loading = false
@@ -525,3 +704,415 @@ func TestModel_Update_DeleteURLMsg_APIErrorDoesNotRefresh(t *testing.T) {
 		t.Fatalf("expected confirm fields cleared")
 	}
 }
+
+func TestModel_Update_CopyMarkdownLink(t *testing.T) {
+	old := clipboardWriteAll
+	defer func() { clipboardWriteAll = old }()
+
+	var copied string
+	clipboardWriteAll = func(s string) error {
+		copied = s
+		return nil
+	}
+
+	m := newModel(tui_config.Config{BaseURL: "https://mjr.wtf/", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{
+		{ShortCode: "abc123", OriginalURL: "https://example.com/docs"},
+		{ShortCode: "long01", OriginalURL: "https://example.com/a/very/long/path/that/keeps/going/and/going/forever?q=[1]"},
+	}
+	m.filtered = m.urls
+	m.cursor = 0
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	mm := m2.(model)
+	if copied != "[https://example.com/docs](https://mjr.wtf/abc123)" {
+		t.Fatalf("copied=%q", copied)
+	}
+	if !strings.Contains(mm.status, "Copied Markdown link") {
+		t.Fatalf("status=%q", mm.status)
+	}
+
+	mm.cursor = 1
+	_, _ = mm.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	if want := "[https://example.com/a/very/long/path/that/keeps/going/and/g…](https://mjr.wtf/long01)"; copied != want {
+		t.Fatalf("copied=%q, want %q", copied, want)
+	}
+}
+
+func TestModel_Update_CopyMarkdownLink_ClipboardError(t *testing.T) {
+	old := clipboardWriteAll
+	defer func() { clipboardWriteAll = old }()
+	clipboardWriteAll = func(string) error { return errors.New("no clipboard") }
+
+	m := newModel(tui_config.Config{BaseURL: "https://mjr.wtf", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{{ShortCode: "abc123", OriginalURL: "https://example.com"}}
+	m.filtered = m.urls
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	if status := m2.(model).status; !strings.Contains(status, "Copy failed: no clipboard") {
+		t.Fatalf("status=%q", status)
+	}
+}
+
+func TestMarkdownLink_EscapesBrackets(t *testing.T) {
+	got := markdownLink("https://mjr.wtf", tuiURL{ShortCode: "x1y", OriginalURL: "https://example.com/?a=[b]"})
+	if want := `[https://example.com/?a=\[b\]](https://mjr.wtf/x1y)`; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestModel_Update_HelpToggle(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     viewMode
+		closeKey tea.KeyPressMsg
+	}{
+		{"browsing_esc", modeBrowsing, tea.KeyPressMsg{Code: tea.KeyEscape}},
+		{"analytics_esc", modeViewingAnalytics, tea.KeyPressMsg{Code: tea.KeyEscape}},
+		{"sessions_question_mark", modeSessions, tea.KeyPressMsg{Code: '?', Text: "?"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newModel(tui_config.Config{BaseURL: "http://example", Token: "abcdef"}, nil)
+			m.mode = tt.mode
+
+			m2, _ := m.Update(tea.KeyPressMsg{Code: '?', Text: "?"})
+			mm := m2.(model)
+			if mm.mode != modeHelp {
+				t.Fatalf("mode=%v want modeHelp", mm.mode)
+			}
+			if out := mm.View().Content; !strings.Contains(out, "Keyboard shortcuts") || !strings.Contains(out, "Session manager") {
+				t.Fatalf("expected help overlay with every mode's bindings")
+			}
+
+			m3, _ := mm.Update(tt.closeKey)
+			if got := m3.(model).mode; got != tt.mode {
+				t.Fatalf("mode=%v want %v", got, tt.mode)
+			}
+		})
+	}
+}
+
+func TestModel_Update_HelpKeyTypesInTextModes(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "abcdef"}, nil)
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'c', Text: "c"})
+	mm := m2.(model)
+
+	m3, _ := mm.Update(tea.KeyPressMsg{Code: '?', Text: "?"})
+	mm = m3.(model)
+	if mm.mode != modeCreating {
+		t.Fatalf("mode=%v want modeCreating", mm.mode)
+	}
+	if got := mm.createInput.Value(); got != "?" {
+		t.Fatalf("create input=%q want %q", got, "?")
+	}
+}
+
+func TestModel_Update_CycleSortSavesPreference(t *testing.T) {
+	old := updatePreferences
+	defer func() { updatePreferences = old }()
+	var saved tui_config.Preferences
+	updatePreferences = func(update func(*tui_config.Preferences)) error {
+		update(&saved)
+		return nil
+	}
+
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "abcdef"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{
+		{ShortCode: "bbb", ClickCount: 1},
+		{ShortCode: "ccc", ClickCount: 9},
+		{ShortCode: "aaa", ClickCount: 5},
+	}
+	m.applyFilter()
+
+	codes := func(m model) string {
+		var out []string
+		for _, u := range m.filtered {
+			out = append(out, u.ShortCode)
+		}
+		return strings.Join(out, ",")
+	}
+
+	steps := []struct {
+		sort  string
+		order string
+	}{
+		{tui_config.SortClicks, "ccc,aaa,bbb"},
+		{tui_config.SortCode, "aaa,bbb,ccc"},
+		{tui_config.SortCreated, "bbb,ccc,aaa"},
+	}
+	for _, step := range steps {
+		m2, cmd := m.Update(tea.KeyPressMsg{Code: 's', ShiftedCode: 'S', Mod: tea.ModShift, Text: "S"})
+		m = m2.(model)
+		if m.cfg.Sort != step.sort {
+			t.Fatalf("sort=%q want %q", m.cfg.Sort, step.sort)
+		}
+		if got := codes(m); got != step.order {
+			t.Fatalf("sort %q: order=%s want %s", step.sort, got, step.order)
+		}
+		if cmd == nil {
+			t.Fatalf("expected a cmd to save the sort")
+		}
+		m3, _ := m.Update(cmd())
+		m = m3.(model)
+		if saved.Sort != step.sort {
+			t.Fatalf("saved sort=%q want %q", saved.Sort, step.sort)
+		}
+	}
+
+	updatePreferences = func(func(*tui_config.Preferences)) error { return errors.New("read-only") }
+	m2, cmd := m.Update(tea.KeyPressMsg{Code: 's', ShiftedCode: 'S', Mod: tea.ModShift, Text: "S"})
+	m3, _ := m2.(model).Update(cmd())
+	if status := m3.(model).status; !strings.Contains(status, "could not save preferences: read-only") {
+		t.Fatalf("status=%q", status)
+	}
+}
+
+func TestModel_Update_SpaceTogglesSelection(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{{ShortCode: "abc123"}, {ShortCode: "def456"}}
+	m.filtered = m.urls
+
+	space := tea.KeyPressMsg{Code: tea.KeySpace, Text: " "}
+	m2, _ := m.Update(space)
+	mm := m2.(model)
+	mm.cursorDown()
+	m2, _ = mm.Update(space)
+	mm = m2.(model)
+	if !mm.selected["abc123"] || !mm.selected["def456"] {
+		t.Fatalf("selected=%v", mm.selected)
+	}
+	if !strings.Contains(mm.View().Content, "✓") {
+		t.Fatalf("expected a checkmark for selected rows")
+	}
+
+	m2, _ = mm.Update(space)
+	mm = m2.(model)
+	if mm.selected["def456"] || len(mm.selected) != 1 {
+		t.Fatalf("expected def456 to be unselected, selected=%v", mm.selected)
+	}
+	if mm.status != "1 selected" {
+		t.Fatalf("status=%q", mm.status)
+	}
+
+	// A page load drops marks for URLs that are no longer shown
+	m2, _ = mm.Update(listURLsMsg{urls: []tuiURL{{ShortCode: "zzz999"}}, total: 1})
+	if sel := m2.(model).selected; len(sel) != 0 {
+		t.Fatalf("expected selection to be pruned, got %v", sel)
+	}
+}
+
+func TestModel_Update_BulkDeleteRemovesSelected(t *testing.T) {
+	var deleted atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deleted.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	m := newModel(tui_config.Config{BaseURL: ts.URL, Token: "t"}, nil)
+	m.loading = false
+	m.total = 3
+	m.urls = []tuiURL{{ShortCode: "abc123"}, {ShortCode: "def456"}, {ShortCode: "ghi789"}}
+	m.filtered = m.urls
+	m.selected = map[string]bool{"abc123": true, "ghi789": true}
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	mm := m2.(model)
+	if mm.mode != modeDeleteConfirm {
+		t.Fatalf("mode=%v want modeDeleteConfirm", mm.mode)
+	}
+	if !strings.Contains(mm.View().Content, "Confirm Delete: 2 URLs") {
+		t.Fatalf("expected the confirmation to show the count")
+	}
+
+	m2, _ = mm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	mm = m2.(model)
+	if !mm.deleteLoading {
+		t.Fatalf("expected deleteLoading=true")
+	}
+	for _, code := range []string{"abc123", "ghi789"} {
+		msg := deleteURLCmd(mm.cfg, code)()
+		m2, _ = mm.Update(msg)
+		mm = m2.(model)
+	}
+
+	if deleted.Load() != 2 {
+		t.Fatalf("deleted %d URLs, want 2", deleted.Load())
+	}
+	if len(mm.urls) != 1 || mm.urls[0].ShortCode != "def456" {
+		t.Fatalf("urls=%v", mm.urls)
+	}
+	if mm.total != 1 || len(mm.selected) != 0 || mm.mode != modeBrowsing || mm.deleteLoading {
+		t.Fatalf("total=%d selected=%v mode=%v deleteLoading=%v", mm.total, mm.selected, mm.mode, mm.deleteLoading)
+	}
+	if mm.status != "Deleted: 2 URLs" {
+		t.Fatalf("status=%q", mm.status)
+	}
+}
+
+func TestModel_Update_BulkDeletePartialFailure(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.total = 3
+	m.urls = []tuiURL{{ShortCode: "abc123"}, {ShortCode: "def456"}, {ShortCode: "ghi789"}}
+	m.filtered = m.urls
+	m.selected = map[string]bool{"abc123": true, "def456": true, "ghi789": true}
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	m2, cmd := m2.(model).Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if cmd == nil {
+		t.Fatalf("expected delete cmds")
+	}
+	mm := m2.(model)
+
+	results := []deleteURLMsg{
+		{shortCode: "abc123"},
+		{shortCode: "def456", err: &client.APIError{StatusCode: 403, Message: "forbidden"}},
+		{shortCode: "ghi789"},
+	}
+	for _, msg := range results {
+		m2, _ = mm.Update(msg)
+		mm = m2.(model)
+	}
+
+	if len(mm.urls) != 1 || mm.urls[0].ShortCode != "def456" {
+		t.Fatalf("urls=%v", mm.urls)
+	}
+	if !mm.selected["def456"] || len(mm.selected) != 1 {
+		t.Fatalf("expected the failed URL to stay selected, got %v", mm.selected)
+	}
+	if !strings.HasPrefix(mm.status, "Delete failed for 1 of 3 URLs (2 deleted)") {
+		t.Fatalf("status=%q", mm.status)
+	}
+}
+
+func TestModel_Update_FilterNarrowsAsYouType(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{
+		{ShortCode: "abc123", OriginalURL: "https://example.com"},
+		{ShortCode: "abd456", OriginalURL: "https://example.org"},
+		{ShortCode: "xyz789", OriginalURL: "https://other.net"},
+	}
+	m.applyFilter()
+	m.cursor = 2
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	mm := m2.(model)
+
+	steps := []struct {
+		key  rune
+		want int
+	}{
+		{'a', 2},
+		{'b', 2},
+		{'d', 1}, // keys that are list shortcuts still go to the filter
+		{'r', 0},
+	}
+	for _, step := range steps {
+		m2, _ = mm.Update(tea.KeyPressMsg{Code: step.key, Text: string(step.key)})
+		mm = m2.(model)
+		if mm.mode != modeFiltering {
+			t.Fatalf("mode=%v want modeFiltering", mm.mode)
+		}
+		if len(mm.filtered) != step.want {
+			t.Fatalf("query %q: filtered=%d want %d", mm.filterQuery, len(mm.filtered), step.want)
+		}
+		if mm.cursor < 0 || (len(mm.filtered) > 0 && mm.cursor >= len(mm.filtered)) {
+			t.Fatalf("query %q: cursor %d out of range for %d rows", mm.filterQuery, mm.cursor, len(mm.filtered))
+		}
+	}
+
+	m2, _ = mm.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	mm = m2.(model)
+	if len(mm.filtered) != 1 || mm.filtered[0].ShortCode != "abd456" {
+		t.Fatalf("after backspace filtered=%v", mm.filtered)
+	}
+
+	m2, _ = mm.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	mm = m2.(model)
+	if mm.mode != modeBrowsing || len(mm.filtered) != 1 {
+		t.Fatalf("mode=%v filtered=%d", mm.mode, len(mm.filtered))
+	}
+}
+
+func TestModel_Update_FilterEscRestoresListAndCursor(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{{ShortCode: "abc123"}, {ShortCode: "def456"}, {ShortCode: "ghi789"}}
+	m.applyFilter()
+	m.cursor = 2
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	m2, _ = m2.(model).Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	mm := m2.(model)
+	if len(mm.filtered) != 1 || mm.cursor != 0 {
+		t.Fatalf("filtered=%d cursor=%d", len(mm.filtered), mm.cursor)
+	}
+
+	m2, _ = mm.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	mm = m2.(model)
+	if mm.mode != modeBrowsing || len(mm.filtered) != 3 {
+		t.Fatalf("mode=%v filtered=%d", mm.mode, len(mm.filtered))
+	}
+	if mm.cursor != 2 {
+		t.Fatalf("cursor=%d want 2", mm.cursor)
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		ago  time.Duration
+		want string
+	}{
+		{"now", 0, "just now"},
+		{"seconds", 45 * time.Second, "45s ago"},
+		{"minutes", 3*time.Minute + 20*time.Second, "3m ago"},
+		{"hours", 5*time.Hour + 59*time.Minute, "5h ago"},
+		{"days", 3 * 24 * time.Hour, "3d ago"},
+		{"months", 75 * 24 * time.Hour, "2mo ago"},
+		{"years", 800 * 24 * time.Hour, "2y ago"},
+		{"slight clock skew", -20 * time.Second, "just now"},
+		{"future", -2 * time.Hour, "in 2h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := relativeTime(now.Add(-tt.ago), now); got != tt.want {
+				t.Fatalf("relativeTime(-%v)=%q want %q", tt.ago, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_Update_ToggleRelativeTimes(t *testing.T) {
+	created := time.Now().Add(-3 * time.Hour)
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{{ShortCode: "abc123", OriginalURL: "https://example.com", CreatedAt: &created}}
+	m.filtered = m.urls
+
+	absolute := created.Format("2006-01-02 15:04:05")
+	if out := m.View().Content; !strings.Contains(out, absolute) || strings.Contains(out, "3h ago") {
+		t.Fatalf("expected an absolute timestamp by default")
+	}
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	mm := m2.(model)
+	if out := mm.View().Content; !strings.Contains(out, "3h ago") || strings.Contains(out, absolute) {
+		t.Fatalf("expected a relative timestamp after toggling, got:\n%s", out)
+	}
+
+	m3, _ := mm.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	if !strings.Contains(m3.(model).View().Content, absolute) {
+		t.Fatalf("expected an absolute timestamp after toggling back")
+	}
+}
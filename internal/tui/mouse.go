@@ -0,0 +1,52 @@
+package tui
+
+import tea "charm.land/bubbletea/v2"
+
+// listFirstRowY is the screen row of the first URL in the list table: the
+// title, base URL and their blank lines take rows 0-3, then the table's top
+// border, header and header separator.
+const listFirstRowY = 7
+
+// handleMouseWheel scrolls whatever the current screen scrolls with j/k.
+func (m model) handleMouseWheel(msg tea.MouseWheelMsg) (tea.Model, tea.Cmd) {
+	down := msg.Button == tea.MouseWheelDown
+	if !down && msg.Button != tea.MouseWheelUp {
+		return m, nil
+	}
+
+	switch m.mode {
+	case modeBrowsing:
+		if down {
+			m.cursorDown()
+		} else {
+			m.cursorUp()
+		}
+	case modeViewingAnalytics:
+		if down && m.analyticsScroll < m.analyticsMaxScroll() {
+			m.analyticsScroll++
+		} else if !down && m.analyticsScroll > 0 {
+			m.analyticsScroll--
+		}
+	case modeSessions:
+		if m.sessionRevokeID != "" {
+			return m, nil
+		}
+		if down && m.sessionsCursor < len(m.sessions)-1 {
+			m.sessionsCursor++
+		} else if !down && m.sessionsCursor > 0 {
+			m.sessionsCursor--
+		}
+	}
+	return m, nil
+}
+
+// handleMouseClick selects the URL list row under a left click.
+func (m model) handleMouseClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd) {
+	if msg.Button != tea.MouseLeft || m.mode != modeBrowsing || m.loading {
+		return m, nil
+	}
+	if row := msg.Y - listFirstRowY; row >= 0 && row < len(m.filtered) {
+		m.cursor = row
+	}
+	return m, nil
+}
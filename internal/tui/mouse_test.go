@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/client"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+func TestModel_Update_MouseWheelMovesCursor(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{{ShortCode: "abc123"}, {ShortCode: "def456"}}
+	m.filtered = m.urls
+
+	m2, _ := m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	mm := m2.(model)
+	if mm.cursor != 1 {
+		t.Fatalf("cursor=%d want 1", mm.cursor)
+	}
+	m2, _ = mm.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if got := m2.(model).cursor; got != 0 {
+		t.Fatalf("cursor=%d want 0", got)
+	}
+}
+
+func TestModel_Update_MouseWheelScrollsAnalytics(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.mode = modeViewingAnalytics
+	m.height = 15
+	byCountry := map[string]int64{}
+	for _, c := range []string{"AA", "BB", "CC", "DD", "EE", "FF", "GG", "HH", "II", "JJ", "KK", "LL"} {
+		byCountry[c] = 1
+	}
+	m.analytics = &client.GetAnalyticsResponse{ShortCode: "abc123", TotalClicks: 12, ByCountry: byCountry}
+
+	m2, _ := m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if got := m2.(model).analyticsScroll; got != 1 {
+		t.Fatalf("analyticsScroll=%d want 1", got)
+	}
+}
+
+func TestModel_Update_MouseClickSelectsRow(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.urls = []tuiURL{{ShortCode: "abc123"}, {ShortCode: "def456"}, {ShortCode: "ghi789"}}
+	m.filtered = m.urls
+
+	// Find the screen row the third URL is drawn on
+	y := -1
+	for i, line := range strings.Split(m.View().Content, "\n") {
+		if strings.Contains(line, "ghi789") {
+			y = i
+		}
+	}
+	if y != listFirstRowY+2 {
+		t.Fatalf("ghi789 drawn on row %d, want %d; update listFirstRowY", y, listFirstRowY+2)
+	}
+
+	m2, _ := m.Update(tea.MouseClickMsg{Button: tea.MouseLeft, Y: y})
+	if got := m2.(model).cursor; got != 2 {
+		t.Fatalf("cursor=%d want 2", got)
+	}
+
+	// Clicks outside the rows leave the selection alone
+	m3, _ := m2.(model).Update(tea.MouseClickMsg{Button: tea.MouseLeft, Y: 1})
+	if got := m3.(model).cursor; got != 2 {
+		t.Fatalf("cursor=%d want 2", got)
+	}
+}
+
+func TestModel_View_MouseModeFollowsFlag(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	if got := m.View().MouseMode; got != tea.MouseModeNone {
+		t.Fatalf("MouseMode=%v want none by default", got)
+	}
+	m.mouse = true
+	if got := m.View().MouseMode; got != tea.MouseModeCellMotion {
+		t.Fatalf("MouseMode=%v want cell motion", got)
+	}
+}
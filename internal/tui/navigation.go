@@ -1,11 +1,18 @@
 package tui
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
 )
 
+// updatePreferences is swapped out in tests so they never touch the real file.
+var updatePreferences = tui_config.UpdatePreferences
+
 func (m *model) cursorDown() {
 	if len(m.filtered) == 0 {
 		m.cursor = 0
@@ -62,18 +69,126 @@ func (m model) prevPage() (tea.Model, tea.Cmd) {
 	return m, tea.Batch(m.spinner.Tick, listURLsCmd(m.cfg, m.pageSize, m.offset))
 }
 
+// currentPage returns the 1-based page number for the current offset.
+func (m model) currentPage() int {
+	if m.pageSize <= 0 {
+		return 1
+	}
+	return m.offset/m.pageSize + 1
+}
+
+// totalPages returns the number of pages needed to show total URLs (at least 1).
+func (m model) totalPages() int {
+	if m.pageSize <= 0 || m.total <= 0 {
+		return 1
+	}
+	return (m.total + m.pageSize - 1) / m.pageSize
+}
+
+func (m *model) startJumpToPage() {
+	m.mode = modeJumpToPage
+	m.jumpPageInput = ""
+}
+
+func (m *model) cancelJumpToPage() {
+	m.mode = modeBrowsing
+	m.jumpPageInput = ""
+	m.status = "Jump cancelled"
+}
+
+func (m *model) jumpPageInputKey(k tea.KeyPressMsg) {
+	s := k.String()
+	switch s {
+	case "backspace":
+		if len(m.jumpPageInput) > 0 {
+			m.jumpPageInput = m.jumpPageInput[:len(m.jumpPageInput)-1]
+		}
+	default:
+		if len(s) == 1 && s[0] >= '0' && s[0] <= '9' && len(m.jumpPageInput) < 6 {
+			m.jumpPageInput += s
+		}
+	}
+}
+
+func (m model) submitJumpToPage() (tea.Model, tea.Cmd) {
+	input := strings.TrimSpace(m.jumpPageInput)
+	m.mode = modeBrowsing
+	m.jumpPageInput = ""
+	if input == "" {
+		m.status = "Jump cancelled"
+		return m, nil
+	}
+	page, err := strconv.Atoi(input)
+	if err != nil {
+		m.status = "Error: invalid page number"
+		return m, nil
+	}
+	return m.jumpToPage(page)
+}
+
+// jumpToPage loads the given 1-based page, clamping it to the valid range.
+func (m model) jumpToPage(page int) (tea.Model, tea.Cmd) {
+	if m.loading {
+		return m, nil
+	}
+	if page < 1 {
+		page = 1
+	}
+	if last := m.totalPages(); page > last {
+		page = last
+	}
+	m.offset = (page - 1) * m.pageSize
+	m.loading = true
+	m.status = fmt.Sprintf("Loading page %d...", page)
+	m.cursor = 0
+	return m, tea.Batch(m.spinner.Tick, listURLsCmd(m.cfg, m.pageSize, m.offset))
+}
+
 func (m *model) startFilter() {
 	m.mode = modeFiltering
+	m.filterPrevShortCode = ""
+	if m.cursor >= 0 && m.cursor < len(m.filtered) {
+		m.filterPrevShortCode = m.filtered[m.cursor].ShortCode
+	}
 	m.filterQuery = ""
+	m.filterURLs()
+	m.clampCursor()
 }
 
+// cancelFilter clears the filter and puts the cursor back on the URL it was
+// on before filtering started.
 func (m *model) cancelFilter() {
-	m.mode = modeBrowsing
 	m.filterQuery = ""
 	m.applyFilter()
+	for i, u := range m.filtered {
+		if u.ShortCode == m.filterPrevShortCode {
+			m.cursor = i
+		}
+	}
 	m.status = "Filter cleared"
 }
 
+// updateFilterKey narrows the list live as the query is typed; enter commits
+// the filter and esc cancels it.
+func (m model) updateFilterKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.cancelFilter()
+	case "enter":
+		m.applyFilter()
+		m.status = fmt.Sprintf("Filtered to %d/%d", len(m.filtered), len(m.urls))
+	case "tab":
+		m.fuzzyFilter = !m.fuzzyFilter
+		m.filterURLs()
+		m.clampCursor()
+	default:
+		m.filterInput(msg)
+		m.filterURLs()
+		m.clampCursor()
+	}
+	return m, nil
+}
+
 func (m *model) filterInput(k tea.KeyPressMsg) {
 	s := k.String()
 	switch s {
@@ -89,21 +204,83 @@ func (m *model) filterInput(k tea.KeyPressMsg) {
 }
 
 func (m *model) applyFilter() {
+	m.filterURLs()
+	m.cursor = 0
+	m.mode = modeBrowsing
+}
+
+// filterURLs recomputes m.filtered from the loaded page and the current query.
+// Fuzzy results are ranked by match score rather than the sort key.
+func (m *model) filterURLs() {
 	q := strings.ToLower(strings.TrimSpace(m.filterQuery))
+	m.filterMatches = nil
+	if m.fuzzyFilter && q != "" {
+		hits := fuzzyFilter(m.urls, q)
+		m.filtered = make([]tuiURL, 0, len(hits))
+		m.filterMatches = make(map[string]fuzzyHit, len(hits))
+		for _, hit := range hits {
+			m.filtered = append(m.filtered, hit.url)
+			m.filterMatches[hit.url.ShortCode] = hit
+		}
+		return
+	}
 	if q == "" {
 		m.filtered = append([]tuiURL(nil), m.urls...)
+	} else {
+		filtered := make([]tuiURL, 0, len(m.urls))
+		for _, u := range m.urls {
+			if strings.Contains(strings.ToLower(u.ShortCode), q) || strings.Contains(strings.ToLower(u.OriginalURL), q) {
+				filtered = append(filtered, u)
+			}
+		}
+		m.filtered = filtered
+	}
+	sortURLs(m.filtered, m.cfg.Sort)
+}
+
+func (m *model) clampCursor() {
+	if m.cursor >= len(m.filtered) {
+		m.cursor = len(m.filtered) - 1
+	}
+	if m.cursor < 0 {
 		m.cursor = 0
-		m.mode = modeBrowsing
-		return
 	}
+}
+
+// sortURLs orders the loaded page by key. SortCreated keeps the server's
+// newest-first order, so it needs no sorting.
+func sortURLs(urls []tuiURL, key string) {
+	switch key {
+	case tui_config.SortClicks:
+		sort.SliceStable(urls, func(i, j int) bool { return urls[i].ClickCount > urls[j].ClickCount })
+	case tui_config.SortCode:
+		sort.SliceStable(urls, func(i, j int) bool { return urls[i].ShortCode < urls[j].ShortCode })
+	}
+}
 
-	filtered := make([]tuiURL, 0, len(m.urls))
-	for _, u := range m.urls {
-		if strings.Contains(strings.ToLower(u.ShortCode), q) || strings.Contains(strings.ToLower(u.OriginalURL), q) {
-			filtered = append(filtered, u)
+type savePreferencesMsg struct {
+	err error
+}
+
+// cycleSort switches to the next sort key and remembers it for future launches.
+func (m model) cycleSort() (tea.Model, tea.Cmd) {
+	next := tui_config.SortKeys[0]
+	current := m.cfg.Sort
+	if current == "" {
+		current = tui_config.SortCreated
+	}
+	for i, k := range tui_config.SortKeys {
+		if k == current {
+			next = tui_config.SortKeys[(i+1)%len(tui_config.SortKeys)]
 		}
 	}
-	m.filtered = filtered
-	m.cursor = 0
-	m.mode = modeBrowsing
+	m.cfg.Sort = next
+	m.applyFilter()
+	m.status = fmt.Sprintf("Sorted by %s", next)
+
+	return m, func() tea.Msg {
+		return savePreferencesMsg{err: updatePreferences(func(p *tui_config.Preferences) {
+			p.Sort = next
+		})}
+	}
 }
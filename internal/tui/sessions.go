@@ -0,0 +1,250 @@
+package tui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+	"github.com/matt-riley/mjrwtf/internal/client"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+const (
+	sessionUserAgentWidthMargin = 70
+	minSessionUserAgentWidth    = 16
+	maxSessionUserAgentWidth    = 60
+)
+
+type listSessionsMsg struct {
+	sessions []client.SessionResponse
+	err      error
+}
+
+type revokeSessionMsg struct {
+	id  string
+	err error
+}
+
+func listSessionsCmd(cfg tui_config.Config) tea.Cmd {
+	return func() tea.Msg {
+		c, err := newAPIClient(cfg)
+		if err != nil {
+			return listSessionsMsg{err: err}
+		}
+
+		ctx, cancel := requestContext(cfg)
+		defer cancel()
+
+		resp, err := c.ListSessions(ctx)
+		if err != nil {
+			return listSessionsMsg{err: requestError(err)}
+		}
+		return listSessionsMsg{sessions: resp.Sessions}
+	}
+}
+
+func revokeSessionCmd(cfg tui_config.Config, id string) tea.Cmd {
+	return func() tea.Msg {
+		c, err := newAPIClient(cfg)
+		if err != nil {
+			return revokeSessionMsg{id: id, err: err}
+		}
+
+		ctx, cancel := requestContext(cfg)
+		defer cancel()
+
+		if err := c.RevokeSession(ctx, id); err != nil {
+			return revokeSessionMsg{id: id, err: requestError(err)}
+		}
+		return revokeSessionMsg{id: id}
+	}
+}
+
+func (m model) openSessions() (tea.Model, tea.Cmd) {
+	m.mode = modeSessions
+	m.sessionsLoading = true
+	m.sessions = nil
+	m.sessionsCursor = 0
+	m.sessionRevokeID = ""
+	m.status = "Loading sessions..."
+	return m, tea.Batch(m.spinner.Tick, listSessionsCmd(m.cfg))
+}
+
+func (m model) updateSessionsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.sessionRevokeID != "" {
+		switch msg.String() {
+		case "enter", "y":
+			if m.sessionRevoking {
+				return m, nil
+			}
+			m.sessionRevoking = true
+			m.status = fmt.Sprintf("Revoking session: %s...", m.sessionRevokeID)
+			return m, tea.Batch(m.spinner.Tick, revokeSessionCmd(m.cfg, m.sessionRevokeID))
+		case "esc", "n":
+			if m.sessionRevoking {
+				return m, nil
+			}
+			m.sessionRevokeID = ""
+			m.status = "Revoke cancelled"
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "b", "esc":
+		m.mode = modeBrowsing
+		m.status = "Back to list"
+		return m, nil
+	case "r":
+		if m.sessionsLoading {
+			return m, nil
+		}
+		return m.openSessions()
+	case "j", "down":
+		if m.sessionsCursor < len(m.sessions)-1 {
+			m.sessionsCursor++
+		}
+		return m, nil
+	case "k", "up":
+		if m.sessionsCursor > 0 {
+			m.sessionsCursor--
+		}
+		return m, nil
+	case "d":
+		if m.sessionsLoading {
+			return m, nil
+		}
+		if m.sessionsCursor < 0 || m.sessionsCursor >= len(m.sessions) {
+			m.status = "No selected session"
+			return m, nil
+		}
+		sess := m.sessions[m.sessionsCursor]
+		m.sessionRevokeID = sess.ID
+		if sess.Current {
+			m.status = fmt.Sprintf("Warning: revoke current session %s? This logs out the browser using it [y/n]", sess.ID)
+		} else {
+			m.status = fmt.Sprintf("Revoke session %s? [y/n]", sess.ID)
+		}
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m model) handleListSessions(msg listSessionsMsg) (tea.Model, tea.Cmd) {
+	m.sessionsLoading = false
+	if msg.err != nil {
+		if apiErr, ok := msg.err.(*client.APIError); ok {
+			m.status = fmt.Sprintf("Sessions failed (%d): %s", apiErr.StatusCode, apiErr.Message)
+		} else {
+			m.status = fmt.Sprintf("Sessions failed: %v", msg.err)
+		}
+		return m, nil
+	}
+	m.sessions = msg.sessions
+	if m.sessionsCursor >= len(m.sessions) {
+		m.sessionsCursor = len(m.sessions) - 1
+	}
+	if m.sessionsCursor < 0 {
+		m.sessionsCursor = 0
+	}
+	m.status = fmt.Sprintf("Loaded %d sessions", len(m.sessions))
+	return m, nil
+}
+
+func (m model) handleRevokeSession(msg revokeSessionMsg) (tea.Model, tea.Cmd) {
+	m.sessionRevoking = false
+	m.sessionRevokeID = ""
+	if msg.err != nil {
+		if apiErr, ok := msg.err.(*client.APIError); ok {
+			m.status = fmt.Sprintf("Revoke failed (%d): %s", apiErr.StatusCode, apiErr.Message)
+			if client.IsErrorCode(apiErr, client.CodeSessionNotFound, http.StatusNotFound) {
+				// Already gone (expired or revoked elsewhere); refresh to show the current state.
+				m.sessionsLoading = true
+				return m, tea.Batch(m.spinner.Tick, listSessionsCmd(m.cfg))
+			}
+		} else {
+			m.status = fmt.Sprintf("Revoke failed: %v", msg.err)
+		}
+		return m, nil
+	}
+
+	remaining := make([]client.SessionResponse, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		if s.ID == msg.id {
+			continue
+		}
+		remaining = append(remaining, s)
+	}
+	m.sessions = remaining
+	if m.sessionsCursor >= len(m.sessions) {
+		m.sessionsCursor = len(m.sessions) - 1
+	}
+	if m.sessionsCursor < 0 {
+		m.sessionsCursor = 0
+	}
+
+	m.status = fmt.Sprintf("Revoked: %s", msg.id)
+	return m, nil
+}
+
+func (m model) sessionsView() string {
+	if m.sessionsLoading {
+		return styles.MutedStyle.Render(fmt.Sprintf("%s Loading sessions...", m.spinner.View()))
+	}
+	if len(m.sessions) == 0 {
+		msg := styles.MutedStyle.Render("No active sessions. Sessions are created by logging in to the web dashboard.")
+		return styles.BorderStyle.Padding(1, 2).Render(msg)
+	}
+
+	uaMax := maxSessionUserAgentWidth
+	if m.width > 0 {
+		uaMax = m.width - sessionUserAgentWidthMargin
+		if uaMax < minSessionUserAgentWidth {
+			uaMax = minSessionUserAgentWidth
+		}
+		if uaMax > maxSessionUserAgentWidth {
+			uaMax = maxSessionUserAgentWidth
+		}
+	}
+
+	rows := make([][]string, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		id := s.ID
+		if s.Current {
+			id += " *"
+		}
+		rows = append(rows, []string{
+			id,
+			s.IPAddress,
+			truncate(s.UserAgent, uaMax),
+			s.CreatedAt.Local().Format("2006-01-02 15:04"),
+			s.LastActivityAt.Local().Format("2006-01-02 15:04"),
+		})
+	}
+
+	t := table.New().
+		Headers("id", "ip_address", "user_agent", "created_at", "last_activity").
+		Rows(rows...).
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(styles.TableBorderStyle).
+		Wrap(false).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			cell := styles.UnselectedRowStyle
+			if row == table.HeaderRow {
+				cell = styles.TitleStyle
+			} else if row == m.sessionsCursor {
+				cell = styles.SelectedRowStyle
+			}
+			return cell.Padding(0, 1)
+		})
+	if m.width > 0 {
+		t.Width(m.width)
+	}
+
+	legend := styles.MutedStyle.Render("* current session")
+	return strings.Join([]string{t.Render(), legend}, "\n")
+}
@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/client"
+	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
+)
+
+func TestListSessionsCmd(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/auth/sessions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sessions":[{"id":"abcdefghijkl","ip_address":"203.0.113.1","user_agent":"Firefox","created_at":"2025-11-20T10:00:00Z","last_activity_at":"2025-11-20T11:00:00Z","expires_at":"2025-11-21T11:00:00Z","current":false}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	msg := listSessionsCmd(tui_config.Config{BaseURL: srv.URL, Token: "t"})().(listSessionsMsg)
+	if msg.err != nil {
+		t.Fatalf("expected nil err, got %v", msg.err)
+	}
+	if len(msg.sessions) != 1 || msg.sessions[0].IPAddress != "203.0.113.1" {
+		t.Fatalf("sessions=%+v", msg.sessions)
+	}
+}
+
+func TestRevokeSessionCmd(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/api/auth/sessions/abcdefghijkl" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	msg := revokeSessionCmd(tui_config.Config{BaseURL: srv.URL, Token: "t"}, "abcdefghijkl")().(revokeSessionMsg)
+	if msg.err != nil {
+		t.Fatalf("expected nil err, got %v", msg.err)
+	}
+	if msg.id != "abcdefghijkl" {
+		t.Fatalf("id=%q", msg.id)
+	}
+}
+
+func TestModel_Update_OpenSessions(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+
+	m2, cmd := m.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	mm := m2.(model)
+	if mm.mode != modeSessions {
+		t.Fatalf("mode=%v", mm.mode)
+	}
+	if !mm.sessionsLoading {
+		t.Fatalf("expected sessionsLoading=true")
+	}
+	if cmd == nil {
+		t.Fatalf("expected cmd")
+	}
+}
+
+func TestModel_Update_SessionsFilterTypesS(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.loading = false
+	m.mode = modeFiltering
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	mm := m2.(model)
+	if mm.mode != modeFiltering || mm.filterQuery != "s" {
+		t.Fatalf("mode=%v filterQuery=%q", mm.mode, mm.filterQuery)
+	}
+}
+
+func TestModel_Update_RevokeSessionConfirmFlow(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.mode = modeSessions
+	m.sessions = []client.SessionResponse{{ID: "aaaaaaaaaaaa"}, {ID: "bbbbbbbbbbbb", Current: true}}
+	m.sessionsCursor = 1
+
+	m2, _ := m.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	mm := m2.(model)
+	if mm.sessionRevokeID != "bbbbbbbbbbbb" {
+		t.Fatalf("sessionRevokeID=%q", mm.sessionRevokeID)
+	}
+	if !strings.Contains(mm.status, "current session") {
+		t.Fatalf("expected warning about current session, status=%q", mm.status)
+	}
+
+	m3, _ := mm.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	mm = m3.(model)
+	if mm.sessionRevokeID != "" || mm.mode != modeSessions {
+		t.Fatalf("expected cancel to stay in sessions mode, mode=%v revoke=%q", mm.mode, mm.sessionRevokeID)
+	}
+
+	m4, _ := mm.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	m5, cmd := m4.(model).Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	mm = m5.(model)
+	if !mm.sessionRevoking {
+		t.Fatalf("expected sessionRevoking=true")
+	}
+	if cmd == nil {
+		t.Fatalf("expected cmd")
+	}
+
+	m6, _ := mm.Update(revokeSessionMsg{id: "bbbbbbbbbbbb"})
+	mm = m6.(model)
+	if len(mm.sessions) != 1 || mm.sessions[0].ID != "aaaaaaaaaaaa" {
+		t.Fatalf("sessions=%+v", mm.sessions)
+	}
+	if mm.sessionsCursor != 0 {
+		t.Fatalf("sessionsCursor=%d", mm.sessionsCursor)
+	}
+	if !strings.HasPrefix(mm.status, "Revoked:") {
+		t.Fatalf("status=%q", mm.status)
+	}
+}
+
+func TestModel_Update_RevokeSessionForbidden(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.mode = modeSessions
+	m.sessions = []client.SessionResponse{{ID: "aaaaaaaaaaaa"}}
+	m.sessionRevokeID = "aaaaaaaaaaaa"
+	m.sessionRevoking = true
+
+	m2, _ := m.Update(revokeSessionMsg{id: "aaaaaaaaaaaa", err: &client.APIError{StatusCode: 403, Message: "forbidden"}})
+	mm := m2.(model)
+	if len(mm.sessions) != 1 {
+		t.Fatalf("expected session to remain, got %+v", mm.sessions)
+	}
+	if statusKindFromText(mm.status) != statusKindError {
+		t.Fatalf("expected error status, got %q", mm.status)
+	}
+}
+
+func TestModel_View_Sessions(t *testing.T) {
+	m := newModel(tui_config.Config{BaseURL: "http://example", Token: "t"}, nil)
+	m.mode = modeSessions
+	m.sessions = []client.SessionResponse{{ID: "aaaaaaaaaaaa", IPAddress: "203.0.113.9", UserAgent: "Firefox", Current: true}}
+
+	out := m.View().Content
+	for _, want := range []string{"Sessions", "aaaaaaaaaaaa *", "203.0.113.9", "Firefox", "[d] revoke"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected view to contain %q", want)
+		}
+	}
+}
@@ -3,6 +3,11 @@
 // The TUI uses the Catppuccin palette and lipgloss AdaptiveColor values so the
 // same UI automatically looks good in both dark and light terminal themes.
 //
+// Apply switches between themes (the default Catppuccin palette and a
+// high-contrast variant) and rebuilds the shared styles accordingly. SetTheme
+// overrides the detected terminal background to force the light or dark variant,
+// and DisableColor strips colors entirely for monochrome output.
+//
 // Keep colors and common styles in this package so screens stay visually
 // consistent and future tweaks can be made in one place.
 package styles
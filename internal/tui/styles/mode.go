@@ -0,0 +1,40 @@
+package styles
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2/compat"
+)
+
+// Modes accepted by SetTheme.
+const (
+	// ModeAuto picks light or dark colors from the detected terminal background.
+	ModeAuto = "auto"
+	// ModeDark always uses the dark (Mocha) variant of adaptive colors.
+	ModeDark = "dark"
+	// ModeLight always uses the light (Latte) variant of adaptive colors.
+	ModeLight = "light"
+)
+
+// detectedDarkBackground is the terminal background lipgloss detected at
+// startup; ModeAuto restores it after a forced mode.
+var detectedDarkBackground = compat.HasDarkBackground
+
+// SetTheme forces adaptive colors to their dark or light variant for
+// terminals whose background is misdetected. An empty mode means ModeAuto.
+// Like Apply, it is not safe for concurrent use and should be called before
+// the TUI starts.
+func SetTheme(mode string) error {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", ModeAuto:
+		compat.HasDarkBackground = detectedDarkBackground
+	case ModeDark:
+		compat.HasDarkBackground = true
+	case ModeLight:
+		compat.HasDarkBackground = false
+	default:
+		return fmt.Errorf("unknown theme mode %q (supported: %s, %s, %s)", mode, ModeAuto, ModeDark, ModeLight)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package styles
+
+import "charm.land/lipgloss/v2"
+
+var colorDisabled bool
+
+// DisableColor strips foreground, background and border colors from the shared
+// styles for monochrome terminals, CI logs and NO_COLOR users. Bold, underline
+// and borders are kept, and selected rows switch to reverse video so the
+// selection stays visible. It persists across later Apply calls.
+func DisableColor() {
+	colorDisabled = true
+	Apply(current)
+}
+
+// EnableColor undoes DisableColor and rebuilds the styles for the current theme.
+func EnableColor() {
+	colorDisabled = false
+	Apply(current)
+}
+
+// ColorDisabled reports whether DisableColor has been called.
+func ColorDisabled() bool {
+	return colorDisabled
+}
+
+func stripColors() {
+	for _, s := range []*lipgloss.Style{
+		&TitleStyle, &BorderStyle, &TableBorderStyle, &PanelStyle, &WarningPanelStyle,
+		&InputBoxStyle, &InputBoxFocusedStyle, &StatusBarStyle, &HintStyle,
+		&SuccessStyle, &ErrorStyle, &WarningStyle, &SelectedRowStyle,
+		&UnselectedRowStyle, &MutedStyle, &LinkStyle, &MatchStyle,
+	} {
+		*s = s.UnsetForeground().UnsetBackground().UnsetBorderForeground().UnsetBorderBackground()
+	}
+	SelectedRowStyle = SelectedRowStyle.Reverse(true)
+}
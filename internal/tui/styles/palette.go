@@ -0,0 +1,153 @@
+package styles
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/compat"
+	"github.com/pelletier/go-toml/v2"
+)
+
+var hexColorRegex = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// paletteOverrides holds colors loaded by LoadPalette, keyed by lower-cased
+// color name. They are layered over the theme palette on every Apply.
+var paletteOverrides map[string]compat.AdaptiveColor
+
+// LoadPalette reads a JSON (.json) or TOML (.toml) theme file and overrides
+// the named palette colors, then rebuilds the shared styles. Each entry maps a
+// color name (Mauve, Sapphire, Text, Surface0, ...) to either a single hex
+// value used for light and dark terminals, or a {light, dark} pair:
+//
+//	{"Mauve": "#c792ea", "Base": {"light": "#ffffff", "dark": "#11111b"}}
+//
+// Colors not named in the file keep their theme value. On any error the
+// built-in palette is restored and the error is returned for the caller to
+// report as a warning.
+func LoadPalette(path string) error {
+	overrides, err := readPalette(path)
+	if err != nil {
+		paletteOverrides = nil
+		Apply(current)
+		return fmt.Errorf("load palette %s: %w", path, err)
+	}
+
+	paletteOverrides = overrides
+	Apply(current)
+	return nil
+}
+
+// ResetPalette drops colors loaded by LoadPalette and restores the built-in palette.
+func ResetPalette() {
+	paletteOverrides = nil
+	Apply(current)
+}
+
+func readPalette(path string) (map[string]compat.AdaptiveColor, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(b, &raw)
+	case ".toml":
+		err = toml.Unmarshal(b, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported palette format %q (use .json or .toml)", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse palette: %w", err)
+	}
+
+	overrides := make(map[string]compat.AdaptiveColor, len(raw))
+	for name, v := range raw {
+		key := strings.ToLower(name)
+		if (&palette{}).color(key) == nil {
+			return nil, fmt.Errorf("unknown color %q", name)
+		}
+		c, err := parsePaletteColor(v)
+		if err != nil {
+			return nil, fmt.Errorf("color %s: %w", name, err)
+		}
+		overrides[key] = c
+	}
+	return overrides, nil
+}
+
+func parsePaletteColor(v any) (compat.AdaptiveColor, error) {
+	switch v := v.(type) {
+	case string:
+		if !hexColorRegex.MatchString(v) {
+			return compat.AdaptiveColor{}, fmt.Errorf("invalid hex color %q", v)
+		}
+		return adaptive(v, v), nil
+	case map[string]any:
+		light, _ := v["light"].(string)
+		dark, _ := v["dark"].(string)
+		if light == "" && dark == "" {
+			return compat.AdaptiveColor{}, fmt.Errorf("expected a hex string or {light, dark}")
+		}
+		if light == "" {
+			light = dark
+		}
+		if dark == "" {
+			dark = light
+		}
+		for _, h := range []string{light, dark} {
+			if !hexColorRegex.MatchString(h) {
+				return compat.AdaptiveColor{}, fmt.Errorf("invalid hex color %q", h)
+			}
+		}
+		return compat.AdaptiveColor{Light: lipgloss.Color(light), Dark: lipgloss.Color(dark)}, nil
+	}
+	return compat.AdaptiveColor{}, fmt.Errorf("expected a hex string or {light, dark}, got %T", v)
+}
+
+// withOverrides returns p with any loaded palette colors applied.
+func (p palette) withOverrides() palette {
+	for name, c := range paletteOverrides {
+		*p.color(name) = c
+	}
+	return p
+}
+
+// color returns the field of p for a lower-cased color name, or nil.
+func (p *palette) color(name string) *compat.AdaptiveColor {
+	switch name {
+	case "mauve":
+		return &p.Mauve
+	case "sapphire":
+		return &p.Sapphire
+	case "green":
+		return &p.Green
+	case "red":
+		return &p.Red
+	case "peach":
+		return &p.Peach
+	case "lavender":
+		return &p.Lavender
+	case "text":
+		return &p.Text
+	case "subtext1":
+		return &p.Subtext1
+	case "subtext0":
+		return &p.Subtext0
+	case "base":
+		return &p.Base
+	case "surface0":
+		return &p.Surface0
+	case "surface1":
+		return &p.Surface1
+	case "overlay0":
+		return &p.Overlay0
+	}
+	return nil
+}
@@ -2,74 +2,128 @@ package styles
 
 import "charm.land/lipgloss/v2"
 
-// Base Styles using Catppuccin adaptive color palette (Mocha for dark, Latte for light terminals)
-
-// TitleStyle - Bold text with Mauve accent for titles and headers
-var TitleStyle = lipgloss.NewStyle().
-	Bold(true).
-	Foreground(Mauve)
-
-// BorderStyle - Standard border using Overlay0 color
-var BorderStyle = lipgloss.NewStyle().
-	BorderStyle(lipgloss.RoundedBorder()).
-	BorderForeground(Overlay0)
-
-// PanelStyle - Standard bordered panel with consistent padding
-var PanelStyle = BorderStyle.Copy().
-	Padding(1, 2)
-
-// WarningPanelStyle - Warning-colored bordered panel (e.g. delete confirmation)
-var WarningPanelStyle = BorderStyle.Copy().
-	BorderForeground(Peach).
-	Padding(1, 2)
-
-// InputBoxStyle - Border+padding wrapper for text inputs when not focused
-var InputBoxStyle = BorderStyle.Copy().
-	Padding(0, 1)
-
-// InputBoxFocusedStyle - Border+padding wrapper for focused text inputs
-var InputBoxFocusedStyle = BorderStyle.Copy().
-	BorderForeground(Mauve).
-	Padding(0, 1)
-
-// StatusBarStyle - Status bar with Surface0 background
-var StatusBarStyle = lipgloss.NewStyle().
-	Background(Surface0).
-	Padding(0, 1)
-
-// HintStyle - Muted text for keyboard hints and help text
-var HintStyle = lipgloss.NewStyle().
-	Foreground(Subtext0)
-
-// SuccessStyle - Green foreground for success messages
-var SuccessStyle = lipgloss.NewStyle().
-	Foreground(Green).
-	Bold(true)
-
-// ErrorStyle - Red foreground for error messages
-var ErrorStyle = lipgloss.NewStyle().
-	Foreground(Red).
-	Bold(true)
-
-// WarningStyle - Peach foreground for warnings
-var WarningStyle = lipgloss.NewStyle().
-	Foreground(Peach).
-	Bold(true)
-
-// SelectedRowStyle - Highlighted row with Surface1 background and Lavender accent
-var SelectedRowStyle = lipgloss.NewStyle().
-	Background(Surface1).
-	Foreground(Lavender)
-
-// UnselectedRowStyle - Base background for unselected rows
-var UnselectedRowStyle = lipgloss.NewStyle().
-	Background(Base).
-	Foreground(Text)
-
-// MutedStyle - Subtext0 for muted/secondary information
-var MutedStyle = lipgloss.NewStyle().
-	Foreground(Subtext0)
-
-// LinkStyle - Sapphire color for URLs and links
-var LinkStyle = lipgloss.NewStyle().
-	Foreground(Sapphire)
+// Base Styles using Catppuccin adaptive color palette (Mocha for dark, Latte for light terminals).
+// They are (re)built from the active palette by Apply.
+var (
+	// TitleStyle - Bold text with Mauve accent for titles and headers
+	TitleStyle lipgloss.Style
+
+	// BorderStyle - Standard border using Overlay0 color
+	BorderStyle lipgloss.Style
+
+	// TableBorderStyle - Overlay0 foreground for table borders. Tables style
+	// each border segment with it, so it must not draw a border of its own.
+	TableBorderStyle lipgloss.Style
+
+	// PanelStyle - Standard bordered panel with consistent padding
+	PanelStyle lipgloss.Style
+
+	// WarningPanelStyle - Warning-colored bordered panel (e.g. delete confirmation)
+	WarningPanelStyle lipgloss.Style
+
+	// InputBoxStyle - Border+padding wrapper for text inputs when not focused
+	InputBoxStyle lipgloss.Style
+
+	// InputBoxFocusedStyle - Border+padding wrapper for focused text inputs
+	InputBoxFocusedStyle lipgloss.Style
+
+	// StatusBarStyle - Status bar with Surface0 background
+	StatusBarStyle lipgloss.Style
+
+	// HintStyle - Muted text for keyboard hints and help text
+	HintStyle lipgloss.Style
+
+	// SuccessStyle - Green foreground for success messages
+	SuccessStyle lipgloss.Style
+
+	// ErrorStyle - Red foreground for error messages
+	ErrorStyle lipgloss.Style
+
+	// WarningStyle - Peach foreground for warnings
+	WarningStyle lipgloss.Style
+
+	// SelectedRowStyle - Highlighted row with Surface1 background and Lavender accent
+	SelectedRowStyle lipgloss.Style
+
+	// UnselectedRowStyle - Base background for unselected rows
+	UnselectedRowStyle lipgloss.Style
+
+	// MutedStyle - Subtext0 for muted/secondary information
+	MutedStyle lipgloss.Style
+
+	// LinkStyle - Sapphire color for URLs and links
+	LinkStyle lipgloss.Style
+
+	// MatchStyle - Underlined Peach for characters matched by the fuzzy filter
+	MatchStyle lipgloss.Style
+)
+
+func init() {
+	buildStyles()
+}
+
+// buildStyles derives the shared styles from the current palette variables.
+func buildStyles() {
+	TitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(Mauve)
+
+	BorderStyle = lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(Overlay0)
+
+	TableBorderStyle = lipgloss.NewStyle().
+		Foreground(Overlay0)
+
+	PanelStyle = BorderStyle.Copy().
+		Padding(1, 2)
+
+	WarningPanelStyle = BorderStyle.Copy().
+		BorderForeground(Peach).
+		Padding(1, 2)
+
+	InputBoxStyle = BorderStyle.Copy().
+		Padding(0, 1)
+
+	InputBoxFocusedStyle = BorderStyle.Copy().
+		BorderForeground(Mauve).
+		Padding(0, 1)
+
+	StatusBarStyle = lipgloss.NewStyle().
+		Background(Surface0).
+		Padding(0, 1)
+
+	HintStyle = lipgloss.NewStyle().
+		Foreground(Subtext0)
+
+	SuccessStyle = lipgloss.NewStyle().
+		Foreground(Green).
+		Bold(true)
+
+	ErrorStyle = lipgloss.NewStyle().
+		Foreground(Red).
+		Bold(true)
+
+	WarningStyle = lipgloss.NewStyle().
+		Foreground(Peach).
+		Bold(true)
+
+	SelectedRowStyle = lipgloss.NewStyle().
+		Background(Surface1).
+		Foreground(Lavender)
+
+	UnselectedRowStyle = lipgloss.NewStyle().
+		Background(Base).
+		Foreground(Text)
+
+	MutedStyle = lipgloss.NewStyle().
+		Foreground(Subtext0)
+
+	LinkStyle = lipgloss.NewStyle().
+		Foreground(Sapphire)
+
+	MatchStyle = lipgloss.NewStyle().
+		Foreground(Peach).
+		Bold(true).
+		Underline(true)
+}
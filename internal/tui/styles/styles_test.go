@@ -3,6 +3,9 @@ package styles
 import (
 	"fmt"
 	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	lipgloss "charm.land/lipgloss/v2"
@@ -152,3 +155,252 @@ func TestStyleImmutability(t *testing.T) {
 		// color comparison, but this ensures Copy() doesn't break things
 	})
 }
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Theme
+		wantErr bool
+	}{
+		{"", ThemeCatppuccin, false},
+		{"catppuccin", ThemeCatppuccin, false},
+		{"High-Contrast", ThemeHighContrast, false},
+		{" high-contrast ", ThemeHighContrast, false},
+		{"solarized", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseTheme(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTheme(%q) err=%v wantErr=%v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseTheme(%q)=%q want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighContrastTheme_SelectedRowUsesReverse(t *testing.T) {
+	Apply(ThemeHighContrast)
+	t.Cleanup(func() { Apply(ThemeCatppuccin) })
+
+	if Current() != ThemeHighContrast {
+		t.Fatalf("Current()=%q", Current())
+	}
+	if !SelectedRowStyle.GetReverse() {
+		t.Fatal("expected selected rows to use reverse video")
+	}
+	if UnselectedRowStyle.GetReverse() {
+		t.Fatal("expected unselected rows not to use reverse video")
+	}
+
+	// SGR 7 is reverse video; the difference must be visible without relying on hue.
+	selected := SelectedRowStyle.Render("row")
+	unselected := UnselectedRowStyle.Render("row")
+	if !strings.Contains(selected, "\x1b[7") && !strings.Contains(selected, ";7") {
+		t.Fatalf("selected row not rendered in reverse: %q", selected)
+	}
+	if strings.Contains(unselected, "7m") {
+		t.Fatalf("unselected row unexpectedly rendered in reverse: %q", unselected)
+	}
+
+	if !ErrorStyle.GetBold() || !ErrorStyle.GetUnderline() {
+		t.Fatal("expected errors to be bold and underlined")
+	}
+	if !LinkStyle.GetUnderline() {
+		t.Fatal("expected links to be underlined")
+	}
+}
+
+func TestApply_RestoresCatppuccinPalette(t *testing.T) {
+	Apply(ThemeHighContrast)
+	Apply(ThemeCatppuccin)
+
+	if colorHex(Mauve.Dark) != "#cba6f7" {
+		t.Fatalf("Mauve dark=%s after restoring default theme", colorHex(Mauve.Dark))
+	}
+	if SelectedRowStyle.GetReverse() {
+		t.Fatal("expected default theme selected rows not to use reverse video")
+	}
+}
+
+func TestSetTheme_ForcesVariant(t *testing.T) {
+	t.Cleanup(func() { _ = SetTheme(ModeAuto) })
+
+	// Green is #40a02b (64,160,43) in Latte and #a6e3a1 (166,227,161) in Mocha.
+	const lightGreen, darkGreen = "38;2;64;160;43", "38;2;166;227;161"
+
+	if err := SetTheme(ModeLight); err != nil {
+		t.Fatalf("SetTheme(light): %v", err)
+	}
+	if got := SuccessStyle.Render("x"); !strings.Contains(got, lightGreen) {
+		t.Fatalf("light mode rendered %q, want light green", got)
+	}
+
+	if err := SetTheme("Dark"); err != nil {
+		t.Fatalf("SetTheme(Dark): %v", err)
+	}
+	if got := SuccessStyle.Render("x"); !strings.Contains(got, darkGreen) {
+		t.Fatalf("dark mode rendered %q, want dark green", got)
+	}
+}
+
+func TestSetTheme_AutoRestoresDetectedBackground(t *testing.T) {
+	t.Cleanup(func() { _ = SetTheme(ModeAuto) })
+
+	if err := SetTheme(ModeLight); err != nil {
+		t.Fatalf("SetTheme(light): %v", err)
+	}
+	if err := SetTheme(ModeAuto); err != nil {
+		t.Fatalf("SetTheme(auto): %v", err)
+	}
+	if compat.HasDarkBackground != detectedDarkBackground {
+		t.Fatalf("HasDarkBackground=%v, want detected %v", compat.HasDarkBackground, detectedDarkBackground)
+	}
+}
+
+func TestSetTheme_RejectsUnknownMode(t *testing.T) {
+	if err := SetTheme("sepia"); err == nil {
+		t.Fatal("expected error for unknown mode")
+	}
+}
+
+func TestDisableColor_StripsColorsKeepsAttributes(t *testing.T) {
+	t.Cleanup(func() {
+		EnableColor()
+		Apply(ThemeCatppuccin)
+	})
+
+	DisableColor()
+	if !ColorDisabled() {
+		t.Fatal("expected ColorDisabled() to be true")
+	}
+
+	for name, style := range map[string]lipgloss.Style{
+		"SuccessStyle":     SuccessStyle,
+		"ErrorStyle":       ErrorStyle,
+		"StatusBarStyle":   StatusBarStyle,
+		"SelectedRowStyle": SelectedRowStyle,
+		"PanelStyle":       PanelStyle,
+	} {
+		got := style.Render("x")
+		if !strings.Contains(got, "x") {
+			t.Errorf("%s dropped its text: %q", name, got)
+		}
+		// SGR 38/48 set foreground/background colors.
+		if strings.Contains(got, "38;") || strings.Contains(got, "48;") {
+			t.Errorf("%s rendered a color escape: %q", name, got)
+		}
+	}
+
+	if got := SuccessStyle.Render("x"); !strings.Contains(got, "\x1b[1m") {
+		t.Errorf("expected SuccessStyle to stay bold, got %q", got)
+	}
+	if !SelectedRowStyle.GetReverse() {
+		t.Error("expected selected rows to use reverse video without color")
+	}
+}
+
+func TestDisableColor_SurvivesThemeChange(t *testing.T) {
+	t.Cleanup(func() {
+		EnableColor()
+		Apply(ThemeCatppuccin)
+	})
+
+	DisableColor()
+	Apply(ThemeHighContrast)
+
+	if got := LinkStyle.Render("x"); strings.Contains(got, "38;") {
+		t.Fatalf("expected no color after Apply, got %q", got)
+	}
+	if !LinkStyle.GetUnderline() {
+		t.Fatal("expected high-contrast underline to be kept")
+	}
+}
+
+func writePalette(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write palette: %v", err)
+	}
+	return path
+}
+
+func TestLoadPalette_OverridesColors(t *testing.T) {
+	t.Cleanup(ResetPalette)
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"json", "theme.json", `{"Mauve": "#112233", "surface0": {"light": "#aabbcc", "dark": "#010203"}}`},
+		{"toml", "theme.toml", "Mauve = \"#112233\"\n\n[Surface0]\nlight = \"#aabbcc\"\ndark = \"#010203\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := LoadPalette(writePalette(t, tt.file, tt.content)); err != nil {
+				t.Fatalf("LoadPalette() error: %v", err)
+			}
+
+			if colorHex(Mauve.Dark) != "#112233" || colorHex(Mauve.Light) != "#112233" {
+				t.Fatalf("Mauve = %s/%s, want #112233 for both", colorHex(Mauve.Light), colorHex(Mauve.Dark))
+			}
+			if colorHex(Surface0.Light) != "#aabbcc" || colorHex(Surface0.Dark) != "#010203" {
+				t.Fatalf("Surface0 = %s/%s", colorHex(Surface0.Light), colorHex(Surface0.Dark))
+			}
+			// Unlisted colors keep the built-in palette.
+			if colorHex(Green.Dark) != "#a6e3a1" {
+				t.Fatalf("Green dark = %s, want built-in", colorHex(Green.Dark))
+			}
+			// Styles are rebuilt from the new palette.
+			if got := TitleStyle.Render("x"); !strings.Contains(got, "38;2;17;34;51") {
+				t.Fatalf("TitleStyle not rebuilt: %q", got)
+			}
+		})
+	}
+}
+
+func TestLoadPalette_RejectsMalformedHex(t *testing.T) {
+	t.Cleanup(ResetPalette)
+
+	if err := LoadPalette(writePalette(t, "ok.json", `{"Mauve": "#112233"}`)); err != nil {
+		t.Fatalf("LoadPalette() error: %v", err)
+	}
+
+	for _, content := range []string{
+		`{"Mauve": "112233"}`,
+		`{"Mauve": "#12345g"}`,
+		`{"Mauve": {"light": "#fff", "dark": "blue"}}`,
+		`{"Mauve": 42}`,
+		`{"Chartreuse": "#112233"}`,
+	} {
+		err := LoadPalette(writePalette(t, "bad.json", content))
+		if err == nil {
+			t.Errorf("LoadPalette(%s): expected error", content)
+			continue
+		}
+		if colorHex(Mauve.Dark) != "#cba6f7" {
+			t.Errorf("LoadPalette(%s): Mauve dark = %s, want built-in fallback", content, colorHex(Mauve.Dark))
+		}
+	}
+}
+
+func TestLoadPalette_PersistsAcrossApply(t *testing.T) {
+	t.Cleanup(func() {
+		ResetPalette()
+		Apply(ThemeCatppuccin)
+	})
+
+	if err := LoadPalette(writePalette(t, "theme.json", `{"Red": "#ff0000"}`)); err != nil {
+		t.Fatalf("LoadPalette() error: %v", err)
+	}
+	Apply(ThemeHighContrast)
+	if colorHex(Red.Dark) != "#ff0000" {
+		t.Fatalf("Red dark = %s after Apply, want override", colorHex(Red.Dark))
+	}
+}
@@ -0,0 +1,120 @@
+package styles
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/compat"
+)
+
+// Theme selects the palette and style attributes used by the TUI.
+type Theme string
+
+const (
+	// ThemeCatppuccin is the default adaptive Catppuccin (Mocha/Latte) theme.
+	ThemeCatppuccin Theme = "catppuccin"
+	// ThemeHighContrast is a minimal, high-contrast theme for low-vision users.
+	// State is conveyed with bold, underline and reverse video instead of subtle hue changes.
+	ThemeHighContrast Theme = "high-contrast"
+)
+
+// Themes lists the supported theme names.
+var Themes = []Theme{ThemeCatppuccin, ThemeHighContrast}
+
+// ParseTheme converts a user-supplied theme name into a Theme.
+// An empty string selects ThemeCatppuccin.
+func ParseTheme(s string) (Theme, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	if v == "" {
+		return ThemeCatppuccin, nil
+	}
+	for _, t := range Themes {
+		if string(t) == v {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown theme %q (supported: %s, %s)", s, ThemeCatppuccin, ThemeHighContrast)
+}
+
+type palette struct {
+	Mauve, Sapphire, Green, Red, Peach, Lavender compat.AdaptiveColor
+	Text, Subtext1, Subtext0                     compat.AdaptiveColor
+	Base, Surface0, Surface1, Overlay0           compat.AdaptiveColor
+}
+
+var catppuccinPalette = palette{
+	Mauve: Mauve, Sapphire: Sapphire, Green: Green, Red: Red, Peach: Peach, Lavender: Lavender,
+	Text: Text, Subtext1: Subtext1, Subtext0: Subtext0,
+	Base: Base, Surface0: Surface0, Surface1: Surface1, Overlay0: Overlay0,
+}
+
+func adaptive(light, dark string) compat.AdaptiveColor {
+	return compat.AdaptiveColor{Light: lipgloss.Color(light), Dark: lipgloss.Color(dark)}
+}
+
+// noBackground leaves the terminal's own background in place so text keeps
+// its full contrast against it.
+var noBackground = compat.AdaptiveColor{Light: lipgloss.NoColor{}, Dark: lipgloss.NoColor{}}
+
+// highContrastPalette keeps foregrounds near black (light) or white (dark);
+// the few accent colors all exceed a 7:1 contrast ratio on their background.
+var highContrastPalette = palette{
+	Mauve:    adaptive("#000000", "#ffffff"),
+	Sapphire: adaptive("#0000b3", "#9fdcff"),
+	Green:    adaptive("#005200", "#8dff8d"),
+	Red:      adaptive("#8b0000", "#ffb3b3"),
+	Peach:    adaptive("#5c2e00", "#ffe08a"),
+	Lavender: adaptive("#000000", "#ffffff"),
+	Text:     adaptive("#000000", "#ffffff"),
+	Subtext1: adaptive("#000000", "#ffffff"),
+	Subtext0: adaptive("#1a1a1a", "#e6e6e6"),
+	Base:     noBackground,
+	Surface0: noBackground,
+	Surface1: noBackground,
+	Overlay0: adaptive("#000000", "#ffffff"),
+}
+
+var current = ThemeCatppuccin
+
+// Current returns the active theme.
+func Current() Theme {
+	return current
+}
+
+// Apply switches the package palette and rebuilds all shared styles for t.
+// It is not safe for concurrent use and should be called before the TUI starts.
+func Apply(t Theme) {
+	switch t {
+	case ThemeHighContrast:
+		setPalette(highContrastPalette.withOverrides())
+		buildStyles()
+		applyHighContrastAttributes()
+	default:
+		t = ThemeCatppuccin
+		setPalette(catppuccinPalette.withOverrides())
+		buildStyles()
+	}
+	if colorDisabled {
+		stripColors()
+	}
+	current = t
+}
+
+func setPalette(p palette) {
+	Mauve, Sapphire, Green, Red, Peach, Lavender = p.Mauve, p.Sapphire, p.Green, p.Red, p.Peach, p.Lavender
+	Text, Subtext1, Subtext0 = p.Text, p.Subtext1, p.Subtext0
+	Base, Surface0, Surface1, Overlay0 = p.Base, p.Surface0, p.Surface1, p.Overlay0
+}
+
+// applyHighContrastAttributes layers text attributes on top of the base styles
+// so states stay distinguishable even where color is hard to perceive.
+func applyHighContrastAttributes() {
+	SelectedRowStyle = SelectedRowStyle.Reverse(true).Bold(true)
+	ErrorStyle = ErrorStyle.Underline(true)
+	WarningStyle = WarningStyle.Underline(true)
+	LinkStyle = LinkStyle.Underline(true)
+	TitleStyle = TitleStyle.Underline(true)
+	InputBoxFocusedStyle = InputBoxFocusedStyle.BorderStyle(lipgloss.ThickBorder())
+	StatusBarStyle = StatusBarStyle.Bold(true)
+}
@@ -4,13 +4,23 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"os"
+	"strconv"
+	"strings"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
 	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
 )
 
 var runProgram = func(m tea.Model) error {
-	p := tea.NewProgram(m)
+	var opts []tea.ProgramOption
+	if styles.ColorDisabled() {
+		// Also strip colors the views apply inline from the palette.
+		opts = append(opts, tea.WithColorProfile(colorprofile.Ascii))
+	}
+	p := tea.NewProgram(m, opts...)
 	_, err := p.Run()
 	return err
 }
@@ -21,20 +31,72 @@ func Run(args []string) error {
 
 	flagBaseURL := fs.String("base-url", "", "API base URL")
 	flagToken := fs.String("token", "", "API bearer token")
+	flagTheme := fs.String("theme", "", "Color theme (catppuccin, high-contrast)")
+	flagThemeMode := fs.String("theme-mode", "", "Force light or dark colors (auto, dark, light)")
+	flagPageSize := fs.String("page-size", "", "URLs per page (5-100, default 20)")
+	flagTimeout := fs.String("timeout", "", "Per-request timeout (e.g. 10s, default 5s)")
+	flagMouse := fs.Bool("mouse", false, "Enable mouse wheel scrolling and click-to-select")
 
 	if err := fs.Parse(args); err != nil {
 		return fmt.Errorf("parse flags: %w", err)
 	}
 
 	cfg, warnings, err := tui_config.Load(tui_config.LoadOptions{
-		FlagBaseURL: *flagBaseURL,
-		FlagToken:   *flagToken,
+		FlagBaseURL:  *flagBaseURL,
+		FlagToken:    *flagToken,
+		FlagTheme:    *flagTheme,
+		FlagPageSize: *flagPageSize,
+		FlagTimeout:  *flagTimeout,
+
+		FlagThemeMode: *flagThemeMode,
 	})
 	if err != nil {
 		return err
 	}
 
+	rememberTheme := *flagTheme != ""
+	theme, err := styles.ParseTheme(cfg.Theme)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Warning: %v; using %s", err, styles.ThemeCatppuccin))
+		theme = styles.ThemeCatppuccin
+		rememberTheme = false
+	}
+	styles.Apply(theme)
+
+	// Remember a valid --page-size and --theme for the next launch
+	n, err := strconv.Atoi(strings.TrimSpace(*flagPageSize))
+	rememberPageSize := err == nil && n == cfg.PageSize
+	if rememberPageSize || rememberTheme {
+		err := tui_config.UpdatePreferences(func(p *tui_config.Preferences) {
+			if rememberPageSize {
+				p.PageSize = cfg.PageSize
+			}
+			if rememberTheme {
+				p.Theme = string(theme)
+			}
+		})
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Warning: could not save preferences: %v", err))
+		}
+	}
+
+	if cfg.Palette != "" {
+		if err := styles.LoadPalette(cfg.Palette); err != nil {
+			warnings = append(warnings, fmt.Sprintf("Warning: %v; using the built-in palette", err))
+		}
+	}
+	// https://no-color.org: any non-empty NO_COLOR disables color.
+	if os.Getenv("NO_COLOR") != "" {
+		styles.DisableColor()
+	}
+
+	if err := styles.SetTheme(cfg.ThemeMode); err != nil {
+		warnings = append(warnings, fmt.Sprintf("Warning: %v; using %s", err, styles.ModeAuto))
+		_ = styles.SetTheme(styles.ModeAuto)
+	}
+
 	m := newModel(cfg, warnings)
+	m.mouse = *flagMouse
 	if err := runProgram(m); err != nil {
 		return fmt.Errorf("run tui: %w", err)
 	}
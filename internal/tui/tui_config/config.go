@@ -4,26 +4,67 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/yaml.v3"
 )
 
+const (
+	// DefaultPageSize is the number of URLs fetched per page when none is configured.
+	DefaultPageSize = 20
+	// MinPageSize and MaxPageSize bound the configurable page size.
+	MinPageSize = 5
+	MaxPageSize = 100
+
+	// DefaultTimeout bounds each API request when no timeout is configured.
+	DefaultTimeout = 5 * time.Second
+)
+
 type Config struct {
-	BaseURL string `yaml:"base_url" toml:"base_url"`
-	Token   string `yaml:"token" toml:"token"`
+	BaseURL  string `yaml:"base_url" toml:"base_url"`
+	Token    string `yaml:"token" toml:"token"`
+	Theme    string `yaml:"theme" toml:"theme"`
+	PageSize int    `yaml:"page_size" toml:"page_size"`
+
+	// ThemeMode forces the light or dark color variant ("auto", "dark", "light").
+	ThemeMode string `yaml:"theme_mode" toml:"theme_mode"`
+	// Palette is the path of a JSON or TOML file overriding palette colors.
+	Palette string `yaml:"palette" toml:"palette"`
+	// Sort orders the loaded page of URLs: SortCreated (default), SortClicks or SortCode.
+	Sort string `yaml:"sort" toml:"sort"`
+
+	// Timeout bounds each API request. It is read from the "timeout" key of the
+	// config file as a duration string (e.g. "10s").
+	Timeout time.Duration `yaml:"-" toml:"-"`
+}
+
+// fileConfig is the on-disk shape of Config; durations are stored as strings
+// because TOML has no duration type.
+type fileConfig struct {
+	Config  `yaml:",inline"`
+	Timeout string `yaml:"timeout" toml:"timeout"`
 }
 
 type LoadOptions struct {
-	FlagBaseURL string
-	FlagToken   string
+	FlagBaseURL   string
+	FlagToken     string
+	FlagTheme     string
+	FlagThemeMode string
+	FlagPageSize  string
+	FlagTimeout   string
 }
 
 func Load(opts LoadOptions) (Config, []string, error) {
-	cfg := Config{BaseURL: "http://localhost:8080"}
+	cfg := Config{BaseURL: "http://localhost:8080", PageSize: DefaultPageSize, Timeout: DefaultTimeout, Sort: SortCreated}
 	warnings := []string{}
 
+	prefs, prefsWarnings := LoadPreferences()
+	warnings = append(warnings, prefsWarnings...)
+	mergePreferences(&cfg, prefs)
+
 	fileCfg, fileWarnings, err := loadFromFile()
 	if err != nil {
 		return Config{}, nil, err
@@ -37,6 +78,29 @@ func Load(opts LoadOptions) (Config, []string, error) {
 	if v := strings.TrimSpace(os.Getenv("MJR_TOKEN")); v != "" {
 		cfg.Token = v
 	}
+	if v := strings.TrimSpace(os.Getenv("MJR_THEME")); v != "" {
+		cfg.Theme = v
+	}
+	if v := strings.TrimSpace(os.Getenv("MJR_THEME_MODE")); v != "" {
+		cfg.ThemeMode = v
+	}
+	if v := strings.TrimSpace(os.Getenv("MJR_PALETTE")); v != "" {
+		cfg.Palette = v
+	}
+	if v := strings.TrimSpace(os.Getenv("MJR_PAGE_SIZE")); v != "" {
+		size, warning := parsePageSize("MJR_PAGE_SIZE", v)
+		cfg.PageSize = size
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("MJR_TIMEOUT")); v != "" {
+		timeout, warning := parseTimeout("MJR_TIMEOUT", v)
+		cfg.Timeout = timeout
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
 
 	if v := strings.TrimSpace(opts.FlagBaseURL); v != "" {
 		cfg.BaseURL = v
@@ -44,10 +108,70 @@ func Load(opts LoadOptions) (Config, []string, error) {
 	if v := strings.TrimSpace(opts.FlagToken); v != "" {
 		cfg.Token = v
 	}
+	if v := strings.TrimSpace(opts.FlagTheme); v != "" {
+		cfg.Theme = v
+	}
+	if v := strings.TrimSpace(opts.FlagThemeMode); v != "" {
+		cfg.ThemeMode = v
+	}
+	if v := strings.TrimSpace(opts.FlagPageSize); v != "" {
+		size, warning := parsePageSize("--page-size", v)
+		cfg.PageSize = size
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if v := strings.TrimSpace(opts.FlagTimeout); v != "" {
+		timeout, warning := parseTimeout("--timeout", v)
+		cfg.Timeout = timeout
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	if size, warning := clampPageSize("page_size", cfg.PageSize); warning != "" {
+		cfg.PageSize = size
+		warnings = append(warnings, warning)
+	}
+	if !validSort(cfg.Sort) {
+		warnings = append(warnings, fmt.Sprintf("Warning: unknown sort %q; using %s", cfg.Sort, SortCreated))
+		cfg.Sort = SortCreated
+	}
 
 	return cfg, warnings, nil
 }
 
+// parsePageSize parses a page size from source, falling back to DefaultPageSize
+// when it isn't an integer and clamping it to MinPageSize..MaxPageSize.
+// A non-empty warning describes any adjustment made.
+func parsePageSize(source, raw string) (int, string) {
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return DefaultPageSize, fmt.Sprintf("Warning: invalid %s %q; using %d", source, raw, DefaultPageSize)
+	}
+	return clampPageSize(source, n)
+}
+
+func clampPageSize(source string, n int) (int, string) {
+	switch {
+	case n < MinPageSize:
+		return MinPageSize, fmt.Sprintf("Warning: %s %d is below %d; using %d", source, n, MinPageSize, MinPageSize)
+	case n > MaxPageSize:
+		return MaxPageSize, fmt.Sprintf("Warning: %s %d is above %d; using %d", source, n, MaxPageSize, MaxPageSize)
+	}
+	return n, ""
+}
+
+// parseTimeout parses a positive duration from source, falling back to
+// DefaultTimeout when it is invalid. A non-empty warning describes the fallback.
+func parseTimeout(source, raw string) (time.Duration, string) {
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return DefaultTimeout, fmt.Sprintf("Warning: invalid %s %q; using %s", source, raw, DefaultTimeout)
+	}
+	return d, ""
+}
+
 func merge(dst *Config, src Config) {
 	if v := strings.TrimSpace(src.BaseURL); v != "" {
 		dst.BaseURL = v
@@ -55,6 +179,24 @@ func merge(dst *Config, src Config) {
 	if v := strings.TrimSpace(src.Token); v != "" {
 		dst.Token = v
 	}
+	if v := strings.TrimSpace(src.Theme); v != "" {
+		dst.Theme = v
+	}
+	if v := strings.TrimSpace(src.ThemeMode); v != "" {
+		dst.ThemeMode = v
+	}
+	if v := strings.TrimSpace(src.Palette); v != "" {
+		dst.Palette = v
+	}
+	if v := strings.TrimSpace(src.Sort); v != "" {
+		dst.Sort = v
+	}
+	if src.PageSize != 0 {
+		dst.PageSize = src.PageSize
+	}
+	if src.Timeout != 0 {
+		dst.Timeout = src.Timeout
+	}
 }
 
 func loadFromFile() (Config, []string, error) {
@@ -92,20 +234,29 @@ func loadFromFile() (Config, []string, error) {
 		return Config{}, nil, fmt.Errorf("read config file: %w", err)
 	}
 
-	var cfg Config
+	var fc fileConfig
 	switch format {
 	case "yaml":
-		if err := yaml.Unmarshal(b, &cfg); err != nil {
+		if err := yaml.Unmarshal(b, &fc); err != nil {
 			return Config{}, nil, fmt.Errorf("parse yaml config: %w", err)
 		}
 	case "toml":
-		if err := toml.Unmarshal(b, &cfg); err != nil {
+		if err := toml.Unmarshal(b, &fc); err != nil {
 			return Config{}, nil, fmt.Errorf("parse toml config: %w", err)
 		}
 	default:
 		panic("unreachable: format must be yaml or toml")
 	}
 
+	cfg := fc.Config
+	if v := strings.TrimSpace(fc.Timeout); v != "" {
+		timeout, warning := parseTimeout("timeout", v)
+		cfg.Timeout = timeout
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
 	return cfg, warnings, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestMaskToken(t *testing.T) {
@@ -138,3 +139,229 @@ func TestLoad_Precedence(t *testing.T) {
 		}
 	})
 }
+
+func TestLoad_ThemePrecedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfgDir := filepath.Join(home, ".config", "mjrwtf")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte("theme = \"catppuccin\"\n"), 0o600); err != nil {
+		t.Fatalf("write toml: %v", err)
+	}
+
+	t.Setenv("MJR_THEME", "")
+	cfg, _, err := Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Theme != "catppuccin" {
+		t.Fatalf("Theme = %q, want catppuccin", cfg.Theme)
+	}
+
+	t.Setenv("MJR_THEME", "high-contrast")
+	cfg, _, err = Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Theme != "high-contrast" {
+		t.Fatalf("Theme = %q, want env value", cfg.Theme)
+	}
+
+	cfg, _, err = Load(LoadOptions{FlagTheme: "catppuccin"})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Theme != "catppuccin" {
+		t.Fatalf("Theme = %q, want flag value", cfg.Theme)
+	}
+}
+
+func TestLoad_PageSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         string
+		flag        string
+		want        int
+		wantWarning string
+	}{
+		{"default", "", "", DefaultPageSize, ""},
+		{"env", "50", "", 50, ""},
+		{"flag_over_env", "50", "10", 10, ""},
+		{"min_boundary", "5", "", 5, ""},
+		{"max_boundary", "100", "", 100, ""},
+		{"clamp_low", "2", "", MinPageSize, "below"},
+		{"clamp_high", "500", "", MaxPageSize, "above"},
+		{"invalid_env", "lots", "", DefaultPageSize, "invalid MJR_PAGE_SIZE"},
+		{"invalid_flag", "", "ten", DefaultPageSize, "invalid --page-size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("MJR_PAGE_SIZE", tt.env)
+
+			cfg, warnings, err := Load(LoadOptions{FlagPageSize: tt.flag})
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if cfg.PageSize != tt.want {
+				t.Fatalf("PageSize = %d, want %d", cfg.PageSize, tt.want)
+			}
+			if tt.wantWarning == "" {
+				if len(warnings) != 0 {
+					t.Fatalf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.wantWarning) {
+				t.Fatalf("expected warning containing %q, got %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestLoad_PageSizeFromFileIsClamped(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MJR_PAGE_SIZE", "")
+
+	cfgDir := filepath.Join(home, ".config", "mjrwtf")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte("page_size: 1000\n"), 0o600); err != nil {
+		t.Fatalf("write yaml: %v", err)
+	}
+
+	cfg, warnings, err := Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.PageSize != MaxPageSize {
+		t.Fatalf("PageSize = %d, want %d", cfg.PageSize, MaxPageSize)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "page_size") {
+		t.Fatalf("expected page_size warning, got %v", warnings)
+	}
+}
+
+func TestLoad_Timeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		file        string
+		env         string
+		flag        string
+		want        time.Duration
+		wantWarning string
+	}{
+		{"default", "", "", "", DefaultTimeout, ""},
+		{"file", "timeout: 12s\n", "", "", 12 * time.Second, ""},
+		{"env_over_file", "timeout: 12s\n", "30s", "", 30 * time.Second, ""},
+		{"flag_over_env", "", "30s", "2s", 2 * time.Second, ""},
+		{"invalid_file", "timeout: soon\n", "", "", DefaultTimeout, "invalid timeout"},
+		{"invalid_env", "", "forever", "", DefaultTimeout, "invalid MJR_TIMEOUT"},
+		{"non_positive_flag", "", "", "0s", DefaultTimeout, "invalid --timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("MJR_TIMEOUT", tt.env)
+
+			if tt.file != "" {
+				cfgDir := filepath.Join(home, ".config", "mjrwtf")
+				if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+					t.Fatalf("mkdir: %v", err)
+				}
+				if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte(tt.file), 0o600); err != nil {
+					t.Fatalf("write yaml: %v", err)
+				}
+			}
+
+			cfg, warnings, err := Load(LoadOptions{FlagTimeout: tt.flag})
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if cfg.Timeout != tt.want {
+				t.Fatalf("Timeout = %v, want %v", cfg.Timeout, tt.want)
+			}
+			if tt.wantWarning == "" {
+				if len(warnings) != 0 {
+					t.Fatalf("expected no warnings, got %v", warnings)
+				}
+				return
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.wantWarning) {
+				t.Fatalf("expected warning containing %q, got %v", tt.wantWarning, warnings)
+			}
+		})
+	}
+}
+
+func TestLoad_TimeoutFromTOML(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MJR_TIMEOUT", "")
+
+	cfgDir := filepath.Join(home, ".config", "mjrwtf")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.toml"), []byte("base_url = \"https://toml.example\"\ntimeout = \"15s\"\n"), 0o600); err != nil {
+		t.Fatalf("write toml: %v", err)
+	}
+
+	cfg, _, err := Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Timeout != 15*time.Second {
+		t.Fatalf("Timeout = %v, want 15s", cfg.Timeout)
+	}
+	if cfg.BaseURL != "https://toml.example" {
+		t.Fatalf("BaseURL = %q, want https://toml.example", cfg.BaseURL)
+	}
+}
+
+func TestLoad_ThemeModePrecedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfgDir := filepath.Join(home, ".config", "mjrwtf")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(cfgDir, "config.yaml"), []byte("theme_mode: light\n"), 0o600); err != nil {
+		t.Fatalf("write yaml: %v", err)
+	}
+
+	t.Setenv("MJR_THEME_MODE", "")
+	cfg, _, err := Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.ThemeMode != "light" {
+		t.Fatalf("ThemeMode = %q, want file value", cfg.ThemeMode)
+	}
+
+	t.Setenv("MJR_THEME_MODE", "dark")
+	cfg, _, err = Load(LoadOptions{})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.ThemeMode != "dark" {
+		t.Fatalf("ThemeMode = %q, want env value", cfg.ThemeMode)
+	}
+
+	cfg, _, err = Load(LoadOptions{FlagThemeMode: "auto"})
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.ThemeMode != "auto" {
+		t.Fatalf("ThemeMode = %q, want flag value", cfg.ThemeMode)
+	}
+}
@@ -0,0 +1,127 @@
+package tui_config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// URL list sort keys. SortCreated keeps the server's newest-first order.
+const (
+	SortCreated = "created"
+	SortClicks  = "clicks"
+	SortCode    = "code"
+)
+
+// SortKeys lists the valid sort keys in the order the TUI cycles through them.
+var SortKeys = []string{SortCreated, SortClicks, SortCode}
+
+// Preferences are settings the TUI remembers between launches. They are the
+// lowest-priority source: the config file, env vars and flags all override them.
+type Preferences struct {
+	PageSize int    `toml:"page_size,omitempty"`
+	Sort     string `toml:"sort,omitempty"`
+	Theme    string `toml:"theme,omitempty"`
+}
+
+// PreferencesPath returns the preferences file location,
+// ~/.config/mjrwtf/preferences.toml (next to the config file).
+func PreferencesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("get home dir: %w", err)
+	}
+	return filepath.Join(home, ".config", "mjrwtf", "preferences.toml"), nil
+}
+
+// LoadPreferences reads the preferences file. A missing file yields zero
+// Preferences; an unreadable or malformed one is ignored with a warning.
+func LoadPreferences() (Preferences, []string) {
+	path, err := PreferencesPath()
+	if err != nil {
+		return Preferences{}, []string{fmt.Sprintf("Warning: ignoring preferences: %v", err)}
+	}
+
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Preferences{}, nil
+	}
+	if err != nil {
+		return Preferences{}, []string{fmt.Sprintf("Warning: ignoring preferences: %v", err)}
+	}
+
+	var p Preferences
+	if err := toml.Unmarshal(b, &p); err != nil {
+		return Preferences{}, []string{fmt.Sprintf("Warning: ignoring malformed %s: %v", filepath.Base(path), err)}
+	}
+	if p.Sort != "" && !validSort(p.Sort) {
+		return Preferences{}, []string{fmt.Sprintf("Warning: ignoring preferences with unknown sort %q", p.Sort)}
+	}
+	return p, nil
+}
+
+// SavePreferences writes p to the preferences file, creating its directory if
+// needed. The file is replaced atomically so a crash never leaves it half-written.
+func SavePreferences(p Preferences) error {
+	path, err := PreferencesPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create preferences dir: %w", err)
+	}
+
+	b, err := toml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encode preferences: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".preferences-*.toml")
+	if err != nil {
+		return fmt.Errorf("write preferences: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write preferences: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write preferences: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write preferences: %w", err)
+	}
+	return nil
+}
+
+// UpdatePreferences applies update to the saved preferences and writes them
+// back, so changing one setting keeps the others.
+func UpdatePreferences(update func(*Preferences)) error {
+	p, _ := LoadPreferences()
+	update(&p)
+	return SavePreferences(p)
+}
+
+func mergePreferences(dst *Config, p Preferences) {
+	if p.PageSize != 0 {
+		dst.PageSize = p.PageSize
+	}
+	if v := strings.TrimSpace(p.Sort); v != "" {
+		dst.Sort = v
+	}
+	if v := strings.TrimSpace(p.Theme); v != "" {
+		dst.Theme = v
+	}
+}
+
+func validSort(key string) bool {
+	for _, k := range SortKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
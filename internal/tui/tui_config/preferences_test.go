@@ -0,0 +1,137 @@
+package tui_config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPreferences_SaveLoadRoundTrip(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	want := Preferences{PageSize: 50, Sort: SortClicks, Theme: "high-contrast"}
+	// The config directory doesn't exist yet; saving creates it.
+	if err := SavePreferences(want); err != nil {
+		t.Fatalf("SavePreferences() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, ".config", "mjrwtf", "preferences.toml")); err != nil {
+		t.Fatalf("expected preferences file: %v", err)
+	}
+
+	got, warnings := LoadPreferences()
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %v", warnings)
+	}
+	if got != want {
+		t.Fatalf("LoadPreferences() = %+v, want %+v", got, want)
+	}
+
+	// Updating one setting keeps the others
+	if err := UpdatePreferences(func(p *Preferences) { p.Sort = SortCode }); err != nil {
+		t.Fatalf("UpdatePreferences() error: %v", err)
+	}
+	got, _ = LoadPreferences()
+	want.Sort = SortCode
+	if got != want {
+		t.Fatalf("after update = %+v, want %+v", got, want)
+	}
+}
+
+func TestPreferences_MissingFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	got, warnings := LoadPreferences()
+	if len(warnings) != 0 || got != (Preferences{}) {
+		t.Fatalf("LoadPreferences() = %+v, %v; want zero and no warnings", got, warnings)
+	}
+}
+
+func TestPreferences_MalformedFileIgnored(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		warning string
+	}{
+		{name: "invalid toml", content: "page_size = [", warning: "malformed preferences.toml"},
+		{name: "unknown sort", content: "sort = \"size\"\npage_size = 50\n", warning: `unknown sort "size"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("MJR_PAGE_SIZE", "")
+			writePreferences(t, home, tt.content)
+
+			cfg, warnings, err := Load(LoadOptions{})
+			if err != nil {
+				t.Fatalf("Load() error: %v", err)
+			}
+			if len(warnings) != 1 || !strings.Contains(warnings[0], tt.warning) {
+				t.Fatalf("expected a %q warning, got %v", tt.warning, warnings)
+			}
+			if cfg.PageSize != DefaultPageSize || cfg.Sort != SortCreated {
+				t.Fatalf("expected defaults, got page_size=%d sort=%q", cfg.PageSize, cfg.Sort)
+			}
+		})
+	}
+}
+
+func TestLoad_PreferencesPrecedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("MJR_BASE_URL", "")
+	t.Setenv("MJR_TOKEN", "")
+	t.Setenv("MJR_THEME", "")
+	t.Setenv("MJR_PAGE_SIZE", "")
+	writePreferences(t, home, "page_size = 50\nsort = \"clicks\"\ntheme = \"high-contrast\"\n")
+
+	t.Run("preferences_over_defaults", func(t *testing.T) {
+		cfg, _, err := Load(LoadOptions{})
+		if err != nil {
+			t.Fatalf("Load() error: %v", err)
+		}
+		if cfg.PageSize != 50 || cfg.Sort != SortClicks || cfg.Theme != "high-contrast" {
+			t.Fatalf("got page_size=%d sort=%q theme=%q", cfg.PageSize, cfg.Sort, cfg.Theme)
+		}
+	})
+
+	t.Run("flags_over_preferences", func(t *testing.T) {
+		cfg, _, err := Load(LoadOptions{FlagPageSize: "30", FlagTheme: "catppuccin"})
+		if err != nil {
+			t.Fatalf("Load() error: %v", err)
+		}
+		if cfg.PageSize != 30 || cfg.Theme != "catppuccin" {
+			t.Fatalf("got page_size=%d theme=%q", cfg.PageSize, cfg.Theme)
+		}
+		if cfg.Sort != SortClicks {
+			t.Fatalf("Sort = %q, want the remembered %q", cfg.Sort, SortClicks)
+		}
+	})
+
+	t.Run("config_file_over_preferences", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(home, ".config", "mjrwtf", "config.yaml"), []byte("page_size: 40\n"), 0o600); err != nil {
+			t.Fatalf("write yaml: %v", err)
+		}
+		cfg, _, err := Load(LoadOptions{})
+		if err != nil {
+			t.Fatalf("Load() error: %v", err)
+		}
+		if cfg.PageSize != 40 {
+			t.Fatalf("PageSize = %d, want 40", cfg.PageSize)
+		}
+	})
+}
+
+func writePreferences(t *testing.T, home, content string) {
+	t.Helper()
+	dir := filepath.Join(home, ".config", "mjrwtf")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "preferences.toml"), []byte(content), 0o600); err != nil {
+		t.Fatalf("write preferences: %v", err)
+	}
+}
@@ -1,9 +1,13 @@
 package tui
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/matt-riley/mjrwtf/internal/tui/styles"
 	"github.com/matt-riley/mjrwtf/internal/tui/tui_config"
 )
 
@@ -73,3 +77,176 @@ func TestRun_InvalidFlags(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestRun_ThemeFlagAppliesTheme(t *testing.T) {
+	old := runProgram
+	t.Cleanup(func() {
+		runProgram = old
+		styles.Apply(styles.ThemeCatppuccin)
+	})
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MJR_THEME", "")
+
+	runProgram = func(m tea.Model) error { return nil }
+
+	if err := Run([]string{"--theme", "high-contrast"}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if styles.Current() != styles.ThemeHighContrast {
+		t.Fatalf("theme=%q", styles.Current())
+	}
+}
+
+func TestRun_ThemeModeFlagForcesLightColors(t *testing.T) {
+	old := runProgram
+	t.Cleanup(func() {
+		runProgram = old
+		_ = styles.SetTheme(styles.ModeAuto)
+	})
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MJR_THEME_MODE", "")
+
+	runProgram = func(m tea.Model) error { return nil }
+
+	if err := Run([]string{"--theme-mode", "light"}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	// Latte green #40a02b.
+	if got := styles.SuccessStyle.Render("x"); !strings.Contains(got, "38;2;64;160;43") {
+		t.Fatalf("expected light variant, rendered %q", got)
+	}
+}
+
+func TestRun_UnknownThemeWarnsAndFallsBack(t *testing.T) {
+	old := runProgram
+	t.Cleanup(func() {
+		runProgram = old
+		styles.Apply(styles.ThemeCatppuccin)
+	})
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MJR_THEME", "neon")
+
+	var got model
+	runProgram = func(m tea.Model) error {
+		got = m.(model)
+		return nil
+	}
+
+	if err := Run([]string{}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if styles.Current() != styles.ThemeCatppuccin {
+		t.Fatalf("theme=%q", styles.Current())
+	}
+	if !strings.Contains(got.status, "unknown theme") {
+		t.Fatalf("expected theme warning in status, got %q", got.status)
+	}
+}
+
+func TestRun_PageSizeFlagThreadsIntoModel(t *testing.T) {
+	old := runProgram
+	t.Cleanup(func() { runProgram = old })
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MJR_PAGE_SIZE", "")
+
+	var got model
+	runProgram = func(m tea.Model) error {
+		got = m.(model)
+		return nil
+	}
+
+	if err := Run([]string{"--page-size", "50"}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if got.pageSize != 50 {
+		t.Fatalf("pageSize=%d", got.pageSize)
+	}
+
+	// The flag is remembered, so the next launch uses it without the flag
+	if err := Run([]string{}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if got.pageSize != 50 {
+		t.Fatalf("remembered pageSize=%d", got.pageSize)
+	}
+}
+
+func TestRun_InvalidPageSizeWarns(t *testing.T) {
+	old := runProgram
+	t.Cleanup(func() { runProgram = old })
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("MJR_PAGE_SIZE", "abc")
+
+	var got model
+	runProgram = func(m tea.Model) error {
+		got = m.(model)
+		return nil
+	}
+
+	if err := Run([]string{}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if got.pageSize != tui_config.DefaultPageSize {
+		t.Fatalf("pageSize=%d", got.pageSize)
+	}
+	if !strings.Contains(got.status, "MJR_PAGE_SIZE") {
+		t.Fatalf("expected page size warning in status, got %q", got.status)
+	}
+}
+
+func TestRun_NoColorEnvDisablesColor(t *testing.T) {
+	old := runProgram
+	t.Cleanup(func() {
+		runProgram = old
+		styles.EnableColor()
+	})
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("NO_COLOR", "1")
+
+	runProgram = func(m tea.Model) error { return nil }
+
+	if err := Run([]string{}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !styles.ColorDisabled() {
+		t.Fatal("expected NO_COLOR to disable color")
+	}
+	if got := styles.SuccessStyle.Render("x"); strings.Contains(got, "38;") {
+		t.Fatalf("expected no color escape, got %q", got)
+	}
+}
+
+func TestRun_InvalidPaletteWarnsAndFallsBack(t *testing.T) {
+	old := runProgram
+	t.Cleanup(func() {
+		runProgram = old
+		styles.ResetPalette()
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "palette.json")
+	if err := os.WriteFile(path, []byte(`{"Mauve": "purple"}`), 0o600); err != nil {
+		t.Fatalf("write palette: %v", err)
+	}
+	t.Setenv("HOME", dir)
+	t.Setenv("MJR_PALETTE", path)
+
+	var got model
+	runProgram = func(m tea.Model) error {
+		got = m.(model)
+		return nil
+	}
+
+	if err := Run([]string{}); err != nil {
+		t.Fatalf("Run() error: %v", err)
+	}
+	if !strings.Contains(got.status, "invalid hex color") {
+		t.Fatalf("expected palette warning in status, got %q", got.status)
+	}
+}
@@ -0,0 +1,10 @@
+// Package mjrwtf holds assets that live at the repository root but are
+// embedded into the binaries.
+package mjrwtf
+
+import _ "embed"
+
+// OpenAPISpec is the API specification in openapi.yaml
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte